@@ -35,20 +35,32 @@ const (
 	CryptoNewKeyEvent = "CreateNewKey"
 	// CryptoDeleteKeyEvent occurs when deleting a key.
 	CryptoDeleteKeyEvent = "DeleteKey"
+	// CryptoBackupKeysEvent occurs when exporting private keys into a backup archive.
+	CryptoBackupKeysEvent = "BackupKeys"
+	// CryptoRestoreKeysEvent occurs when importing private keys from a backup archive.
+	CryptoRestoreKeysEvent = "RestoreKeys"
 	// CryptoSignJWTEvent occurs when signing a JWT.
 	CryptoSignJWTEvent = "SignJWT"
 	// CryptoSignJWSEvent occurs when signing a JWS.
 	CryptoSignJWSEvent = "SignJWS"
+	// CryptoSignDataEvent occurs when signing arbitrary data (e.g. for a Data Integrity proof).
+	CryptoSignDataEvent = "SignData"
 	// CryptoEncryptJWEEvent occurs when encryping a JWE
 	CryptoEncryptJWEEvent = "EncryptJWE"
 	// CryptoDecryptJWEEvent occurs when decryping a JWE
 	CryptoDecryptJWEEvent = "DecryptJWE"
+	// CryptoKeyUsageDeniedEvent occurs when a key is refused for an operation it isn't registered for in its DID document.
+	CryptoKeyUsageDeniedEvent = "KeyUsageDenied"
+	// KeyRotatedEvent occurs when the keys of a subject are rotated.
+	KeyRotatedEvent = "KeyRotated"
 	// AccessGrantedEvent occurs when access to a protected API endpoint was granted
 	AccessGrantedEvent = "AccessGranted"
 	// AccessDeniedEvent occurs when access to a protected API endpoint was denied
 	AccessDeniedEvent = "AccessDenied"
 	// AccessKeyRegisteredEvent occurs when an authorized key is registered for future authorization events
 	AccessKeyRegisteredEvent = "AccessKeyRegistered"
+	// AccessTokenRevokedEvent occurs when an OAuth2 access token or refresh token issued by the node is revoked.
+	AccessTokenRevokedEvent = "AccessTokenRevoked"
 	// InvalidOAuthTokenEvent occurs when a client presents an invalid (unknown/expired) OAuth2 token.
 	InvalidOAuthTokenEvent = "InvalidOAuthToken"
 	// VerifiableCredentialRetrievedEvent occurs when a VC is retrieved by the remote wallet.
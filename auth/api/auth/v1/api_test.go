@@ -74,6 +74,26 @@ func (m *mockAuthClient) AuthorizationEndpointEnabled() bool {
 	return true
 }
 
+func (m *mockAuthClient) RefreshTokenValidity() time.Duration {
+	return 0
+}
+
+func (m *mockAuthClient) TokenExchangeEnabled() bool {
+	return false
+}
+
+func (m *mockAuthClient) DomainLinkageRequired() bool {
+	return false
+}
+
+func (m *mockAuthClient) JWTAccessTokensEnabled() bool {
+	return false
+}
+
+func (m *mockAuthClient) AccessTokenAudiences() map[string]string {
+	return nil
+}
+
 func (m *mockAuthClient) AuthzServer() oauth.AuthorizationServer {
 	return m.authzServer
 }
@@ -19,10 +19,17 @@
 package iam
 
 import (
+	"context"
+	"crypto"
+	"encoding/base64"
 	"fmt"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/lestrrat-go/jwx/v2/jwt"
 	"github.com/nuts-foundation/nuts-node/auth/oauth"
 	"github.com/nuts-foundation/nuts-node/core/to"
-	"github.com/nuts-foundation/nuts-node/crypto"
+	nutsCrypto "github.com/nuts-foundation/nuts-node/crypto"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/nuts-foundation/nuts-node/crypto/dpop"
@@ -34,6 +41,8 @@ type AccessToken struct {
 	DPoP *dpop.DPoP `json:"dpop"`
 	// Token is the access token
 	Token string `json:"token"`
+	// JwtID is the 'jti' claim of a JWT access token, it is empty for opaque access tokens.
+	JwtID string `json:"jti,omitempty"`
 	// Issuer and Subject of a token are always the same.
 	Issuer string `json:"issuer"`
 	// TODO: should client_id be extracted to the PDPMap using the presentation definition?
@@ -45,6 +54,9 @@ type AccessToken struct {
 	Expiration time.Time `json:"expiration"`
 	// Scope the token grants access to. Not necessarily the same as the requested scope
 	Scope string `json:"scope"`
+	// Actor is the delegation chain of a token obtained through token exchange (RFC8693), the current actor being the outermost.
+	// It is nil for tokens that were issued directly to the client.
+	Actor *Actor `json:"actor,omitempty"`
 	// InputDescriptorConstraintIdMap maps the ID field of a PresentationDefinition input descriptor constraint to the value provided in the VPToken for the constraint.
 	// The Policy Decision Point can use this map to make decisions without having to deal with PEX/VCs/VPs/SignatureValidation
 	InputDescriptorConstraintIdMap map[string]any `json:"inputdescriptor_constraint_id_map,omitempty"`
@@ -59,8 +71,15 @@ type AccessToken struct {
 	PresentationDefinitions pe.WalletOwnerMapping `json:"presentation_definitions,omitempty"`
 }
 
-// createAccessToken is used in both the s2s and openid4vp flows
-func (r Wrapper) createAccessToken(issuerURL string, clientID string, issueTime time.Time, scope string, pexState PEXConsumer, dpopToken *dpop.DPoP) (*oauth.TokenResponse, error) {
+// jwtAccessTokenType is the 'typ' header of JWT access tokens, as specified by RFC9068.
+const jwtAccessTokenType = "at+jwt"
+
+// reservedIntrospectionClaims are the claims of the token introspection response that can't be overwritten by input descriptor constraint values.
+var reservedIntrospectionClaims = []string{"iss", "sub", "exp", "iat", "active", "act", "client_id", "scope"}
+
+// createAccessToken is used in both the s2s and openid4vp flows.
+// The access token is issued by the authorization server of the given subject.
+func (r Wrapper) createAccessToken(ctx context.Context, subject string, clientID string, issueTime time.Time, scope string, pexState PEXConsumer, dpopToken *dpop.DPoP) (*oauth.TokenResponse, error) {
 	credentialMap, err := pexState.credentialMap()
 	if err != nil {
 		return nil, err
@@ -70,10 +89,11 @@ func (r Wrapper) createAccessToken(issuerURL string, clientID string, issueTime
 		return nil, err
 	}
 
+	issuerURL := r.subjectToBaseURL(subject)
 	accessToken := AccessToken{
 		DPoP:                           dpopToken,
-		Token:                          crypto.GenerateNonce(),
-		Issuer:                         issuerURL,
+		Token:                          nutsCrypto.GenerateNonce(),
+		Issuer:                         issuerURL.String(),
 		IssuedAt:                       issueTime,
 		ClientId:                       clientID,
 		Expiration:                     issueTime.Add(accessTokenValidity),
@@ -82,24 +102,102 @@ func (r Wrapper) createAccessToken(issuerURL string, clientID string, issueTime
 		PresentationDefinitions:        pexState.RequiredPresentationDefinitions,
 		InputDescriptorConstraintIdMap: fieldsMap,
 	}
-	for _, envelope := range pexState.SubmittedEnvelopes {
-		accessToken.VPToken = append(accessToken.VPToken, envelope.Presentations...)
-	}
+	accessToken.VPToken = append(accessToken.VPToken, pexState.presentations()...)
 
-	err = r.accessTokenServerStore().Put(accessToken.Token, accessToken)
+	tokenResponse, err := r.storeAccessToken(ctx, subject, &accessToken)
 	if err != nil {
+		return nil, err
+	}
+	if refreshTokenValidity := r.auth.RefreshTokenValidity(); refreshTokenValidity > 0 {
+		if err = r.issueRefreshToken(tokenResponse, accessToken, refreshTokenExpiration(accessToken, refreshTokenValidity)); err != nil {
+			return nil, err
+		}
+	}
+	return tokenResponse, nil
+}
+
+// storeAccessToken stores the given access token, so it can be introspected, and returns the token response for it.
+// If JWT access tokens are enabled, the token value is replaced by a JWT (RFC9068) signed by the authorization server of the given subject.
+func (r Wrapper) storeAccessToken(ctx context.Context, subject string, accessToken *AccessToken) (*oauth.TokenResponse, error) {
+	if r.auth.JWTAccessTokensEnabled() {
+		accessToken.JwtID = nutsCrypto.GenerateNonce()
+		token, err := r.signAccessToken(ctx, subject, *accessToken)
+		if err != nil {
+			return nil, fmt.Errorf("unable to sign access token: %w", err)
+		}
+		accessToken.Token = token
+	}
+	if err := r.accessTokenServerStore().Put(accessToken.Token, *accessToken); err != nil {
 		return nil, fmt.Errorf("unable to store access token: %w", err)
 	}
-	expiresIn := int(accessTokenValidity.Seconds())
+	expiresIn := int(accessToken.Expiration.Sub(accessToken.IssuedAt).Seconds())
 	tokenResponse := oauth.TokenResponse{
 		AccessToken: accessToken.Token,
 		ExpiresIn:   &expiresIn,
-		Scope:       &scope,
+		Scope:       to.Ptr(accessToken.Scope),
 		TokenType:   AccessTokenTypeBearer,
 	}
-	if dpopToken != nil {
+	if accessToken.DPoP != nil {
 		tokenResponse.TokenType = AccessTokenTypeDPoP
-		tokenResponse.DPoPKid = to.Ptr(dpopToken.Kid)
+		tokenResponse.DPoPKid = to.Ptr(accessToken.DPoP.Kid)
 	}
 	return &tokenResponse, nil
 }
+
+// signAccessToken creates a JWT access token (RFC9068) for the given access token, signed with the key of the given subject.
+// The claims equal those returned by token introspection, so resource servers can validate the token without introspecting it.
+// The 'aud' claim contains the resource servers configured for the token's scopes.
+func (r Wrapper) signAccessToken(ctx context.Context, subject string, accessToken AccessToken) (string, error) {
+	audience, err := r.accessTokenAudience(accessToken.Scope)
+	if err != nil {
+		return "", err
+	}
+	_, kid, err := r.subjectKeySet(ctx, subject)
+	if err != nil {
+		return "", err
+	}
+	claims := map[string]interface{}{}
+	for key, value := range accessToken.InputDescriptorConstraintIdMap {
+		claims[key] = value
+	}
+	for _, reserved := range append(slices.Clone(reservedIntrospectionClaims), jwt.AudienceKey, jwt.JwtIDKey, "cnf") {
+		if _, isReserved := claims[reserved]; isReserved {
+			return "", fmt.Errorf("InputDescriptorConstraintIdMap contains reserved claim name: %s", reserved)
+		}
+	}
+	claims[jwt.IssuerKey] = accessToken.Issuer
+	claims[jwt.AudienceKey] = audience
+	claims[jwt.SubjectKey] = accessToken.ClientId
+	claims[jwt.IssuedAtKey] = accessToken.IssuedAt.Unix()
+	claims[jwt.ExpirationKey] = accessToken.Expiration.Unix()
+	claims[jwt.JwtIDKey] = accessToken.JwtID
+	claims[oauth.ClientIDParam] = accessToken.ClientId
+	claims[oauth.ScopeParam] = accessToken.Scope
+	if accessToken.Actor != nil {
+		claims["act"] = accessToken.Actor
+	}
+	if accessToken.DPoP != nil {
+		hash, err := accessToken.DPoP.Headers.JWK().Thumbprint(crypto.SHA256)
+		if err != nil {
+			return "", err
+		}
+		claims["cnf"] = map[string]interface{}{"jkt": base64.RawURLEncoding.EncodeToString(hash)}
+	}
+	return r.jwtSigner.SignJWT(ctx, claims, map[string]interface{}{jws.TypeKey: jwtAccessTokenType}, kid)
+}
+
+// accessTokenAudience returns the resource servers configured for the given (space-delimited) scope, which JWT access tokens for it are intended for (RFC9068 section 3).
+// It returns an invalid_scope error if no resource server is configured for any of the scopes.
+func (r Wrapper) accessTokenAudience(scope string) ([]string, error) {
+	audiences := r.auth.AccessTokenAudiences()
+	var result []string
+	for _, curr := range strings.Fields(scope) {
+		if audience, ok := audiences[curr]; ok && !slices.Contains(result, audience) {
+			result = append(result, audience)
+		}
+	}
+	if len(result) == 0 {
+		return nil, oauthError(oauth.InvalidScope, fmt.Sprintf("no resource server configured for scope '%s'", scope))
+	}
+	return result, nil
+}
@@ -86,10 +86,12 @@ var cacheControlMaxAgeURLs = []string{
 	"/.well-known/openid-configuration/oauth2/:subjectID",
 	"/oauth2/:subjectID/oauth-client",
 	"/statuslist/:did/:page",
+	"/statuslist/:did/:purpose/:page",
 }
 
 // cacheControlNoCacheURLs holds API endpoints that should have a no-cache cache control header set.
 var cacheControlNoCacheURLs = []string{
+	"/oauth2/:subjectID/par",
 	"/oauth2/:subjectID/token",
 }
 
@@ -243,6 +245,12 @@ func (r Wrapper) HandleTokenRequest(ctx context.Context, request HandleTokenRequ
 			}
 		}
 		return r.handleS2SAccessTokenRequest(ctx, *request.Body.ClientId, request.SubjectID, *request.Body.Scope, *request.Body.PresentationSubmission, *request.Body.Assertion)
+	case oauth.RefreshTokenGrantType:
+		// refresh token issued alongside an access token obtained through one of the flows above
+		return r.handleRefreshTokenRequest(ctx, request.SubjectID, *request.Body)
+	case oauth.TokenExchangeGrantType:
+		// RFC8693 token exchange, e.g. by a resource server to obtain a down-scoped token for a downstream service
+		return r.handleTokenExchangeRequest(ctx, request.SubjectID, *request.Body)
 	default:
 		return nil, oauth.OAuth2Error{
 			Code:        oauth.UnsupportedGrantType,
@@ -420,6 +428,7 @@ func (r Wrapper) introspectAccessToken(input string) (*ExtendedTokenIntrospectio
 	exp := int(token.Expiration.Unix())
 	response := ExtendedTokenIntrospectionResponse{
 		Active:                  true,
+		Act:                     token.Actor,
 		Cnf:                     cnf,
 		Iat:                     &iat,
 		Exp:                     &exp,
@@ -432,7 +441,7 @@ func (r Wrapper) introspectAccessToken(input string) (*ExtendedTokenIntrospectio
 	}
 
 	if token.InputDescriptorConstraintIdMap != nil {
-		for _, reserved := range []string{"iss", "sub", "exp", "iat", "active", "client_id", "scope"} {
+		for _, reserved := range reservedIntrospectionClaims {
 			if _, isReserved := token.InputDescriptorConstraintIdMap[reserved]; isReserved {
 				return nil, fmt.Errorf("IntrospectAccessToken: InputDescriptorConstraintIdMap contains reserved claim name: %s", reserved)
 			}
@@ -469,8 +478,15 @@ func (r Wrapper) HandleAuthorizeRequest(ctx context.Context, request HandleAutho
 // handleAuthorizeRequest handles calls to the authorization endpoint for starting an authorization code flow.
 // The caller must ensure ownDID is actually owned by this node.
 func (r Wrapper) handleAuthorizeRequest(ctx context.Context, subject string, ownMetadata oauth.AuthorizationServerMetadata, request url.URL) (HandleAuthorizeRequestResponseObject, error) {
-	// parse and validate as JAR (RFC9101, JWT Authorization Request)
-	requestObject, err := r.jar.Parse(ctx, ownMetadata, request.Query())
+	var requestObject oauthParameters
+	var err error
+	if query := request.Query(); isPushedAuthorizationRequest(query) {
+		// already validated when it was pushed (RFC9126)
+		requestObject, err = r.loadPushedAuthorizationRequest(subject, query)
+	} else {
+		// parse and validate as JAR (RFC9101, JWT Authorization Request)
+		requestObject, err = r.jar.Parse(ctx, ownMetadata, query)
+	}
 	if err != nil {
 		// already an oauth.OAuth2Error
 		return nil, err
@@ -618,6 +634,16 @@ func (r Wrapper) oauthAuthorizationServerMetadata(clientID url.URL) (*oauth.Auth
 	md := authorizationServerMetadata(&clientID, r.auth.SupportedDIDMethods())
 	if !r.auth.AuthorizationEndpointEnabled() {
 		md.AuthorizationEndpoint = ""
+		md.PushedAuthorizationRequestEndpoint = ""
+	}
+	if r.auth.RefreshTokenValidity() > 0 {
+		md.GrantTypesSupported = append(slices.Clone(md.GrantTypesSupported), oauth.RefreshTokenGrantType)
+	}
+	if r.auth.TokenExchangeEnabled() {
+		md.GrantTypesSupported = append(slices.Clone(md.GrantTypesSupported), oauth.TokenExchangeGrantType)
+	}
+	if r.auth.JWTAccessTokensEnabled() {
+		md.JwksURI = clientID.JoinPath("jwks").String()
 	}
 	return &md, nil
 }
@@ -634,17 +660,60 @@ func (r Wrapper) OAuthClientMetadata(ctx context.Context, request OAuthClientMet
 	return OAuthClientMetadata200JSONResponse(clientMetadata(identityURL)), nil
 }
 
+// Jwks returns the JWK Set of the subject, containing the keys JWT access tokens issued by its authorization server are signed with.
+func (r Wrapper) Jwks(ctx context.Context, request JwksRequestObject) (JwksResponseObject, error) {
+	set, _, err := r.subjectKeySet(ctx, request.SubjectID)
+	if err != nil {
+		return nil, err
+	}
+	// convert to the generated type, which can't hold the jwk.Set directly
+	asJSON, _ := json.Marshal(set)
+	var response Jwks200JSONResponse
+	_ = json.Unmarshal(asJSON, &response)
+	return response, nil
+}
+
 func (r Wrapper) OpenIDConfiguration(ctx context.Context, request OpenIDConfigurationRequestObject) (OpenIDConfigurationResponseObject, error) {
+	set, signingKey, err := r.subjectKeySet(ctx, request.SubjectID)
+	if err != nil {
+		return nil, err
+	}
+	// we sign with a JWK, the receiving party can verify with the signature but not if the key corresponds to the DID since the DID method might not be supported.
+	// this is a shortcoming of the openID federation vs OpenID4VP/DID worlds
+	// issuer URL equals server baseURL + :/oauth2/:subject
+	issuerURL := r.subjectToBaseURL(request.SubjectID)
+	configuration := openIDConfiguration(issuerURL, set, r.auth.SupportedDIDMethods())
+	claims := make(map[string]interface{})
+	asJson, _ := json.Marshal(configuration)
+	_ = json.Unmarshal(asJson, &claims)
+	// create jwt
+	token, err := r.jwtSigner.SignJWT(ctx, claims, nil, signingKey)
+	if err != nil {
+		return nil, oauth.OAuth2Error{
+			Code:          oauth.ServerError,
+			InternalError: err,
+		}
+	}
+
+	return OpenIDConfiguration200ApplicationentityStatementJwtResponse{
+		Body:          strings.NewReader(token),
+		ContentLength: int64(len(token)),
+	}, nil
+}
+
+// subjectKeySet returns the JWK Set containing the assertionMethod key of every DID of the subject,
+// and the key ID of the key the authorization server of the subject signs with.
+func (r Wrapper) subjectKeySet(ctx context.Context, subject string) (jwk.Set, string, error) {
 	// find DIDs for subject
-	dids, err := r.subjectManager.ListDIDs(ctx, request.SubjectID)
+	dids, err := r.subjectManager.ListDIDs(ctx, subject)
 	if err != nil {
 		if errors.Is(err, didsubject.ErrSubjectNotFound) {
-			return nil, oauth.OAuth2Error{
+			return nil, "", oauth.OAuth2Error{
 				Code:        oauth.InvalidRequest,
 				Description: err.Error(),
 			}
 		}
-		return nil, oauth.OAuth2Error{
+		return nil, "", oauth.OAuth2Error{
 			Code:          oauth.ServerError,
 			InternalError: err,
 		}
@@ -655,7 +724,7 @@ func (r Wrapper) OpenIDConfiguration(ctx context.Context, request OpenIDConfigur
 	for _, currentDID := range dids {
 		kid, key, err := r.keyResolver.ResolveKey(currentDID, nil, resolver.AssertionMethod)
 		if err != nil {
-			return nil, oauth.OAuth2Error{
+			return nil, "", oauth.OAuth2Error{
 				Code:          oauth.ServerError,
 				InternalError: err,
 			}
@@ -663,7 +732,7 @@ func (r Wrapper) OpenIDConfiguration(ctx context.Context, request OpenIDConfigur
 		// create JWK and add to set
 		jwkKey, err := jwk.FromRaw(key)
 		if err != nil {
-			return nil, oauth.OAuth2Error{
+			return nil, "", oauth.OAuth2Error{
 				Code:          oauth.ServerError,
 				InternalError: err,
 			}
@@ -675,27 +744,7 @@ func (r Wrapper) OpenIDConfiguration(ctx context.Context, request OpenIDConfigur
 			signingKey = kid
 		}
 	}
-	// we sign with a JWK, the receiving party can verify with the signature but not if the key corresponds to the DID since the DID method might not be supported.
-	// this is a shortcoming of the openID federation vs OpenID4VP/DID worlds
-	// issuer URL equals server baseURL + :/oauth2/:subject
-	issuerURL := r.subjectToBaseURL(request.SubjectID)
-	configuration := openIDConfiguration(issuerURL, set, r.auth.SupportedDIDMethods())
-	claims := make(map[string]interface{})
-	asJson, _ := json.Marshal(configuration)
-	_ = json.Unmarshal(asJson, &claims)
-	// create jwt
-	token, err := r.jwtSigner.SignJWT(ctx, claims, nil, signingKey)
-	if err != nil {
-		return nil, oauth.OAuth2Error{
-			Code:          oauth.ServerError,
-			InternalError: err,
-		}
-	}
-
-	return OpenIDConfiguration200ApplicationentityStatementJwtResponse{
-		Body:          strings.NewReader(token),
-		ContentLength: int64(len(token)),
-	}, nil
+	return set, signingKey, nil
 }
 
 func (r Wrapper) PresentationDefinition(ctx context.Context, request PresentationDefinitionRequestObject) (PresentationDefinitionResponseObject, error) {
@@ -703,7 +752,12 @@ func (r Wrapper) PresentationDefinition(ctx context.Context, request Presentatio
 		return PresentationDefinition200JSONResponse(PresentationDefinition{}), nil
 	}
 
-	mapping, err := r.policyBackend.PresentationDefinitions(ctx, request.Params.Scope)
+	// The client_id is optional and unauthenticated here; the token endpoint consults the policy backend again with the authenticated client.
+	var clientID string
+	if request.Params.ClientId != nil {
+		clientID = *request.Params.ClientId
+	}
+	mapping, err := r.policyBackend.PresentationDefinitions(policy.Context(ctx, clientID, request.SubjectID), request.Params.Scope)
 	if err != nil {
 		return nil, oauth.OAuth2Error{
 			Code:        oauth.InvalidScope,
@@ -774,10 +828,22 @@ func (r Wrapper) RequestServiceAccessToken(ctx context.Context, request RequestS
 		useDPoP = false
 	}
 	clientID := r.subjectToBaseURL(request.SubjectID)
-	tokenResult, err := r.auth.IAMClient().RequestRFC021AccessToken(ctx, clientID.String(), request.SubjectID, request.Body.AuthorizationServer, request.Body.Scope, useDPoP, credentials)
-	if err != nil {
-		// this can be an internal server error, a 400 oauth error or a 412 precondition failed if the wallet does not contain the required credentials
-		return nil, err
+	var tokenResult *TokenResponse
+	if request.Params.CacheControl == nil || *request.Params.CacheControl != "no-cache" {
+		// try to use a refresh token from a previous request, to prevent a full presentation exchange
+		tokenResult = r.refreshServiceAccessToken(ctx, clientID.String(), cacheKey, request.Body.AuthorizationServer)
+	}
+	if tokenResult == nil {
+		tokenResult, err = r.auth.IAMClient().RequestRFC021AccessToken(ctx, clientID.String(), request.SubjectID, request.Body.AuthorizationServer, request.Body.Scope, useDPoP, credentials)
+		if err != nil {
+			// this can be an internal server error, a 400 oauth error or a 412 precondition failed if the wallet does not contain the required credentials
+			return nil, err
+		}
+	}
+	if tokenResult.RefreshToken != nil {
+		// refresh tokens are kept by the node and used transparently, they're not returned to the application
+		r.storeServiceRefreshToken(cacheKey, *tokenResult)
+		tokenResult.RefreshToken = nil
 	}
 	ttl := accessTokenValidity
 	if tokenResult.ExpiresIn != nil {
@@ -860,6 +926,19 @@ func (r Wrapper) StatusList(ctx context.Context, request StatusListRequestObject
 	return StatusList200JSONResponse(*cred), nil
 }
 
+func (r Wrapper) BitstringStatusList(ctx context.Context, request BitstringStatusListRequestObject) (BitstringStatusListResponseObject, error) {
+	requestDID, err := did.ParseDID(request.Did)
+	if err != nil {
+		return nil, err
+	}
+	cred, err := r.vcr.Issuer().BitstringStatusList(ctx, *requestDID, request.Purpose, request.Page)
+	if err != nil {
+		return nil, err
+	}
+
+	return BitstringStatusList200JSONResponse(*cred), nil
+}
+
 func (r Wrapper) openid4vciMetadata(ctx context.Context, issuer string) (*oauth.OpenIDCredentialIssuerMetadata, *oauth.AuthorizationServerMetadata, error) {
 	credentialIssuerMetadata, err := r.auth.IAMClient().OpenIdCredentialIssuerMetadata(ctx, issuer)
 	if err != nil {
@@ -892,6 +971,7 @@ func (r Wrapper) openid4vciMetadata(ctx context.Context, issuer string) (*oauth.
 // It can create both regular OAuth2 requests and OpenID4VP requests due to the requestObjectModifier.
 // This modifier is used by JAR.Create to generate a (JAR) request object that is added as 'request_uri' parameter.
 // It's able to create an unsigned request and a signed request (JAR) based on the OAuth Server Metadata.
+// If the authorization server supports pushed authorization requests (RFC9126), the signed request object is pushed to it.
 func (r Wrapper) createAuthorizationRequest(ctx context.Context, subject string, metadata oauth.AuthorizationServerMetadata, modifier requestObjectModifier) (*url.URL, error) {
 	if len(metadata.AuthorizationEndpoint) == 0 {
 		return nil, fmt.Errorf("no authorization endpoint found in metadata for %s", metadata.Issuer)
@@ -913,9 +993,26 @@ func (r Wrapper) createAuthorizationRequest(ctx context.Context, subject string,
 		audience = ""
 	}
 
+	requestObj := r.jar.Create(*signerDID, clientID.String(), audience, modifier)
+	if metadata.PushedAuthorizationRequestEndpoint != "" {
+		// RFC9126: push the signed request object to the authorization server, which returns the request_uri to use
+		requestObject, err := r.jar.Sign(ctx, requestObj.Claims)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign authorization Request Object: %w", err)
+		}
+		response, err := r.auth.IAMClient().PushedAuthorizationRequest(ctx, metadata.PushedAuthorizationRequestEndpoint, clientID.String(), requestObject)
+		if err != nil {
+			return nil, err
+		}
+		redirectURL := nutsHttp.AddQueryParams(*endpoint, map[string]string{
+			oauth.ClientIDParam:   clientID.String(),
+			oauth.RequestURIParam: response.RequestURI,
+		})
+		return &redirectURL, nil
+	}
+
 	// request_uri
 	requestURIID := nutsCrypto.GenerateNonce()
-	requestObj := r.jar.Create(*signerDID, clientID.String(), audience, modifier)
 	if err := r.authzRequestObjectStore().Put(requestURIID, requestObj); err != nil {
 		return nil, err
 	}
@@ -1003,10 +1100,14 @@ func (r Wrapper) determineClientDID(ctx context.Context, authServerMetadata oaut
 			Description: "authorization server does not support 'entity_id' client_id scheme",
 		}
 	}
-	candidateDIDs, err := r.subjectManager.ListDIDs(ctx, subjectID)
+	// the authorization server is the party the request object is sent to, so only its did:peer DID (if any) can be used
+	candidateDIDs, err := r.subjectManager.ListDIDsForRelationship(ctx, subjectID, authServerMetadata.Issuer)
 	if err != nil {
 		return nil, err
 	}
+	if len(candidateDIDs) == 0 {
+		return nil, fmt.Errorf("subject has no DID that can be presented to %s", authServerMetadata.Issuer)
+	}
 	return &candidateDIDs[0], nil
 }
 
@@ -56,6 +56,7 @@ import (
 	"github.com/nuts-foundation/nuts-node/test"
 	"github.com/nuts-foundation/nuts-node/vcr"
 	"github.com/nuts-foundation/nuts-node/vcr/credential"
+	"github.com/nuts-foundation/nuts-node/vcr/dcql"
 	"github.com/nuts-foundation/nuts-node/vcr/holder"
 	"github.com/nuts-foundation/nuts-node/vcr/issuer"
 	"github.com/nuts-foundation/nuts-node/vcr/pe"
@@ -88,6 +89,41 @@ func TestWrapper_OAuthAuthorizationServerMetadata(t *testing.T) {
 		require.NoError(t, err)
 		assert.IsType(t, OAuthAuthorizationServerMetadata200JSONResponse{}, res)
 		assert.NotEmpty(t, res.(OAuthAuthorizationServerMetadata200JSONResponse).AuthorizationEndpoint)
+		assert.NotContains(t, res.(OAuthAuthorizationServerMetadata200JSONResponse).GrantTypesSupported, oauth.TokenExchangeGrantType)
+		assert.Empty(t, res.(OAuthAuthorizationServerMetadata200JSONResponse).JwksURI)
+	})
+	t.Run("token exchange enabled", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.tokenExchangeEnabled = true
+
+		res, err := ctx.client.OAuthAuthorizationServerMetadata(nil, OAuthAuthorizationServerMetadataRequestObject{SubjectID: verifierSubject})
+
+		require.NoError(t, err)
+		require.IsType(t, OAuthAuthorizationServerMetadata200JSONResponse{}, res)
+		assert.Contains(t, res.(OAuthAuthorizationServerMetadata200JSONResponse).GrantTypesSupported, oauth.TokenExchangeGrantType)
+		// must not alter the shared list of supported grant types
+		assert.NotContains(t, grantTypesSupported, oauth.TokenExchangeGrantType)
+	})
+	t.Run("refresh tokens enabled", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.refreshTokenValidity = time.Hour
+
+		res, err := ctx.client.OAuthAuthorizationServerMetadata(nil, OAuthAuthorizationServerMetadataRequestObject{SubjectID: verifierSubject})
+
+		require.NoError(t, err)
+		require.IsType(t, OAuthAuthorizationServerMetadata200JSONResponse{}, res)
+		assert.Contains(t, res.(OAuthAuthorizationServerMetadata200JSONResponse).GrantTypesSupported, oauth.RefreshTokenGrantType)
+		assert.NotContains(t, grantTypesSupported, oauth.RefreshTokenGrantType)
+	})
+	t.Run("JWT access tokens enabled", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.jwtAccessTokensEnabled = true
+
+		res, err := ctx.client.OAuthAuthorizationServerMetadata(nil, OAuthAuthorizationServerMetadataRequestObject{SubjectID: verifierSubject})
+
+		require.NoError(t, err)
+		require.IsType(t, OAuthAuthorizationServerMetadata200JSONResponse{}, res)
+		assert.Equal(t, "https://example.com/oauth2/verifier/jwks", res.(OAuthAuthorizationServerMetadata200JSONResponse).JwksURI)
 	})
 	t.Run("authorization endpoint disabled", func(t *testing.T) {
 		ctx := newCustomTestClient(t, verifierURL, false)
@@ -124,6 +160,40 @@ func TestWrapper_GetOAuthClientMetadata(t *testing.T) {
 	})
 }
 
+func TestWrapper_Jwks(t *testing.T) {
+	testKey := test2.GenerateECKey()
+	t.Run("ok", func(t *testing.T) {
+		ctx := newTestClient(t)
+		ctx.keyResolver.EXPECT().ResolveKey(verifierDID, nil, resolver.AssertionMethod).Return("kid", testKey.Public(), nil)
+
+		res, err := ctx.client.Jwks(nil, JwksRequestObject{SubjectID: verifierSubject})
+
+		require.NoError(t, err)
+		require.IsType(t, Jwks200JSONResponse{}, res)
+		keys := res.(Jwks200JSONResponse).Keys
+		require.Len(t, keys, 1)
+		assert.Equal(t, "kid", keys[0]["kid"])
+		assert.Equal(t, "EC", keys[0]["kty"])
+	})
+	t.Run("error - subject does not exist", func(t *testing.T) {
+		ctx := newTestClient(t)
+
+		res, err := ctx.client.Jwks(nil, JwksRequestObject{SubjectID: unknownSubjectID})
+
+		requireOAuthError(t, err, oauth.InvalidRequest, "subject not found")
+		assert.Nil(t, res)
+	})
+	t.Run("error - key resolution error", func(t *testing.T) {
+		ctx := newTestClient(t)
+		ctx.keyResolver.EXPECT().ResolveKey(verifierDID, nil, resolver.AssertionMethod).Return("", nil, assert.AnError)
+
+		res, err := ctx.client.Jwks(nil, JwksRequestObject{SubjectID: verifierSubject})
+
+		requireOAuthError(t, err, oauth.ServerError, "")
+		assert.Nil(t, res)
+	})
+}
+
 func TestWrapper_OpenIDConfiguration(t *testing.T) {
 	testKey := test2.GenerateECKey()
 	t.Run("ok", func(t *testing.T) {
@@ -200,6 +270,22 @@ func TestWrapper_PresentationDefinition(t *testing.T) {
 		assert.True(t, ok)
 	})
 
+	t.Run("ok - client_id is passed to policy backend", func(t *testing.T) {
+		test := newTestClient(t)
+		clientID := "https://example.com/oauth2/requester"
+		test.policy.EXPECT().PresentationDefinitions(gomock.Any(), "example-scope").DoAndReturn(func(ctx context.Context, _ string) (pe.WalletOwnerMapping, error) {
+			info := policy.RequestInfoFromContext(ctx)
+			assert.Equal(t, clientID, info.ClientID)
+			assert.Equal(t, verifierSubject, info.Subject)
+			return walletOwnerMapping, nil
+		})
+
+		response, err := test.client.PresentationDefinition(ctx, PresentationDefinitionRequestObject{SubjectID: verifierSubject, Params: PresentationDefinitionParams{Scope: "example-scope", ClientId: &clientID}})
+
+		require.NoError(t, err)
+		require.NotNil(t, response)
+	})
+
 	t.Run("ok - missing scope", func(t *testing.T) {
 		test := newTestClient(t)
 
@@ -726,6 +812,23 @@ func TestWrapper_IntrospectAccessToken(t *testing.T) {
 		require.True(t, ok)
 		assert.Equal(t, "Doe", tokenResponse.AdditionalProperties["family_name"])
 	})
+	t.Run("with delegation chain from token exchange", func(t *testing.T) {
+		token := AccessToken{
+			Expiration: time.Now().Add(time.Second),
+			Actor:      &Actor{Sub: "https://example.com/oauth2/gateway"},
+		}
+		require.NoError(t, ctx.client.accessTokenServerStore().Put("token", token))
+
+		res, err := ctx.client.IntrospectAccessToken(reqCtx, IntrospectAccessTokenRequestObject{Body: &TokenIntrospectionRequest{Token: "token"}})
+
+		require.NoError(t, err)
+		tokenResponse, ok := res.(IntrospectAccessToken200JSONResponse)
+		require.True(t, ok)
+		require.NotNil(t, tokenResponse.Act)
+		assert.Equal(t, "https://example.com/oauth2/gateway", tokenResponse.Act.Sub)
+		responseJSON, _ := json.Marshal(tokenResponse)
+		assert.Contains(t, string(responseJSON), `"act":{"sub":"https://example.com/oauth2/gateway"}`)
+	})
 	t.Run("InputDescriptorConstraintIdMap contains reserved claim", func(t *testing.T) {
 		token := AccessToken{
 			Expiration: time.Now().Add(time.Second),
@@ -955,6 +1058,61 @@ func TestWrapper_RequestServiceAccessToken(t *testing.T) {
 			assert.NotEqual(t, token, otherToken)
 		})
 	})
+	t.Run("refresh token", func(t *testing.T) {
+		request := RequestServiceAccessTokenRequestObject{SubjectID: holderSubjectID, Body: body}
+		cacheKey := accessTokenRequestCacheKey(request)
+		newResponse := func(accessToken string, refreshToken string) *oauth.TokenResponse {
+			return &oauth.TokenResponse{
+				AccessToken:  accessToken,
+				TokenType:    "DPoP",
+				ExpiresIn:    to.Ptr(900),
+				DPoPKid:      to.Ptr("kid"),
+				RefreshToken: to.Ptr(refreshToken),
+			}
+		}
+		t.Run("is not returned, but used when the access token expired", func(t *testing.T) {
+			ctx := newTestClient(t)
+			ctx.iamClient.EXPECT().RequestRFC021AccessToken(nil, holderClientID, holderSubjectID, verifierURL.String(), "first second", true, nil).Return(newResponse("first", "refresh-1"), nil)
+			ctx.iamClient.EXPECT().RefreshAccessToken(nil, verifierURL.String(), holderClientID, "refresh-1", "kid").Return(newResponse("second", "refresh-2"), nil)
+
+			token, err := ctx.client.RequestServiceAccessToken(nil, request)
+
+			require.NoError(t, err)
+			assert.Nil(t, token.(RequestServiceAccessToken200JSONResponse).RefreshToken)
+
+			require.NoError(t, ctx.client.accessTokenCache().Delete(cacheKey))
+			token, err = ctx.client.RequestServiceAccessToken(nil, request)
+
+			require.NoError(t, err)
+			assert.Equal(t, "second", token.(RequestServiceAccessToken200JSONResponse).AccessToken)
+			assert.Nil(t, token.(RequestServiceAccessToken200JSONResponse).RefreshToken)
+			var stored serviceRefreshToken
+			require.NoError(t, ctx.client.refreshTokenClientStore().Get(cacheKey, &stored))
+			assert.Equal(t, serviceRefreshToken{RefreshToken: "refresh-2", DPoPKid: "kid"}, stored)
+		})
+		t.Run("refresh fails, falls back to new access token request", func(t *testing.T) {
+			ctx := newTestClient(t)
+			require.NoError(t, ctx.client.refreshTokenClientStore().Put(cacheKey, serviceRefreshToken{RefreshToken: "refresh-1"}))
+			ctx.iamClient.EXPECT().RefreshAccessToken(nil, verifierURL.String(), holderClientID, "refresh-1", "").Return(nil, oauth.OAuth2Error{Code: oauth.InvalidGrant})
+			ctx.iamClient.EXPECT().RequestRFC021AccessToken(nil, holderClientID, holderSubjectID, verifierURL.String(), "first second", true, nil).Return(newResponse("first", "refresh-2"), nil)
+
+			token, err := ctx.client.RequestServiceAccessToken(nil, request)
+
+			require.NoError(t, err)
+			assert.Equal(t, "first", token.(RequestServiceAccessToken200JSONResponse).AccessToken)
+		})
+		t.Run("not used when client uses Cache-Control: no-cache", func(t *testing.T) {
+			ctx := newTestClient(t)
+			require.NoError(t, ctx.client.refreshTokenClientStore().Put(cacheKey, serviceRefreshToken{RefreshToken: "refresh-1"}))
+			noCacheRequest := request
+			noCacheRequest.Params.CacheControl = to.Ptr("no-cache")
+			ctx.iamClient.EXPECT().RequestRFC021AccessToken(nil, holderClientID, holderSubjectID, verifierURL.String(), "first second", true, nil).Return(newResponse("first", "refresh-2"), nil)
+
+			_, err := ctx.client.RequestServiceAccessToken(nil, noCacheRequest)
+
+			require.NoError(t, err)
+		})
+	})
 	t.Run("ok - no DPoP", func(t *testing.T) {
 		ctx := newTestClient(t)
 		tokenTypeBearer := ServiceAccessTokenRequestTokenType("bearer")
@@ -1182,6 +1340,34 @@ func TestWrapper_StatusList(t *testing.T) {
 	})
 }
 
+func TestWrapper_BitstringStatusList(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctx := newTestClient(t)
+		page := 1
+		issuerDID := did.MustParseDID("did:web:example.com:iam:123")
+		slCred := VerifiableCredential{Issuer: ssi.MustParseURI(issuerDID.String())}
+		ctx.vcIssuer.EXPECT().BitstringStatusList(nil, issuerDID, "suspension", page).Return(&slCred, nil)
+
+		res, err := ctx.client.BitstringStatusList(nil, BitstringStatusListRequestObject{
+			Did:     issuerDID.String(),
+			Purpose: "suspension",
+			Page:    page,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, BitstringStatusList200JSONResponse(slCred), res)
+	})
+	t.Run("error - not found", func(t *testing.T) {
+		ctx := newTestClient(t)
+		ctx.vcIssuer.EXPECT().BitstringStatusList(nil, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, types.ErrNotFound)
+
+		res, err := ctx.client.BitstringStatusList(nil, BitstringStatusListRequestObject{Did: verifierDID.String(), Purpose: "revocation"})
+
+		assert.ErrorIs(t, err, types.ErrNotFound)
+		assert.Nil(t, res)
+	})
+}
+
 func TestWrapper_GetRequestJWT(t *testing.T) {
 	cont := context.Background()
 	requestID := "thisID"
@@ -1406,6 +1592,40 @@ func TestWrapper_CreateAuthorizationRequest(t *testing.T) {
 		assert.Equal(t, "post", redirectURL.Query().Get(oauth.RequestURIMethodParam))
 		assert.NotEmpty(t, redirectURL.Query().Get(oauth.RequestURIParam))
 	})
+	t.Run("ok - pushed authorization request", func(t *testing.T) {
+		ctx := newTestClient(t)
+		customMetadata := serverMetadata
+		customMetadata.PushedAuthorizationRequestEndpoint = "https://server.test/par"
+		var expectedJarReq jarRequest
+		ctx.jar.EXPECT().Create(holderDID, holderClientID, issuerURL.String(), gomock.Any()).DoAndReturn(func(client did.DID, clientID string, authServerURL string, modifier requestObjectModifier) jarRequest {
+			expectedJarReq = createJarRequest(client, clientID, authServerURL, modifier)
+			return expectedJarReq
+		})
+		ctx.jar.EXPECT().Sign(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, claims oauthParameters) (string, error) {
+			assert.Equal(t, expectedJarReq.Claims, claims)
+			return "signed-request", nil
+		})
+		ctx.iamClient.EXPECT().PushedAuthorizationRequest(gomock.Any(), "https://server.test/par", holderClientID, "signed-request").
+			Return(&oauth.PushedAuthorizationResponse{RequestURI: "urn:ietf:params:oauth:request_uri:ref", ExpiresIn: 60}, nil)
+
+		redirectURL, err := ctx.client.createAuthorizationRequest(context.Background(), holderSubjectID, customMetadata, modifier)
+
+		require.NoError(t, err)
+		assert.Equal(t, "https://server.test/authorize?client_id=https%3A%2F%2Fexample.com%2Foauth2%2Fholder&request_uri=urn%3Aietf%3Aparams%3Aoauth%3Arequest_uri%3Aref", redirectURL.String())
+	})
+	t.Run("error - pushed authorization request fails", func(t *testing.T) {
+		ctx := newTestClient(t)
+		customMetadata := serverMetadata
+		customMetadata.PushedAuthorizationRequestEndpoint = "https://server.test/par"
+		ctx.jar.EXPECT().Create(holderDID, holderClientID, issuerURL.String(), gomock.Any()).DoAndReturn(createJarRequest)
+		ctx.jar.EXPECT().Sign(gomock.Any(), gomock.Any()).Return("signed-request", nil)
+		ctx.iamClient.EXPECT().PushedAuthorizationRequest(gomock.Any(), "https://server.test/par", holderClientID, "signed-request").Return(nil, assert.AnError)
+
+		redirectURL, err := ctx.client.createAuthorizationRequest(context.Background(), holderSubjectID, customMetadata, modifier)
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, redirectURL)
+	})
 	t.Run("error - missing authorization endpoint", func(t *testing.T) {
 		ctx := newTestClient(t)
 		customMetadata := serverMetadata
@@ -1416,6 +1636,14 @@ func TestWrapper_CreateAuthorizationRequest(t *testing.T) {
 		assert.Error(t, err)
 		assert.ErrorContains(t, err, "no authorization endpoint found in metadata for")
 	})
+	t.Run("error - no DID for the authorization server", func(t *testing.T) {
+		ctx := newTestClient(t)
+		ctx.subjectManager.EXPECT().ListDIDsForRelationship(gomock.Any(), "peer-only", issuerURL.String()).Return([]did.DID{}, nil)
+
+		_, err := ctx.client.createAuthorizationRequest(context.Background(), "peer-only", serverMetadata, modifier)
+
+		assert.EqualError(t, err, "subject has no DID that can be presented to "+issuerURL.String())
+	})
 }
 
 // testAuthzReqRedirectURI compares to expectedRedirectURI and actualRedirectURI
@@ -1588,6 +1816,18 @@ type testCtx struct {
 	wallet         *holder.MockWallet
 	subjectManager *didsubject.MockManager
 	jar            *MockJAR
+	// tokenExchangeEnabled is returned by authnServices.TokenExchangeEnabled(), tests can set it to enable token exchange.
+	tokenExchangeEnabled *bool
+	// refreshTokenValidity is returned by authnServices.RefreshTokenValidity(), tests can set it to enable refresh tokens.
+	refreshTokenValidity *time.Duration
+	// jwtAccessTokensEnabled is returned by authnServices.JWTAccessTokensEnabled(), tests can set it to issue JWT access tokens.
+	jwtAccessTokensEnabled *bool
+	// accessTokenAudiences is returned by authnServices.AccessTokenAudiences(), tests can add a scope to configure the resource server of JWT access tokens.
+	accessTokenAudiences map[string]string
+	// domainLinkageRequired is returned by authnServices.DomainLinkageRequired(), tests can set it to require domain linkage of clients.
+	domainLinkageRequired *bool
+	// dcqlQueries is consulted by policy.DCQLQueries(), tests can add a scope to express it in DCQL. Other scopes return policy.ErrNotFound.
+	dcqlQueries map[string]dcql.WalletOwnerMapping
 }
 
 func newTestClient(t testing.TB) *testCtx {
@@ -1621,11 +1861,38 @@ func newCustomTestClient(t testing.TB, publicURL *url.URL, authEndpointEnabled b
 	mockVCR.EXPECT().Wallet().Return(mockWallet).AnyTimes()
 	authnServices.EXPECT().IAMClient().Return(iamClient).AnyTimes()
 	authnServices.EXPECT().AuthorizationEndpointEnabled().Return(authEndpointEnabled).AnyTimes()
+	tokenExchangeEnabled := new(bool)
+	authnServices.EXPECT().TokenExchangeEnabled().DoAndReturn(func() bool {
+		return *tokenExchangeEnabled
+	}).AnyTimes()
+	refreshTokenValidity := new(time.Duration)
+	authnServices.EXPECT().RefreshTokenValidity().DoAndReturn(func() time.Duration {
+		return *refreshTokenValidity
+	}).AnyTimes()
+	domainLinkageRequired := new(bool)
+	authnServices.EXPECT().DomainLinkageRequired().DoAndReturn(func() bool {
+		return *domainLinkageRequired
+	}).AnyTimes()
+	jwtAccessTokensEnabled := new(bool)
+	authnServices.EXPECT().JWTAccessTokensEnabled().DoAndReturn(func() bool {
+		return *jwtAccessTokensEnabled
+	}).AnyTimes()
+	accessTokenAudiences := map[string]string{"everything": "https://example.com/resource"}
+	authnServices.EXPECT().AccessTokenAudiences().Return(accessTokenAudiences).AnyTimes()
+	dcqlQueries := make(map[string]dcql.WalletOwnerMapping)
+	policyInstance.EXPECT().DCQLQueries(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, scope string) (dcql.WalletOwnerMapping, error) {
+		if queries, ok := dcqlQueries[scope]; ok {
+			return queries, nil
+		}
+		return nil, policy.ErrNotFound
+	}).AnyTimes()
 
 	subjectManager.EXPECT().ListDIDs(gomock.Any(), holderSubjectID).Return([]did.DID{holderDID}, nil).AnyTimes()
 	subjectManager.EXPECT().ListDIDs(gomock.Any(), unknownSubjectID).Return(nil, didsubject.ErrSubjectNotFound).AnyTimes()
 	subjectManager.EXPECT().ListDIDs(gomock.Any(), verifierSubject).Return([]did.DID{verifierDID}, nil).AnyTimes()
 	subjectManager.EXPECT().ListDIDs(gomock.Any(), issuerSubjectID).Return([]did.DID{issuerDID}, nil).AnyTimes()
+	subjectManager.EXPECT().ListDIDsForRelationship(gomock.Any(), holderSubjectID, gomock.Any()).Return([]did.DID{holderDID}, nil).AnyTimes()
+	subjectManager.EXPECT().ListDIDsForRelationship(gomock.Any(), verifierSubject, gomock.Any()).Return([]did.DID{verifierDID}, nil).AnyTimes()
 	subjectManager.EXPECT().Exists(gomock.Any(), holderSubjectID).Return(true, nil).AnyTimes()
 	subjectManager.EXPECT().Exists(gomock.Any(), verifierSubject).Return(true, nil).AnyTimes()
 	subjectManager.EXPECT().Exists(gomock.Any(), unknownSubjectID).Return(false, nil).AnyTimes()
@@ -1657,5 +1924,12 @@ func newCustomTestClient(t testing.TB, publicURL *url.URL, authEndpointEnabled b
 		jwtSigner:      jwtSigner,
 		jar:            mockJAR,
 		client:         client,
+
+		tokenExchangeEnabled:   tokenExchangeEnabled,
+		refreshTokenValidity:   refreshTokenValidity,
+		jwtAccessTokensEnabled: jwtAccessTokensEnabled,
+		accessTokenAudiences:   accessTokenAudiences,
+		domainLinkageRequired:  domainLinkageRequired,
+		dcqlQueries:            dcqlQueries,
 	}
 }
@@ -31,6 +31,15 @@ const (
 	UserAccessTokenRequestTokenTypeDPoP   UserAccessTokenRequestTokenType = "DPoP"
 )
 
+// Actor Delegation chain of an access token obtained through token exchange, as specified by RFC8693 section 4.1.
+type Actor struct {
+	// Act Previous actor in the delegation chain, if any.
+	Act *Actor `json:"act,omitempty"`
+
+	// Sub Identifier of the party that acted on behalf of the token's original client.
+	Sub string `json:"sub"`
+}
+
 // DPoPRequest defines model for DPoPRequest.
 type DPoPRequest struct {
 	// Htm The HTTP method for which the DPoP proof is requested.
@@ -78,6 +87,9 @@ type DPoPValidateResponse struct {
 
 // ExtendedTokenIntrospectionResponse defines model for ExtendedTokenIntrospectionResponse.
 type ExtendedTokenIntrospectionResponse struct {
+	// Act Delegation chain of an access token obtained through token exchange, as specified by RFC8693 section 4.1.
+	Act *Actor `json:"act,omitempty"`
+
 	// Active True if the token is active, false if the token is expired, malformed etc. Required per RFC7662
 	Active bool `json:"active"`
 
@@ -112,6 +124,11 @@ type ExtendedTokenIntrospectionResponse struct {
 	AdditionalProperties map[string]interface{}    `json:"-"`
 }
 
+// JWKS JSON Web Key Set (RFC7517)
+type JWKS struct {
+	Keys []map[string]interface{} `json:"keys"`
+}
+
 // OpenIDConfiguration OpenID entity configuration
 // Contain properties from several specifications and may grow over time
 type OpenIDConfiguration = map[string]interface{}
@@ -162,6 +179,11 @@ type TokenIntrospectionRequest struct {
 	Token string `json:"token"`
 }
 
+// TokenRevocationRequest Request to revoke an access token or refresh token issued by this node.
+type TokenRevocationRequest struct {
+	Token string `json:"token"`
+}
+
 // UserAccessTokenRequest Request for an access token for a user.
 type UserAccessTokenRequest struct {
 	// AuthorizationServer The OAuth Authorization Server's identifier as specified in RFC 8414 (section 2),
@@ -257,6 +279,11 @@ type CallbackParams struct {
 type PresentationDefinitionParams struct {
 	Scope           string           `form:"scope" json:"scope"`
 	WalletOwnerType *WalletOwnerType `form:"wallet_owner_type,omitempty" json:"wallet_owner_type,omitempty"`
+
+	// ClientId The client_id of the relying party requesting the presentation definition.
+	// It is passed to the policy backend, so it can select the presentation definition for that client.
+	// The parameter is not authenticated: the policy backend is called again with the authenticated client_id at the token endpoint.
+	ClientId *string `form:"client_id,omitempty" json:"client_id,omitempty"`
 }
 
 // RequestJWTByPostFormdataBody defines parameters for RequestJWTByPost.
@@ -282,19 +309,47 @@ type HandleAuthorizeResponseFormdataBody struct {
 	// State the client state for the verifier
 	State *string `form:"state,omitempty" json:"state,omitempty"`
 
-	// VpToken A Verifiable Presentation in either JSON-LD or JWT format.
+	// VpToken A Verifiable Presentation in either JSON-LD or JWT format, or an array of them.
+	// For DCQL queries, a JSON object mapping credential query IDs to Verifiable Presentations.
 	VpToken *string `form:"vp_token,omitempty" json:"vp_token,omitempty"`
 }
 
+// RevokeTokenFormdataBody defines parameters for RevokeToken.
+type RevokeTokenFormdataBody struct {
+	// ClientId the client that requests the revocation, must match the client the token was issued to.
+	ClientId string `form:"client_id" json:"client_id"`
+
+	// Token the access token or refresh token to revoke.
+	Token string `form:"token" json:"token"`
+
+	// TokenTypeHint hint about the type of the token: access_token or refresh_token.
+	TokenTypeHint *string `form:"token_type_hint,omitempty" json:"token_type_hint,omitempty"`
+}
+
 // HandleTokenRequestFormdataBody defines parameters for HandleTokenRequest.
 type HandleTokenRequestFormdataBody struct {
+	// ActorToken An access token issued to the exchanging party by this authorization server, authenticating it as the actor (token exchange grant type only).
+	ActorToken             *string `form:"actor_token,omitempty" json:"actor_token,omitempty"`
+	ActorTokenType         *string `form:"actor_token_type,omitempty" json:"actor_token_type,omitempty"`
 	Assertion              *string `form:"assertion,omitempty" json:"assertion,omitempty"`
 	ClientId               *string `form:"client_id,omitempty" json:"client_id,omitempty"`
 	Code                   *string `form:"code,omitempty" json:"code,omitempty"`
 	CodeVerifier           *string `form:"code_verifier,omitempty" json:"code_verifier,omitempty"`
 	GrantType              string  `form:"grant_type" json:"grant_type"`
 	PresentationSubmission *string `form:"presentation_submission,omitempty" json:"presentation_submission,omitempty"`
+	RefreshToken           *string `form:"refresh_token,omitempty" json:"refresh_token,omitempty"`
+	RequestedTokenType     *string `form:"requested_token_type,omitempty" json:"requested_token_type,omitempty"`
 	Scope                  *string `form:"scope,omitempty" json:"scope,omitempty"`
+	SubjectToken           *string `form:"subject_token,omitempty" json:"subject_token,omitempty"`
+	SubjectTokenType       *string `form:"subject_token_type,omitempty" json:"subject_token_type,omitempty"`
+}
+
+// PushedAuthorizationRequestFormdataBody defines parameters for PushedAuthorizationRequest.
+type PushedAuthorizationRequestFormdataBody struct {
+	ClientId string `form:"client_id" json:"client_id"`
+
+	// Request The signed request object (RFC9101).
+	Request *string `form:"request,omitempty" json:"request,omitempty"`
 }
 
 // IntrospectAccessTokenFormdataRequestBody defines body for IntrospectAccessToken for application/x-www-form-urlencoded ContentType.
@@ -303,6 +358,9 @@ type IntrospectAccessTokenFormdataRequestBody = TokenIntrospectionRequest
 // IntrospectAccessTokenExtendedFormdataRequestBody defines body for IntrospectAccessTokenExtended for application/x-www-form-urlencoded ContentType.
 type IntrospectAccessTokenExtendedFormdataRequestBody = TokenIntrospectionRequest
 
+// RevokeAccessTokenFormdataRequestBody defines body for RevokeAccessToken for application/x-www-form-urlencoded ContentType.
+type RevokeAccessTokenFormdataRequestBody = TokenRevocationRequest
+
 // ValidateDPoPProofJSONRequestBody defines body for ValidateDPoPProof for application/json ContentType.
 type ValidateDPoPProofJSONRequestBody = DPoPValidateRequest
 
@@ -324,9 +382,15 @@ type RequestJWTByPostFormdataRequestBody RequestJWTByPostFormdataBody
 // HandleAuthorizeResponseFormdataRequestBody defines body for HandleAuthorizeResponse for application/x-www-form-urlencoded ContentType.
 type HandleAuthorizeResponseFormdataRequestBody HandleAuthorizeResponseFormdataBody
 
+// RevokeTokenFormdataRequestBody defines body for RevokeToken for application/x-www-form-urlencoded ContentType.
+type RevokeTokenFormdataRequestBody RevokeTokenFormdataBody
+
 // HandleTokenRequestFormdataRequestBody defines body for HandleTokenRequest for application/x-www-form-urlencoded ContentType.
 type HandleTokenRequestFormdataRequestBody HandleTokenRequestFormdataBody
 
+// PushedAuthorizationRequestFormdataRequestBody defines body for PushedAuthorizationRequest for application/x-www-form-urlencoded ContentType.
+type PushedAuthorizationRequestFormdataRequestBody PushedAuthorizationRequestFormdataBody
+
 // Getter for additional properties for ExtendedTokenIntrospectionResponse. Returns the specified
 // element and whether it was found
 func (a ExtendedTokenIntrospectionResponse) Get(fieldName string) (value interface{}, found bool) {
@@ -352,6 +416,14 @@ func (a *ExtendedTokenIntrospectionResponse) UnmarshalJSON(b []byte) error {
 		return err
 	}
 
+	if raw, found := object["act"]; found {
+		err = json.Unmarshal(raw, &a.Act)
+		if err != nil {
+			return fmt.Errorf("error reading 'act': %w", err)
+		}
+		delete(object, "act")
+	}
+
 	if raw, found := object["active"]; found {
 		err = json.Unmarshal(raw, &a.Active)
 		if err != nil {
@@ -459,6 +531,13 @@ func (a ExtendedTokenIntrospectionResponse) MarshalJSON() ([]byte, error) {
 	var err error
 	object := make(map[string]json.RawMessage)
 
+	if a.Act != nil {
+		object["act"], err = json.Marshal(a.Act)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling 'act': %w", err)
+		}
+	}
+
 	object["active"], err = json.Marshal(a.Active)
 	if err != nil {
 		return nil, fmt.Errorf("error marshaling 'active': %w", err)
@@ -560,6 +639,10 @@ type ServerInterface interface {
 	// Presentation Submissions and Verifiable Presentations added.
 	// (POST /internal/auth/v2/accesstoken/introspect_extended)
 	IntrospectAccessTokenExtended(ctx echo.Context) error
+	// Revokes an access token or refresh token issued by this node, before it expires.
+	// Subsequent introspection of the token reports it as inactive.
+	// (POST /internal/auth/v2/accesstoken/revoke)
+	RevokeAccessToken(ctx echo.Context) error
 	// Get the access token from the Nuts node that was requested through /request-user-access-token.
 	// (GET /internal/auth/v2/accesstoken/{sessionID})
 	RetrieveAccessToken(ctx echo.Context, sessionID string) error
@@ -587,6 +670,9 @@ type ServerInterface interface {
 	// Get the OAuth2 Client metadata
 	// (GET /oauth2/{subjectID}/oauth-client)
 	OAuthClientMetadata(ctx echo.Context, subjectID string) error
+	// Get the JWK Set of the authorization server of the subject
+	// (GET /oauth2/{subjectID}/jwks)
+	Jwks(ctx echo.Context, subjectID string) error
 	// Used by relying parties to obtain a presentation definition for desired scopes as specified by Nuts RFC021.
 	// (GET /oauth2/{subjectID}/presentation_definition)
 	PresentationDefinition(ctx echo.Context, subjectID string, params PresentationDefinitionParams) error
@@ -599,12 +685,21 @@ type ServerInterface interface {
 	// Used by wallets to post the authorization response or error to.
 	// (POST /oauth2/{subjectID}/response)
 	HandleAuthorizeResponse(ctx echo.Context, subjectID string) error
+	// Used by OAuth2 clients to revoke access- or refresh tokens as described by RFC7009.
+	// (POST /oauth2/{subjectID}/revoke)
+	RevokeToken(ctx echo.Context, subjectID string) error
 	// Used by the OAuth2 client (backend, not the browser) to request access- or refresh tokens.
 	// (POST /oauth2/{subjectID}/token)
 	HandleTokenRequest(ctx echo.Context, subjectID string) error
+	// Used by OAuth2 clients to push an authorization request to the authorization server as described by RFC9126.
+	// (POST /oauth2/{subjectID}/par)
+	PushedAuthorizationRequest(ctx echo.Context, subjectID string) error
 	// Get the StatusList2021Credential for the given DID and page
 	// (GET /statuslist/{did}/{page})
 	StatusList(ctx echo.Context, did string, page int) error
+	// Get the BitstringStatusListCredential for the given DID, status purpose and page
+	// (GET /statuslist/{did}/{purpose}/{page})
+	BitstringStatusList(ctx echo.Context, did string, purpose string, page int) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -670,6 +765,17 @@ func (w *ServerInterfaceWrapper) IntrospectAccessTokenExtended(ctx echo.Context)
 	return err
 }
 
+// RevokeAccessToken converts echo context to params.
+func (w *ServerInterfaceWrapper) RevokeAccessToken(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.RevokeAccessToken(ctx)
+	return err
+}
+
 // RetrieveAccessToken converts echo context to params.
 func (w *ServerInterfaceWrapper) RetrieveAccessToken(ctx echo.Context) error {
 	var err error
@@ -881,6 +987,24 @@ func (w *ServerInterfaceWrapper) OAuthClientMetadata(ctx echo.Context) error {
 	return err
 }
 
+// Jwks converts echo context to params.
+func (w *ServerInterfaceWrapper) Jwks(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "subjectID" -------------
+	var subjectID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "subjectID", ctx.Param("subjectID"), &subjectID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter subjectID: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.Jwks(ctx, subjectID)
+	return err
+}
+
 // PresentationDefinition converts echo context to params.
 func (w *ServerInterfaceWrapper) PresentationDefinition(ctx echo.Context) error {
 	var err error
@@ -910,6 +1034,13 @@ func (w *ServerInterfaceWrapper) PresentationDefinition(ctx echo.Context) error
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter wallet_owner_type: %s", err))
 	}
 
+	// ------------- Optional query parameter "client_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "client_id", ctx.QueryParams(), &params.ClientId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter client_id: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
 	err = w.Handler.PresentationDefinition(ctx, subjectID, params)
 	return err
@@ -982,6 +1113,21 @@ func (w *ServerInterfaceWrapper) HandleAuthorizeResponse(ctx echo.Context) error
 	return err
 }
 
+// RevokeToken converts echo context to params.
+func (w *ServerInterfaceWrapper) RevokeToken(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "subjectID" -------------
+	var subjectID string
+
+	subjectID = ctx.Param("subjectID")
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.RevokeToken(ctx, subjectID)
+	return err
+}
+
 // HandleTokenRequest converts echo context to params.
 func (w *ServerInterfaceWrapper) HandleTokenRequest(ctx echo.Context) error {
 	var err error
@@ -1000,6 +1146,24 @@ func (w *ServerInterfaceWrapper) HandleTokenRequest(ctx echo.Context) error {
 	return err
 }
 
+// PushedAuthorizationRequest converts echo context to params.
+func (w *ServerInterfaceWrapper) PushedAuthorizationRequest(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "subjectID" -------------
+	var subjectID string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "subjectID", ctx.Param("subjectID"), &subjectID, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter subjectID: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PushedAuthorizationRequest(ctx, subjectID)
+	return err
+}
+
 // StatusList converts echo context to params.
 func (w *ServerInterfaceWrapper) StatusList(ctx echo.Context) error {
 	var err error
@@ -1023,6 +1187,37 @@ func (w *ServerInterfaceWrapper) StatusList(ctx echo.Context) error {
 	return err
 }
 
+// BitstringStatusList converts echo context to params.
+func (w *ServerInterfaceWrapper) BitstringStatusList(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "did" -------------
+	var did string
+
+	did = ctx.Param("did")
+
+	// ------------- Path parameter "purpose" -------------
+	var purpose string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "purpose", ctx.Param("purpose"), &purpose, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter purpose: %s", err))
+	}
+
+	// ------------- Path parameter "page" -------------
+	var page int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "page", ctx.Param("page"), &page, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter page: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.BitstringStatusList(ctx, did, purpose, page)
+	return err
+}
+
 // This is a simple interface which specifies echo.Route addition functions which
 // are present on both echo.Echo and echo.Group, since we want to allow using
 // either of them for path registration
@@ -1055,6 +1250,7 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 	router.GET(baseURL+"/.well-known/openid-configuration/oauth2/:subjectID", wrapper.OpenIDConfiguration)
 	router.POST(baseURL+"/internal/auth/v2/accesstoken/introspect", wrapper.IntrospectAccessToken)
 	router.POST(baseURL+"/internal/auth/v2/accesstoken/introspect_extended", wrapper.IntrospectAccessTokenExtended)
+	router.POST(baseURL+"/internal/auth/v2/accesstoken/revoke", wrapper.RevokeAccessToken)
 	router.GET(baseURL+"/internal/auth/v2/accesstoken/:sessionID", wrapper.RetrieveAccessToken)
 	router.POST(baseURL+"/internal/auth/v2/dpop/validate", wrapper.ValidateDPoPProof)
 	router.POST(baseURL+"/internal/auth/v2/dpop/:kid", wrapper.CreateDPoPProof)
@@ -1064,12 +1260,16 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 	router.GET(baseURL+"/oauth2/:subjectID/authorize", wrapper.HandleAuthorizeRequest)
 	router.GET(baseURL+"/oauth2/:subjectID/callback", wrapper.Callback)
 	router.GET(baseURL+"/oauth2/:subjectID/oauth-client", wrapper.OAuthClientMetadata)
+	router.GET(baseURL+"/oauth2/:subjectID/jwks", wrapper.Jwks)
 	router.GET(baseURL+"/oauth2/:subjectID/presentation_definition", wrapper.PresentationDefinition)
 	router.GET(baseURL+"/oauth2/:subjectID/request.jwt/:id", wrapper.RequestJWTByGet)
 	router.POST(baseURL+"/oauth2/:subjectID/request.jwt/:id", wrapper.RequestJWTByPost)
 	router.POST(baseURL+"/oauth2/:subjectID/response", wrapper.HandleAuthorizeResponse)
+	router.POST(baseURL+"/oauth2/:subjectID/revoke", wrapper.RevokeToken)
 	router.POST(baseURL+"/oauth2/:subjectID/token", wrapper.HandleTokenRequest)
+	router.POST(baseURL+"/oauth2/:subjectID/par", wrapper.PushedAuthorizationRequest)
 	router.GET(baseURL+"/statuslist/:did/:page", wrapper.StatusList)
+	router.GET(baseURL+"/statuslist/:did/:purpose/:page", wrapper.BitstringStatusList)
 
 }
 
@@ -1200,6 +1400,30 @@ func (response IntrospectAccessTokenExtended401Response) VisitIntrospectAccessTo
 	return nil
 }
 
+type RevokeAccessTokenRequestObject struct {
+	Body *RevokeAccessTokenFormdataRequestBody
+}
+
+type RevokeAccessTokenResponseObject interface {
+	VisitRevokeAccessTokenResponse(w http.ResponseWriter) error
+}
+
+type RevokeAccessToken204Response struct {
+}
+
+func (response RevokeAccessToken204Response) VisitRevokeAccessTokenResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type RevokeAccessToken401Response struct {
+}
+
+func (response RevokeAccessToken401Response) VisitRevokeAccessTokenResponse(w http.ResponseWriter) error {
+	w.WriteHeader(401)
+	return nil
+}
+
 type RetrieveAccessTokenRequestObject struct {
 	SessionID string `json:"sessionID"`
 }
@@ -1544,6 +1768,44 @@ func (response OAuthClientMetadatadefaultApplicationProblemPlusJSONResponse) Vis
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
+type JwksRequestObject struct {
+	SubjectID string `json:"subjectID"`
+}
+
+type JwksResponseObject interface {
+	VisitJwksResponse(w http.ResponseWriter) error
+}
+
+type Jwks200JSONResponse JWKS
+
+func (response Jwks200JSONResponse) VisitJwksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type JwksdefaultApplicationProblemPlusJSONResponse struct {
+	Body struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+	StatusCode int
+}
+
+func (response JwksdefaultApplicationProblemPlusJSONResponse) VisitJwksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
 type PresentationDefinitionRequestObject struct {
 	SubjectID string `json:"subjectID"`
 	Params    PresentationDefinitionParams
@@ -1691,6 +1953,35 @@ func (response HandleAuthorizeResponse200JSONResponse) VisitHandleAuthorizeRespo
 	return json.NewEncoder(w).Encode(response)
 }
 
+type RevokeTokenRequestObject struct {
+	SubjectID string `json:"subjectID"`
+	Body      *RevokeTokenFormdataRequestBody
+}
+
+type RevokeTokenResponseObject interface {
+	VisitRevokeTokenResponse(w http.ResponseWriter) error
+}
+
+type RevokeToken200Response struct {
+}
+
+func (response RevokeToken200Response) VisitRevokeTokenResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type RevokeTokendefaultJSONResponse struct {
+	Body       ErrorResponse
+	StatusCode int
+}
+
+func (response RevokeTokendefaultJSONResponse) VisitRevokeTokenResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
 type HandleTokenRequestRequestObject struct {
 	SubjectID string `json:"subjectID"`
 	Body      *HandleTokenRequestFormdataRequestBody
@@ -1721,6 +2012,36 @@ func (response HandleTokenRequestdefaultJSONResponse) VisitHandleTokenRequestRes
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
+type PushedAuthorizationRequestRequestObject struct {
+	SubjectID string `json:"subjectID"`
+	Body      *PushedAuthorizationRequestFormdataRequestBody
+}
+
+type PushedAuthorizationRequestResponseObject interface {
+	VisitPushedAuthorizationRequestResponse(w http.ResponseWriter) error
+}
+
+type PushedAuthorizationRequest201JSONResponse PushedAuthorizationResponse
+
+func (response PushedAuthorizationRequest201JSONResponse) VisitPushedAuthorizationRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PushedAuthorizationRequestdefaultJSONResponse struct {
+	Body       ErrorResponse
+	StatusCode int
+}
+
+func (response PushedAuthorizationRequestdefaultJSONResponse) VisitPushedAuthorizationRequestResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
 type StatusListRequestObject struct {
 	Did  string `json:"did"`
 	Page int    `json:"page"`
@@ -1760,6 +2081,46 @@ func (response StatusListdefaultApplicationProblemPlusJSONResponse) VisitStatusL
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
+type BitstringStatusListRequestObject struct {
+	Did     string `json:"did"`
+	Purpose string `json:"purpose"`
+	Page    int    `json:"page"`
+}
+
+type BitstringStatusListResponseObject interface {
+	VisitBitstringStatusListResponse(w http.ResponseWriter) error
+}
+
+type BitstringStatusList200JSONResponse VerifiableCredential
+
+func (response BitstringStatusList200JSONResponse) VisitBitstringStatusListResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type BitstringStatusListdefaultApplicationProblemPlusJSONResponse struct {
+	Body struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+	StatusCode int
+}
+
+func (response BitstringStatusListdefaultApplicationProblemPlusJSONResponse) VisitBitstringStatusListResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
 	// Get the OAuth2 Authorization Server metadata for the specified subject.
@@ -1777,6 +2138,10 @@ type StrictServerInterface interface {
 	// Presentation Submissions and Verifiable Presentations added.
 	// (POST /internal/auth/v2/accesstoken/introspect_extended)
 	IntrospectAccessTokenExtended(ctx context.Context, request IntrospectAccessTokenExtendedRequestObject) (IntrospectAccessTokenExtendedResponseObject, error)
+	// Revokes an access token or refresh token issued by this node, before it expires.
+	// Subsequent introspection of the token reports it as inactive.
+	// (POST /internal/auth/v2/accesstoken/revoke)
+	RevokeAccessToken(ctx context.Context, request RevokeAccessTokenRequestObject) (RevokeAccessTokenResponseObject, error)
 	// Get the access token from the Nuts node that was requested through /request-user-access-token.
 	// (GET /internal/auth/v2/accesstoken/{sessionID})
 	RetrieveAccessToken(ctx context.Context, request RetrieveAccessTokenRequestObject) (RetrieveAccessTokenResponseObject, error)
@@ -1804,6 +2169,9 @@ type StrictServerInterface interface {
 	// Get the OAuth2 Client metadata
 	// (GET /oauth2/{subjectID}/oauth-client)
 	OAuthClientMetadata(ctx context.Context, request OAuthClientMetadataRequestObject) (OAuthClientMetadataResponseObject, error)
+	// Get the JWK Set of the authorization server of the subject
+	// (GET /oauth2/{subjectID}/jwks)
+	Jwks(ctx context.Context, request JwksRequestObject) (JwksResponseObject, error)
 	// Used by relying parties to obtain a presentation definition for desired scopes as specified by Nuts RFC021.
 	// (GET /oauth2/{subjectID}/presentation_definition)
 	PresentationDefinition(ctx context.Context, request PresentationDefinitionRequestObject) (PresentationDefinitionResponseObject, error)
@@ -1816,12 +2184,21 @@ type StrictServerInterface interface {
 	// Used by wallets to post the authorization response or error to.
 	// (POST /oauth2/{subjectID}/response)
 	HandleAuthorizeResponse(ctx context.Context, request HandleAuthorizeResponseRequestObject) (HandleAuthorizeResponseResponseObject, error)
+	// Used by OAuth2 clients to revoke access- or refresh tokens as described by RFC7009.
+	// (POST /oauth2/{subjectID}/revoke)
+	RevokeToken(ctx context.Context, request RevokeTokenRequestObject) (RevokeTokenResponseObject, error)
 	// Used by the OAuth2 client (backend, not the browser) to request access- or refresh tokens.
 	// (POST /oauth2/{subjectID}/token)
 	HandleTokenRequest(ctx context.Context, request HandleTokenRequestRequestObject) (HandleTokenRequestResponseObject, error)
+	// Used by OAuth2 clients to push an authorization request to the authorization server as described by RFC9126.
+	// (POST /oauth2/{subjectID}/par)
+	PushedAuthorizationRequest(ctx context.Context, request PushedAuthorizationRequestRequestObject) (PushedAuthorizationRequestResponseObject, error)
 	// Get the StatusList2021Credential for the given DID and page
 	// (GET /statuslist/{did}/{page})
 	StatusList(ctx context.Context, request StatusListRequestObject) (StatusListResponseObject, error)
+	// Get the BitstringStatusListCredential for the given DID, status purpose and page
+	// (GET /statuslist/{did}/{purpose}/{page})
+	BitstringStatusList(ctx context.Context, request BitstringStatusListRequestObject) (BitstringStatusListResponseObject, error)
 }
 
 type StrictHandlerFunc = strictecho.StrictEchoHandlerFunc
@@ -1952,6 +2329,39 @@ func (sh *strictHandler) IntrospectAccessTokenExtended(ctx echo.Context) error {
 	return nil
 }
 
+// RevokeAccessToken operation middleware
+func (sh *strictHandler) RevokeAccessToken(ctx echo.Context) error {
+	var request RevokeAccessTokenRequestObject
+
+	if form, err := ctx.FormParams(); err == nil {
+		var body RevokeAccessTokenFormdataRequestBody
+		if err := runtime.BindForm(&body, form, nil, nil); err != nil {
+			return err
+		}
+		request.Body = &body
+	} else {
+		return err
+	}
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.RevokeAccessToken(ctx.Request().Context(), request.(RevokeAccessTokenRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RevokeAccessToken")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(RevokeAccessTokenResponseObject); ok {
+		return validResponse.VisitRevokeAccessTokenResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // RetrieveAccessToken operation middleware
 func (sh *strictHandler) RetrieveAccessToken(ctx echo.Context, sessionID string) error {
 	var request RetrieveAccessTokenRequestObject
@@ -2208,6 +2618,31 @@ func (sh *strictHandler) OAuthClientMetadata(ctx echo.Context, subjectID string)
 	return nil
 }
 
+// Jwks operation middleware
+func (sh *strictHandler) Jwks(ctx echo.Context, subjectID string) error {
+	var request JwksRequestObject
+
+	request.SubjectID = subjectID
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.Jwks(ctx.Request().Context(), request.(JwksRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "Jwks")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(JwksResponseObject); ok {
+		return validResponse.VisitJwksResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // PresentationDefinition operation middleware
 func (sh *strictHandler) PresentationDefinition(ctx echo.Context, subjectID string, params PresentationDefinitionParams) error {
 	var request PresentationDefinitionRequestObject
@@ -2331,6 +2766,41 @@ func (sh *strictHandler) HandleAuthorizeResponse(ctx echo.Context, subjectID str
 	return nil
 }
 
+// RevokeToken operation middleware
+func (sh *strictHandler) RevokeToken(ctx echo.Context, subjectID string) error {
+	var request RevokeTokenRequestObject
+
+	request.SubjectID = subjectID
+
+	if form, err := ctx.FormParams(); err == nil {
+		var body RevokeTokenFormdataRequestBody
+		if err := runtime.BindForm(&body, form, nil, nil); err != nil {
+			return err
+		}
+		request.Body = &body
+	} else {
+		return err
+	}
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.RevokeToken(ctx.Request().Context(), request.(RevokeTokenRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RevokeToken")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(RevokeTokenResponseObject); ok {
+		return validResponse.VisitRevokeTokenResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // HandleTokenRequest operation middleware
 func (sh *strictHandler) HandleTokenRequest(ctx echo.Context, subjectID string) error {
 	var request HandleTokenRequestRequestObject
@@ -2366,6 +2836,41 @@ func (sh *strictHandler) HandleTokenRequest(ctx echo.Context, subjectID string)
 	return nil
 }
 
+// PushedAuthorizationRequest operation middleware
+func (sh *strictHandler) PushedAuthorizationRequest(ctx echo.Context, subjectID string) error {
+	var request PushedAuthorizationRequestRequestObject
+
+	request.SubjectID = subjectID
+
+	if form, err := ctx.FormParams(); err == nil {
+		var body PushedAuthorizationRequestFormdataRequestBody
+		if err := runtime.BindForm(&body, form, nil, nil); err != nil {
+			return err
+		}
+		request.Body = &body
+	} else {
+		return err
+	}
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PushedAuthorizationRequest(ctx.Request().Context(), request.(PushedAuthorizationRequestRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PushedAuthorizationRequest")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PushedAuthorizationRequestResponseObject); ok {
+		return validResponse.VisitPushedAuthorizationRequestResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // StatusList operation middleware
 func (sh *strictHandler) StatusList(ctx echo.Context, did string, page int) error {
 	var request StatusListRequestObject
@@ -2391,3 +2896,30 @@ func (sh *strictHandler) StatusList(ctx echo.Context, did string, page int) erro
 	}
 	return nil
 }
+
+// BitstringStatusList operation middleware
+func (sh *strictHandler) BitstringStatusList(ctx echo.Context, did string, purpose string, page int) error {
+	var request BitstringStatusListRequestObject
+
+	request.Did = did
+	request.Purpose = purpose
+	request.Page = page
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.BitstringStatusList(ctx.Request().Context(), request.(BitstringStatusListRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "BitstringStatusList")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(BitstringStatusListResponseObject); ok {
+		return validResponse.VisitBitstringStatusListResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
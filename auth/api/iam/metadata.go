@@ -53,6 +53,8 @@ func authorizationServerMetadata(issuerURL *url.URL, supportedDIDMethods []strin
 		metadata.Issuer = issuerURL.String()
 		metadata.AuthorizationEndpoint = issuerURL.JoinPath("authorize").String()
 		metadata.PresentationDefinitionEndpoint = issuerURL.JoinPath("presentation_definition").String()
+		metadata.PushedAuthorizationRequestEndpoint = issuerURL.JoinPath("par").String()
+		metadata.RevocationEndpoint = issuerURL.JoinPath("revoke").String()
 		metadata.TokenEndpoint = issuerURL.JoinPath("token").String()
 	}
 	return *metadata
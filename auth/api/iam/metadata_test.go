@@ -42,7 +42,9 @@ func Test_authorizationServerMetadata(t *testing.T) {
 		PreAuthorizedGrantAnonymousAccessSupported: true,
 		PresentationDefinitionEndpoint:             "https://example.com/oauth2/example/presentation_definition",
 		PresentationDefinitionUriSupported:         &presentationDefinitionURISupported,
+		PushedAuthorizationRequestEndpoint:         "https://example.com/oauth2/example/par",
 		RequireSignedRequestObject:                 true,
+		RevocationEndpoint:                         "https://example.com/oauth2/example/revoke",
 		ResponseTypesSupported:                     []string{"code", "vp_token"},
 		ResponseModesSupported:                     []string{"query", "direct_post"},
 		VPFormats:                                  oauth.DefaultOpenIDSupportedFormats(),
@@ -42,10 +42,13 @@ import (
 	"github.com/nuts-foundation/nuts-node/crypto"
 	httpNuts "github.com/nuts-foundation/nuts-node/http"
 	"github.com/nuts-foundation/nuts-node/network/log"
+	"github.com/nuts-foundation/nuts-node/policy"
 	"github.com/nuts-foundation/nuts-node/storage"
 	"github.com/nuts-foundation/nuts-node/vcr/credential"
+	"github.com/nuts-foundation/nuts-node/vcr/dcql"
 	"github.com/nuts-foundation/nuts-node/vcr/holder"
 	"github.com/nuts-foundation/nuts-node/vcr/pe"
+	"github.com/nuts-foundation/nuts-node/vcr/signature/proof"
 	"github.com/nuts-foundation/nuts-node/vdr/didjwk"
 	"github.com/nuts-foundation/nuts-node/vdr/resolver"
 )
@@ -108,10 +111,10 @@ func (r Wrapper) handleAuthorizeRequestFromHolder(ctx context.Context, subject s
 		return nil, oauthError(oauth.InvalidRequest, "invalid value for code_challenge_method parameter, only S256 is supported")
 	}
 
-	// Determine which PEX Presentation Definitions we want to see fulfilled during authorization through OpenID4VP.
-	// Each Presentation Definition triggers 1 OpenID4VP flow.
+	// Determine which PEX Presentation Definitions (or DCQL queries) we want to see fulfilled during authorization through OpenID4VP.
+	// Each Presentation Definition or DCQL query triggers 1 OpenID4VP flow.
 	// TODO: Support multiple scopes?
-	presentationDefinitions, err := r.presentationDefinitionForScope(ctx, params.get(oauth.ScopeParam))
+	verifier, err := r.openID4VPVerifierForScope(policy.Context(ctx, params.get(oauth.ClientIDParam), subject), params.get(oauth.ScopeParam))
 	if err != nil {
 		return nil, withCallbackURI(err, redirectURL)
 	}
@@ -122,7 +125,7 @@ func (r Wrapper) handleAuthorizeRequestFromHolder(ctx context.Context, subject s
 		OwnSubject:        &subject,
 		ClientState:       params.get(oauth.StateParam),
 		RedirectURI:       redirectURL.String(),
-		OpenID4VPVerifier: newPEXConsumer(presentationDefinitions),
+		OpenID4VPVerifier: verifier,
 		PKCEParams: PKCEParams{ // store params, when generating authorization code we take the params from the nonceStore and encrypt them in the authorization code
 			Challenge:       params.get(oauth.CodeChallengeParam),
 			ChallengeMethod: params.get(oauth.CodeChallengeMethodParam),
@@ -158,12 +161,11 @@ func (r Wrapper) nextOpenID4VPFlow(ctx context.Context, state string, session OA
 	// own generic endpoint
 	ownURL := r.subjectToBaseURL(*session.OwnSubject)
 
-	// generate presentation_definition_uri based on own presentation_definition endpoint + scope + wallet owner type
-	pdURL := ownURL.JoinPath("presentation_definition")
-	presentationDefinitionURI := httpNuts.AddQueryParams(*pdURL, map[string]string{
-		"scope":             session.Scope,
-		"wallet_owner_type": string(*walletOwnerType),
-	})
+	// scopes expressed in DCQL pass the query by value, since the presentation_definition endpoint only serves Presentation Definitions
+	var dcqlQuery []byte
+	if query, ok := session.OpenID4VPVerifier.RequiredDCQLQueries[*walletOwnerType]; ok {
+		dcqlQuery, _ = json.Marshal(query)
+	}
 
 	// redirect to wallet authorization endpoint, use direct_post mode
 	// like this or as JAR (RFC9101):
@@ -173,7 +175,7 @@ func (r Wrapper) nextOpenID4VPFlow(ctx context.Context, state string, session OA
 	//    &client_metadata_uri=https%3A%2F%2Fexample.com%2Fiam%2F123%2F%2Fclient_metadata
 	//    &client_id=did:web:example.com:iam:123
 	//    &response_uri=https%3A%2F%2Fexample.com%2Fiam%2F123%2F%2Fresponse
-	//    &presentation_definition_uri=...
+	//    &presentation_definition_uri=... (or &dcql_query=...)
 	//    &response_mode=direct_post
 	//    &nonce=n-0S6_WzA2Mj HTTP/1.1
 	nonce := crypto.GenerateNonce()
@@ -184,7 +186,17 @@ func (r Wrapper) nextOpenID4VPFlow(ctx context.Context, state string, session OA
 		values[oauth.ResponseTypeParam] = oauth.VPTokenResponseType
 		values[oauth.ClientIDSchemeParam] = entityClientIDScheme
 		values[oauth.ResponseURIParam] = callbackURL.String()
-		values[oauth.PresentationDefUriParam] = presentationDefinitionURI.String()
+		if dcqlQuery != nil {
+			values[oauth.DCQLQueryParam] = string(dcqlQuery)
+		} else {
+			// generate presentation_definition_uri based on own presentation_definition endpoint + scope + wallet owner type
+			pdURL := ownURL.JoinPath("presentation_definition")
+			presentationDefinitionURI := httpNuts.AddQueryParams(*pdURL, map[string]string{
+				"scope":             session.Scope,
+				"wallet_owner_type": string(*walletOwnerType),
+			})
+			values[oauth.PresentationDefUriParam] = presentationDefinitionURI.String()
+		}
 		values[oauth.ClientMetadataURIParam] = metadataURL.String()
 		values[oauth.ResponseModeParam] = responseModeDirectPost
 		values[oauth.NonceParam] = nonce
@@ -239,6 +251,7 @@ func (r Wrapper) nextOpenID4VPFlow(ctx context.Context, state string, session OA
 // response_uri, REQUIRED. This must be the verifier node url
 // response_mode, REQUIRED. Value MUST be "direct_post"
 // presentation_definition_uri, REQUIRED. For getting the presentation definition
+// dcql_query, alternative to presentation_definition_uri. Contains the DCQL query the wallet needs to fulfill
 
 // there are way more error conditions that listed at: https://openid.net/specs/openid-4-verifiable-presentations-1_0.html#name-error-response
 // missing or invalid parameters are all mapped to invalid_request
@@ -280,8 +293,14 @@ func (r Wrapper) handleAuthorizeRequestFromVerifier(ctx context.Context, subject
 		return r.sendAndHandleDirectPostError(ctx, *oauth2Err, responseURI, state)
 	}
 
-	// get presentation_definition
-	presentationDefinition, oauth2Err := r.getPresentationDefinitionFromRequest(ctx, params)
+	// get dcql_query or presentation_definition
+	var dcqlQuery *dcql.Query
+	var presentationDefinition *pe.PresentationDefinition
+	if _, ok := params[oauth.DCQLQueryParam]; ok {
+		dcqlQuery, oauth2Err = getDCQLQueryFromRequest(params)
+	} else {
+		presentationDefinition, oauth2Err = r.getPresentationDefinitionFromRequest(ctx, params)
+	}
 	if oauth2Err != nil {
 		return r.sendAndHandleDirectPostError(ctx, *oauth2Err, responseURI, state)
 	}
@@ -297,12 +316,16 @@ func (r Wrapper) handleAuthorizeRequestFromVerifier(ctx context.Context, subject
 	}
 
 	targetWallet := r.vcr.Wallet()
-	candidateDIDs, err := r.subjectManager.ListDIDs(ctx, subject)
+	// the verifier is the party the presentation is made to, so only its did:peer DID (if any) can be presented
+	candidateDIDs, err := r.subjectManager.ListDIDsForRelationship(ctx, subject, buildParams.Audience)
 	if err != nil {
 		return nil, err
 	}
-	// same behaviour as determineClientDID
-	walletDID := candidateDIDs[0]
+	var walletDID did.DID
+	if len(candidateDIDs) > 0 {
+		// same behaviour as determineClientDID
+		walletDID = candidateDIDs[0]
+	}
 	if walletOwnerType == pe.WalletOwnerUser {
 		// User wallet
 		var privateKey jwk.Key
@@ -318,6 +341,19 @@ func (r Wrapper) handleAuthorizeRequestFromVerifier(ctx context.Context, subject
 			map[did.DID][]vc.VerifiableCredential{userSession.Wallet.DID: userSession.Wallet.Credentials},
 		)
 	}
+	if walletDID.Empty() {
+		return r.sendAndHandleDirectPostError(ctx, oauth.OAuth2Error{Code: oauth.InvalidRequest, Description: "wallet has no DID that can be presented to the verifier"}, responseURI, state)
+	}
+	if dcqlQuery != nil {
+		vpToken, err := buildDCQLResponse(ctx, targetWallet, walletDID, *dcqlQuery, buildParams)
+		if err != nil {
+			if errors.Is(err, dcql.ErrNoCredentials) {
+				return r.sendAndHandleDirectPostError(ctx, oauth.OAuth2Error{Code: oauth.InvalidRequest, Description: fmt.Sprintf("wallet could not fulfill requirements (DCQL query, wallet: %s): %s", walletDID, err.Error())}, responseURI, state)
+			}
+			return r.sendAndHandleDirectPostError(ctx, oauth.OAuth2Error{Code: oauth.ServerError, Description: err.Error()}, responseURI, state)
+		}
+		return r.sendAndHandleDCQLDirectPost(ctx, subject, vpToken, responseURI, state)
+	}
 	vp, submission, err := targetWallet.BuildSubmission(ctx, []did.DID{walletDID}, nil, *presentationDefinition, buildParams)
 	if err != nil {
 		if errors.Is(err, pe.ErrNoCredentials) {
@@ -371,6 +407,67 @@ func (r Wrapper) getPresentationDefinitionFromRequest(ctx context.Context, param
 	return presentationDefinition, nil
 }
 
+// getDCQLQueryFromRequest parses the dcql_query parameter of the authorization request.
+// It's passed as JSON string in a query parameter, or as JSON object in a request object (JAR).
+func getDCQLQueryFromRequest(params oauthParameters) (*dcql.Query, *oauth.OAuth2Error) {
+	if params.get(oauth.PresentationDefParam) != "" || params.get(oauth.PresentationDefUriParam) != "" {
+		return nil, &oauth.OAuth2Error{Code: oauth.InvalidRequest, Description: "dcql_query and presentation_definition(_uri) are mutually exclusive"}
+	}
+	queryJSON := []byte(params.get(oauth.DCQLQueryParam))
+	if len(queryJSON) == 0 {
+		queryJSON, _ = json.Marshal(params[oauth.DCQLQueryParam])
+	}
+	query, err := dcql.Parse(queryJSON)
+	if err != nil {
+		return nil, &oauth.OAuth2Error{Code: oauth.InvalidRequest, Description: "invalid dcql_query", InternalError: err}
+	}
+	return query, nil
+}
+
+// buildDCQLResponse selects the wallet's credentials that fulfill the DCQL query and presents each of them in a Verifiable Presentation.
+// It returns dcql.ErrNoCredentials if the wallet doesn't contain the credentials to fulfill the query.
+func buildDCQLResponse(ctx context.Context, wallet holder.Wallet, walletDID did.DID, query dcql.Query, params holder.BuildParams) (dcql.VPToken, error) {
+	credentials, err := wallet.List(ctx, walletDID)
+	if err != nil {
+		return nil, err
+	}
+	matches, err := query.Match(credentials)
+	if err != nil {
+		return nil, err
+	}
+	// Find supported VP format, matching support from:
+	// - what the local Nuts node supports
+	// - the verifier's metadata (optional)
+	formatCandidates := credential.OpenIDSupportedFormats(oauth.DefaultOpenIDSupportedFormats())
+	formatCandidates = formatCandidates.Match(credential.OpenIDSupportedFormats(params.Format))
+	format := pe.ChooseVPFormat(formatCandidates.Map)
+	if format == "" {
+		return nil, errors.New("requester and verifier (authorization server metadata) don't share a supported VP format")
+	}
+	holderURI := walletDID.URI()
+	vpToken := dcql.VPToken{}
+	for credentialQueryID, creds := range matches {
+		for _, cred := range creds {
+			vp, err := wallet.BuildPresentation(ctx, []vc.VerifiableCredential{cred}, holder.PresentationOptions{
+				Format: format,
+				Holder: &holderURI,
+				ProofOptions: proof.ProofOptions{
+					Created:   time.Now(),
+					Challenge: &params.Nonce,
+					Domain:    &params.Audience,
+					Expires:   &params.Expires,
+					Nonce:     &params.Nonce,
+				},
+			}, &walletDID, false)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create verifiable presentation: %w", err)
+			}
+			vpToken[credentialQueryID] = append(vpToken[credentialQueryID], *vp)
+		}
+	}
+	return vpToken, nil
+}
+
 // sendAndHandleDirectPost sends OpenID4VP direct_post to the verifier. The verifier responds with a redirect to the client (including error fields if needed).
 // If the direct post fails, the user-agent will be redirected back to the client with an error. (Original redirect_uri).
 func (r Wrapper) sendAndHandleDirectPost(ctx context.Context, subject string, vp vc.VerifiablePresentation, presentationSubmission pe.PresentationSubmission, verifierResponseURI string, state string) (HandleAuthorizeRequestResponseObject, error) {
@@ -378,6 +475,21 @@ func (r Wrapper) sendAndHandleDirectPost(ctx context.Context, subject string, vp
 	if err != nil {
 		return nil, err
 	}
+	return r.handleDirectPostRedirect(ctx, subject, redirectURI)
+}
+
+// sendAndHandleDCQLDirectPost sends an OpenID4VP direct_post containing a DCQL VP Token to the verifier.
+// It handles the verifier's response the same way as sendAndHandleDirectPost.
+func (r Wrapper) sendAndHandleDCQLDirectPost(ctx context.Context, subject string, vpToken dcql.VPToken, verifierResponseURI string, state string) (HandleAuthorizeRequestResponseObject, error) {
+	redirectURI, err := r.auth.IAMClient().PostDCQLAuthorizationResponse(ctx, vpToken, verifierResponseURI, state)
+	if err != nil {
+		return nil, err
+	}
+	return r.handleDirectPostRedirect(ctx, subject, redirectURI)
+}
+
+// handleDirectPostRedirect handles the redirect URI the verifier responded with to a direct_post.
+func (r Wrapper) handleDirectPostRedirect(ctx context.Context, subject string, redirectURI string) (HandleAuthorizeRequestResponseObject, error) {
 	// Redirect URI starting with openid4vp: is a signal from the OpenID4VP verifier
 	// that it requires another Verifiable Presentation, but this time from a user wallet.
 	if strings.HasPrefix(redirectURI, "openid4vp:") {
@@ -485,16 +597,31 @@ func (r Wrapper) handleAuthorizeResponseSubmission(ctx context.Context, request
 		return nil, oauthError(oauth.InvalidRequest, "missing vp_token")
 	}
 
-	pexEnvelope, err := pe.ParseEnvelope([]byte(*request.Body.VpToken))
-	if err != nil || len(pexEnvelope.Presentations) == 0 {
-		return nil, oauthError(oauth.InvalidRequest, "invalid vp_token", err)
-	}
-
 	// Retrieve session through state, since we need to update it given the state.
+	// The session determines whether the vp_token is a DCQL VP Token or a Presentation Exchange envelope.
 	var session OAuthSession
 	state := *request.Body.State
-	if err = r.oauthClientStateStore().Get(state, &session); err != nil {
-		return nil, oauthError(oauth.InvalidRequest, "invalid or expired session", err)
+	sessionErr := r.oauthClientStateStore().Get(state, &session)
+
+	var presentations []vc.VerifiablePresentation
+	var pexEnvelope *pe.Envelope
+	var vpToken dcql.VPToken
+	var err error
+	if sessionErr == nil && session.OpenID4VPVerifier != nil && len(session.OpenID4VPVerifier.RequiredDCQLQueries) > 0 {
+		vpToken, err = dcql.ParseVPToken([]byte(*request.Body.VpToken))
+		if err != nil {
+			return nil, oauthError(oauth.InvalidRequest, "invalid vp_token", err)
+		}
+		presentations = vpToken.Presentations()
+	} else {
+		pexEnvelope, err = pe.ParseEnvelope([]byte(*request.Body.VpToken))
+		if err != nil || len(pexEnvelope.Presentations) == 0 {
+			return nil, oauthError(oauth.InvalidRequest, "invalid vp_token", err)
+		}
+		presentations = pexEnvelope.Presentations
+	}
+	if sessionErr != nil {
+		return nil, oauthError(oauth.InvalidRequest, "invalid or expired session", sessionErr)
 	}
 	if request.SubjectID != *session.OwnSubject {
 		return nil, oauthError(oauth.InvalidRequest, "incorrect tenant", fmt.Errorf("expected: %s, was: %s", *session.OwnSubject, request.SubjectID))
@@ -506,16 +633,20 @@ func (r Wrapper) handleAuthorizeResponseSubmission(ctx context.Context, request
 
 	// check presence of the nonce and make sure the nonce is burned in the process.
 	// Also asserts that nonce and state reference the same OAuthSession.
-	if err = r.validatePresentationNonce(pexEnvelope.Presentations, state); err != nil {
+	if err = r.validatePresentationNonce(presentations, state); err != nil {
 		return nil, withCallbackURI(err, callbackURI)
 	}
 
-	if request.Body.PresentationSubmission == nil {
-		return nil, oauthError(oauth.InvalidRequest, "missing presentation_submission")
-	}
-	submission, err := pe.ParsePresentationSubmission([]byte(*request.Body.PresentationSubmission))
-	if err != nil {
-		return nil, withCallbackURI(oauthError(oauth.InvalidRequest, fmt.Sprintf("invalid presentation_submission: %s", err.Error())), callbackURI)
+	// DCQL VP Tokens don't have a presentation_submission, the credential queries are identified by the VP Token's keys.
+	var submission *pe.PresentationSubmission
+	if pexEnvelope != nil {
+		if request.Body.PresentationSubmission == nil {
+			return nil, oauthError(oauth.InvalidRequest, "missing presentation_submission")
+		}
+		submission, err = pe.ParsePresentationSubmission([]byte(*request.Body.PresentationSubmission))
+		if err != nil {
+			return nil, withCallbackURI(oauthError(oauth.InvalidRequest, fmt.Sprintf("invalid presentation_submission: %s", err.Error())), callbackURI)
+		}
 	}
 
 	// validate all presentations:
@@ -523,7 +654,7 @@ func (r Wrapper) handleAuthorizeResponseSubmission(ctx context.Context, request
 	// - same audience for VPs
 	// - same signer
 	var credentialSubjectID did.DID
-	for _, presentation := range pexEnvelope.Presentations {
+	for _, presentation := range presentations {
 		if subjectDID, err := validatePresentationSigner(presentation, credentialSubjectID); err != nil {
 			return nil, withCallbackURI(oauthError(oauth.InvalidRequest, err.Error()), callbackURI)
 		} else {
@@ -534,8 +665,8 @@ func (r Wrapper) handleAuthorizeResponseSubmission(ctx context.Context, request
 		}
 	}
 
-	// Check signatures of VP and VCs. Trust should be established by the Presentation Definition.
-	for _, presentation := range pexEnvelope.Presentations {
+	// Check signatures of VP and VCs. Trust should be established by the Presentation Definition (or DCQL query).
+	for _, presentation := range presentations {
 		_, err = r.vcr.Verifier().VerifyVP(presentation, true, true, nil)
 		if err != nil {
 			return nil, oauth.OAuth2Error{
@@ -548,7 +679,12 @@ func (r Wrapper) handleAuthorizeResponseSubmission(ctx context.Context, request
 	}
 	// we take the existing OAuthSession and add the credential map to it
 	// todo: use the InputDescriptor.Path to map the Id to Value@JSONPath since this will be later used to set the state for the access token
-	if err := session.OpenID4VPVerifier.fulfill(*submission, *pexEnvelope); err != nil {
+	if pexEnvelope != nil {
+		err = session.OpenID4VPVerifier.fulfill(*submission, *pexEnvelope)
+	} else {
+		err = session.OpenID4VPVerifier.fulfillDCQL(vpToken)
+	}
+	if err != nil {
 		return nil, oauthError(oauth.InvalidRequest, err.Error())
 	}
 	if err = r.oauthClientStateStore().Put(state, session); err != nil {
@@ -716,8 +852,7 @@ func (r Wrapper) handleAccessTokenRequest(ctx context.Context, request HandleTok
 	}
 
 	// All done, issue access token
-	issuerURL := r.subjectToBaseURL(*oauthSession.OwnSubject)
-	response, err := r.createAccessToken(issuerURL.String(), oauthSession.ClientID, time.Now(), oauthSession.Scope, *oauthSession.OpenID4VPVerifier, dpopProof)
+	response, err := r.createAccessToken(ctx, *oauthSession.OwnSubject, oauthSession.ClientID, time.Now(), oauthSession.Scope, *oauthSession.OpenID4VPVerifier, dpopProof)
 	if err != nil {
 		return nil, oauthError(oauth.ServerError, fmt.Sprintf("failed to create access token: %s", err.Error()))
 	}
@@ -778,7 +913,7 @@ func verificationErrorDescription(err error) string {
 		return err.Error()
 	}
 	if errors.Is(err, resolver.ErrNotFound) || errors.Is(err, resolver.ErrKeyNotFound) || strings.Contains(err.Error(), "unable to resolve") ||
-		errors.Is(err, types.ErrStatusNotFound) || errors.Is(err, types.ErrRevoked) || errors.Is(err, types.ErrCredentialNotValidAtTime) || errors.Is(err, types.ErrPresentationNotValidAtTime) {
+		errors.Is(err, types.ErrStatusNotFound) || errors.Is(err, types.ErrRevoked) || errors.Is(err, types.ErrSuspended) || errors.Is(err, types.ErrCredentialNotValidAtTime) || errors.Is(err, types.ErrPresentationNotValidAtTime) {
 		return verifier.ToVerificationError(err).Error()
 	}
 
@@ -32,12 +32,15 @@ import (
 	"github.com/nuts-foundation/nuts-node/vcr/verifier"
 
 	"github.com/lestrrat-go/jwx/v2/jwt"
+	ssi "github.com/nuts-foundation/go-did"
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/go-did/vc"
 	"github.com/nuts-foundation/nuts-node/auth/oauth"
 	"github.com/nuts-foundation/nuts-node/policy"
 	"github.com/nuts-foundation/nuts-node/storage"
 	"github.com/nuts-foundation/nuts-node/test"
+	"github.com/nuts-foundation/nuts-node/vcr/dcql"
+	"github.com/nuts-foundation/nuts-node/vcr/holder"
 	"github.com/nuts-foundation/nuts-node/vcr/pe"
 	"github.com/nuts-foundation/nuts-node/vdr/resolver"
 	"github.com/stretchr/testify/assert"
@@ -53,6 +56,8 @@ var holderClientID = holderURL.String()
 var issuerClientID = issuerURL.String()
 var issuerSubjectID = "issuer"
 
+var dcqlOrganizationQuery = dcql.Query{Credentials: []dcql.CredentialQuery{{ID: "organization", Format: dcql.FormatLDPVC}}}
+
 func TestWrapper_handleAuthorizeRequestFromHolder(t *testing.T) {
 	defaultParams := func() oauthParameters {
 		return map[string]interface{}{
@@ -140,6 +145,32 @@ func TestWrapper_handleAuthorizeRequestFromHolder(t *testing.T) {
 
 		requireOAuthError(t, err, oauth.ServerError, "failed to authorize client")
 	})
+	t.Run("ok - scope expressed in DCQL", func(t *testing.T) {
+		ctx := newTestClient(t)
+		ctx.dcqlQueries["test"] = dcql.WalletOwnerMapping{pe.WalletOwnerOrganization: dcqlOrganizationQuery}
+		params := defaultParams()
+		ctx.iamClient.EXPECT().OpenIDConfiguration(context.Background(), holderClientID).Return(&oauth.OpenIDConfiguration{
+			Metadata: oauth.EntityStatementMetadata{
+				OpenIDProvider: oauth.AuthorizationServerMetadata{
+					AuthorizationEndpoint:      "https://example.com/authorize",
+					ClientIdSchemesSupported:   []string{entityClientIDScheme},
+					RequireSignedRequestObject: true,
+				},
+			},
+		}, nil)
+		var requestObject jarRequest
+		ctx.jar.EXPECT().Create(verifierDID, verifierURL.String(), "", gomock.Any()).DoAndReturn(func(client did.DID, clientID string, audience string, modifier requestObjectModifier) jarRequest {
+			requestObject = createJarRequest(client, clientID, audience, modifier)
+			return requestObject
+		})
+
+		response, err := ctx.client.handleAuthorizeRequestFromHolder(context.Background(), verifierSubject, params)
+
+		require.NoError(t, err)
+		assert.IsType(t, HandleAuthorizeRequest302Response{}, response)
+		assert.JSONEq(t, `{"credentials":[{"id":"organization","format":"ldp_vc"}]}`, requestObject.Claims.get(oauth.DCQLQueryParam))
+		assert.Empty(t, requestObject.Claims.get(oauth.PresentationDefUriParam))
+	})
 	t.Run("failed to resolve OpenID configuration", func(t *testing.T) {
 		ctx := newTestClient(t)
 		ctx.policy.EXPECT().PresentationDefinitions(gomock.Any(), "test").Return(pe.WalletOwnerMapping{pe.WalletOwnerOrganization: PresentationDefinition{}}, nil)
@@ -352,6 +383,78 @@ func TestWrapper_handleAuthorizeRequestFromVerifier(t *testing.T) {
 
 		require.NoError(t, err)
 	})
+	t.Run("DCQL", func(t *testing.T) {
+		dcqlParams := func() map[string]interface{} {
+			params := defaultParams()
+			delete(params, oauth.PresentationDefUriParam)
+			params[oauth.DCQLQueryParam] = `{"credentials":[{"id":"organization","format":"ldp_vc"}]}`
+			return params
+		}
+		credential := vc.VerifiableCredential{Issuer: issuerDID.URI()}
+		t.Run("ok", func(t *testing.T) {
+			ctx := newTestClient(t)
+			putState(ctx, "state", authzCodeSession)
+			ctx.iamClient.EXPECT().ClientMetadata(gomock.Any(), "https://example.com/.well-known/authorization-server/iam/verifier").Return(&clientMetadata, nil)
+			ctx.wallet.EXPECT().List(gomock.Any(), holderDID).Return([]vc.VerifiableCredential{credential}, nil)
+			vp := vc.VerifiablePresentation{Type: []ssi.URI{ssi.MustParseURI("VerifiablePresentation")}}
+			ctx.wallet.EXPECT().BuildPresentation(gomock.Any(), []vc.VerifiableCredential{credential}, gomock.Any(), &holderDID, false).DoAndReturn(func(_ context.Context, _ []vc.VerifiableCredential, options holder.PresentationOptions, _ *did.DID, _ bool) (*vc.VerifiablePresentation, error) {
+				assert.Equal(t, "nonce", *options.ProofOptions.Nonce)
+				assert.Equal(t, verifierDID.String(), *options.ProofOptions.Domain)
+				return &vp, nil
+			})
+			ctx.iamClient.EXPECT().PostDCQLAuthorizationResponse(gomock.Any(), dcql.VPToken{"organization": {vp}}, responseURI, "state").Return("https://example.com/iam/holder/redirect", nil)
+
+			response, err := ctx.client.handleAuthorizeRequestFromVerifier(httpRequestCtx, holderSubjectID, dcqlParams(), pe.WalletOwnerOrganization)
+
+			require.NoError(t, err)
+			assert.Equal(t, "https://example.com/iam/holder/redirect", response.(HandleAuthorizeRequest302Response).Headers.Location)
+		})
+		t.Run("dcql_query and presentation_definition_uri are mutually exclusive", func(t *testing.T) {
+			ctx := newTestClient(t)
+			ctx.iamClient.EXPECT().ClientMetadata(gomock.Any(), "https://example.com/.well-known/authorization-server/iam/verifier").Return(&clientMetadata, nil)
+			params := dcqlParams()
+			params[oauth.PresentationDefUriParam] = pdEndpoint
+			expectPostError(t, ctx, oauth.InvalidRequest, "dcql_query and presentation_definition(_uri) are mutually exclusive", responseURI, "state")
+
+			_, err := ctx.client.handleAuthorizeRequestFromVerifier(httpRequestCtx, holderSubjectID, params, pe.WalletOwnerOrganization)
+
+			require.NoError(t, err)
+		})
+		t.Run("invalid dcql_query", func(t *testing.T) {
+			ctx := newTestClient(t)
+			ctx.iamClient.EXPECT().ClientMetadata(gomock.Any(), "https://example.com/.well-known/authorization-server/iam/verifier").Return(&clientMetadata, nil)
+			params := dcqlParams()
+			params[oauth.DCQLQueryParam] = `{"credentials":[]}`
+			expectPostError(t, ctx, oauth.InvalidRequest, "invalid dcql_query", responseURI, "state")
+
+			_, err := ctx.client.handleAuthorizeRequestFromVerifier(httpRequestCtx, holderSubjectID, params, pe.WalletOwnerOrganization)
+
+			require.NoError(t, err)
+		})
+		t.Run("missing credentials in wallet", func(t *testing.T) {
+			ctx := newTestClient(t)
+			putState(ctx, "state", authzCodeSession)
+			ctx.iamClient.EXPECT().ClientMetadata(gomock.Any(), "https://example.com/.well-known/authorization-server/iam/verifier").Return(&clientMetadata, nil)
+			ctx.wallet.EXPECT().List(gomock.Any(), holderDID).Return(nil, nil)
+			expectPostError(t, ctx, oauth.InvalidRequest, "wallet could not fulfill requirements (DCQL query, wallet: did:web:example.com:iam:holder): missing credentials\ncredential queries not satisfied: organization", responseURI, "state")
+
+			_, err := ctx.client.handleAuthorizeRequestFromVerifier(httpRequestCtx, holderSubjectID, dcqlParams(), pe.WalletOwnerOrganization)
+
+			require.NoError(t, err)
+		})
+		t.Run("failed to create verifiable presentation", func(t *testing.T) {
+			ctx := newTestClient(t)
+			putState(ctx, "state", authzCodeSession)
+			ctx.iamClient.EXPECT().ClientMetadata(gomock.Any(), "https://example.com/.well-known/authorization-server/iam/verifier").Return(&clientMetadata, nil)
+			ctx.wallet.EXPECT().List(gomock.Any(), holderDID).Return([]vc.VerifiableCredential{credential}, nil)
+			ctx.wallet.EXPECT().BuildPresentation(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), false).Return(nil, assert.AnError)
+			expectPostError(t, ctx, oauth.ServerError, "failed to create verifiable presentation: "+assert.AnError.Error(), responseURI, "state")
+
+			_, err := ctx.client.handleAuthorizeRequestFromVerifier(httpRequestCtx, holderSubjectID, dcqlParams(), pe.WalletOwnerOrganization)
+
+			require.NoError(t, err)
+		})
+	})
 	t.Run("missing credentials in wallet", func(t *testing.T) {
 		ctx := newTestClient(t)
 		params := defaultParams()
@@ -580,6 +683,78 @@ func TestWrapper_HandleAuthorizeResponse(t *testing.T) {
 			_ = assertOAuthError(t, err, "presentation submission does not conform to presentation definition (id=1)")
 		})
 	})
+	t.Run("DCQL submission", func(t *testing.T) {
+		challenge := "challenge"
+		vp := `{"type":"VerifiablePresentation", "verifiableCredential":{"type":"VerifiableCredential", "credentialSubject":{"id":"did:web:example.com:iam:holder"}},"proof":{"challenge":"challenge","domain":"https://example.com/oauth2/verifier","proofPurpose":"assertionMethod","type":"JsonWebSignature2020","verificationMethod":"did:web:example.com:iam:holder#0"}}`
+		vpToken := `{"organization":[` + vp + `]}`
+		state := "state"
+		dcqlSession := func(queries dcql.WalletOwnerMapping) OAuthSession {
+			result := session
+			result.OpenID4VPVerifier = newDCQLConsumer(queries)
+			return result
+		}
+		baseRequest := func() HandleAuthorizeResponseRequestObject {
+			return HandleAuthorizeResponseRequestObject{
+				Body: &HandleAuthorizeResponseFormdataRequestBody{
+					VpToken: &vpToken,
+					State:   &state,
+				},
+				SubjectID: verifierSubject,
+			}
+		}
+		t.Run("ok - all DCQL queries fulfilled - code issued", func(t *testing.T) {
+			ctx := newTestClient(t)
+			putState(ctx, "state", dcqlSession(dcql.WalletOwnerMapping{pe.WalletOwnerOrganization: dcqlOrganizationQuery}))
+			putNonce(ctx, challenge)
+			ctx.vcVerifier.EXPECT().VerifyVP(gomock.Any(), true, true, nil).Return(nil, nil)
+
+			response, err := ctx.client.HandleAuthorizeResponse(context.Background(), baseRequest())
+
+			require.NoError(t, err)
+			redirectURI, _ := url.Parse(response.(HandleAuthorizeResponse200JSONResponse).RedirectURI)
+			assert.True(t, redirectURI.Query().Has("code"))
+			assert.Equal(t, "client-state", redirectURI.Query().Get("state"))
+		})
+		t.Run("ok - another DCQL query needs to be fulfilled", func(t *testing.T) {
+			ctx := newTestClient(t)
+			putState(ctx, "state", dcqlSession(dcql.WalletOwnerMapping{
+				pe.WalletOwnerOrganization: dcqlOrganizationQuery,
+				pe.WalletOwnerUser:         dcqlOrganizationQuery,
+			}))
+			putNonce(ctx, challenge)
+			ctx.vcVerifier.EXPECT().VerifyVP(gomock.Any(), true, true, nil).Return(nil, nil)
+			ctx.jar.EXPECT().Create(verifierDID, verifierURL.String(), "", gomock.Any()).DoAndReturn(createJarRequest)
+
+			response, err := ctx.client.HandleAuthorizeResponse(context.Background(), baseRequest())
+
+			require.NoError(t, err)
+			actualRedirectURL, _ := url.Parse(response.(HandleAuthorizeResponse200JSONResponse).RedirectURI)
+			assert.True(t, strings.HasPrefix(actualRedirectURL.String(), "openid4vp:"))
+			assert.JSONEq(t, `{"credentials":[{"id":"organization","format":"ldp_vc"}]}`, actualRedirectURL.Query().Get(oauth.DCQLQueryParam))
+			assert.False(t, actualRedirectURL.Query().Has(oauth.PresentationDefUriParam))
+		})
+		t.Run("invalid vp_token", func(t *testing.T) {
+			ctx := newTestClient(t)
+			putState(ctx, "state", dcqlSession(dcql.WalletOwnerMapping{pe.WalletOwnerOrganization: dcqlOrganizationQuery}))
+			request := baseRequest()
+			request.Body.VpToken = &vp
+
+			_, err := ctx.client.HandleAuthorizeResponse(context.Background(), request)
+
+			requireOAuthError(t, err, oauth.InvalidRequest, "invalid vp_token")
+		})
+		t.Run("vp_token does not conform to DCQL query", func(t *testing.T) {
+			ctx := newTestClient(t)
+			query := dcql.Query{Credentials: []dcql.CredentialQuery{{ID: "organization", Format: dcql.FormatJWTVC}}}
+			putState(ctx, "state", dcqlSession(dcql.WalletOwnerMapping{pe.WalletOwnerOrganization: query}))
+			putNonce(ctx, challenge)
+			ctx.vcVerifier.EXPECT().VerifyVP(gomock.Any(), true, true, nil).Return(nil, nil)
+
+			_, err := ctx.client.HandleAuthorizeResponse(context.Background(), baseRequest())
+
+			requireOAuthError(t, err, oauth.InvalidRequest, "vp_token does not conform to DCQL query (wallet owner type=organization): presentation for credential query 'organization' contains a credential that does not match")
+		})
+	})
 	t.Run("error", func(t *testing.T) {
 		code := string(oauth.InvalidRequest)
 		description := "error description"
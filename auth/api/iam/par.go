@@ -0,0 +1,125 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package iam
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/nuts-foundation/nuts-node/auth/oauth"
+	nutsCrypto "github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/nuts-foundation/nuts-node/storage"
+)
+
+// pushedAuthorizationRequestValidity is the time a request_uri returned by the pushed authorization request endpoint can be used.
+const pushedAuthorizationRequestValidity = 60 * time.Second
+
+var pushedAuthorizationRequestKey = []string{"oauth", "par"}
+
+// pushedAuthorizationRequest is a validated authorization request that was pushed to the authorization server of Subject by the client.
+type pushedAuthorizationRequest struct {
+	Subject  string          `json:"subject"`
+	ClientID string          `json:"client_id"`
+	Params   oauthParameters `json:"params"`
+}
+
+// PushedAuthorizationRequest handles pushed authorization requests as specified by RFC9126.
+// The request must contain a signed request object, which is validated as if it were sent to the authorization endpoint.
+// The returned request_uri can be used once in an authorization request of the same client.
+func (r Wrapper) PushedAuthorizationRequest(ctx context.Context, request PushedAuthorizationRequestRequestObject) (PushedAuthorizationRequestResponseObject, error) {
+	if !r.auth.AuthorizationEndpointEnabled() {
+		return nil, oauth.OAuth2Error{
+			Code:        oauth.InvalidRequest,
+			Description: "authorization endpoint is disabled",
+		}
+	}
+	if err := r.subjectExists(ctx, request.SubjectID); err != nil {
+		return nil, err
+	}
+	if request.Body == nil || request.Body.ClientId == "" {
+		return nil, oauthError(oauth.InvalidRequest, "missing required parameters")
+	}
+	if request.Body.Request == nil {
+		// require_signed_request_object is true, so we reject anything that isn't
+		return nil, oauthError(oauth.InvalidRequest, "pushed authorization requests are required to use signed request objects (RFC9101)")
+	}
+	issuerURL := r.subjectToBaseURL(request.SubjectID)
+	metadata, err := r.oauthAuthorizationServerMetadata(issuerURL)
+	if err != nil {
+		return nil, err
+	}
+	params, err := r.jar.Parse(ctx, *metadata, url.Values{
+		oauth.ClientIDParam: {request.Body.ClientId},
+		oauth.RequestParam:  {*request.Body.Request},
+	})
+	if err != nil {
+		// already an oauth.OAuth2Error
+		return nil, err
+	}
+	// RFC9126 section 2.1: the request_uri parameter must not be provided
+	if params.get(oauth.RequestURIParam) != "" {
+		return nil, oauthError(oauth.InvalidRequest, "request_uri is not allowed in a pushed authorization request")
+	}
+
+	reference := nutsCrypto.GenerateNonce()
+	err = r.pushedAuthorizationRequestStore().Put(reference, pushedAuthorizationRequest{
+		Subject:  request.SubjectID,
+		ClientID: request.Body.ClientId,
+		Params:   params,
+	})
+	if err != nil {
+		return nil, oauthError(oauth.ServerError, "unable to store pushed authorization request", err)
+	}
+	return PushedAuthorizationRequest201JSONResponse{
+		RequestURI: oauth.PushedAuthorizationRequestURIPrefix + reference,
+		ExpiresIn:  int(pushedAuthorizationRequestValidity.Seconds()),
+	}, nil
+}
+
+// isPushedAuthorizationRequest returns true if the authorization request refers to a pushed authorization request.
+func isPushedAuthorizationRequest(query url.Values) bool {
+	return strings.HasPrefix(query.Get(oauth.RequestURIParam), oauth.PushedAuthorizationRequestURIPrefix)
+}
+
+// loadPushedAuthorizationRequest returns the parameters of the pushed authorization request the authorization request refers to.
+// The pushed authorization request is removed, so its request_uri can't be used again.
+func (r Wrapper) loadPushedAuthorizationRequest(subject string, query url.Values) (oauthParameters, error) {
+	reference := strings.TrimPrefix(query.Get(oauth.RequestURIParam), oauth.PushedAuthorizationRequestURIPrefix)
+	var pushedRequest pushedAuthorizationRequest
+	if err := r.pushedAuthorizationRequestStore().GetAndDelete(reference, &pushedRequest); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, oauthError(oauth.InvalidRequestURI, "request_uri is invalid or expired")
+		}
+		return nil, oauthError(oauth.ServerError, "unable to retrieve pushed authorization request", err)
+	}
+	if pushedRequest.Subject != subject {
+		return nil, oauthError(oauth.InvalidRequestURI, "request_uri is invalid or expired")
+	}
+	if pushedRequest.ClientID != query.Get(oauth.ClientIDParam) {
+		return nil, oauthError(oauth.InvalidRequest, "client_id does not match the pushed authorization request")
+	}
+	return pushedRequest.Params, nil
+}
+
+func (r Wrapper) pushedAuthorizationRequestStore() storage.SessionStore {
+	return r.storageEngine.GetSessionDatabase().GetStore(pushedAuthorizationRequestValidity, pushedAuthorizationRequestKey...)
+}
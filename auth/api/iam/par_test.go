@@ -0,0 +1,196 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package iam
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/nuts-foundation/nuts-node/auth/oauth"
+	"github.com/nuts-foundation/nuts-node/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestWrapper_PushedAuthorizationRequest(t *testing.T) {
+	requestParams := oauthParameters{
+		oauth.ClientIDParam:     holderClientID,
+		oauth.ResponseTypeParam: oauth.CodeResponseType,
+		oauth.ScopeParam:        "test",
+	}
+	newRequest := func() PushedAuthorizationRequestRequestObject {
+		requestObject := "signed-request"
+		return PushedAuthorizationRequestRequestObject{
+			SubjectID: verifierSubject,
+			Body: &PushedAuthorizationRequestFormdataRequestBody{
+				ClientId: holderClientID,
+				Request:  &requestObject,
+			},
+		}
+	}
+	t.Run("ok", func(t *testing.T) {
+		ctx := newTestClient(t)
+		ctx.jar.EXPECT().Parse(gomock.Any(), gomock.Any(), url.Values{
+			oauth.ClientIDParam: {holderClientID},
+			oauth.RequestParam:  {"signed-request"},
+		}).Return(requestParams, nil)
+
+		response, err := ctx.client.PushedAuthorizationRequest(context.Background(), newRequest())
+
+		require.NoError(t, err)
+		require.IsType(t, PushedAuthorizationRequest201JSONResponse{}, response)
+		result := response.(PushedAuthorizationRequest201JSONResponse)
+		assert.True(t, strings.HasPrefix(result.RequestURI, "urn:ietf:params:oauth:request_uri:"))
+		assert.Equal(t, 60, result.ExpiresIn)
+		var stored pushedAuthorizationRequest
+		require.NoError(t, ctx.client.pushedAuthorizationRequestStore().Get(strings.TrimPrefix(result.RequestURI, oauth.PushedAuthorizationRequestURIPrefix), &stored))
+		assert.Equal(t, verifierSubject, stored.Subject)
+		assert.Equal(t, holderClientID, stored.ClientID)
+		assert.Equal(t, "test", stored.Params.get(oauth.ScopeParam))
+	})
+	t.Run("authorization endpoint disabled", func(t *testing.T) {
+		ctx := newCustomTestClient(t, verifierURL, false)
+
+		response, err := ctx.client.PushedAuthorizationRequest(context.Background(), newRequest())
+
+		requireOAuthError(t, err, oauth.InvalidRequest, "authorization endpoint is disabled")
+		assert.Nil(t, response)
+	})
+	t.Run("unknown subject", func(t *testing.T) {
+		ctx := newTestClient(t)
+		request := newRequest()
+		request.SubjectID = unknownSubjectID
+
+		response, err := ctx.client.PushedAuthorizationRequest(context.Background(), request)
+
+		requireOAuthError(t, err, oauth.InvalidRequest, "subject not found")
+		assert.Nil(t, response)
+	})
+	t.Run("missing client_id", func(t *testing.T) {
+		ctx := newTestClient(t)
+		request := newRequest()
+		request.Body.ClientId = ""
+
+		response, err := ctx.client.PushedAuthorizationRequest(context.Background(), request)
+
+		requireOAuthError(t, err, oauth.InvalidRequest, "missing required parameters")
+		assert.Nil(t, response)
+	})
+	t.Run("unsigned request", func(t *testing.T) {
+		ctx := newTestClient(t)
+		request := newRequest()
+		request.Body.Request = nil
+
+		response, err := ctx.client.PushedAuthorizationRequest(context.Background(), request)
+
+		requireOAuthError(t, err, oauth.InvalidRequest, "pushed authorization requests are required to use signed request objects (RFC9101)")
+		assert.Nil(t, response)
+	})
+	t.Run("invalid request object", func(t *testing.T) {
+		ctx := newTestClient(t)
+		ctx.jar.EXPECT().Parse(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, oauth.OAuth2Error{Code: oauth.InvalidRequestObject, Description: "request signature validation failed"})
+
+		response, err := ctx.client.PushedAuthorizationRequest(context.Background(), newRequest())
+
+		requireOAuthError(t, err, oauth.InvalidRequestObject, "request signature validation failed")
+		assert.Nil(t, response)
+	})
+	t.Run("request object contains request_uri", func(t *testing.T) {
+		ctx := newTestClient(t)
+		ctx.jar.EXPECT().Parse(gomock.Any(), gomock.Any(), gomock.Any()).Return(oauthParameters{
+			oauth.ClientIDParam:   holderClientID,
+			oauth.RequestURIParam: "https://example.com/request.jwt/1",
+		}, nil)
+
+		response, err := ctx.client.PushedAuthorizationRequest(context.Background(), newRequest())
+
+		requireOAuthError(t, err, oauth.InvalidRequest, "request_uri is not allowed in a pushed authorization request")
+		assert.Nil(t, response)
+	})
+}
+
+func TestWrapper_loadPushedAuthorizationRequest(t *testing.T) {
+	const reference = "ref"
+	pushedRequest := pushedAuthorizationRequest{
+		Subject:  verifierSubject,
+		ClientID: holderClientID,
+		Params: oauthParameters{
+			oauth.ClientIDParam:     holderClientID,
+			oauth.ResponseTypeParam: "unsupported",
+		},
+	}
+	query := url.Values{
+		oauth.ClientIDParam:   {holderClientID},
+		oauth.RequestURIParam: {oauth.PushedAuthorizationRequestURIPrefix + reference},
+	}
+	t.Run("ok", func(t *testing.T) {
+		ctx := newTestClient(t)
+		require.NoError(t, ctx.client.pushedAuthorizationRequestStore().Put(reference, pushedRequest))
+
+		params, err := ctx.client.loadPushedAuthorizationRequest(verifierSubject, query)
+
+		require.NoError(t, err)
+		assert.Equal(t, "unsupported", params.get(oauth.ResponseTypeParam))
+		// request_uri can only be used once
+		assert.ErrorIs(t, ctx.client.pushedAuthorizationRequestStore().Get(reference, new(pushedAuthorizationRequest)), storage.ErrNotFound)
+	})
+	t.Run("used by handleAuthorizeRequest", func(t *testing.T) {
+		ctx := newTestClient(t)
+		require.NoError(t, ctx.client.pushedAuthorizationRequestStore().Put(reference, pushedRequest))
+		requestURL := url.URL{RawQuery: query.Encode()}
+
+		// the request object isn't parsed again, jar.Parse is not expected to be called
+		_, err := ctx.client.handleAuthorizeRequest(context.Background(), verifierSubject, oauth.AuthorizationServerMetadata{}, requestURL)
+
+		requireOAuthError(t, err, oauth.UnsupportedResponseType, "")
+	})
+	t.Run("unknown request_uri", func(t *testing.T) {
+		ctx := newTestClient(t)
+
+		params, err := ctx.client.loadPushedAuthorizationRequest(verifierSubject, query)
+
+		requireOAuthError(t, err, oauth.InvalidRequestURI, "request_uri is invalid or expired")
+		assert.Nil(t, params)
+	})
+	t.Run("pushed to other subject", func(t *testing.T) {
+		ctx := newTestClient(t)
+		require.NoError(t, ctx.client.pushedAuthorizationRequestStore().Put(reference, pushedRequest))
+
+		params, err := ctx.client.loadPushedAuthorizationRequest(holderSubjectID, query)
+
+		requireOAuthError(t, err, oauth.InvalidRequestURI, "request_uri is invalid or expired")
+		assert.Nil(t, params)
+	})
+	t.Run("client_id mismatch", func(t *testing.T) {
+		ctx := newTestClient(t)
+		require.NoError(t, ctx.client.pushedAuthorizationRequestStore().Put(reference, pushedRequest))
+		otherQuery := url.Values{
+			oauth.ClientIDParam:   {"https://example.com/oauth2/other"},
+			oauth.RequestURIParam: query[oauth.RequestURIParam],
+		}
+
+		params, err := ctx.client.loadPushedAuthorizationRequest(verifierSubject, otherQuery)
+
+		requireOAuthError(t, err, oauth.InvalidRequest, "client_id does not match the pushed authorization request")
+		assert.Nil(t, params)
+	})
+}
@@ -0,0 +1,246 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package iam
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/nuts-foundation/nuts-node/auth/log"
+	"github.com/nuts-foundation/nuts-node/auth/oauth"
+	nutsCrypto "github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/nuts-foundation/nuts-node/crypto/dpop"
+	"github.com/nuts-foundation/nuts-node/storage"
+)
+
+// refreshTokenClientValidity is the maximum time the client keeps a refresh token received from a remote authorization server.
+const refreshTokenClientValidity = 24 * time.Hour
+
+// RefreshToken is issued alongside an access token when refresh tokens are enabled.
+// It can be used once to obtain a new access token (and refresh token) for the same client and authorization,
+// without performing a new presentation exchange.
+type RefreshToken struct {
+	// AccessToken is the access token the refresh token was issued for. New access tokens are derived from it.
+	AccessToken AccessToken `json:"access_token"`
+	// Expiration is the time the refresh token expires. Rotated refresh tokens keep the expiration of the original refresh token,
+	// which never exceeds the validity of the credentials that were presented to obtain the original access token.
+	Expiration time.Time `json:"expiration"`
+}
+
+// handleRefreshTokenRequest handles the /token request with the refresh_token grant type (RFC6749 section 6).
+// Refresh tokens are rotated: a refresh token can only be used once, and a new refresh token is issued alongside the new access token.
+// Before a new access token is issued, the credentials that were presented to obtain the original access token are checked for expiration, revocation and suspension.
+func (r Wrapper) handleRefreshTokenRequest(ctx context.Context, subject string, request HandleTokenRequestFormdataRequestBody) (HandleTokenRequestResponseObject, error) {
+	if r.auth.RefreshTokenValidity() <= 0 {
+		return nil, oauth.OAuth2Error{
+			Code:        oauth.UnsupportedGrantType,
+			Description: "refresh tokens are disabled",
+		}
+	}
+	if request.RefreshToken == nil || request.ClientId == nil {
+		return nil, oauth.OAuth2Error{
+			Code:        oauth.InvalidRequest,
+			Description: "missing required parameters",
+		}
+	}
+	// always burn the refresh token once presented, a failing request could indicate a stolen refresh token
+	refreshToken := RefreshToken{}
+	if err := r.refreshTokenServerStore().GetAndDelete(*request.RefreshToken, &refreshToken); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, oauthError(oauth.InvalidGrant, "refresh token is invalid or expired")
+		}
+		return nil, oauthError(oauth.ServerError, "unable to retrieve refresh token", err)
+	}
+	issueTime := time.Now()
+	if !refreshToken.Expiration.After(issueTime) {
+		return nil, oauthError(oauth.InvalidGrant, "refresh token is invalid or expired")
+	}
+	previous := refreshToken.AccessToken
+	issuerURL := r.subjectToBaseURL(subject)
+	if previous.Issuer != issuerURL.String() {
+		return nil, oauthError(oauth.InvalidGrant, "refresh token was not issued by this authorization server")
+	}
+	if previous.ClientId != *request.ClientId {
+		return nil, oauthError(oauth.InvalidGrant, "client_id does not match")
+	}
+	scope := previous.Scope
+	if request.Scope != nil {
+		if !isScopeSubset(*request.Scope, previous.Scope) {
+			return nil, oauthError(oauth.InvalidScope, "requested scope exceeds the scope of the refresh token")
+		}
+		scope = *request.Scope
+	}
+
+	// Parse optional DPoP header
+	httpRequest := ctx.Value(httpRequestContextKey{}).(*http.Request)
+	dpopProof, err := dpopFromRequest(*httpRequest)
+	if err != nil {
+		return nil, err
+	}
+	if err = validateRefreshTokenDPoP(previous.DPoP, dpopProof); err != nil {
+		return nil, err
+	}
+
+	if err = r.verifyPresentedCredentials(previous); err != nil {
+		return nil, oauthError(oauth.InvalidGrant, "credentials presented to obtain the access token are no longer valid", err)
+	}
+
+	expiration := issueTime.Add(accessTokenValidity)
+	if refreshToken.Expiration.Before(expiration) {
+		expiration = refreshToken.Expiration
+	}
+	accessToken := previous
+	accessToken.DPoP = dpopProof
+	accessToken.Token = nutsCrypto.GenerateNonce()
+	accessToken.IssuedAt = issueTime
+	accessToken.Expiration = expiration
+	accessToken.Scope = scope
+	tokenResponse, err := r.storeAccessToken(ctx, subject, &accessToken)
+	if err != nil {
+		return nil, err
+	}
+	// the new refresh token grants the same scope as the one that was used, even if the new access token has a narrower scope
+	template := accessToken
+	template.Scope = previous.Scope
+	if err = r.issueRefreshToken(tokenResponse, template, refreshToken.Expiration); err != nil {
+		return nil, err
+	}
+	return HandleTokenRequest200JSONResponse(*tokenResponse), nil
+}
+
+// issueRefreshToken issues a refresh token for the given access token, which expires at the given time, and adds it to the token response.
+// No refresh token is issued if it would expire before the access token.
+func (r Wrapper) issueRefreshToken(tokenResponse *oauth.TokenResponse, accessToken AccessToken, expiration time.Time) error {
+	if !expiration.After(accessToken.Expiration) {
+		return nil
+	}
+	token := nutsCrypto.GenerateNonce()
+	refreshToken := RefreshToken{
+		AccessToken: accessToken,
+		Expiration:  expiration,
+	}
+	if err := r.refreshTokenServerStore().Put(token, refreshToken, storage.WithTTL(time.Until(expiration))); err != nil {
+		return fmt.Errorf("unable to store refresh token: %w", err)
+	}
+	if err := r.refreshTokenLinkStore().Put(accessToken.Token, token, storage.WithTTL(time.Until(accessToken.Expiration))); err != nil {
+		return fmt.Errorf("unable to store refresh token: %w", err)
+	}
+	tokenResponse.RefreshToken = &token
+	return nil
+}
+
+// verifyPresentedCredentials checks whether the credentials that were presented to obtain the access token are still valid,
+// meaning they're not expired, revoked or suspended.
+func (r Wrapper) verifyPresentedCredentials(accessToken AccessToken) error {
+	for _, presentation := range accessToken.VPToken {
+		for _, credential := range presentation.VerifiableCredential {
+			// signature and trust were verified when the credential was presented
+			if err := r.vcr.Verifier().Verify(credential, true, false, nil); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// refreshTokenExpiration returns the expiration of a refresh token issued for the given access token:
+// the given validity after issuance of the access token, limited to the expiration of the presented credentials.
+func refreshTokenExpiration(accessToken AccessToken, validity time.Duration) time.Time {
+	result := accessToken.IssuedAt.Add(validity)
+	for _, presentation := range accessToken.VPToken {
+		for _, credential := range presentation.VerifiableCredential {
+			if credential.ExpirationDate != nil && credential.ExpirationDate.Before(result) {
+				result = *credential.ExpirationDate
+			}
+		}
+	}
+	return result
+}
+
+// validateRefreshTokenDPoP checks that the DPoP proof of a refresh token request is signed with the key the refresh token is bound to (RFC9449 section 5).
+// Refresh tokens that aren't bound to a key can be used without DPoP proof, or with a DPoP proof to obtain a DPoP-bound access token.
+func validateRefreshTokenDPoP(boundTo *dpop.DPoP, proof *dpop.DPoP) error {
+	if boundTo == nil {
+		return nil
+	}
+	if proof == nil {
+		return oauthError(oauth.InvalidDPopProof, "refresh token is DPoP-bound, a DPoP proof is required")
+	}
+	expected, _ := boundTo.Headers.JWK().Thumbprint(crypto.SHA256)
+	actual, _ := proof.Headers.JWK().Thumbprint(crypto.SHA256)
+	if !bytes.Equal(expected, actual) {
+		return oauthError(oauth.InvalidDPopProof, "DPoP proof is not signed with the key the refresh token is bound to")
+	}
+	return nil
+}
+
+// serviceRefreshToken is a refresh token the node received from a remote authorization server for a service access token request.
+type serviceRefreshToken struct {
+	RefreshToken string `json:"refresh_token"`
+	// DPoPKid is the ID of the key the refresh token is bound to, if the access token was DPoP-bound.
+	DPoPKid string `json:"dpop_kid,omitempty"`
+}
+
+// refreshServiceAccessToken tries to obtain a new access token using a refresh token that was received for an earlier, identical, service access token request.
+// It returns nil if there's no refresh token, or if the refresh failed. The caller then performs a new presentation exchange.
+func (r Wrapper) refreshServiceAccessToken(ctx context.Context, clientID string, cacheKey string, authServerURL string) *TokenResponse {
+	var entry serviceRefreshToken
+	if err := r.refreshTokenClientStore().GetAndDelete(cacheKey, &entry); err != nil {
+		if !errors.Is(err, storage.ErrNotFound) {
+			log.Logger().WithError(err).Warn("Failed to retrieve refresh token from store")
+		}
+		return nil
+	}
+	result, err := r.auth.IAMClient().RefreshAccessToken(ctx, authServerURL, clientID, entry.RefreshToken, entry.DPoPKid)
+	if err != nil {
+		log.Logger().WithError(err).Info("Failed to refresh access token, requesting a new access token")
+		return nil
+	}
+	return result
+}
+
+// storeServiceRefreshToken stores the refresh token of the given token response, so it can be used for subsequent identical service access token requests.
+func (r Wrapper) storeServiceRefreshToken(cacheKey string, tokenResponse TokenResponse) {
+	entry := serviceRefreshToken{RefreshToken: *tokenResponse.RefreshToken}
+	if tokenResponse.DPoPKid != nil {
+		entry.DPoPKid = *tokenResponse.DPoPKid
+	}
+	if err := r.refreshTokenClientStore().Put(cacheKey, entry); err != nil {
+		// only log error, don't fail
+		log.Logger().WithError(err).Warn("Failed to store refresh token")
+	}
+}
+
+// refreshTokenClientStore is used by the client to store refresh tokens received for service access token requests.
+// The lifetime of refresh tokens is not known to the client, so entries expire after refreshTokenClientValidity.
+// If the refresh token expired before that time, the refresh fails and a new access token is requested.
+func (r Wrapper) refreshTokenClientStore() storage.SessionStore {
+	return r.storageEngine.GetSessionDatabase().GetStore(refreshTokenClientValidity, "clientrefreshtoken")
+}
+
+// refreshTokenServerStore is used by the Auth server to store issued refresh tokens.
+// Entries are stored with the expiration of the refresh token as TTL.
+func (r Wrapper) refreshTokenServerStore() storage.SessionStore {
+	return r.storageEngine.GetSessionDatabase().GetStore(r.auth.RefreshTokenValidity(), "serverrefreshtoken")
+}
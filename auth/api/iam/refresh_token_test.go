@@ -0,0 +1,279 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package iam
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/auth/oauth"
+	"github.com/nuts-foundation/nuts-node/core/to"
+	"github.com/nuts-foundation/nuts-node/vcr/test"
+	"github.com/nuts-foundation/nuts-node/vcr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestWrapper_handleRefreshTokenRequest(t *testing.T) {
+	const clientID = "https://example.com/oauth2/client"
+	bearerRequestCtx := context.WithValue(context.Background(), httpRequestContextKey{}, &http.Request{Header: http.Header{}})
+	dpopToken, _, _ := newSignedTestDPoP()
+	dpopHeader, _, _ := newSignedTestDPoP()
+	dpopRequestCtx := context.WithValue(context.Background(), httpRequestContextKey{}, &http.Request{
+		Header: http.Header{
+			"Dpop": []string{dpopHeader.String()},
+		},
+	})
+	newRefreshToken := func(t *testing.T, ctx *testCtx) (string, RefreshToken) {
+		refreshToken := RefreshToken{
+			AccessToken: AccessToken{
+				Token:      "access-token",
+				Issuer:     verifierURL.String(),
+				ClientId:   clientID,
+				IssuedAt:   time.Now().Add(-time.Hour),
+				Expiration: time.Now().Add(-45 * time.Minute),
+				Scope:      "first second",
+			},
+			Expiration: time.Now().Add(time.Hour),
+		}
+		require.NoError(t, ctx.client.refreshTokenServerStore().Put("refresh-token", refreshToken))
+		return "refresh-token", refreshToken
+	}
+	newRequest := func() HandleTokenRequestFormdataRequestBody {
+		return HandleTokenRequestFormdataRequestBody{
+			GrantType:    oauth.RefreshTokenGrantType,
+			ClientId:     to.Ptr(clientID),
+			RefreshToken: to.Ptr("refresh-token"),
+		}
+	}
+	tokenResponse := func(t *testing.T, response HandleTokenRequestResponseObject) TokenResponse {
+		require.IsType(t, HandleTokenRequest200JSONResponse{}, response)
+		return TokenResponse(response.(HandleTokenRequest200JSONResponse))
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.refreshTokenValidity = 24 * time.Hour
+		newRefreshToken(t, ctx)
+
+		response, err := ctx.client.HandleTokenRequest(bearerRequestCtx, HandleTokenRequestRequestObject{
+			SubjectID: verifierSubject,
+			Body:      to.Ptr(newRequest()),
+		})
+
+		require.NoError(t, err)
+		result := tokenResponse(t, response)
+		assert.Equal(t, AccessTokenTypeBearer, result.TokenType)
+		assert.Equal(t, "first second", *result.Scope)
+		assert.Equal(t, int(accessTokenValidity.Seconds()), *result.ExpiresIn)
+		var accessToken AccessToken
+		require.NoError(t, ctx.client.accessTokenServerStore().Get(result.AccessToken, &accessToken))
+		assert.Equal(t, clientID, accessToken.ClientId)
+		assert.Equal(t, "first second", accessToken.Scope)
+		t.Run("refresh token is rotated", func(t *testing.T) {
+			require.NotNil(t, result.RefreshToken)
+			assert.NotEqual(t, "refresh-token", *result.RefreshToken)
+			_, err := ctx.client.handleRefreshTokenRequest(bearerRequestCtx, verifierSubject, newRequest())
+			requireOAuthError(t, err, oauth.InvalidGrant, "refresh token is invalid or expired")
+
+			request := newRequest()
+			request.RefreshToken = result.RefreshToken
+			response, err := ctx.client.handleRefreshTokenRequest(bearerRequestCtx, verifierSubject, request)
+			require.NoError(t, err)
+			assert.NotNil(t, tokenResponse(t, response).RefreshToken)
+		})
+	})
+	t.Run("ok - narrowed scope", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.refreshTokenValidity = 24 * time.Hour
+		newRefreshToken(t, ctx)
+		request := newRequest()
+		request.Scope = to.Ptr("second")
+
+		response, err := ctx.client.handleRefreshTokenRequest(bearerRequestCtx, verifierSubject, request)
+
+		require.NoError(t, err)
+		assert.Equal(t, "second", *tokenResponse(t, response).Scope)
+	})
+	t.Run("ok - access token expiration is limited by refresh token expiration", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.refreshTokenValidity = 24 * time.Hour
+		_, refreshToken := newRefreshToken(t, ctx)
+		refreshToken.Expiration = time.Now().Add(time.Minute)
+		require.NoError(t, ctx.client.refreshTokenServerStore().Put("refresh-token", refreshToken))
+
+		response, err := ctx.client.handleRefreshTokenRequest(bearerRequestCtx, verifierSubject, newRequest())
+
+		require.NoError(t, err)
+		result := tokenResponse(t, response)
+		assert.LessOrEqual(t, *result.ExpiresIn, 60)
+		// a refresh token that expires together with the access token is useless
+		assert.Nil(t, result.RefreshToken)
+	})
+	t.Run("ok - DPoP-bound", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.refreshTokenValidity = 24 * time.Hour
+		_, refreshToken := newRefreshToken(t, ctx)
+		refreshToken.AccessToken.DPoP = dpopHeader
+		require.NoError(t, ctx.client.refreshTokenServerStore().Put("refresh-token", refreshToken))
+
+		response, err := ctx.client.handleRefreshTokenRequest(dpopRequestCtx, verifierSubject, newRequest())
+
+		require.NoError(t, err)
+		assert.Equal(t, AccessTokenTypeDPoP, tokenResponse(t, response).TokenType)
+	})
+	t.Run("ok - presented credentials are verified", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.refreshTokenValidity = 24 * time.Hour
+		_, refreshToken := newRefreshToken(t, ctx)
+		refreshToken.AccessToken.VPToken = []VerifiablePresentation{{VerifiableCredential: []vc.VerifiableCredential{test.ValidNutsOrganizationCredential(t)}}}
+		require.NoError(t, ctx.client.refreshTokenServerStore().Put("refresh-token", refreshToken))
+		ctx.vcVerifier.EXPECT().Verify(gomock.Any(), true, false, nil).Return(nil)
+
+		_, err := ctx.client.handleRefreshTokenRequest(bearerRequestCtx, verifierSubject, newRequest())
+
+		require.NoError(t, err)
+	})
+	t.Run("error - refresh tokens disabled", func(t *testing.T) {
+		ctx := newTestClient(t)
+
+		_, err := ctx.client.handleRefreshTokenRequest(bearerRequestCtx, verifierSubject, newRequest())
+
+		requireOAuthError(t, err, oauth.UnsupportedGrantType, "refresh tokens are disabled")
+	})
+	t.Run("error - missing refresh_token", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.refreshTokenValidity = 24 * time.Hour
+		request := newRequest()
+		request.RefreshToken = nil
+
+		_, err := ctx.client.handleRefreshTokenRequest(bearerRequestCtx, verifierSubject, request)
+
+		requireOAuthError(t, err, oauth.InvalidRequest, "missing required parameters")
+	})
+	t.Run("error - unknown refresh token", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.refreshTokenValidity = 24 * time.Hour
+
+		_, err := ctx.client.handleRefreshTokenRequest(bearerRequestCtx, verifierSubject, newRequest())
+
+		requireOAuthError(t, err, oauth.InvalidGrant, "refresh token is invalid or expired")
+	})
+	t.Run("error - expired refresh token", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.refreshTokenValidity = 24 * time.Hour
+		_, refreshToken := newRefreshToken(t, ctx)
+		refreshToken.Expiration = time.Now().Add(-time.Second)
+		require.NoError(t, ctx.client.refreshTokenServerStore().Put("refresh-token", refreshToken))
+
+		_, err := ctx.client.handleRefreshTokenRequest(bearerRequestCtx, verifierSubject, newRequest())
+
+		requireOAuthError(t, err, oauth.InvalidGrant, "refresh token is invalid or expired")
+	})
+	t.Run("error - issued by other authorization server", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.refreshTokenValidity = 24 * time.Hour
+		newRefreshToken(t, ctx)
+
+		_, err := ctx.client.handleRefreshTokenRequest(bearerRequestCtx, holderSubjectID, newRequest())
+
+		requireOAuthError(t, err, oauth.InvalidGrant, "refresh token was not issued by this authorization server")
+	})
+	t.Run("error - other client", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.refreshTokenValidity = 24 * time.Hour
+		newRefreshToken(t, ctx)
+		request := newRequest()
+		request.ClientId = to.Ptr("https://example.com/oauth2/other")
+
+		_, err := ctx.client.handleRefreshTokenRequest(bearerRequestCtx, verifierSubject, request)
+
+		requireOAuthError(t, err, oauth.InvalidGrant, "client_id does not match")
+		t.Run("refresh token is burned", func(t *testing.T) {
+			_, err := ctx.client.handleRefreshTokenRequest(bearerRequestCtx, verifierSubject, newRequest())
+
+			requireOAuthError(t, err, oauth.InvalidGrant, "refresh token is invalid or expired")
+		})
+	})
+	t.Run("error - scope exceeds refresh token scope", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.refreshTokenValidity = 24 * time.Hour
+		newRefreshToken(t, ctx)
+		request := newRequest()
+		request.Scope = to.Ptr("first third")
+
+		_, err := ctx.client.handleRefreshTokenRequest(bearerRequestCtx, verifierSubject, request)
+
+		requireOAuthError(t, err, oauth.InvalidScope, "requested scope exceeds the scope of the refresh token")
+	})
+	t.Run("error - DPoP-bound, but no DPoP proof", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.refreshTokenValidity = 24 * time.Hour
+		_, refreshToken := newRefreshToken(t, ctx)
+		refreshToken.AccessToken.DPoP = dpopToken
+		require.NoError(t, ctx.client.refreshTokenServerStore().Put("refresh-token", refreshToken))
+
+		_, err := ctx.client.handleRefreshTokenRequest(bearerRequestCtx, verifierSubject, newRequest())
+
+		requireOAuthError(t, err, oauth.InvalidDPopProof, "refresh token is DPoP-bound, a DPoP proof is required")
+	})
+	t.Run("error - DPoP proof signed with other key", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.refreshTokenValidity = 24 * time.Hour
+		_, refreshToken := newRefreshToken(t, ctx)
+		refreshToken.AccessToken.DPoP = dpopToken
+		require.NoError(t, ctx.client.refreshTokenServerStore().Put("refresh-token", refreshToken))
+
+		_, err := ctx.client.handleRefreshTokenRequest(dpopRequestCtx, verifierSubject, newRequest())
+
+		requireOAuthError(t, err, oauth.InvalidDPopProof, "DPoP proof is not signed with the key the refresh token is bound to")
+	})
+	t.Run("error - presented credential was revoked", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.refreshTokenValidity = 24 * time.Hour
+		_, refreshToken := newRefreshToken(t, ctx)
+		refreshToken.AccessToken.VPToken = []VerifiablePresentation{{VerifiableCredential: []vc.VerifiableCredential{test.ValidNutsOrganizationCredential(t)}}}
+		require.NoError(t, ctx.client.refreshTokenServerStore().Put("refresh-token", refreshToken))
+		ctx.vcVerifier.EXPECT().Verify(gomock.Any(), true, false, nil).Return(types.ErrRevoked)
+
+		_, err := ctx.client.handleRefreshTokenRequest(bearerRequestCtx, verifierSubject, newRequest())
+
+		requireOAuthError(t, err, oauth.InvalidGrant, "credentials presented to obtain the access token are no longer valid")
+	})
+}
+
+func Test_refreshTokenExpiration(t *testing.T) {
+	issuedAt := time.Now()
+	accessToken := AccessToken{IssuedAt: issuedAt}
+	t.Run("no credentials", func(t *testing.T) {
+		assert.Equal(t, issuedAt.Add(time.Hour), refreshTokenExpiration(accessToken, time.Hour))
+	})
+	t.Run("limited by credential expiration", func(t *testing.T) {
+		credential := test.ValidNutsOrganizationCredential(t)
+		credential.ExpirationDate = to.Ptr(issuedAt.Add(time.Minute))
+		token := accessToken
+		token.VPToken = []VerifiablePresentation{{VerifiableCredential: []vc.VerifiableCredential{credential}}}
+
+		assert.Equal(t, issuedAt.Add(time.Minute), refreshTokenExpiration(token, time.Hour))
+	})
+}
@@ -23,11 +23,13 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/go-did/vc"
 	"github.com/nuts-foundation/nuts-node/auth/oauth"
+	"github.com/nuts-foundation/nuts-node/policy"
 	"github.com/nuts-foundation/nuts-node/storage"
 	"github.com/nuts-foundation/nuts-node/vcr/credential"
 	"github.com/nuts-foundation/nuts-node/vcr/pe"
@@ -73,7 +75,7 @@ func (r Wrapper) handleS2SAccessTokenRequest(ctx context.Context, clientID strin
 			return nil, err
 		}
 	}
-	walletOwnerMapping, err := r.presentationDefinitionForScope(ctx, scope)
+	walletOwnerMapping, err := r.presentationDefinitionForScope(policy.Context(ctx, clientID, subject), scope)
 	if err != nil {
 		return nil, err
 	}
@@ -107,15 +109,42 @@ func (r Wrapper) handleS2SAccessTokenRequest(ctx context.Context, clientID strin
 		}
 	}
 
+	// Check the presenter's DID is linked to the origin of the client_id, so the client_id can't be spoofed.
+	if r.auth.DomainLinkageRequired() {
+		if err := r.verifyClientDomainLinkage(ctx, clientID, credentialSubjectID); err != nil {
+			return nil, err
+		}
+	}
+
 	// All OK, allow access
-	issuerURL := r.subjectToBaseURL(subject)
-	response, err := r.createAccessToken(issuerURL.String(), clientID, time.Now(), scope, *pexConsumer, dpopProof)
+	response, err := r.createAccessToken(ctx, subject, clientID, time.Now(), scope, *pexConsumer, dpopProof)
 	if err != nil {
 		return nil, err
 	}
 	return HandleTokenRequest200JSONResponse(*response), nil
 }
 
+// verifyClientDomainLinkage checks whether the client's DID is linked to the origin of its client_id,
+// using the DIF Well-Known DID Configuration served on that origin.
+func (r Wrapper) verifyClientDomainLinkage(ctx context.Context, clientID string, clientDID did.DID) error {
+	clientURL, err := url.Parse(clientID)
+	if err != nil {
+		return oauthError(oauth.InvalidClient, "client_id must be a URL when domain linkage is required")
+	}
+	origin, err := credential.ParseOrigin(clientURL.Scheme + "://" + clientURL.Host)
+	if err != nil {
+		return oauthError(oauth.InvalidClient, "client_id must be a URL when domain linkage is required")
+	}
+	if err = r.vcr.Verifier().VerifyDomainLinkage(ctx, clientDID, origin); err != nil {
+		return oauth.OAuth2Error{
+			Code:          oauth.InvalidClient,
+			Description:   "client DID is not linked to the origin of the client_id",
+			InternalError: err,
+		}
+	}
+	return nil
+}
+
 func resolveInputDescriptorValues(presentationDefinitions pe.WalletOwnerMapping, credentialMap map[string]vc.VerifiableCredential) (map[string]any, error) {
 	fieldsMap := make(map[string]any)
 	for _, definition := range presentationDefinitions {
@@ -40,6 +40,7 @@ import (
 	"github.com/nuts-foundation/nuts-node/vcr/pe"
 	"github.com/nuts-foundation/nuts-node/vcr/signature/proof"
 	"github.com/nuts-foundation/nuts-node/vcr/test"
+	"github.com/nuts-foundation/nuts-node/vdr/resolver"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -118,7 +119,10 @@ func TestWrapper_handleS2SAccessTokenRequest(t *testing.T) {
 	t.Run("JSON-LD VP", func(t *testing.T) {
 		ctx := newTestClient(t)
 		ctx.vcVerifier.EXPECT().VerifyVP(presentation, true, true, gomock.Any()).Return(presentation.VerifiableCredential, nil)
-		ctx.policy.EXPECT().PresentationDefinitions(gomock.Any(), requestedScope).Return(walletOwnerMapping, nil)
+		ctx.policy.EXPECT().PresentationDefinitions(gomock.Any(), requestedScope).DoAndReturn(func(ctx context.Context, _ string) (pe.WalletOwnerMapping, error) {
+			assert.Equal(t, policy.RequestInfo{ClientID: clientID, Subject: issuerSubjectID}, policy.RequestInfoFromContext(ctx))
+			return walletOwnerMapping, nil
+		})
 
 		resp, err := ctx.client.handleS2SAccessTokenRequest(contextWithValue, clientID, issuerSubjectID, requestedScope, submissionJSON, presentation.Raw())
 
@@ -294,6 +298,43 @@ func TestWrapper_handleS2SAccessTokenRequest(t *testing.T) {
 			assert.Nil(t, resp)
 		})
 	})
+	t.Run("domain linkage", func(t *testing.T) {
+		t.Run("ok", func(t *testing.T) {
+			ctx := newTestClient(t)
+			*ctx.domainLinkageRequired = true
+			ctx.vcVerifier.EXPECT().VerifyVP(presentation, true, true, gomock.Any()).Return(presentation.VerifiableCredential, nil)
+			ctx.vcVerifier.EXPECT().VerifyDomainLinkage(gomock.Any(), *subjectDID, "https://example.com").Return(nil)
+			ctx.policy.EXPECT().PresentationDefinitions(gomock.Any(), requestedScope).Return(walletOwnerMapping, nil)
+
+			resp, err := ctx.client.handleS2SAccessTokenRequest(contextWithValue, clientID, issuerSubjectID, requestedScope, submissionJSON, presentation.Raw())
+
+			require.NoError(t, err)
+			assert.IsType(t, HandleTokenRequest200JSONResponse{}, resp)
+		})
+		t.Run("not linked", func(t *testing.T) {
+			ctx := newTestClient(t)
+			*ctx.domainLinkageRequired = true
+			ctx.vcVerifier.EXPECT().VerifyVP(presentation, true, true, gomock.Any()).Return(presentation.VerifiableCredential, nil)
+			ctx.vcVerifier.EXPECT().VerifyDomainLinkage(gomock.Any(), *subjectDID, "https://example.com").Return(errors.New("not linked"))
+			ctx.policy.EXPECT().PresentationDefinitions(gomock.Any(), requestedScope).Return(walletOwnerMapping, nil)
+
+			resp, err := ctx.client.handleS2SAccessTokenRequest(contextWithValue, clientID, issuerSubjectID, requestedScope, submissionJSON, presentation.Raw())
+
+			assert.EqualError(t, err, "invalid_client - not linked - client DID is not linked to the origin of the client_id")
+			assert.Nil(t, resp)
+		})
+		t.Run("client_id is not a URL", func(t *testing.T) {
+			ctx := newTestClient(t)
+			*ctx.domainLinkageRequired = true
+			ctx.vcVerifier.EXPECT().VerifyVP(presentation, true, true, gomock.Any()).Return(presentation.VerifiableCredential, nil)
+			ctx.policy.EXPECT().PresentationDefinitions(gomock.Any(), requestedScope).Return(walletOwnerMapping, nil)
+
+			resp, err := ctx.client.handleS2SAccessTokenRequest(contextWithValue, "holder", issuerSubjectID, requestedScope, submissionJSON, presentation.Raw())
+
+			assert.EqualError(t, err, "invalid_client - client_id must be a URL when domain linkage is required")
+			assert.Nil(t, resp)
+		})
+	})
 	t.Run("VP verification fails", func(t *testing.T) {
 		ctx := newTestClient(t)
 		ctx.vcVerifier.EXPECT().VerifyVP(presentation, true, true, gomock.Any()).Return(nil, errors.New("invalid"))
@@ -429,6 +470,7 @@ func TestWrapper_createAccessToken(t *testing.T) {
 		},
 	}
 	dpopToken, _, _ := newSignedTestDPoP()
+	signingKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	verifiablePresentation := test.ParsePresentation(t, presentation)
 	pexEnvelopeJSON, _ := json.Marshal(verifiablePresentation)
 	pexEnvelope, err := pe.ParseEnvelope(pexEnvelopeJSON)
@@ -447,7 +489,7 @@ func TestWrapper_createAccessToken(t *testing.T) {
 		ctx := newTestClient(t)
 
 		require.NoError(t, err)
-		accessToken, err := ctx.client.createAccessToken(issuerURL.String(), credentialSubjectID.String(), time.Now(), "everything", pexConsumer, dpopToken)
+		accessToken, err := ctx.client.createAccessToken(context.Background(), issuerSubjectID, credentialSubjectID.String(), time.Now(), "everything", pexConsumer, dpopToken)
 
 		require.NoError(t, err)
 		assert.NotEmpty(t, accessToken.AccessToken)
@@ -468,12 +510,98 @@ func TestWrapper_createAccessToken(t *testing.T) {
 		assert.Equal(t, issuerURL.String(), storedToken.Issuer)
 		assert.NotEmpty(t, storedToken.Expiration)
 	})
+	t.Run("ok - JWT access token", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.jwtAccessTokensEnabled = true
+		ctx.keyResolver.EXPECT().ResolveKey(issuerDID, nil, resolver.AssertionMethod).Return("kid", signingKey.Public(), nil)
+		ctx.jwtSigner.EXPECT().SignJWT(gomock.Any(), gomock.Any(), gomock.Any(), "kid").DoAndReturn(func(_ context.Context, claims map[string]interface{}, headers map[string]interface{}, _ string) (string, error) {
+			assert.Equal(t, "at+jwt", headers["typ"])
+			assert.Equal(t, issuerURL.String(), claims["iss"])
+			assert.Equal(t, []string{"https://example.com/resource"}, claims["aud"])
+			assert.Equal(t, credentialSubjectID.String(), claims["sub"])
+			assert.Equal(t, credentialSubjectID.String(), claims["client_id"])
+			assert.Equal(t, "everything", claims["scope"])
+			assert.NotEmpty(t, claims["jti"])
+			assert.NotEmpty(t, claims["iat"])
+			assert.NotEmpty(t, claims["exp"])
+			assert.Equal(t, []interface{}{"NutsOrganizationCredential", "VerifiableCredential"}, claims["credential_type"])
+			assert.Contains(t, claims["cnf"], "jkt")
+			return "signed-jwt", nil
+		})
+
+		accessToken, err := ctx.client.createAccessToken(context.Background(), issuerSubjectID, credentialSubjectID.String(), time.Now(), "everything", pexConsumer, dpopToken)
+
+		require.NoError(t, err)
+		assert.Equal(t, "signed-jwt", accessToken.AccessToken)
+		assert.Equal(t, "DPoP", accessToken.TokenType)
+		var storedToken AccessToken
+		require.NoError(t, ctx.client.accessTokenServerStore().Get("signed-jwt", &storedToken))
+		assert.Equal(t, "signed-jwt", storedToken.Token)
+		assert.NotEmpty(t, storedToken.JwtID)
+		assert.NotEqual(t, storedToken.Token, storedToken.JwtID)
+	})
+	t.Run("ok - JWT access token for multiple resource servers", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.jwtAccessTokensEnabled = true
+		ctx.accessTokenAudiences["first"] = "https://example.com/first"
+		ctx.accessTokenAudiences["second"] = "https://example.com/second"
+		ctx.accessTokenAudiences["third"] = "https://example.com/first"
+		ctx.keyResolver.EXPECT().ResolveKey(issuerDID, nil, resolver.AssertionMethod).Return("kid", signingKey.Public(), nil)
+		ctx.jwtSigner.EXPECT().SignJWT(gomock.Any(), gomock.Any(), gomock.Any(), "kid").DoAndReturn(func(_ context.Context, claims map[string]interface{}, _ map[string]interface{}, _ string) (string, error) {
+			assert.Equal(t, []string{"https://example.com/first", "https://example.com/second"}, claims["aud"])
+			return "signed-jwt", nil
+		})
+
+		_, err := ctx.client.createAccessToken(context.Background(), issuerSubjectID, credentialSubjectID.String(), time.Now(), "first unknown second third", pexConsumer, nil)
+
+		require.NoError(t, err)
+	})
+	t.Run("error - no resource server configured for scope", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.jwtAccessTokensEnabled = true
+
+		accessToken, err := ctx.client.createAccessToken(context.Background(), issuerSubjectID, credentialSubjectID.String(), time.Now(), "unknown", pexConsumer, nil)
+
+		requireOAuthError(t, err, oauth.InvalidScope, "no resource server configured for scope 'unknown'")
+		assert.Nil(t, accessToken)
+	})
+	t.Run("error - signing JWT access token fails", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.jwtAccessTokensEnabled = true
+		ctx.keyResolver.EXPECT().ResolveKey(issuerDID, nil, resolver.AssertionMethod).Return("kid", signingKey.Public(), nil)
+		ctx.jwtSigner.EXPECT().SignJWT(gomock.Any(), gomock.Any(), gomock.Any(), "kid").Return("", assert.AnError)
+
+		accessToken, err := ctx.client.createAccessToken(context.Background(), issuerSubjectID, credentialSubjectID.String(), time.Now(), "everything", pexConsumer, nil)
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, accessToken)
+	})
 	t.Run("ok - bearer token", func(t *testing.T) {
 		ctx := newTestClient(t)
-		accessToken, err := ctx.client.createAccessToken(issuerURL.String(), credentialSubjectID.String(), time.Now(), "everything", pexConsumer, nil)
+		accessToken, err := ctx.client.createAccessToken(context.Background(), issuerSubjectID, credentialSubjectID.String(), time.Now(), "everything", pexConsumer, nil)
 
 		require.NoError(t, err)
 		assert.NotEmpty(t, accessToken.AccessToken)
 		assert.Equal(t, "Bearer", accessToken.TokenType)
 	})
+	t.Run("ok - with refresh token", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.refreshTokenValidity = time.Hour
+
+		accessToken, err := ctx.client.createAccessToken(context.Background(), issuerSubjectID, credentialSubjectID.String(), time.Now(), "everything", pexConsumer, nil)
+
+		require.NoError(t, err)
+		require.NotNil(t, accessToken.RefreshToken)
+		var storedToken RefreshToken
+		require.NoError(t, ctx.client.refreshTokenServerStore().Get(*accessToken.RefreshToken, &storedToken))
+		assert.Equal(t, accessToken.AccessToken, storedToken.AccessToken.Token)
+	})
+	t.Run("ok - refresh tokens disabled", func(t *testing.T) {
+		ctx := newTestClient(t)
+
+		accessToken, err := ctx.client.createAccessToken(context.Background(), issuerSubjectID, credentialSubjectID.String(), time.Now(), "everything", pexConsumer, nil)
+
+		require.NoError(t, err)
+		assert.Nil(t, accessToken.RefreshToken)
+	})
 }
@@ -28,6 +28,7 @@ import (
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/go-did/vc"
 	"github.com/nuts-foundation/nuts-node/http"
+	"github.com/nuts-foundation/nuts-node/vcr/dcql"
 	"github.com/nuts-foundation/nuts-node/vcr/pe"
 )
 
@@ -70,6 +71,7 @@ const (
 // PEXConsumer consumes Presentation Submissions, according to https://identity.foundation/presentation-exchange/
 // This is a component of a OpenID4VP Verifier.
 // It can track multiple required Presentation Definitions.
+// For scopes that are expressed in DCQL, it tracks the required DCQL queries and submitted VP Tokens instead.
 type PEXConsumer struct {
 	RequiredPresentationDefinitions pe.WalletOwnerMapping `json:"required_presentations"`
 	// Submissions tracks which Submissions have been submitted through OpenID4VP
@@ -77,6 +79,10 @@ type PEXConsumer struct {
 	// SubmittedEnvelopes tracks the Presentation Exchange Envelopes that were submitted.
 	// They correspond to the submissions.
 	SubmittedEnvelopes map[string]pe.Envelope `json:"submitted_envelopes"`
+	// RequiredDCQLQueries contains the DCQL queries that need to be fulfilled, if the scope is expressed in DCQL.
+	RequiredDCQLQueries dcql.WalletOwnerMapping `json:"required_dcql_queries,omitempty"`
+	// DCQLResponses tracks the VP Tokens that were submitted for the DCQL queries.
+	DCQLResponses map[pe.WalletOwnerType]dcql.VPToken `json:"dcql_responses,omitempty"`
 }
 
 func newPEXConsumer(requiredPresentationDefinitions pe.WalletOwnerMapping) *PEXConsumer {
@@ -87,10 +93,23 @@ func newPEXConsumer(requiredPresentationDefinitions pe.WalletOwnerMapping) *PEXC
 	}
 }
 
+func newDCQLConsumer(requiredQueries dcql.WalletOwnerMapping) *PEXConsumer {
+	return &PEXConsumer{
+		RequiredDCQLQueries: requiredQueries,
+		Submissions:         map[string]pe.PresentationSubmission{},
+		SubmittedEnvelopes:  map[string]pe.Envelope{},
+		DCQLResponses:       map[pe.WalletOwnerType]dcql.VPToken{},
+	}
+}
+
 // next returns the Presentation Definition that should be fulfilled next.
 // It also returns the wallet owner type that should fulfill the Presentation Definition.
 // If all Presentation Definitions have been fulfilled, it returns nil.
+// For DCQL queries, only the wallet owner type is returned.
 func (v *PEXConsumer) next() (*pe.WalletOwnerType, *pe.PresentationDefinition) {
+	if len(v.RequiredDCQLQueries) > 0 {
+		return v.nextDCQLQuery(), nil
+	}
 	// Note: this is now fairly hardcoded, since there are only 2 PDs possible, one targeting the organization wallet and
 	//       1 targeting the user wallet. In the future, this could be more dynamic.
 	if def, required := v.RequiredPresentationDefinitions[pe.WalletOwnerOrganization]; required && !v.isFulfilled(def.Id) {
@@ -135,6 +154,37 @@ func (v *PEXConsumer) fulfill(submission pe.PresentationSubmission, envelope pe.
 	return nil
 }
 
+// nextDCQLQuery returns the wallet owner type of the DCQL query that should be fulfilled next.
+// If all DCQL queries have been fulfilled, it returns nil.
+func (v *PEXConsumer) nextDCQLQuery() *pe.WalletOwnerType {
+	for _, walletOwnerType := range []pe.WalletOwnerType{pe.WalletOwnerOrganization, pe.WalletOwnerUser} {
+		if _, required := v.RequiredDCQLQueries[walletOwnerType]; !required {
+			continue
+		}
+		if _, fulfilled := v.DCQLResponses[walletOwnerType]; !fulfilled {
+			return &walletOwnerType
+		}
+	}
+	return nil
+}
+
+// fulfillDCQL tries to fulfill the DCQL query that should be fulfilled next with the given VP Token.
+// It checks whether the presented credentials satisfy the query, but does not verify the presentations, that's the caller's responsibility.
+func (v *PEXConsumer) fulfillDCQL(vpToken dcql.VPToken) error {
+	walletOwnerType := v.nextDCQLQuery()
+	if walletOwnerType == nil {
+		return errors.New("DCQL queries are already fulfilled")
+	}
+	if _, err := v.RequiredDCQLQueries[*walletOwnerType].Evaluate(vpToken); err != nil {
+		return fmt.Errorf("vp_token does not conform to DCQL query (wallet owner type=%s): %w", *walletOwnerType, err)
+	}
+	if v.DCQLResponses == nil {
+		v.DCQLResponses = map[pe.WalletOwnerType]dcql.VPToken{}
+	}
+	v.DCQLResponses[*walletOwnerType] = vpToken
+	return nil
+}
+
 func (v *PEXConsumer) isFulfilled(presentationDefinitionID string) bool {
 	_, fulfilled := v.Submissions[presentationDefinitionID]
 	return fulfilled
@@ -154,9 +204,31 @@ func (v *PEXConsumer) credentialMap() (map[string]vc.VerifiableCredential, error
 			credentialMap[inputDescriptorID] = cred
 		}
 	}
+	for walletOwnerType, vpToken := range v.DCQLResponses {
+		credentials, err := v.RequiredDCQLQueries[walletOwnerType].Evaluate(vpToken)
+		if err != nil {
+			return nil, err
+		}
+		// multiple credentials can be presented for a Credential Query, only the first one is mapped
+		for credentialQueryID, creds := range credentials {
+			credentialMap[credentialQueryID] = creds[0]
+		}
+	}
 	return credentialMap, nil
 }
 
+// presentations returns all presentations that were submitted, either in Presentation Exchange Envelopes or DCQL VP Tokens.
+func (v *PEXConsumer) presentations() []vc.VerifiablePresentation {
+	var result []vc.VerifiablePresentation
+	for _, envelope := range v.SubmittedEnvelopes {
+		result = append(result, envelope.Presentations...)
+	}
+	for _, vpToken := range v.DCQLResponses {
+		result = append(result, vpToken.Presentations()...)
+	}
+	return result
+}
+
 // ServerState is a convenience type for extracting different types of data from the session.
 type ServerState struct {
 	CredentialMap          map[string]vc.VerifiableCredential
@@ -20,6 +20,9 @@ package iam
 
 import (
 	"encoding/json"
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/vcr/dcql"
 	"github.com/nuts-foundation/nuts-node/vcr/pe"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -176,3 +179,45 @@ func TestPEXConsumer_fulfill(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func TestPEXConsumer_fulfillDCQL(t *testing.T) {
+	query := dcql.Query{Credentials: []dcql.CredentialQuery{{ID: "organization", Format: dcql.FormatLDPVC}}}
+	credential := vc.VerifiableCredential{Issuer: ssi.MustParseURI("did:web:example.com")}
+	vpToken := dcql.VPToken{"organization": {{VerifiableCredential: []vc.VerifiableCredential{credential}}}}
+	t.Run("ok", func(t *testing.T) {
+		v := newDCQLConsumer(dcql.WalletOwnerMapping{
+			pe.WalletOwnerOrganization: query,
+			pe.WalletOwnerUser:         query,
+		})
+		ownerType, definition := v.next()
+		require.Equal(t, pe.WalletOwnerOrganization, *ownerType)
+		assert.Nil(t, definition)
+
+		err := v.fulfillDCQL(vpToken)
+
+		require.NoError(t, err)
+		ownerType, _ = v.next()
+		assert.Equal(t, pe.WalletOwnerUser, *ownerType)
+		credentialMap, err := v.credentialMap()
+		require.NoError(t, err)
+		assert.Equal(t, credential, credentialMap["organization"])
+		assert.Len(t, v.presentations(), 1)
+	})
+	t.Run("does not conform to query", func(t *testing.T) {
+		v := newDCQLConsumer(dcql.WalletOwnerMapping{pe.WalletOwnerOrganization: query})
+
+		err := v.fulfillDCQL(dcql.VPToken{"other": vpToken["organization"]})
+
+		assert.EqualError(t, err, "vp_token does not conform to DCQL query (wallet owner type=organization): vp_token contains presentations for unknown credential query: other")
+	})
+	t.Run("already fulfilled", func(t *testing.T) {
+		v := newDCQLConsumer(dcql.WalletOwnerMapping{pe.WalletOwnerOrganization: query})
+		require.NoError(t, v.fulfillDCQL(vpToken))
+
+		err := v.fulfillDCQL(vpToken)
+
+		assert.EqualError(t, err, "DCQL queries are already fulfilled")
+		ownerType, _ := v.next()
+		assert.Nil(t, ownerType)
+	})
+}
@@ -0,0 +1,175 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package iam
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/nuts-foundation/nuts-node/auth/oauth"
+	nutsCrypto "github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/nuts-foundation/nuts-node/crypto/dpop"
+	"github.com/nuts-foundation/nuts-node/storage"
+)
+
+// handleTokenExchangeRequest handles the /token request with the token exchange grant type (RFC8693).
+// It exchanges an access token issued by this authorization server (the subject token) for a new access token,
+// which the exchanging party (the actor) can use to access a downstream service on behalf of the original client.
+// The actor authenticates with an access token this authorization server issued to it (the actor token), its client_id is the actor's identity.
+// The new token can't grant more than the subject token: its scope must be a subset of the subject token's scope and it can't outlive the subject token.
+// The actor is added to the delegation chain ('act' claim) of the new token.
+// If the actor token is DPoP-bound, the request must contain a DPoP proof signed with the actor's key.
+// The new token is bound to the key of the DPoP proof (if any), since the actor is the party that will present it.
+// A DPoP-bound subject token can be exchanged without its key: the actor presents it on behalf of the client, but doesn't hold the client's key.
+func (r Wrapper) handleTokenExchangeRequest(ctx context.Context, subject string, request HandleTokenRequestFormdataRequestBody) (HandleTokenRequestResponseObject, error) {
+	if !r.auth.TokenExchangeEnabled() {
+		return nil, oauth.OAuth2Error{
+			Code:        oauth.UnsupportedGrantType,
+			Description: "token exchange is disabled",
+		}
+	}
+	if request.SubjectToken == nil || request.SubjectTokenType == nil || request.ActorToken == nil || request.ActorTokenType == nil {
+		return nil, oauth.OAuth2Error{
+			Code:        oauth.InvalidRequest,
+			Description: "missing required parameters",
+		}
+	}
+	if *request.SubjectTokenType != oauth.AccessTokenTokenType {
+		return nil, oauthError(oauth.InvalidRequest, "unsupported subject_token_type")
+	}
+	if *request.ActorTokenType != oauth.AccessTokenTokenType {
+		return nil, oauthError(oauth.InvalidRequest, "unsupported actor_token_type")
+	}
+	if request.RequestedTokenType != nil && *request.RequestedTokenType != oauth.AccessTokenTokenType {
+		return nil, oauthError(oauth.InvalidRequest, "unsupported requested_token_type")
+	}
+
+	issueTime := time.Now()
+	issuerURL := r.subjectToBaseURL(subject)
+	subjectToken, err := r.exchangedAccessToken(*request.SubjectToken, oauth.SubjectTokenParam, issuerURL.String(), issueTime)
+	if err != nil {
+		return nil, err
+	}
+	actorToken, err := r.exchangedAccessToken(*request.ActorToken, oauth.ActorTokenParam, issuerURL.String(), issueTime)
+	if err != nil {
+		return nil, err
+	}
+	if request.ClientId != nil && *request.ClientId != actorToken.ClientId {
+		return nil, oauthError(oauth.InvalidGrant, "client_id does not match the actor_token")
+	}
+	scope := subjectToken.Scope
+	if request.Scope != nil {
+		if !isScopeSubset(*request.Scope, subjectToken.Scope) {
+			return nil, oauthError(oauth.InvalidScope, "requested scope exceeds the scope of the subject_token")
+		}
+		scope = *request.Scope
+	}
+
+	// Parse optional DPoP header, it must be signed with the key of a DPoP-bound actor token
+	httpRequest := ctx.Value(httpRequestContextKey{}).(*http.Request)
+	dpopProof, err := dpopFromRequest(*httpRequest)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateActorTokenDPoP(actorToken.DPoP, dpopProof); err != nil {
+		return nil, err
+	}
+
+	expiration := issueTime.Add(accessTokenValidity)
+	if subjectToken.Expiration.Before(expiration) {
+		expiration = subjectToken.Expiration
+	}
+	accessToken := AccessToken{
+		DPoP:                           dpopProof,
+		Token:                          nutsCrypto.GenerateNonce(),
+		Issuer:                         subjectToken.Issuer,
+		ClientId:                       subjectToken.ClientId,
+		IssuedAt:                       issueTime,
+		Expiration:                     expiration,
+		Scope:                          scope,
+		Actor:                          &Actor{Sub: actorToken.ClientId, Act: subjectToken.Actor},
+		InputDescriptorConstraintIdMap: subjectToken.InputDescriptorConstraintIdMap,
+		VPToken:                        subjectToken.VPToken,
+		PresentationSubmissions:        subjectToken.PresentationSubmissions,
+		PresentationDefinitions:        subjectToken.PresentationDefinitions,
+	}
+	tokenResponse, err := r.storeAccessToken(ctx, subject, &accessToken)
+	if err != nil {
+		return nil, err
+	}
+	tokenResponse.With(oauth.IssuedTokenTypeParam, oauth.AccessTokenTokenType)
+	return HandleTokenRequest200JSONResponse(*tokenResponse), nil
+}
+
+// exchangedAccessToken retrieves a subject or actor token of a token exchange request.
+// It must be a valid access token issued by the given authorization server.
+func (r Wrapper) exchangedAccessToken(token string, param string, issuer string, now time.Time) (*AccessToken, error) {
+	result := AccessToken{}
+	if err := r.accessTokenServerStore().Get(token, &result); err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, oauthError(oauth.InvalidGrant, param+" is invalid or expired")
+		}
+		return nil, oauthError(oauth.ServerError, "unable to retrieve "+param, err)
+	}
+	if !result.Expiration.After(now) {
+		return nil, oauthError(oauth.InvalidGrant, param+" is invalid or expired")
+	}
+	if result.Issuer != issuer {
+		return nil, oauthError(oauth.InvalidGrant, param+" was not issued by this authorization server")
+	}
+	return &result, nil
+}
+
+// validateActorTokenDPoP checks that the DPoP proof of a token exchange request is signed with the key a DPoP-bound actor token is bound to (cnf.jkt).
+// This prevents a sender-constrained actor token from being used by a party that doesn't hold its key.
+func validateActorTokenDPoP(boundTo *dpop.DPoP, proof *dpop.DPoP) error {
+	if boundTo == nil {
+		return nil
+	}
+	if proof == nil {
+		return oauthError(oauth.InvalidDPopProof, oauth.ActorTokenParam+" is DPoP-bound, a DPoP proof is required")
+	}
+	expected, _ := boundTo.Headers.JWK().Thumbprint(crypto.SHA256)
+	actual, _ := proof.Headers.JWK().Thumbprint(crypto.SHA256)
+	if !bytes.Equal(expected, actual) {
+		return oauthError(oauth.InvalidDPopProof, "DPoP proof is not signed with the key the "+oauth.ActorTokenParam+" is bound to")
+	}
+	return nil
+}
+
+// isScopeSubset returns true if all scopes in the space-delimited requested scope are part of the space-delimited granted scope.
+func isScopeSubset(requested string, granted string) bool {
+	requestedScopes := strings.Fields(requested)
+	if len(requestedScopes) == 0 {
+		return false
+	}
+	grantedScopes := strings.Fields(granted)
+	for _, scope := range requestedScopes {
+		if !slices.Contains(grantedScopes, scope) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,405 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package iam
+
+import (
+	"context"
+	"crypto"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/nuts-foundation/nuts-node/audit"
+	"github.com/nuts-foundation/nuts-node/auth/oauth"
+	"github.com/nuts-foundation/nuts-node/core/to"
+	"github.com/nuts-foundation/nuts-node/vcr/pe"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrapper_handleTokenExchangeRequest(t *testing.T) {
+	const actorID = "https://example.com/oauth2/gateway"
+	bearerRequestCtx := context.WithValue(context.Background(), httpRequestContextKey{}, &http.Request{Header: http.Header{}})
+	dpopToken, dpopHeader, _ := newSignedTestDPoP()
+	otherDPoPToken, _, _ := newSignedTestDPoP()
+	dpopRequestCtx := context.WithValue(context.Background(), httpRequestContextKey{}, &http.Request{
+		Header: http.Header{
+			"Dpop": []string{dpopHeader.String()},
+		},
+	})
+	newSubjectToken := func(t *testing.T, ctx *testCtx) AccessToken {
+		token := AccessToken{
+			Token:      "subject-token",
+			Issuer:     verifierURL.String(),
+			ClientId:   "https://example.com/oauth2/client",
+			IssuedAt:   time.Now(),
+			Expiration: time.Now().Add(time.Minute),
+			Scope:      "first second",
+			InputDescriptorConstraintIdMap: map[string]any{
+				"organization_name": "Hospital",
+			},
+			PresentationDefinitions: pe.WalletOwnerMapping{
+				pe.WalletOwnerOrganization: pe.PresentationDefinition{Id: "test"},
+			},
+		}
+		require.NoError(t, ctx.client.accessTokenServerStore().Put(token.Token, token))
+		return token
+	}
+	newActorToken := func(t *testing.T, ctx *testCtx) AccessToken {
+		token := AccessToken{
+			Token:      "actor-token",
+			Issuer:     verifierURL.String(),
+			ClientId:   actorID,
+			IssuedAt:   time.Now(),
+			Expiration: time.Now().Add(time.Minute),
+			Scope:      "gateway",
+		}
+		require.NoError(t, ctx.client.accessTokenServerStore().Put(token.Token, token))
+		return token
+	}
+	newRequest := func() HandleTokenRequestFormdataRequestBody {
+		return HandleTokenRequestFormdataRequestBody{
+			GrantType:        oauth.TokenExchangeGrantType,
+			ClientId:         to.Ptr(actorID),
+			SubjectToken:     to.Ptr("subject-token"),
+			SubjectTokenType: to.Ptr(oauth.AccessTokenTokenType),
+			ActorToken:       to.Ptr("actor-token"),
+			ActorTokenType:   to.Ptr(oauth.AccessTokenTokenType),
+			Scope:            to.Ptr("second"),
+		}
+	}
+	exchangedToken := func(t *testing.T, ctx *testCtx, response HandleTokenRequestResponseObject) AccessToken {
+		require.IsType(t, HandleTokenRequest200JSONResponse{}, response)
+		tokenResponse := TokenResponse(response.(HandleTokenRequest200JSONResponse))
+		var result AccessToken
+		require.NoError(t, ctx.client.accessTokenServerStore().Get(tokenResponse.AccessToken, &result))
+		return result
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.tokenExchangeEnabled = true
+		subjectToken := newSubjectToken(t, ctx)
+		newActorToken(t, ctx)
+
+		response, err := ctx.client.HandleTokenRequest(bearerRequestCtx, HandleTokenRequestRequestObject{
+			SubjectID: verifierSubject,
+			Body:      to.Ptr(newRequest()),
+		})
+
+		require.NoError(t, err)
+		require.IsType(t, HandleTokenRequest200JSONResponse{}, response)
+		tokenResponse := TokenResponse(response.(HandleTokenRequest200JSONResponse))
+		assert.Equal(t, AccessTokenTypeBearer, tokenResponse.TokenType)
+		assert.Equal(t, "second", *tokenResponse.Scope)
+		assert.Equal(t, oauth.AccessTokenTokenType, tokenResponse.Get(oauth.IssuedTokenTypeParam))
+		assert.NotEqual(t, subjectToken.Token, tokenResponse.AccessToken)
+		assert.LessOrEqual(t, *tokenResponse.ExpiresIn, 60)
+		accessToken := exchangedToken(t, ctx, response)
+		assert.Equal(t, subjectToken.ClientId, accessToken.ClientId)
+		assert.Equal(t, subjectToken.Issuer, accessToken.Issuer)
+		assert.Equal(t, &Actor{Sub: actorID}, accessToken.Actor)
+		assert.False(t, accessToken.Expiration.After(subjectToken.Expiration))
+		assert.Equal(t, subjectToken.InputDescriptorConstraintIdMap, accessToken.InputDescriptorConstraintIdMap)
+		assert.Equal(t, subjectToken.PresentationDefinitions, accessToken.PresentationDefinitions)
+	})
+	t.Run("ok - scope defaults to subject token scope", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.tokenExchangeEnabled = true
+		newSubjectToken(t, ctx)
+		newActorToken(t, ctx)
+		request := newRequest()
+		request.Scope = nil
+
+		response, err := ctx.client.handleTokenExchangeRequest(bearerRequestCtx, verifierSubject, request)
+
+		require.NoError(t, err)
+		assert.Equal(t, "first second", exchangedToken(t, ctx, response).Scope)
+	})
+	t.Run("ok - client_id is optional", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.tokenExchangeEnabled = true
+		newSubjectToken(t, ctx)
+		newActorToken(t, ctx)
+		request := newRequest()
+		request.ClientId = nil
+
+		response, err := ctx.client.handleTokenExchangeRequest(bearerRequestCtx, verifierSubject, request)
+
+		require.NoError(t, err)
+		assert.Equal(t, &Actor{Sub: actorID}, exchangedToken(t, ctx, response).Actor)
+	})
+	t.Run("ok - delegation chain is extended", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.tokenExchangeEnabled = true
+		subjectToken := newSubjectToken(t, ctx)
+		newActorToken(t, ctx)
+		subjectToken.Actor = &Actor{Sub: "https://example.com/oauth2/first-actor"}
+		require.NoError(t, ctx.client.accessTokenServerStore().Put(subjectToken.Token, subjectToken))
+
+		response, err := ctx.client.handleTokenExchangeRequest(bearerRequestCtx, verifierSubject, newRequest())
+
+		require.NoError(t, err)
+		assert.Equal(t, &Actor{
+			Sub: actorID,
+			Act: &Actor{Sub: "https://example.com/oauth2/first-actor"},
+		}, exchangedToken(t, ctx, response).Actor)
+	})
+	t.Run("ok - DPoP-bound", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.tokenExchangeEnabled = true
+		subjectToken := newSubjectToken(t, ctx)
+		newActorToken(t, ctx)
+		subjectToken.DPoP = dpopToken
+		require.NoError(t, ctx.client.accessTokenServerStore().Put(subjectToken.Token, subjectToken))
+
+		response, err := ctx.client.handleTokenExchangeRequest(dpopRequestCtx, verifierSubject, newRequest())
+
+		require.NoError(t, err)
+		tokenResponse := TokenResponse(response.(HandleTokenRequest200JSONResponse))
+		assert.Equal(t, AccessTokenTypeDPoP, tokenResponse.TokenType)
+		assert.NotNil(t, exchangedToken(t, ctx, response).DPoP)
+	})
+	t.Run("ok - DPoP-bound subject_token is bound to the actor's key", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.tokenExchangeEnabled = true
+		subjectToken := newSubjectToken(t, ctx)
+		subjectToken.DPoP = otherDPoPToken
+		require.NoError(t, ctx.client.accessTokenServerStore().Put(subjectToken.Token, subjectToken))
+		actorToken := newActorToken(t, ctx)
+		actorToken.DPoP = dpopToken
+		require.NoError(t, ctx.client.accessTokenServerStore().Put(actorToken.Token, actorToken))
+
+		response, err := ctx.client.handleTokenExchangeRequest(dpopRequestCtx, verifierSubject, newRequest())
+
+		require.NoError(t, err)
+		expected, _ := dpopToken.Headers.JWK().Thumbprint(crypto.SHA256)
+		actual, _ := exchangedToken(t, ctx, response).DPoP.Headers.JWK().Thumbprint(crypto.SHA256)
+		assert.Equal(t, expected, actual)
+	})
+	t.Run("ok - DPoP-bound subject_token exchanged without DPoP proof", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.tokenExchangeEnabled = true
+		subjectToken := newSubjectToken(t, ctx)
+		newActorToken(t, ctx)
+		subjectToken.DPoP = dpopToken
+		require.NoError(t, ctx.client.accessTokenServerStore().Put(subjectToken.Token, subjectToken))
+
+		response, err := ctx.client.handleTokenExchangeRequest(bearerRequestCtx, verifierSubject, newRequest())
+
+		require.NoError(t, err)
+		assert.Nil(t, exchangedToken(t, ctx, response).DPoP)
+	})
+	t.Run("error - token exchange disabled", func(t *testing.T) {
+		ctx := newTestClient(t)
+		newSubjectToken(t, ctx)
+		newActorToken(t, ctx)
+
+		response, err := ctx.client.handleTokenExchangeRequest(bearerRequestCtx, verifierSubject, newRequest())
+
+		requireOAuthError(t, err, oauth.UnsupportedGrantType, "token exchange is disabled")
+		assert.Nil(t, response)
+	})
+	t.Run("error - missing subject_token", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.tokenExchangeEnabled = true
+		request := newRequest()
+		request.SubjectToken = nil
+
+		response, err := ctx.client.handleTokenExchangeRequest(bearerRequestCtx, verifierSubject, request)
+
+		requireOAuthError(t, err, oauth.InvalidRequest, "missing required parameters")
+		assert.Nil(t, response)
+	})
+	t.Run("error - unsupported subject_token_type", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.tokenExchangeEnabled = true
+		request := newRequest()
+		request.SubjectTokenType = to.Ptr("urn:ietf:params:oauth:token-type:id_token")
+
+		response, err := ctx.client.handleTokenExchangeRequest(bearerRequestCtx, verifierSubject, request)
+
+		requireOAuthError(t, err, oauth.InvalidRequest, "unsupported subject_token_type")
+		assert.Nil(t, response)
+	})
+	t.Run("error - missing actor_token", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.tokenExchangeEnabled = true
+		request := newRequest()
+		request.ActorToken = nil
+
+		response, err := ctx.client.handleTokenExchangeRequest(bearerRequestCtx, verifierSubject, request)
+
+		requireOAuthError(t, err, oauth.InvalidRequest, "missing required parameters")
+		assert.Nil(t, response)
+	})
+	t.Run("error - unsupported actor_token_type", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.tokenExchangeEnabled = true
+		request := newRequest()
+		request.ActorTokenType = to.Ptr("urn:ietf:params:oauth:token-type:jwt")
+
+		response, err := ctx.client.handleTokenExchangeRequest(bearerRequestCtx, verifierSubject, request)
+
+		requireOAuthError(t, err, oauth.InvalidRequest, "unsupported actor_token_type")
+		assert.Nil(t, response)
+	})
+	t.Run("error - unsupported requested_token_type", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.tokenExchangeEnabled = true
+		request := newRequest()
+		request.RequestedTokenType = to.Ptr("urn:ietf:params:oauth:token-type:refresh_token")
+
+		response, err := ctx.client.handleTokenExchangeRequest(bearerRequestCtx, verifierSubject, request)
+
+		requireOAuthError(t, err, oauth.InvalidRequest, "unsupported requested_token_type")
+		assert.Nil(t, response)
+	})
+	t.Run("error - unknown subject_token", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.tokenExchangeEnabled = true
+
+		response, err := ctx.client.handleTokenExchangeRequest(bearerRequestCtx, verifierSubject, newRequest())
+
+		requireOAuthError(t, err, oauth.InvalidGrant, "subject_token is invalid or expired")
+		assert.Nil(t, response)
+	})
+	t.Run("error - expired subject_token", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.tokenExchangeEnabled = true
+		subjectToken := newSubjectToken(t, ctx)
+		newActorToken(t, ctx)
+		subjectToken.Expiration = time.Now().Add(-time.Second)
+		require.NoError(t, ctx.client.accessTokenServerStore().Put(subjectToken.Token, subjectToken))
+
+		response, err := ctx.client.handleTokenExchangeRequest(bearerRequestCtx, verifierSubject, newRequest())
+
+		requireOAuthError(t, err, oauth.InvalidGrant, "subject_token is invalid or expired")
+		assert.Nil(t, response)
+	})
+	t.Run("error - revoked subject_token", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.tokenExchangeEnabled = true
+		subjectToken := newSubjectToken(t, ctx)
+		newActorToken(t, ctx)
+		_, err := ctx.client.RevokeToken(audit.TestContext(), RevokeTokenRequestObject{
+			SubjectID: verifierSubject,
+			Body:      &RevokeTokenFormdataRequestBody{Token: subjectToken.Token, ClientId: subjectToken.ClientId},
+		})
+		require.NoError(t, err)
+
+		response, err := ctx.client.handleTokenExchangeRequest(bearerRequestCtx, verifierSubject, newRequest())
+
+		requireOAuthError(t, err, oauth.InvalidGrant, "subject_token is invalid or expired")
+		assert.Nil(t, response)
+	})
+	t.Run("error - subject_token issued by other subject", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.tokenExchangeEnabled = true
+		newSubjectToken(t, ctx)
+		newActorToken(t, ctx)
+
+		response, err := ctx.client.handleTokenExchangeRequest(bearerRequestCtx, holderSubjectID, newRequest())
+
+		requireOAuthError(t, err, oauth.InvalidGrant, "subject_token was not issued by this authorization server")
+		assert.Nil(t, response)
+	})
+	t.Run("error - unknown actor_token", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.tokenExchangeEnabled = true
+		newSubjectToken(t, ctx)
+
+		response, err := ctx.client.handleTokenExchangeRequest(bearerRequestCtx, verifierSubject, newRequest())
+
+		requireOAuthError(t, err, oauth.InvalidGrant, "actor_token is invalid or expired")
+		assert.Nil(t, response)
+	})
+	t.Run("error - actor_token issued by other authorization server", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.tokenExchangeEnabled = true
+		newSubjectToken(t, ctx)
+		actorToken := newActorToken(t, ctx)
+		actorToken.Issuer = "https://example.com/oauth2/other"
+		require.NoError(t, ctx.client.accessTokenServerStore().Put(actorToken.Token, actorToken))
+
+		response, err := ctx.client.handleTokenExchangeRequest(bearerRequestCtx, verifierSubject, newRequest())
+
+		requireOAuthError(t, err, oauth.InvalidGrant, "actor_token was not issued by this authorization server")
+		assert.Nil(t, response)
+	})
+	t.Run("error - client_id does not match actor_token", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.tokenExchangeEnabled = true
+		newSubjectToken(t, ctx)
+		newActorToken(t, ctx)
+		request := newRequest()
+		request.ClientId = to.Ptr("https://example.com/oauth2/impostor")
+
+		response, err := ctx.client.handleTokenExchangeRequest(bearerRequestCtx, verifierSubject, request)
+
+		requireOAuthError(t, err, oauth.InvalidGrant, "client_id does not match the actor_token")
+		assert.Nil(t, response)
+	})
+	t.Run("error - scope exceeds subject_token scope", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.tokenExchangeEnabled = true
+		newSubjectToken(t, ctx)
+		newActorToken(t, ctx)
+		request := newRequest()
+		request.Scope = to.Ptr("second third")
+
+		response, err := ctx.client.handleTokenExchangeRequest(bearerRequestCtx, verifierSubject, request)
+
+		requireOAuthError(t, err, oauth.InvalidScope, "requested scope exceeds the scope of the subject_token")
+		assert.Nil(t, response)
+	})
+	t.Run("error - DPoP-bound actor_token without DPoP proof", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.tokenExchangeEnabled = true
+		newSubjectToken(t, ctx)
+		actorToken := newActorToken(t, ctx)
+		actorToken.DPoP = dpopToken
+		require.NoError(t, ctx.client.accessTokenServerStore().Put(actorToken.Token, actorToken))
+
+		response, err := ctx.client.handleTokenExchangeRequest(bearerRequestCtx, verifierSubject, newRequest())
+
+		requireOAuthError(t, err, oauth.InvalidDPopProof, "actor_token is DPoP-bound, a DPoP proof is required")
+		assert.Nil(t, response)
+	})
+	t.Run("error - DPoP proof signed with other key than actor_token is bound to", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.tokenExchangeEnabled = true
+		newSubjectToken(t, ctx)
+		actorToken := newActorToken(t, ctx)
+		actorToken.DPoP = otherDPoPToken
+		require.NoError(t, ctx.client.accessTokenServerStore().Put(actorToken.Token, actorToken))
+
+		response, err := ctx.client.handleTokenExchangeRequest(dpopRequestCtx, verifierSubject, newRequest())
+
+		requireOAuthError(t, err, oauth.InvalidDPopProof, "DPoP proof is not signed with the key the actor_token is bound to")
+		assert.Nil(t, response)
+	})
+}
+
+func Test_isScopeSubset(t *testing.T) {
+	assert.True(t, isScopeSubset("a", "a b"))
+	assert.True(t, isScopeSubset("b a", "a b"))
+	assert.False(t, isScopeSubset("a c", "a b"))
+	assert.False(t, isScopeSubset(" ", "a b"))
+}
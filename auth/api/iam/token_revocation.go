@@ -0,0 +1,166 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package iam
+
+import (
+	"context"
+	"errors"
+
+	"github.com/nuts-foundation/nuts-node/audit"
+	"github.com/nuts-foundation/nuts-node/auth/log"
+	"github.com/nuts-foundation/nuts-node/auth/oauth"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/storage"
+)
+
+// token type hints as specified by RFC7009 section 2.1
+const (
+	accessTokenTypeHint  = "access_token"
+	refreshTokenTypeHint = "refresh_token"
+)
+
+// revocableToken is an access token or refresh token issued by this node, that can be revoked.
+type revocableToken struct {
+	// value is the token as presented to the node.
+	value string
+	// tokenType is either accessTokenTypeHint or refreshTokenTypeHint.
+	tokenType string
+	// accessToken is the access token, or the access token the refresh token was issued for.
+	accessToken AccessToken
+	store       storage.SessionStore
+}
+
+// RevokeToken handles token revocation requests as specified by RFC7009.
+// Clients don't authenticate at the token endpoint, so instead of client authentication (RFC7009 section 2.1) the client_id is required,
+// and it must match the client the token was issued to.
+// Tokens that are unknown, expired or issued by another subject are ignored, as specified by RFC7009 section 2.2.
+func (r Wrapper) RevokeToken(ctx context.Context, request RevokeTokenRequestObject) (RevokeTokenResponseObject, error) {
+	if request.Body == nil || request.Body.Token == "" || request.Body.ClientId == "" {
+		return nil, oauthError(oauth.InvalidRequest, "missing required parameters")
+	}
+	preferRefreshToken := request.Body.TokenTypeHint != nil && *request.Body.TokenTypeHint == refreshTokenTypeHint
+	token, err := r.findRevocableToken(request.Body.Token, preferRefreshToken)
+	if err != nil {
+		return nil, oauthError(oauth.ServerError, "unable to retrieve token", err)
+	}
+	issuerURL := r.subjectToBaseURL(request.SubjectID)
+	if token == nil || token.accessToken.Issuer != issuerURL.String() {
+		return RevokeToken200Response{}, nil
+	}
+	if request.Body.ClientId != token.accessToken.ClientId {
+		return nil, oauthError(oauth.UnauthorizedClient, "token was not issued to this client")
+	}
+	if err = r.revokeToken(ctx, *token); err != nil {
+		return nil, oauthError(oauth.ServerError, "unable to revoke token", err)
+	}
+	return RevokeToken200Response{}, nil
+}
+
+// RevokeAccessToken allows the node operator to revoke an access token or refresh token issued by this node.
+func (r Wrapper) RevokeAccessToken(ctx context.Context, request RevokeAccessTokenRequestObject) (RevokeAccessTokenResponseObject, error) {
+	if request.Body == nil || request.Body.Token == "" {
+		return nil, core.InvalidInputError("missing token")
+	}
+	token, err := r.findRevocableToken(request.Body.Token, false)
+	if err != nil {
+		return nil, err
+	}
+	if token == nil {
+		log.Logger().Debug("RevokeAccessToken: token not found (unknown or expired)")
+		return RevokeAccessToken204Response{}, nil
+	}
+	if err = r.revokeToken(ctx, *token); err != nil {
+		return nil, err
+	}
+	return RevokeAccessToken204Response{}, nil
+}
+
+// findRevocableToken looks up the given token in the access token and refresh token stores.
+// It returns nil if the token is unknown or expired.
+func (r Wrapper) findRevocableToken(value string, preferRefreshToken bool) (*revocableToken, error) {
+	lookups := []func() (*revocableToken, error){
+		func() (*revocableToken, error) {
+			var accessToken AccessToken
+			store := r.accessTokenServerStore()
+			if err := store.Get(value, &accessToken); err != nil {
+				return nil, err
+			}
+			return &revocableToken{value: value, tokenType: accessTokenTypeHint, accessToken: accessToken, store: store}, nil
+		},
+		func() (*revocableToken, error) {
+			var refreshToken RefreshToken
+			store := r.refreshTokenServerStore()
+			if err := store.Get(value, &refreshToken); err != nil {
+				return nil, err
+			}
+			return &revocableToken{value: value, tokenType: refreshTokenTypeHint, accessToken: refreshToken.AccessToken, store: store}, nil
+		},
+	}
+	if preferRefreshToken {
+		lookups[0], lookups[1] = lookups[1], lookups[0]
+	}
+	for _, lookup := range lookups {
+		token, err := lookup()
+		if err == nil {
+			return token, nil
+		}
+		if !errors.Is(err, storage.ErrNotFound) {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// revokeToken removes the given token from its store, so it can't be used anymore and introspection reports it as inactive.
+// The access token and refresh token that were issued together are always revoked together (RFC7009 section 2.1).
+// Note that resource servers that validate JWT access tokens themselves (without introspection) will accept a revoked JWT access token until it expires.
+func (r Wrapper) revokeToken(ctx context.Context, token revocableToken) error {
+	if err := token.store.Delete(token.value); err != nil {
+		return err
+	}
+	if token.tokenType == refreshTokenTypeHint {
+		if err := r.accessTokenServerStore().Delete(token.accessToken.Token); err != nil {
+			return err
+		}
+	} else {
+		var refreshToken string
+		err := r.refreshTokenLinkStore().Get(token.accessToken.Token, &refreshToken)
+		if err == nil {
+			err = r.refreshTokenServerStore().Delete(refreshToken)
+		}
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return err
+		}
+	}
+	if err := r.refreshTokenLinkStore().Delete(token.accessToken.Token); err != nil {
+		return err
+	}
+	audit.Log(ctx, log.Logger(), audit.AccessTokenRevokedEvent).
+		WithField("token_type", token.tokenType).
+		WithField("client_id", token.accessToken.ClientId).
+		WithField("issuer", token.accessToken.Issuer).
+		Info("Revoked OAuth2 token")
+	return nil
+}
+
+// refreshTokenLinkStore maps access tokens to the refresh token that was issued alongside them,
+// so revoking an access token revokes its refresh token as well.
+func (r Wrapper) refreshTokenLinkStore() storage.SessionStore {
+	return r.storageEngine.GetSessionDatabase().GetStore(accessTokenValidity, "serverrefreshtokenlink")
+}
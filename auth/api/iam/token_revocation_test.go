@@ -0,0 +1,192 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package iam
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nuts-foundation/nuts-node/audit"
+	"github.com/nuts-foundation/nuts-node/auth/oauth"
+	"github.com/nuts-foundation/nuts-node/core/to"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrapper_RevokeToken(t *testing.T) {
+	const clientID = "https://example.com/oauth2/client"
+	newAccessToken := func(t *testing.T, ctx *testCtx) AccessToken {
+		token := AccessToken{
+			Token:      "access-token",
+			Issuer:     verifierURL.String(),
+			ClientId:   clientID,
+			IssuedAt:   time.Now(),
+			Expiration: time.Now().Add(time.Minute),
+		}
+		require.NoError(t, ctx.client.accessTokenServerStore().Put(token.Token, token))
+		return token
+	}
+	newRequest := func(token string) RevokeTokenRequestObject {
+		return RevokeTokenRequestObject{
+			SubjectID: verifierSubject,
+			Body: &RevokeTokenFormdataRequestBody{
+				Token:    token,
+				ClientId: clientID,
+			},
+		}
+	}
+
+	t.Run("ok - access token", func(t *testing.T) {
+		ctx := newTestClient(t)
+		capturedLog := audit.CaptureAuditLogs(t)
+		newAccessToken(t, ctx)
+
+		response, err := ctx.client.RevokeToken(audit.TestContext(), newRequest("access-token"))
+
+		require.NoError(t, err)
+		assert.IsType(t, RevokeToken200Response{}, response)
+		assert.False(t, ctx.client.accessTokenServerStore().Exists("access-token"))
+		capturedLog.AssertContains(t, "Auth", audit.AccessTokenRevokedEvent, audit.TestActor, "Revoked OAuth2 token")
+		t.Run("introspection reports token as inactive", func(t *testing.T) {
+			response, err := ctx.client.introspectAccessToken("access-token")
+
+			require.NoError(t, err)
+			assert.Nil(t, response)
+		})
+	})
+	t.Run("ok - refresh token also revokes its access token", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.refreshTokenValidity = time.Hour
+		accessToken := newAccessToken(t, ctx)
+		require.NoError(t, ctx.client.refreshTokenServerStore().Put("refresh-token", RefreshToken{AccessToken: accessToken, Expiration: time.Now().Add(time.Hour)}))
+		request := newRequest("refresh-token")
+		request.Body.TokenTypeHint = to.Ptr("refresh_token")
+
+		_, err := ctx.client.RevokeToken(audit.TestContext(), request)
+
+		require.NoError(t, err)
+		assert.False(t, ctx.client.refreshTokenServerStore().Exists("refresh-token"))
+		assert.False(t, ctx.client.accessTokenServerStore().Exists("access-token"))
+	})
+	t.Run("ok - access token also revokes its refresh token", func(t *testing.T) {
+		ctx := newTestClient(t)
+		*ctx.refreshTokenValidity = time.Hour
+		accessToken := newAccessToken(t, ctx)
+		tokenResponse := oauth.TokenResponse{}
+		require.NoError(t, ctx.client.issueRefreshToken(&tokenResponse, accessToken, time.Now().Add(time.Hour)))
+		require.NotNil(t, tokenResponse.RefreshToken)
+
+		_, err := ctx.client.RevokeToken(audit.TestContext(), newRequest("access-token"))
+
+		require.NoError(t, err)
+		assert.False(t, ctx.client.accessTokenServerStore().Exists("access-token"))
+		assert.False(t, ctx.client.refreshTokenServerStore().Exists(*tokenResponse.RefreshToken))
+		assert.False(t, ctx.client.refreshTokenLinkStore().Exists("access-token"))
+	})
+	t.Run("ok - token type hint is only a hint", func(t *testing.T) {
+		ctx := newTestClient(t)
+		newAccessToken(t, ctx)
+		request := newRequest("access-token")
+		request.Body.TokenTypeHint = to.Ptr("refresh_token")
+
+		_, err := ctx.client.RevokeToken(audit.TestContext(), request)
+
+		require.NoError(t, err)
+		assert.False(t, ctx.client.accessTokenServerStore().Exists("access-token"))
+	})
+	t.Run("ok - unknown token", func(t *testing.T) {
+		ctx := newTestClient(t)
+
+		response, err := ctx.client.RevokeToken(audit.TestContext(), newRequest("unknown"))
+
+		require.NoError(t, err)
+		assert.IsType(t, RevokeToken200Response{}, response)
+	})
+	t.Run("ok - token issued by other subject is not revoked", func(t *testing.T) {
+		ctx := newTestClient(t)
+		newAccessToken(t, ctx)
+		request := newRequest("access-token")
+		request.SubjectID = holderSubjectID
+
+		response, err := ctx.client.RevokeToken(audit.TestContext(), request)
+
+		require.NoError(t, err)
+		assert.IsType(t, RevokeToken200Response{}, response)
+		assert.True(t, ctx.client.accessTokenServerStore().Exists("access-token"))
+	})
+	t.Run("error - missing token", func(t *testing.T) {
+		ctx := newTestClient(t)
+
+		_, err := ctx.client.RevokeToken(audit.TestContext(), newRequest(""))
+
+		requireOAuthError(t, err, oauth.InvalidRequest, "missing required parameters")
+	})
+	t.Run("error - missing client_id", func(t *testing.T) {
+		ctx := newTestClient(t)
+		newAccessToken(t, ctx)
+		request := newRequest("access-token")
+		request.Body.ClientId = ""
+
+		_, err := ctx.client.RevokeToken(audit.TestContext(), request)
+
+		requireOAuthError(t, err, oauth.InvalidRequest, "missing required parameters")
+		assert.True(t, ctx.client.accessTokenServerStore().Exists("access-token"))
+	})
+	t.Run("error - token issued to other client", func(t *testing.T) {
+		ctx := newTestClient(t)
+		newAccessToken(t, ctx)
+		request := newRequest("access-token")
+		request.Body.ClientId = "https://example.com/oauth2/other"
+
+		_, err := ctx.client.RevokeToken(audit.TestContext(), request)
+
+		requireOAuthError(t, err, oauth.UnauthorizedClient, "token was not issued to this client")
+		assert.True(t, ctx.client.accessTokenServerStore().Exists("access-token"))
+	})
+}
+
+func TestWrapper_RevokeAccessToken(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctx := newTestClient(t)
+		capturedLog := audit.CaptureAuditLogs(t)
+		require.NoError(t, ctx.client.accessTokenServerStore().Put("access-token", AccessToken{Token: "access-token", Issuer: verifierURL.String()}))
+
+		response, err := ctx.client.RevokeAccessToken(audit.TestContext(), RevokeAccessTokenRequestObject{Body: &TokenRevocationRequest{Token: "access-token"}})
+
+		require.NoError(t, err)
+		assert.IsType(t, RevokeAccessToken204Response{}, response)
+		assert.False(t, ctx.client.accessTokenServerStore().Exists("access-token"))
+		capturedLog.AssertContains(t, "Auth", audit.AccessTokenRevokedEvent, audit.TestActor, "Revoked OAuth2 token")
+	})
+	t.Run("ok - unknown token", func(t *testing.T) {
+		ctx := newTestClient(t)
+
+		response, err := ctx.client.RevokeAccessToken(audit.TestContext(), RevokeAccessTokenRequestObject{Body: &TokenRevocationRequest{Token: "unknown"}})
+
+		require.NoError(t, err)
+		assert.IsType(t, RevokeAccessToken204Response{}, response)
+	})
+	t.Run("error - missing token", func(t *testing.T) {
+		ctx := newTestClient(t)
+
+		_, err := ctx.client.RevokeAccessToken(audit.TestContext(), RevokeAccessTokenRequestObject{Body: &TokenRevocationRequest{}})
+
+		assert.EqualError(t, err, "missing token")
+	})
+}
@@ -52,6 +52,9 @@ type RedirectResponse = oauth.Redirect
 // TokenResponse is an alias
 type TokenResponse = oauth.TokenResponse
 
+// PushedAuthorizationResponse is an alias
+type PushedAuthorizationResponse = oauth.PushedAuthorizationResponse
+
 // OAuthAuthorizationServerMetadata is an alias
 type OAuthAuthorizationServerMetadata = oauth.AuthorizationServerMetadata
 
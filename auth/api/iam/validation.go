@@ -96,3 +96,24 @@ func (r Wrapper) presentationDefinitionForScope(ctx context.Context, scope strin
 	}
 	return mapping, err
 }
+
+// openID4VPVerifierForScope creates the PEXConsumer for the OpenID4VP flows of the given scope.
+// If the scope is expressed in DCQL, the DCQL queries need to be fulfilled. Otherwise, the Presentation Definitions need to be fulfilled.
+func (r Wrapper) openID4VPVerifierForScope(ctx context.Context, scope string) (*PEXConsumer, error) {
+	queries, err := r.policyBackend.DCQLQueries(ctx, scope)
+	if err == nil {
+		return newDCQLConsumer(queries), nil
+	}
+	if !errors.Is(err, policy.ErrNotFound) {
+		return nil, oauth.OAuth2Error{
+			Code:          oauth.ServerError,
+			InternalError: err,
+			Description:   fmt.Sprintf("failed to retrieve DCQL query for scope (%s): %s", scope, err.Error()),
+		}
+	}
+	presentationDefinitions, err := r.presentationDefinitionForScope(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+	return newPEXConsumer(presentationDefinitions), nil
+}
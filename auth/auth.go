@@ -21,6 +21,7 @@ package auth
 import (
 	"crypto/tls"
 	"errors"
+	"fmt"
 	"github.com/nuts-foundation/nuts-node/auth/client/iam"
 	"github.com/nuts-foundation/nuts-node/vdr"
 	"github.com/nuts-foundation/nuts-node/vdr/didjwk"
@@ -93,6 +94,35 @@ func (auth *Auth) AuthorizationEndpointEnabled() bool {
 	return auth.config.AuthorizationEndpoint.Enabled
 }
 
+// RefreshTokenValidity returns the maximum validity of refresh tokens issued by the v2 API's token endpoint,
+// or 0 if refresh tokens are disabled.
+func (auth *Auth) RefreshTokenValidity() time.Duration {
+	if !auth.config.RefreshToken.Enabled {
+		return 0
+	}
+	return auth.config.RefreshToken.Validity
+}
+
+// JWTAccessTokensEnabled returns whether the v2 API's token endpoint issues JWT access tokens (RFC9068) instead of opaque access tokens.
+func (auth *Auth) JWTAccessTokensEnabled() bool {
+	return auth.config.AccessToken.Format == AccessTokenFormatJWT
+}
+
+// AccessTokenAudiences returns the resource servers (mapped by scope) JWT access tokens issued by the v2 API's token endpoint are intended for.
+func (auth *Auth) AccessTokenAudiences() map[string]string {
+	return auth.config.AccessToken.Audiences
+}
+
+// TokenExchangeEnabled returns whether the v2 API's token endpoint supports the OAuth 2.0 Token Exchange grant type.
+func (auth *Auth) TokenExchangeEnabled() bool {
+	return auth.config.TokenExchange.Enabled
+}
+
+// DomainLinkageRequired returns whether clients of the service-to-service flow must prove their DID is linked to the origin of their client_id.
+func (auth *Auth) DomainLinkageRequired() bool {
+	return auth.config.DomainLinkage.Required
+}
+
 // ContractNotary returns an implementation of the ContractNotary interface.
 func (auth *Auth) ContractNotary() services.ContractNotary {
 	return auth.contractNotary
@@ -131,6 +161,16 @@ func (auth *Auth) IAMClient() iam.Client {
 
 // Configure the Auth struct by creating a validator and create an Irma server
 func (auth *Auth) Configure(config core.ServerConfig) error {
+	if auth.config.RefreshToken.Enabled && auth.config.RefreshToken.Validity <= 0 {
+		return errors.New("auth.refreshtoken.validity must be greater than 0")
+	}
+	if auth.config.AccessToken.Format != AccessTokenFormatOpaque && auth.config.AccessToken.Format != AccessTokenFormatJWT {
+		return fmt.Errorf("auth.accesstoken.format must be '%s' or '%s'", AccessTokenFormatOpaque, AccessTokenFormatJWT)
+	}
+	if auth.config.AccessToken.Format == AccessTokenFormatJWT && len(auth.config.AccessToken.Audiences) == 0 {
+		return errors.New("auth.accesstoken.audiences must be set when auth.accesstoken.format is 'jwt'")
+	}
+
 	if auth.config.Irma.SchemeManager == "" {
 		return errors.New("IRMA SchemeManager must be set")
 	}
@@ -29,6 +29,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"testing"
+	"time"
 )
 
 func TestAuth_Configure(t *testing.T) {
@@ -51,6 +52,31 @@ func TestAuth_Configure(t *testing.T) {
 
 		require.NoError(t, i.Configure(tlsServerConfig))
 	})
+	t.Run("error - invalid refresh token validity", func(t *testing.T) {
+		config := DefaultConfig()
+		config.RefreshToken.Enabled = true
+		config.RefreshToken.Validity = 0
+
+		i := NewAuthInstance(config, nil, nil, nil, nil, nil, nil, nil)
+
+		assert.EqualError(t, i.Configure(tlsServerConfig), "auth.refreshtoken.validity must be greater than 0")
+	})
+	t.Run("error - invalid access token format", func(t *testing.T) {
+		config := DefaultConfig()
+		config.AccessToken.Format = "paseto"
+
+		i := NewAuthInstance(config, nil, nil, nil, nil, nil, nil, nil)
+
+		assert.EqualError(t, i.Configure(tlsServerConfig), "auth.accesstoken.format must be 'opaque' or 'jwt'")
+	})
+	t.Run("error - JWT access tokens without audiences", func(t *testing.T) {
+		config := DefaultConfig()
+		config.AccessToken.Format = AccessTokenFormatJWT
+
+		i := NewAuthInstance(config, nil, nil, nil, nil, nil, nil, nil)
+
+		assert.EqualError(t, i.Configure(tlsServerConfig), "auth.accesstoken.audiences must be set when auth.accesstoken.format is 'jwt'")
+	})
 	t.Run("use legacy auth.http.timeout config", func(t *testing.T) {
 		config := DefaultConfig()
 		config.HTTPTimeout = 10
@@ -145,3 +171,31 @@ func TestAuth_SupportedDIDMethods(t *testing.T) {
 		assert.Contains(t, (&Auth{configuredDIDMethods: []string{"web"}}).SupportedDIDMethods(), "web")
 	})
 }
+
+func TestAuth_RefreshTokenValidity(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		assert.Zero(t, (&Auth{config: DefaultConfig()}).RefreshTokenValidity())
+	})
+	t.Run("enabled", func(t *testing.T) {
+		config := DefaultConfig()
+		config.RefreshToken.Enabled = true
+		assert.Equal(t, 24*time.Hour, (&Auth{config: config}).RefreshTokenValidity())
+	})
+}
+
+func TestAuth_JWTAccessTokensEnabled(t *testing.T) {
+	t.Run("opaque (default)", func(t *testing.T) {
+		assert.False(t, (&Auth{config: DefaultConfig()}).JWTAccessTokensEnabled())
+	})
+	t.Run("jwt", func(t *testing.T) {
+		config := DefaultConfig()
+		config.AccessToken.Format = AccessTokenFormatJWT
+		assert.True(t, (&Auth{config: config}).JWTAccessTokensEnabled())
+	})
+}
+
+func TestAuth_AccessTokenAudiences(t *testing.T) {
+	config := DefaultConfig()
+	config.AccessToken.Audiences = map[string]string{"patient:read": "https://fhir.example.com"}
+	assert.Equal(t, map[string]string{"patient:read": "https://fhir.example.com"}, (&Auth{config: config}).AccessTokenAudiences())
+}
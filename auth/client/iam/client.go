@@ -38,6 +38,7 @@ import (
 	"github.com/nuts-foundation/nuts-node/auth/log"
 	"github.com/nuts-foundation/nuts-node/auth/oauth"
 	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/vcr/dcql"
 	"github.com/nuts-foundation/nuts-node/vcr/pe"
 )
 
@@ -168,6 +169,38 @@ func (hb HTTPClient) RequestObjectByPost(ctx context.Context, requestURI string,
 	return string(data), err
 }
 
+// PushedAuthorizationRequest posts the authorization request to the pushed authorization request endpoint (RFC9126) and returns the response.
+func (hb HTTPClient) PushedAuthorizationRequest(ctx context.Context, endpoint string, form url.Values) (*oauth.PushedAuthorizationResponse, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Add("Accept", "application/json")
+	request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	response, err := hb.httpClient.Do(request.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to call endpoint: %w", err)
+	}
+	if err = core.TestResponseCode(http.StatusCreated, response); err != nil {
+		httpErr := err.(core.HttpError)
+		if response.StatusCode == http.StatusBadRequest || response.StatusCode == http.StatusUnauthorized {
+			oauthError := oauth.OAuth2Error{}
+			if json.Unmarshal(httpErr.ResponseBody, &oauthError) == nil && oauthError.Code != "" {
+				return nil, oauthError
+			}
+		}
+		return nil, err
+	}
+	var result oauth.PushedAuthorizationResponse
+	if err = json.NewDecoder(response.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal response: %w", err)
+	}
+	if result.RequestURI == "" {
+		return nil, errors.New("response does not contain a request_uri")
+	}
+	return &result, nil
+}
+
 func (hb HTTPClient) AccessToken(ctx context.Context, tokenEndpoint string, data url.Values, dpopHeader string) (oauth.TokenResponse, error) {
 	var token oauth.TokenResponse
 	tokenURL, err := url.Parse(tokenEndpoint)
@@ -242,6 +275,20 @@ func (hb HTTPClient) PostAuthorizationResponse(ctx context.Context, vp vc.Verifi
 	return hb.postFormExpectRedirect(ctx, data, verifierResponseURI)
 }
 
+// PostDCQLAuthorizationResponse posts the authorization response to a DCQL query to the verifier response URL and returns the callback URL.
+// The vp_token is a JSON object containing the presentations keyed by Credential Query ID, and there's no presentation_submission.
+func (hb HTTPClient) PostDCQLAuthorizationResponse(ctx context.Context, vpToken dcql.VPToken, verifierResponseURI url.URL, state string) (string, error) {
+	vpTokenBytes, err := json.Marshal(vpToken)
+	if err != nil {
+		return "", err
+	}
+	data := url.Values{}
+	data.Set(oauth.VpTokenParam, string(vpTokenBytes))
+	data.Set(oauth.StateParam, state)
+
+	return hb.postFormExpectRedirect(ctx, data, verifierResponseURI)
+}
+
 func (hb HTTPClient) OpenIdCredentialIssuerMetadata(ctx context.Context, oauthIssuerURI string) (*oauth.OpenIDCredentialIssuerMetadata, error) {
 	metadataURL, err := oauth.IssuerIdToWellKnown(oauthIssuerURI, oauth.OpenIdCredIssuerWellKnown, hb.strictMode)
 	if err != nil {
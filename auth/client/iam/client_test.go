@@ -42,6 +42,7 @@ import (
 	"github.com/nuts-foundation/nuts-node/core"
 	"github.com/nuts-foundation/nuts-node/test"
 	http2 "github.com/nuts-foundation/nuts-node/test/http"
+	"github.com/nuts-foundation/nuts-node/vcr/dcql"
 	"github.com/nuts-foundation/nuts-node/vcr/pe"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -330,6 +331,29 @@ func TestHTTPClient_PostAuthorizationResponse(t *testing.T) {
 	})
 }
 
+func TestHTTPClient_PostDCQLAuthorizationResponse(t *testing.T) {
+	presentation, _ := vc.ParseVerifiablePresentation(`{"id": "https://test.test"}`)
+	redirectReturn := oauth.Redirect{
+		RedirectURI: "http://test.test",
+	}
+	t.Run("ok", func(t *testing.T) {
+		ctx := context.Background()
+		handler := http2.Handler{StatusCode: http.StatusOK, ResponseData: redirectReturn}
+		tlsServer, client := testServerAndClient(t, &handler)
+		tlsServerURL := test.MustParseURL(tlsServer.URL)
+
+		redirectURI, err := client.PostDCQLAuthorizationResponse(ctx, dcql.VPToken{"organization": {*presentation}}, *tlsServerURL, "state")
+
+		require.NoError(t, err)
+		assert.Equal(t, redirectReturn.RedirectURI, redirectURI)
+		form, err := url.ParseQuery(string(handler.RequestData))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"organization": [{"id": "https://test.test"}]}`, form.Get(oauth.VpTokenParam))
+		assert.Equal(t, "state", form.Get(oauth.StateParam))
+		assert.False(t, form.Has(oauth.PresentationSubmissionParam))
+	})
+}
+
 func TestHTTPClient_postFormExpectRedirect(t *testing.T) {
 	redirectReturn := oauth.Redirect{
 		RedirectURI: "http://test.test",
@@ -420,6 +444,53 @@ func TestHTTPClient_RequestObjectPost(t *testing.T) {
 	})
 }
 
+func TestHTTPClient_PushedAuthorizationRequest(t *testing.T) {
+	ctx := context.Background()
+	form := url.Values{oauth.ClientIDParam: {"client"}, oauth.RequestParam: {"signed request"}}
+	t.Run("ok", func(t *testing.T) {
+		handler := http2.Handler{StatusCode: http.StatusCreated, ResponseData: oauth.PushedAuthorizationResponse{RequestURI: "urn:ietf:params:oauth:request_uri:ref", ExpiresIn: 60}}
+		tlsServer, client := testServerAndClient(t, &handler)
+
+		response, err := client.PushedAuthorizationRequest(ctx, tlsServer.URL, form)
+
+		require.NoError(t, err)
+		assert.Equal(t, "urn:ietf:params:oauth:request_uri:ref", response.RequestURI)
+		assert.Equal(t, "application/x-www-form-urlencoded", handler.RequestHeaders.Get("Content-Type"))
+		assert.Equal(t, form.Encode(), string(handler.RequestData))
+	})
+	t.Run("error - oauth error", func(t *testing.T) {
+		handler := http2.Handler{StatusCode: http.StatusBadRequest, ResponseData: oauth.OAuth2Error{Code: oauth.InvalidRequestObject, Description: "invalid signature"}}
+		tlsServer, client := testServerAndClient(t, &handler)
+
+		response, err := client.PushedAuthorizationRequest(ctx, tlsServer.URL, form)
+
+		var oauthErr oauth.OAuth2Error
+		require.ErrorAs(t, err, &oauthErr)
+		assert.Equal(t, oauth.InvalidRequestObject, oauthErr.Code)
+		assert.Nil(t, response)
+	})
+	t.Run("error - other status code", func(t *testing.T) {
+		handler := http2.Handler{StatusCode: http.StatusInternalServerError, ResponseData: "throw this away"}
+		tlsServer, client := testServerAndClient(t, &handler)
+
+		response, err := client.PushedAuthorizationRequest(ctx, tlsServer.URL, form)
+
+		var httpErr core.HttpError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusInternalServerError, httpErr.StatusCode)
+		assert.Nil(t, response)
+	})
+	t.Run("error - missing request_uri", func(t *testing.T) {
+		handler := http2.Handler{StatusCode: http.StatusCreated, ResponseData: map[string]interface{}{"expires_in": 60}}
+		tlsServer, client := testServerAndClient(t, &handler)
+
+		response, err := client.PushedAuthorizationRequest(ctx, tlsServer.URL, form)
+
+		assert.EqualError(t, err, "response does not contain a request_uri")
+		assert.Nil(t, response)
+	})
+}
+
 func TestHTTPClient_doGet(t *testing.T) {
 	t.Run("error - non 200 return value", func(t *testing.T) {
 		handler := http2.Handler{StatusCode: http.StatusBadRequest}
@@ -468,6 +539,10 @@ func (t testKeyResolver) ResolveKey(id did.DID, validAt *time.Time, relationType
 	return t.kid, t.key.Public(), nil
 }
 
+func (t testKeyResolver) ResolveKeys(id did.DID, validAt *time.Time, relationType resolver.RelationType) (map[string]crypto.PublicKey, error) {
+	return map[string]crypto.PublicKey{t.kid: t.key.Public()}, nil
+}
+
 func testServerAndClient(t *testing.T, handler http.Handler) (*httptest.Server, *HTTPClient) {
 	tlsServer := http2.TestTLSServer(t, handler)
 	return tlsServer, &HTTPClient{
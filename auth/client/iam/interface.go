@@ -22,6 +22,7 @@ import (
 	"context"
 	"github.com/nuts-foundation/go-did/vc"
 	"github.com/nuts-foundation/nuts-node/auth/oauth"
+	"github.com/nuts-foundation/nuts-node/vcr/dcql"
 	"github.com/nuts-foundation/nuts-node/vcr/pe"
 )
 
@@ -41,8 +42,16 @@ type Client interface {
 	PostError(ctx context.Context, auth2Error oauth.OAuth2Error, verifierResponseURI string, verifierClientState string) (string, error)
 	// PostAuthorizationResponse posts the authorization response to the verifier. If it fails, an error is returned.
 	PostAuthorizationResponse(ctx context.Context, vp vc.VerifiablePresentation, presentationSubmission pe.PresentationSubmission, verifierResponseURI string, state string) (string, error)
+	// PostDCQLAuthorizationResponse posts the authorization response to a DCQL query to the verifier. If it fails, an error is returned.
+	PostDCQLAuthorizationResponse(ctx context.Context, vpToken dcql.VPToken, verifierResponseURI string, state string) (string, error)
 	// PresentationDefinition returns the presentation definition from the given endpoint.
 	PresentationDefinition(ctx context.Context, endpoint string) (*pe.PresentationDefinition, error)
+	// PushedAuthorizationRequest pushes the signed authorization request object to the pushed authorization request endpoint of a remote Authorization Server (RFC9126).
+	// It returns the request_uri to use in the authorization request.
+	PushedAuthorizationRequest(ctx context.Context, endpoint string, clientID string, requestObject string) (*oauth.PushedAuthorizationResponse, error)
+	// RefreshAccessToken uses a refresh token to request a new access token from a remote OAuth2 Authorization Server.
+	// If dpopKid is not empty, the request contains a DPoP proof signed with that key, which must be the key the refresh token is bound to.
+	RefreshAccessToken(ctx context.Context, authServerURL string, clientID string, refreshToken string, dpopKid string) (*oauth.TokenResponse, error)
 	// RequestRFC021AccessToken is called by the local EHR node to request an access token from a remote OAuth2 Authorization Server using Nuts RFC021.
 	RequestRFC021AccessToken(ctx context.Context, clientID string, subjectDID string, authServerURL string, scopes string, useDPoP bool,
 		credentials []vc.VerifiableCredential) (*oauth.TokenResponse, error)
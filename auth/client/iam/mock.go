@@ -15,6 +15,7 @@ import (
 
 	vc "github.com/nuts-foundation/go-did/vc"
 	oauth "github.com/nuts-foundation/nuts-node/auth/oauth"
+	dcql "github.com/nuts-foundation/nuts-node/vcr/dcql"
 	pe "github.com/nuts-foundation/nuts-node/vcr/pe"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -133,6 +134,21 @@ func (mr *MockClientMockRecorder) PostAuthorizationResponse(ctx, vp, presentatio
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostAuthorizationResponse", reflect.TypeOf((*MockClient)(nil).PostAuthorizationResponse), ctx, vp, presentationSubmission, verifierResponseURI, state)
 }
 
+// PostDCQLAuthorizationResponse mocks base method.
+func (m *MockClient) PostDCQLAuthorizationResponse(ctx context.Context, vpToken dcql.VPToken, verifierResponseURI, state string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostDCQLAuthorizationResponse", ctx, vpToken, verifierResponseURI, state)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostDCQLAuthorizationResponse indicates an expected call of PostDCQLAuthorizationResponse.
+func (mr *MockClientMockRecorder) PostDCQLAuthorizationResponse(ctx, vpToken, verifierResponseURI, state any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostDCQLAuthorizationResponse", reflect.TypeOf((*MockClient)(nil).PostDCQLAuthorizationResponse), ctx, vpToken, verifierResponseURI, state)
+}
+
 // PostError mocks base method.
 func (m *MockClient) PostError(ctx context.Context, auth2Error oauth.OAuth2Error, verifierResponseURI, verifierClientState string) (string, error) {
 	m.ctrl.T.Helper()
@@ -163,6 +179,36 @@ func (mr *MockClientMockRecorder) PresentationDefinition(ctx, endpoint any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PresentationDefinition", reflect.TypeOf((*MockClient)(nil).PresentationDefinition), ctx, endpoint)
 }
 
+// PushedAuthorizationRequest mocks base method.
+func (m *MockClient) PushedAuthorizationRequest(ctx context.Context, endpoint, clientID, requestObject string) (*oauth.PushedAuthorizationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PushedAuthorizationRequest", ctx, endpoint, clientID, requestObject)
+	ret0, _ := ret[0].(*oauth.PushedAuthorizationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PushedAuthorizationRequest indicates an expected call of PushedAuthorizationRequest.
+func (mr *MockClientMockRecorder) PushedAuthorizationRequest(ctx, endpoint, clientID, requestObject any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushedAuthorizationRequest", reflect.TypeOf((*MockClient)(nil).PushedAuthorizationRequest), ctx, endpoint, clientID, requestObject)
+}
+
+// RefreshAccessToken mocks base method.
+func (m *MockClient) RefreshAccessToken(ctx context.Context, authServerURL, clientID, refreshToken, dpopKid string) (*oauth.TokenResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshAccessToken", ctx, authServerURL, clientID, refreshToken, dpopKid)
+	ret0, _ := ret[0].(*oauth.TokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshAccessToken indicates an expected call of RefreshAccessToken.
+func (mr *MockClientMockRecorder) RefreshAccessToken(ctx, authServerURL, clientID, refreshToken, dpopKid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshAccessToken", reflect.TypeOf((*MockClient)(nil).RefreshAccessToken), ctx, authServerURL, clientID, refreshToken, dpopKid)
+}
+
 // RequestObjectByGet mocks base method.
 func (m *MockClient) RequestObjectByGet(ctx context.Context, requestURI string) (string, error) {
 	m.ctrl.T.Helper()
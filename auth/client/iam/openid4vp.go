@@ -42,6 +42,7 @@ import (
 	nutsCrypto "github.com/nuts-foundation/nuts-node/crypto"
 	"github.com/nuts-foundation/nuts-node/crypto/dpop"
 	nutsHttp "github.com/nuts-foundation/nuts-node/http"
+	"github.com/nuts-foundation/nuts-node/vcr/dcql"
 	"github.com/nuts-foundation/nuts-node/vcr/holder"
 	"github.com/nuts-foundation/nuts-node/vcr/pe"
 	"github.com/nuts-foundation/nuts-node/vdr/resolver"
@@ -126,6 +127,18 @@ func (c *OpenID4VPClient) PostAuthorizationResponse(ctx context.Context, vp vc.V
 	return "", fmt.Errorf("failed to post authorization response to verifier: %w", err)
 }
 
+func (c *OpenID4VPClient) PostDCQLAuthorizationResponse(ctx context.Context, vpToken dcql.VPToken, verifierResponseURI string, state string) (string, error) {
+	responseURL, err := core.ParsePublicURL(verifierResponseURI, c.strictMode)
+	if err != nil {
+		return "", fmt.Errorf("failed to post authorization response to verifier: %w", err)
+	}
+	redirectURL, err := c.httpClient.PostDCQLAuthorizationResponse(ctx, vpToken, *responseURL, state)
+	if err != nil {
+		return "", fmt.Errorf("failed to post authorization response to verifier: %w", err)
+	}
+	return redirectURL, nil
+}
+
 func (c *OpenID4VPClient) PresentationDefinition(ctx context.Context, endpoint string) (*pe.PresentationDefinition, error) {
 	iamClient := c.httpClient
 	parsedURL, err := core.ParsePublicURL(endpoint, c.strictMode)
@@ -189,6 +202,22 @@ func (c *OpenID4VPClient) RequestObjectByPost(ctx context.Context, requestURI st
 	return requestObject, nil
 }
 
+func (c *OpenID4VPClient) PushedAuthorizationRequest(ctx context.Context, endpoint string, clientID string, requestObject string) (*oauth.PushedAuthorizationResponse, error) {
+	parsedURL, err := core.ParsePublicURL(endpoint, c.strictMode)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pushed authorization request endpoint: %w", err)
+	}
+	form := url.Values{
+		oauth.ClientIDParam: {clientID},
+		oauth.RequestParam:  {requestObject},
+	}
+	response, err := c.httpClient.PushedAuthorizationRequest(ctx, parsedURL.String(), form)
+	if err != nil {
+		return nil, fmt.Errorf("failed to push authorization request: %w", err)
+	}
+	return response, nil
+}
+
 func (c *OpenID4VPClient) AccessToken(ctx context.Context, code string, tokenEndpoint string, callbackURI string, subject string, clientID string, codeVerifier string, useDPoP bool) (*oauth.TokenResponse, error) {
 	iamClient := c.httpClient
 	// validate tokenEndpoint
@@ -248,7 +277,8 @@ func (c *OpenID4VPClient) RequestRFC021AccessToken(ctx context.Context, clientID
 		return nil, err
 	}
 	presentationDefinitionURL := nutsHttp.AddQueryParams(*parsedURL, map[string]string{
-		"scope": scopes,
+		"scope":             scopes,
+		oauth.ClientIDParam: clientID,
 	})
 	presentationDefinition, err := c.PresentationDefinition(ctx, presentationDefinitionURL.String())
 	if err != nil {
@@ -263,7 +293,8 @@ func (c *OpenID4VPClient) RequestRFC021AccessToken(ctx context.Context, clientID
 		Nonce:      nutsCrypto.GenerateNonce(),
 	}
 
-	subjectDIDs, err := c.subjectManager.ListDIDs(ctx, subjectID)
+	// the authorization server is the party the presentation is made to, so only its did:peer DID (if any) can be presented
+	subjectDIDs, err := c.subjectManager.ListDIDsForRelationship(ctx, subjectID, authServerURL)
 	if err != nil {
 		return nil, err
 	}
@@ -335,10 +366,11 @@ func (c *OpenID4VPClient) RequestRFC021AccessToken(ctx context.Context, clientID
 		return nil, err
 	}
 	tokenResponse := oauth.TokenResponse{
-		AccessToken: token.AccessToken,
-		ExpiresIn:   token.ExpiresIn,
-		TokenType:   token.TokenType,
-		Scope:       &scopes,
+		AccessToken:  token.AccessToken,
+		ExpiresIn:    token.ExpiresIn,
+		RefreshToken: token.RefreshToken,
+		TokenType:    token.TokenType,
+		Scope:        &scopes,
 	}
 	if dpopKid != "" {
 		tokenResponse.DPoPKid = &dpopKid
@@ -346,6 +378,39 @@ func (c *OpenID4VPClient) RequestRFC021AccessToken(ctx context.Context, clientID
 	return &tokenResponse, nil
 }
 
+func (c *OpenID4VPClient) RefreshAccessToken(ctx context.Context, authServerURL string, clientID string, refreshToken string, dpopKid string) (*oauth.TokenResponse, error) {
+	metadata, err := c.AuthorizationServerMetadata(ctx, authServerURL)
+	if err != nil {
+		return nil, err
+	}
+	data := url.Values{}
+	data.Set(oauth.ClientIDParam, clientID)
+	data.Set(oauth.GrantTypeParam, oauth.RefreshTokenGrantType)
+	data.Set(oauth.RefreshTokenParam, refreshToken)
+
+	var dpopHeader string
+	if dpopKid != "" {
+		// the refresh token is bound to the key that was used to request the original access token
+		request, err := http.NewRequestWithContext(ctx, http.MethodPost, metadata.TokenEndpoint, nil)
+		if err != nil {
+			return nil, err
+		}
+		dpopHeader, err = c.jwtSigner.SignDPoP(ctx, *dpop.New(*request), dpopKid)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create DPoP header: %w", err)
+		}
+	}
+
+	token, err := c.httpClient.AccessToken(ctx, metadata.TokenEndpoint, data, dpopHeader)
+	if err != nil {
+		return nil, fmt.Errorf("remote server: error creating access token: %w", err)
+	}
+	if dpopKid != "" {
+		token.DPoPKid = &dpopKid
+	}
+	return &token, nil
+}
+
 func (c *OpenID4VPClient) OpenIdCredentialIssuerMetadata(ctx context.Context, oauthIssuerURI string) (*oauth.OpenIDCredentialIssuerMetadata, error) {
 	iamClient := c.httpClient
 	rsp, err := iamClient.OpenIdCredentialIssuerMetadata(ctx, oauthIssuerURI)
@@ -41,6 +41,7 @@ import (
 	"github.com/nuts-foundation/nuts-node/auth/oauth"
 	"github.com/nuts-foundation/nuts-node/crypto"
 	http2 "github.com/nuts-foundation/nuts-node/test/http"
+	"github.com/nuts-foundation/nuts-node/vcr/dcql"
 	"github.com/nuts-foundation/nuts-node/vcr/holder"
 	"github.com/nuts-foundation/nuts-node/vcr/pe"
 	"github.com/nuts-foundation/nuts-node/vdr/didweb"
@@ -184,6 +185,33 @@ func TestIAMClient_PostResponse(t *testing.T) {
 	})
 }
 
+func TestIAMClient_PostDCQLAuthorizationResponse(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctx := createClientServerTestContext(t)
+		ctx.dcqlResponse = true
+		endpoint := fmt.Sprintf("%s/response", ctx.tlsServer.URL)
+		vp := vc.VerifiablePresentation{Type: []ssi.URI{ssi.MustParseURI("VerifiablePresentation")}}
+		// marshal and unmarshal to make sure Raw() works
+		bytes, _ := json.Marshal(vp)
+		_ = json.Unmarshal(bytes, &vp)
+
+		redirect, err := ctx.client.PostDCQLAuthorizationResponse(ctx.audit, dcql.VPToken{"organization": {vp}}, endpoint, "state")
+
+		require.NoError(t, err)
+		assert.Equal(t, "redirect", redirect)
+	})
+	t.Run("error", func(t *testing.T) {
+		ctx := createClientServerTestContext(t)
+		endpoint := fmt.Sprintf("%s/response", ctx.tlsServer.URL)
+		ctx.response = nil
+
+		redirect, err := ctx.client.PostDCQLAuthorizationResponse(ctx.audit, dcql.VPToken{}, endpoint, "")
+
+		assert.ErrorContains(t, err, "failed to post authorization response to verifier")
+		assert.Empty(t, redirect)
+	})
+}
+
 func TestIAMClient_PresentationDefinition(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
 		ctx := createClientServerTestContext(t)
@@ -236,6 +264,58 @@ func TestIAMClient_AuthorizationServerMetadata(t *testing.T) {
 	})
 }
 
+func TestIAMClient_RefreshAccessToken(t *testing.T) {
+	clientID := "https://test.test/oauth2/123"
+	kid := "did:web:test.test:iam:123#1"
+
+	t.Run("ok", func(t *testing.T) {
+		ctx := createClientServerTestContext(t)
+		ctx.token = func(writer http.ResponseWriter) {
+			writer.Header().Add("Content-Type", "application/json")
+			writer.WriteHeader(http.StatusOK)
+			_, _ = writer.Write([]byte(`{"access_token": "token", "token_type": "bearer", "refresh_token": "new-refresh-token"}`))
+		}
+
+		response, err := ctx.client.RefreshAccessToken(context.Background(), ctx.verifierURL.String(), clientID, "refresh-token", "")
+
+		require.NoError(t, err)
+		require.NotNil(t, response)
+		assert.Equal(t, "token", response.AccessToken)
+		require.NotNil(t, response.RefreshToken)
+		assert.Equal(t, "new-refresh-token", *response.RefreshToken)
+		assert.Nil(t, response.DPoPKid)
+	})
+	t.Run("ok - with DPoP", func(t *testing.T) {
+		ctx := createClientServerTestContext(t)
+		ctx.jwtSigner.EXPECT().SignDPoP(context.Background(), gomock.Any(), kid).Return("dpop", nil)
+
+		response, err := ctx.client.RefreshAccessToken(context.Background(), ctx.verifierURL.String(), clientID, "refresh-token", kid)
+
+		require.NoError(t, err)
+		require.NotNil(t, response)
+		require.NotNil(t, response.DPoPKid)
+		assert.Equal(t, kid, *response.DPoPKid)
+	})
+	t.Run("error - failed to create DPoP header", func(t *testing.T) {
+		ctx := createClientServerTestContext(t)
+		ctx.jwtSigner.EXPECT().SignDPoP(context.Background(), gomock.Any(), kid).Return("", assert.AnError)
+
+		response, err := ctx.client.RefreshAccessToken(context.Background(), ctx.verifierURL.String(), clientID, "refresh-token", kid)
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, response)
+	})
+	t.Run("error - token endpoint returns error", func(t *testing.T) {
+		ctx := createClientServerTestContext(t)
+		ctx.token = nil
+
+		response, err := ctx.client.RefreshAccessToken(context.Background(), ctx.verifierURL.String(), clientID, "refresh-token", "")
+
+		assert.EqualError(t, err, "remote server: error creating access token: server returned HTTP 404 (expected: 200)")
+		assert.Nil(t, response)
+	})
+}
+
 func TestRelyingParty_RequestRFC021AccessToken(t *testing.T) {
 	const subjectID = "subby"
 	const subjectClientID = "https://example.com/oauth2/subby"
@@ -250,7 +330,7 @@ func TestRelyingParty_RequestRFC021AccessToken(t *testing.T) {
 
 	t.Run("fulfills the Presentation Definition", func(t *testing.T) {
 		ctx := createClientServerTestContext(t)
-		ctx.subjectManager.EXPECT().ListDIDs(gomock.Any(), subjectID).Return([]did.DID{primaryWalletDID, secondaryWalletDID}, nil)
+		ctx.subjectManager.EXPECT().ListDIDsForRelationship(gomock.Any(), subjectID, ctx.verifierURL.String()).Return([]did.DID{primaryWalletDID, secondaryWalletDID}, nil)
 		ctx.wallet.EXPECT().BuildSubmission(gomock.Any(), []did.DID{primaryWalletDID, secondaryWalletDID}, gomock.Any(), gomock.Any(), gomock.Any()).Return(createdVP, &pe.PresentationSubmission{}, nil)
 
 		response, err := ctx.client.RequestRFC021AccessToken(context.Background(), subjectClientID, subjectID, ctx.verifierURL.String(), scopes, false, nil)
@@ -259,10 +339,12 @@ func TestRelyingParty_RequestRFC021AccessToken(t *testing.T) {
 		require.NotNil(t, response)
 		assert.Equal(t, "token", response.AccessToken)
 		assert.Equal(t, "bearer", response.TokenType)
+		assert.Equal(t, scopes, ctx.presentationDefinitionQuery.Get(oauth.ScopeParam))
+		assert.Equal(t, subjectClientID, ctx.presentationDefinitionQuery.Get(oauth.ClientIDParam))
 	})
 	t.Run("no DID fulfills the Presentation Definition", func(t *testing.T) {
 		ctx := createClientServerTestContext(t)
-		ctx.subjectManager.EXPECT().ListDIDs(gomock.Any(), subjectID).Return([]did.DID{primaryWalletDID, secondaryWalletDID}, nil)
+		ctx.subjectManager.EXPECT().ListDIDsForRelationship(gomock.Any(), subjectID, ctx.verifierURL.String()).Return([]did.DID{primaryWalletDID, secondaryWalletDID}, nil)
 		ctx.wallet.EXPECT().BuildSubmission(gomock.Any(), []did.DID{primaryWalletDID, secondaryWalletDID}, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil, pe.ErrNoCredentials)
 
 		response, err := ctx.client.RequestRFC021AccessToken(context.Background(), subjectClientID, subjectID, ctx.verifierURL.String(), scopes, false, nil)
@@ -273,7 +355,7 @@ func TestRelyingParty_RequestRFC021AccessToken(t *testing.T) {
 	t.Run("DID not supported", func(t *testing.T) {
 		ctx := createClientServerTestContext(t)
 		ctx.authzServerMetadata.DIDMethodsSupported = []string{"other"}
-		ctx.subjectManager.EXPECT().ListDIDs(gomock.Any(), subjectID).Return([]did.DID{primaryWalletDID, secondaryWalletDID}, nil)
+		ctx.subjectManager.EXPECT().ListDIDsForRelationship(gomock.Any(), subjectID, ctx.verifierURL.String()).Return([]did.DID{primaryWalletDID, secondaryWalletDID}, nil)
 
 		response, err := ctx.client.RequestRFC021AccessToken(context.Background(), subjectClientID, subjectID, ctx.verifierURL.String(), scopes, false, nil)
 
@@ -284,7 +366,7 @@ func TestRelyingParty_RequestRFC021AccessToken(t *testing.T) {
 	})
 	t.Run("with additional credentials", func(t *testing.T) {
 		ctx := createClientServerTestContext(t)
-		ctx.subjectManager.EXPECT().ListDIDs(gomock.Any(), subjectID).Return([]did.DID{primaryWalletDID, secondaryWalletDID}, nil)
+		ctx.subjectManager.EXPECT().ListDIDsForRelationship(gomock.Any(), subjectID, ctx.verifierURL.String()).Return([]did.DID{primaryWalletDID, secondaryWalletDID}, nil)
 		credentials := []vc.VerifiableCredential{
 			{
 				Context: []ssi.URI{
@@ -323,7 +405,7 @@ func TestRelyingParty_RequestRFC021AccessToken(t *testing.T) {
 		ctx := createClientServerTestContext(t)
 		ctx.keyResolver.EXPECT().ResolveKey(primaryWalletDID, nil, resolver.NutsSigningKeyType).Return(primaryKID, nil, nil)
 		ctx.jwtSigner.EXPECT().SignDPoP(context.Background(), gomock.Any(), primaryKID).Return("dpop", nil)
-		ctx.subjectManager.EXPECT().ListDIDs(gomock.Any(), subjectID).Return([]did.DID{primaryWalletDID, secondaryWalletDID}, nil)
+		ctx.subjectManager.EXPECT().ListDIDsForRelationship(gomock.Any(), subjectID, ctx.verifierURL.String()).Return([]did.DID{primaryWalletDID, secondaryWalletDID}, nil)
 		ctx.wallet.EXPECT().BuildSubmission(gomock.Any(), []did.DID{primaryWalletDID, secondaryWalletDID}, gomock.Any(), gomock.Any(), gomock.Any()).Return(createdVP, &pe.PresentationSubmission{}, nil)
 
 		response, err := ctx.client.RequestRFC021AccessToken(context.Background(), subjectClientID, subjectID, ctx.verifierURL.String(), scopes, true, nil)
@@ -345,7 +427,7 @@ func TestRelyingParty_RequestRFC021AccessToken(t *testing.T) {
 			writer.WriteHeader(http.StatusBadRequest)
 			_, _ = writer.Write(oauthErrorBytes)
 		}
-		ctx.subjectManager.EXPECT().ListDIDs(gomock.Any(), subjectID).Return([]did.DID{primaryWalletDID, secondaryWalletDID}, nil)
+		ctx.subjectManager.EXPECT().ListDIDsForRelationship(gomock.Any(), subjectID, ctx.verifierURL.String()).Return([]did.DID{primaryWalletDID, secondaryWalletDID}, nil)
 		ctx.wallet.EXPECT().BuildSubmission(gomock.Any(), []did.DID{primaryWalletDID, secondaryWalletDID}, gomock.Any(), gomock.Any(), gomock.Any()).Return(createdVP, &pe.PresentationSubmission{}, nil)
 
 		_, err := ctx.client.RequestRFC021AccessToken(context.Background(), subjectClientID, subjectID, ctx.verifierURL.String(), scopes, false, nil)
@@ -397,7 +479,7 @@ func TestRelyingParty_RequestRFC021AccessToken(t *testing.T) {
 	})
 	t.Run("error - failed to build vp", func(t *testing.T) {
 		ctx := createClientServerTestContext(t)
-		ctx.subjectManager.EXPECT().ListDIDs(gomock.Any(), subjectID).Return([]did.DID{primaryWalletDID, secondaryWalletDID}, nil)
+		ctx.subjectManager.EXPECT().ListDIDsForRelationship(gomock.Any(), subjectID, ctx.verifierURL.String()).Return([]did.DID{primaryWalletDID, secondaryWalletDID}, nil)
 		ctx.wallet.EXPECT().BuildSubmission(gomock.Any(), []did.DID{primaryWalletDID, secondaryWalletDID}, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil, assert.AnError)
 
 		_, err := ctx.client.RequestRFC021AccessToken(context.Background(), subjectClientID, subjectID, ctx.verifierURL.String(), scopes, false, nil)
@@ -436,6 +518,35 @@ func TestIAMClient_RequestObjectByGet(t *testing.T) {
 	})
 }
 
+func TestIAMClient_PushedAuthorizationRequest(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctx := createClientServerTestContext(t)
+
+		response, err := ctx.client.PushedAuthorizationRequest(context.Background(), ctx.tlsServer.URL+"/par", "https://example.com/oauth2/holder", "signed request")
+
+		require.NoError(t, err)
+		assert.Equal(t, "urn:ietf:params:oauth:request_uri:ref", response.RequestURI)
+		assert.Equal(t, 60, response.ExpiresIn)
+	})
+	t.Run("error - invalid endpoint", func(t *testing.T) {
+		ctx := createClientServerTestContext(t)
+
+		response, err := ctx.client.PushedAuthorizationRequest(context.Background(), ":", "https://example.com/oauth2/holder", "signed request")
+
+		assert.EqualError(t, err, "invalid pushed authorization request endpoint: parse \":\": missing protocol scheme")
+		assert.Nil(t, response)
+	})
+	t.Run("error - endpoint returns error", func(t *testing.T) {
+		ctx := createClientServerTestContext(t)
+		ctx.par = nil
+
+		response, err := ctx.client.PushedAuthorizationRequest(context.Background(), ctx.tlsServer.URL+"/par", "https://example.com/oauth2/holder", "signed request")
+
+		assert.EqualError(t, err, "failed to push authorization request: server returned HTTP 404 (expected: 201)")
+		assert.Nil(t, response)
+	})
+}
+
 func TestIAMClient_RequestObjectByPost(t *testing.T) {
 	metadata := oauth.AuthorizationServerMetadata{Issuer: "me"}
 	t.Run("ok", func(t *testing.T) {
@@ -521,10 +632,13 @@ type clientServerTestContext struct {
 	metadata                       func(writer http.ResponseWriter)
 	credentialIssuerMetadata       func(writer http.ResponseWriter)
 	presentationDefinition         func(writer http.ResponseWriter)
+	presentationDefinitionQuery    url.Values
 	response                       func(writer http.ResponseWriter)
+	dcqlResponse                   bool
 	token                          func(writer http.ResponseWriter)
 	credentials                    func(writer http.ResponseWriter)
 	requestObjectJWT               func(writer http.ResponseWriter)
+	par                            func(writer http.ResponseWriter)
 }
 
 func createClientServerTestContext(t *testing.T) *clientServerTestContext {
@@ -589,6 +703,12 @@ func createClientServerTestContext(t *testing.T) *clientServerTestContext {
 			_, _ = writer.Write([]byte(`Request Object`))
 			return
 		},
+		par: func(writer http.ResponseWriter) {
+			writer.Header().Add("Content-Type", "application/json")
+			writer.WriteHeader(http.StatusCreated)
+			_, _ = writer.Write([]byte(`{"request_uri": "urn:ietf:params:oauth:request_uri:ref", "expires_in": 60}`))
+			return
+		},
 	}
 
 	ctx.handler = func(writer http.ResponseWriter, request *http.Request) {
@@ -610,6 +730,7 @@ func createClientServerTestContext(t *testing.T) *clientServerTestContext {
 				return
 			}
 		case "/presentation_definition":
+			ctx.presentationDefinitionQuery = request.URL.Query()
 			if ctx.presentationDefinition != nil {
 				ctx.presentationDefinition(writer)
 				return
@@ -617,7 +738,12 @@ func createClientServerTestContext(t *testing.T) *clientServerTestContext {
 		case "/response":
 			if ctx.response != nil {
 				assert.NotEmpty(t, request.FormValue(oauth.VpTokenParam))
-				assert.NotEmpty(t, request.FormValue(oauth.PresentationSubmissionParam))
+				if ctx.dcqlResponse {
+					// a response to a DCQL query has no presentation_submission
+					assert.False(t, request.Form.Has(oauth.PresentationSubmissionParam))
+				} else {
+					assert.NotEmpty(t, request.FormValue(oauth.PresentationSubmissionParam))
+				}
 				assert.NotEmpty(t, request.FormValue(oauth.StateParam))
 				ctx.errorResponse(writer)
 				return
@@ -637,6 +763,13 @@ func createClientServerTestContext(t *testing.T) *clientServerTestContext {
 				ctx.requestObjectJWT(writer)
 				return
 			}
+		case "/par":
+			if ctx.par != nil {
+				assert.Equal(t, "signed request", request.FormValue(oauth.RequestParam))
+				assert.NotEmpty(t, request.FormValue(oauth.ClientIDParam))
+				ctx.par(writer)
+				return
+			}
 		}
 		writer.WriteHeader(http.StatusNotFound)
 	}
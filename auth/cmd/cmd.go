@@ -47,6 +47,24 @@ const ConfAccessTokenLifeSpan = "auth.accesstokenlifespan"
 // ConfAuthEndpointEnabled is the config key for enabling the Auth v2 API's Authorization Endpoint
 const ConfAuthEndpointEnabled = "auth.authorizationendpoint.enabled"
 
+// ConfTokenExchangeEnabled is the config key for enabling the OAuth 2.0 Token Exchange grant type on the Auth v2 API's token endpoint
+const ConfTokenExchangeEnabled = "auth.tokenexchange.enabled"
+
+// ConfDomainLinkageRequired is the config key for requiring domain linkage of clients in the Auth v2 API's service-to-service flow
+const ConfDomainLinkageRequired = "auth.domainlinkage.required"
+
+// ConfRefreshTokenEnabled is the config key for enabling refresh tokens on the Auth v2 API's token endpoint
+const ConfRefreshTokenEnabled = "auth.refreshtoken.enabled"
+
+// ConfRefreshTokenValidity is the config key for the maximum validity of refresh tokens issued by the Auth v2 API's token endpoint
+const ConfRefreshTokenValidity = "auth.refreshtoken.validity"
+
+// ConfAccessTokenFormat is the config key for the format of access tokens issued by the Auth v2 API's token endpoint
+const ConfAccessTokenFormat = "auth.accesstoken.format"
+
+// ConfAccessTokenAudiences is the config key for mapping scopes to the resource servers JWT access tokens are intended for
+const ConfAccessTokenAudiences = "auth.accesstoken.audiences"
+
 // FlagSet returns the configuration flags supported by this module.
 func FlagSet() *pflag.FlagSet {
 	flags := pflag.NewFlagSet("auth", pflag.ContinueOnError)
@@ -61,6 +79,18 @@ func FlagSet() *pflag.FlagSet {
 	flags.StringSlice(ConfContractValidators, defs.ContractValidators, "sets the different contract validators to use")
 	flags.Bool(ConfAuthEndpointEnabled, defs.AuthorizationEndpoint.Enabled, "enables the v2 API's OAuth2 Authorization Endpoint, used by OpenID4VP and OpenID4VCI. "+
 		"This flag might be removed in a future version (or its default become 'true') as the use cases and implementation of OpenID4VP and OpenID4VCI mature.")
+	flags.Bool(ConfTokenExchangeEnabled, defs.TokenExchange.Enabled, "enables the OAuth 2.0 Token Exchange grant type (RFC8693) on the v2 API's token endpoint, "+
+		"allowing access tokens issued by this node to be exchanged for down-scoped access tokens.")
+	flags.Bool(ConfDomainLinkageRequired, defs.DomainLinkage.Required, "requires clients of the v2 API's service-to-service flow to prove their DID is linked to the origin of their client_id, "+
+		"using a DIF Well-Known DID Configuration (/.well-known/did-configuration.json) served on that origin.")
+	flags.Bool(ConfRefreshTokenEnabled, defs.RefreshToken.Enabled, "enables issuing refresh tokens alongside access tokens on the v2 API's token endpoint. "+
+		"Refresh tokens are rotated on every use and become invalid when a presented credential expires or is revoked.")
+	flags.Duration(ConfRefreshTokenValidity, defs.RefreshToken.Validity, "maximum time refresh tokens can be used to obtain new access tokens, "+
+		"counted from the issuance of the original access token. Specified as Golang duration (e.g. 1m, 1h30s).")
+	flags.String(ConfAccessTokenFormat, defs.AccessToken.Format, "format of access tokens issued by the v2 API's token endpoint: 'opaque' or 'jwt'. "+
+		"JWT access tokens (RFC9068) are signed by the authorization server's subject, so resource servers can verify them without introspection.")
+	flags.StringToString(ConfAccessTokenAudiences, defs.AccessToken.Audiences, "maps scopes to the resource server (URL) JWT access tokens for that scope are intended for, e.g. patient:read=https://fhir.example.com. "+
+		"The resource servers of the token's scopes are listed in its 'aud' claim. Required when auth.accesstoken.format is 'jwt'.")
 	_ = flags.MarkDeprecated("auth.http.timeout", "use httpclient.timeout instead")
 
 	return flags
@@ -42,14 +42,19 @@ func TestFlagSet(t *testing.T) {
 	sort.Strings(keys)
 
 	assert.Equal(t, []string{
+		ConfAccessTokenFormat,
 		ConfAccessTokenLifeSpan,
 		ConfAuthEndpointEnabled,
 		ConfClockSkew,
 		ConfContractValidators,
+		ConfDomainLinkageRequired,
 		ConfHTTPTimeout,
 		ConfAutoUpdateIrmaSchemas,
 		ConfIrmaCorsOrigin,
 		ConfIrmaSchemeManager,
+		ConfRefreshTokenEnabled,
+		ConfRefreshTokenValidity,
+		ConfTokenExchangeEnabled,
 	}, keys)
 }
 
@@ -19,6 +19,8 @@
 package auth
 
 import (
+	"time"
+
 	"github.com/nuts-foundation/nuts-node/auth/services"
 	"github.com/nuts-foundation/nuts-node/auth/services/dummy"
 	"github.com/nuts-foundation/nuts-node/auth/services/selfsigned"
@@ -32,8 +34,19 @@ type Config struct {
 	ContractValidators    []string                    `koanf:"contractvalidators"`
 	AccessTokenLifeSpan   int                         `koanf:"accesstokenlifespan"`
 	AuthorizationEndpoint AuthorizationEndpointConfig `koanf:"authorizationendpoint"`
+	TokenExchange         TokenExchangeConfig         `koanf:"tokenexchange"`
+	RefreshToken          RefreshTokenConfig          `koanf:"refreshtoken"`
+	AccessToken           AccessTokenConfig           `koanf:"accesstoken"`
+	DomainLinkage         DomainLinkageConfig         `koanf:"domainlinkage"`
 }
 
+const (
+	// AccessTokenFormatOpaque is the access token format for opaque access tokens, which resource servers need to introspect.
+	AccessTokenFormatOpaque = "opaque"
+	// AccessTokenFormatJWT is the access token format for JWT access tokens (RFC9068), which resource servers can verify themselves.
+	AccessTokenFormatJWT = "jwt"
+)
+
 type AuthorizationEndpointConfig struct {
 	// Enabled is a flag to enable or disable the v2 API's Authorization Endpoint (/authorize), used for:
 	// - As OpenID4VP verifier: to authenticate clients (that initiate the Authorized Code flow) using OpenID4VP
@@ -43,6 +56,36 @@ type AuthorizationEndpointConfig struct {
 	Enabled bool `koanf:"enabled"`
 }
 
+type TokenExchangeConfig struct {
+	// Enabled is a flag to enable or disable the OAuth 2.0 Token Exchange grant type (RFC8693) on the v2 API's token endpoint.
+	// It allows a resource server to exchange an access token issued by this node for a down-scoped access token,
+	// recording the exchanging party in the token's delegation chain ('act' claim).
+	// Disabling token exchange removes the grant type from the metadata.
+	Enabled bool `koanf:"enabled"`
+}
+
+type RefreshTokenConfig struct {
+	// Enabled is a flag to enable or disable issuing refresh tokens alongside access tokens on the v2 API's token endpoint.
+	// Refresh tokens are rotated on every use, and can't be used after any of the credentials presented to obtain the access token expire or are revoked.
+	Enabled bool `koanf:"enabled"`
+	// Validity is the maximum time a refresh token (and its successors) can be used after the original access token was issued.
+	Validity time.Duration `koanf:"validity"`
+}
+
+type AccessTokenConfig struct {
+	// Format is the format of access tokens issued by the v2 API's token endpoint, either AccessTokenFormatOpaque or AccessTokenFormatJWT.
+	// JWT access tokens are signed with a key of the authorization server's subject, and can be verified using its JWK Set.
+	Format string `koanf:"format"`
+	// Audiences maps scopes to the resource server JWT access tokens for that scope are intended for (their 'aud' claim, RFC9068 section 3).
+	Audiences map[string]string `koanf:"audiences"`
+}
+
+type DomainLinkageConfig struct {
+	// Required is a flag that requires clients of the service-to-service flow to prove that their DID is linked to the origin of their client_id,
+	// according to the DIF Well-Known DID Configuration specification.
+	Required bool `koanf:"required"`
+}
+
 type IrmaConfig struct {
 	SchemeManager     string     `koanf:"schememanager"`
 	AutoUpdateSchemas bool       `koanf:"autoupdateschemas"`
@@ -69,5 +112,11 @@ func DefaultConfig() Config {
 			selfsigned.ContractFormat,
 		},
 		AccessTokenLifeSpan: 60, // seconds, as specced in RFC003
+		RefreshToken: RefreshTokenConfig{
+			Validity: 24 * time.Hour,
+		},
+		AccessToken: AccessTokenConfig{
+			Format: AccessTokenFormatOpaque,
+		},
 	}
 }
@@ -23,6 +23,7 @@ import (
 	"github.com/nuts-foundation/nuts-node/auth/services"
 	"github.com/nuts-foundation/nuts-node/auth/services/oauth"
 	"net/url"
+	"time"
 )
 
 // ModuleName contains the name of this module
@@ -42,6 +43,18 @@ type AuthenticationServices interface {
 	PublicURL() *url.URL
 	// AuthorizationEndpointEnabled returns whether the v2 API's OAuth2 Authorization Endpoint is enabled.
 	AuthorizationEndpointEnabled() bool
+	// TokenExchangeEnabled returns whether the v2 API's token endpoint supports the OAuth 2.0 Token Exchange grant type.
+	TokenExchangeEnabled() bool
+	// DomainLinkageRequired returns whether clients of the service-to-service flow must prove their DID is linked to the origin of their client_id,
+	// using a DIF Well-Known DID Configuration.
+	DomainLinkageRequired() bool
+	// JWTAccessTokensEnabled returns whether the v2 API's token endpoint issues JWT access tokens (RFC9068) instead of opaque access tokens.
+	JWTAccessTokensEnabled() bool
+	// AccessTokenAudiences returns the resource servers (mapped by scope) JWT access tokens issued by the v2 API's token endpoint are intended for.
+	AccessTokenAudiences() map[string]string
+	// RefreshTokenValidity returns the maximum validity of refresh tokens issued by the v2 API's token endpoint,
+	// or 0 if refresh tokens are disabled.
+	RefreshTokenValidity() time.Duration
 	// SupportedDIDMethods lists the DID methods the Nuts node can resolve.
 	SupportedDIDMethods() []string
 }
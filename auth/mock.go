@@ -12,6 +12,7 @@ package auth
 import (
 	url "net/url"
 	reflect "reflect"
+	time "time"
 
 	iam "github.com/nuts-foundation/nuts-node/auth/client/iam"
 	services "github.com/nuts-foundation/nuts-node/auth/services"
@@ -43,6 +44,20 @@ func (m *MockAuthenticationServices) EXPECT() *MockAuthenticationServicesMockRec
 	return m.recorder
 }
 
+// AccessTokenAudiences mocks base method.
+func (m *MockAuthenticationServices) AccessTokenAudiences() map[string]string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AccessTokenAudiences")
+	ret0, _ := ret[0].(map[string]string)
+	return ret0
+}
+
+// AccessTokenAudiences indicates an expected call of AccessTokenAudiences.
+func (mr *MockAuthenticationServicesMockRecorder) AccessTokenAudiences() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccessTokenAudiences", reflect.TypeOf((*MockAuthenticationServices)(nil).AccessTokenAudiences))
+}
+
 // AuthorizationEndpointEnabled mocks base method.
 func (m *MockAuthenticationServices) AuthorizationEndpointEnabled() bool {
 	m.ctrl.T.Helper()
@@ -85,6 +100,20 @@ func (mr *MockAuthenticationServicesMockRecorder) ContractNotary() *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ContractNotary", reflect.TypeOf((*MockAuthenticationServices)(nil).ContractNotary))
 }
 
+// DomainLinkageRequired mocks base method.
+func (m *MockAuthenticationServices) DomainLinkageRequired() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DomainLinkageRequired")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// DomainLinkageRequired indicates an expected call of DomainLinkageRequired.
+func (mr *MockAuthenticationServicesMockRecorder) DomainLinkageRequired() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DomainLinkageRequired", reflect.TypeOf((*MockAuthenticationServices)(nil).DomainLinkageRequired))
+}
+
 // IAMClient mocks base method.
 func (m *MockAuthenticationServices) IAMClient() iam.Client {
 	m.ctrl.T.Helper()
@@ -99,6 +128,20 @@ func (mr *MockAuthenticationServicesMockRecorder) IAMClient() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IAMClient", reflect.TypeOf((*MockAuthenticationServices)(nil).IAMClient))
 }
 
+// JWTAccessTokensEnabled mocks base method.
+func (m *MockAuthenticationServices) JWTAccessTokensEnabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JWTAccessTokensEnabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// JWTAccessTokensEnabled indicates an expected call of JWTAccessTokensEnabled.
+func (mr *MockAuthenticationServicesMockRecorder) JWTAccessTokensEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JWTAccessTokensEnabled", reflect.TypeOf((*MockAuthenticationServices)(nil).JWTAccessTokensEnabled))
+}
+
 // PublicURL mocks base method.
 func (m *MockAuthenticationServices) PublicURL() *url.URL {
 	m.ctrl.T.Helper()
@@ -113,6 +156,20 @@ func (mr *MockAuthenticationServicesMockRecorder) PublicURL() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublicURL", reflect.TypeOf((*MockAuthenticationServices)(nil).PublicURL))
 }
 
+// RefreshTokenValidity mocks base method.
+func (m *MockAuthenticationServices) RefreshTokenValidity() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshTokenValidity")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// RefreshTokenValidity indicates an expected call of RefreshTokenValidity.
+func (mr *MockAuthenticationServicesMockRecorder) RefreshTokenValidity() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshTokenValidity", reflect.TypeOf((*MockAuthenticationServices)(nil).RefreshTokenValidity))
+}
+
 // RelyingParty mocks base method.
 func (m *MockAuthenticationServices) RelyingParty() oauth.RelyingParty {
 	m.ctrl.T.Helper()
@@ -140,3 +197,17 @@ func (mr *MockAuthenticationServicesMockRecorder) SupportedDIDMethods() *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SupportedDIDMethods", reflect.TypeOf((*MockAuthenticationServices)(nil).SupportedDIDMethods))
 }
+
+// TokenExchangeEnabled mocks base method.
+func (m *MockAuthenticationServices) TokenExchangeEnabled() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TokenExchangeEnabled")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// TokenExchangeEnabled indicates an expected call of TokenExchangeEnabled.
+func (mr *MockAuthenticationServicesMockRecorder) TokenExchangeEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TokenExchangeEnabled", reflect.TypeOf((*MockAuthenticationServices)(nil).TokenExchangeEnabled))
+}
@@ -45,6 +45,10 @@ const (
 	// AccessDenied is returned wthen the resource owner or authorization server denied the
 	// request.
 	AccessDenied ErrorCode = "access_denied"
+	// InvalidClient is returned when client authentication failed, e.g. because the client's identity couldn't be verified.
+	InvalidClient ErrorCode = "invalid_client"
+	// UnauthorizedClient is returned when the client is not authorized to perform the request, e.g. revoke a token that was issued to another client.
+	UnauthorizedClient ErrorCode = "unauthorized_client"
 	// UnsupportedGrantType is returned when the authorization grant type is not supported by the authorization server.
 	UnsupportedGrantType ErrorCode = "unsupported_grant_type"
 	// UnsupportedResponseType is returned when the authorization server does not support obtaining an authorization code using this method.
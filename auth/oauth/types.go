@@ -31,12 +31,13 @@ import (
 // TokenResponse is the OAuth access token response.
 // Through With() and Get() additional parameters (for OpenID4VCI, for instance) can be set and retrieved.
 type TokenResponse struct {
-	AccessToken string  `json:"access_token"`
-	DPoPKid     *string `json:"dpop_kid,omitempty"`
-	ExpiresAt   *int    `json:"expires_at,omitempty"`
-	ExpiresIn   *int    `json:"expires_in,omitempty"`
-	TokenType   string  `json:"token_type"`
-	Scope       *string `json:"scope,omitempty"`
+	AccessToken  string  `json:"access_token"`
+	DPoPKid      *string `json:"dpop_kid,omitempty"`
+	ExpiresAt    *int    `json:"expires_at,omitempty"`
+	ExpiresIn    *int    `json:"expires_in,omitempty"`
+	RefreshToken *string `json:"refresh_token,omitempty"`
+	TokenType    string  `json:"token_type"`
+	Scope        *string `json:"scope,omitempty"`
 
 	additionalParams map[string]interface{}
 }
@@ -57,6 +58,7 @@ func (t *TokenResponse) UnmarshalJSON(data []byte) error {
 	delete(additionalParams, "access_token")
 	delete(additionalParams, "expires_at")
 	delete(additionalParams, "expires_in")
+	delete(additionalParams, "refresh_token")
 	delete(additionalParams, "token_type")
 	delete(additionalParams, "scope")
 	delete(additionalParams, "dpop_kid")
@@ -79,6 +81,9 @@ func (t TokenResponse) MarshalJSON() ([]byte, error) {
 	if t.ExpiresAt != nil {
 		result["expires_at"] = *t.ExpiresAt
 	}
+	if t.RefreshToken != nil {
+		result["refresh_token"] = *t.RefreshToken
+	}
 	result["token_type"] = t.TokenType
 	if t.Scope != nil {
 		result["scope"] = *t.Scope
@@ -92,7 +97,7 @@ func (t TokenResponse) MarshalJSON() ([]byte, error) {
 
 // With adds a parameter to the token response.
 // It's a builder-style function.
-// It should not be used to set any of the base parameters (access_token, expires_in, token_type, scope).
+// It should not be used to set any of the base parameters (access_token, expires_in, refresh_token, token_type, scope).
 func (t *TokenResponse) With(key string, value interface{}) *TokenResponse {
 	if t.additionalParams == nil {
 		t.additionalParams = make(map[string]interface{})
@@ -103,7 +108,7 @@ func (t *TokenResponse) With(key string, value interface{}) *TokenResponse {
 
 // Get returns the value of the additional parameter with the given key as a string.
 // If the key does not exist or the value is not a string, it returns an empty string.
-// It should not be used to get any of the base parameters (access_token, expires_in, token_type, scope).
+// It should not be used to get any of the base parameters (access_token, expires_in, refresh_token, token_type, scope).
 func (t TokenResponse) Get(key string) string {
 	if t.additionalParams == nil {
 		return ""
@@ -116,6 +121,17 @@ func (t TokenResponse) Get(key string) string {
 	return ""
 }
 
+// PushedAuthorizationRequestURIPrefix is the prefix of the request_uri returned by the pushed authorization request endpoint (RFC9126).
+const PushedAuthorizationRequestURIPrefix = "urn:ietf:params:oauth:request_uri:"
+
+// PushedAuthorizationResponse is the response of the pushed authorization request endpoint, as specified by RFC9126.
+type PushedAuthorizationResponse struct {
+	// RequestURI is the request_uri the client uses in the subsequent authorization request.
+	RequestURI string `json:"request_uri"`
+	// ExpiresIn is the lifetime of the request_uri in seconds.
+	ExpiresIn int `json:"expires_in"`
+}
+
 const (
 	// AccessTokenRequestStatusPending is the status for a pending access token
 	AccessTokenRequestStatusPending = "pending"
@@ -139,6 +155,10 @@ const (
 
 // oauth parameter keys
 const (
+	// ActorTokenParam is the parameter name for the actor_token parameter. (RFC8693)
+	ActorTokenParam = "actor_token"
+	// ActorTokenTypeParam is the parameter name for the actor_token_type parameter. (RFC8693)
+	ActorTokenTypeParam = "actor_token_type"
 	// AssertionParam is the parameter name for the assertion parameter. (RFC021)
 	AssertionParam = "assertion"
 	// AuthorizationDetailsParam is the parameter name for the authorization_details parameter. (RFC9396)
@@ -161,8 +181,12 @@ const (
 	CodeChallengeMethodParam = "code_challenge_method"
 	// CodeVerifierParam is the parameter name for the code_verifier parameter. (RFC7636)
 	CodeVerifierParam = "code_verifier"
+	// DCQLQueryParam is the parameter name for the OpenID4VP dcql_query parameter. (OpenID4VP)
+	DCQLQueryParam = "dcql_query"
 	// GrantTypeParam is the parameter name for the grant_type parameter. (RFC6749)
 	GrantTypeParam = "grant_type"
+	// IssuedTokenTypeParam is the parameter name for the issued_token_type parameter in a token exchange response. (RFC8693)
+	IssuedTokenTypeParam = "issued_token_type"
 	// NonceParam is the parameter name for the nonce parameter
 	NonceParam = "nonce"
 	// PresentationDefParam is the parameter name for the OpenID4VP presentation_definition parameter. (OpenID4VP)
@@ -171,12 +195,16 @@ const (
 	PresentationDefUriParam = "presentation_definition_uri"
 	// PresentationSubmissionParam is the parameter name for the presentation_submission parameter. (OpenID4VP)
 	PresentationSubmissionParam = "presentation_submission"
+	// RefreshTokenParam is the parameter name for the refresh_token parameter. (RFC6749)
+	RefreshTokenParam = "refresh_token"
 	// RedirectURIParam is the parameter name for the redirect_uri parameter. (RFC6749)
 	RedirectURIParam = "redirect_uri"
 	// RequestParam is the parameter name for the request parameter.	(RFC9101)
 	RequestParam = "request"
 	// RequestURIParam is the parameter name for the request parameter. (RFC9101)
 	RequestURIParam = "request_uri"
+	// RequestedTokenTypeParam is the parameter name for the requested_token_type parameter. (RFC8693)
+	RequestedTokenTypeParam = "requested_token_type"
 	// RequestURIMethodParam states what http method (get/post) should be used for RequestURIParam. (OpenID4VP)
 	RequestURIMethodParam = "request_uri_method"
 	// ResponseModeParam is the parameter name for the OAuth2 response_mode parameter.
@@ -189,6 +217,14 @@ const (
 	ScopeParam = "scope"
 	// StateParam is the parameter name for the state parameter. (RFC6749)
 	StateParam = "state"
+	// SubjectTokenParam is the parameter name for the subject_token parameter. (RFC8693)
+	SubjectTokenParam = "subject_token"
+	// SubjectTokenTypeParam is the parameter name for the subject_token_type parameter. (RFC8693)
+	SubjectTokenTypeParam = "subject_token_type"
+	// TokenParam is the parameter name for the token parameter of token revocation and introspection requests. (RFC7009, RFC7662)
+	TokenParam = "token"
+	// TokenTypeHintParam is the parameter name for the token_type_hint parameter of token revocation requests. (RFC7009)
+	TokenTypeHintParam = "token_type_hint"
 	// VpTokenParam is the parameter name for the vp_token parameter. (OpenID4VP)
 	VpTokenParam = "vp_token"
 	// WalletMetadataParam is used by the wallet to provide its metadata in an authorization request when RequestURIMethodParam is 'post'
@@ -203,10 +239,20 @@ const (
 	AuthorizationCodeGrantType = "authorization_code"
 	// PreAuthorizedCodeGrantType is the grant_type for the pre-authorized_code grant type. (OpenID4VCI)
 	PreAuthorizedCodeGrantType = "urn:ietf:params:oauth:grant-type:pre-authorized_code"
+	// RefreshTokenGrantType is the grant_type for the refresh_token grant type. (RFC6749)
+	RefreshTokenGrantType = "refresh_token"
+	// TokenExchangeGrantType is the grant_type for the token exchange grant type. (RFC8693)
+	TokenExchangeGrantType = "urn:ietf:params:oauth:grant-type:token-exchange"
 	// VpTokenGrantType is the grant_type for the vp_token-bearer grant type. (RFC021)
 	VpTokenGrantType = "vp_token-bearer"
 )
 
+// token type identifiers
+const (
+	// AccessTokenTokenType indicates that the token is an OAuth 2.0 access token issued by the given authorization server. (RFC8693)
+	AccessTokenTokenType = "urn:ietf:params:oauth:token-type:access_token"
+)
+
 // response types
 const (
 	// CodeResponseType is the parameter name for the code parameter. (RFC6749)
@@ -238,6 +284,9 @@ type AuthorizationServerMetadata struct {
 	// Issuer defines the authorization server's identifier, which is a URL that uses the "https" scheme and has no query or fragment components.
 	Issuer string `json:"issuer,omitempty"`
 
+	// JwksURI defines the URL of the authorization server's JWK Set [RFC7517] document, containing the keys JWT access tokens [RFC9068] are signed with.
+	JwksURI string `json:"jwks_uri,omitempty"`
+
 	/* ******** /authorize ******** */
 
 	// AuthorizationEndpoint defines the URL of the authorization server's authorization endpoint [RFC6749]
@@ -253,6 +302,11 @@ type AuthorizationServerMetadata struct {
 	// TODO: is `form_post` something we want in the future?
 	ResponseModesSupported []string `json:"response_modes_supported,omitempty"`
 
+	/* ******** /par ******** */
+
+	// PushedAuthorizationRequestEndpoint defines the URL of the authorization server's pushed authorization request endpoint [RFC9126].
+	PushedAuthorizationRequestEndpoint string `json:"pushed_authorization_request_endpoint,omitempty"`
+
 	/* ******** /token ******** */
 
 	// TokenEndpoint defines the URL of the authorization server's token endpoint [RFC6749].
@@ -261,6 +315,11 @@ type AuthorizationServerMetadata struct {
 	// GrantTypesSupported is a list of the OAuth 2.0 grant type values that this authorization server supports.
 	GrantTypesSupported []string `json:"grant_types_supported,omitempty"`
 
+	/* ******** /revoke ******** */
+
+	// RevocationEndpoint defines the URL of the authorization server's token revocation endpoint [RFC7009].
+	RevocationEndpoint string `json:"revocation_endpoint,omitempty"`
+
 	//// TODO: what do we support?
 	//// TokenEndpointAuthMethodsSupported is a JSON array containing a list of client authentication methods supported by this token endpoint.
 	//// Client authentication method values are used in the "token_endpoint_auth_method" parameter defined in Section 2 of [RFC7591].
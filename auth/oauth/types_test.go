@@ -68,12 +68,12 @@ func TestIssuerIdToWellKnown(t *testing.T) {
 }
 
 func TestTokenResponse_Marshalling(t *testing.T) {
-	expected := (&TokenResponse{AccessToken: "1234567", TokenType: "bearer", ExpiresIn: to.Ptr(5), Scope: to.Ptr("abc"), DPoPKid: to.Ptr("kid")}).With("c_nonce", "hello")
+	expected := (&TokenResponse{AccessToken: "1234567", TokenType: "bearer", ExpiresIn: to.Ptr(5), RefreshToken: to.Ptr("refresh"), Scope: to.Ptr("abc"), DPoPKid: to.Ptr("kid")}).With("c_nonce", "hello")
 
 	t.Run("marshal", func(t *testing.T) {
 		data, err := json.Marshal(expected)
 		require.NoError(t, err)
-		assert.JSONEq(t, `{"access_token":"1234567","expires_in":5,"token_type":"bearer","scope":"abc","dpop_kid":"kid", "c_nonce":"hello"}`, string(data))
+		assert.JSONEq(t, `{"access_token":"1234567","expires_in":5,"refresh_token":"refresh","token_type":"bearer","scope":"abc","dpop_kid":"kid", "c_nonce":"hello"}`, string(data))
 	})
 	t.Run("unmarshal", func(t *testing.T) {
 		data, _ := json.Marshal(expected)
@@ -59,6 +59,7 @@ import (
 	networkCmd "github.com/nuts-foundation/nuts-node/network/cmd"
 	"github.com/nuts-foundation/nuts-node/pki"
 	"github.com/nuts-foundation/nuts-node/policy"
+	policyAPI "github.com/nuts-foundation/nuts-node/policy/api/v1"
 	"github.com/nuts-foundation/nuts-node/storage"
 	storageCmd "github.com/nuts-foundation/nuts-node/storage/cmd"
 	"github.com/nuts-foundation/nuts-node/tracing"
@@ -67,7 +68,9 @@ import (
 	openid4vciAPI "github.com/nuts-foundation/nuts-node/vcr/api/openid4vci/v0"
 	vcrAPI "github.com/nuts-foundation/nuts-node/vcr/api/vcr/v2"
 	vcrCmd "github.com/nuts-foundation/nuts-node/vcr/cmd"
+	"github.com/nuts-foundation/nuts-node/vcr/domainlinkage"
 	"github.com/nuts-foundation/nuts-node/vdr"
+	uniresolverAPI "github.com/nuts-foundation/nuts-node/vdr/api/uniresolver"
 	vdrAPI "github.com/nuts-foundation/nuts-node/vdr/api/v1"
 	vdrAPIv2 "github.com/nuts-foundation/nuts-node/vdr/api/v2"
 	vdrCmd "github.com/nuts-foundation/nuts-node/vdr/cmd"
@@ -213,7 +216,8 @@ func CreateSystem(shutdownCallback context.CancelFunc) *core.System {
 	system.RegisterRoutes(&cryptoAPI.Wrapper{C: cryptoInstance, K: didKeyResolver})
 	system.RegisterRoutes(&networkAPI.Wrapper{Service: networkInstance})
 	system.RegisterRoutes(&vdrAPI.Wrapper{VDR: vdrInstance, SubjectManager: vdrInstance})
-	system.RegisterRoutes(&vdrAPIv2.Wrapper{VDR: vdrInstance, SubjectManager: vdrInstance})
+	system.RegisterRoutes(&vdrAPIv2.Wrapper{VDR: vdrInstance, SubjectManager: vdrInstance, DomainLinkage: domainlinkage.NewPublisher(credentialInstance, vdrInstance, vdrInstance)})
+	system.RegisterRoutes(&uniresolverAPI.Wrapper{VDR: vdrInstance})
 	system.RegisterRoutes(&vcrAPI.Wrapper{VCR: credentialInstance, ContextManager: jsonld, SubjectManager: vdrInstance})
 	system.RegisterRoutes(&openid4vciAPI.Wrapper{VCR: credentialInstance, VDR: vdrInstance})
 	system.RegisterRoutes(statusEngine.(core.Routable))
@@ -224,6 +228,7 @@ func CreateSystem(shutdownCallback context.CancelFunc) *core.System {
 	system.RegisterRoutes(&didmanAPI.Wrapper{Didman: didmanInstance})
 	system.RegisterRoutes(&discoveryAPI.Wrapper{Client: discoveryInstance})
 	system.RegisterRoutes(&discoveryServerAPI.Wrapper{Server: discoveryInstance})
+	system.RegisterRoutes(&policyAPI.Wrapper{Policy: policyInstance})
 
 	// Register engines
 	// Tracing engine MUST be registered first to ensure tracing is active before other engines configure/start,
@@ -345,6 +350,7 @@ func serverConfigFlags() *pflag.FlagSet {
 	set.AddFlagSet(goldenHammerCmd.FlagSet())
 	set.AddFlagSet(discoveryCmd.FlagSet())
 	set.AddFlagSet(policy.FlagSet())
+	set.AddFlagSet(vdrCmd.FlagSet())
 	set.AddFlagSet(tracingCmd.FlagSet())
 
 	return set
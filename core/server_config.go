@@ -47,6 +47,8 @@ const configValueListSeparator = ","
 
 // redactedConfigKeys contains the configuration keys that are masked when logged, to avoid leaking secrets.
 var redactedConfigKeys = []string{
+	"crypto.csc.accesstoken",
+	"crypto.pkcs11.pin",
 	"crypto.vault.token",
 	"storage.redis.password",
 	"storage.redis.sentinel.password",
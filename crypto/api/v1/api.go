@@ -21,13 +21,17 @@ package v1
 import (
 	"context"
 	crypt "crypto"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/nuts-foundation/nuts-node/vdr/resolver"
+	"maps"
 	"net/http"
+	"slices"
 	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/lestrrat-go/jwx/v2/jwk"
 	"github.com/lestrrat-go/jwx/v2/jws"
 	"github.com/nuts-foundation/go-did/did"
 
@@ -48,9 +52,12 @@ type Wrapper struct {
 // ResolveStatusCode maps errors returned by this API to specific HTTP status codes.
 func (w *Wrapper) ResolveStatusCode(err error) int {
 	return core.ResolveStatusCode(err, map[error]int{
-		crypto.ErrPrivateKeyNotFound: http.StatusBadRequest,
-		resolver.ErrNotFound:         http.StatusNotFound,
-		resolver.ErrKeyNotFound:      http.StatusNotFound,
+		crypto.ErrPrivateKeyNotFound:   http.StatusBadRequest,
+		crypto.ErrArchiveKeyMissing:    http.StatusBadRequest,
+		crypto.ErrKeyArchiveDecryption: http.StatusBadRequest,
+		crypto.ErrKeyUsageNotAllowed:   http.StatusForbidden,
+		resolver.ErrNotFound:           http.StatusNotFound,
+		resolver.ErrKeyNotFound:        http.StatusNotFound,
 	})
 }
 
@@ -97,9 +104,13 @@ func (signRequest SignJwsRequest) validate() error {
 }
 
 func (signRequest EncryptJweRequest) validate() error {
-	if len(signRequest.Receiver) == 0 {
+	receivers := signRequest.receivers()
+	if len(receivers) == 0 {
 		return errors.New("missing receiver")
 	}
+	if signRequest.Receiver != nil && signRequest.Receivers != nil {
+		return errors.New("only one of receiver and receivers can be provided")
+	}
 	if signRequest.Headers == nil {
 		return errors.New("missing headers")
 	}
@@ -112,9 +123,21 @@ func (signRequest EncryptJweRequest) validate() error {
 	}
 
 	// receiver can be either a DID or kid, so parse it as a DIDURL
-	_, err := did.ParseDIDURL(signRequest.Receiver)
-	if err != nil {
-		return fmt.Errorf("invalid receiver: %w", err)
+	for _, receiver := range receivers {
+		if _, err := did.ParseDIDURL(receiver); err != nil {
+			return fmt.Errorf("invalid receiver: %w", err)
+		}
+	}
+	return nil
+}
+
+// receivers returns the receivers of the message, from either the receiver or receivers field.
+func (signRequest EncryptJweRequest) receivers() []string {
+	if signRequest.Receivers != nil {
+		return *signRequest.Receivers
+	}
+	if signRequest.Receiver != nil && len(*signRequest.Receiver) > 0 {
+		return []string{*signRequest.Receiver}
 	}
 	return nil
 }
@@ -165,51 +188,82 @@ func (w *Wrapper) EncryptJwe(ctx context.Context, request EncryptJweRequestObjec
 	if err := encryptRequest.validate(); err != nil {
 		return nil, core.InvalidInputError("invalid encrypt request: %w", err)
 	}
-	receiver := encryptRequest.Receiver
-	id, err := did.ParseDIDURL(receiver)
-	if err != nil {
-		return nil, err
-	}
-	key, keyID, err := w.resolvePublicKey(id)
-	if err != nil {
-		if errors.Is(err, resolver.ErrNotFound) || errors.Is(err, resolver.ErrKeyNotFound) {
-			return nil, core.InvalidInputError("unable to locate receiver %s: %w", receiver, err)
+	var recipients []jwk.Key
+	for _, receiver := range encryptRequest.receivers() {
+		id, _ := did.ParseDIDURL(receiver) // validated
+		keys, err := w.resolvePublicKeys(id)
+		if err != nil {
+			if errors.Is(err, resolver.ErrNotFound) || errors.Is(err, resolver.ErrKeyNotFound) {
+				return nil, core.InvalidInputError("unable to locate receiver %s: %w", receiver, err)
+			}
+			return nil, core.InvalidInputError("invalid receiver: %w", err)
+		}
+		for _, key := range keys {
+			if !slices.ContainsFunc(recipients, func(other jwk.Key) bool { return other.KeyID() == key.KeyID() }) {
+				recipients = append(recipients, key)
+			}
 		}
-		return nil, core.InvalidInputError("invalid receiver: %w", err)
 	}
 
 	headers := encryptRequest.Headers
-
-	// set / override kid in headers with actual used kid
-	headers[jws.KeyIDKey] = keyID
-
-	jwe, err := w.C.EncryptJWE(ctx, encryptRequest.Payload, headers, key)
+	var jwe string
+	var err error
+	if len(recipients) == 1 {
+		// single recipient: compact serialization
+		var key crypt.PublicKey
+		if err = recipients[0].Raw(&key); err != nil {
+			return nil, err
+		}
+		// set / override kid in headers with actual used kid
+		headers[jws.KeyIDKey] = recipients[0].KeyID()
+		jwe, err = w.C.EncryptJWE(ctx, encryptRequest.Payload, headers, key)
+	} else {
+		if _, ok := headers[jws.AlgorithmKey]; ok {
+			return nil, core.InvalidInputError("invalid encrypt request: alg header is not allowed for multiple recipients")
+		}
+		jwe, err = w.C.EncryptJWEForRecipients(ctx, encryptRequest.Payload, headers, recipients)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to encrypt JWE: %w", err)
 	}
 	return EncryptJwe200TextResponse(jwe), err
 }
 
-func (w *Wrapper) resolvePublicKey(id *did.DIDURL) (key crypt.PublicKey, keyID string, err error) {
+// resolvePublicKeys resolves the keyAgreement key(s) of the given receiver, as JWKs with their kid set.
+// If the receiver is a KID, only that key is returned. If it's a DID, all its keyAgreement keys are returned.
+func (w *Wrapper) resolvePublicKeys(id *did.DIDURL) ([]jwk.Key, error) {
+	keys := make(map[string]crypt.PublicKey)
 	if id.Fragment != "" {
 		// Assume it is a keyId
 		now := time.Now()
 		metadata := &resolver.ResolveMetadata{
 			ResolveTime: &now,
 		}
-		key, err = w.K.ResolveKeyByID(id.String(), metadata, resolver.KeyAgreement)
+		key, err := w.K.ResolveKeyByID(id.String(), metadata, resolver.KeyAgreement)
 		if err != nil {
-			return nil, "", err
+			return nil, err
 		}
-		keyID = id.String()
+		keys[id.String()] = key
 	} else {
 		// Assume it is a DID
-		keyID, key, err = w.K.ResolveKey(id.DID, nil, resolver.KeyAgreement)
+		var err error
+		keys, err = w.K.ResolveKeys(id.DID, nil, resolver.KeyAgreement)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var result []jwk.Key
+	for _, keyID := range slices.Sorted(maps.Keys(keys)) {
+		key, err := jwk.FromRaw(keys[keyID])
 		if err != nil {
-			return nil, "", err
+			return nil, fmt.Errorf("invalid key (kid=%s): %w", keyID, err)
 		}
+		if err = key.Set(jwk.KeyIDKey, keyID); err != nil {
+			return nil, err
+		}
+		result = append(result, key)
 	}
-	return key, keyID, nil
+	return result, nil
 }
 
 // DecryptJwe handles api calls for decrypting JWE messages
@@ -224,3 +278,58 @@ func (w *Wrapper) DecryptJwe(ctx context.Context, request DecryptJweRequestObjec
 	}
 	return DecryptJwe200JSONResponse{Body: jwe, Headers: headers}, err
 }
+
+// BackupKeys handles api calls for exporting all private keys into an encrypted key archive
+func (w *Wrapper) BackupKeys(ctx context.Context, request BackupKeysRequestObject) (BackupKeysResponseObject, error) {
+	archiveKey, err := toArchiveKey(request.Body.Passphrase, request.Body.Recipient)
+	if err != nil {
+		return nil, core.InvalidInputError("invalid backup request: %w", err)
+	}
+	archive, err := w.C.Backup(ctx, archiveKey)
+	if err != nil {
+		return nil, err
+	}
+	return BackupKeys200TextResponse(archive), nil
+}
+
+// RestoreKeys handles api calls for importing private keys from an encrypted key archive
+func (w *Wrapper) RestoreKeys(ctx context.Context, request RestoreKeysRequestObject) (RestoreKeysResponseObject, error) {
+	if len(request.Body.Archive) == 0 {
+		return nil, core.InvalidInputError("invalid restore request: missing archive")
+	}
+	archiveKey, err := toArchiveKey(request.Body.Passphrase, request.Body.Key)
+	if err != nil {
+		return nil, core.InvalidInputError("invalid restore request: %w", err)
+	}
+	kids, err := w.C.Restore(ctx, []byte(request.Body.Archive), archiveKey)
+	if err != nil {
+		return nil, err
+	}
+	if kids == nil {
+		kids = []string{}
+	}
+	return RestoreKeys200JSONResponse{Kids: kids}, nil
+}
+
+// toArchiveKey converts the passphrase or JWK from a backup/restore request to a crypto.ArchiveKey.
+func toArchiveKey(passphrase *string, key *map[string]interface{}) (crypto.ArchiveKey, error) {
+	var result crypto.ArchiveKey
+	if passphrase != nil {
+		result.Passphrase = *passphrase
+	}
+	if key != nil {
+		data, _ := json.Marshal(*key)
+		parsed, err := jwk.ParseKey(data)
+		if err != nil {
+			return result, fmt.Errorf("invalid JWK: %w", err)
+		}
+		result.JWK = parsed
+	}
+	if result.Passphrase == "" && result.JWK == nil {
+		return result, errors.New("missing passphrase or key")
+	}
+	if result.Passphrase != "" && result.JWK != nil {
+		return result, errors.New("only one of passphrase and key can be provided")
+	}
+	return result, nil
+}
@@ -19,15 +19,23 @@
 package v1
 
 import (
+	crypt "crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/nuts-foundation/nuts-node/audit"
 	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/core/to"
 	"github.com/nuts-foundation/nuts-node/vdr/resolver"
 	"net/http"
 	"testing"
 
+	"github.com/lestrrat-go/jwx/v2/jwk"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
 	"github.com/nuts-foundation/nuts-node/crypto"
@@ -37,6 +45,10 @@ func Test_ErrorStatusCodes(t *testing.T) {
 	assert.NotNil(t, (&Wrapper{}).ResolveStatusCode(nil))
 }
 
+func TestWrapper_ResolveStatusCode(t *testing.T) {
+	assert.Equal(t, http.StatusForbidden, (&Wrapper{}).ResolveStatusCode(fmt.Errorf("%w: foo", crypto.ErrKeyUsageNotAllowed)))
+}
+
 func TestWrapper_SignJwt(t *testing.T) {
 	t.Run("error - missing claim", func(t *testing.T) {
 		ctx := newMockContext(t)
@@ -181,12 +193,14 @@ func TestWrapper_SignJws(t *testing.T) {
 
 func TestWrapper_EncryptJwe(t *testing.T) {
 	payload, _ := json.Marshal(map[string]interface{}{"iss": "nuts"})
+	privateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	publicKey := privateKey.Public()
 	t.Run("Corrupt receiver returns 400", func(t *testing.T) {
 		ctx := newMockContext(t)
 		request := EncryptJweRequest{
 			Payload:  payload,
 			Headers:  map[string]interface{}{},
-			Receiver: "bananas",
+			Receiver: to.Ptr("bananas"),
 		}
 
 		jwe, err := ctx.client.EncryptJwe(nil, EncryptJweRequestObject{Body: &request})
@@ -211,7 +225,7 @@ func TestWrapper_EncryptJwe(t *testing.T) {
 		request := EncryptJweRequest{
 			Payload:  payload,
 			Headers:  map[string]interface{}{},
-			Receiver: "",
+			Receiver: to.Ptr(""),
 		}
 
 		jwe, err := ctx.client.EncryptJwe(nil, EncryptJweRequestObject{Body: &request})
@@ -222,7 +236,7 @@ func TestWrapper_EncryptJwe(t *testing.T) {
 	t.Run("Missing payload returns 400", func(t *testing.T) {
 		ctx := newMockContext(t)
 		request := EncryptJweRequest{
-			Receiver: "did:nuts:1234",
+			Receiver: to.Ptr("did:nuts:1234"),
 			Headers:  map[string]interface{}{},
 		}
 
@@ -235,7 +249,7 @@ func TestWrapper_EncryptJwe(t *testing.T) {
 		ctx := newMockContext(t)
 		request := EncryptJweRequest{
 			Payload:  payload,
-			Receiver: "did:nuts:1234",
+			Receiver: to.Ptr("did:nuts:1234"),
 		}
 
 		jwe, err := ctx.client.EncryptJwe(nil, EncryptJweRequestObject{Body: &request})
@@ -247,11 +261,11 @@ func TestWrapper_EncryptJwe(t *testing.T) {
 		ctx := newMockContext(t)
 		headers := map[string]interface{}{"typ": "JWE"}
 		request := EncryptJweRequest{
-			Receiver: "did:nuts:12345",
+			Receiver: to.Ptr("did:nuts:12345"),
 			Payload:  payload,
 			Headers:  headers,
 		}
-		ctx.keyResolver.EXPECT().ResolveKey(gomock.Any(), nil, resolver.KeyAgreement).Return("", nil, errors.New("FAIL"))
+		ctx.keyResolver.EXPECT().ResolveKeys(gomock.Any(), nil, resolver.KeyAgreement).Return(nil, errors.New("FAIL"))
 
 		jwe, err := ctx.client.EncryptJwe(nil, EncryptJweRequestObject{Body: &request})
 		assert.EqualError(t, err, "invalid receiver: FAIL")
@@ -262,11 +276,11 @@ func TestWrapper_EncryptJwe(t *testing.T) {
 		ctx := newMockContext(t)
 		headers := map[string]interface{}{"typ": "JWE"}
 		request := EncryptJweRequest{
-			Receiver: "did:nuts:12345",
+			Receiver: to.Ptr("did:nuts:12345"),
 			Payload:  payload,
 			Headers:  headers,
 		}
-		ctx.keyResolver.EXPECT().ResolveKey(gomock.Any(), nil, resolver.KeyAgreement).Return("", nil, errors.New("FAIL"))
+		ctx.keyResolver.EXPECT().ResolveKeys(gomock.Any(), nil, resolver.KeyAgreement).Return(nil, errors.New("FAIL"))
 
 		jwe, err := ctx.client.EncryptJwe(nil, EncryptJweRequestObject{Body: &request})
 		assert.EqualError(t, err, "invalid receiver: FAIL")
@@ -277,11 +291,11 @@ func TestWrapper_EncryptJwe(t *testing.T) {
 		ctx := newMockContext(t)
 		headers := map[string]interface{}{"typ": "JWE"}
 		request := EncryptJweRequest{
-			Receiver: "did:nuts:12345#key-1",
+			Receiver: to.Ptr("did:nuts:12345#key-1"),
 			Payload:  payload,
 			Headers:  headers,
 		}
-		ctx.keyResolver.EXPECT().ResolveKeyByID(gomock.Any(), gomock.Any(), resolver.KeyAgreement).Return("", errors.New("FAIL"))
+		ctx.keyResolver.EXPECT().ResolveKeyByID(gomock.Any(), gomock.Any(), resolver.KeyAgreement).Return(nil, errors.New("FAIL"))
 
 		jwe, err := ctx.client.EncryptJwe(nil, EncryptJweRequestObject{Body: &request})
 		assert.EqualError(t, err, "invalid receiver: FAIL")
@@ -292,11 +306,11 @@ func TestWrapper_EncryptJwe(t *testing.T) {
 		ctx := newMockContext(t)
 		headers := map[string]interface{}{"typ": "JWE"}
 		request := EncryptJweRequest{
-			Receiver: "did:nuts:12345",
+			Receiver: to.Ptr("did:nuts:12345"),
 			Payload:  payload,
 			Headers:  headers,
 		}
-		ctx.keyResolver.EXPECT().ResolveKey(gomock.Any(), nil, resolver.KeyAgreement).Return("", nil, resolver.ErrNotFound)
+		ctx.keyResolver.EXPECT().ResolveKeys(gomock.Any(), nil, resolver.KeyAgreement).Return(nil, resolver.ErrNotFound)
 
 		jwe, err := ctx.client.EncryptJwe(nil, EncryptJweRequestObject{Body: &request})
 		assert.EqualError(t, err, "unable to locate receiver did:nuts:12345: unable to find the DID document")
@@ -307,11 +321,11 @@ func TestWrapper_EncryptJwe(t *testing.T) {
 		ctx := newMockContext(t)
 		headers := map[string]interface{}{"typ": "JWE"}
 		request := EncryptJweRequest{
-			Receiver: "did:nuts:12345",
+			Receiver: to.Ptr("did:nuts:12345"),
 			Payload:  payload,
 			Headers:  headers,
 		}
-		ctx.keyResolver.EXPECT().ResolveKey(gomock.Any(), nil, resolver.KeyAgreement).Return("", nil, resolver.ErrNotFound)
+		ctx.keyResolver.EXPECT().ResolveKeys(gomock.Any(), nil, resolver.KeyAgreement).Return(nil, resolver.ErrNotFound)
 
 		jwe, err := ctx.client.EncryptJwe(nil, EncryptJweRequestObject{Body: &request})
 		assert.EqualError(t, err, "unable to locate receiver did:nuts:12345: unable to find the DID document")
@@ -322,11 +336,11 @@ func TestWrapper_EncryptJwe(t *testing.T) {
 		ctx := newMockContext(t)
 		headers := map[string]interface{}{"typ": "JWE"}
 		request := EncryptJweRequest{
-			Receiver: "did:nuts:12345#key-1",
+			Receiver: to.Ptr("did:nuts:12345#key-1"),
 			Payload:  payload,
 			Headers:  headers,
 		}
-		ctx.keyResolver.EXPECT().ResolveKeyByID(gomock.Any(), gomock.Any(), resolver.KeyAgreement).Return("", resolver.ErrNotFound)
+		ctx.keyResolver.EXPECT().ResolveKeyByID(gomock.Any(), gomock.Any(), resolver.KeyAgreement).Return(nil, resolver.ErrNotFound)
 
 		jwe, err := ctx.client.EncryptJwe(nil, EncryptJweRequestObject{Body: &request})
 		assert.EqualError(t, err, "unable to locate receiver did:nuts:12345#key-1: unable to find the DID document")
@@ -338,7 +352,7 @@ func TestWrapper_EncryptJwe(t *testing.T) {
 		kid := "did:nuts:12345#mykey-1"
 		headers := map[string]interface{}{"typ": "JWE", "kid": kid}
 		request := EncryptJweRequest{
-			Receiver: kid,
+			Receiver: to.Ptr(kid),
 			Headers:  headers,
 			Payload:  payload,
 		}
@@ -351,13 +365,13 @@ func TestWrapper_EncryptJwe(t *testing.T) {
 		ctx := newMockContext(t)
 		headers := map[string]interface{}{"typ": "JWE"}
 		request := EncryptJweRequest{
-			Receiver: "did:nuts:12345",
+			Receiver: to.Ptr("did:nuts:12345"),
 			Payload:  payload,
 			Headers:  headers,
 		}
 		ctx.keyStore.EXPECT().EncryptJWE(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return("", errors.New("b00m!"))
 
-		ctx.keyResolver.EXPECT().ResolveKey(gomock.Any(), nil, resolver.KeyAgreement).Return("did:nuts:12345", nil, nil)
+		ctx.keyResolver.EXPECT().ResolveKeys(gomock.Any(), nil, resolver.KeyAgreement).Return(map[string]crypt.PublicKey{"did:nuts:12345#key-1": publicKey}, nil)
 
 		jwe, err := ctx.client.EncryptJwe(audit.TestContext(), EncryptJweRequestObject{Body: &request})
 
@@ -369,12 +383,12 @@ func TestWrapper_EncryptJwe(t *testing.T) {
 		ctx := newMockContext(t)
 		headers := map[string]interface{}{"typ": "JWE"}
 		request := EncryptJweRequest{
-			Receiver: "did:nuts:12345",
+			Receiver: to.Ptr("did:nuts:12345"),
 			Headers:  headers,
 			Payload:  payload,
 		}
 		ctx.keyStore.EXPECT().EncryptJWE(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return("jwe", nil)
-		ctx.keyResolver.EXPECT().ResolveKey(gomock.Any(), nil, resolver.KeyAgreement).Return("did:nuts:12345", nil, nil)
+		ctx.keyResolver.EXPECT().ResolveKeys(gomock.Any(), nil, resolver.KeyAgreement).Return(map[string]crypt.PublicKey{"did:nuts:12345#key-1": publicKey}, nil)
 
 		resp, err := ctx.client.EncryptJwe(nil, EncryptJweRequestObject{Body: &request})
 
@@ -386,12 +400,12 @@ func TestWrapper_EncryptJwe(t *testing.T) {
 		headers := map[string]interface{}{"typ": "JWE"}
 		kid := "did:nuts:12345#mykey-1"
 		request := EncryptJweRequest{
-			Receiver: kid,
+			Receiver: to.Ptr(kid),
 			Headers:  headers,
 			Payload:  payload,
 		}
 		ctx.keyStore.EXPECT().EncryptJWE(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return("jwe", nil)
-		ctx.keyResolver.EXPECT().ResolveKeyByID(gomock.Any(), gomock.Any(), resolver.KeyAgreement).Return(kid, nil)
+		ctx.keyResolver.EXPECT().ResolveKeyByID(gomock.Any(), gomock.Any(), resolver.KeyAgreement).Return(publicKey, nil)
 
 		resp, err := ctx.client.EncryptJwe(nil, EncryptJweRequestObject{Body: &request})
 
@@ -403,12 +417,12 @@ func TestWrapper_EncryptJwe(t *testing.T) {
 		kid := "did:nuts:12345#mykey-1"
 		headers := map[string]interface{}{"typ": "JWE"}
 		request := EncryptJweRequest{
-			Receiver: "did:nuts:12345",
+			Receiver: to.Ptr("did:nuts:12345"),
 			Headers:  headers,
 			Payload:  payload,
 		}
 		ctx.keyStore.EXPECT().EncryptJWE(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return("jwe", nil)
-		ctx.keyResolver.EXPECT().ResolveKey(gomock.Any(), nil, resolver.KeyAgreement).Return(kid, nil, nil)
+		ctx.keyResolver.EXPECT().ResolveKeys(gomock.Any(), nil, resolver.KeyAgreement).Return(map[string]crypt.PublicKey{kid: publicKey}, nil)
 
 		resp, err := ctx.client.EncryptJwe(nil, EncryptJweRequestObject{Body: &request})
 
@@ -421,16 +435,119 @@ func TestWrapper_EncryptJwe(t *testing.T) {
 		request := EncryptJweRequest{
 			Payload:  payload,
 			Headers:  map[string]interface{}{},
-			Receiver: did,
+			Receiver: to.Ptr(did),
 		}
 		ctx.keyStore.EXPECT().EncryptJWE(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return("jwe", nil)
-		ctx.keyResolver.EXPECT().ResolveKey(gomock.Any(), nil, resolver.KeyAgreement).Return(did, nil, nil)
+		ctx.keyResolver.EXPECT().ResolveKeys(gomock.Any(), nil, resolver.KeyAgreement).Return(map[string]crypt.PublicKey{did + "#key-1": publicKey}, nil)
 
 		resp, err := ctx.client.EncryptJwe(nil, EncryptJweRequestObject{Body: &request})
 
 		assert.Nil(t, err)
 		assert.Equal(t, "jwe", string(resp.(EncryptJwe200TextResponse)))
 	})
+	t.Run("receiver and receivers returns 400", func(t *testing.T) {
+		ctx := newMockContext(t)
+		request := EncryptJweRequest{
+			Payload:   payload,
+			Headers:   map[string]interface{}{},
+			Receiver:  to.Ptr("did:nuts:12345"),
+			Receivers: &[]string{"did:nuts:67890"},
+		}
+
+		jwe, err := ctx.client.EncryptJwe(nil, EncryptJweRequestObject{Body: &request})
+
+		assert.EqualError(t, err, "invalid encrypt request: only one of receiver and receivers can be provided")
+		assert.Equal(t, err.(core.HTTPStatusCodeError).StatusCode(), http.StatusBadRequest)
+		assert.Empty(t, jwe)
+	})
+	t.Run("empty receivers returns 400", func(t *testing.T) {
+		ctx := newMockContext(t)
+		request := EncryptJweRequest{
+			Payload:   payload,
+			Headers:   map[string]interface{}{},
+			Receivers: &[]string{},
+		}
+
+		jwe, err := ctx.client.EncryptJwe(nil, EncryptJweRequestObject{Body: &request})
+
+		assert.EqualError(t, err, "invalid encrypt request: missing receiver")
+		assert.Empty(t, jwe)
+	})
+	t.Run("multiple receivers returns JSON serialized JWE", func(t *testing.T) {
+		ctx := newMockContext(t)
+		request := EncryptJweRequest{
+			Payload:   payload,
+			Headers:   map[string]interface{}{"typ": "JWE"},
+			Receivers: &[]string{"did:nuts:12345#key-1", "did:nuts:67890"},
+		}
+		ctx.keyResolver.EXPECT().ResolveKeyByID("did:nuts:12345#key-1", gomock.Any(), resolver.KeyAgreement).Return(publicKey, nil)
+		ctx.keyResolver.EXPECT().ResolveKeys(gomock.Any(), nil, resolver.KeyAgreement).Return(map[string]crypt.PublicKey{"did:nuts:67890#key-1": publicKey}, nil)
+		var capturedKids []string
+		ctx.keyStore.EXPECT().EncryptJWEForRecipients(gomock.Any(), payload, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ interface{}, _ []byte, headers map[string]interface{}, recipients []jwk.Key) (string, error) {
+				assert.NotContains(t, headers, "kid")
+				for _, recipient := range recipients {
+					capturedKids = append(capturedKids, recipient.KeyID())
+				}
+				return "jwe", nil
+			})
+
+		resp, err := ctx.client.EncryptJwe(nil, EncryptJweRequestObject{Body: &request})
+
+		require.NoError(t, err)
+		assert.Equal(t, "jwe", string(resp.(EncryptJwe200TextResponse)))
+		assert.Equal(t, []string{"did:nuts:12345#key-1", "did:nuts:67890#key-1"}, capturedKids)
+	})
+	t.Run("DID with multiple keyAgreement keys returns JSON serialized JWE", func(t *testing.T) {
+		ctx := newMockContext(t)
+		otherKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		request := EncryptJweRequest{
+			Payload:  payload,
+			Headers:  map[string]interface{}{},
+			Receiver: to.Ptr("did:nuts:12345"),
+		}
+		ctx.keyResolver.EXPECT().ResolveKeys(gomock.Any(), nil, resolver.KeyAgreement).Return(map[string]crypt.PublicKey{
+			"did:nuts:12345#key-2": otherKey.Public(),
+			"did:nuts:12345#key-1": publicKey,
+		}, nil)
+		ctx.keyStore.EXPECT().EncryptJWEForRecipients(gomock.Any(), payload, gomock.Any(), gomock.Len(2)).Return("jwe", nil)
+
+		resp, err := ctx.client.EncryptJwe(nil, EncryptJweRequestObject{Body: &request})
+
+		require.NoError(t, err)
+		assert.Equal(t, "jwe", string(resp.(EncryptJwe200TextResponse)))
+	})
+	t.Run("duplicate receivers are encrypted for once", func(t *testing.T) {
+		ctx := newMockContext(t)
+		request := EncryptJweRequest{
+			Payload:   payload,
+			Headers:   map[string]interface{}{},
+			Receivers: &[]string{"did:nuts:12345#key-1", "did:nuts:12345"},
+		}
+		ctx.keyResolver.EXPECT().ResolveKeyByID("did:nuts:12345#key-1", gomock.Any(), resolver.KeyAgreement).Return(publicKey, nil)
+		ctx.keyResolver.EXPECT().ResolveKeys(gomock.Any(), nil, resolver.KeyAgreement).Return(map[string]crypt.PublicKey{"did:nuts:12345#key-1": publicKey}, nil)
+		ctx.keyStore.EXPECT().EncryptJWE(gomock.Any(), payload, gomock.Any(), publicKey).Return("jwe", nil)
+
+		resp, err := ctx.client.EncryptJwe(nil, EncryptJweRequestObject{Body: &request})
+
+		require.NoError(t, err)
+		assert.Equal(t, "jwe", string(resp.(EncryptJwe200TextResponse)))
+	})
+	t.Run("alg header with multiple receivers returns 400", func(t *testing.T) {
+		ctx := newMockContext(t)
+		request := EncryptJweRequest{
+			Payload:   payload,
+			Headers:   map[string]interface{}{"alg": "ECDH-ES+A256KW"},
+			Receivers: &[]string{"did:nuts:12345#key-1", "did:nuts:67890#key-1"},
+		}
+		ctx.keyResolver.EXPECT().ResolveKeyByID(gomock.Any(), gomock.Any(), resolver.KeyAgreement).Return(publicKey, nil).Times(2)
+
+		jwe, err := ctx.client.EncryptJwe(nil, EncryptJweRequestObject{Body: &request})
+
+		assert.EqualError(t, err, "invalid encrypt request: alg header is not allowed for multiple recipients")
+		assert.Equal(t, err.(core.HTTPStatusCodeError).StatusCode(), http.StatusBadRequest)
+		assert.Empty(t, jwe)
+	})
 }
 
 func TestWrapper_DecryptJwe(t *testing.T) {
@@ -465,6 +582,121 @@ func TestWrapper_DecryptJwe(t *testing.T) {
 	})
 }
 
+func TestWrapper_BackupKeys(t *testing.T) {
+	passphrase := "secret"
+	recipient := map[string]interface{}{
+		"kty": "EC",
+		"crv": "P-256",
+		"x":   "MKBCTNIcKUSDii11ySs3526iDZ8AiTo7Tu6KPAqv7D4",
+		"y":   "4Etl6SRW2YiLUrN5vfvVHuhp7x8PxltmWWlbbM4IFyM",
+	}
+	t.Run("ok - passphrase", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.keyStore.EXPECT().Backup(gomock.Any(), crypto.ArchiveKey{Passphrase: passphrase}).Return([]byte("archive"), nil)
+
+		resp, err := ctx.client.BackupKeys(nil, BackupKeysRequestObject{Body: &BackupKeysRequest{Passphrase: &passphrase}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "archive", string(resp.(BackupKeys200TextResponse)))
+	})
+	t.Run("ok - recipient", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.keyStore.EXPECT().Backup(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, key crypto.ArchiveKey) ([]byte, error) {
+			assert.Empty(t, key.Passphrase)
+			assert.NotNil(t, key.JWK)
+			return []byte("archive"), nil
+		})
+
+		resp, err := ctx.client.BackupKeys(nil, BackupKeysRequestObject{Body: &BackupKeysRequest{Recipient: &recipient}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "archive", string(resp.(BackupKeys200TextResponse)))
+	})
+	t.Run("missing passphrase and recipient returns 400", func(t *testing.T) {
+		ctx := newMockContext(t)
+
+		_, err := ctx.client.BackupKeys(nil, BackupKeysRequestObject{Body: &BackupKeysRequest{}})
+
+		assert.EqualError(t, err, "invalid backup request: missing passphrase or key")
+		assert.Equal(t, http.StatusBadRequest, err.(core.HTTPStatusCodeError).StatusCode())
+	})
+	t.Run("both passphrase and recipient returns 400", func(t *testing.T) {
+		ctx := newMockContext(t)
+
+		_, err := ctx.client.BackupKeys(nil, BackupKeysRequestObject{Body: &BackupKeysRequest{Passphrase: &passphrase, Recipient: &recipient}})
+
+		assert.EqualError(t, err, "invalid backup request: only one of passphrase and key can be provided")
+		assert.Equal(t, http.StatusBadRequest, err.(core.HTTPStatusCodeError).StatusCode())
+	})
+	t.Run("invalid recipient returns 400", func(t *testing.T) {
+		ctx := newMockContext(t)
+		invalid := map[string]interface{}{"kty": "foo"}
+
+		_, err := ctx.client.BackupKeys(nil, BackupKeysRequestObject{Body: &BackupKeysRequest{Recipient: &invalid}})
+
+		assert.ErrorContains(t, err, "invalid backup request: invalid JWK")
+		assert.Equal(t, http.StatusBadRequest, err.(core.HTTPStatusCodeError).StatusCode())
+	})
+	t.Run("error - Backup fails", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.keyStore.EXPECT().Backup(gomock.Any(), gomock.Any()).Return(nil, errors.New("b00m!"))
+
+		resp, err := ctx.client.BackupKeys(nil, BackupKeysRequestObject{Body: &BackupKeysRequest{Passphrase: &passphrase}})
+
+		assert.EqualError(t, err, "b00m!")
+		assert.Nil(t, resp)
+	})
+}
+
+func TestWrapper_RestoreKeys(t *testing.T) {
+	passphrase := "secret"
+	t.Run("ok", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.keyStore.EXPECT().Restore(gomock.Any(), []byte("archive"), crypto.ArchiveKey{Passphrase: passphrase}).Return([]string{"kid"}, nil)
+
+		resp, err := ctx.client.RestoreKeys(nil, RestoreKeysRequestObject{Body: &RestoreKeysRequest{Archive: "archive", Passphrase: &passphrase}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"kid"}, resp.(RestoreKeys200JSONResponse).Kids)
+	})
+	t.Run("ok - nothing restored", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.keyStore.EXPECT().Restore(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+
+		resp, err := ctx.client.RestoreKeys(nil, RestoreKeysRequestObject{Body: &RestoreKeysRequest{Archive: "archive", Passphrase: &passphrase}})
+
+		assert.NoError(t, err)
+		assert.NotNil(t, resp.(RestoreKeys200JSONResponse).Kids)
+		assert.Empty(t, resp.(RestoreKeys200JSONResponse).Kids)
+	})
+	t.Run("missing archive returns 400", func(t *testing.T) {
+		ctx := newMockContext(t)
+
+		_, err := ctx.client.RestoreKeys(nil, RestoreKeysRequestObject{Body: &RestoreKeysRequest{Passphrase: &passphrase}})
+
+		assert.EqualError(t, err, "invalid restore request: missing archive")
+		assert.Equal(t, http.StatusBadRequest, err.(core.HTTPStatusCodeError).StatusCode())
+	})
+	t.Run("missing passphrase and key returns 400", func(t *testing.T) {
+		ctx := newMockContext(t)
+
+		_, err := ctx.client.RestoreKeys(nil, RestoreKeysRequestObject{Body: &RestoreKeysRequest{Archive: "archive"}})
+
+		assert.EqualError(t, err, "invalid restore request: missing passphrase or key")
+		assert.Equal(t, http.StatusBadRequest, err.(core.HTTPStatusCodeError).StatusCode())
+	})
+	t.Run("error - Restore fails", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.keyStore.EXPECT().Restore(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, crypto.ErrKeyArchiveDecryption)
+
+		resp, err := ctx.client.RestoreKeys(nil, RestoreKeysRequestObject{Body: &RestoreKeysRequest{Archive: "archive", Passphrase: &passphrase}})
+
+		assert.ErrorIs(t, err, crypto.ErrKeyArchiveDecryption)
+		assert.Equal(t, http.StatusBadRequest, ctx.client.ResolveStatusCode(err))
+		assert.Nil(t, resp)
+	})
+}
+
 type mockContext struct {
 	ctrl        *gomock.Controller
 	keyStore    *crypto.MockKeyStore
@@ -21,9 +21,19 @@ const (
 	JwtBearerAuthScopes = "jwtBearerAuth.Scopes"
 )
 
+// BackupKeysRequest defines model for BackupKeysRequest.
+type BackupKeysRequest struct {
+	// Passphrase The passphrase used to encrypt the archive. Either passphrase or recipient must be provided.
+	Passphrase *string `json:"passphrase,omitempty"`
+
+	// Recipient The public key (as JWK) of the recipient, used to encrypt the archive. Must be an EC or RSA key. Either passphrase or recipient must be provided.
+	Recipient *map[string]interface{} `json:"recipient,omitempty"`
+}
+
 // DecryptJweRequest defines model for DecryptJweRequest.
 type DecryptJweRequest struct {
-	// Message The message to be decrypted as string in format aa==.bb==.cc==.dd==.ee==
+	// Message The message to be decrypted as string, either in compact serialization (format aa==.bb==.cc==.dd==.ee==) or JSON serialization.
+	// If the message has multiple recipients, it is decrypted using the first recipient key (identified by the kid header) that is present in the node's key store.
 	Message string `json:"message"`
 }
 
@@ -37,7 +47,32 @@ type EncryptJweRequest struct {
 	Payload []byte `json:"payload"`
 
 	// Receiver The DID reference of the message receiver OR the KID of the message receiver.
-	Receiver string `json:"receiver"`
+	// If a DID is given, the message is encrypted for each of its keyAgreement keys.
+	// Either receiver or receivers must be provided.
+	Receiver *string `json:"receiver,omitempty"`
+
+	// Receivers The DID references and/or KIDs of the message receivers, for encrypting the message for multiple receivers.
+	// If a DID is given, the message is encrypted for each of its keyAgreement keys.
+	// Either receiver or receivers must be provided.
+	Receivers *[]string `json:"receivers,omitempty"`
+}
+
+// RestoreKeysRequest defines model for RestoreKeysRequest.
+type RestoreKeysRequest struct {
+	// Archive The encrypted key archive, as created by the backup operation.
+	Archive string `json:"archive"`
+
+	// Key The private key (as JWK) of the recipient, used to decrypt the archive. Either passphrase or key must be provided.
+	Key *map[string]interface{} `json:"key,omitempty"`
+
+	// Passphrase The passphrase used to decrypt the archive. Either passphrase or key must be provided.
+	Passphrase *string `json:"passphrase,omitempty"`
+}
+
+// RestoreKeysResult defines model for RestoreKeysResult.
+type RestoreKeysResult struct {
+	// Kids The key IDs of the restored key references.
+	Kids []string `json:"kids"`
 }
 
 // SignJwsRequest defines model for SignJwsRequest.
@@ -61,12 +96,18 @@ type SignJwtRequest struct {
 	Kid    string                 `json:"kid"`
 }
 
+// BackupKeysJSONRequestBody defines body for BackupKeys for application/json ContentType.
+type BackupKeysJSONRequestBody = BackupKeysRequest
+
 // DecryptJweJSONRequestBody defines body for DecryptJwe for application/json ContentType.
 type DecryptJweJSONRequestBody = DecryptJweRequest
 
 // EncryptJweJSONRequestBody defines body for EncryptJwe for application/json ContentType.
 type EncryptJweJSONRequestBody = EncryptJweRequest
 
+// RestoreKeysJSONRequestBody defines body for RestoreKeys for application/json ContentType.
+type RestoreKeysJSONRequestBody = RestoreKeysRequest
+
 // SignJwsJSONRequestBody defines body for SignJws for application/json ContentType.
 type SignJwsJSONRequestBody = SignJwsRequest
 
@@ -151,6 +192,11 @@ type ClientInterface interface {
 
 	DecryptJwe(ctx context.Context, body DecryptJweJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// RestoreKeysWithBody request with any body
+	RestoreKeysWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	RestoreKeys(ctx context.Context, body RestoreKeysJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// EncryptJweWithBody request with any body
 	EncryptJweWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -165,6 +211,11 @@ type ClientInterface interface {
 	SignJwtWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	SignJwt(ctx context.Context, body SignJwtJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// BackupKeysWithBody request with any body
+	BackupKeysWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	BackupKeys(ctx context.Context, body BackupKeysJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
 func (c *Client) DecryptJweWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -191,6 +242,30 @@ func (c *Client) DecryptJwe(ctx context.Context, body DecryptJweJSONRequestBody,
 	return c.Client.Do(req)
 }
 
+func (c *Client) RestoreKeysWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRestoreKeysRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RestoreKeys(ctx context.Context, body RestoreKeysJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRestoreKeysRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) EncryptJweWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewEncryptJweRequestWithBody(c.Server, contentType, body)
 	if err != nil {
@@ -263,6 +338,30 @@ func (c *Client) SignJwt(ctx context.Context, body SignJwtJSONRequestBody, reqEd
 	return c.Client.Do(req)
 }
 
+func (c *Client) BackupKeysWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewBackupKeysRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) BackupKeys(ctx context.Context, body BackupKeysJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewBackupKeysRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 // NewDecryptJweRequest calls the generic DecryptJwe builder with application/json body
 func NewDecryptJweRequest(server string, body DecryptJweJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
@@ -303,6 +402,46 @@ func NewDecryptJweRequestWithBody(server string, contentType string, body io.Rea
 	return req, nil
 }
 
+// NewRestoreKeysRequest calls the generic RestoreKeys builder with application/json body
+func NewRestoreKeysRequest(server string, body RestoreKeysJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewRestoreKeysRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewRestoreKeysRequestWithBody generates requests for RestoreKeys with any type of body
+func NewRestoreKeysRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/crypto/v1/restore")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
 // NewEncryptJweRequest calls the generic EncryptJwe builder with application/json body
 func NewEncryptJweRequest(server string, body EncryptJweJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
@@ -423,6 +562,46 @@ func NewSignJwtRequestWithBody(server string, contentType string, body io.Reader
 	return req, nil
 }
 
+// NewBackupKeysRequest calls the generic BackupKeys builder with application/json body
+func NewBackupKeysRequest(server string, body BackupKeysJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewBackupKeysRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewBackupKeysRequestWithBody generates requests for BackupKeys with any type of body
+func NewBackupKeysRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/crypto/v1/backup")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
 func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
 	for _, r := range c.RequestEditors {
 		if err := r(ctx, req); err != nil {
@@ -471,6 +650,11 @@ type ClientWithResponsesInterface interface {
 
 	DecryptJweWithResponse(ctx context.Context, body DecryptJweJSONRequestBody, reqEditors ...RequestEditorFn) (*DecryptJweResponse, error)
 
+	// RestoreKeysWithBodyWithResponse request with any body
+	RestoreKeysWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RestoreKeysResponse, error)
+
+	RestoreKeysWithResponse(ctx context.Context, body RestoreKeysJSONRequestBody, reqEditors ...RequestEditorFn) (*RestoreKeysResponse, error)
+
 	// EncryptJweWithBodyWithResponse request with any body
 	EncryptJweWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*EncryptJweResponse, error)
 
@@ -485,6 +669,11 @@ type ClientWithResponsesInterface interface {
 	SignJwtWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SignJwtResponse, error)
 
 	SignJwtWithResponse(ctx context.Context, body SignJwtJSONRequestBody, reqEditors ...RequestEditorFn) (*SignJwtResponse, error)
+
+	// BackupKeysWithBodyWithResponse request with any body
+	BackupKeysWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*BackupKeysResponse, error)
+
+	BackupKeysWithResponse(ctx context.Context, body BackupKeysJSONRequestBody, reqEditors ...RequestEditorFn) (*BackupKeysResponse, error)
 }
 
 type DecryptJweResponse struct {
@@ -525,6 +714,38 @@ func (r DecryptJweResponse) StatusCode() int {
 	return 0
 }
 
+type RestoreKeysResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *RestoreKeysResult
+	ApplicationproblemJSONDefault *struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r RestoreKeysResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RestoreKeysResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type EncryptJweResponse struct {
 	Body                          []byte
 	HTTPResponse                  *http.Response
@@ -618,6 +839,37 @@ func (r SignJwtResponse) StatusCode() int {
 	return 0
 }
 
+type BackupKeysResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	ApplicationproblemJSONDefault *struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r BackupKeysResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r BackupKeysResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 // DecryptJweWithBodyWithResponse request with arbitrary body returning *DecryptJweResponse
 func (c *ClientWithResponses) DecryptJweWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*DecryptJweResponse, error) {
 	rsp, err := c.DecryptJweWithBody(ctx, contentType, body, reqEditors...)
@@ -635,6 +887,23 @@ func (c *ClientWithResponses) DecryptJweWithResponse(ctx context.Context, body D
 	return ParseDecryptJweResponse(rsp)
 }
 
+// RestoreKeysWithBodyWithResponse request with arbitrary body returning *RestoreKeysResponse
+func (c *ClientWithResponses) RestoreKeysWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RestoreKeysResponse, error) {
+	rsp, err := c.RestoreKeysWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRestoreKeysResponse(rsp)
+}
+
+func (c *ClientWithResponses) RestoreKeysWithResponse(ctx context.Context, body RestoreKeysJSONRequestBody, reqEditors ...RequestEditorFn) (*RestoreKeysResponse, error) {
+	rsp, err := c.RestoreKeys(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRestoreKeysResponse(rsp)
+}
+
 // EncryptJweWithBodyWithResponse request with arbitrary body returning *EncryptJweResponse
 func (c *ClientWithResponses) EncryptJweWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*EncryptJweResponse, error) {
 	rsp, err := c.EncryptJweWithBody(ctx, contentType, body, reqEditors...)
@@ -686,6 +955,23 @@ func (c *ClientWithResponses) SignJwtWithResponse(ctx context.Context, body Sign
 	return ParseSignJwtResponse(rsp)
 }
 
+// BackupKeysWithBodyWithResponse request with arbitrary body returning *BackupKeysResponse
+func (c *ClientWithResponses) BackupKeysWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*BackupKeysResponse, error) {
+	rsp, err := c.BackupKeysWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBackupKeysResponse(rsp)
+}
+
+func (c *ClientWithResponses) BackupKeysWithResponse(ctx context.Context, body BackupKeysJSONRequestBody, reqEditors ...RequestEditorFn) (*BackupKeysResponse, error) {
+	rsp, err := c.BackupKeys(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBackupKeysResponse(rsp)
+}
+
 // ParseDecryptJweResponse parses an HTTP response from a DecryptJweWithResponse call
 func ParseDecryptJweResponse(rsp *http.Response) (*DecryptJweResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -734,6 +1020,48 @@ func ParseDecryptJweResponse(rsp *http.Response) (*DecryptJweResponse, error) {
 	return response, nil
 }
 
+// ParseRestoreKeysResponse parses an HTTP response from a RestoreKeysWithResponse call
+func ParseRestoreKeysResponse(rsp *http.Response) (*RestoreKeysResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RestoreKeysResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest RestoreKeysResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest struct {
+			// Detail A human-readable explanation specific to this occurrence of the problem.
+			Detail string `json:"detail"`
+
+			// Status HTTP statuscode
+			Status float32 `json:"status"`
+
+			// Title A short, human-readable summary of the problem type.
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseEncryptJweResponse parses an HTTP response from a EncryptJweWithResponse call
 func ParseEncryptJweResponse(rsp *http.Response) (*EncryptJweResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -839,11 +1167,49 @@ func ParseSignJwtResponse(rsp *http.Response) (*SignJwtResponse, error) {
 	return response, nil
 }
 
+// ParseBackupKeysResponse parses an HTTP response from a BackupKeysWithResponse call
+func ParseBackupKeysResponse(rsp *http.Response) (*BackupKeysResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &BackupKeysResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest struct {
+			// Detail A human-readable explanation specific to this occurrence of the problem.
+			Detail string `json:"detail"`
+
+			// Status HTTP statuscode
+			Status float32 `json:"status"`
+
+			// Title A short, human-readable summary of the problem type.
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 	// Decrypt a payload with the private key related to the KeyID in the header
 	// (POST /internal/crypto/v1/decrypt_jwe)
 	DecryptJwe(ctx echo.Context) error
+	// Restore private keys and key references from an encrypted key archive
+	// (POST /internal/crypto/v1/restore)
+	RestoreKeys(ctx echo.Context) error
 	// Encrypt a payload and headers with the public key of the given DID into a JWE object
 	// (POST /internal/crypto/v1/encrypt_jwe)
 	EncryptJwe(ctx echo.Context) error
@@ -853,6 +1219,9 @@ type ServerInterface interface {
 	// sign a JWT payload with the private key of the given kid
 	// (POST /internal/crypto/v1/sign_jwt)
 	SignJwt(ctx echo.Context) error
+	// Export all private keys and key references into an encrypted key archive
+	// (POST /internal/crypto/v1/backup)
+	BackupKeys(ctx echo.Context) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -871,6 +1240,17 @@ func (w *ServerInterfaceWrapper) DecryptJwe(ctx echo.Context) error {
 	return err
 }
 
+// RestoreKeys converts echo context to params.
+func (w *ServerInterfaceWrapper) RestoreKeys(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.RestoreKeys(ctx)
+	return err
+}
+
 // EncryptJwe converts echo context to params.
 func (w *ServerInterfaceWrapper) EncryptJwe(ctx echo.Context) error {
 	var err error
@@ -904,6 +1284,17 @@ func (w *ServerInterfaceWrapper) SignJwt(ctx echo.Context) error {
 	return err
 }
 
+// BackupKeys converts echo context to params.
+func (w *ServerInterfaceWrapper) BackupKeys(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.BackupKeys(ctx)
+	return err
+}
+
 // This is a simple interface which specifies echo.Route addition functions which
 // are present on both echo.Echo and echo.Group, since we want to allow using
 // either of them for path registration
@@ -933,9 +1324,11 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 	}
 
 	router.POST(baseURL+"/internal/crypto/v1/decrypt_jwe", wrapper.DecryptJwe)
+	router.POST(baseURL+"/internal/crypto/v1/restore", wrapper.RestoreKeys)
 	router.POST(baseURL+"/internal/crypto/v1/encrypt_jwe", wrapper.EncryptJwe)
 	router.POST(baseURL+"/internal/crypto/v1/sign_jws", wrapper.SignJws)
 	router.POST(baseURL+"/internal/crypto/v1/sign_jwt", wrapper.SignJwt)
+	router.POST(baseURL+"/internal/crypto/v1/backup", wrapper.BackupKeys)
 
 }
 
@@ -983,6 +1376,44 @@ func (response DecryptJwedefaultApplicationProblemPlusJSONResponse) VisitDecrypt
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
+type RestoreKeysRequestObject struct {
+	Body *RestoreKeysJSONRequestBody
+}
+
+type RestoreKeysResponseObject interface {
+	VisitRestoreKeysResponse(w http.ResponseWriter) error
+}
+
+type RestoreKeys200JSONResponse RestoreKeysResult
+
+func (response RestoreKeys200JSONResponse) VisitRestoreKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RestoreKeysdefaultApplicationProblemPlusJSONResponse struct {
+	Body struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+	StatusCode int
+}
+
+func (response RestoreKeysdefaultApplicationProblemPlusJSONResponse) VisitRestoreKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
 type EncryptJweRequestObject struct {
 	Body *EncryptJweJSONRequestBody
 }
@@ -1100,11 +1531,53 @@ func (response SignJwtdefaultApplicationProblemPlusJSONResponse) VisitSignJwtRes
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
+type BackupKeysRequestObject struct {
+	Body *BackupKeysJSONRequestBody
+}
+
+type BackupKeysResponseObject interface {
+	VisitBackupKeysResponse(w http.ResponseWriter) error
+}
+
+type BackupKeys200TextResponse string
+
+func (response BackupKeys200TextResponse) VisitBackupKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/plain")
+	w.WriteHeader(200)
+
+	_, err := w.Write([]byte(response))
+	return err
+}
+
+type BackupKeysdefaultApplicationProblemPlusJSONResponse struct {
+	Body struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+	StatusCode int
+}
+
+func (response BackupKeysdefaultApplicationProblemPlusJSONResponse) VisitBackupKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
 	// Decrypt a payload with the private key related to the KeyID in the header
 	// (POST /internal/crypto/v1/decrypt_jwe)
 	DecryptJwe(ctx context.Context, request DecryptJweRequestObject) (DecryptJweResponseObject, error)
+	// Restore private keys and key references from an encrypted key archive
+	// (POST /internal/crypto/v1/restore)
+	RestoreKeys(ctx context.Context, request RestoreKeysRequestObject) (RestoreKeysResponseObject, error)
 	// Encrypt a payload and headers with the public key of the given DID into a JWE object
 	// (POST /internal/crypto/v1/encrypt_jwe)
 	EncryptJwe(ctx context.Context, request EncryptJweRequestObject) (EncryptJweResponseObject, error)
@@ -1114,6 +1587,9 @@ type StrictServerInterface interface {
 	// sign a JWT payload with the private key of the given kid
 	// (POST /internal/crypto/v1/sign_jwt)
 	SignJwt(ctx context.Context, request SignJwtRequestObject) (SignJwtResponseObject, error)
+	// Export all private keys and key references into an encrypted key archive
+	// (POST /internal/crypto/v1/backup)
+	BackupKeys(ctx context.Context, request BackupKeysRequestObject) (BackupKeysResponseObject, error)
 }
 
 type StrictHandlerFunc = strictecho.StrictEchoHandlerFunc
@@ -1157,6 +1633,35 @@ func (sh *strictHandler) DecryptJwe(ctx echo.Context) error {
 	return nil
 }
 
+// RestoreKeys operation middleware
+func (sh *strictHandler) RestoreKeys(ctx echo.Context) error {
+	var request RestoreKeysRequestObject
+
+	var body RestoreKeysJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.RestoreKeys(ctx.Request().Context(), request.(RestoreKeysRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RestoreKeys")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(RestoreKeysResponseObject); ok {
+		return validResponse.VisitRestoreKeysResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // EncryptJwe operation middleware
 func (sh *strictHandler) EncryptJwe(ctx echo.Context) error {
 	var request EncryptJweRequestObject
@@ -1243,3 +1748,32 @@ func (sh *strictHandler) SignJwt(ctx echo.Context) error {
 	}
 	return nil
 }
+
+// BackupKeys operation middleware
+func (sh *strictHandler) BackupKeys(ctx echo.Context) error {
+	var request BackupKeysRequestObject
+
+	var body BackupKeysJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.BackupKeys(ctx.Request().Context(), request.(BackupKeysRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "BackupKeys")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(BackupKeysResponseObject); ok {
+		return validResponse.VisitBackupKeysResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
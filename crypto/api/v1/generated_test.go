@@ -53,6 +53,14 @@ func (t *testServerInterface) SignJws(_ echo.Context) error {
 	return t.err
 }
 
+func (t *testServerInterface) BackupKeys(_ echo.Context) error {
+	return t.err
+}
+
+func (t *testServerInterface) RestoreKeys(_ echo.Context) error {
+	return t.err
+}
+
 var siws = []*ServerInterfaceWrapper{
 	serverInterfaceWrapper(nil), serverInterfaceWrapper(errors.New("server error")),
 }
@@ -94,6 +102,8 @@ func TestRegisterHandlers(t *testing.T) {
 		echo.EXPECT().POST("/internal/crypto/v1/sign_jws", gomock.Any())
 		echo.EXPECT().POST("/internal/crypto/v1/encrypt_jwe", gomock.Any())
 		echo.EXPECT().POST("/internal/crypto/v1/decrypt_jwe", gomock.Any())
+		echo.EXPECT().POST("/internal/crypto/v1/backup", gomock.Any())
+		echo.EXPECT().POST("/internal/crypto/v1/restore", gomock.Any())
 
 		RegisterHandlers(echo, &testServerInterface{})
 	})
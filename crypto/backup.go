@@ -0,0 +1,302 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package crypto
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwe"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/nuts-foundation/nuts-node/audit"
+	"github.com/nuts-foundation/nuts-node/crypto/log"
+	"github.com/nuts-foundation/nuts-node/storage/orm"
+	"gorm.io/gorm"
+)
+
+// keyArchiveVersion is the version of the key backup archive format.
+const keyArchiveVersion = 1
+
+// keyArchiveContentType is the content type (cty header) of the JWE containing a key backup archive.
+const keyArchiveContentType = "nuts-key-archive+json"
+
+// ErrArchiveKeyMissing is returned when neither a passphrase nor a JWK is provided for encrypting or decrypting a key backup archive.
+var ErrArchiveKeyMissing = errors.New("either a passphrase or a JWK must be provided for the key archive")
+
+// ErrKeyArchiveDecryption is returned when a key backup archive can't be decrypted, e.g. because of an incorrect passphrase or key.
+var ErrKeyArchiveDecryption = errors.New("unable to decrypt key archive")
+
+// ArchiveKey specifies the key used to encrypt or decrypt a key backup archive.
+// Exactly one of Passphrase and JWK must be set.
+// When creating a backup, JWK is the public key of the recipient. When restoring a backup, JWK is the recipient's private key.
+type ArchiveKey struct {
+	// Passphrase is used to derive the archive encryption key (PBES2).
+	Passphrase string
+	// JWK is the EC or RSA key of the recipient of the archive.
+	JWK jwk.Key
+}
+
+func (a ArchiveKey) validate() error {
+	if a.Passphrase == "" && a.JWK == nil {
+		return ErrArchiveKeyMissing
+	}
+	if a.Passphrase != "" && a.JWK != nil {
+		return errors.New("only one of passphrase and JWK can be provided for the key archive")
+	}
+	return nil
+}
+
+// keyArchive is the (unencrypted) content of a key backup archive.
+type keyArchive struct {
+	Version       int                    `json:"version"`
+	Keys          []archivedKey          `json:"keys"`
+	KeyReferences []archivedKeyReference `json:"key_references"`
+}
+
+type archivedKey struct {
+	KeyName    string          `json:"key_name"`
+	Version    string          `json:"version"`
+	PrivateKey json.RawMessage `json:"private_key"`
+}
+
+type archivedKeyReference struct {
+	KID     string `json:"kid"`
+	KeyName string `json:"key_name"`
+	Version string `json:"version"`
+}
+
+// Backup exports all private keys in the storage backend, together with their key references, into an encrypted archive.
+// The archive is a JWE, encrypted using the given passphrase or recipient key.
+// It fails if the storage backend doesn't allow private keys to be exported (e.g. an HSM).
+func (client *Crypto) Backup(ctx context.Context, key ArchiveKey) ([]byte, error) {
+	if err := key.validate(); err != nil {
+		return nil, err
+	}
+	archive := keyArchive{Version: keyArchiveVersion}
+	err := client.continueTransaction(ctx, func(tx *gorm.DB) error {
+		for _, keyNameVersion := range client.backend.ListPrivateKeys(ctx) {
+			signer, err := client.backend.GetPrivateKey(ctx, keyNameVersion.KeyName, keyNameVersion.Version)
+			if err != nil {
+				return fmt.Errorf("unable to retrieve private key (name=%s): %w", keyNameVersion.KeyName, err)
+			}
+			privateKeyJSON, err := exportPrivateKey(signer)
+			if err != nil {
+				return fmt.Errorf("unable to export private key (name=%s, storage=%s): %w", keyNameVersion.KeyName, client.backend.Name(), err)
+			}
+			archive.Keys = append(archive.Keys, archivedKey{
+				KeyName:    keyNameVersion.KeyName,
+				Version:    keyNameVersion.Version,
+				PrivateKey: privateKeyJSON,
+			})
+		}
+		var keyRefs []orm.KeyReference
+		if err := tx.WithContext(ctx).Find(&keyRefs).Error; err != nil {
+			return fmt.Errorf("unable to list key references: %w", err)
+		}
+		for _, keyRef := range keyRefs {
+			archive.KeyReferences = append(archive.KeyReferences, archivedKeyReference{
+				KID:     keyRef.KID,
+				KeyName: keyRef.KeyName,
+				Version: keyRef.Version,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	payload, err := json.Marshal(archive)
+	if err != nil {
+		return nil, err
+	}
+	result, err := encryptArchive(payload, key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encrypt key archive: %w", err)
+	}
+	audit.Log(ctx, log.Logger(), audit.CryptoBackupKeysEvent).Infof("Exported %d private keys into backup archive", len(archive.Keys))
+	return result, nil
+}
+
+// Restore imports the private keys and key references from an encrypted archive created by Backup into the storage backend.
+// Private keys that already exist in the storage backend are skipped, so a restore can safely be retried.
+// It returns the KIDs of the key references that were restored.
+func (client *Crypto) Restore(ctx context.Context, data []byte, key ArchiveKey) ([]string, error) {
+	if err := key.validate(); err != nil {
+		return nil, err
+	}
+	payload, err := decryptArchive(data, key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrKeyArchiveDecryption, err)
+	}
+	var archive keyArchive
+	if err = json.Unmarshal(payload, &archive); err != nil {
+		return nil, fmt.Errorf("invalid key archive: %w", err)
+	}
+	if archive.Version != keyArchiveVersion {
+		return nil, fmt.Errorf("unsupported key archive version: %d", archive.Version)
+	}
+	var kids []string
+	err = client.continueTransaction(ctx, func(tx *gorm.DB) error {
+		for _, archived := range archive.Keys {
+			exists, err := client.backend.PrivateKeyExists(ctx, archived.KeyName, "")
+			if err != nil {
+				return fmt.Errorf("unable to check existence of private key (name=%s): %w", archived.KeyName, err)
+			}
+			if exists {
+				log.Logger().Infof("Private key already exists in storage, skipping (name=%s)", archived.KeyName)
+				continue
+			}
+			privateKey, err := importPrivateKey(archived.PrivateKey)
+			if err != nil {
+				return fmt.Errorf("invalid private key in key archive (name=%s): %w", archived.KeyName, err)
+			}
+			if err = client.backend.SavePrivateKey(ctx, archived.KeyName, privateKey); err != nil {
+				return fmt.Errorf("unable to store private key (name=%s, storage=%s): %w", archived.KeyName, client.backend.Name(), err)
+			}
+		}
+		// Storage backends might assign their own versions to imported keys (e.g. Azure Key Vault),
+		// so key references must point to the version as known by the target storage backend.
+		versions := make(map[string]string)
+		for _, keyNameVersion := range client.backend.ListPrivateKeys(ctx) {
+			versions[keyNameVersion.KeyName] = keyNameVersion.Version
+		}
+		for _, archivedRef := range archive.KeyReferences {
+			ref := orm.KeyReference{
+				KID:     archivedRef.KID,
+				KeyName: archivedRef.KeyName,
+				Version: archivedRef.Version,
+			}
+			if version, ok := versions[ref.KeyName]; ok {
+				ref.Version = version
+			}
+			if err := tx.WithContext(ctx).Save(&ref).Error; err != nil {
+				return fmt.Errorf("unable to store key reference (kid=%s): %w", ref.KID, err)
+			}
+			kids = append(kids, ref.KID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	audit.Log(ctx, log.Logger(), audit.CryptoRestoreKeysEvent).Infof("Restored %d private keys and %d key references from backup archive", len(archive.Keys), len(kids))
+	return kids, nil
+}
+
+// exportPrivateKey returns the private key of the signer as JWK.
+// Only in-memory private keys can be exported, signers backed by a remote key store (e.g. an HSM) can't.
+func exportPrivateKey(signer crypto.Signer) ([]byte, error) {
+	switch signer.(type) {
+	case *ecdsa.PrivateKey, *rsa.PrivateKey, ed25519.PrivateKey:
+	default:
+		return nil, errors.New("private key is not exportable")
+	}
+	privateKey, err := jwk.FromRaw(signer)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(privateKey)
+}
+
+func importPrivateKey(data []byte) (crypto.PrivateKey, error) {
+	privateKey, err := jwk.ParseKey(data)
+	if err != nil {
+		return nil, err
+	}
+	if !isPrivateJWK(privateKey) {
+		return nil, errors.New("not a private key")
+	}
+	var result crypto.PrivateKey
+	if err = privateKey.Raw(&result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func isPrivateJWK(key jwk.Key) bool {
+	switch key.(type) {
+	case jwk.ECDSAPrivateKey, jwk.RSAPrivateKey, jwk.OKPPrivateKey:
+		return true
+	}
+	return false
+}
+
+func encryptArchive(payload []byte, key ArchiveKey) ([]byte, error) {
+	headers := jwe.NewHeaders()
+	if err := headers.Set(jwe.ContentTypeKey, keyArchiveContentType); err != nil {
+		return nil, err
+	}
+	if key.Passphrase != "" {
+		return jwe.Encrypt(payload,
+			jwe.WithProtectedHeaders(headers),
+			jwe.WithContentEncryption(jwa.A256GCM),
+			jwe.WithKey(jwa.PBES2_HS512_A256KW, []byte(key.Passphrase)))
+	}
+	var publicKey crypto.PublicKey
+	if err := key.JWK.Raw(&publicKey); err != nil {
+		return nil, fmt.Errorf("invalid recipient key: %w", err)
+	}
+	// the public key might've been derived from a private key
+	if signer, ok := publicKey.(crypto.Signer); ok {
+		publicKey = signer.Public()
+	}
+	alg, err := encryptionAlgorithm(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	if key.JWK.KeyID() != "" {
+		if err = headers.Set(jwe.KeyIDKey, key.JWK.KeyID()); err != nil {
+			return nil, err
+		}
+	}
+	return jwe.Encrypt(payload,
+		jwe.WithProtectedHeaders(headers),
+		jwe.WithContentEncryption(jwa.A256GCM),
+		jwe.WithKey(alg, publicKey))
+}
+
+func decryptArchive(data []byte, key ArchiveKey) ([]byte, error) {
+	msg, err := jwe.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	alg := msg.ProtectedHeaders().Algorithm()
+	isPassphraseEncrypted := strings.HasPrefix(alg.String(), "PBES2")
+	if key.Passphrase != "" {
+		if !isPassphraseEncrypted {
+			return nil, errors.New("key archive is not encrypted using a passphrase")
+		}
+		return jwe.Decrypt(data, jwe.WithKey(alg, []byte(key.Passphrase)))
+	}
+	if isPassphraseEncrypted {
+		return nil, errors.New("key archive is encrypted using a passphrase")
+	}
+	if !isPrivateJWK(key.JWK) {
+		return nil, errors.New("decrypting the key archive requires the recipient's private key")
+	}
+	return jwe.Decrypt(data, jwe.WithKey(alg, key.JWK))
+}
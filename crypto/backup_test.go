@@ -0,0 +1,236 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package crypto
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwe"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/nuts-foundation/nuts-node/audit"
+	"github.com/nuts-foundation/nuts-node/crypto/storage/spi"
+	"github.com/nuts-foundation/nuts-node/storage/orm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCrypto_BackupRestore(t *testing.T) {
+	ctx := audit.TestContext()
+	passphrase := ArchiveKey{Passphrase: "correct horse battery staple"}
+	recipientKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	recipientPrivateJWK, _ := jwk.FromRaw(recipientKey)
+	recipientPublicJWK, _ := jwk.FromRaw(recipientKey.Public())
+
+	source := createCrypto(t)
+	ref1, publicKey1, err := source.New(ctx, StringNamingFunc("kid-1"))
+	require.NoError(t, err)
+	ref2, publicKey2, err := source.New(ctx, StringNamingFunc("kid-2"))
+	require.NoError(t, err)
+
+	assertRestored := func(t *testing.T, target *Crypto) {
+		t.Helper()
+		resolved1, err := target.Resolve(ctx, ref1.KID)
+		require.NoError(t, err)
+		assert.Equal(t, publicKey1, resolved1)
+		resolved2, err := target.Resolve(ctx, ref2.KID)
+		require.NoError(t, err)
+		assert.Equal(t, publicKey2, resolved2)
+	}
+
+	t.Run("passphrase", func(t *testing.T) {
+		archive, err := source.Backup(ctx, passphrase)
+		require.NoError(t, err)
+		target := createCrypto(t)
+
+		kids, err := target.Restore(ctx, archive, passphrase)
+
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"kid-1", "kid-2"}, kids)
+		assertRestored(t, target)
+		t.Run("archive is encrypted", func(t *testing.T) {
+			msg, err := jwe.Parse(archive)
+			require.NoError(t, err)
+			assert.Equal(t, "PBES2-HS512+A256KW", msg.ProtectedHeaders().Algorithm().String())
+			assert.Equal(t, "nuts-key-archive+json", msg.ProtectedHeaders().ContentType())
+		})
+		t.Run("restore again skips existing keys", func(t *testing.T) {
+			kids, err := target.Restore(ctx, archive, passphrase)
+
+			require.NoError(t, err)
+			assert.Len(t, kids, 2)
+			assertRestored(t, target)
+		})
+	})
+	t.Run("recipient key", func(t *testing.T) {
+		archive, err := source.Backup(ctx, ArchiveKey{JWK: recipientPublicJWK})
+		require.NoError(t, err)
+		target := createCrypto(t)
+
+		kids, err := target.Restore(ctx, archive, ArchiveKey{JWK: recipientPrivateJWK})
+
+		require.NoError(t, err)
+		assert.Len(t, kids, 2)
+		assertRestored(t, target)
+	})
+	t.Run("target assigns other key version", func(t *testing.T) {
+		archive, err := source.Backup(ctx, passphrase)
+		require.NoError(t, err)
+		ctrl := gomock.NewController(t)
+		backend := spi.NewMockStorage(ctrl)
+		backend.EXPECT().PrivateKeyExists(gomock.Any(), gomock.Any(), "").Return(false, nil).Times(2)
+		backend.EXPECT().SavePrivateKey(gomock.Any(), ref1.KeyName, gomock.Any()).Return(nil)
+		backend.EXPECT().SavePrivateKey(gomock.Any(), ref2.KeyName, gomock.Any()).Return(nil)
+		backend.EXPECT().ListPrivateKeys(gomock.Any()).Return([]spi.KeyNameVersion{
+			{KeyName: ref1.KeyName, Version: "v2"},
+			{KeyName: ref2.KeyName, Version: "v2"},
+		})
+		target := NewTestCryptoInstance(orm.NewTestDatabase(t), backend)
+
+		_, err = target.Restore(ctx, archive, passphrase)
+
+		require.NoError(t, err)
+		keyRef, err := target.findKeyReferenceByKid(ctx, ref1.KID)
+		require.NoError(t, err)
+		assert.Equal(t, "v2", keyRef.Version)
+	})
+	t.Run("error - wrong passphrase", func(t *testing.T) {
+		archive, err := source.Backup(ctx, passphrase)
+		require.NoError(t, err)
+
+		_, err = createCrypto(t).Restore(ctx, archive, ArchiveKey{Passphrase: "wrong"})
+
+		assert.ErrorIs(t, err, ErrKeyArchiveDecryption)
+	})
+	t.Run("error - archive encrypted with passphrase, restoring with key", func(t *testing.T) {
+		archive, err := source.Backup(ctx, passphrase)
+		require.NoError(t, err)
+
+		_, err = createCrypto(t).Restore(ctx, archive, ArchiveKey{JWK: recipientPrivateJWK})
+
+		assert.EqualError(t, err, "unable to decrypt key archive: key archive is encrypted using a passphrase")
+	})
+	t.Run("error - archive encrypted with key, restoring with passphrase", func(t *testing.T) {
+		archive, err := source.Backup(ctx, ArchiveKey{JWK: recipientPublicJWK})
+		require.NoError(t, err)
+
+		_, err = createCrypto(t).Restore(ctx, archive, passphrase)
+
+		assert.EqualError(t, err, "unable to decrypt key archive: key archive is not encrypted using a passphrase")
+	})
+	t.Run("error - restoring with public key", func(t *testing.T) {
+		archive, err := source.Backup(ctx, ArchiveKey{JWK: recipientPublicJWK})
+		require.NoError(t, err)
+
+		_, err = createCrypto(t).Restore(ctx, archive, ArchiveKey{JWK: recipientPublicJWK})
+
+		assert.EqualError(t, err, "unable to decrypt key archive: decrypting the key archive requires the recipient's private key")
+	})
+	t.Run("error - target does not support importing keys", func(t *testing.T) {
+		archive, err := source.Backup(ctx, passphrase)
+		require.NoError(t, err)
+		ctrl := gomock.NewController(t)
+		backend := spi.NewMockStorage(ctrl)
+		backend.EXPECT().Name().Return("hsm")
+		backend.EXPECT().PrivateKeyExists(gomock.Any(), gomock.Any(), "").Return(false, nil)
+		backend.EXPECT().SavePrivateKey(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("not supported"))
+		target := NewTestCryptoInstance(orm.NewTestDatabase(t), backend)
+
+		_, err = target.Restore(ctx, archive, passphrase)
+
+		assert.ErrorContains(t, err, "storage=hsm): not supported")
+	})
+}
+
+func TestCrypto_Backup(t *testing.T) {
+	ctx := audit.TestContext()
+	t.Run("error - no archive key", func(t *testing.T) {
+		_, err := createCrypto(t).Backup(ctx, ArchiveKey{})
+
+		assert.ErrorIs(t, err, ErrArchiveKeyMissing)
+	})
+	t.Run("error - both passphrase and key", func(t *testing.T) {
+		key, _ := jwk.FromRaw([]byte("secret"))
+
+		_, err := createCrypto(t).Backup(ctx, ArchiveKey{Passphrase: "secret", JWK: key})
+
+		assert.EqualError(t, err, "only one of passphrase and JWK can be provided for the key archive")
+	})
+	t.Run("error - private key is not exportable", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		backend := spi.NewMockStorage(ctrl)
+		backend.EXPECT().Name().Return("hsm")
+		backend.EXPECT().ListPrivateKeys(gomock.Any()).Return([]spi.KeyNameVersion{{KeyName: "key", Version: "1"}})
+		backend.EXPECT().GetPrivateKey(gomock.Any(), "key", "1").Return(remoteSigner{}, nil)
+		client := NewTestCryptoInstance(orm.NewTestDatabase(t), backend)
+
+		_, err := client.Backup(ctx, ArchiveKey{Passphrase: "secret"})
+
+		assert.EqualError(t, err, "unable to export private key (name=key, storage=hsm): private key is not exportable")
+	})
+}
+
+func TestCrypto_Restore(t *testing.T) {
+	ctx := audit.TestContext()
+	passphrase := ArchiveKey{Passphrase: "secret"}
+	t.Run("error - not a JWE", func(t *testing.T) {
+		_, err := createCrypto(t).Restore(ctx, []byte("not a JWE"), passphrase)
+
+		assert.ErrorContains(t, err, "unable to decrypt key archive")
+	})
+	t.Run("error - unsupported version", func(t *testing.T) {
+		payload, _ := json.Marshal(keyArchive{Version: 2})
+		archive, err := encryptArchive(payload, passphrase)
+		require.NoError(t, err)
+
+		_, err = createCrypto(t).Restore(ctx, archive, passphrase)
+
+		assert.EqualError(t, err, "unsupported key archive version: 2")
+	})
+	t.Run("error - archived key is not a private key", func(t *testing.T) {
+		privateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		publicJWK, _ := jwk.FromRaw(privateKey.Public())
+		publicKeyJSON, _ := json.Marshal(publicJWK)
+		payload, _ := json.Marshal(keyArchive{Version: keyArchiveVersion, Keys: []archivedKey{{KeyName: "key", Version: "1", PrivateKey: publicKeyJSON}}})
+		archive, err := encryptArchive(payload, passphrase)
+		require.NoError(t, err)
+
+		_, err = createCrypto(t).Restore(ctx, archive, passphrase)
+
+		assert.EqualError(t, err, "invalid private key in key archive (name=key): not a private key")
+	})
+}
+
+// remoteSigner is a crypto.Signer of which the private key can't be exported, e.g. because it's stored in an HSM.
+type remoteSigner struct{}
+
+func (r remoteSigner) Public() crypto.PublicKey {
+	return nil
+}
+
+func (r remoteSigner) Sign(_ io.Reader, _ []byte, _ crypto.SignerOpts) ([]byte, error) {
+	return nil, errors.New("not implemented")
+}
@@ -19,20 +19,29 @@
 package cmd
 
 import (
+	"bufio"
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"os"
+	"strings"
 
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/nuts-foundation/nuts-node/audit"
 	"github.com/nuts-foundation/nuts-node/core"
 	cryptoEngine "github.com/nuts-foundation/nuts-node/crypto"
 	"github.com/nuts-foundation/nuts-node/crypto/storage/azure"
+	"github.com/nuts-foundation/nuts-node/crypto/storage/csc"
 	"github.com/nuts-foundation/nuts-node/crypto/storage/external"
 	"github.com/nuts-foundation/nuts-node/crypto/storage/fs"
+	"github.com/nuts-foundation/nuts-node/crypto/storage/pkcs11"
 	"github.com/nuts-foundation/nuts-node/crypto/storage/spi"
 	"github.com/nuts-foundation/nuts-node/crypto/storage/vault"
 	"github.com/nuts-foundation/nuts-node/storage"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"golang.org/x/term"
 )
 
 // FlagSet returns the configuration flags for crypto
@@ -40,8 +49,8 @@ func FlagSet() *pflag.FlagSet {
 	flags := pflag.NewFlagSet("crypto", pflag.ContinueOnError)
 
 	defs := cryptoEngine.DefaultCryptoConfig()
-	flags.String("crypto.storage", defs.Storage, fmt.Sprintf("Storage to use, '%s' for file system (for development purposes), '%s' for HashiCorp Vault KV store, '%s' for Azure Key Vault, '%s' for an external backend (deprecated).",
-		fs.StorageType, vault.StorageType, azure.StorageType, external.StorageType))
+	flags.String("crypto.storage", defs.Storage, fmt.Sprintf("Storage to use, '%s' for file system (for development purposes), '%s' for HashiCorp Vault KV store, '%s' for Azure Key Vault, '%s' for a PKCS#11 token (e.g. an HSM), '%s' for remote signing through the Cloud Signature Consortium API of a trust service provider, '%s' for an external backend (deprecated).",
+		fs.StorageType, vault.StorageType, azure.StorageType, pkcs11.StorageType, csc.StorageType, external.StorageType))
 	flags.String("crypto.vault.token", defs.Vault.Token, "The Vault token. If set it overwrites the VAULT_TOKEN env var.")
 	flags.String("crypto.vault.address", defs.Vault.Address, "The Vault address. If set it overwrites the VAULT_ADDR env var.")
 	flags.Duration("crypto.vault.timeout", defs.Vault.Timeout, "Timeout of client calls to Vault, in Golang time.Duration string format (e.g. 1s).")
@@ -50,6 +59,13 @@ func FlagSet() *pflag.FlagSet {
 	flags.Duration("crypto.azurekv.timeout", defs.AzureKeyVault.Timeout, "Timeout of client calls to Azure Key Vault, in Golang time.Duration string format (e.g. 10s).")
 	flags.Bool("crypto.azurekv.hsm", defs.AzureKeyVault.UseHSM, fmt.Sprintf("Whether to store the key in a hardware security module (HSM). If true, the Azure Key Vault must be configured for HSM usage. Default: %t", defs.AzureKeyVault.UseHSM))
 	flags.String("crypto.azurekv.auth.type", defs.AzureKeyVault.Auth.Type, fmt.Sprintf("Credential type to use when authenticating to the Azure Key Vault. Options: %s, %s (see https://github.com/Azure/azure-sdk-for-go/blob/main/sdk/azidentity/README.md for an explanation of the options).", azure.DefaultChainCredentialType, azure.ManagedIdentityCredentialType))
+	flags.String("crypto.pkcs11.library", defs.PKCS11.Library, "Path to the PKCS#11 module (shared library) of the HSM.")
+	flags.String("crypto.pkcs11.tokenlabel", defs.PKCS11.TokenLabel, "Label of the PKCS#11 token to store private keys on.")
+	flags.String("crypto.pkcs11.pin", defs.PKCS11.Pin, "User PIN to log in to the PKCS#11 token.")
+	flags.String("crypto.csc.address", defs.CSC.Address, "Base URL of the Cloud Signature Consortium (CSC) API of the trust service provider, e.g. https://qtsp.example.com/csc/v2")
+	flags.String("crypto.csc.accesstoken", defs.CSC.AccessToken, "OAuth2 access token used to authenticate to the CSC API.")
+	flags.String("crypto.csc.userid", defs.CSC.UserID, "User ID of which the credentials are listed from the CSC API. Only required if the access token isn't bound to a user.")
+	flags.Duration("crypto.csc.timeout", defs.CSC.Timeout, "Timeout of client calls to the CSC API, in Golang time.Duration string format (e.g. 10s).")
 	flags.String("crypto.external.address", defs.External.Address, "Address of the external storage service.")
 	flags.Duration("crypto.external.timeout", defs.External.Timeout, "Time-out when invoking the external storage backend, in Golang time.Duration string format (e.g. 1s).")
 
@@ -66,6 +82,8 @@ func ServerCmd() *cobra.Command {
 		Short: "crypto commands",
 	}
 	cmd.AddCommand(fs2VaultCommand())
+	cmd.AddCommand(backupCommand())
+	cmd.AddCommand(restoreCommand())
 	return cmd
 }
 
@@ -106,6 +124,152 @@ func fs2VaultCommand() *cobra.Command {
 	}
 }
 
+func backupCommand() *cobra.Command {
+	result := &cobra.Command{
+		Use:   "backup [file]",
+		Short: "Exports all private keys and key references into an encrypted key archive.",
+		Long: "Exports all private keys and key references into an encrypted key archive, which is written to the given file. " +
+			"The archive is encrypted using either the public key of the recipient (--recipient, a JWK file) or a passphrase. " +
+			"The passphrase is read from the " + archivePassphraseEnv + " environment variable, or otherwise from stdin (prompted for when run in a terminal). " +
+			"It fails if the configured crypto storage doesn't allow private keys to be exported (e.g. an HSM). " +
+			"Can only be run on the local Nuts node, from the directory where nuts.yaml resides.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			archiveKey, err := archiveKeyFromFlags(cmd, "recipient", true)
+			if err != nil {
+				return err
+			}
+			instance, err := LoadCryptoModule(cmd)
+			if err != nil {
+				return err
+			}
+			ctx := audit.Context(cmd.Context(), "app", cryptoEngine.ModuleName, audit.CryptoBackupKeysEvent)
+			archive, err := instance.Backup(ctx, archiveKey)
+			if err != nil {
+				return fmt.Errorf("unable to create key archive: %w", err)
+			}
+			if err = os.WriteFile(args[0], archive, 0600); err != nil {
+				return fmt.Errorf("unable to write key archive: %w", err)
+			}
+			cmd.Println("Key archive written to", args[0])
+			return nil
+		},
+	}
+	result.Flags().String("recipient", "", "Path to a JWK file containing the public key (EC or RSA) to encrypt the key archive for.")
+	return result
+}
+
+func restoreCommand() *cobra.Command {
+	result := &cobra.Command{
+		Use:   "restore [file]",
+		Short: "Imports private keys and key references from an encrypted key archive.",
+		Long: "Imports private keys and key references from an encrypted key archive (created by the backup command) into the configured crypto storage. " +
+			"The archive is decrypted using either the private key of the recipient (--key, a JWK file) or a passphrase. " +
+			"The passphrase is read from the " + archivePassphraseEnv + " environment variable, or otherwise from stdin (prompted for when run in a terminal). " +
+			"Private keys that already exist in the crypto storage are skipped. " +
+			"Can only be run on the local Nuts node, from the directory where nuts.yaml resides.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			archiveKey, err := archiveKeyFromFlags(cmd, "key", false)
+			if err != nil {
+				return err
+			}
+			archive, err := os.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("unable to read key archive: %w", err)
+			}
+			instance, err := LoadCryptoModule(cmd)
+			if err != nil {
+				return err
+			}
+			ctx := audit.Context(cmd.Context(), "app", cryptoEngine.ModuleName, audit.CryptoRestoreKeysEvent)
+			kids, err := instance.Restore(ctx, archive, archiveKey)
+			if err != nil {
+				return fmt.Errorf("unable to restore key archive: %w", err)
+			}
+			cmd.Println(fmt.Sprintf("Restored %d keys:", len(kids)))
+			for _, kid := range kids {
+				cmd.Println("  ", kid)
+			}
+			return nil
+		},
+	}
+	result.Flags().String("key", "", "Path to a JWK file containing the private key to decrypt the key archive with.")
+	return result
+}
+
+// archivePassphraseEnv is the environment variable the key archive passphrase is read from.
+// The passphrase can't be passed as flag, since command line arguments end up in the shell history and process list.
+const archivePassphraseEnv = "NUTS_CRYPTO_ARCHIVE_PASSPHRASE"
+
+// archiveKeyFromFlags reads the key archive JWK from the file specified by the given flag.
+// If the flag isn't set, it reads the passphrase instead (see readArchivePassphrase).
+func archiveKeyFromFlags(cmd *cobra.Command, jwkFlag string, confirm bool) (cryptoEngine.ArchiveKey, error) {
+	var result cryptoEngine.ArchiveKey
+	jwkFile, _ := cmd.Flags().GetString(jwkFlag)
+	if jwkFile == "" {
+		passphrase, err := readArchivePassphrase(cmd, confirm)
+		if err != nil {
+			return result, err
+		}
+		result.Passphrase = passphrase
+		return result, nil
+	}
+	if os.Getenv(archivePassphraseEnv) != "" {
+		return result, fmt.Errorf("only one of %s and --%s can be specified", archivePassphraseEnv, jwkFlag)
+	}
+	data, err := os.ReadFile(jwkFile)
+	if err != nil {
+		return result, fmt.Errorf("unable to read JWK file: %w", err)
+	}
+	result.JWK, err = jwk.ParseKey(data)
+	if err != nil {
+		return result, fmt.Errorf("invalid JWK file: %w", err)
+	}
+	return result, nil
+}
+
+// readArchivePassphrase reads the key archive passphrase from the environment, or otherwise from stdin.
+// If stdin is a terminal, the user is prompted for the passphrase without echoing it.
+// If confirm is true, the user has to enter the passphrase twice when prompted.
+func readArchivePassphrase(cmd *cobra.Command, confirm bool) (string, error) {
+	if passphrase := os.Getenv(archivePassphraseEnv); passphrase != "" {
+		return passphrase, nil
+	}
+	var passphrase string
+	var err error
+	if stdin, ok := cmd.InOrStdin().(*os.File); ok && term.IsTerminal(int(stdin.Fd())) {
+		passphrase, err = promptPassphrase(cmd, int(stdin.Fd()), "Passphrase: ")
+		if err == nil && confirm && passphrase != "" {
+			var confirmation string
+			confirmation, err = promptPassphrase(cmd, int(stdin.Fd()), "Confirm passphrase: ")
+			if err == nil && confirmation != passphrase {
+				return "", errors.New("passphrases do not match")
+			}
+		}
+	} else {
+		passphrase, err = bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+		if errors.Is(err, io.EOF) {
+			err = nil
+		}
+		passphrase = strings.TrimRight(passphrase, "\r\n")
+	}
+	if err != nil {
+		return "", fmt.Errorf("unable to read passphrase: %w", err)
+	}
+	if passphrase == "" {
+		return "", fmt.Errorf("no passphrase or JWK file specified (set %s, or enter the passphrase on stdin)", archivePassphraseEnv)
+	}
+	return passphrase, nil
+}
+
+func promptPassphrase(cmd *cobra.Command, fd int, prompt string) (string, error) {
+	cmd.PrintErr(prompt)
+	passphrase, err := term.ReadPassword(fd)
+	cmd.PrintErrln()
+	return string(passphrase), err
+}
+
 // LoadCryptoModule creates a Crypto module instance and configures it using the given server root command.
 func LoadCryptoModule(cmd *cobra.Command) (*cryptoEngine.Crypto, error) {
 	cfg := core.NewServerConfig()
@@ -32,6 +32,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"path"
 	"strings"
 	"testing"
 
@@ -93,6 +94,8 @@ func Test_fs2VaultCommand(t *testing.T) {
 	t.Setenv("NUTS_CRYPTO_STORAGE", "vaultkv")
 	t.Setenv("NUTS_CRYPTO_VAULT_ADDRESS", s.URL)
 	t.Setenv("NUTS_STRICTMODE", "false")
+	// keep the SQL database out of the source tree
+	t.Setenv("NUTS_DATADIR", testIo.TestDirectory(t))
 
 	testDirectory := testIo.TestDirectory(t)
 	setupFSStoreData(t, testDirectory)
@@ -137,3 +140,85 @@ func setupFSStoreData(t *testing.T, testDirectory string) {
 	_ = fs.SavePrivateKey(ctx, "pk2", pk2)
 	_ = fs.SavePrivateKey(ctx, "pk3", pk3)
 }
+
+func Test_backupRestoreCommand(t *testing.T) {
+	t.Setenv("NUTS_CRYPTO_STORAGE", "fs")
+	t.Setenv("NUTS_STRICTMODE", "false")
+	archiveFile := path.Join(testIo.TestDirectory(t), "keys.jwe")
+
+	execute := func(t *testing.T, datadir string, stdin string, args ...string) (string, error) {
+		t.Setenv("NUTS_DATADIR", datadir)
+		outBuf := new(bytes.Buffer)
+		cryptoCmd := ServerCmd()
+		for _, cmd := range cryptoCmd.Commands() {
+			cmd.Flags().AddFlagSet(core.FlagSet())
+			cmd.Flags().AddFlagSet(FlagSet())
+		}
+		cryptoCmd.SetOut(outBuf)
+		cryptoCmd.SetErr(io.Discard)
+		cryptoCmd.SetIn(strings.NewReader(stdin))
+		cryptoCmd.SetArgs(args)
+		err := cryptoCmd.Execute()
+		return outBuf.String(), err
+	}
+
+	t.Run("ok - passphrase from environment", func(t *testing.T) {
+		t.Setenv("NUTS_CRYPTO_ARCHIVE_PASSPHRASE", "secret")
+		sourceDir := testIo.TestDirectory(t)
+		setupFSStoreData(t, path.Join(sourceDir, "crypto"))
+		targetDir := testIo.TestDirectory(t)
+
+		output, err := execute(t, sourceDir, "", "backup", archiveFile)
+		require.NoError(t, err)
+		assert.Contains(t, output, "Key archive written to")
+
+		_, err = execute(t, targetDir, "", "restore", archiveFile)
+		require.NoError(t, err)
+
+		for _, keyName := range []string{"pk1", "pk2", "pk3"} {
+			assert.FileExists(t, path.Join(targetDir, "crypto", keyName+"_private.pem"))
+		}
+	})
+	t.Run("ok - passphrase from stdin", func(t *testing.T) {
+		sourceDir := testIo.TestDirectory(t)
+		setupFSStoreData(t, path.Join(sourceDir, "crypto"))
+		targetDir := testIo.TestDirectory(t)
+
+		_, err := execute(t, sourceDir, "secret\n", "backup", archiveFile)
+		require.NoError(t, err)
+
+		_, err = execute(t, targetDir, "secret", "restore", archiveFile)
+		require.NoError(t, err)
+
+		for _, keyName := range []string{"pk1", "pk2", "pk3"} {
+			assert.FileExists(t, path.Join(targetDir, "crypto", keyName+"_private.pem"))
+		}
+	})
+	t.Run("missing passphrase and key", func(t *testing.T) {
+		_, err := execute(t, testIo.TestDirectory(t), "", "restore", archiveFile)
+
+		assert.EqualError(t, err, "no passphrase or JWK file specified (set NUTS_CRYPTO_ARCHIVE_PASSPHRASE, or enter the passphrase on stdin)")
+	})
+	t.Run("both passphrase and recipient", func(t *testing.T) {
+		t.Setenv("NUTS_CRYPTO_ARCHIVE_PASSPHRASE", "secret")
+
+		_, err := execute(t, testIo.TestDirectory(t), "", "backup", archiveFile, "--recipient", "recipient.json")
+
+		assert.EqualError(t, err, "only one of NUTS_CRYPTO_ARCHIVE_PASSPHRASE and --recipient can be specified")
+	})
+	t.Run("passphrase flag is not supported", func(t *testing.T) {
+		_, err := execute(t, testIo.TestDirectory(t), "", "backup", archiveFile, "--passphrase", "secret")
+
+		assert.EqualError(t, err, "unknown flag: --passphrase")
+	})
+	t.Run("wrong passphrase", func(t *testing.T) {
+		sourceDir := testIo.TestDirectory(t)
+		setupFSStoreData(t, path.Join(sourceDir, "crypto"))
+		_, err := execute(t, sourceDir, "secret\n", "backup", archiveFile)
+		require.NoError(t, err)
+
+		_, err = execute(t, testIo.TestDirectory(t), "wrong\n", "restore", archiveFile)
+
+		assert.ErrorContains(t, err, "unable to restore key archive: unable to decrypt key archive")
+	})
+}
@@ -28,14 +28,18 @@ import (
 	"github.com/nuts-foundation/nuts-node/storage"
 	"github.com/nuts-foundation/nuts-node/storage/orm"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"io"
 	"path"
 	"time"
 
 	"github.com/nuts-foundation/nuts-node/audit"
 	"github.com/nuts-foundation/nuts-node/core"
 	"github.com/nuts-foundation/nuts-node/crypto/log"
+	"github.com/nuts-foundation/nuts-node/crypto/storage/csc"
 	"github.com/nuts-foundation/nuts-node/crypto/storage/external"
 	"github.com/nuts-foundation/nuts-node/crypto/storage/fs"
+	"github.com/nuts-foundation/nuts-node/crypto/storage/pkcs11"
 	"github.com/nuts-foundation/nuts-node/crypto/storage/spi"
 	"github.com/nuts-foundation/nuts-node/crypto/storage/vault"
 )
@@ -50,6 +54,8 @@ type Config struct {
 	Storage       string          `koanf:"storage"`
 	Vault         vault.Config    `koanf:"vault"`
 	AzureKeyVault azure.Config    `koanf:"azurekv"`
+	PKCS11        pkcs11.Config   `koanf:"pkcs11"`
+	CSC           csc.Config      `koanf:"csc"`
 	External      external.Config `koanf:"external"`
 }
 
@@ -58,6 +64,8 @@ func DefaultCryptoConfig() Config {
 	return Config{
 		Vault:         vault.DefaultConfig(),
 		AzureKeyVault: azure.DefaultConfig(),
+		PKCS11:        pkcs11.DefaultConfig(),
+		CSC:           csc.DefaultConfig(),
 		External: external.Config{
 			Timeout: 100 * time.Millisecond,
 		},
@@ -139,6 +147,26 @@ func (client *Crypto) setupAzureKeyVaultBackend(_ core.ServerConfig) error {
 	return nil
 }
 
+func (client *Crypto) setupPKCS11Backend(_ core.ServerConfig) error {
+	log.Logger().Debug("Setting up PKCS#11 backend for storage of private key material.")
+	pkcs11Backend, err := pkcs11.New(client.config.PKCS11)
+	if err != nil {
+		return err
+	}
+	client.backend = spi.NewValidatedKIDBackendWrapper(pkcs11Backend, spi.KidPattern)
+	return nil
+}
+
+func (client *Crypto) setupCSCBackend(_ core.ServerConfig) error {
+	log.Logger().Debug("Setting up CSC backend for remote signing with private keys held by a trust service provider.")
+	cscBackend, err := csc.New(client.config.CSC)
+	if err != nil {
+		return err
+	}
+	client.backend = spi.NewValidatedKIDBackendWrapper(cscBackend, spi.KidPattern)
+	return nil
+}
+
 // List returns the KIDs of the private keys that are present in the key store.
 func (client *Crypto) List(ctx context.Context) []string {
 	kids := make([]string, 0)
@@ -170,6 +198,10 @@ func (client *Crypto) Configure(config core.ServerConfig) error {
 		return client.setupVaultBackend(config)
 	case azure.StorageType:
 		return client.setupAzureKeyVaultBackend(config)
+	case pkcs11.StorageType:
+		return client.setupPKCS11Backend(config)
+	case csc.StorageType:
+		return client.setupCSCBackend(config)
 	case external.StorageType:
 		return client.setupStorageAPIBackend()
 	case "":
@@ -179,8 +211,21 @@ func (client *Crypto) Configure(config core.ServerConfig) error {
 		// default to file system and run this setup again
 		return client.setupFSBackend(config)
 	default:
-		return fmt.Errorf("invalid config for crypto.storage. Available options are: vaultkv, fs, %s(experimental)", external.StorageType)
+		return fmt.Errorf("invalid config for crypto.storage. Available options are: vaultkv, fs, %s, %s, %s, %s(experimental)", azure.StorageType, pkcs11.StorageType, csc.StorageType, external.StorageType)
+	}
+}
+
+// Start does nothing, the backend is set up in Configure.
+func (client *Crypto) Start() error {
+	return nil
+}
+
+// Shutdown releases the resources held by the storage backend (e.g. the PKCS#11 session).
+func (client *Crypto) Shutdown() error {
+	if closer, ok := client.backend.(io.Closer); ok {
+		return closer.Close()
 	}
+	return nil
 }
 
 func (client *Crypto) Migrate() error {
@@ -224,14 +269,21 @@ func (client *Crypto) Migrate() error {
 // New generates a new key pair.
 // Stores the private key, returns the public key and DB reference.
 // It returns an error when a key with the resulting ID already exists.
+// If the backend can't generate keys but hands out provisioned keys (spi.KeyClaimer), a key that isn't referenced yet is claimed instead.
 func (client *Crypto) New(ctx context.Context, namingFunc KIDNamingFunc) (*orm.KeyReference, crypto.PublicKey, error) {
 	var ref *orm.KeyReference
 	var publicKey crypto.PublicKey
 	err := client.continueTransaction(ctx, func(tx *gorm.DB) error {
-		keyName := uuid.New().String()
+		var keyName, version string
 		var err error
-		var version string
-		publicKey, version, err = client.backend.NewPrivateKey(ctx, keyName)
+		if claimer, ok := client.backend.(spi.KeyClaimer); ok {
+			keyName, publicKey, version, err = claimer.ClaimPrivateKey(ctx, func(keyName string) (bool, error) {
+				return client.claimKey(ctx, tx, keyName)
+			})
+		} else {
+			keyName = uuid.New().String()
+			publicKey, version, err = client.backend.NewPrivateKey(ctx, keyName)
+		}
 		if err != nil {
 			return err
 		}
@@ -251,6 +303,26 @@ func (client *Crypto) New(ctx context.Context, namingFunc KIDNamingFunc) (*orm.K
 	return ref, publicKey, err
 }
 
+// claimKey claims the provisioned key with the given name, it returns false if the key has already been claimed.
+// The claim is recorded in the key_claim table, of which the primary key prevents concurrent transactions (e.g. of nodes sharing the database)
+// from claiming the same key. Keys referenced by a KID other than their own name (e.g. restored from a backup) count as claimed as well.
+// Keys registered by Migrate are referenced by their own name, which doesn't count as a claim.
+func (client *Crypto) claimKey(ctx context.Context, tx *gorm.DB, keyName string) (bool, error) {
+	var count int64
+	err := tx.WithContext(ctx).Model(&orm.KeyReference{}).Where("key_name = ? and kid <> ?", keyName, keyName).Count(&count).Error
+	if err != nil {
+		return false, fmt.Errorf("error finding KeyReference in DB: %w", err)
+	}
+	if count > 0 {
+		return false, nil
+	}
+	result := tx.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&orm.KeyClaim{KeyName: keyName})
+	if result.Error != nil {
+		return false, fmt.Errorf("unable to claim key (name=%s): %w", keyName, result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
 // Delete removes the private key with the given KID from the KeyStore.
 func (client *Crypto) Delete(ctx context.Context, kid string) error {
 	return client.continueTransaction(ctx, func(tx *gorm.DB) error {
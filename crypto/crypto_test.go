@@ -20,6 +20,8 @@ package crypto
 
 import (
 	"context"
+	"crypto"
+	"errors"
 	"github.com/nuts-foundation/nuts-node/audit"
 	"github.com/nuts-foundation/nuts-node/crypto/storage/fs"
 	"github.com/nuts-foundation/nuts-node/crypto/storage/spi"
@@ -122,6 +124,45 @@ func TestCrypto_New(t *testing.T) {
 		require.Error(t, err)
 		assert.ErrorIs(t, err, assert.AnError)
 	})
+	t.Run("ok - provisioned key is claimed", func(t *testing.T) {
+		key, _ := spi.GenerateKeyPair()
+		backend := &provisionedStorage{Storage: spi.NewMockStorage(gomock.NewController(t)), keys: map[string]crypto.PublicKey{"seal": key.Public()}}
+		client := createCrypto(t)
+		client.backend = spi.NewValidatedKIDBackendWrapper(backend, spi.KidPattern)
+		// keys registered by Migrate are referenced by their own name, which doesn't count as claim
+		require.NoError(t, client.Link(ctx, "seal", "seal", "1"))
+
+		ref, publicKey, err := client.New(ctx, StringNamingFunc("did:web:example.com#1"))
+
+		require.NoError(t, err)
+		assert.Equal(t, "seal", ref.KeyName)
+		assert.Equal(t, "did:web:example.com#1", ref.KID)
+		assert.Equal(t, key.Public(), publicKey)
+
+		t.Run("error - provisioned key already claimed", func(t *testing.T) {
+			_, _, err := client.New(ctx, StringNamingFunc("did:web:example.com#2"))
+
+			assert.EqualError(t, err, "no unclaimed keys")
+		})
+		t.Run("error - claim remains when key reference is removed", func(t *testing.T) {
+			require.NoError(t, client.db.Where("kid = ?", ref.KID).Delete(&orm.KeyReference{}).Error)
+
+			_, _, err := client.New(ctx, StringNamingFunc("did:web:example.com#3"))
+
+			assert.EqualError(t, err, "no unclaimed keys")
+		})
+	})
+	t.Run("provisioned key referenced by another KID is not claimed", func(t *testing.T) {
+		key, _ := spi.GenerateKeyPair()
+		backend := &provisionedStorage{Storage: spi.NewMockStorage(gomock.NewController(t)), keys: map[string]crypto.PublicKey{"restored": key.Public()}}
+		client := createCrypto(t)
+		client.backend = spi.NewValidatedKIDBackendWrapper(backend, spi.KidPattern)
+		require.NoError(t, client.Link(ctx, "did:web:example.com#restored", "restored", "1"))
+
+		_, _, err := client.New(ctx, StringNamingFunc("did:web:example.com#1"))
+
+		assert.EqualError(t, err, "no unclaimed keys")
+	})
 	t.Run("error from backend", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		storageMock := spi.NewMockStorage(ctrl)
@@ -240,10 +281,72 @@ func TestCrypto_Configure(t *testing.T) {
 		client := createCrypto(t)
 		client.config.Storage = "unknown"
 		err := client.Configure(cfg)
-		assert.EqualError(t, err, "invalid config for crypto.storage. Available options are: vaultkv, fs, external(experimental)", "expected error")
+		assert.EqualError(t, err, "invalid config for crypto.storage. Available options are: vaultkv, fs, azure-keyvault, pkcs11, csc, external(experimental)", "expected error")
+	})
+	t.Run("error - pkcs11 backend without library", func(t *testing.T) {
+		client := createCrypto(t)
+		client.config.Storage = "pkcs11"
+		err := client.Configure(cfg)
+		assert.EqualError(t, err, "missing PKCS#11 library path")
+	})
+	t.Run("error - csc backend without address", func(t *testing.T) {
+		client := createCrypto(t)
+		client.config.Storage = "csc"
+		err := client.Configure(cfg)
+		assert.EqualError(t, err, "missing CSC API address")
+	})
+}
+
+func TestCrypto_Shutdown(t *testing.T) {
+	t.Run("closes backend", func(t *testing.T) {
+		backend := &closableStorage{Storage: spi.NewMockStorage(gomock.NewController(t))}
+		client := createCrypto(t)
+		client.backend = spi.NewValidatedKIDBackendWrapper(backend, spi.KidPattern)
+
+		err := client.Shutdown()
+
+		require.NoError(t, err)
+		assert.True(t, backend.closed)
+	})
+	t.Run("backend without resources", func(t *testing.T) {
+		client := createCrypto(t)
+
+		err := client.Shutdown()
+
+		assert.NoError(t, err)
 	})
 }
 
+// provisionedStorage is a spi.Storage that hands out provisioned keys (spi.KeyClaimer).
+type provisionedStorage struct {
+	spi.Storage
+	keys map[string]crypto.PublicKey
+}
+
+func (p *provisionedStorage) ClaimPrivateKey(_ context.Context, claim func(keyName string) (bool, error)) (string, crypto.PublicKey, string, error) {
+	for keyName, publicKey := range p.keys {
+		claimed, err := claim(keyName)
+		if err != nil {
+			return "", nil, "", err
+		}
+		if claimed {
+			return keyName, publicKey, "1", nil
+		}
+	}
+	return "", nil, "", errors.New("no unclaimed keys")
+}
+
+// closableStorage is a spi.Storage that records whether it has been closed.
+type closableStorage struct {
+	spi.Storage
+	closed bool
+}
+
+func (c *closableStorage) Close() error {
+	c.closed = true
+	return nil
+}
+
 func Test_CryptoGetters(t *testing.T) {
 	instance := NewCryptoInstance(nil)
 	assert.Equal(t, ModuleName, instance.Name())
@@ -22,10 +22,15 @@ import (
 	"context"
 	"crypto/ecdsa"
 	"errors"
+
+	"github.com/nuts-foundation/nuts-node/storage/orm"
 )
 
 // Decrypt decrypts the `cipherText` with key `kid`
 func (client *Crypto) Decrypt(ctx context.Context, kid string, cipherText []byte) ([]byte, error) {
+	if err := client.checkKeyUsage(ctx, kid, orm.KeyAgreementUsage); err != nil {
+		return nil, err
+	}
 	keyRef, err := client.findKeyReferenceByKid(ctx, kid)
 	if err != nil {
 		return nil, err
@@ -24,6 +24,9 @@ import (
 )
 
 func (client *Crypto) SignDPoP(ctx context.Context, token dpop.DPoP, kid string) (string, error) {
+	if err := client.checkKeyUsage(ctx, kid, signingKeyUsageFor(ctx, nil)); err != nil {
+		return "", err
+	}
 	privateKey, _, err := client.getPrivateKey(ctx, kid)
 	if err != nil {
 		return "", err
@@ -22,6 +22,7 @@ import (
 	"context"
 	"crypto"
 	"errors"
+	"github.com/lestrrat-go/jwx/v2/jwk"
 	"github.com/nuts-foundation/nuts-node/crypto/dpop"
 	"github.com/nuts-foundation/nuts-node/storage/orm"
 )
@@ -61,6 +62,7 @@ type KeyStore interface {
 	KeyCreator
 	KeyResolver
 	JWTSigner
+	DataSigner
 
 	// Delete removes the private key with the given KID from the KeyStore.
 	Delete(ctx context.Context, kid string) error
@@ -68,6 +70,13 @@ type KeyStore interface {
 	// Link links the key in the keystore to a kid
 	// see https://github.com/nuts-foundation/nuts-node/issues/3292
 	Link(ctx context.Context, kid string, keyName string, version string) error
+
+	// Backup exports all private keys and their key references into an archive, encrypted using the given key.
+	// The context is used to pass audit information.
+	Backup(ctx context.Context, key ArchiveKey) ([]byte, error)
+	// Restore imports the private keys and key references from an archive created by Backup, decrypting it using the given key.
+	// It returns the KIDs of the restored key references. The context is used to pass audit information.
+	Restore(ctx context.Context, archive []byte, key ArchiveKey) ([]string, error)
 }
 
 // Decrypter is the interface to support decryption
@@ -99,6 +108,15 @@ type JWTSigner interface {
 	SignDPoP(ctx context.Context, token dpop.DPoP, kid string) (string, error)
 }
 
+// DataSigner is the interface used to sign arbitrary data, e.g. for Data Integrity proofs.
+type DataSigner interface {
+	// SignData signs the data using the indicated key, with the signature algorithm that matches the key type (e.g. ES256 for P-256 keys, EdDSA for Ed25519 keys).
+	// ECDSA signatures are returned in IEEE P1363 format (r || s), as used by JWS and Data Integrity cryptosuites.
+	// The context is used to pass audit information.
+	// Returns ErrPrivateKeyNotFound when the private key is not present.
+	SignData(ctx context.Context, data []byte, kid string) ([]byte, error)
+}
+
 // JsonWebEncryptor is the interface used to encrypt and decrypt JWE messages.
 type JsonWebEncryptor interface {
 	// EncryptJWE encrypts a payload as bytes into a JWE message with the given key and kid.
@@ -106,7 +124,12 @@ type JsonWebEncryptor interface {
 	// The kid must be the KeyID and will be placed in the header, if not set.
 	EncryptJWE(ctx context.Context, payload []byte, headers map[string]interface{}, publicKey interface{}) (string, error)
 
+	// EncryptJWEForRecipients encrypts a payload as bytes into a JWE message (JSON serialization) for each of the given recipients.
+	// Each recipient must be a public key with a kid, which will be placed in the recipient's header.
+	EncryptJWEForRecipients(ctx context.Context, payload []byte, headers map[string]interface{}, recipients []jwk.Key) (string, error)
+
 	// DecryptJWE decrypts a message as bytes into a decrypted body and headers.
 	// The corresponding private key must be located in the KeyID (kid) header.
+	// If the message has multiple recipients, the first recipient for which the private key is present in the key store is used.
 	DecryptJWE(ctx context.Context, message string) (body []byte, headers map[string]interface{}, err error)
 }
@@ -39,6 +39,7 @@ import (
 	"github.com/nuts-foundation/nuts-node/crypto/jwx"
 	"github.com/nuts-foundation/nuts-node/crypto/log"
 	"github.com/nuts-foundation/nuts-node/crypto/storage/spi"
+	"github.com/nuts-foundation/nuts-node/storage/orm"
 )
 
 // GenerateJWK a new in-memory key pair and returns it as JWK.
@@ -61,6 +62,9 @@ func (client *Crypto) SignJWT(ctx context.Context, claims map[string]interface{}
 	headersLocal := make(map[string]interface{})
 	maps.Copy(headersLocal, headers)
 
+	if err := client.checkKeyUsage(ctx, kid, signingKeyUsageFor(ctx, claims)); err != nil {
+		return "", err
+	}
 	privateKey, kid, err := client.getPrivateKey(ctx, kid)
 	if err != nil {
 		return "", err
@@ -77,6 +81,9 @@ func (client *Crypto) SignJWT(ctx context.Context, claims map[string]interface{}
 
 // SignJWS creates a signed JWS using the indicated key and map of headers and payload as bytes.
 func (client *Crypto) SignJWS(ctx context.Context, payload []byte, headers map[string]interface{}, kid string, detached bool) (string, error) {
+	if err := client.checkKeyUsage(ctx, kid, signingKeyUsageFor(ctx, nil)); err != nil {
+		return "", err
+	}
 	privateKey, kid, err := client.getPrivateKey(ctx, kid)
 	if err != nil {
 		return "", err
@@ -86,23 +93,52 @@ func (client *Crypto) SignJWS(ctx context.Context, payload []byte, headers map[s
 	return SignJWS(ctx, payload, headers, privateKey, detached)
 }
 
+func (client *Crypto) SignData(ctx context.Context, data []byte, kid string) ([]byte, error) {
+	if err := client.checkKeyUsage(ctx, kid, signingKeyUsageFor(ctx, nil)); err != nil {
+		return nil, err
+	}
+	privateKey, kid, err := client.getPrivateKey(ctx, kid)
+	if err != nil {
+		return nil, err
+	}
+	audit.Log(ctx, log.Logger(), audit.CryptoSignDataEvent).Infof("Signing data with key: %s", kid)
+	alg, err := SignatureAlgorithm(privateKey.Public())
+	if err != nil {
+		return nil, err
+	}
+	signer, err := jws.NewSigner(alg)
+	if err != nil {
+		return nil, err
+	}
+	return signer.Sign(data, privateKey)
+}
+
 // EncryptJWE encrypts a payload using the provided public key and key identifier.
 func (client *Crypto) EncryptJWE(ctx context.Context, payload []byte, headers map[string]interface{}, publicKey interface{}) (string, error) {
 	audit.Log(ctx, log.Logger(), audit.CryptoEncryptJWEEvent).Info("Encrypting a JWE")
 	return EncryptJWE(payload, headers, publicKey)
 }
 
+// EncryptJWEForRecipients encrypts a payload for multiple recipients, using the provided public keys.
+func (client *Crypto) EncryptJWEForRecipients(ctx context.Context, payload []byte, headers map[string]interface{}, recipients []jwk.Key) (string, error) {
+	audit.Log(ctx, log.Logger(), audit.CryptoEncryptJWEEvent).Infof("Encrypting a JWE for %d recipients", len(recipients))
+	return EncryptJWEForRecipients(payload, headers, recipients)
+}
+
 // DecryptJWE decrypts a message using the associated private key from the kid header.
+// If the message has multiple recipients (JSON serialization), the first recipient of which the private key is present is used.
 func (client *Crypto) DecryptJWE(ctx context.Context, message string) (body []byte, headers map[string]interface{}, err error) {
 	msg, err := jwe.Parse([]byte(message))
 	if err != nil {
 		return nil, nil, err
 	}
 
-	protectedHeaders := msg.ProtectedHeaders()
-	kid := protectedHeaders.KeyID()
-	if len(kid) == 0 {
-		return nil, nil, errors.New("kid header not found")
+	kid, alg, err := client.selectJWERecipient(ctx, msg)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = client.checkKeyUsage(ctx, kid, orm.KeyAgreementUsage); err != nil {
+		return nil, nil, err
 	}
 	privateKey, kid, err := client.getPrivateKey(ctx, kid)
 	if err != nil {
@@ -115,7 +151,7 @@ func (client *Crypto) DecryptJWE(ctx context.Context, message string) (body []by
 	if err != nil {
 		return nil, nil, fmt.Errorf("keys stored in '%s' do not support JWE decryption", client.backend.Name())
 	}
-	body, err = jwe.Decrypt([]byte(message), jwe.WithKey(protectedHeaders.Algorithm(), keyJWK))
+	body, err = jwe.Decrypt([]byte(message), jwe.WithKey(alg, keyJWK))
 	if err != nil {
 		return nil, nil, err
 	}
@@ -322,6 +358,88 @@ func EncryptJWE(payload []byte, protectedHeaders map[string]interface{}, publicK
 	return string(encoded), err
 }
 
+// EncryptJWEForRecipients encrypts a payload for each of the given recipient public keys into a JWE in JSON serialization.
+// Each recipient key must have a key ID, which is placed in the recipient's header.
+// The key encryption algorithm is derived from the key type of each recipient, so the protected headers can't contain the alg or kid header.
+func EncryptJWEForRecipients(payload []byte, protectedHeaders map[string]interface{}, recipients []jwk.Key) (string, error) {
+	if len(recipients) == 0 {
+		return "", errors.New("no recipients provided")
+	}
+	if _, ok := protectedHeaders[jwe.AlgorithmKey]; ok {
+		return "", errors.New("alg header is not allowed for multiple recipients")
+	}
+	if _, ok := protectedHeaders[jwe.KeyIDKey]; ok {
+		return "", errors.New("kid header is not allowed for multiple recipients")
+	}
+	data, err := json.Marshal(protectedHeaders)
+	if err != nil {
+		return "", err
+	}
+	headers := jwe.NewHeaders()
+	if err = headers.UnmarshalJSON(data); err != nil {
+		return "", err
+	}
+	enc := jwx.DefaultContentEncryptionAlgorithm
+	if len(headers.ContentEncryption().String()) > 0 {
+		enc = headers.ContentEncryption()
+	}
+	options := []jwe.EncryptOption{
+		jwe.WithJSON(),
+		jwe.WithProtectedHeaders(headers),
+		jwe.WithContentEncryption(enc),
+		jwe.WithCompress(headers.Compression()), // "" means no compression
+	}
+	for _, recipient := range recipients {
+		if recipient.KeyID() == "" {
+			return "", errors.New("recipient key has no kid")
+		}
+		var publicKey crypto.PublicKey
+		if err = recipient.Raw(&publicKey); err != nil {
+			return "", fmt.Errorf("invalid recipient key (kid=%s): %w", recipient.KeyID(), err)
+		}
+		alg, err := encryptionAlgorithm(publicKey)
+		if err != nil {
+			return "", fmt.Errorf("invalid recipient key (kid=%s): %w", recipient.KeyID(), err)
+		}
+		options = append(options, jwe.WithKey(alg, recipient))
+	}
+	encoded, err := jwe.Encrypt(payload, options...)
+	return string(encoded), err
+}
+
+// selectJWERecipient returns the kid and key encryption algorithm of the JWE recipient that can be decrypted using a private key in the key store.
+// For compact serialized JWEs (single recipient) these are taken from the protected headers.
+func (client *Crypto) selectJWERecipient(ctx context.Context, msg *jwe.Message) (string, jwa.KeyEncryptionAlgorithm, error) {
+	protectedHeaders := msg.ProtectedHeaders()
+	if kid := protectedHeaders.KeyID(); kid != "" {
+		return kid, protectedHeaders.Algorithm(), nil
+	}
+	var kidFound bool
+	for _, recipient := range msg.Recipients() {
+		kid := recipient.Headers().KeyID()
+		if kid == "" {
+			continue
+		}
+		kidFound = true
+		_, err := client.findKeyReferenceByKid(ctx, kid)
+		if errors.Is(err, ErrPrivateKeyNotFound) {
+			continue
+		}
+		if err != nil {
+			return "", "", err
+		}
+		alg := recipient.Headers().Algorithm()
+		if alg == "" {
+			alg = protectedHeaders.Algorithm()
+		}
+		return kid, alg, nil
+	}
+	if !kidFound {
+		return "", "", errors.New("kid header not found")
+	}
+	return "", "", ErrPrivateKeyNotFound
+}
+
 // ExtractProtectedHeaders extracts the protected headers from a JWT string.
 // The function takes a JWT string as input and returns a map of the protected headers.
 // Note that:
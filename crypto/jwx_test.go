@@ -308,6 +308,37 @@ func TestCrypto_SignJWS(t *testing.T) {
 	})
 }
 
+func TestCrypto_SignData(t *testing.T) {
+	client := createCrypto(t)
+
+	kid := "kid"
+	_, pubKey := newKeyReference(t, client, kid)
+
+	t.Run("creates valid signature", func(t *testing.T) {
+		data := []byte("hello world")
+
+		signature, err := client.SignData(audit.TestContext(), data, kid)
+
+		require.NoError(t, err)
+		verifier, err := jws.NewVerifier(jwa.ES256)
+		require.NoError(t, err)
+		assert.NoError(t, verifier.Verify(data, signature, pubKey))
+	})
+	t.Run("writes audit log", func(t *testing.T) {
+		auditLogs := audit.CaptureAuditLogs(t)
+
+		_, err := client.SignData(audit.TestContext(), []byte{1, 2, 3}, kid)
+
+		require.NoError(t, err)
+		auditLogs.AssertContains(t, ModuleName, "SignData", audit.TestActor, "Signing data with key: kid")
+	})
+	t.Run("returns error for not found", func(t *testing.T) {
+		_, err := client.SignData(audit.TestContext(), []byte{1, 2, 3}, "unknown")
+
+		assert.ErrorIs(t, err, ErrPrivateKeyNotFound)
+	})
+}
+
 func TestCrypto_EncryptJWE(t *testing.T) {
 	client := createCrypto(t)
 
@@ -474,6 +505,97 @@ func TestCrypto_DecryptJWE(t *testing.T) {
 
 		auditLogs.AssertContains(t, ModuleName, "DecryptJWE", audit.TestActor, fmt.Sprintf("Decrypting a JWE with kid: %s", kid))
 	})
+	t.Run("multiple recipients, selects local key", func(t *testing.T) {
+		remoteKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		remoteRSAKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+		recipients := []jwk.Key{
+			recipientJWK(t, "did:nuts:remote#key-1", remoteKey.Public()),
+			recipientJWK(t, "did:nuts:remote#key-2", remoteRSAKey.Public()),
+			recipientJWK(t, kid, pubKey),
+		}
+		message, err := client.EncryptJWEForRecipients(audit.TestContext(), []byte("hello"), map[string]interface{}{"typ": "JWT"}, recipients)
+		require.NoError(t, err)
+
+		body, hdrs, err := client.DecryptJWE(audit.TestContext(), message)
+
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(body))
+		assert.Equal(t, "JWT", hdrs["typ"])
+	})
+	t.Run("multiple recipients, no local key", func(t *testing.T) {
+		remoteKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		message, err := EncryptJWEForRecipients([]byte("hello"), map[string]interface{}{}, []jwk.Key{recipientJWK(t, "did:nuts:remote#key-1", remoteKey.Public())})
+		require.NoError(t, err)
+
+		_, _, err = client.DecryptJWE(audit.TestContext(), message)
+
+		assert.ErrorIs(t, err, ErrPrivateKeyNotFound)
+	})
+}
+
+func TestEncryptJWEForRecipients(t *testing.T) {
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	recipients := []jwk.Key{
+		recipientJWK(t, "ec", ecKey.Public()),
+		recipientJWK(t, "rsa", rsaKey.Public()),
+	}
+	t.Run("ok", func(t *testing.T) {
+		message, err := EncryptJWEForRecipients([]byte("hello"), map[string]interface{}{"typ": "JWT"}, recipients)
+		require.NoError(t, err)
+
+		msg, err := jwe.Parse([]byte(message))
+		require.NoError(t, err)
+		require.Len(t, msg.Recipients(), 2)
+		assert.Equal(t, "ec", msg.Recipients()[0].Headers().KeyID())
+		assert.Equal(t, jwx.DefaultEcEncryptionAlgorithm, msg.Recipients()[0].Headers().Algorithm())
+		assert.Equal(t, "rsa", msg.Recipients()[1].Headers().KeyID())
+		assert.Equal(t, jwx.DefaultRsaEncryptionAlgorithm, msg.Recipients()[1].Headers().Algorithm())
+		assert.Equal(t, "JWT", msg.ProtectedHeaders().Type())
+		for _, privateKey := range []interface{}{ecKey, rsaKey} {
+			alg, _ := encryptionAlgorithm(privateKey.(crypto.Signer).Public())
+			body, err := jwe.Decrypt([]byte(message), jwe.WithKey(alg, privateKey))
+			require.NoError(t, err)
+			assert.Equal(t, "hello", string(body))
+		}
+	})
+	t.Run("no recipients", func(t *testing.T) {
+		_, err := EncryptJWEForRecipients([]byte("hello"), map[string]interface{}{}, nil)
+
+		assert.EqualError(t, err, "no recipients provided")
+	})
+	t.Run("recipient without kid", func(t *testing.T) {
+		key, _ := jwk.FromRaw(ecKey.Public())
+
+		_, err := EncryptJWEForRecipients([]byte("hello"), map[string]interface{}{}, []jwk.Key{key})
+
+		assert.EqualError(t, err, "recipient key has no kid")
+	})
+	t.Run("alg header is not allowed", func(t *testing.T) {
+		_, err := EncryptJWEForRecipients([]byte("hello"), map[string]interface{}{"alg": "RSA-OAEP"}, recipients)
+
+		assert.EqualError(t, err, "alg header is not allowed for multiple recipients")
+	})
+	t.Run("kid header is not allowed", func(t *testing.T) {
+		_, err := EncryptJWEForRecipients([]byte("hello"), map[string]interface{}{"kid": "ec"}, recipients)
+
+		assert.EqualError(t, err, "kid header is not allowed for multiple recipients")
+	})
+	t.Run("unsupported key type", func(t *testing.T) {
+		edKey, _, _ := ed25519.GenerateKey(rand.Reader)
+
+		_, err := EncryptJWEForRecipients([]byte("hello"), map[string]interface{}{}, []jwk.Key{recipientJWK(t, "ed", edKey)})
+
+		assert.ErrorContains(t, err, "invalid recipient key (kid=ed): could not determine encryption algorithm")
+	})
+}
+
+func recipientJWK(t *testing.T, kid string, publicKey crypto.PublicKey) jwk.Key {
+	t.Helper()
+	key, err := jwk.FromRaw(publicKey)
+	require.NoError(t, err)
+	require.NoError(t, key.Set(jwk.KeyIDKey, kid))
+	return key
 }
 
 func TestSignJWS(t *testing.T) {
@@ -0,0 +1,111 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package crypto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nuts-foundation/nuts-node/audit"
+	"github.com/nuts-foundation/nuts-node/crypto/log"
+	"github.com/nuts-foundation/nuts-node/storage/orm"
+	"gorm.io/gorm"
+)
+
+// ErrKeyUsageNotAllowed is returned when a key is used for a purpose it isn't registered for in its DID document.
+var ErrKeyUsageNotAllowed = errors.New("key usage not allowed")
+
+// signingKeyUsage contains the verification method relationships that allow a key to be used for signing.
+const signingKeyUsage = orm.AssertionMethodUsage | orm.AuthenticationUsage | orm.CapabilityDelegationUsage | orm.CapabilityInvocationUsage
+
+// presentationKeyUsage contains the verification method relationships that allow a key to be used for signing Verifiable Presentations.
+// Next to authentication, it includes assertionMethod since Nuts nodes sign VPs with the holder's assertionMethod key.
+const presentationKeyUsage = orm.AuthenticationUsage | orm.AssertionMethodUsage
+
+type keyUsageContextKey struct{}
+
+// WithKeyUsage returns a child context of the given parent context, specifying the purpose (verification method relationship)
+// for which keys are used when signing with the KeyStore. If multiple flags are set, a key must be registered for at least one of them.
+// If not specified, the KeyStore derives the purpose from the JWT claims (e.g. 'vc' or 'vp'), or allows any signing key.
+func WithKeyUsage(parent context.Context, usage orm.DIDKeyFlags) context.Context {
+	return context.WithValue(parent, keyUsageContextKey{}, usage)
+}
+
+// signingKeyUsageFor returns the verification method relationships that allow a key to be used for signing the given JWT claims (which may be nil).
+func signingKeyUsageFor(ctx context.Context, claims map[string]interface{}) orm.DIDKeyFlags {
+	if usage, ok := ctx.Value(keyUsageContextKey{}).(orm.DIDKeyFlags); ok && usage != 0 {
+		return usage
+	}
+	if _, ok := claims["vc"]; ok {
+		return orm.AssertionMethodUsage
+	}
+	if _, ok := claims["vp"]; ok {
+		return presentationKeyUsage
+	}
+	return signingKeyUsage
+}
+
+// checkKeyUsage checks whether the key identified by the given KID may be used for (one of) the given verification method relationships.
+// The key usage is derived from the verification method with the same ID in the DID document(s) managed by this node.
+// Keys that aren't registered in any DID document are not restricted.
+// A refusal is logged as audit event.
+func (client *Crypto) checkKeyUsage(ctx context.Context, kid string, required orm.DIDKeyFlags) error {
+	var verificationMethods []orm.VerificationMethod
+	err := client.continueTransaction(ctx, func(tx *gorm.DB) error {
+		return tx.WithContext(ctx).Model(&orm.VerificationMethod{}).Select("key_types").Where("id = ?", kid).Find(&verificationMethods).Error
+	})
+	if err != nil {
+		return fmt.Errorf("could not find verification method in DB: %w", err)
+	}
+	if len(verificationMethods) == 0 {
+		return nil
+	}
+	registered := orm.DIDKeyFlags(verificationMethods[0].KeyTypes)
+	if registered.Is(required) {
+		return nil
+	}
+	audit.Log(ctx, log.Logger(), audit.CryptoKeyUsageDeniedEvent).
+		Warnf("Refused to use key for %s, it is registered for: %s (kid=%s)", keyUsageString(required), keyUsageString(registered), kid)
+	return fmt.Errorf("%w: key is not registered for %s (kid=%s)", ErrKeyUsageNotAllowed, keyUsageString(required), kid)
+}
+
+// keyUsageString returns a human-readable representation of the given key usage flags, e.g. "assertionMethod or authentication".
+func keyUsageString(flags orm.DIDKeyFlags) string {
+	var names []string
+	for _, usage := range []struct {
+		flag orm.DIDKeyFlags
+		name string
+	}{
+		{orm.AssertionMethodUsage, "assertionMethod"},
+		{orm.AuthenticationUsage, "authentication"},
+		{orm.CapabilityDelegationUsage, "capabilityDelegation"},
+		{orm.CapabilityInvocationUsage, "capabilityInvocation"},
+		{orm.KeyAgreementUsage, "keyAgreement"},
+	} {
+		if flags.Is(usage.flag) {
+			names = append(names, usage.name)
+		}
+	}
+	if len(names) == 0 {
+		return "nothing"
+	}
+	return strings.Join(names, " or ")
+}
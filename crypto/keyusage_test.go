@@ -0,0 +1,155 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package crypto
+
+import (
+	"testing"
+
+	"github.com/nuts-foundation/nuts-node/audit"
+	"github.com/nuts-foundation/nuts-node/storage/orm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCrypto_KeyUsage(t *testing.T) {
+	ctx := audit.TestContext()
+	client := createCrypto(t)
+	assertionKID := registerKeyWithUsage(t, client, "did:test:alice", "assertion", orm.AssertionMethodUsage)
+	authenticationKID := registerKeyWithUsage(t, client, "did:test:bob", "authentication", orm.AuthenticationUsage)
+	keyAgreementKID := registerKeyWithUsage(t, client, "did:test:charlie", "keyagreement", orm.KeyAgreementUsage)
+	_, _, err := client.New(ctx, StringNamingFunc("unregistered"))
+	require.NoError(t, err)
+
+	t.Run("SignJWT", func(t *testing.T) {
+		vcClaims := map[string]interface{}{"vc": map[string]interface{}{}}
+		vpClaims := map[string]interface{}{"vp": map[string]interface{}{}}
+		t.Run("VC with assertionMethod key", func(t *testing.T) {
+			_, err := client.SignJWT(ctx, vcClaims, nil, assertionKID)
+
+			assert.NoError(t, err)
+		})
+		t.Run("VC with authentication key is refused", func(t *testing.T) {
+			_, err := client.SignJWT(ctx, vcClaims, nil, authenticationKID)
+
+			assert.ErrorIs(t, err, ErrKeyUsageNotAllowed)
+			assert.EqualError(t, err, "key usage not allowed: key is not registered for assertionMethod (kid=authentication)")
+		})
+		t.Run("VP with authentication key", func(t *testing.T) {
+			_, err := client.SignJWT(ctx, vpClaims, nil, authenticationKID)
+
+			assert.NoError(t, err)
+		})
+		t.Run("VP with assertionMethod key", func(t *testing.T) {
+			_, err := client.SignJWT(ctx, vpClaims, nil, assertionKID)
+
+			assert.NoError(t, err)
+		})
+		t.Run("VP with keyAgreement key is refused", func(t *testing.T) {
+			_, err := client.SignJWT(ctx, vpClaims, nil, keyAgreementKID)
+
+			assert.ErrorIs(t, err, ErrKeyUsageNotAllowed)
+		})
+		t.Run("other JWT with keyAgreement key is refused", func(t *testing.T) {
+			_, err := client.SignJWT(ctx, map[string]interface{}{"iss": "alice"}, nil, keyAgreementKID)
+
+			assert.ErrorIs(t, err, ErrKeyUsageNotAllowed)
+		})
+		t.Run("usage from context takes precedence over claims", func(t *testing.T) {
+			_, err := client.SignJWT(WithKeyUsage(ctx, orm.AuthenticationUsage), vcClaims, nil, authenticationKID)
+
+			assert.NoError(t, err)
+		})
+		t.Run("key not registered in a DID document is not restricted", func(t *testing.T) {
+			_, err := client.SignJWT(ctx, vcClaims, nil, "unregistered")
+
+			assert.NoError(t, err)
+		})
+	})
+	t.Run("SignJWS", func(t *testing.T) {
+		t.Run("ok", func(t *testing.T) {
+			_, err := client.SignJWS(ctx, []byte("payload"), map[string]interface{}{}, authenticationKID, false)
+
+			assert.NoError(t, err)
+		})
+		t.Run("keyAgreement key is refused", func(t *testing.T) {
+			_, err := client.SignJWS(ctx, []byte("payload"), map[string]interface{}{}, keyAgreementKID, false)
+
+			assert.ErrorIs(t, err, ErrKeyUsageNotAllowed)
+		})
+		t.Run("usage from context", func(t *testing.T) {
+			_, err := client.SignJWS(WithKeyUsage(ctx, orm.CapabilityInvocationUsage), []byte("payload"), map[string]interface{}{}, assertionKID, false)
+
+			assert.EqualError(t, err, "key usage not allowed: key is not registered for capabilityInvocation (kid=assertion)")
+		})
+	})
+	t.Run("SignData", func(t *testing.T) {
+		_, err := client.SignData(ctx, []byte("data"), keyAgreementKID)
+
+		assert.ErrorIs(t, err, ErrKeyUsageNotAllowed)
+	})
+	t.Run("DecryptJWE", func(t *testing.T) {
+		t.Run("assertionMethod key is refused", func(t *testing.T) {
+			publicKey, err := client.Resolve(ctx, assertionKID)
+			require.NoError(t, err)
+			message, err := client.EncryptJWE(ctx, []byte("payload"), map[string]interface{}{"kid": assertionKID}, publicKey)
+			require.NoError(t, err)
+
+			_, _, err = client.DecryptJWE(ctx, message)
+
+			assert.EqualError(t, err, "key usage not allowed: key is not registered for keyAgreement (kid=assertion)")
+		})
+		t.Run("keyAgreement key", func(t *testing.T) {
+			publicKey, err := client.Resolve(ctx, keyAgreementKID)
+			require.NoError(t, err)
+			message, err := client.EncryptJWE(ctx, []byte("payload"), map[string]interface{}{"kid": keyAgreementKID}, publicKey)
+			require.NoError(t, err)
+
+			body, _, err := client.DecryptJWE(ctx, message)
+
+			require.NoError(t, err)
+			assert.Equal(t, "payload", string(body))
+		})
+	})
+}
+
+func Test_keyUsageString(t *testing.T) {
+	assert.Equal(t, "nothing", keyUsageString(0))
+	assert.Equal(t, "keyAgreement", keyUsageString(orm.KeyAgreementUsage))
+	assert.Equal(t, "assertionMethod or authentication", keyUsageString(presentationKeyUsage))
+}
+
+// registerKeyWithUsage creates a new key and registers it as verification method with the given usage in a DID document.
+func registerKeyWithUsage(t *testing.T, client *Crypto, id string, kid string, usage orm.DIDKeyFlags) string {
+	t.Helper()
+	_, _, err := client.New(audit.TestContext(), StringNamingFunc(kid))
+	require.NoError(t, err)
+	document := orm.DidDocument{
+		ID:  id + "#1",
+		DID: orm.DID{ID: id, Subject: id},
+		VerificationMethods: []orm.VerificationMethod{
+			{
+				ID:       kid,
+				KeyTypes: orm.VerificationMethodKeyType(usage),
+				Data:     []byte("{}"),
+			},
+		},
+	}
+	require.NoError(t, client.db.Create(&document).Error)
+	return kid
+}
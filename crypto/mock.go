@@ -14,6 +14,7 @@ import (
 	crypto "crypto"
 	reflect "reflect"
 
+	jwk "github.com/lestrrat-go/jwx/v2/jwk"
 	dpop "github.com/nuts-foundation/nuts-node/crypto/dpop"
 	orm "github.com/nuts-foundation/nuts-node/storage/orm"
 	gomock "go.uber.org/mock/gomock"
@@ -151,6 +152,21 @@ func (m *MockKeyStore) EXPECT() *MockKeyStoreMockRecorder {
 	return m.recorder
 }
 
+// Backup mocks base method.
+func (m *MockKeyStore) Backup(ctx context.Context, key ArchiveKey) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Backup", ctx, key)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Backup indicates an expected call of Backup.
+func (mr *MockKeyStoreMockRecorder) Backup(ctx, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Backup", reflect.TypeOf((*MockKeyStore)(nil).Backup), ctx, key)
+}
+
 // Decrypt mocks base method.
 func (m *MockKeyStore) Decrypt(ctx context.Context, kid string, ciphertext []byte) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -211,6 +227,21 @@ func (mr *MockKeyStoreMockRecorder) EncryptJWE(ctx, payload, headers, publicKey
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EncryptJWE", reflect.TypeOf((*MockKeyStore)(nil).EncryptJWE), ctx, payload, headers, publicKey)
 }
 
+// EncryptJWEForRecipients mocks base method.
+func (m *MockKeyStore) EncryptJWEForRecipients(ctx context.Context, payload []byte, headers map[string]any, recipients []jwk.Key) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EncryptJWEForRecipients", ctx, payload, headers, recipients)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EncryptJWEForRecipients indicates an expected call of EncryptJWEForRecipients.
+func (mr *MockKeyStoreMockRecorder) EncryptJWEForRecipients(ctx, payload, headers, recipients any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EncryptJWEForRecipients", reflect.TypeOf((*MockKeyStore)(nil).EncryptJWEForRecipients), ctx, payload, headers, recipients)
+}
+
 // Exists mocks base method.
 func (m *MockKeyStore) Exists(ctx context.Context, kid string) (bool, error) {
 	m.ctrl.T.Helper()
@@ -285,6 +316,21 @@ func (mr *MockKeyStoreMockRecorder) Resolve(ctx, kid any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockKeyStore)(nil).Resolve), ctx, kid)
 }
 
+// Restore mocks base method.
+func (m *MockKeyStore) Restore(ctx context.Context, archive []byte, key ArchiveKey) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, archive, key)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockKeyStoreMockRecorder) Restore(ctx, archive, key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockKeyStore)(nil).Restore), ctx, archive, key)
+}
+
 // SignDPoP mocks base method.
 func (m *MockKeyStore) SignDPoP(ctx context.Context, token dpop.DPoP, kid string) (string, error) {
 	m.ctrl.T.Helper()
@@ -300,6 +346,21 @@ func (mr *MockKeyStoreMockRecorder) SignDPoP(ctx, token, kid any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignDPoP", reflect.TypeOf((*MockKeyStore)(nil).SignDPoP), ctx, token, kid)
 }
 
+// SignData mocks base method.
+func (m *MockKeyStore) SignData(ctx context.Context, data []byte, kid string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignData", ctx, data, kid)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignData indicates an expected call of SignData.
+func (mr *MockKeyStoreMockRecorder) SignData(ctx, data, kid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignData", reflect.TypeOf((*MockKeyStore)(nil).SignData), ctx, data, kid)
+}
+
 // SignJWS mocks base method.
 func (m *MockKeyStore) SignJWS(ctx context.Context, payload []byte, headers map[string]any, kid string, detached bool) (string, error) {
 	m.ctrl.T.Helper()
@@ -438,6 +499,45 @@ func (mr *MockJWTSignerMockRecorder) SignJWT(ctx, claims, headers, kid any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignJWT", reflect.TypeOf((*MockJWTSigner)(nil).SignJWT), ctx, claims, headers, kid)
 }
 
+// MockDataSigner is a mock of DataSigner interface.
+type MockDataSigner struct {
+	ctrl     *gomock.Controller
+	recorder *MockDataSignerMockRecorder
+	isgomock struct{}
+}
+
+// MockDataSignerMockRecorder is the mock recorder for MockDataSigner.
+type MockDataSignerMockRecorder struct {
+	mock *MockDataSigner
+}
+
+// NewMockDataSigner creates a new mock instance.
+func NewMockDataSigner(ctrl *gomock.Controller) *MockDataSigner {
+	mock := &MockDataSigner{ctrl: ctrl}
+	mock.recorder = &MockDataSignerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDataSigner) EXPECT() *MockDataSignerMockRecorder {
+	return m.recorder
+}
+
+// SignData mocks base method.
+func (m *MockDataSigner) SignData(ctx context.Context, data []byte, kid string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignData", ctx, data, kid)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignData indicates an expected call of SignData.
+func (mr *MockDataSignerMockRecorder) SignData(ctx, data, kid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignData", reflect.TypeOf((*MockDataSigner)(nil).SignData), ctx, data, kid)
+}
+
 // MockJsonWebEncryptor is a mock of JsonWebEncryptor interface.
 type MockJsonWebEncryptor struct {
 	ctrl     *gomock.Controller
@@ -492,3 +592,18 @@ func (mr *MockJsonWebEncryptorMockRecorder) EncryptJWE(ctx, payload, headers, pu
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EncryptJWE", reflect.TypeOf((*MockJsonWebEncryptor)(nil).EncryptJWE), ctx, payload, headers, publicKey)
 }
+
+// EncryptJWEForRecipients mocks base method.
+func (m *MockJsonWebEncryptor) EncryptJWEForRecipients(ctx context.Context, payload []byte, headers map[string]any, recipients []jwk.Key) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EncryptJWEForRecipients", ctx, payload, headers, recipients)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EncryptJWEForRecipients indicates an expected call of EncryptJWEForRecipients.
+func (mr *MockJsonWebEncryptorMockRecorder) EncryptJWEForRecipients(ctx, payload, headers, recipients any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EncryptJWEForRecipients", reflect.TypeOf((*MockJsonWebEncryptor)(nil).EncryptJWEForRecipients), ctx, payload, headers, recipients)
+}
@@ -21,6 +21,8 @@ package azure
 import (
 	"context"
 	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -180,9 +182,34 @@ func (a Keyvault) getPrivateKey(ctx context.Context, keyName string, version str
 	return &response, nil
 }
 
-func (a Keyvault) SavePrivateKey(ctx context.Context, kid string, key crypto.PrivateKey) error {
-	// Only used for migrating to a new storage backend, which is not implemented yet for Azure Key Vault
-	return errors.New("SavePrivateKey() is not supported for Azure Key Vault")
+// SavePrivateKey imports the given private key into Azure Key Vault, e.g. when restoring a key backup.
+// The imported key is marked as non-exportable.
+func (a Keyvault) SavePrivateKey(ctx context.Context, keyName string, key crypto.PrivateKey) error {
+	ecKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok || ecKey.Curve != elliptic.P256() {
+		return errors.New("only ES256 keys are supported")
+	}
+	keyAsJWK, err := jwk.FromRaw(ecKey)
+	if err != nil {
+		return fmt.Errorf("unable to convert private key to JWK: %w", err)
+	}
+	jwkData, _ := json.Marshal(keyAsJWK)
+	var azureKey azkeys.JSONWebKey
+	if err = json.Unmarshal(jwkData, &azureKey); err != nil {
+		return fmt.Errorf("unable to convert private key to Azure Key Vault key: %w", err)
+	}
+	_, err = a.client.ImportKey(ctx, keyName, azkeys.ImportKeyParameters{
+		Key: &azureKey,
+		HSM: to.Ptr(a.useHSM),
+		KeyAttributes: &azkeys.KeyAttributes{
+			Enabled:    to.Ptr(true),
+			Exportable: to.Ptr(false),
+		},
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("unable to import key into Azure Key Vault (name=%s): %w", keyName, err)
+	}
+	return nil
 }
 
 func (a Keyvault) ListPrivateKeys(ctx context.Context) []spi.KeyNameVersion {
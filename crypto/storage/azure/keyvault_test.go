@@ -21,6 +21,7 @@ package azure
 import (
 	"context"
 	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/sha256"
@@ -206,6 +207,48 @@ func Test_Keyvault_DeletePrivateKey(t *testing.T) {
 	})
 }
 
+func Test_Keyvault_SavePrivateKey(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		vaultClient := NewMockkeyVaultClient(ctrl)
+		privateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		capturedParams := azkeys.ImportKeyParameters{}
+		vaultClient.EXPECT().ImportKey(gomock.Any(), "did-web-example-com-0", gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, parameters azkeys.ImportKeyParameters, _ *azkeys.ImportKeyOptions) (azkeys.ImportKeyResponse, error) {
+				capturedParams = parameters
+				return azkeys.ImportKeyResponse{}, nil
+			})
+
+		store := Keyvault{client: vaultClient, useHSM: true}
+		err := store.SavePrivateKey(context.Background(), "did-web-example-com-0", privateKey)
+
+		require.NoError(t, err)
+		assert.Equal(t, azkeys.KeyTypeEC, *capturedParams.Key.Kty)
+		assert.Equal(t, azkeys.CurveNameP256, *capturedParams.Key.Crv)
+		assert.Equal(t, privateKey.D.FillBytes(make([]byte, 32)), capturedParams.Key.D)
+		assert.True(t, *capturedParams.HSM)
+		assert.False(t, *capturedParams.KeyAttributes.Exportable)
+	})
+	t.Run("unsupported key type", func(t *testing.T) {
+		privateKey, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+
+		err := Keyvault{}.SavePrivateKey(context.Background(), "did-web-example-com-0", privateKey)
+
+		assert.EqualError(t, err, "only ES256 keys are supported")
+	})
+	t.Run("error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		vaultClient := NewMockkeyVaultClient(ctrl)
+		privateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		vaultClient.EXPECT().ImportKey(gomock.Any(), "did-web-example-com-0", gomock.Any(), gomock.Any()).
+			Return(azkeys.ImportKeyResponse{}, errors.New("error"))
+
+		err := Keyvault{client: vaultClient}.SavePrivateKey(context.Background(), "did-web-example-com-0", privateKey)
+
+		assert.EqualError(t, err, "unable to import key into Azure Key Vault (name=did-web-example-com-0): error")
+	})
+}
+
 func Test_azureSigningKey_Sign(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
 		// These constants are used to verify the ASN.1 marshalling of the signature (raw r|s to ASN.1)
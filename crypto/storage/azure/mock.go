@@ -87,6 +87,21 @@ func (mr *MockkeyVaultClientMockRecorder) GetKey(ctx, name, version, options any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKey", reflect.TypeOf((*MockkeyVaultClient)(nil).GetKey), ctx, name, version, options)
 }
 
+// ImportKey mocks base method.
+func (m *MockkeyVaultClient) ImportKey(ctx context.Context, name string, parameters azkeys.ImportKeyParameters, options *azkeys.ImportKeyOptions) (azkeys.ImportKeyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportKey", ctx, name, parameters, options)
+	ret0, _ := ret[0].(azkeys.ImportKeyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportKey indicates an expected call of ImportKey.
+func (mr *MockkeyVaultClientMockRecorder) ImportKey(ctx, name, parameters, options any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportKey", reflect.TypeOf((*MockkeyVaultClient)(nil).ImportKey), ctx, name, parameters, options)
+}
+
 // NewListKeyPropertiesPager mocks base method.
 func (m *MockkeyVaultClient) NewListKeyPropertiesPager(options *azkeys.ListKeyPropertiesOptions) *runtime.Pager[azkeys.ListKeyPropertiesResponse] {
 	m.ctrl.T.Helper()
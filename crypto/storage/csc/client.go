@@ -0,0 +1,206 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package csc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/nuts-foundation/nuts-node/core"
+)
+
+const (
+	// hashAlgorithmSHA256 is the OID of the SHA-256 hash algorithm.
+	hashAlgorithmSHA256 = "2.16.840.1.101.3.4.2.1"
+	// signAlgorithmECDSAWithSHA256 is the OID of the ecdsa-with-SHA256 signature algorithm.
+	signAlgorithmECDSAWithSHA256 = "1.2.840.10045.4.3.2"
+)
+
+const (
+	// authModeImplicit indicates the credential is authorized by the trust service provider without user interaction.
+	authModeImplicit = "implicit"
+	// authModeOAuth2Code indicates the credential is authorized using an OAuth2 access token with scope "credential".
+	authModeOAuth2Code = "oauth2code"
+)
+
+// apiError is returned when the CSC API responds with an error.
+type apiError struct {
+	StatusCode  int    `json:"-"`
+	Code        string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+func (e apiError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("CSC API returned HTTP %d", e.StatusCode)
+	}
+	return fmt.Sprintf("CSC API returned HTTP %d: %s (%s)", e.StatusCode, e.Code, e.Description)
+}
+
+// invalidCredentialID returns true if the error indicates the credential doesn't exist.
+func (e apiError) invalidCredentialID() bool {
+	return e.StatusCode == http.StatusNotFound ||
+		(e.Code == "invalid_request" && strings.Contains(e.Description, "credentialID"))
+}
+
+type credentialsListRequest struct {
+	UserID string `json:"userID,omitempty"`
+}
+
+type credentialsListResponse struct {
+	CredentialIDs []string `json:"credentialIDs"`
+}
+
+type credentialsInfoRequest struct {
+	CredentialID string `json:"credentialID"`
+	Certificates string `json:"certificates"`
+	AuthInfo     bool   `json:"authInfo"`
+}
+
+type credentialsInfoResponse struct {
+	Key struct {
+		Status string `json:"status"`
+	} `json:"key"`
+	Cert struct {
+		Certificates []string `json:"certificates"`
+	} `json:"cert"`
+	AuthMode string `json:"authMode"`
+}
+
+type credentialsAuthorizeRequest struct {
+	CredentialID     string   `json:"credentialID"`
+	NumSignatures    int      `json:"numSignatures"`
+	Hashes           []string `json:"hashes"`
+	HashAlgorithmOID string   `json:"hashAlgorithmOID"`
+}
+
+type credentialsAuthorizeResponse struct {
+	SAD string `json:"SAD"`
+}
+
+type signHashRequest struct {
+	CredentialID     string   `json:"credentialID"`
+	SAD              string   `json:"SAD,omitempty"`
+	Hashes           []string `json:"hashes"`
+	HashAlgorithmOID string   `json:"hashAlgorithmOID"`
+	SignAlgo         string   `json:"signAlgo"`
+}
+
+type signHashResponse struct {
+	Signatures []string `json:"signatures"`
+}
+
+// apiClient performs the calls to the CSC API (v2) of a remote trust service provider.
+type apiClient struct {
+	address     string
+	accessToken string
+	httpClient  core.HTTPRequestDoer
+}
+
+func (c apiClient) info(ctx context.Context) error {
+	return c.call(ctx, "info", struct{}{}, &map[string]interface{}{})
+}
+
+func (c apiClient) listCredentials(ctx context.Context, userID string) ([]string, error) {
+	var response credentialsListResponse
+	if err := c.call(ctx, "credentials/list", credentialsListRequest{UserID: userID}, &response); err != nil {
+		return nil, err
+	}
+	return response.CredentialIDs, nil
+}
+
+func (c apiClient) credentialInfo(ctx context.Context, credentialID string) (*credentialsInfoResponse, error) {
+	var response credentialsInfoResponse
+	err := c.call(ctx, "credentials/info", credentialsInfoRequest{
+		CredentialID: credentialID,
+		Certificates: "single",
+		AuthInfo:     true,
+	}, &response)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func (c apiClient) authorize(ctx context.Context, credentialID string, hashes []string) (string, error) {
+	var response credentialsAuthorizeResponse
+	err := c.call(ctx, "credentials/authorize", credentialsAuthorizeRequest{
+		CredentialID:     credentialID,
+		NumSignatures:    len(hashes),
+		Hashes:           hashes,
+		HashAlgorithmOID: hashAlgorithmSHA256,
+	}, &response)
+	if err != nil {
+		return "", err
+	}
+	return response.SAD, nil
+}
+
+func (c apiClient) signHash(ctx context.Context, credentialID string, sad string, hashes []string) ([]string, error) {
+	var response signHashResponse
+	err := c.call(ctx, "signatures/signHash", signHashRequest{
+		CredentialID:     credentialID,
+		SAD:              sad,
+		Hashes:           hashes,
+		HashAlgorithmOID: hashAlgorithmSHA256,
+		SignAlgo:         signAlgorithmECDSAWithSHA256,
+	}, &response)
+	if err != nil {
+		return nil, err
+	}
+	return response.Signatures, nil
+}
+
+// call invokes the given CSC API method. CSC API methods are all invoked using POST with a JSON request body.
+// If the API responds with an error, it is returned as apiError.
+func (c apiClient) call(ctx context.Context, method string, request interface{}, response interface{}) error {
+	requestBody, _ := json.Marshal(request)
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(c.address, "/")+"/"+method, bytes.NewReader(requestBody))
+	if err != nil {
+		return err
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpRequest.Header.Set("Accept", "application/json")
+	if c.accessToken != "" {
+		httpRequest.Header.Set("Authorization", "Bearer "+c.accessToken)
+	}
+	httpResponse, err := c.httpClient.Do(httpRequest)
+	if err != nil {
+		return err
+	}
+	defer httpResponse.Body.Close()
+	responseBody, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return err
+	}
+	if httpResponse.StatusCode != http.StatusOK {
+		result := apiError{StatusCode: httpResponse.StatusCode}
+		_ = json.Unmarshal(responseBody, &result)
+		return result
+	}
+	if err = json.Unmarshal(responseBody, response); err != nil {
+		return fmt.Errorf("invalid %s response: %w", method, err)
+	}
+	return nil
+}
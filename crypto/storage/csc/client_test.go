@@ -0,0 +1,78 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package csc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIClient_call(t *testing.T) {
+	ctx := context.Background()
+	t.Run("ok", func(t *testing.T) {
+		var capturedRequest *http.Request
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			capturedRequest = request
+			writeJSON(writer, http.StatusOK, map[string]interface{}{"credentialIDs": []string{"seal"}})
+		}))
+		defer server.Close()
+		client := apiClient{address: server.URL + "/csc/v2/", accessToken: "token", httpClient: http.DefaultClient}
+
+		credentialIDs, err := client.listCredentials(ctx, "user")
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"seal"}, credentialIDs)
+		assert.Equal(t, "/csc/v2/credentials/list", capturedRequest.URL.Path)
+		assert.Equal(t, "Bearer token", capturedRequest.Header.Get("Authorization"))
+		assert.Equal(t, "application/json", capturedRequest.Header.Get("Content-Type"))
+	})
+	t.Run("error without CSC error body", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+			writer.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+		client := apiClient{address: server.URL, httpClient: http.DefaultClient}
+
+		_, err := client.listCredentials(ctx, "")
+
+		assert.EqualError(t, err, "CSC API returned HTTP 500")
+	})
+	t.Run("invalid response", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+			_, _ = writer.Write([]byte("not JSON"))
+		}))
+		defer server.Close()
+		client := apiClient{address: server.URL, httpClient: http.DefaultClient}
+
+		_, err := client.listCredentials(ctx, "")
+
+		assert.ErrorContains(t, err, "invalid credentials/list response")
+	})
+}
+
+func TestAPIError_invalidCredentialID(t *testing.T) {
+	assert.True(t, apiError{StatusCode: http.StatusNotFound}.invalidCredentialID())
+	assert.True(t, apiError{StatusCode: http.StatusBadRequest, Code: "invalid_request", Description: "Invalid parameter credentialID"}.invalidCredentialID())
+	assert.False(t, apiError{StatusCode: http.StatusBadRequest, Code: "invalid_request", Description: "Invalid parameter SAD"}.invalidCredentialID())
+}
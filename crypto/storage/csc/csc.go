@@ -0,0 +1,263 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package csc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/url"
+
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/crypto/log"
+	"github.com/nuts-foundation/nuts-node/crypto/storage/spi"
+	"github.com/nuts-foundation/nuts-node/http/client"
+)
+
+// StorageType is the name of this storage type, used in health check reports and configuration.
+const StorageType = "csc"
+
+// keyVersion is the version reported for CSC credentials, since the CSC API doesn't support key versioning.
+const keyVersion = "1"
+
+var _ spi.Storage = (*Storage)(nil)
+var _ spi.KeyClaimer = (*Storage)(nil)
+
+// New creates a new storage backend that delegates signing to a remote trust service provider through the Cloud Signature Consortium (CSC) API v2.
+func New(config Config) (*Storage, error) {
+	if config.Address == "" {
+		return nil, errors.New("missing CSC API address")
+	}
+	if _, err := url.ParseRequestURI(config.Address); err != nil {
+		return nil, fmt.Errorf("invalid CSC API address: %w", err)
+	}
+	return &Storage{
+		client: apiClient{
+			address:     config.Address,
+			accessToken: config.AccessToken,
+			httpClient:  client.New(config.Timeout),
+		},
+		userID: config.UserID,
+	}, nil
+}
+
+// Storage is a spi.Storage implementation for keys held by a (qualified) trust service provider, accessed through the CSC API.
+// Keys are identified by their CSC credential ID. Credentials (and their keys) are provisioned by the trust service provider,
+// since the CSC API doesn't support creating them. New keys are claimed from the provisioned credentials instead (see ClaimPrivateKey).
+// The private keys never leave the trust service provider: signing is performed remotely.
+type Storage struct {
+	client apiClient
+	userID string
+}
+
+func (s Storage) Name() string {
+	return StorageType
+}
+
+func (s Storage) CheckHealth() map[string]core.Health {
+	results := make(map[string]core.Health)
+	if err := s.client.info(context.Background()); err != nil {
+		results[StorageType] = core.Health{Status: core.HealthStatusDown, Details: fmt.Errorf("unable to connect to CSC API: %w", err).Error()}
+	} else {
+		results[StorageType] = core.Health{Status: core.HealthStatusUp}
+	}
+	return results
+}
+
+func (s Storage) NewPrivateKey(_ context.Context, _ string) (crypto.PublicKey, string, error) {
+	// The CSC API doesn't support creating credentials, they're provisioned by the trust service provider.
+	// New keys are claimed using ClaimPrivateKey instead.
+	return nil, "", errors.New("NewPrivateKey() is not supported for CSC, credentials must be provisioned by the trust service provider")
+}
+
+// ClaimPrivateKey claims the first credential listed by the CSC API that hasn't been claimed yet and can be used for signing.
+// Credentials that can't be used (e.g. disabled, or with an unsupported key type or authMode) are skipped without claiming them,
+// so they can still be claimed once they become usable.
+func (s Storage) ClaimPrivateKey(ctx context.Context, claim func(keyName string) (bool, error)) (string, crypto.PublicKey, string, error) {
+	credentialIDs, err := s.client.listCredentials(ctx, s.userID)
+	if err != nil {
+		return "", nil, "", fmt.Errorf("unable to list credentials from CSC API: %w", err)
+	}
+	for _, credentialID := range credentialIDs {
+		signer, err := s.GetPrivateKey(ctx, credentialID, keyVersion)
+		if err != nil {
+			log.Logger().WithError(err).Warnf("Skipping unusable CSC credential (name=%s)", credentialID)
+			continue
+		}
+		claimed, err := claim(credentialID)
+		if err != nil {
+			return "", nil, "", err
+		}
+		if !claimed {
+			continue
+		}
+		return credentialID, signer.Public(), keyVersion, nil
+	}
+	return "", nil, "", errors.New("no unclaimed CSC credentials left, they must be provisioned by the trust service provider")
+}
+
+func (s Storage) GetPrivateKey(ctx context.Context, keyName string, _ string) (crypto.Signer, error) {
+	info, err := s.client.credentialInfo(ctx, keyName)
+	var cscErr apiError
+	if errors.As(err, &cscErr) && cscErr.invalidCredentialID() {
+		return nil, spi.ErrNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to get credential from CSC API (name=%s): %w", keyName, err)
+	}
+	if info.Key.Status != "enabled" {
+		return nil, fmt.Errorf("CSC credential key is not enabled (name=%s, status=%s)", keyName, info.Key.Status)
+	}
+	if info.AuthMode != authModeImplicit && info.AuthMode != authModeOAuth2Code {
+		return nil, fmt.Errorf("unsupported CSC credential authMode (name=%s, authMode=%s)", keyName, info.AuthMode)
+	}
+	publicKey, err := parsePublicKey(info.Cert.Certificates)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSC credential certificate (name=%s): %w", keyName, err)
+	}
+	return &cscSigningKey{
+		client:       s.client,
+		credentialID: keyName,
+		authMode:     info.AuthMode,
+		publicKey:    publicKey,
+	}, nil
+}
+
+func (s Storage) PrivateKeyExists(ctx context.Context, keyName string, _ string) (bool, error) {
+	credentialIDs, err := s.client.listCredentials(ctx, s.userID)
+	if err != nil {
+		return false, fmt.Errorf("unable to list credentials from CSC API: %w", err)
+	}
+	for _, credentialID := range credentialIDs {
+		if credentialID == keyName {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s Storage) SavePrivateKey(_ context.Context, _ string, _ crypto.PrivateKey) error {
+	// Keys are held by the trust service provider and can't be imported through the CSC API.
+	return errors.New("SavePrivateKey() is not supported for CSC")
+}
+
+func (s Storage) ListPrivateKeys(ctx context.Context) []spi.KeyNameVersion {
+	credentialIDs, err := s.client.listCredentials(ctx, s.userID)
+	if err != nil {
+		log.Logger().WithError(err).Error("unable to list credentials from CSC API")
+		return nil
+	}
+	result := make([]spi.KeyNameVersion, 0, len(credentialIDs))
+	for _, credentialID := range credentialIDs {
+		result = append(result, spi.KeyNameVersion{KeyName: credentialID, Version: keyVersion})
+	}
+	return result
+}
+
+func (s Storage) DeletePrivateKey(_ context.Context, _ string) error {
+	// Credentials are managed by the trust service provider and can't be deleted through the CSC API.
+	return fmt.Errorf("DeletePrivateKey() is not supported for CSC: %w", spi.ErrDeleteNotSupported)
+}
+
+// parsePublicKey parses the public key from the (base64 encoded, DER) end-entity certificate of a CSC credential.
+func parsePublicKey(certificates []string) (crypto.PublicKey, error) {
+	if len(certificates) == 0 {
+		return nil, errors.New("missing certificate")
+	}
+	certificateDER, err := base64.StdEncoding.DecodeString(certificates[0])
+	if err != nil {
+		return nil, err
+	}
+	certificate, err := x509.ParseCertificate(certificateDER)
+	if err != nil {
+		return nil, err
+	}
+	publicKey, ok := certificate.PublicKey.(*ecdsa.PublicKey)
+	if !ok || publicKey.Curve != elliptic.P256() {
+		return nil, errors.New("only ES256 keys are supported")
+	}
+	return publicKey, nil
+}
+
+var _ crypto.Signer = &cscSigningKey{}
+
+// cscSigningKey is a crypto.Signer that signs using a remote CSC credential.
+type cscSigningKey struct {
+	client       apiClient
+	credentialID string
+	authMode     string
+	publicKey    crypto.PublicKey
+}
+
+func (c cscSigningKey) Public() crypto.PublicKey {
+	return c.publicKey
+}
+
+func (c cscSigningKey) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	if opts == nil || opts.HashFunc() != crypto.SHA256 {
+		return nil, errors.New("only SHA-256 digests are supported")
+	}
+	ctx := context.Background()
+	hashes := []string{base64.StdEncoding.EncodeToString(digest)}
+	// Implicitly authorized credentials require Signature Activation Data (SAD), which is obtained without user interaction.
+	// For oauth2code credentials, the access token authorizes the credential.
+	var sad string
+	if c.authMode == authModeImplicit {
+		var err error
+		if sad, err = c.client.authorize(ctx, c.credentialID, hashes); err != nil {
+			return nil, fmt.Errorf("unable to authorize CSC credential: %w", err)
+		}
+	}
+	signatures, err := c.client.signHash(ctx, c.credentialID, sad, hashes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign with CSC API: %w", err)
+	}
+	if len(signatures) != 1 {
+		return nil, fmt.Errorf("unable to sign with CSC API: expected 1 signature, got %d", len(signatures))
+	}
+	signature, err := base64.StdEncoding.DecodeString(signatures[0])
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign with CSC API: invalid signature: %w", err)
+	}
+	return toASN1Signature(signature)
+}
+
+// toASN1Signature returns the ECDSA signature ASN.1 (DER) encoded, as required by crypto.Signer.
+// Trust service providers return either an ASN.1-encoded signature, or the raw r and s components concatenated.
+func toASN1Signature(signature []byte) ([]byte, error) {
+	var parsed struct {
+		R, S *big.Int
+	}
+	if rest, err := asn1.Unmarshal(signature, &parsed); err == nil && len(rest) == 0 {
+		return signature, nil
+	}
+	if len(signature) != 64 {
+		return nil, errors.New("unable to sign with CSC API: invalid ECDSA signature")
+	}
+	parsed.R = new(big.Int).SetBytes(signature[:32])
+	parsed.S = new(big.Int).SetBytes(signature[32:])
+	return asn1.Marshal(parsed)
+}
@@ -0,0 +1,466 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package csc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/crypto/storage/spi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAccessToken = "access-token"
+
+// testCredential is a credential held by the stand-in trust service provider.
+type testCredential struct {
+	key         crypto.Signer
+	certificate []byte
+	authMode    string
+	status      string
+}
+
+// testServer is a stand-in for the CSC API of a trust service provider.
+type testServer struct {
+	*httptest.Server
+	credentials map[string]*testCredential
+	// rawSignatures makes the server return ECDSA signatures as concatenated r and s, instead of ASN.1.
+	rawSignatures bool
+	// requests records the invoked CSC API methods
+	requests []string
+}
+
+func newTestServer(t *testing.T) *testServer {
+	result := &testServer{credentials: map[string]*testCredential{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /info", func(writer http.ResponseWriter, _ *http.Request) {
+		writeJSON(writer, http.StatusOK, map[string]interface{}{"specs": "2.0.0.2", "name": "stand-in"})
+	})
+	mux.HandleFunc("POST /credentials/list", result.authenticated(func(writer http.ResponseWriter, _ map[string]interface{}) {
+		credentialIDs := make([]string, 0)
+		for credentialID := range result.credentials {
+			credentialIDs = append(credentialIDs, credentialID)
+		}
+		writeJSON(writer, http.StatusOK, map[string]interface{}{"credentialIDs": credentialIDs})
+	}))
+	mux.HandleFunc("POST /credentials/info", result.authenticated(result.withCredential(func(writer http.ResponseWriter, credential *testCredential, _ map[string]interface{}) {
+		writeJSON(writer, http.StatusOK, map[string]interface{}{
+			"key":      map[string]interface{}{"status": credential.status, "algo": []string{"1.2.840.10045.2.1"}},
+			"cert":     map[string]interface{}{"status": "valid", "certificates": []string{base64.StdEncoding.EncodeToString(credential.certificate)}},
+			"authMode": credential.authMode,
+		})
+	})))
+	mux.HandleFunc("POST /credentials/authorize", result.authenticated(result.withCredential(func(writer http.ResponseWriter, credential *testCredential, _ map[string]interface{}) {
+		writeJSON(writer, http.StatusOK, map[string]interface{}{"SAD": "sad"})
+	})))
+	mux.HandleFunc("POST /signatures/signHash", result.authenticated(result.withCredential(func(writer http.ResponseWriter, credential *testCredential, request map[string]interface{}) {
+		if credential.authMode == authModeImplicit && request["SAD"] != "sad" {
+			writeJSON(writer, http.StatusBadRequest, apiError{Code: "invalid_request", Description: "Invalid parameter SAD"})
+			return
+		}
+		if request["hashAlgorithmOID"] != hashAlgorithmSHA256 || request["signAlgo"] != signAlgorithmECDSAWithSHA256 {
+			writeJSON(writer, http.StatusBadRequest, apiError{Code: "invalid_request", Description: "Invalid parameter signAlgo"})
+			return
+		}
+		signatures := make([]string, 0)
+		for _, hash := range request["hashes"].([]interface{}) {
+			digest, _ := base64.StdEncoding.DecodeString(hash.(string))
+			signature, _ := credential.key.Sign(rand.Reader, digest, crypto.SHA256)
+			if result.rawSignatures {
+				r, s, _ := ecdsa.Sign(rand.Reader, credential.key.(*ecdsa.PrivateKey), digest)
+				signature = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+			}
+			signatures = append(signatures, base64.StdEncoding.EncodeToString(signature))
+		}
+		writeJSON(writer, http.StatusOK, map[string]interface{}{"signatures": signatures})
+	})))
+	result.Server = httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		result.requests = append(result.requests, request.URL.Path)
+		mux.ServeHTTP(writer, request)
+	}))
+	t.Cleanup(result.Close)
+	return result
+}
+
+// addCredential provisions a new credential with a P-256 key at the stand-in trust service provider.
+func (s *testServer) addCredential(t *testing.T, credentialID string, authMode string) *testCredential {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	credential := &testCredential{key: key, certificate: selfSignedCertificate(t, key), authMode: authMode, status: "enabled"}
+	s.credentials[credentialID] = credential
+	return credential
+}
+
+func (s *testServer) authenticated(handler func(writer http.ResponseWriter, request map[string]interface{})) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		if request.Header.Get("Authorization") != "Bearer "+testAccessToken {
+			writeJSON(writer, http.StatusUnauthorized, apiError{Code: "invalid_token", Description: "Invalid access token"})
+			return
+		}
+		var body map[string]interface{}
+		if err := json.NewDecoder(request.Body).Decode(&body); err != nil {
+			writeJSON(writer, http.StatusBadRequest, apiError{Code: "invalid_request", Description: "Malformed request"})
+			return
+		}
+		handler(writer, body)
+	}
+}
+
+func (s *testServer) withCredential(handler func(writer http.ResponseWriter, credential *testCredential, request map[string]interface{})) func(http.ResponseWriter, map[string]interface{}) {
+	return func(writer http.ResponseWriter, request map[string]interface{}) {
+		credentialID, _ := request["credentialID"].(string)
+		credential, ok := s.credentials[credentialID]
+		if !ok {
+			writeJSON(writer, http.StatusBadRequest, apiError{Code: "invalid_request", Description: "Invalid parameter credentialID"})
+			return
+		}
+		handler(writer, credential, request)
+	}
+}
+
+func writeJSON(writer http.ResponseWriter, status int, body interface{}) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(status)
+	_ = json.NewEncoder(writer).Encode(body)
+}
+
+func selfSignedCertificate(t *testing.T, key crypto.Signer) []byte {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "Organization Seal"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+	return certificate
+}
+
+func newTestStorage(t *testing.T, server *testServer) *Storage {
+	storage, err := New(Config{Address: server.URL, AccessToken: testAccessToken, Timeout: time.Second})
+	require.NoError(t, err)
+	return storage
+}
+
+func TestNew(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		storage, err := New(Config{Address: "https://qtsp.example.com/csc/v2"})
+
+		require.NoError(t, err)
+		assert.Equal(t, StorageType, storage.Name())
+	})
+	t.Run("missing address", func(t *testing.T) {
+		_, err := New(Config{})
+
+		assert.EqualError(t, err, "missing CSC API address")
+	})
+	t.Run("invalid address", func(t *testing.T) {
+		_, err := New(Config{Address: "not a URL"})
+
+		assert.ErrorContains(t, err, "invalid CSC API address")
+	})
+}
+
+func TestStorage_CheckHealth(t *testing.T) {
+	t.Run("up", func(t *testing.T) {
+		storage := newTestStorage(t, newTestServer(t))
+
+		result := storage.CheckHealth()
+
+		assert.Equal(t, core.HealthStatusUp, result[StorageType].Status)
+	})
+	t.Run("down", func(t *testing.T) {
+		server := newTestServer(t)
+		storage := newTestStorage(t, server)
+		server.Close()
+
+		result := storage.CheckHealth()
+
+		assert.Equal(t, core.HealthStatusDown, result[StorageType].Status)
+		assert.Contains(t, result[StorageType].Details, "unable to connect to CSC API")
+	})
+}
+
+func TestStorage_GetPrivateKey(t *testing.T) {
+	ctx := context.Background()
+	t.Run("implicit authMode", func(t *testing.T) {
+		server := newTestServer(t)
+		credential := server.addCredential(t, "seal", authModeImplicit)
+		storage := newTestStorage(t, server)
+
+		signer, err := storage.GetPrivateKey(ctx, "seal", keyVersion)
+
+		require.NoError(t, err)
+		assert.Equal(t, credential.key.Public(), signer.Public())
+		digest := sha256.Sum256([]byte("hello"))
+		signature, err := signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+		require.NoError(t, err)
+		assert.True(t, ecdsa.VerifyASN1(credential.key.Public().(*ecdsa.PublicKey), digest[:], signature))
+		assert.Equal(t, []string{"/credentials/info", "/credentials/authorize", "/signatures/signHash"}, server.requests)
+	})
+	t.Run("oauth2code authMode does not authorize credential", func(t *testing.T) {
+		server := newTestServer(t)
+		credential := server.addCredential(t, "seal", authModeOAuth2Code)
+		storage := newTestStorage(t, server)
+
+		signer, err := storage.GetPrivateKey(ctx, "seal", keyVersion)
+		require.NoError(t, err)
+		digest := sha256.Sum256([]byte("hello"))
+		signature, err := signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+
+		require.NoError(t, err)
+		assert.True(t, ecdsa.VerifyASN1(credential.key.Public().(*ecdsa.PublicKey), digest[:], signature))
+		assert.Equal(t, []string{"/credentials/info", "/signatures/signHash"}, server.requests)
+	})
+	t.Run("raw r||s signature is converted to ASN.1", func(t *testing.T) {
+		server := newTestServer(t)
+		server.rawSignatures = true
+		credential := server.addCredential(t, "seal", authModeImplicit)
+		storage := newTestStorage(t, server)
+
+		signer, err := storage.GetPrivateKey(ctx, "seal", keyVersion)
+		require.NoError(t, err)
+		digest := sha256.Sum256([]byte("hello"))
+		signature, err := signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+
+		require.NoError(t, err)
+		assert.True(t, ecdsa.VerifyASN1(credential.key.Public().(*ecdsa.PublicKey), digest[:], signature))
+	})
+	t.Run("can be used to sign a JWS", func(t *testing.T) {
+		server := newTestServer(t)
+		credential := server.addCredential(t, "seal", authModeImplicit)
+		storage := newTestStorage(t, server)
+		signer, err := storage.GetPrivateKey(ctx, "seal", keyVersion)
+		require.NoError(t, err)
+
+		signed, err := jws.Sign([]byte("payload"), jws.WithKey(jwa.ES256, signer))
+		require.NoError(t, err)
+
+		payload, err := jws.Verify(signed, jws.WithKey(jwa.ES256, credential.key.Public()))
+		require.NoError(t, err)
+		assert.Equal(t, "payload", string(payload))
+	})
+	t.Run("unknown credential", func(t *testing.T) {
+		storage := newTestStorage(t, newTestServer(t))
+
+		_, err := storage.GetPrivateKey(ctx, "unknown", keyVersion)
+
+		assert.ErrorIs(t, err, spi.ErrNotFound)
+	})
+	t.Run("unauthorized", func(t *testing.T) {
+		server := newTestServer(t)
+		server.addCredential(t, "seal", authModeImplicit)
+		storage, _ := New(Config{Address: server.URL, AccessToken: "invalid"})
+
+		_, err := storage.GetPrivateKey(ctx, "seal", keyVersion)
+
+		assert.EqualError(t, err, "unable to get credential from CSC API (name=seal): CSC API returned HTTP 401: invalid_token (Invalid access token)")
+	})
+	t.Run("key disabled", func(t *testing.T) {
+		server := newTestServer(t)
+		server.addCredential(t, "seal", authModeImplicit).status = "disabled"
+		storage := newTestStorage(t, server)
+
+		_, err := storage.GetPrivateKey(ctx, "seal", keyVersion)
+
+		assert.EqualError(t, err, "CSC credential key is not enabled (name=seal, status=disabled)")
+	})
+	t.Run("explicit authMode is not supported", func(t *testing.T) {
+		server := newTestServer(t)
+		server.addCredential(t, "seal", "explicit")
+		storage := newTestStorage(t, server)
+
+		_, err := storage.GetPrivateKey(ctx, "seal", keyVersion)
+
+		assert.EqualError(t, err, "unsupported CSC credential authMode (name=seal, authMode=explicit)")
+	})
+	t.Run("RSA key is not supported", func(t *testing.T) {
+		server := newTestServer(t)
+		credential := server.addCredential(t, "seal", authModeImplicit)
+		rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+		credential.certificate = selfSignedCertificate(t, rsaKey)
+		storage := newTestStorage(t, server)
+
+		_, err := storage.GetPrivateKey(ctx, "seal", keyVersion)
+
+		assert.EqualError(t, err, "invalid CSC credential certificate (name=seal): only ES256 keys are supported")
+	})
+	t.Run("signing fails", func(t *testing.T) {
+		server := newTestServer(t)
+		server.addCredential(t, "seal", authModeOAuth2Code)
+		storage := newTestStorage(t, server)
+		signer, err := storage.GetPrivateKey(ctx, "seal", keyVersion)
+		require.NoError(t, err)
+		delete(server.credentials, "seal")
+		digest := sha256.Sum256([]byte("hello"))
+
+		_, err = signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+
+		assert.EqualError(t, err, "unable to sign with CSC API: CSC API returned HTTP 400: invalid_request (Invalid parameter credentialID)")
+	})
+	t.Run("digest other than SHA-256", func(t *testing.T) {
+		server := newTestServer(t)
+		server.addCredential(t, "seal", authModeImplicit)
+		storage := newTestStorage(t, server)
+		signer, err := storage.GetPrivateKey(ctx, "seal", keyVersion)
+		require.NoError(t, err)
+
+		_, err = signer.Sign(rand.Reader, make([]byte, 48), crypto.SHA384)
+
+		assert.EqualError(t, err, "only SHA-256 digests are supported")
+	})
+}
+
+func TestStorage_PrivateKeyExists(t *testing.T) {
+	server := newTestServer(t)
+	server.addCredential(t, "seal", authModeImplicit)
+	storage := newTestStorage(t, server)
+
+	t.Run("exists", func(t *testing.T) {
+		exists, err := storage.PrivateKeyExists(context.Background(), "seal", keyVersion)
+
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+	t.Run("does not exist", func(t *testing.T) {
+		exists, err := storage.PrivateKeyExists(context.Background(), "unknown", keyVersion)
+
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+	t.Run("error", func(t *testing.T) {
+		storage, _ := New(Config{Address: server.URL, AccessToken: "invalid"})
+
+		_, err := storage.PrivateKeyExists(context.Background(), "seal", keyVersion)
+
+		assert.ErrorContains(t, err, "unable to list credentials from CSC API")
+	})
+}
+
+func TestStorage_ListPrivateKeys(t *testing.T) {
+	server := newTestServer(t)
+	server.addCredential(t, "seal", authModeImplicit)
+	t.Run("ok", func(t *testing.T) {
+		storage := newTestStorage(t, server)
+
+		keys := storage.ListPrivateKeys(context.Background())
+
+		assert.Equal(t, []spi.KeyNameVersion{{KeyName: "seal", Version: keyVersion}}, keys)
+	})
+	t.Run("error", func(t *testing.T) {
+		storage, _ := New(Config{Address: server.URL, AccessToken: "invalid"})
+
+		keys := storage.ListPrivateKeys(context.Background())
+
+		assert.Nil(t, keys)
+	})
+}
+
+func TestStorage_ClaimPrivateKey(t *testing.T) {
+	ctx := context.Background()
+	server := newTestServer(t)
+	seal := server.addCredential(t, "seal", authModeImplicit)
+	storage := newTestStorage(t, server)
+
+	t.Run("ok", func(t *testing.T) {
+		keyName, publicKey, version, err := storage.ClaimPrivateKey(ctx, func(string) (bool, error) {
+			return true, nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "seal", keyName)
+		assert.Equal(t, seal.key.Public(), publicKey)
+		assert.Equal(t, keyVersion, version)
+	})
+	t.Run("all credentials claimed", func(t *testing.T) {
+		_, _, _, err := storage.ClaimPrivateKey(ctx, func(string) (bool, error) {
+			return false, nil
+		})
+
+		assert.EqualError(t, err, "no unclaimed CSC credentials left, they must be provisioned by the trust service provider")
+	})
+	t.Run("unusable credentials are skipped without claiming them", func(t *testing.T) {
+		server := newTestServer(t)
+		server.addCredential(t, "disabled", authModeImplicit).status = "disabled"
+		storage := newTestStorage(t, server)
+		var claimed []string
+
+		_, _, _, err := storage.ClaimPrivateKey(ctx, func(keyName string) (bool, error) {
+			claimed = append(claimed, keyName)
+			return true, nil
+		})
+
+		assert.Empty(t, claimed)
+		assert.EqualError(t, err, "no unclaimed CSC credentials left, they must be provisioned by the trust service provider")
+	})
+	t.Run("error - claim fails", func(t *testing.T) {
+		_, _, _, err := storage.ClaimPrivateKey(ctx, func(string) (bool, error) {
+			return false, assert.AnError
+		})
+
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+	t.Run("error - list credentials", func(t *testing.T) {
+		storage, _ := New(Config{Address: server.URL, AccessToken: "invalid"})
+
+		_, _, _, err := storage.ClaimPrivateKey(ctx, func(string) (bool, error) {
+			return false, nil
+		})
+
+		assert.ErrorContains(t, err, "unable to list credentials from CSC API")
+	})
+}
+
+func TestStorage_unsupportedOperations(t *testing.T) {
+	storage := newTestStorage(t, newTestServer(t))
+	ctx := context.Background()
+
+	_, _, err := storage.NewPrivateKey(ctx, "seal")
+	assert.EqualError(t, err, "NewPrivateKey() is not supported for CSC, credentials must be provisioned by the trust service provider")
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.EqualError(t, storage.SavePrivateKey(ctx, "seal", key), "SavePrivateKey() is not supported for CSC")
+	err = storage.DeletePrivateKey(ctx, "seal")
+	assert.ErrorIs(t, err, spi.ErrDeleteNotSupported)
+	assert.EqualError(t, err, "DeletePrivateKey() is not supported for CSC: deleting private keys is not supported by the storage backend")
+}
+
+func Test_toASN1Signature(t *testing.T) {
+	t.Run("invalid signature", func(t *testing.T) {
+		_, err := toASN1Signature([]byte("invalid"))
+
+		assert.EqualError(t, err, "unable to sign with CSC API: invalid ECDSA signature")
+	})
+}
@@ -0,0 +1,40 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package csc
+
+import "time"
+
+// Config contains the config options to configure the Cloud Signature Consortium (CSC) storage backend.
+type Config struct {
+	// Address specifies the base URL of the CSC API of the trust service provider, e.g. https://qtsp.example.com/csc/v2
+	Address string `koanf:"address"`
+	// AccessToken specifies the OAuth2 access token (service authorization) used to authenticate to the CSC API.
+	AccessToken string `koanf:"accesstoken"`
+	// UserID specifies the user whose credentials are listed. Only required if the access token isn't bound to a user.
+	UserID string `koanf:"userid"`
+	// Timeout specifies the CSC API client timeout.
+	Timeout time.Duration `koanf:"timeout"`
+}
+
+// DefaultConfig returns the default configuration for the CSC storage backend.
+func DefaultConfig() Config {
+	return Config{
+		Timeout: 10 * time.Second,
+	}
+}
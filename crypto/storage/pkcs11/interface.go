@@ -0,0 +1,53 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package pkcs11
+
+import "crypto"
+
+// Config contains the config options to configure the PKCS#11 storage backend.
+type Config struct {
+	// Library specifies the path to the PKCS#11 module (shared library) of the HSM vendor.
+	Library string `koanf:"library"`
+	// TokenLabel specifies the label of the token (slot) the keys are stored in.
+	TokenLabel string `koanf:"tokenlabel"`
+	// Pin specifies the user PIN used to log in to the token.
+	Pin string `koanf:"pin"`
+}
+
+// DefaultConfig returns the default configuration for the PKCS#11 storage backend.
+func DefaultConfig() Config {
+	return Config{}
+}
+
+// token abstracts the PKCS#11 token, to support mocking.
+// Keys are identified on the token by their label (CKA_LABEL).
+type token interface {
+	// GenerateKey generates a new, non-exportable key pair on the token with the given label.
+	GenerateKey(label string) (crypto.Signer, error)
+	// FindKey returns the key pair with the given label. It returns nil if the key does not exist.
+	FindKey(label string) (crypto.Signer, error)
+	// DeleteKey deletes the key pair with the given label. It returns false if the key does not exist.
+	DeleteKey(label string) (bool, error)
+	// ListKeys returns the labels of all key pairs on the token.
+	ListKeys() ([]string, error)
+	// Ping checks whether the token can be used, by performing an operation that requires a logged-in session.
+	Ping() error
+	// Close logs out of the token and releases the PKCS#11 module.
+	Close() error
+}
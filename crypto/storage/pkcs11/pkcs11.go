@@ -0,0 +1,150 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package pkcs11
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/crypto/log"
+	"github.com/nuts-foundation/nuts-node/crypto/storage/spi"
+)
+
+// StorageType is the name of this storage type, used in health check reports and configuration.
+const StorageType = "pkcs11"
+
+// keyVersion is the version reported for keys stored on a PKCS#11 token, since tokens don't support key versioning.
+const keyVersion = "1"
+
+var _ spi.Storage = (*Storage)(nil)
+var _ io.Closer = (*Storage)(nil)
+
+// New creates a new PKCS#11 storage backend, which stores private keys on the token identified by the config.
+// Keys are generated on the token and can't be exported; signing is performed by the token.
+func New(config Config) (*Storage, error) {
+	if config.Library == "" {
+		return nil, errors.New("missing PKCS#11 library path")
+	}
+	if config.TokenLabel == "" {
+		return nil, errors.New("missing PKCS#11 token label")
+	}
+	t, err := openToken(config)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open PKCS#11 token (label=%s): %w", config.TokenLabel, err)
+	}
+	return &Storage{token: t}, nil
+}
+
+// Storage is a spi.Storage implementation that stores private keys on a PKCS#11 token (e.g. an HSM).
+type Storage struct {
+	token token
+}
+
+func (s Storage) Name() string {
+	return StorageType
+}
+
+func (s Storage) CheckHealth() map[string]core.Health {
+	health := make(map[string]core.Health)
+	if err := s.token.Ping(); err != nil {
+		health[s.Name()] = core.Health{Status: core.HealthStatusDown, Details: err.Error()}
+	} else {
+		health[s.Name()] = core.Health{Status: core.HealthStatusUp}
+	}
+	return health
+}
+
+func (s Storage) NewPrivateKey(_ context.Context, keyName string) (crypto.PublicKey, string, error) {
+	existing, err := s.token.FindKey(keyName)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to look up key on PKCS#11 token (name=%s): %w", keyName, err)
+	}
+	if existing != nil {
+		return nil, "", spi.ErrKeyAlreadyExists
+	}
+	signer, err := s.token.GenerateKey(keyName)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to create key on PKCS#11 token (name=%s): %w", keyName, err)
+	}
+	return signer.Public(), keyVersion, nil
+}
+
+func (s Storage) GetPrivateKey(_ context.Context, keyName string, _ string) (crypto.Signer, error) {
+	signer, err := s.token.FindKey(keyName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get key from PKCS#11 token (name=%s): %w", keyName, err)
+	}
+	if signer == nil {
+		return nil, spi.ErrNotFound
+	}
+	return signer, nil
+}
+
+func (s Storage) PrivateKeyExists(ctx context.Context, keyName string, version string) (bool, error) {
+	_, err := s.GetPrivateKey(ctx, keyName, version)
+	if errors.Is(err, spi.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (s Storage) SavePrivateKey(_ context.Context, _ string, _ crypto.PrivateKey) error {
+	// Keys on the token are generated there and are non-exportable, importing keys is not supported.
+	return errors.New("SavePrivateKey() is not supported for PKCS#11")
+}
+
+func (s Storage) ListPrivateKeys(_ context.Context) []spi.KeyNameVersion {
+	labels, err := s.token.ListKeys()
+	if err != nil {
+		log.Logger().WithError(err).Error("unable to list keys from PKCS#11 token")
+		return nil
+	}
+	result := make([]spi.KeyNameVersion, 0, len(labels))
+	for _, label := range labels {
+		result = append(result, spi.KeyNameVersion{KeyName: label, Version: keyVersion})
+	}
+	return result
+}
+
+func (s Storage) DeletePrivateKey(_ context.Context, keyName string) error {
+	deleted, err := s.token.DeleteKey(keyName)
+	if err != nil {
+		return fmt.Errorf("unable to delete key from PKCS#11 token (name=%s): %w", keyName, err)
+	}
+	if !deleted {
+		return spi.ErrNotFound
+	}
+	return nil
+}
+
+// Close closes the session with the PKCS#11 token and finalizes the PKCS#11 module.
+// The Storage can't be used after it has been closed.
+func (s Storage) Close() error {
+	if err := s.token.Close(); err != nil {
+		return fmt.Errorf("unable to close PKCS#11 token: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,258 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package pkcs11
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/crypto/storage/spi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const keyName = "did-web-example-com-0"
+
+func TestNew(t *testing.T) {
+	t.Run("missing library", func(t *testing.T) {
+		_, err := New(Config{TokenLabel: "nuts"})
+		assert.EqualError(t, err, "missing PKCS#11 library path")
+	})
+	t.Run("missing token label", func(t *testing.T) {
+		_, err := New(Config{Library: "/usr/lib/softhsm/libsofthsm2.so"})
+		assert.EqualError(t, err, "missing PKCS#11 token label")
+	})
+	t.Run("library does not exist", func(t *testing.T) {
+		_, err := New(Config{Library: "/non-existing/libpkcs11.so", TokenLabel: "nuts"})
+		assert.ErrorContains(t, err, "unable to open PKCS#11 token (label=nuts)")
+	})
+}
+
+func TestStorage_Name(t *testing.T) {
+	assert.Equal(t, "pkcs11", Storage{}.Name())
+}
+
+func TestStorage_CheckHealth(t *testing.T) {
+	t.Run("up", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		tkn := NewMocktoken(ctrl)
+		tkn.EXPECT().Ping().Return(nil)
+
+		result := Storage{token: tkn}.CheckHealth()
+
+		assert.Equal(t, core.HealthStatusUp, result["pkcs11"].Status)
+	})
+	t.Run("down", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		tkn := NewMocktoken(ctrl)
+		tkn.EXPECT().Ping().Return(errors.New("CKR_SESSION_HANDLE_INVALID"))
+
+		result := Storage{token: tkn}.CheckHealth()
+
+		assert.Equal(t, core.HealthStatusDown, result["pkcs11"].Status)
+		assert.Equal(t, "CKR_SESSION_HANDLE_INVALID", result["pkcs11"].Details)
+	})
+}
+
+func TestStorage_NewPrivateKey(t *testing.T) {
+	privateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		tkn := NewMocktoken(ctrl)
+		tkn.EXPECT().FindKey(keyName).Return(nil, nil)
+		tkn.EXPECT().GenerateKey(keyName).Return(privateKey, nil)
+
+		publicKey, version, err := Storage{token: tkn}.NewPrivateKey(context.Background(), keyName)
+
+		require.NoError(t, err)
+		assert.Equal(t, privateKey.Public(), publicKey)
+		assert.Equal(t, "1", version)
+	})
+	t.Run("already exists", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		tkn := NewMocktoken(ctrl)
+		tkn.EXPECT().FindKey(keyName).Return(privateKey, nil)
+
+		_, _, err := Storage{token: tkn}.NewPrivateKey(context.Background(), keyName)
+
+		assert.ErrorIs(t, err, spi.ErrKeyAlreadyExists)
+	})
+	t.Run("generate fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		tkn := NewMocktoken(ctrl)
+		tkn.EXPECT().FindKey(keyName).Return(nil, nil)
+		tkn.EXPECT().GenerateKey(keyName).Return(nil, errors.New("CKR_DEVICE_ERROR"))
+
+		_, _, err := Storage{token: tkn}.NewPrivateKey(context.Background(), keyName)
+
+		assert.EqualError(t, err, "unable to create key on PKCS#11 token (name=did-web-example-com-0): CKR_DEVICE_ERROR")
+	})
+}
+
+func TestStorage_GetPrivateKey(t *testing.T) {
+	privateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	t.Run("found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		tkn := NewMocktoken(ctrl)
+		tkn.EXPECT().FindKey(keyName).Return(privateKey, nil)
+
+		signer, err := Storage{token: tkn}.GetPrivateKey(context.Background(), keyName, "1")
+
+		require.NoError(t, err)
+		assert.Same(t, privateKey, signer)
+	})
+	t.Run("not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		tkn := NewMocktoken(ctrl)
+		tkn.EXPECT().FindKey(keyName).Return(nil, nil)
+
+		_, err := Storage{token: tkn}.GetPrivateKey(context.Background(), keyName, "1")
+
+		assert.ErrorIs(t, err, spi.ErrNotFound)
+	})
+	t.Run("error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		tkn := NewMocktoken(ctrl)
+		tkn.EXPECT().FindKey(keyName).Return(nil, errors.New("CKR_DEVICE_ERROR"))
+
+		_, err := Storage{token: tkn}.GetPrivateKey(context.Background(), keyName, "1")
+
+		assert.EqualError(t, err, "unable to get key from PKCS#11 token (name=did-web-example-com-0): CKR_DEVICE_ERROR")
+	})
+}
+
+func TestStorage_PrivateKeyExists(t *testing.T) {
+	privateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	t.Run("found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		tkn := NewMocktoken(ctrl)
+		tkn.EXPECT().FindKey(keyName).Return(privateKey, nil)
+
+		exists, err := Storage{token: tkn}.PrivateKeyExists(context.Background(), keyName, "1")
+
+		require.NoError(t, err)
+		assert.True(t, exists)
+	})
+	t.Run("not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		tkn := NewMocktoken(ctrl)
+		tkn.EXPECT().FindKey(keyName).Return(nil, nil)
+
+		exists, err := Storage{token: tkn}.PrivateKeyExists(context.Background(), keyName, "1")
+
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+	t.Run("error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		tkn := NewMocktoken(ctrl)
+		tkn.EXPECT().FindKey(keyName).Return(nil, errors.New("CKR_DEVICE_ERROR"))
+
+		exists, err := Storage{token: tkn}.PrivateKeyExists(context.Background(), keyName, "1")
+
+		assert.Error(t, err)
+		assert.False(t, exists)
+	})
+}
+
+func TestStorage_SavePrivateKey(t *testing.T) {
+	privateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	err := Storage{}.SavePrivateKey(context.Background(), keyName, privateKey)
+
+	assert.EqualError(t, err, "SavePrivateKey() is not supported for PKCS#11")
+}
+
+func TestStorage_ListPrivateKeys(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		tkn := NewMocktoken(ctrl)
+		tkn.EXPECT().ListKeys().Return([]string{"key-1", "key-2"}, nil)
+
+		keys := Storage{token: tkn}.ListPrivateKeys(context.Background())
+
+		assert.Equal(t, []spi.KeyNameVersion{{KeyName: "key-1", Version: "1"}, {KeyName: "key-2", Version: "1"}}, keys)
+	})
+	t.Run("error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		tkn := NewMocktoken(ctrl)
+		tkn.EXPECT().ListKeys().Return(nil, errors.New("CKR_DEVICE_ERROR"))
+
+		keys := Storage{token: tkn}.ListPrivateKeys(context.Background())
+
+		assert.Nil(t, keys)
+	})
+}
+
+func TestStorage_DeletePrivateKey(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		tkn := NewMocktoken(ctrl)
+		tkn.EXPECT().DeleteKey(keyName).Return(true, nil)
+
+		err := Storage{token: tkn}.DeletePrivateKey(context.Background(), keyName)
+
+		assert.NoError(t, err)
+	})
+	t.Run("not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		tkn := NewMocktoken(ctrl)
+		tkn.EXPECT().DeleteKey(keyName).Return(false, nil)
+
+		err := Storage{token: tkn}.DeletePrivateKey(context.Background(), keyName)
+
+		assert.ErrorIs(t, err, spi.ErrNotFound)
+	})
+	t.Run("error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		tkn := NewMocktoken(ctrl)
+		tkn.EXPECT().DeleteKey(keyName).Return(false, errors.New("CKR_DEVICE_ERROR"))
+
+		err := Storage{token: tkn}.DeletePrivateKey(context.Background(), keyName)
+
+		assert.EqualError(t, err, "unable to delete key from PKCS#11 token (name=did-web-example-com-0): CKR_DEVICE_ERROR")
+	})
+}
+
+func TestStorage_Close(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		tkn := NewMocktoken(ctrl)
+		tkn.EXPECT().Close().Return(nil)
+
+		err := Storage{token: tkn}.Close()
+
+		assert.NoError(t, err)
+	})
+	t.Run("error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		tkn := NewMocktoken(ctrl)
+		tkn.EXPECT().Close().Return(errors.New("CKR_DEVICE_ERROR"))
+
+		err := Storage{token: tkn}.Close()
+
+		assert.EqualError(t, err, "unable to close PKCS#11 token: CKR_DEVICE_ERROR")
+	})
+}
@@ -0,0 +1,115 @@
+//go:build cgo
+
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package pkcs11
+
+import (
+	"crypto"
+	"crypto/elliptic"
+	"crypto/rand"
+
+	"github.com/ThalesIgnite/crypto11"
+)
+
+// keyIDLength is the length of the random CKA_ID assigned to generated key pairs.
+const keyIDLength = 16
+
+// pingLabel is the label of the key pair that is looked up to check whether the token can be used. It doesn't need to exist.
+const pingLabel = "nuts-health-check"
+
+func openToken(config Config) (token, error) {
+	ctx, err := crypto11.Configure(&crypto11.Config{
+		Path:       config.Library,
+		TokenLabel: config.TokenLabel,
+		Pin:        config.Pin,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &crypto11Token{ctx: ctx}, nil
+}
+
+var _ token = (*crypto11Token)(nil)
+
+// crypto11Token is a token implementation that uses crypto11 to access the PKCS#11 module.
+type crypto11Token struct {
+	ctx *crypto11.Context
+}
+
+func (c crypto11Token) GenerateKey(label string) (crypto.Signer, error) {
+	// crypto11 requires a CKA_ID, but keys are looked up by label.
+	id := make([]byte, keyIDLength)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	// crypto11 marks generated private keys as sensitive and non-extractable.
+	return c.ctx.GenerateECDSAKeyPairWithLabel(id, []byte(label), elliptic.P256())
+}
+
+func (c crypto11Token) FindKey(label string) (crypto.Signer, error) {
+	signer, err := c.ctx.FindKeyPair(nil, []byte(label))
+	if err != nil {
+		return nil, err
+	}
+	if signer == nil {
+		return nil, nil
+	}
+	return signer, nil
+}
+
+func (c crypto11Token) DeleteKey(label string) (bool, error) {
+	signer, err := c.ctx.FindKeyPair(nil, []byte(label))
+	if err != nil {
+		return false, err
+	}
+	if signer == nil {
+		return false, nil
+	}
+	return true, signer.Delete()
+}
+
+func (c crypto11Token) ListKeys() ([]string, error) {
+	signers, err := c.ctx.FindAllKeyPairs()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]string, 0, len(signers))
+	for _, signer := range signers {
+		label, err := c.ctx.GetAttribute(signer, crypto11.CkaLabel)
+		if err != nil {
+			return nil, err
+		}
+		// skip key pairs without a label, since those can't be addressed by this backend
+		if label == nil || len(label.Value) == 0 {
+			continue
+		}
+		result = append(result, string(label.Value))
+	}
+	return result, nil
+}
+
+func (c crypto11Token) Ping() error {
+	_, err := c.ctx.FindKeyPairs(nil, []byte(pingLabel))
+	return err
+}
+
+func (c crypto11Token) Close() error {
+	return c.ctx.Close()
+}
@@ -0,0 +1,28 @@
+//go:build !cgo
+
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package pkcs11
+
+import "errors"
+
+// openToken is not available when the node is built without cgo, since the PKCS#11 bindings require it.
+func openToken(_ Config) (token, error) {
+	return nil, errors.New("PKCS#11 support requires the Nuts node to be built with cgo (CGO_ENABLED=1)")
+}
@@ -0,0 +1,117 @@
+//go:build cgo
+
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package pkcs11
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nuts-foundation/nuts-node/crypto/storage/spi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIntegrationTest tests the storage against a real PKCS#11 token, e.g. SoftHSM2.
+// It only runs when NUTS_TEST_PKCS11_LIBRARY is set. To run it locally against SoftHSM2:
+//
+//	softhsm2-util --init-token --free --label nuts --pin 1234 --so-pin 1234
+//	NUTS_TEST_PKCS11_LIBRARY=/usr/lib/softhsm/libsofthsm2.so NUTS_TEST_PKCS11_TOKEN=nuts NUTS_TEST_PKCS11_PIN=1234 go test ./crypto/storage/pkcs11/...
+func TestIntegrationTest(t *testing.T) {
+	library := os.Getenv("NUTS_TEST_PKCS11_LIBRARY")
+	if library == "" {
+		t.Skip("NUTS_TEST_PKCS11_LIBRARY not set")
+	}
+	store, err := New(Config{
+		Library:    library,
+		TokenLabel: os.Getenv("NUTS_TEST_PKCS11_TOKEN"),
+		Pin:        os.Getenv("NUTS_TEST_PKCS11_PIN"),
+	})
+	require.NoError(t, err)
+
+	keyName := uuid.NewString()
+	ctx := context.Background()
+	_, version, err := store.NewPrivateKey(ctx, keyName)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = store.DeletePrivateKey(ctx, keyName)
+	})
+
+	t.Run("New", func(t *testing.T) {
+		t.Run("already exists", func(t *testing.T) {
+			_, _, err := store.NewPrivateKey(ctx, keyName)
+			assert.ErrorIs(t, err, spi.ErrKeyAlreadyExists)
+		})
+	})
+	t.Run("PrivateKeyExists", func(t *testing.T) {
+		t.Run("does not exist", func(t *testing.T) {
+			exists, err := store.PrivateKeyExists(ctx, "does-not-exist", "")
+			assert.NoError(t, err)
+			assert.False(t, exists)
+		})
+		t.Run("exists", func(t *testing.T) {
+			exists, err := store.PrivateKeyExists(ctx, keyName, version)
+			assert.NoError(t, err)
+			assert.True(t, exists)
+		})
+	})
+	t.Run("ListPrivateKeys", func(t *testing.T) {
+		keys := store.ListPrivateKeys(ctx)
+		assert.Contains(t, keys, spi.KeyNameVersion{KeyName: keyName, Version: version})
+	})
+	t.Run("GetPrivateKey", func(t *testing.T) {
+		t.Run("does not exist", func(t *testing.T) {
+			_, err := store.GetPrivateKey(ctx, "does-not-exist", "")
+			assert.ErrorIs(t, err, spi.ErrNotFound)
+		})
+		t.Run("sign", func(t *testing.T) {
+			signer, err := store.GetPrivateKey(ctx, keyName, version)
+			require.NoError(t, err)
+
+			digest := sha256.Sum256([]byte("hello"))
+			signature, err := signer.Sign(nil, digest[:], nil)
+			require.NoError(t, err)
+			valid := ecdsa.VerifyASN1(signer.Public().(*ecdsa.PublicKey), digest[:], signature)
+			assert.True(t, valid)
+		})
+	})
+	t.Run("DeletePrivateKey", func(t *testing.T) {
+		t.Run("ok", func(t *testing.T) {
+			otherKeyName := uuid.NewString()
+			_, version, err := store.NewPrivateKey(ctx, otherKeyName)
+			require.NoError(t, err)
+
+			err = store.DeletePrivateKey(ctx, otherKeyName)
+			assert.NoError(t, err)
+
+			exists, err := store.PrivateKeyExists(ctx, otherKeyName, version)
+			assert.NoError(t, err)
+			assert.False(t, exists)
+		})
+		t.Run("does not exist", func(t *testing.T) {
+			err := store.DeletePrivateKey(ctx, "does-not-exist")
+			assert.ErrorIs(t, err, spi.ErrNotFound)
+		})
+	})
+}
@@ -39,6 +39,9 @@ var ErrNotFound = errors.New("entry not found")
 // ErrKeyAlreadyExists indicates that a private key for this keyID already exists.
 var ErrKeyAlreadyExists = errors.New("key already exists")
 
+// ErrDeleteNotSupported indicates that the storage backend can't delete private keys (e.g. because they're managed by a third party).
+var ErrDeleteNotSupported = errors.New("deleting private keys is not supported by the storage backend")
+
 // KidPattern is the regexp for acceptable kids
 var KidPattern = regexp.MustCompile(`^(?:(?:[\da-zA-Z_\- :#.])|(?:%[0-9a-fA-F]{2}))+$`)
 
@@ -62,6 +65,15 @@ type Storage interface {
 	DeletePrivateKey(ctx context.Context, keyName string) error
 }
 
+// KeyClaimer is implemented by storage backends that can't create keys, but hand out keys that were provisioned in advance
+// (e.g. CSC credentials provisioned by a trust service provider). If a backend implements it, new keys are claimed instead of created.
+type KeyClaimer interface {
+	// ClaimPrivateKey claims a provisioned key and returns its name, public key and version.
+	// The claim function claims the key with the given name, it returns false if the key has already been claimed.
+	// Since claims are permanent, backends should only claim keys they are able to hand out.
+	ClaimPrivateKey(ctx context.Context, claim func(keyName string) (bool, error)) (string, crypto.PublicKey, string, error)
+}
+
 // KeyNameVersion contains a key name and version. It used as return argument for ListPrivateKeys.
 type KeyNameVersion struct {
 	KeyName string
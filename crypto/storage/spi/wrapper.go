@@ -23,6 +23,7 @@ import (
 	"crypto"
 	"fmt"
 	"github.com/nuts-foundation/nuts-node/core"
+	"io"
 	"regexp"
 )
 
@@ -41,13 +42,26 @@ func (w wrapper) CheckHealth() map[string]core.Health {
 	return w.wrappedBackend.CheckHealth()
 }
 
+// Close closes the wrapped backend if it holds resources that need to be released (implements io.Closer).
+func (w wrapper) Close() error {
+	if closer, ok := w.wrappedBackend.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
 // NewValidatedKIDBackendWrapper creates a new wrapper for storage backends.
 // Every call to the backend which takes a kid as param, gets the kid validated against the provided kidPattern.
+// If the backend implements KeyClaimer, so does the returned wrapper.
 func NewValidatedKIDBackendWrapper(backend Storage, kidPattern *regexp.Regexp) Storage {
-	return wrapper{
+	result := wrapper{
 		kidPattern:     kidPattern,
 		wrappedBackend: backend,
 	}
+	if claimer, ok := backend.(KeyClaimer); ok {
+		return claimingWrapper{wrapper: result, claimer: claimer}
+	}
+	return result
 }
 
 func (w wrapper) validateKID(kid string) error {
@@ -96,3 +110,20 @@ func (w wrapper) NewPrivateKey(ctx context.Context, keyName string) (crypto.Publ
 	}
 	return publicKey, version, err
 }
+
+// claimingWrapper is a wrapper for storage backends that implement KeyClaimer.
+type claimingWrapper struct {
+	wrapper
+	claimer KeyClaimer
+}
+
+func (w claimingWrapper) ClaimPrivateKey(ctx context.Context, claim func(keyName string) (bool, error)) (string, crypto.PublicKey, string, error) {
+	keyName, publicKey, version, err := w.claimer.ClaimPrivateKey(ctx, claim)
+	if err != nil {
+		return "", nil, "", err
+	}
+	if err := w.validateKID(keyName); err != nil {
+		return "", nil, "", err
+	}
+	return keyName, publicKey, version, nil
+}
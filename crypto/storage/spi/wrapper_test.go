@@ -20,6 +20,7 @@ package spi
 
 import (
 	"context"
+	"crypto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -164,3 +165,43 @@ func TestWrapper_ListPrivateKeys(t *testing.T) {
 		ctrl.Finish()
 	})
 }
+
+func TestWrapper_ClaimPrivateKey(t *testing.T) {
+	ctx := context.Background()
+	claim := func(string) (bool, error) {
+		return true, nil
+	}
+	t.Run("wrapper of backend that can't claim keys doesn't implement KeyClaimer", func(t *testing.T) {
+		w := NewValidatedKIDBackendWrapper(NewMockStorage(gomock.NewController(t)), KidPattern)
+
+		_, ok := w.(KeyClaimer)
+
+		assert.False(t, ok)
+	})
+	t.Run("expect call to wrapped backend", func(t *testing.T) {
+		w := NewValidatedKIDBackendWrapper(testClaimer{Storage: NewMockStorage(gomock.NewController(t)), keyName: goodKIDs[0]}, KidPattern)
+
+		keyName, _, version, err := w.(KeyClaimer).ClaimPrivateKey(ctx, claim)
+
+		require.NoError(t, err)
+		assert.Equal(t, goodKIDs[0], keyName)
+		assert.Equal(t, "1", version)
+	})
+	t.Run("expect error for bad KIDs", func(t *testing.T) {
+		w := NewValidatedKIDBackendWrapper(testClaimer{Storage: NewMockStorage(gomock.NewController(t)), keyName: badKIDs[0]}, KidPattern)
+
+		_, _, _, err := w.(KeyClaimer).ClaimPrivateKey(ctx, claim)
+
+		assert.EqualError(t, err, "invalid key ID: "+badKIDs[0])
+	})
+}
+
+// testClaimer is a Storage that hands out a single provisioned key.
+type testClaimer struct {
+	Storage
+	keyName string
+}
+
+func (c testClaimer) ClaimPrivateKey(_ context.Context, _ func(keyName string) (bool, error)) (string, crypto.PublicKey, string, error) {
+	return c.keyName, nil, "1", nil
+}
@@ -150,8 +150,9 @@ func newKeyReference(t *testing.T, client *Crypto, kid string) (*orm.KeyReferenc
 		DID: DID,
 		VerificationMethods: []orm.VerificationMethod{
 			{
-				ID:   kid,
-				Data: []byte("{}"),
+				ID:       kid,
+				KeyTypes: orm.VerificationMethodKeyType(orm.AssertionKeyUsage() | orm.EncryptionKeyUsage()),
+				Data:     []byte("{}"),
 			},
 		},
 	}
@@ -113,6 +113,19 @@ func (r *CachingRoundTripper) cacheResponse(httpRequest *http.Request, httpRespo
 	return nil
 }
 
+// CacheExpiration returns until when the given response may be cached according to RFC 7234.
+// It returns nil if the response doesn't specify an expiration time, and a zero time if the response must not be cached.
+func CacheExpiration(httpRequest *http.Request, httpResponse *http.Response) *time.Time {
+	reasons, expirationTime, err := cachecontrol.CachableResponse(httpRequest, httpResponse, cachecontrol.Options{PrivateCache: false})
+	if err != nil || len(reasons) > 0 {
+		return &time.Time{}
+	}
+	if expirationTime.IsZero() {
+		return nil
+	}
+	return &expirationTime
+}
+
 func newCache(responsesCacheSize int) *responseCache {
 	return &responseCache{
 		maxBytes:     responsesCacheSize,
@@ -202,6 +202,37 @@ func Test_httpClientCache(t *testing.T) {
 	})
 }
 
+func TestCacheExpiration(t *testing.T) {
+	httpRequest := &http.Request{
+		Method: http.MethodGet,
+		URL:    test.MustParseURL("http://example.com"),
+	}
+	newResponse := func(headers map[string]string) *http.Response {
+		response := &http.Response{StatusCode: http.StatusOK, Header: make(http.Header)}
+		for key, value := range headers {
+			response.Header.Set(key, value)
+		}
+		return response
+	}
+	t.Run("max-age", func(t *testing.T) {
+		result := CacheExpiration(httpRequest, newResponse(map[string]string{"Cache-Control": "max-age=60"}))
+
+		require.NotNil(t, result)
+		assert.WithinDuration(t, time.Now().Add(time.Minute), *result, 5*time.Second)
+	})
+	t.Run("no-store", func(t *testing.T) {
+		result := CacheExpiration(httpRequest, newResponse(map[string]string{"Cache-Control": "no-store"}))
+
+		require.NotNil(t, result)
+		assert.True(t, result.IsZero())
+	})
+	t.Run("no cache headers", func(t *testing.T) {
+		result := CacheExpiration(httpRequest, newResponse(nil))
+
+		assert.Nil(t, result)
+	})
+}
+
 type stubRoundTripper struct {
 	statusCode  int
 	data        []byte
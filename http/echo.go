@@ -45,6 +45,9 @@ const HealthPath = "/health"
 // InternalPath is the path used for internal endpoints.
 const InternalPath = "/internal"
 
+// UniversalResolverPath is the path used for the DIF Universal Resolver driver endpoint (/1.0/identifiers/{did}).
+const UniversalResolverPath = "/1.0"
+
 // EchoCreator is a function used to create an Echo server.
 type EchoCreator func() (EchoServer, error)
 
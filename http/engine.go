@@ -74,7 +74,9 @@ func (h *Engine) Configure(serverConfig core.ServerConfig) error {
 	// - /status
 	// - /health
 	// - /metrics
+	// - /1.0 (Universal Resolver driver endpoint)
 	// All other paths are bound to the public interface.
+	// The configured internal authentication applies to /internal and /1.0.
 
 	h.server = NewMultiEcho()
 	// Public endpoints
@@ -82,7 +84,7 @@ func (h *Engine) Configure(serverConfig core.ServerConfig) error {
 		return err
 	}
 	// Internal endpoints
-	for _, httpPath := range []string{InternalPath, HealthPath, MetricsPath} {
+	for _, httpPath := range []string{InternalPath, HealthPath, MetricsPath, UniversalResolverPath} {
 		if err := h.server.Bind(httpPath, []string{h.config.Internal.Address}, h.createEchoServer, h.config.ClientIPHeaderName); err != nil {
 			return err
 		}
@@ -95,7 +97,12 @@ func (h *Engine) Configure(serverConfig core.ServerConfig) error {
 	h.applyTracingMiddleware(h.server)
 	h.applyRateLimiterMiddleware(h.server, serverConfig)
 	h.applyLoggerMiddleware(h.server, []string{MetricsPath, StatusPath, HealthPath}, h.config.Log)
-	return h.applyAuthMiddleware(h.server, InternalPath, h.config.Internal.Auth)
+	for _, httpPath := range []string{InternalPath, UniversalResolverPath} {
+		if err := h.applyAuthMiddleware(h.server, httpPath, h.config.Internal.Auth); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (h *Engine) configureClient(serverConfig core.ServerConfig) {
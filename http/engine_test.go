@@ -187,6 +187,7 @@ func TestEngine_Configure(t *testing.T) {
 				// Apply the previously defined request handler at various endpoints on the HTTP engine
 				engine.Router().GET(securedPath, captureUser)
 				engine.Router().GET(unsecuredPath, captureUser)
+				engine.Router().GET(UniversalResolverPath+"/identifiers/:did", captureUser)
 
 				// Start the HTTP engine, ensuring it will be shutdown later
 				_ = engine.Start()
@@ -216,6 +217,26 @@ func TestEngine_Configure(t *testing.T) {
 					assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
 					assert.Empty(t, capturedUser)
 				})
+				t.Run("universal resolver endpoint", func(t *testing.T) {
+					path := UniversalResolverPath + "/identifiers/did:web:example.com"
+					t.Run("no token", func(t *testing.T) {
+						request, _ := http.NewRequest(http.MethodGet, "http://"+engine.config.Internal.Address+path, nil)
+						response, err := http.DefaultClient.Do(request)
+
+						assert.NoError(t, err)
+						assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+					})
+					t.Run("success", func(t *testing.T) {
+						capturedUser = ""
+						request, _ := http.NewRequest(http.MethodGet, "http://"+engine.config.Internal.Address+path, nil)
+						request.Header.Set("Authorization", "Bearer "+string(serializedToken))
+						response, err := http.DefaultClient.Do(request)
+
+						assert.NoError(t, err)
+						assert.Equal(t, http.StatusOK, response.StatusCode)
+						assert.Equal(t, "random@test.local", capturedUser)
+					})
+				})
 				t.Run("invalid token", func(t *testing.T) {
 					capturedUser = ""
 					request, _ := http.NewRequest(http.MethodGet, "http://"+engine.config.Internal.Address+securedPath, nil)
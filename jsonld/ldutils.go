@@ -154,6 +154,12 @@ const W3cVcContext = "https://www.w3.org/2018/credentials/v1"
 // W3cStatusList2021Context contains the StatusList2021 related context
 const W3cStatusList2021Context = "https://w3id.org/vc/status-list/2021/v1"
 
+// W3cBitstringStatusListContext contains the BitstringStatusList related context for VC data model v1 credentials
+const W3cBitstringStatusListContext = "https://www.w3.org/ns/credentials/status/v1"
+
+// DIDConfigurationContext contains the DIF Well-Known DID Configuration context, which defines the DomainLinkageCredential
+const DIDConfigurationContext = "https://identity.foundation/.well-known/did-configuration/v1"
+
 // Jws2020Context contains the JsonWebToken2020 Proof type context
 const Jws2020Context = "https://w3c-ccg.github.io/lds-jws2020/contexts/lds-jws2020-v1.json"
 
@@ -171,6 +177,8 @@ func DefaultContextConfig() ContextsConfig {
 			"https://nuts.nl/credentials/2024": "assets/contexts/nuts-2024.ldjson",
 			W3cVcContext:                       "assets/contexts/w3c-credentials-v1.ldjson",
 			W3cStatusList2021Context:           "assets/contexts/w3c-statuslist2021.ldjson",
+			W3cBitstringStatusListContext:      "assets/contexts/w3c-bitstring-statuslist-v1.ldjson",
+			DIDConfigurationContext:            "assets/contexts/did-configuration-v1.ldjson",
 			Jws2020Context:                     "assets/contexts/lds-jws2020-v1.ldjson",
 			SchemaOrgContext:                   "assets/contexts/schema-org-v13.ldjson",
 		},
@@ -179,7 +187,7 @@ func DefaultContextConfig() ContextsConfig {
 
 // DefaultAllowList returns the default allow list for external contexts
 func DefaultAllowList() []string {
-	return []string{SchemaOrgContext, W3cVcContext, Jws2020Context, W3cStatusList2021Context}
+	return []string{SchemaOrgContext, W3cVcContext, Jws2020Context, W3cStatusList2021Context, W3cBitstringStatusListContext, DIDConfigurationContext}
 }
 
 // NewContextLoader creates a new JSON-LD context loader with the embedded FS as first loader.
@@ -0,0 +1,147 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/audit"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/core/to"
+	"github.com/nuts-foundation/nuts-node/policy"
+	"github.com/nuts-foundation/nuts-node/vcr/dcql"
+	"github.com/nuts-foundation/nuts-node/vcr/pe"
+)
+
+var _ StrictServerInterface = (*Wrapper)(nil)
+var _ core.ErrorStatusCodeResolver = (*Wrapper)(nil)
+
+// Wrapper implements the internal policy API.
+type Wrapper struct {
+	Policy policy.LocalPolicy
+}
+
+func (w *Wrapper) ResolveStatusCode(err error) int {
+	switch {
+	case errors.Is(err, policy.ErrNotFound):
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (w *Wrapper) Routes(router core.EchoRouter) {
+	RegisterHandlers(router, NewStrictHandler(w, []StrictMiddlewareFunc{
+		func(f StrictHandlerFunc, operationID string) StrictHandlerFunc {
+			return func(ctx echo.Context, request interface{}) (response interface{}, err error) {
+				ctx.Set(core.OperationIDContextKey, operationID)
+				ctx.Set(core.ModuleNameContextKey, policy.ModuleName)
+				ctx.Set(core.StatusCodeResolverContextKey, w)
+				return f(ctx, request)
+			}
+		},
+		func(f StrictHandlerFunc, operationID string) StrictHandlerFunc {
+			return audit.StrictMiddleware(f, policy.ModuleName, operationID)
+		},
+	}))
+}
+
+func (w *Wrapper) ListScopes(_ context.Context, _ ListScopesRequestObject) (ListScopesResponseObject, error) {
+	return ListScopes200JSONResponse(w.Policy.Scopes()), nil
+}
+
+func (w *Wrapper) GetScope(_ context.Context, request GetScopeRequestObject) (GetScopeResponseObject, error) {
+	definitions, queries, err := w.scopeMapping(request.Scope)
+	if err != nil {
+		return nil, err
+	}
+	result := WalletOwnerMapping{}
+	for walletOwnerType, definition := range definitions {
+		result[walletOwnerType] = definition
+	}
+	for walletOwnerType, query := range queries {
+		result[walletOwnerType] = query
+	}
+	return GetScope200JSONResponse(result), nil
+}
+
+func (w *Wrapper) DryRunScope(_ context.Context, request DryRunScopeRequestObject) (DryRunScopeResponseObject, error) {
+	if request.Body == nil || len(request.Body.Credentials) == 0 {
+		return nil, core.InvalidInputError("credentials are required")
+	}
+	definitions, queries, err := w.scopeMapping(request.Scope)
+	if err != nil {
+		return nil, err
+	}
+	walletOwnerType := pe.WalletOwnerOrganization
+	if request.Body.WalletOwnerType != nil {
+		walletOwnerType = *request.Body.WalletOwnerType
+	}
+	if queries != nil {
+		return dryRunDCQLQuery(queries, walletOwnerType, request.Body.Credentials)
+	}
+	presentationDefinition, ok := definitions[walletOwnerType]
+	if !ok {
+		return nil, core.NotFoundError("no presentation definition for wallet owner type '%s'", walletOwnerType)
+	}
+	matched, _, err := presentationDefinition.Match(request.Body.Credentials)
+	if err != nil {
+		return DryRunScope200JSONResponse{Match: false, Reason: to.Ptr(err.Error())}, nil
+	}
+	return DryRunScope200JSONResponse{Match: true, Credentials: &matched}, nil
+}
+
+// dryRunDCQLQuery matches the credentials against the DCQL query of the given wallet owner type.
+// The selected credentials are returned in the order of the query's Credential Queries.
+func dryRunDCQLQuery(queries dcql.WalletOwnerMapping, walletOwnerType pe.WalletOwnerType, credentials []vc.VerifiableCredential) (DryRunScopeResponseObject, error) {
+	query, ok := queries[walletOwnerType]
+	if !ok {
+		return nil, core.NotFoundError("no DCQL query for wallet owner type '%s'", walletOwnerType)
+	}
+	selected, err := query.Match(credentials)
+	if err != nil {
+		return DryRunScope200JSONResponse{Match: false, Reason: to.Ptr(err.Error())}, nil
+	}
+	var matched []vc.VerifiableCredential
+	for _, credentialQuery := range query.Credentials {
+		matched = append(matched, selected[credentialQuery.ID]...)
+	}
+	return DryRunScope200JSONResponse{Match: true, Credentials: &matched}, nil
+}
+
+// scopeMapping returns the Presentation Definitions or the DCQL queries of the given scope, depending on how the scope is expressed.
+// It returns policy.ErrNotFound if the scope isn't defined.
+func (w *Wrapper) scopeMapping(scope string) (pe.WalletOwnerMapping, dcql.WalletOwnerMapping, error) {
+	definitions, err := w.Policy.LocalPresentationDefinitions(scope)
+	if err == nil {
+		return definitions, nil, nil
+	}
+	if !errors.Is(err, policy.ErrNotFound) {
+		return nil, nil, err
+	}
+	queries, err := w.Policy.LocalDCQLQueries(scope)
+	if err != nil {
+		return nil, nil, err
+	}
+	return nil, queries, nil
+}
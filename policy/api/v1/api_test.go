@@ -0,0 +1,255 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package v1
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/core/to"
+	"github.com/nuts-foundation/nuts-node/policy"
+	"github.com/nuts-foundation/nuts-node/vcr/dcql"
+	"github.com/nuts-foundation/nuts-node/vcr/pe"
+	"github.com/nuts-foundation/nuts-node/vcr/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const scope = "example-scope"
+
+func TestWrapper_ListScopes(t *testing.T) {
+	ctx := newMockContext(t)
+	ctx.policy.EXPECT().Scopes().Return([]string{"a", "b"})
+
+	response, err := ctx.wrapper.ListScopes(context.Background(), ListScopesRequestObject{})
+
+	require.NoError(t, err)
+	assert.Equal(t, ListScopes200JSONResponse{"a", "b"}, response)
+}
+
+func TestWrapper_GetScope(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctx := newMockContext(t)
+		mapping := pe.WalletOwnerMapping{pe.WalletOwnerOrganization: pe.PresentationDefinition{Id: "test"}}
+		ctx.policy.EXPECT().LocalPresentationDefinitions(scope).Return(mapping, nil)
+
+		response, err := ctx.wrapper.GetScope(context.Background(), GetScopeRequestObject{Scope: scope})
+
+		require.NoError(t, err)
+		assert.Equal(t, GetScope200JSONResponse{pe.WalletOwnerOrganization: pe.PresentationDefinition{Id: "test"}}, response)
+	})
+	t.Run("ok - DCQL", func(t *testing.T) {
+		ctx := newMockContext(t)
+		query := dcql.Query{Credentials: []dcql.CredentialQuery{{ID: "organization", Format: dcql.FormatLDPVC}}}
+		ctx.policy.EXPECT().LocalPresentationDefinitions(scope).Return(nil, policy.ErrNotFound)
+		ctx.policy.EXPECT().LocalDCQLQueries(scope).Return(dcql.WalletOwnerMapping{pe.WalletOwnerOrganization: query}, nil)
+
+		response, err := ctx.wrapper.GetScope(context.Background(), GetScopeRequestObject{Scope: scope})
+
+		require.NoError(t, err)
+		assert.Equal(t, GetScope200JSONResponse{pe.WalletOwnerOrganization: query}, response)
+	})
+	t.Run("not found", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.policy.EXPECT().LocalPresentationDefinitions(scope).Return(nil, policy.ErrNotFound)
+		ctx.policy.EXPECT().LocalDCQLQueries(scope).Return(nil, policy.ErrNotFound)
+
+		_, err := ctx.wrapper.GetScope(context.Background(), GetScopeRequestObject{Scope: scope})
+
+		assert.ErrorIs(t, err, policy.ErrNotFound)
+	})
+}
+
+func TestWrapper_DryRunScope(t *testing.T) {
+	credential := test.ValidNutsOrganizationCredential(t)
+	presentationDefinition := pe.PresentationDefinition{
+		Id: "test",
+		InputDescriptors: []*pe.InputDescriptor{
+			{
+				Id: "organization_credential",
+				Constraints: &pe.Constraints{
+					Fields: []pe.Field{
+						{
+							Path:   []string{"$.type"},
+							Filter: &pe.Filter{Type: "string", Const: to.Ptr("NutsOrganizationCredential")},
+						},
+					},
+				},
+			},
+		},
+	}
+	mapping := pe.WalletOwnerMapping{pe.WalletOwnerOrganization: presentationDefinition}
+	t.Run("match", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.policy.EXPECT().LocalPresentationDefinitions(scope).Return(mapping, nil)
+
+		response, err := ctx.wrapper.DryRunScope(context.Background(), DryRunScopeRequestObject{
+			Scope: scope,
+			Body:  &DryRunScopeJSONRequestBody{Credentials: []vc.VerifiableCredential{credential}},
+		})
+
+		require.NoError(t, err)
+		result := response.(DryRunScope200JSONResponse)
+		assert.True(t, result.Match)
+		assert.Nil(t, result.Reason)
+		require.NotNil(t, result.Credentials)
+		assert.Len(t, *result.Credentials, 1)
+	})
+	t.Run("no match", func(t *testing.T) {
+		ctx := newMockContext(t)
+		otherCredential := vc.VerifiableCredential{Type: []ssi.URI{vc.VerifiableCredentialTypeV1URI(), ssi.MustParseURI("OtherCredential")}}
+		ctx.policy.EXPECT().LocalPresentationDefinitions(scope).Return(mapping, nil)
+
+		response, err := ctx.wrapper.DryRunScope(context.Background(), DryRunScopeRequestObject{
+			Scope: scope,
+			Body:  &DryRunScopeJSONRequestBody{Credentials: []vc.VerifiableCredential{otherCredential}},
+		})
+
+		require.NoError(t, err)
+		result := response.(DryRunScope200JSONResponse)
+		assert.False(t, result.Match)
+		require.NotNil(t, result.Reason)
+		assert.Contains(t, *result.Reason, "missing credentials")
+		assert.Nil(t, result.Credentials)
+	})
+	t.Run("no presentation definition for wallet owner type", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.policy.EXPECT().LocalPresentationDefinitions(scope).Return(mapping, nil)
+		walletOwnerType := pe.WalletOwnerUser
+
+		_, err := ctx.wrapper.DryRunScope(context.Background(), DryRunScopeRequestObject{
+			Scope: scope,
+			Body: &DryRunScopeJSONRequestBody{
+				Credentials:     []vc.VerifiableCredential{credential},
+				WalletOwnerType: &walletOwnerType,
+			},
+		})
+
+		assert.EqualError(t, err, "no presentation definition for wallet owner type 'user'")
+	})
+	t.Run("scope not found", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.policy.EXPECT().LocalPresentationDefinitions(scope).Return(nil, policy.ErrNotFound)
+		ctx.policy.EXPECT().LocalDCQLQueries(scope).Return(nil, policy.ErrNotFound)
+
+		_, err := ctx.wrapper.DryRunScope(context.Background(), DryRunScopeRequestObject{
+			Scope: scope,
+			Body:  &DryRunScopeJSONRequestBody{Credentials: []vc.VerifiableCredential{credential}},
+		})
+
+		assert.ErrorIs(t, err, policy.ErrNotFound)
+	})
+	t.Run("DCQL", func(t *testing.T) {
+		query, err := dcql.Parse([]byte(`{"credentials": [{"id": "organization", "format": "ldp_vc", "meta": {"type_values": [["NutsOrganizationCredential"]]}}]}`))
+		require.NoError(t, err)
+		queries := dcql.WalletOwnerMapping{pe.WalletOwnerOrganization: *query}
+		t.Run("match", func(t *testing.T) {
+			ctx := newMockContext(t)
+			ctx.policy.EXPECT().LocalPresentationDefinitions(scope).Return(nil, policy.ErrNotFound)
+			ctx.policy.EXPECT().LocalDCQLQueries(scope).Return(queries, nil)
+
+			response, err := ctx.wrapper.DryRunScope(context.Background(), DryRunScopeRequestObject{
+				Scope: scope,
+				Body:  &DryRunScopeJSONRequestBody{Credentials: []vc.VerifiableCredential{credential}},
+			})
+
+			require.NoError(t, err)
+			result := response.(DryRunScope200JSONResponse)
+			assert.True(t, result.Match)
+			assert.Nil(t, result.Reason)
+			require.NotNil(t, result.Credentials)
+			assert.Len(t, *result.Credentials, 1)
+		})
+		t.Run("no match", func(t *testing.T) {
+			ctx := newMockContext(t)
+			otherCredential := vc.VerifiableCredential{Type: []ssi.URI{vc.VerifiableCredentialTypeV1URI(), ssi.MustParseURI("OtherCredential")}}
+			ctx.policy.EXPECT().LocalPresentationDefinitions(scope).Return(nil, policy.ErrNotFound)
+			ctx.policy.EXPECT().LocalDCQLQueries(scope).Return(queries, nil)
+
+			response, err := ctx.wrapper.DryRunScope(context.Background(), DryRunScopeRequestObject{
+				Scope: scope,
+				Body:  &DryRunScopeJSONRequestBody{Credentials: []vc.VerifiableCredential{otherCredential}},
+			})
+
+			require.NoError(t, err)
+			result := response.(DryRunScope200JSONResponse)
+			assert.False(t, result.Match)
+			assert.NotNil(t, result.Reason)
+			assert.Nil(t, result.Credentials)
+		})
+		t.Run("no DCQL query for wallet owner type", func(t *testing.T) {
+			ctx := newMockContext(t)
+			ctx.policy.EXPECT().LocalPresentationDefinitions(scope).Return(nil, policy.ErrNotFound)
+			ctx.policy.EXPECT().LocalDCQLQueries(scope).Return(queries, nil)
+			walletOwnerType := pe.WalletOwnerUser
+
+			_, err := ctx.wrapper.DryRunScope(context.Background(), DryRunScopeRequestObject{
+				Scope: scope,
+				Body: &DryRunScopeJSONRequestBody{
+					Credentials:     []vc.VerifiableCredential{credential},
+					WalletOwnerType: &walletOwnerType,
+				},
+			})
+
+			assert.EqualError(t, err, "no DCQL query for wallet owner type 'user'")
+		})
+	})
+	t.Run("no credentials", func(t *testing.T) {
+		ctx := newMockContext(t)
+
+		_, err := ctx.wrapper.DryRunScope(context.Background(), DryRunScopeRequestObject{
+			Scope: scope,
+			Body:  &DryRunScopeJSONRequestBody{},
+		})
+
+		assert.EqualError(t, err, "credentials are required")
+	})
+}
+
+func TestWrapper_ResolveStatusCode(t *testing.T) {
+	expected := map[error]int{
+		errors.New("foo"):  http.StatusInternalServerError,
+		policy.ErrNotFound: http.StatusNotFound,
+	}
+	wrapper := Wrapper{}
+	for err, expectedCode := range expected {
+		t.Run(err.Error(), func(t *testing.T) {
+			assert.Equal(t, expectedCode, wrapper.ResolveStatusCode(err))
+		})
+	}
+}
+
+type mockContext struct {
+	policy  *policy.MockLocalPolicy
+	wrapper Wrapper
+}
+
+func newMockContext(t *testing.T) mockContext {
+	ctrl := gomock.NewController(t)
+	localPolicy := policy.NewMockLocalPolicy(ctrl)
+	return mockContext{
+		policy:  localPolicy,
+		wrapper: Wrapper{Policy: localPolicy},
+	}
+}
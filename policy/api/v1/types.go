@@ -0,0 +1,33 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package v1
+
+import (
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/vcr/pe"
+)
+
+// VerifiableCredential is a type alias for the VerifiableCredential from the go-did library.
+type VerifiableCredential = vc.VerifiableCredential
+
+// WalletOwnerMapping maps wallet owner types to the Presentation Definitions or DCQL queries of a scope.
+type WalletOwnerMapping = map[pe.WalletOwnerType]interface{}
+
+// WalletOwnerType is a type alias for the WalletOwnerType from the pe package.
+type WalletOwnerType = pe.WalletOwnerType
@@ -27,5 +27,9 @@ func FlagSet() *pflag.FlagSet {
 	defCfg := defaultConfig()
 	flagSet := pflag.NewFlagSet("policy", pflag.ContinueOnError)
 	flagSet.String("policy.directory", defCfg.Directory, "Directory to read policy files from. Policy files are JSON files that contain a scope to PresentationDefinition mapping.")
+	flagSet.String("policy.remote.url", defCfg.Remote.URL, "URL of a remote Policy Decision Point. If set, the mapping from scope to PresentationDefinition is requested from the remote PDP instead of read from policy files.")
+	flagSet.Duration("policy.remote.timeout", defCfg.Remote.Timeout, "Timeout for requests to the remote Policy Decision Point (in Golang duration format, e.g. '5s').")
+	flagSet.Duration("policy.remote.cachettl", defCfg.Remote.CacheTTL, "Time responses of the remote Policy Decision Point are cached (in Golang duration format, e.g. '1m'). Set to 0 to disable caching.")
+	flagSet.Bool("policy.remote.failclosed", defCfg.Remote.FailClosed, "If true, authorization requests are denied when the remote Policy Decision Point can't be reached. If false, the policy files from policy.directory are used instead.")
 	return flagSet
 }
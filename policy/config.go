@@ -18,12 +18,36 @@
 
 package policy
 
+import "time"
+
 type Config struct {
 	// Directory is the directory where the policy files are stored
 	// policy files include a scope to presentation definition mapping
 	Directory string `koanf:"directory"`
+	// Remote contains the configuration of the remote Policy Decision Point
+	Remote RemoteConfig `koanf:"remote"`
+}
+
+// RemoteConfig contains the configuration for a remote Policy Decision Point.
+type RemoteConfig struct {
+	// URL is the endpoint of the remote PDP. If set, scopes are resolved by the remote PDP instead of the policy files.
+	URL string `koanf:"url"`
+	// Timeout is the maximum time to wait for a response of the remote PDP.
+	Timeout time.Duration `koanf:"timeout"`
+	// CacheTTL specifies how long responses of the remote PDP are cached. 0 disables caching.
+	CacheTTL time.Duration `koanf:"cachettl"`
+	// FailClosed specifies whether requests are denied when the remote PDP can't be reached.
+	// If false, the policy files from Directory are used instead.
+	FailClosed bool `koanf:"failclosed"`
 }
 
 func defaultConfig() Config {
-	return Config{Directory: "./config/policy"}
+	return Config{
+		Directory: "./config/policy",
+		Remote: RemoteConfig{
+			Timeout:    5 * time.Second,
+			CacheTTL:   time.Minute,
+			FailClosed: true,
+		},
+	}
 }
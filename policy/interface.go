@@ -21,6 +21,7 @@ package policy
 import (
 	"context"
 	"errors"
+	"github.com/nuts-foundation/nuts-node/vcr/dcql"
 	"github.com/nuts-foundation/nuts-node/vcr/pe"
 )
 
@@ -34,5 +35,47 @@ var ErrNotFound = errors.New("not found")
 type PDPBackend interface {
 	// PresentationDefinitions returns the PresentationDefinitions (mapped to a WalletOwnerType) for the given scope
 	// scopes are space delimited. It's up to the backend to decide how to handle this
+	// It returns ErrNotFound if the scope isn't defined, or is expressed in DCQL.
 	PresentationDefinitions(ctx context.Context, scope string) (pe.WalletOwnerMapping, error)
+	// DCQLQueries returns the DCQL queries (mapped to a WalletOwnerType) for the given scope, for scopes that are expressed in DCQL instead of Presentation Exchange.
+	// It returns ErrNotFound if the scope isn't defined, or is expressed in Presentation Exchange.
+	DCQLQueries(ctx context.Context, scope string) (dcql.WalletOwnerMapping, error)
+}
+
+// LocalPolicy provides insight into the scope mappings loaded from the policy directory.
+type LocalPolicy interface {
+	// Scopes returns the scopes defined in the policy directory, sorted alphabetically.
+	Scopes() []string
+	// LocalPresentationDefinitions returns the PresentationDefinitions (mapped to a WalletOwnerType) for the given scope as defined in the policy directory.
+	// It returns ErrNotFound if the scope isn't defined, or is expressed in DCQL.
+	LocalPresentationDefinitions(scope string) (pe.WalletOwnerMapping, error)
+	// LocalDCQLQueries returns the DCQL queries (mapped to a WalletOwnerType) for the given scope as defined in the policy directory.
+	// It returns ErrNotFound if the scope isn't defined, or is expressed in Presentation Exchange.
+	LocalDCQLQueries(scope string) (dcql.WalletOwnerMapping, error)
+}
+
+// RequestInfo contains information about the authorization request a PDPBackend is consulted for.
+// Backends can use it to base their decision on more than the requested scope.
+type RequestInfo struct {
+	// ClientID is the OAuth2 client that requested authorization.
+	ClientID string `json:"client_id,omitempty"`
+	// Subject is the local subject that acts as authorization server for the request.
+	Subject string `json:"subject,omitempty"`
+}
+
+type requestInfoContextKey struct{}
+
+// Context returns a child context of the given parent context, enriched with the requesting client and the subject authorization was requested from.
+func Context(parent context.Context, clientID string, subject string) context.Context {
+	return context.WithValue(parent, requestInfoContextKey{}, RequestInfo{
+		ClientID: clientID,
+		Subject:  subject,
+	})
+}
+
+// RequestInfoFromContext extracts the request info from the given context.
+// It returns an empty RequestInfo if the context doesn't contain any.
+func RequestInfoFromContext(ctx context.Context) RequestInfo {
+	info, _ := ctx.Value(requestInfoContextKey{}).(RequestInfo)
+	return info
 }
@@ -21,33 +21,40 @@ package policy
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"github.com/fsnotify/fsnotify"
 	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/policy/log"
+	"github.com/nuts-foundation/nuts-node/vcr/dcql"
 	"github.com/nuts-foundation/nuts-node/vcr/pe"
 	v2 "github.com/nuts-foundation/nuts-node/vcr/pe/schema/v2"
 	"io"
 	"os"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 )
 
-var _ PDPBackend = (*LocalPDP)(nil)
+// reloadDelay is the time to wait after a change in the policy directory before reloading,
+// so that multiple changes (e.g. an editor writing a file in several steps) result in a single reload.
+const reloadDelay = 500 * time.Millisecond
 
-// New creates a new local policy backend
-func New() *LocalPDP {
-	return &LocalPDP{}
-}
+var _ PDPBackend = (*LocalPDP)(nil)
 
 // LocalPDP is a backend for presentation definitions
-// It loads a file with the mapping from oauth scope to PEX Policy.
-// It allows access when the requester can present a submission according to the Presentation Definition.
+// It loads a file with the mapping from oauth scope to PEX Policy or DCQL queries.
+// It allows access when the requester can present a submission according to the Presentation Definition (or DCQL query).
 type LocalPDP struct {
 	config Config
 	// mapping holds the oauth scope to PEX Policy mapping
 	mapping map[string]validatingWalletOwnerMapping
-}
-
-func (b *LocalPDP) Name() string {
-	return ModuleName
+	// dcqlMapping holds the oauth scope to DCQL query mapping, for scopes expressed in DCQL
+	dcqlMapping map[string]dcql.WalletOwnerMapping
+	// mux guards mapping and dcqlMapping, which are replaced when the policy directory changes
+	mux     sync.RWMutex
+	watcher *fsnotify.Watcher
 }
 
 func (b *LocalPDP) Configure(_ core.ServerConfig) error {
@@ -71,11 +78,9 @@ func (b *LocalPDP) Configure(_ core.ServerConfig) error {
 	return nil
 }
 
-func (b *LocalPDP) Config() interface{} {
-	return &b.config
-}
-
 func (b *LocalPDP) PresentationDefinitions(_ context.Context, scope string) (pe.WalletOwnerMapping, error) {
+	b.mux.RLock()
+	defer b.mux.RUnlock()
 	result := pe.WalletOwnerMapping{}
 	mapping, exists := b.mapping[scope]
 	if !exists {
@@ -87,7 +92,91 @@ func (b *LocalPDP) PresentationDefinitions(_ context.Context, scope string) (pe.
 	return result, nil
 }
 
-// loadFromDirectory traverses all .json files in the given directory and loads them
+func (b *LocalPDP) DCQLQueries(_ context.Context, scope string) (dcql.WalletOwnerMapping, error) {
+	b.mux.RLock()
+	defer b.mux.RUnlock()
+	result := dcql.WalletOwnerMapping{}
+	mapping, exists := b.dcqlMapping[scope]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	for walletOwnerType, query := range mapping {
+		result[walletOwnerType] = query
+	}
+	return result, nil
+}
+
+// Scopes returns the scopes that have a mapping, sorted alphabetically.
+func (b *LocalPDP) Scopes() []string {
+	b.mux.RLock()
+	defer b.mux.RUnlock()
+	result := make([]string, 0, len(b.mapping)+len(b.dcqlMapping))
+	for scope := range b.mapping {
+		result = append(result, scope)
+	}
+	for scope := range b.dcqlMapping {
+		result = append(result, scope)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// watch starts watching the policy directory for changes, reloading the policy when a change is detected.
+func (b *LocalPDP) watch() error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err = watcher.Add(b.config.Directory); err != nil {
+		_ = watcher.Close()
+		return fmt.Errorf("failed to watch policy directory: %w", err)
+	}
+	b.watcher = watcher
+	go func() {
+		var reloadTimer *time.Timer
+		for {
+			select {
+			case _, ok := <-watcher.Events:
+				if !ok {
+					if reloadTimer != nil {
+						reloadTimer.Stop()
+					}
+					return
+				}
+				if reloadTimer != nil {
+					reloadTimer.Stop()
+				}
+				reloadTimer = time.AfterFunc(reloadDelay, b.reload)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Logger().WithError(err).Warn("Error watching policy directory")
+			}
+		}
+	}()
+	return nil
+}
+
+// stopWatching stops watching the policy directory, if it was being watched.
+func (b *LocalPDP) stopWatching() error {
+	if b.watcher == nil {
+		return nil
+	}
+	return b.watcher.Close()
+}
+
+// reload loads the policy directory again. If loading fails, the current policy remains active.
+func (b *LocalPDP) reload() {
+	if err := b.loadFromDirectory(b.config.Directory); err != nil {
+		log.Logger().WithError(err).Error("Failed to reload policy from directory, keeping current policy")
+		return
+	}
+	log.Logger().Infof("Reloaded policy from directory (scopes=%d)", len(b.Scopes()))
+}
+
+// loadFromDirectory traverses all .json files in the given directory and loads them.
+// The current mapping is only replaced if all files are loaded and valid, so an invalid file never results in a partially loaded policy.
 func (b *LocalPDP) loadFromDirectory(directory string) error {
 	// open the directory
 	dir, err := os.Open(directory)
@@ -103,6 +192,8 @@ func (b *LocalPDP) loadFromDirectory(directory string) error {
 	}
 
 	// load all the files
+	mapping := make(map[string]validatingWalletOwnerMapping)
+	dcqlMapping := make(map[string]dcql.WalletOwnerMapping)
 	for _, file := range files {
 		if file.IsDir() {
 			continue
@@ -110,16 +201,34 @@ func (b *LocalPDP) loadFromDirectory(directory string) error {
 		if !strings.HasSuffix(file.Name(), ".json") {
 			continue
 		}
-		err := b.loadFromFile(fmt.Sprintf("%s/%s", directory, file.Name()))
+		err := readMappingFile(fmt.Sprintf("%s/%s", directory, file.Name()), mapping, dcqlMapping)
 		if err != nil {
 			return err
 		}
 	}
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	b.mapping = mapping
+	b.dcqlMapping = dcqlMapping
 	return nil
 }
 
 // LoadFromFile loads the mapping from the given file
 func (b *LocalPDP) loadFromFile(filename string) error {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	if b.mapping == nil {
+		b.mapping = make(map[string]validatingWalletOwnerMapping)
+	}
+	if b.dcqlMapping == nil {
+		b.dcqlMapping = make(map[string]dcql.WalletOwnerMapping)
+	}
+	return readMappingFile(filename, b.mapping, b.dcqlMapping)
+}
+
+// readMappingFile reads the mapping from the given file and adds it to the given mappings, depending on whether a scope is expressed in Presentation Exchange or DCQL.
+// It returns an error if the file contains a scope that's already in one of the mappings.
+func readMappingFile(filename string, target map[string]validatingWalletOwnerMapping, dcqlTarget map[string]dcql.WalletOwnerMapping) error {
 	// read the bytes from the file
 	reader, err := os.Open(filename)
 	if err != nil {
@@ -132,23 +241,68 @@ func (b *LocalPDP) loadFromFile(filename string) error {
 	}
 
 	// unmarshal the bytes into the mapping
-	result := make(map[string]validatingWalletOwnerMapping)
+	result := make(map[string]json.RawMessage)
 	err = json.Unmarshal(bytes, &result)
 	if err != nil {
 		return fmt.Errorf("failed to unmarshal PEX Policy mapping file %s: %w", filename, err)
 	}
-	if b.mapping == nil {
-		b.mapping = make(map[string]validatingWalletOwnerMapping)
-	}
-	for scope, defs := range result {
-		if _, exists := b.mapping[scope]; exists {
+	for scope, data := range result {
+		definitions, queries, err := parseScopeMapping(data)
+		if err != nil {
+			return fmt.Errorf("failed to unmarshal PEX Policy mapping file %s: %w", filename, err)
+		}
+		_, exists := target[scope]
+		if _, dcqlExists := dcqlTarget[scope]; exists || dcqlExists {
 			return fmt.Errorf("mapping for scope '%s' already exists (file=%s)", scope, filename)
 		}
-		b.mapping[scope] = defs
+		if queries != nil {
+			dcqlTarget[scope] = queries
+		} else {
+			target[scope] = definitions
+		}
 	}
 	return nil
 }
 
+// parseScopeMapping parses the mapping of a single scope, which maps wallet owner types to either Presentation Definitions or DCQL queries.
+// DCQL queries are recognized by their 'credentials' property. A scope can't mix Presentation Definitions and DCQL queries.
+// It returns either the Presentation Definitions or the DCQL queries.
+func parseScopeMapping(data []byte) (validatingWalletOwnerMapping, dcql.WalletOwnerMapping, error) {
+	var entries map[pe.WalletOwnerType]json.RawMessage
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, nil, err
+	}
+	dcqlEntries := 0
+	for _, entry := range entries {
+		var properties map[string]json.RawMessage
+		if json.Unmarshal(entry, &properties) == nil && properties["credentials"] != nil {
+			dcqlEntries++
+		}
+	}
+	if dcqlEntries == 0 {
+		var definitions validatingWalletOwnerMapping
+		if err := json.Unmarshal(data, &definitions); err != nil {
+			return nil, nil, err
+		}
+		return definitions, nil, nil
+	}
+	if dcqlEntries != len(entries) {
+		return nil, nil, errors.New("scope mixes Presentation Definitions and DCQL queries")
+	}
+	queries := make(dcql.WalletOwnerMapping)
+	for walletOwnerType, entry := range entries {
+		if walletOwnerType != pe.WalletOwnerOrganization && walletOwnerType != pe.WalletOwnerUser {
+			return nil, nil, fmt.Errorf("invalid wallet owner type: %s", walletOwnerType)
+		}
+		query, err := dcql.Parse(entry)
+		if err != nil {
+			return nil, nil, err
+		}
+		queries[walletOwnerType] = *query
+	}
+	return nil, queries, nil
+}
+
 // validatingPresentationDefinition is an alias for PresentationDefinition that validates the JSON on unmarshal.
 type validatingWalletOwnerMapping pe.WalletOwnerMapping
 
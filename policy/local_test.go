@@ -20,8 +20,13 @@ package policy
 
 import (
 	"context"
+	"os"
+	"path"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/nuts-foundation/nuts-node/vcr/pe"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -52,6 +57,50 @@ func TestStore_LoadFromFile(t *testing.T) {
 
 		assert.ErrorContains(t, err, "missing properties: \"input_descriptors\"")
 	})
+
+	t.Run("loads DCQL queries from the file", func(t *testing.T) {
+		store := LocalPDP{}
+
+		err := store.loadFromFile("test/dcql/dcql_mapping.json")
+
+		require.NoError(t, err)
+		assert.Empty(t, store.mapping)
+		require.Len(t, store.dcqlMapping, 1)
+		assert.Len(t, store.dcqlMapping["dcql-scope"], 2)
+	})
+
+	t.Run("returns an error if a DCQL query is invalid", func(t *testing.T) {
+		store := LocalPDP{}
+		filename := path.Join(t.TempDir(), "invalid.json")
+		require.NoError(t, os.WriteFile(filename, []byte(`{"scope": {"organization": {"credentials": []}}}`), 0644))
+
+		err := store.loadFromFile(filename)
+
+		assert.ErrorContains(t, err, "invalid DCQL query: credentials must not be empty")
+	})
+
+	t.Run("returns an error if a scope mixes Presentation Definitions and DCQL queries", func(t *testing.T) {
+		store := LocalPDP{}
+		mappingFile, err := os.ReadFile("test/definition_mapping.json")
+		require.NoError(t, err)
+		mixed := strings.Replace(string(mappingFile), `"organization": {`, `"user": {"credentials": [{"id": "a", "format": "ldp_vc"}]}, "organization": {`, 1)
+		filename := path.Join(t.TempDir(), "mixed.json")
+		require.NoError(t, os.WriteFile(filename, []byte(mixed), 0644))
+
+		err = store.loadFromFile(filename)
+
+		assert.ErrorContains(t, err, "scope mixes Presentation Definitions and DCQL queries")
+	})
+
+	t.Run("returns an error if a DCQL scope has an invalid wallet owner type", func(t *testing.T) {
+		store := LocalPDP{}
+		filename := path.Join(t.TempDir(), "invalid.json")
+		require.NoError(t, os.WriteFile(filename, []byte(`{"scope": {"robot": {"credentials": [{"id": "a", "format": "ldp_vc"}]}}}`), 0644))
+
+		err := store.loadFromFile(filename)
+
+		assert.ErrorContains(t, err, "invalid wallet owner type: robot")
+	})
 }
 
 func TestStore_PresentationDefinitions(t *testing.T) {
@@ -73,6 +122,43 @@ func TestStore_PresentationDefinitions(t *testing.T) {
 		require.NoError(t, err)
 		assert.NotNil(t, result)
 	})
+
+	t.Run("err - scope is expressed in DCQL", func(t *testing.T) {
+		store := LocalPDP{}
+		err := store.loadFromFile("test/dcql/dcql_mapping.json")
+		require.NoError(t, err)
+
+		_, err = store.PresentationDefinitions(context.Background(), "dcql-scope")
+
+		assert.Equal(t, ErrNotFound, err)
+	})
+}
+
+func TestStore_DCQLQueries(t *testing.T) {
+	store := LocalPDP{}
+	require.NoError(t, store.loadFromFile("test/definition_mapping.json"))
+	require.NoError(t, store.loadFromFile("test/dcql/dcql_mapping.json"))
+
+	t.Run("returns the DCQL queries if the scope exists", func(t *testing.T) {
+		result, err := store.DCQLQueries(context.Background(), "dcql-scope")
+
+		require.NoError(t, err)
+		assert.Equal(t, "organization", result[pe.WalletOwnerOrganization].Credentials[0].ID)
+		assert.Equal(t, "employee", result[pe.WalletOwnerUser].Credentials[0].ID)
+	})
+	t.Run("err - not found", func(t *testing.T) {
+		_, err := store.DCQLQueries(context.Background(), "other-scope")
+
+		assert.Equal(t, ErrNotFound, err)
+	})
+	t.Run("err - scope is expressed in Presentation Exchange", func(t *testing.T) {
+		_, err := store.DCQLQueries(context.Background(), "example-scope")
+
+		assert.Equal(t, ErrNotFound, err)
+	})
+	t.Run("scopes include DCQL scopes", func(t *testing.T) {
+		assert.Equal(t, []string{"dcql-scope", "example-scope"}, store.Scopes())
+	})
 }
 
 func Test_LocalPDP_loadFromDirectory(t *testing.T) {
@@ -112,3 +198,49 @@ func Test_LocalPDP_loadFromDirectory(t *testing.T) {
 		require.EqualError(t, err, "mapping for scope '1' already exists (file=test/2_files_duplicate/2.json)")
 	})
 }
+
+func Test_LocalPDP_loadFromDirectory_keepsCurrentPolicyOnError(t *testing.T) {
+	store := LocalPDP{}
+	require.NoError(t, store.loadFromDirectory("test"))
+
+	err := store.loadFromDirectory("test/invalid")
+
+	assert.Error(t, err)
+	assert.Equal(t, []string{"example-scope"}, store.Scopes())
+}
+
+func Test_LocalPDP_watch(t *testing.T) {
+	directory := t.TempDir()
+	mappingFile, err := os.ReadFile("test/definition_mapping.json")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path.Join(directory, "1.json"), mappingFile, 0644))
+	store := LocalPDP{config: Config{Directory: directory}}
+	require.NoError(t, store.loadFromDirectory(directory))
+	require.NoError(t, store.watch())
+	t.Cleanup(func() {
+		_ = store.stopWatching()
+	})
+
+	t.Run("added file is loaded", func(t *testing.T) {
+		otherMapping := strings.ReplaceAll(string(mappingFile), "example-scope", "other-scope")
+		require.NoError(t, os.WriteFile(path.Join(directory, "2.json"), []byte(otherMapping), 0644))
+
+		assert.Eventually(t, func() bool {
+			return len(store.Scopes()) == 2
+		}, 5*time.Second, 50*time.Millisecond)
+	})
+	t.Run("invalid file is not loaded", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path.Join(directory, "3.json"), []byte("{"), 0644))
+		time.Sleep(2 * reloadDelay)
+
+		assert.Equal(t, []string{"example-scope", "other-scope"}, store.Scopes())
+	})
+	t.Run("removed file is unloaded", func(t *testing.T) {
+		require.NoError(t, os.Remove(path.Join(directory, "3.json")))
+		require.NoError(t, os.Remove(path.Join(directory, "2.json")))
+
+		assert.Eventually(t, func() bool {
+			return len(store.Scopes()) == 1
+		}, 5*time.Second, 50*time.Millisecond)
+	})
+}
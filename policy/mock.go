@@ -13,6 +13,7 @@ import (
 	context "context"
 	reflect "reflect"
 
+	dcql "github.com/nuts-foundation/nuts-node/vcr/dcql"
 	pe "github.com/nuts-foundation/nuts-node/vcr/pe"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -41,6 +42,21 @@ func (m *MockPDPBackend) EXPECT() *MockPDPBackendMockRecorder {
 	return m.recorder
 }
 
+// DCQLQueries mocks base method.
+func (m *MockPDPBackend) DCQLQueries(ctx context.Context, scope string) (dcql.WalletOwnerMapping, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DCQLQueries", ctx, scope)
+	ret0, _ := ret[0].(dcql.WalletOwnerMapping)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DCQLQueries indicates an expected call of DCQLQueries.
+func (mr *MockPDPBackendMockRecorder) DCQLQueries(ctx, scope any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DCQLQueries", reflect.TypeOf((*MockPDPBackend)(nil).DCQLQueries), ctx, scope)
+}
+
 // PresentationDefinitions mocks base method.
 func (m *MockPDPBackend) PresentationDefinitions(ctx context.Context, scope string) (pe.WalletOwnerMapping, error) {
 	m.ctrl.T.Helper()
@@ -55,3 +71,71 @@ func (mr *MockPDPBackendMockRecorder) PresentationDefinitions(ctx, scope any) *g
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PresentationDefinitions", reflect.TypeOf((*MockPDPBackend)(nil).PresentationDefinitions), ctx, scope)
 }
+
+// MockLocalPolicy is a mock of LocalPolicy interface.
+type MockLocalPolicy struct {
+	ctrl     *gomock.Controller
+	recorder *MockLocalPolicyMockRecorder
+	isgomock struct{}
+}
+
+// MockLocalPolicyMockRecorder is the mock recorder for MockLocalPolicy.
+type MockLocalPolicyMockRecorder struct {
+	mock *MockLocalPolicy
+}
+
+// NewMockLocalPolicy creates a new mock instance.
+func NewMockLocalPolicy(ctrl *gomock.Controller) *MockLocalPolicy {
+	mock := &MockLocalPolicy{ctrl: ctrl}
+	mock.recorder = &MockLocalPolicyMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLocalPolicy) EXPECT() *MockLocalPolicyMockRecorder {
+	return m.recorder
+}
+
+// LocalDCQLQueries mocks base method.
+func (m *MockLocalPolicy) LocalDCQLQueries(scope string) (dcql.WalletOwnerMapping, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LocalDCQLQueries", scope)
+	ret0, _ := ret[0].(dcql.WalletOwnerMapping)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LocalDCQLQueries indicates an expected call of LocalDCQLQueries.
+func (mr *MockLocalPolicyMockRecorder) LocalDCQLQueries(scope any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LocalDCQLQueries", reflect.TypeOf((*MockLocalPolicy)(nil).LocalDCQLQueries), scope)
+}
+
+// LocalPresentationDefinitions mocks base method.
+func (m *MockLocalPolicy) LocalPresentationDefinitions(scope string) (pe.WalletOwnerMapping, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LocalPresentationDefinitions", scope)
+	ret0, _ := ret[0].(pe.WalletOwnerMapping)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LocalPresentationDefinitions indicates an expected call of LocalPresentationDefinitions.
+func (mr *MockLocalPolicyMockRecorder) LocalPresentationDefinitions(scope any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LocalPresentationDefinitions", reflect.TypeOf((*MockLocalPolicy)(nil).LocalPresentationDefinitions), scope)
+}
+
+// Scopes mocks base method.
+func (m *MockLocalPolicy) Scopes() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Scopes")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// Scopes indicates an expected call of Scopes.
+func (mr *MockLocalPolicyMockRecorder) Scopes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scopes", reflect.TypeOf((*MockLocalPolicy)(nil).Scopes))
+}
@@ -0,0 +1,115 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/vcr/dcql"
+	"github.com/nuts-foundation/nuts-node/vcr/pe"
+)
+
+var _ PDPBackend = (*Module)(nil)
+var _ LocalPolicy = (*Module)(nil)
+var _ core.Configurable = (*Module)(nil)
+var _ core.Runnable = (*Module)(nil)
+
+// New creates a new policy module.
+func New() *Module {
+	return &Module{}
+}
+
+// Module is the policy engine. It resolves scopes using the local policy files,
+// or delegates to a remote Policy Decision Point if one is configured.
+type Module struct {
+	config Config
+	local  LocalPDP
+	remote *RemotePDP
+}
+
+func (m *Module) Name() string {
+	return ModuleName
+}
+
+func (m *Module) Config() interface{} {
+	return &m.config
+}
+
+func (m *Module) Configure(serverConfig core.ServerConfig) error {
+	m.local.config = m.config
+	if err := m.local.Configure(serverConfig); err != nil {
+		return err
+	}
+	if m.config.Remote.URL == "" {
+		return nil
+	}
+	endpoint, err := url.Parse(m.config.Remote.URL)
+	if err != nil || !endpoint.IsAbs() || endpoint.Host == "" {
+		return fmt.Errorf("invalid policy.remote.url: %s", m.config.Remote.URL)
+	}
+	if serverConfig.Strictmode && endpoint.Scheme != "https" {
+		return errors.New("policy.remote.url must use https in strictmode")
+	}
+	m.remote = NewRemotePDP(m.config.Remote, &m.local)
+	return nil
+}
+
+// Start starts watching the policy directory, so changes to policy files are applied without restarting the node.
+func (m *Module) Start() error {
+	if m.local.config.Directory == "" {
+		return nil
+	}
+	return m.local.watch()
+}
+
+func (m *Module) Shutdown() error {
+	return m.local.stopWatching()
+}
+
+// PresentationDefinitions returns the PresentationDefinitions for the given scope from the remote PDP if configured, otherwise from the local policy files.
+func (m *Module) PresentationDefinitions(ctx context.Context, scope string) (pe.WalletOwnerMapping, error) {
+	if m.remote != nil {
+		return m.remote.PresentationDefinitions(ctx, scope)
+	}
+	return m.local.PresentationDefinitions(ctx, scope)
+}
+
+// DCQLQueries returns the DCQL queries for the given scope from the remote PDP if configured, otherwise from the local policy files.
+func (m *Module) DCQLQueries(ctx context.Context, scope string) (dcql.WalletOwnerMapping, error) {
+	if m.remote != nil {
+		return m.remote.DCQLQueries(ctx, scope)
+	}
+	return m.local.DCQLQueries(ctx, scope)
+}
+
+func (m *Module) Scopes() []string {
+	return m.local.Scopes()
+}
+
+func (m *Module) LocalPresentationDefinitions(scope string) (pe.WalletOwnerMapping, error) {
+	return m.local.PresentationDefinitions(context.Background(), scope)
+}
+
+func (m *Module) LocalDCQLQueries(scope string) (dcql.WalletOwnerMapping, error) {
+	return m.local.DCQLQueries(context.Background(), scope)
+}
@@ -0,0 +1,133 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package policy
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModule_LocalPolicy(t *testing.T) {
+	module := New()
+	module.config = Config{Directory: "test/dcql"}
+	require.NoError(t, module.Configure(core.ServerConfig{}))
+
+	t.Run("DCQL-only scope", func(t *testing.T) {
+		assert.Contains(t, module.Scopes(), "dcql-scope")
+		queries, err := module.LocalDCQLQueries("dcql-scope")
+		require.NoError(t, err)
+		assert.Len(t, queries, 2)
+		_, err = module.LocalPresentationDefinitions("dcql-scope")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+	t.Run("unknown scope", func(t *testing.T) {
+		_, err := module.LocalDCQLQueries("unknown")
+		assert.ErrorIs(t, err, ErrNotFound)
+	})
+}
+
+func TestModule_Configure(t *testing.T) {
+	t.Run("local", func(t *testing.T) {
+		module := New()
+		module.config = Config{Directory: "test"}
+
+		err := module.Configure(core.ServerConfig{})
+
+		require.NoError(t, err)
+		assert.Nil(t, module.remote)
+		_, err = module.PresentationDefinitions(context.Background(), "example-scope")
+		assert.NoError(t, err)
+	})
+	t.Run("remote", func(t *testing.T) {
+		server, requests := newTestPDPServer(t, http.StatusOK)
+		module := New()
+		module.config = defaultConfig()
+		module.config.Remote.URL = server.URL
+
+		err := module.Configure(core.ServerConfig{})
+
+		require.NoError(t, err)
+		require.NotNil(t, module.remote)
+		_, err = module.PresentationDefinitions(context.Background(), "example-scope")
+		assert.NoError(t, err)
+		assert.Len(t, *requests, 1)
+	})
+	t.Run("remote - invalid URL", func(t *testing.T) {
+		module := New()
+		module.config.Remote.URL = "not-a-url"
+
+		err := module.Configure(core.ServerConfig{})
+
+		assert.EqualError(t, err, "invalid policy.remote.url: not-a-url")
+	})
+	t.Run("remote - http in strictmode", func(t *testing.T) {
+		module := New()
+		module.config.Remote.URL = "http://example.com/pdp"
+
+		err := module.Configure(core.ServerConfig{Strictmode: true})
+
+		assert.EqualError(t, err, "policy.remote.url must use https in strictmode")
+	})
+	t.Run("error - invalid policy directory", func(t *testing.T) {
+		module := New()
+		module.config.Directory = "does-not-exist"
+
+		err := module.Configure(core.ServerConfig{})
+
+		assert.ErrorContains(t, err, "failed to load policy from directory")
+	})
+}
+
+func TestModule_Start(t *testing.T) {
+	t.Run("watches policy directory", func(t *testing.T) {
+		module := New()
+		module.config.Directory = t.TempDir()
+		require.NoError(t, module.Configure(core.ServerConfig{}))
+
+		require.NoError(t, module.Start())
+
+		assert.NotNil(t, module.local.watcher)
+		assert.NoError(t, module.Shutdown())
+	})
+	t.Run("no policy directory", func(t *testing.T) {
+		module := New()
+		require.NoError(t, module.Configure(core.ServerConfig{}))
+
+		require.NoError(t, module.Start())
+
+		assert.Nil(t, module.local.watcher)
+		assert.NoError(t, module.Shutdown())
+	})
+}
+
+func TestContext(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		ctx := Context(context.Background(), "client", "subject")
+
+		assert.Equal(t, RequestInfo{ClientID: "client", Subject: "subject"}, RequestInfoFromContext(ctx))
+	})
+	t.Run("not set", func(t *testing.T) {
+		assert.Empty(t, RequestInfoFromContext(context.Background()))
+	})
+}
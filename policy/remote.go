@@ -0,0 +1,222 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package policy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/http/client"
+	"github.com/nuts-foundation/nuts-node/policy/log"
+	"github.com/nuts-foundation/nuts-node/vcr/dcql"
+	"github.com/nuts-foundation/nuts-node/vcr/pe"
+)
+
+var _ PDPBackend = (*RemotePDP)(nil)
+
+// errPDPUnavailable is returned when the remote PDP could not be reached or returned an unexpected response.
+var errPDPUnavailable = errors.New("remote policy decision point unavailable")
+
+// remotePDPRequest is the request body sent to the remote PDP.
+type remotePDPRequest struct {
+	RequestInfo
+	Scope string `json:"scope"`
+}
+
+// remotePDPCacheKey identifies a cached response of the remote PDP.
+type remotePDPCacheKey struct {
+	clientID string
+	subject  string
+	scope    string
+}
+
+// remotePDPCacheEntry holds a cached response of the remote PDP.
+// Depending on the language the scope is expressed in, either mapping or queries is set.
+// If both are nil, the PDP responded with 'not found'.
+type remotePDPCacheEntry struct {
+	mapping pe.WalletOwnerMapping
+	queries dcql.WalletOwnerMapping
+	expires time.Time
+}
+
+// RemotePDP is a backend for presentation definitions that delegates the scope lookup to an external Policy Decision Point.
+// It POSTs the requesting client, subject and scope to the configured URL, which responds with the WalletOwnerMapping for the scope
+// (containing either Presentation Definitions or DCQL queries), or 404 if the scope isn't supported.
+type RemotePDP struct {
+	endpoint   string
+	client     core.HTTPRequestDoer
+	cacheTTL   time.Duration
+	failClosed bool
+	// fallback is used when the remote PDP is unavailable and failClosed is false
+	fallback PDPBackend
+	cache    map[remotePDPCacheKey]remotePDPCacheEntry
+	mux      sync.Mutex
+}
+
+// NewRemotePDP creates a new RemotePDP for the given configuration.
+// The fallback backend is consulted when the remote PDP can't be reached and the configuration isn't fail-closed.
+func NewRemotePDP(config RemoteConfig, fallback PDPBackend) *RemotePDP {
+	return &RemotePDP{
+		endpoint:   config.URL,
+		client:     client.New(config.Timeout),
+		cacheTTL:   config.CacheTTL,
+		failClosed: config.FailClosed,
+		fallback:   fallback,
+		cache:      make(map[remotePDPCacheKey]remotePDPCacheEntry),
+	}
+}
+
+func (r *RemotePDP) PresentationDefinitions(ctx context.Context, scope string) (pe.WalletOwnerMapping, error) {
+	entry, err := r.lookup(ctx, scope)
+	if errors.Is(err, errPDPUnavailable) && !r.failClosed {
+		log.Logger().WithError(err).Warnf("Remote PDP unavailable, falling back to local policy (scope=%s)", scope)
+		return r.fallback.PresentationDefinitions(ctx, scope)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toResult(entry.mapping)
+}
+
+func (r *RemotePDP) DCQLQueries(ctx context.Context, scope string) (dcql.WalletOwnerMapping, error) {
+	entry, err := r.lookup(ctx, scope)
+	if errors.Is(err, errPDPUnavailable) && !r.failClosed {
+		log.Logger().WithError(err).Warnf("Remote PDP unavailable, falling back to local policy (scope=%s)", scope)
+		return r.fallback.DCQLQueries(ctx, scope)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if entry.queries == nil {
+		return nil, ErrNotFound
+	}
+	result := dcql.WalletOwnerMapping{}
+	for walletOwnerType, query := range entry.queries {
+		result[walletOwnerType] = query
+	}
+	return result, nil
+}
+
+// lookup returns the (cached) response of the remote PDP for the given scope.
+// A 'not found' response is returned as entry without mapping and queries.
+func (r *RemotePDP) lookup(ctx context.Context, scope string) (remotePDPCacheEntry, error) {
+	request := remotePDPRequest{
+		RequestInfo: RequestInfoFromContext(ctx),
+		Scope:       scope,
+	}
+	cacheKey := remotePDPCacheKey{clientID: request.ClientID, subject: request.Subject, scope: request.Scope}
+	if entry, ok := r.getCached(cacheKey); ok {
+		return entry, nil
+	}
+	entry, err := r.request(ctx, request)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return remotePDPCacheEntry{}, err
+	}
+	r.putCached(cacheKey, entry)
+	return entry, nil
+}
+
+// request performs the HTTP request to the remote PDP.
+// It returns ErrNotFound if the PDP doesn't know the scope, and wraps errPDPUnavailable if the PDP couldn't be reached or returned an unexpected response.
+func (r *RemotePDP) request(ctx context.Context, request remotePDPRequest) (remotePDPCacheEntry, error) {
+	requestBody, _ := json.Marshal(request)
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, r.endpoint, bytes.NewReader(requestBody))
+	if err != nil {
+		return remotePDPCacheEntry{}, err
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpRequest.Header.Set("Accept", "application/json")
+	httpResponse, err := r.client.Do(httpRequest)
+	if err != nil {
+		return remotePDPCacheEntry{}, fmt.Errorf("%w: %w", errPDPUnavailable, err)
+	}
+	defer httpResponse.Body.Close()
+	if httpResponse.StatusCode == http.StatusNotFound {
+		return remotePDPCacheEntry{}, ErrNotFound
+	}
+	if err = core.TestResponseCodeWithLog(http.StatusOK, httpResponse, log.Logger()); err != nil {
+		return remotePDPCacheEntry{}, fmt.Errorf("%w: %w", errPDPUnavailable, err)
+	}
+	responseBody, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return remotePDPCacheEntry{}, fmt.Errorf("%w: %w", errPDPUnavailable, err)
+	}
+	mapping, queries, err := parseScopeMapping(responseBody)
+	if err != nil {
+		return remotePDPCacheEntry{}, fmt.Errorf("%w: invalid response: %w", errPDPUnavailable, err)
+	}
+	if queries != nil {
+		return remotePDPCacheEntry{queries: queries}, nil
+	}
+	return remotePDPCacheEntry{mapping: pe.WalletOwnerMapping(mapping)}, nil
+}
+
+func (r *RemotePDP) getCached(key remotePDPCacheKey) (remotePDPCacheEntry, bool) {
+	if r.cacheTTL <= 0 {
+		return remotePDPCacheEntry{}, false
+	}
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	entry, ok := r.cache[key]
+	if !ok {
+		return remotePDPCacheEntry{}, false
+	}
+	if time.Now().After(entry.expires) {
+		delete(r.cache, key)
+		return remotePDPCacheEntry{}, false
+	}
+	return entry, true
+}
+
+func (r *RemotePDP) putCached(key remotePDPCacheKey, entry remotePDPCacheEntry) {
+	if r.cacheTTL <= 0 {
+		return
+	}
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	now := time.Now()
+	// prune expired entries to keep the cache from growing indefinitely
+	for k, curr := range r.cache {
+		if now.After(curr.expires) {
+			delete(r.cache, k)
+		}
+	}
+	entry.expires = now.Add(r.cacheTTL)
+	r.cache[key] = entry
+}
+
+// toResult returns a copy of the given mapping, so callers can't alter cached entries, or ErrNotFound if it's nil.
+func toResult(mapping pe.WalletOwnerMapping) (pe.WalletOwnerMapping, error) {
+	if mapping == nil {
+		return nil, ErrNotFound
+	}
+	result := pe.WalletOwnerMapping{}
+	for walletOwnerType, definition := range mapping {
+		result[walletOwnerType] = definition
+	}
+	return result, nil
+}
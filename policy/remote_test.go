@@ -0,0 +1,242 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/nuts-foundation/nuts-node/vcr/dcql"
+	"github.com/nuts-foundation/nuts-node/vcr/pe"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRemotePDP_PresentationDefinitions(t *testing.T) {
+	ctx := Context(context.Background(), "https://example.com/client", "subject")
+	t.Run("ok", func(t *testing.T) {
+		server, requests := newTestPDPServer(t, http.StatusOK)
+		pdp := NewRemotePDP(RemoteConfig{URL: server.URL, Timeout: time.Second, FailClosed: true}, nil)
+
+		result, err := pdp.PresentationDefinitions(ctx, "example-scope")
+
+		require.NoError(t, err)
+		assert.Contains(t, result, pe.WalletOwnerOrganization)
+		require.Len(t, *requests, 1)
+		assert.Equal(t, remotePDPRequest{
+			RequestInfo: RequestInfo{ClientID: "https://example.com/client", Subject: "subject"},
+			Scope:       "example-scope",
+		}, (*requests)[0])
+	})
+	t.Run("not found", func(t *testing.T) {
+		server, _ := newTestPDPServer(t, http.StatusNotFound)
+		pdp := NewRemotePDP(RemoteConfig{URL: server.URL, Timeout: time.Second, FailClosed: true}, nil)
+
+		result, err := pdp.PresentationDefinitions(ctx, "example-scope")
+
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.Nil(t, result)
+	})
+	t.Run("responses are cached", func(t *testing.T) {
+		server, requests := newTestPDPServer(t, http.StatusOK)
+		pdp := NewRemotePDP(RemoteConfig{URL: server.URL, Timeout: time.Second, CacheTTL: time.Minute, FailClosed: true}, nil)
+
+		_, err := pdp.PresentationDefinitions(ctx, "example-scope")
+		require.NoError(t, err)
+		_, err = pdp.PresentationDefinitions(ctx, "example-scope")
+		require.NoError(t, err)
+		// different client: not cached
+		_, err = pdp.PresentationDefinitions(Context(context.Background(), "other", "subject"), "example-scope")
+		require.NoError(t, err)
+
+		assert.Len(t, *requests, 2)
+	})
+	t.Run("cache keys containing spaces don't collide", func(t *testing.T) {
+		server, requests := newTestPDPServer(t, http.StatusOK)
+		pdp := NewRemotePDP(RemoteConfig{URL: server.URL, Timeout: time.Second, CacheTTL: time.Minute, FailClosed: true}, nil)
+
+		_, err := pdp.PresentationDefinitions(Context(context.Background(), "client subject", "x"), "example-scope")
+		require.NoError(t, err)
+		_, err = pdp.PresentationDefinitions(Context(context.Background(), "client", "subject x"), "example-scope")
+		require.NoError(t, err)
+
+		assert.Len(t, *requests, 2)
+	})
+	t.Run("not found responses are cached", func(t *testing.T) {
+		server, requests := newTestPDPServer(t, http.StatusNotFound)
+		pdp := NewRemotePDP(RemoteConfig{URL: server.URL, Timeout: time.Second, CacheTTL: time.Minute, FailClosed: true}, nil)
+
+		_, err := pdp.PresentationDefinitions(ctx, "example-scope")
+		require.ErrorIs(t, err, ErrNotFound)
+		_, err = pdp.PresentationDefinitions(ctx, "example-scope")
+		require.ErrorIs(t, err, ErrNotFound)
+
+		assert.Len(t, *requests, 1)
+	})
+	t.Run("cached entries expire", func(t *testing.T) {
+		server, requests := newTestPDPServer(t, http.StatusOK)
+		pdp := NewRemotePDP(RemoteConfig{URL: server.URL, Timeout: time.Second, CacheTTL: time.Minute, FailClosed: true}, nil)
+
+		_, err := pdp.PresentationDefinitions(ctx, "example-scope")
+		require.NoError(t, err)
+		for key, entry := range pdp.cache {
+			entry.expires = time.Now().Add(-time.Second)
+			pdp.cache[key] = entry
+		}
+		_, err = pdp.PresentationDefinitions(ctx, "example-scope")
+		require.NoError(t, err)
+
+		assert.Len(t, *requests, 2)
+	})
+	t.Run("fail-closed", func(t *testing.T) {
+		t.Run("server error", func(t *testing.T) {
+			server, _ := newTestPDPServer(t, http.StatusInternalServerError)
+			pdp := NewRemotePDP(RemoteConfig{URL: server.URL, Timeout: time.Second, FailClosed: true}, nil)
+
+			result, err := pdp.PresentationDefinitions(ctx, "example-scope")
+
+			assert.ErrorIs(t, err, errPDPUnavailable)
+			assert.ErrorContains(t, err, "server returned HTTP 500 (expected: 200)")
+			assert.Nil(t, result)
+		})
+		t.Run("timeout", func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				time.Sleep(100 * time.Millisecond)
+			}))
+			t.Cleanup(server.Close)
+			pdp := NewRemotePDP(RemoteConfig{URL: server.URL, Timeout: 10 * time.Millisecond, FailClosed: true}, nil)
+
+			_, err := pdp.PresentationDefinitions(ctx, "example-scope")
+
+			assert.ErrorIs(t, err, errPDPUnavailable)
+		})
+		t.Run("invalid response", func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				_, _ = writer.Write([]byte(`{"organization": {}}`))
+			}))
+			t.Cleanup(server.Close)
+			pdp := NewRemotePDP(RemoteConfig{URL: server.URL, Timeout: time.Second, FailClosed: true}, nil)
+
+			_, err := pdp.PresentationDefinitions(ctx, "example-scope")
+
+			assert.ErrorIs(t, err, errPDPUnavailable)
+			assert.ErrorContains(t, err, "invalid response")
+		})
+	})
+	t.Run("fail-open falls back", func(t *testing.T) {
+		server, _ := newTestPDPServer(t, http.StatusServiceUnavailable)
+		ctrl := gomock.NewController(t)
+		fallback := NewMockPDPBackend(ctrl)
+		fallback.EXPECT().PresentationDefinitions(ctx, "example-scope").Return(pe.WalletOwnerMapping{}, nil)
+		pdp := NewRemotePDP(RemoteConfig{URL: server.URL, Timeout: time.Second, CacheTTL: time.Minute, FailClosed: false}, fallback)
+
+		result, err := pdp.PresentationDefinitions(ctx, "example-scope")
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		assert.Empty(t, pdp.cache)
+	})
+}
+
+func TestRemotePDP_DCQLQueries(t *testing.T) {
+	ctx := Context(context.Background(), "https://example.com/client", "subject")
+	t.Run("ok", func(t *testing.T) {
+		server, _ := newTestPDPServer(t, http.StatusOK)
+		pdp := NewRemotePDP(RemoteConfig{URL: server.URL, Timeout: time.Second, FailClosed: true}, nil)
+
+		result, err := pdp.DCQLQueries(ctx, "dcql-scope")
+
+		require.NoError(t, err)
+		assert.Contains(t, result, pe.WalletOwnerOrganization)
+		assert.Contains(t, result, pe.WalletOwnerUser)
+	})
+	t.Run("scope is expressed in Presentation Exchange", func(t *testing.T) {
+		server, _ := newTestPDPServer(t, http.StatusOK)
+		pdp := NewRemotePDP(RemoteConfig{URL: server.URL, Timeout: time.Second, FailClosed: true}, nil)
+
+		result, err := pdp.DCQLQueries(ctx, "example-scope")
+
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.Nil(t, result)
+	})
+	t.Run("PresentationDefinitions for a DCQL scope", func(t *testing.T) {
+		server, _ := newTestPDPServer(t, http.StatusOK)
+		pdp := NewRemotePDP(RemoteConfig{URL: server.URL, Timeout: time.Second, FailClosed: true}, nil)
+
+		result, err := pdp.PresentationDefinitions(ctx, "dcql-scope")
+
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.Nil(t, result)
+	})
+	t.Run("responses are cached for both languages", func(t *testing.T) {
+		server, requests := newTestPDPServer(t, http.StatusOK)
+		pdp := NewRemotePDP(RemoteConfig{URL: server.URL, Timeout: time.Second, CacheTTL: time.Minute, FailClosed: true}, nil)
+
+		_, err := pdp.DCQLQueries(ctx, "dcql-scope")
+		require.NoError(t, err)
+		_, err = pdp.PresentationDefinitions(ctx, "dcql-scope")
+		require.ErrorIs(t, err, ErrNotFound)
+
+		assert.Len(t, *requests, 1)
+	})
+	t.Run("fail-open falls back", func(t *testing.T) {
+		server, _ := newTestPDPServer(t, http.StatusServiceUnavailable)
+		ctrl := gomock.NewController(t)
+		fallback := NewMockPDPBackend(ctrl)
+		fallback.EXPECT().DCQLQueries(ctx, "dcql-scope").Return(dcql.WalletOwnerMapping{}, nil)
+		pdp := NewRemotePDP(RemoteConfig{URL: server.URL, Timeout: time.Second, FailClosed: false}, fallback)
+
+		result, err := pdp.DCQLQueries(ctx, "dcql-scope")
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+	})
+}
+
+// newTestPDPServer starts a test PDP that responds with the given status code, and the test mappings if the status is 200 OK.
+// It returns the server and the requests it received.
+func newTestPDPServer(t *testing.T, statusCode int) (*httptest.Server, *[]remotePDPRequest) {
+	mappings := make(map[string]json.RawMessage)
+	for _, filename := range []string{"test/definition_mapping.json", "test/dcql/dcql_mapping.json"} {
+		mappingFile, err := os.ReadFile(filename)
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(mappingFile, &mappings))
+	}
+	var requests []remotePDPRequest
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		var body remotePDPRequest
+		if request.Method != http.MethodPost || json.NewDecoder(request.Body).Decode(&body) != nil {
+			writer.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		requests = append(requests, body)
+		writer.WriteHeader(statusCode)
+		if statusCode == http.StatusOK {
+			_, _ = writer.Write(mappings[body.Scope])
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
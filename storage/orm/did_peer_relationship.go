@@ -0,0 +1,35 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package orm
+
+import "gorm.io/gorm/schema"
+
+var _ schema.Tabler = (*DIDPeerRelationship)(nil)
+
+// DIDPeerRelationship is the gorm representation of the did_peer_relationship table.
+// It binds a did:peer DID to the party (relationship) it was created for.
+type DIDPeerRelationship struct {
+	DID string `gorm:"primaryKey;column:did"`
+	// Relationship identifies the party the DID is used with, i.e. the audience of presentations made with it.
+	Relationship string
+}
+
+func (d DIDPeerRelationship) TableName() string {
+	return "did_peer_relationship"
+}
@@ -0,0 +1,41 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package orm
+
+import "gorm.io/gorm/schema"
+
+var _ schema.Tabler = (*DIDResolutionCacheEntry)(nil)
+
+// DIDResolutionCacheEntry is the gorm representation of the did_resolution_cache table.
+// It contains a DID document that was resolved from a remote source, along with its metadata.
+type DIDResolutionCacheEntry struct {
+	DID string `gorm:"primaryKey;column:did"`
+	// Document contains the DID document in JSON form.
+	Document string
+	// Metadata contains the DID document metadata in JSON form.
+	Metadata string
+	// ExpiresAt is the time (seconds since UNIX epoch) after which the cached DID document is stale.
+	ExpiresAt int64
+	// StaleUntil is the time (seconds since UNIX epoch) until which the stale DID document may be used if resolving it fails.
+	StaleUntil int64
+}
+
+func (d DIDResolutionCacheEntry) TableName() string {
+	return "did_resolution_cache"
+}
@@ -0,0 +1,33 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package orm
+
+import "gorm.io/gorm/schema"
+
+var _ schema.Tabler = (*KeyClaim)(nil)
+
+// KeyClaim is the gorm representation of the key_claim table.
+// It records that a provisioned key (see spi.KeyClaimer) has been claimed, so it isn't handed out again.
+type KeyClaim struct {
+	KeyName string `gorm:"primaryKey;column:key_name"`
+}
+
+func (k KeyClaim) TableName() string {
+	return "key_claim"
+}
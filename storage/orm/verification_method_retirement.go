@@ -0,0 +1,36 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package orm
+
+import "gorm.io/gorm/schema"
+
+var _ schema.Tabler = (*VerificationMethodRetirement)(nil)
+
+// VerificationMethodRetirement is the gorm representation of the did_verification_method_retirement table.
+// It records a verification method that has been replaced by a key rotation and is to be removed at RetireAt.
+type VerificationMethodRetirement struct {
+	VerificationMethodID string `gorm:"primaryKey"`
+	DID                  string `gorm:"column:did"`
+	// RetireAt is the time (seconds since UNIX epoch) after which the verification method is removed.
+	RetireAt int64
+}
+
+func (v VerificationMethodRetirement) TableName() string {
+	return "did_verification_method_retirement"
+}
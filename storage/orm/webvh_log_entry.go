@@ -0,0 +1,40 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package orm
+
+import "gorm.io/gorm/schema"
+
+var _ schema.Tabler = (*WebVHLogEntry)(nil)
+
+// WebVHLogEntry is the gorm representation of the did_webvh_log_entry table.
+// It contains an entry of the DID log of a did:webvh DID managed by this node.
+type WebVHLogEntry struct {
+	DID           string `gorm:"primaryKey;column:did"`
+	VersionNumber int    `gorm:"primaryKey"`
+	// DIDDocumentVersionID refers to the DID document version the entry was created for. It's empty for the first entry.
+	DIDDocumentVersionID string `gorm:"column:did_document_version_id"`
+	// Entry contains the log entry as it appears in the DID log.
+	Entry string
+	// NextUpdateKey contains the key ID of the pre-rotated update key, which signs the next log entry.
+	NextUpdateKey string
+}
+
+func (w WebVHLogEntry) TableName() string {
+	return "did_webvh_log_entry"
+}
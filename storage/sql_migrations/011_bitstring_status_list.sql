@@ -0,0 +1,40 @@
+-- +goose Up
+-- bitstring_status_list: keeps track of all BitstringStatusListCredentials issued by an issuer, per status purpose, and the highest status list index issued for each credential.
+-- The issued BitstringStatusListCredentials themselves are stored in status_list_credential.
+create table bitstring_status_list
+(
+    -- subject_id: VC.credentialSubject.ID; URL where this status list credential can be downloaded.
+    subject_id          varchar(500)    not null    primary key,
+    -- issuer: the DID of the issuer of this statusListCredential.
+    issuer              varchar(370)    not null,
+    -- status_purpose: the statusPurpose of all entries of this statusListCredential (revocation or suspension).
+    status_purpose      varchar(25)     not null,
+    -- page: the n-th BitstringStatusListCredential issued by this issuer for the status_purpose.
+    page                integer         not null,
+    -- last_issued_index: the highest status_list_index issued for this page. Should not be incremented above the max statusListIndex.
+    last_issued_index   integer         not null,
+    -- Ties status list credentials to DID management.
+    constraint fk_bitstring_status_list_issuer_did foreign key (issuer) references did (id) on delete cascade
+);
+
+-- bitstring_status_list_entry: lists all status list entries for which the status bit is set to true.
+-- Entries are removed when the status is cleared (e.g., a suspension is lifted).
+create table bitstring_status_list_entry
+(
+    -- status_list_credential: URL where the status list credential can be resolved; as referenced in the status list entry.
+    status_list_credential  varchar(500)    not null,
+    -- status_list_index: index in the status list bitstring; as referenced in the status list entry.
+    status_list_index       integer         not null,
+    -- credential_id: id of the credential that lists this status.
+    credential_id           varchar(415)    not null,
+    -- created_at: timestamp the status was set. Measured in seconds since UNIX epoch.
+    created_at              integer         not null,
+    -- bitstring_status_list_entry_id: unique identifier of a status list entry
+    constraint bitstring_status_list_entry_id primary key (status_list_credential, status_list_index),
+    -- Ties the status_list_credential to an issuer (did) via the bitstring_status_list table
+    constraint fk_bitstring_status_list_credential foreign key (status_list_credential) references bitstring_status_list (subject_id) on delete cascade
+);
+
+-- +goose Down
+drop table bitstring_status_list_entry;
+drop table bitstring_status_list;
@@ -0,0 +1,18 @@
+-- +goose Up
+-- did_verification_method_retirement: keeps track of verification methods that have been replaced by a key rotation.
+-- After retire_at, the verification method is removed from the DID document and its private key is deleted.
+create table did_verification_method_retirement
+(
+    -- verification_method_id: id of the verification method that is to be retired, matches did_verification_method.id and key_reference.kid.
+    verification_method_id  varchar(415)    not null    primary key,
+    -- did: the DID the verification method belongs to.
+    did                     varchar(370)    not null,
+    -- retire_at: timestamp after which the verification method is removed. Measured in seconds since UNIX epoch.
+    retire_at               integer         not null,
+    constraint fk_did_verification_method_retirement_did foreign key (did) references did (id) on delete cascade
+);
+
+create index idx_did_verification_method_retirement_retire_at on did_verification_method_retirement (retire_at);
+
+-- +goose Down
+drop table did_verification_method_retirement;
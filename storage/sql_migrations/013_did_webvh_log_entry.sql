@@ -0,0 +1,23 @@
+-- +goose ENVSUB ON
+-- +goose Up
+-- did_webvh_log_entry: contains the entries of the DID logs (did.jsonl) of did:webvh DIDs managed by this node.
+-- There's no foreign key to the did table, since the first entry determines the DID (its SCID) and is stored before the DID itself.
+create table did_webvh_log_entry
+(
+    -- did: the did:webvh DID the log entry belongs to.
+    did                     varchar(370)    not null,
+    -- version_number: the version number of the log entry (first part of its versionId), starting at 1.
+    version_number          integer         not null,
+    -- did_document_version_id: the DID document version the log entry was created for, empty for the first entry.
+    did_document_version_id varchar(36),
+    -- entry: the log entry as it appears in the DID log, in canonical JSON form.
+    entry                   $TEXT_TYPE      not null,
+    -- next_update_key: the key ID of the pre-rotated update key, which is used to sign the next log entry.
+    next_update_key         varchar(415),
+    primary key (did, version_number)
+);
+
+create index idx_did_webvh_log_entry_document_version on did_webvh_log_entry (did_document_version_id);
+
+-- +goose Down
+drop table did_webvh_log_entry;
@@ -0,0 +1,22 @@
+-- +goose ENVSUB ON
+-- +goose Up
+-- did_resolution_cache: caches DID documents that were resolved from remote sources (e.g. did:web), so they survive restarts,
+-- and can still be used (when stale) if the remote source is temporarily unavailable.
+create table did_resolution_cache
+(
+    -- did: the resolved DID.
+    did         varchar(370)    not null    primary key,
+    -- document: the resolved DID document in JSON form.
+    document    $TEXT_TYPE      not null,
+    -- metadata: the DID document metadata in JSON form.
+    metadata    $TEXT_TYPE      not null,
+    -- expires_at: timestamp after which the cached DID document is stale and must be resolved again. Measured in seconds since UNIX epoch.
+    expires_at  integer         not null,
+    -- stale_until: timestamp until which the stale DID document may be used if resolving it fails. Measured in seconds since UNIX epoch.
+    stale_until integer         not null
+);
+
+create index idx_did_resolution_cache_stale_until on did_resolution_cache (stale_until);
+
+-- +goose Down
+drop table did_resolution_cache;
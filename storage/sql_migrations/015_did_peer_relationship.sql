@@ -0,0 +1,16 @@
+-- +goose Up
+-- did_peer_relationship: binds a did:peer DID to the party (relationship) it was created for.
+-- The wallet only presents a did:peer DID to the party it's bound to, so did:peer DIDs are pairwise.
+create table did_peer_relationship
+(
+    -- did: the did:peer DID, matches did.id.
+    did          varchar(370) not null primary key,
+    -- relationship: identifies the party the DID is used with, i.e. the audience of presentations made with it.
+    relationship varchar(415) not null,
+    constraint fk_did_peer_relationship_did foreign key (did) references did (id) on delete cascade
+);
+
+create index idx_did_peer_relationship_relationship on did_peer_relationship (relationship);
+
+-- +goose Down
+drop table did_peer_relationship;
@@ -0,0 +1,9 @@
+-- +goose Up
+-- bitstring_status_list: add status_size column; the number of bits per entry of 'message' status list credentials
+alter table bitstring_status_list add status_size integer NOT NULL DEFAULT 1;
+-- bitstring_status_list_entry: add status column; the status value of 'message' entries
+alter table bitstring_status_list_entry add status integer NOT NULL DEFAULT 1;
+
+-- +goose Down
+alter table bitstring_status_list_entry drop column status;
+alter table bitstring_status_list drop column status_size;
@@ -0,0 +1,14 @@
+-- +goose Up
+-- key_claim: keys handed out by storage backends that hand out provisioned keys (e.g. CSC credentials) instead of creating them.
+-- The primary key prevents a provisioned key from being claimed twice, e.g. by nodes sharing the database. Claims are never released.
+create table key_claim
+(
+    -- key_name: the name of the claimed key in the secure backend, matches key_reference.key_name.
+    key_name varchar(255) not null primary key
+);
+
+-- keys referenced by a KID other than their own name have been claimed before
+insert into key_claim (key_name) select distinct key_name from key_reference where kid <> key_name;
+
+-- +goose Down
+drop table key_claim;
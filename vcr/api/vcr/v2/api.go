@@ -24,6 +24,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -38,6 +39,7 @@ import (
 	"github.com/nuts-foundation/nuts-node/vcr/credential"
 	"github.com/nuts-foundation/nuts-node/vcr/holder"
 	"github.com/nuts-foundation/nuts-node/vcr/issuer"
+	"github.com/nuts-foundation/nuts-node/vcr/revocation"
 	"github.com/nuts-foundation/nuts-node/vcr/signature/proof"
 	vcrTypes "github.com/nuts-foundation/nuts-node/vcr/types"
 	"github.com/nuts-foundation/nuts-node/vcr/verifier"
@@ -82,6 +84,8 @@ func (w *Wrapper) ResolveStatusCode(err error) int {
 		vcrTypes.ErrNotFound:          http.StatusNotFound,
 		resolver.ErrServiceNotFound:   http.StatusPreconditionFailed,
 		vcrTypes.ErrRevoked:           http.StatusConflict,
+		vcrTypes.ErrSuspended:         http.StatusConflict,
+		vcrTypes.ErrNotSuspended:      http.StatusConflict,
 		resolver.ErrNotFound:          http.StatusBadRequest,
 		resolver.ErrKeyNotFound:       http.StatusBadRequest,
 		did.ErrInvalidDID:             http.StatusBadRequest,
@@ -152,11 +156,14 @@ func parseCredentialOptions(request IssueVCRequestObject) (*issuer.CredentialOpt
 	if request.Body.Format != nil {
 		options.Format = string(*request.Body.Format)
 	}
+	if request.Body.SelectivelyDisclosable != nil {
+		options.SelectivelyDisclosable = *request.Body.SelectivelyDisclosable
+	}
 
 	// Valid CredentialOptions:
-	// All: Format
+	// All: Format, SelectivelyDisclosable
 	// did:nuts: PublishToNetwork, Visibility
-	// did:web: WithStatusList2021Revocation
+	// did:web: WithStatusList2021Revocation, WithBitstringStatusListRevocation, WithStatusListSuspension, StatusMessages
 	switch issuerDID.Method {
 	case "nuts":
 		options.Publish = true
@@ -187,14 +194,35 @@ func parseCredentialOptions(request IssueVCRequestObject) (*issuer.CredentialOpt
 		if request.Body.WithStatusList2021Revocation != nil {
 			return nil, core.InvalidInputError("illegal option 'withStatusList2021Revocation' requested for issuer's DID method: %s", issuerDID.Method)
 		}
+		if request.Body.WithBitstringStatusListRevocation != nil {
+			return nil, core.InvalidInputError("illegal option 'withBitstringStatusListRevocation' requested for issuer's DID method: %s", issuerDID.Method)
+		}
+		if request.Body.WithStatusListSuspension != nil {
+			return nil, core.InvalidInputError("illegal option 'withStatusListSuspension' requested for issuer's DID method: %s", issuerDID.Method)
+		}
+		if request.Body.StatusMessages != nil {
+			return nil, core.InvalidInputError("illegal option 'statusMessages' requested for issuer's DID method: %s", issuerDID.Method)
+		}
 	case "web":
 		// check if statusList2021Entry should be added
 		if request.Body.WithStatusList2021Revocation != nil {
 			options.WithStatusListRevocation = *request.Body.WithStatusList2021Revocation
 		}
+		// check if a 'suspension' BitstringStatusListEntry should be added
+		if request.Body.WithBitstringStatusListRevocation != nil {
+			options.WithBitstringStatusListRevocation = *request.Body.WithBitstringStatusListRevocation
+		}
+		if request.Body.WithStatusListSuspension != nil {
+			options.WithStatusListSuspension = *request.Body.WithStatusListSuspension
+		}
+		if request.Body.StatusMessages != nil {
+			for _, message := range *request.Body.StatusMessages {
+				options.StatusMessages = append(options.StatusMessages, revocation.StatusMessage{Status: message.Status, Message: message.Message})
+			}
+		}
 		// non expiring credential MUST set a value for withStatusList2021Revocation
-		if request.Body.ExpirationDate == nil && request.Body.WithStatusList2021Revocation == nil {
-			return nil, core.InvalidInputError("withStatusList2021Revocation MUST be provided for credentials without expirationDate")
+		if request.Body.ExpirationDate == nil && request.Body.WithStatusList2021Revocation == nil && request.Body.WithBitstringStatusListRevocation == nil {
+			return nil, core.InvalidInputError("withStatusList2021Revocation or withBitstringStatusListRevocation MUST be provided for credentials without expirationDate")
 		}
 		// return error for invalid options
 		if request.Body.PublishToNetwork != nil {
@@ -230,6 +258,49 @@ func (w Wrapper) RevokeVC(ctx context.Context, request RevokeVCRequestObject) (R
 	return RevokeVC204Response{}, nil
 }
 
+// SuspendVC handles the API request for suspending a credential.
+func (w Wrapper) SuspendVC(ctx context.Context, request SuspendVCRequestObject) (SuspendVCResponseObject, error) {
+	credentialID, err := ssi.ParseURI(request.Id)
+	if err != nil {
+		return nil, core.InvalidInputError("invalid credential id: %w", err)
+	}
+	if err = w.VCR.Issuer().Suspend(ctx, *credentialID); err != nil {
+		return nil, err
+	}
+	return SuspendVC204Response{}, nil
+}
+
+// UnsuspendVC handles the API request for lifting the suspension of a credential.
+func (w Wrapper) UnsuspendVC(ctx context.Context, request UnsuspendVCRequestObject) (UnsuspendVCResponseObject, error) {
+	credentialID, err := ssi.ParseURI(request.Id)
+	if err != nil {
+		return nil, core.InvalidInputError("invalid credential id: %w", err)
+	}
+	if err = w.VCR.Issuer().Unsuspend(ctx, *credentialID); err != nil {
+		return nil, err
+	}
+	return UnsuspendVC204Response{}, nil
+}
+
+// SetVCStatus handles the API request for setting the status of a credential with a 'message' status entry.
+func (w Wrapper) SetVCStatus(ctx context.Context, request SetVCStatusRequestObject) (SetVCStatusResponseObject, error) {
+	credentialID, err := ssi.ParseURI(request.Id)
+	if err != nil {
+		return nil, core.InvalidInputError("invalid credential id: %w", err)
+	}
+	if !strings.HasPrefix(request.Body.Status, "0x") {
+		return nil, core.InvalidInputError("invalid status: must be a hexadecimal string prefixed with '0x'")
+	}
+	status, err := strconv.ParseUint(request.Body.Status[2:], 16, 8)
+	if err != nil {
+		return nil, core.InvalidInputError("invalid status: %w", err)
+	}
+	if err = w.VCR.Issuer().SetMessageStatus(ctx, *credentialID, int(status)); err != nil {
+		return nil, err
+	}
+	return SetVCStatus204Response{}, nil
+}
+
 // SearchIssuedVCs handles the API request for searching for issued VCs
 func (w *Wrapper) SearchIssuedVCs(ctx context.Context, request SearchIssuedVCsRequestObject) (SearchIssuedVCsResponseObject, error) {
 	issuerDID, err := did.ParseDID(request.Params.Issuer)
@@ -41,6 +41,7 @@ import (
 	"github.com/nuts-foundation/nuts-node/vcr/credential"
 	"github.com/nuts-foundation/nuts-node/vcr/holder"
 	"github.com/nuts-foundation/nuts-node/vcr/issuer"
+	"github.com/nuts-foundation/nuts-node/vcr/revocation"
 	"github.com/nuts-foundation/nuts-node/vcr/signature/proof"
 	"github.com/nuts-foundation/nuts-node/vcr/verifier"
 	"github.com/nuts-foundation/nuts-node/vdr/didsubject"
@@ -317,6 +318,41 @@ func TestWrapper_IssueVC(t *testing.T) {
 				assert.EqualError(t, err, "illegal option 'withStatusList2021Revocation' requested for issuer's DID method: nuts")
 				assert.Nil(t, response)
 			})
+			t.Run("err - WithStatusListSuspension provided", func(t *testing.T) {
+				testContext := newMockContext(t)
+
+				publishValue := false
+				withSuspension := true
+				request := IssueVCRequest{
+					Issuer:                   expectedRequestedVC.Issuer.String(),
+					CredentialSubject:        expectedRequestedVC.CredentialSubject,
+					PublishToNetwork:         &publishValue,
+					WithStatusListSuspension: &withSuspension,
+				}
+				_ = request.Type.FromIssueVCRequestType0(expectedRequestedVC.Type[0].String())
+
+				response, err := testContext.client.IssueVC(testContext.requestCtx, IssueVCRequestObject{Body: &request})
+
+				assert.EqualError(t, err, "illegal option 'withStatusListSuspension' requested for issuer's DID method: nuts")
+				assert.Nil(t, response)
+			})
+			t.Run("err - StatusMessages provided", func(t *testing.T) {
+				testContext := newMockContext(t)
+
+				publishValue := false
+				request := IssueVCRequest{
+					Issuer:            expectedRequestedVC.Issuer.String(),
+					CredentialSubject: expectedRequestedVC.CredentialSubject,
+					PublishToNetwork:  &publishValue,
+					StatusMessages:    &[]StatusMessage{{Status: "0x0", Message: "valid"}, {Status: "0x1", Message: "invalid"}},
+				}
+				_ = request.Type.FromIssueVCRequestType0(expectedRequestedVC.Type[0].String())
+
+				response, err := testContext.client.IssueVC(testContext.requestCtx, IssueVCRequestObject{Body: &request})
+
+				assert.EqualError(t, err, "illegal option 'statusMessages' requested for issuer's DID method: nuts")
+				assert.Nil(t, response)
+			})
 
 		})
 		t.Run("did:web", func(t *testing.T) {
@@ -347,6 +383,27 @@ func TestWrapper_IssueVC(t *testing.T) {
 				assert.NoError(t, err)
 				assert.Equal(t, IssueVC200JSONResponse(expectedRequestedVC), response)
 			})
+			t.Run("ok with statuslist suspension", func(t *testing.T) {
+				testContext := newMockContext(t)
+				withRevocation := true
+				withSuspension := true
+				request := IssueVCRequest{
+					CredentialSubject:            expectedRequestedVC.CredentialSubject,
+					Issuer:                       expectedRequestedVC.Issuer.String(),
+					WithStatusList2021Revocation: &withRevocation,
+					WithStatusListSuspension:     &withSuspension,
+				}
+				_ = request.Type.FromIssueVCRequestType0(expectedRequestedVC.Type[0].String())
+				testContext.mockIssuer.EXPECT().Issue(testContext.requestCtx, expectedRequestedVC, issuer.CredentialOptions{
+					WithStatusListRevocation: true,
+					WithStatusListSuspension: true,
+				}).Return(&expectedRequestedVC, nil)
+
+				response, err := testContext.client.IssueVC(testContext.requestCtx, IssueVCRequestObject{Body: &request})
+
+				assert.NoError(t, err)
+				assert.Equal(t, IssueVC200JSONResponse(expectedRequestedVC), response)
+			})
 			t.Run("ok - without WithStatusList2021Revocation, with ExpirationDate", func(t *testing.T) {
 				testContext := newMockContext(t)
 
@@ -376,6 +433,53 @@ func TestWrapper_IssueVC(t *testing.T) {
 				assert.NoError(t, err)
 				assert.Equal(t, IssueVC200JSONResponse(expectedRequestedVC), response)
 			})
+			t.Run("ok - with BitstringStatusList revocation and status messages", func(t *testing.T) {
+				testContext := newMockContext(t)
+				withRevocation := true
+				request := IssueVCRequest{
+					CredentialSubject:                 expectedRequestedVC.CredentialSubject,
+					Issuer:                            expectedRequestedVC.Issuer.String(),
+					WithBitstringStatusListRevocation: &withRevocation,
+					StatusMessages:                    &[]StatusMessage{{Status: "0x0", Message: "valid"}, {Status: "0x1", Message: "invalid"}},
+				}
+				_ = request.Type.FromIssueVCRequestType0(expectedRequestedVC.Type[0].String())
+				testContext.mockIssuer.EXPECT().Issue(testContext.requestCtx, expectedRequestedVC, issuer.CredentialOptions{
+					WithBitstringStatusListRevocation: true,
+					StatusMessages: []revocation.StatusMessage{
+						{Status: "0x0", Message: "valid"},
+						{Status: "0x1", Message: "invalid"},
+					},
+				}).Return(&expectedRequestedVC, nil)
+
+				response, err := testContext.client.IssueVC(testContext.requestCtx, IssueVCRequestObject{Body: &request})
+
+				assert.NoError(t, err)
+				assert.Equal(t, IssueVC200JSONResponse(expectedRequestedVC), response)
+			})
+			t.Run("ok - SD-JWT VC with selectively disclosable claims", func(t *testing.T) {
+				testContext := newMockContext(t)
+				withRevocation := true
+				format := VcSdJwt
+				disclosable := []string{"name"}
+				request := IssueVCRequest{
+					CredentialSubject:            expectedRequestedVC.CredentialSubject,
+					Issuer:                       expectedRequestedVC.Issuer.String(),
+					WithStatusList2021Revocation: &withRevocation,
+					Format:                       &format,
+					SelectivelyDisclosable:       &disclosable,
+				}
+				_ = request.Type.FromIssueVCRequestType0(expectedRequestedVC.Type[0].String())
+				testContext.mockIssuer.EXPECT().Issue(testContext.requestCtx, expectedRequestedVC, issuer.CredentialOptions{
+					Format:                   "vc+sd-jwt",
+					WithStatusListRevocation: true,
+					SelectivelyDisclosable:   []string{"name"},
+				}).Return(&expectedRequestedVC, nil)
+
+				response, err := testContext.client.IssueVC(testContext.requestCtx, IssueVCRequestObject{Body: &request})
+
+				assert.NoError(t, err)
+				assert.Equal(t, IssueVC200JSONResponse(expectedRequestedVC), response)
+			})
 			t.Run("err - without WithStatusList2021Revocation and ExpirationDate", func(t *testing.T) {
 				testContext := newMockContext(t)
 
@@ -388,7 +492,7 @@ func TestWrapper_IssueVC(t *testing.T) {
 
 				response, err := testContext.client.IssueVC(testContext.requestCtx, IssueVCRequestObject{Body: &request})
 
-				assert.EqualError(t, err, "withStatusList2021Revocation MUST be provided for credentials without expirationDate")
+				assert.EqualError(t, err, "withStatusList2021Revocation or withBitstringStatusListRevocation MUST be provided for credentials without expirationDate")
 				assert.Nil(t, response)
 			})
 			t.Run("err - illegal param: publishToNetwork", func(t *testing.T) {
@@ -731,6 +835,113 @@ func TestWrapper_RevokeVC(t *testing.T) {
 	})
 }
 
+func TestWrapper_SuspendVC(t *testing.T) {
+	credentialID := "did:web:example.com#abc"
+	credentialURI := ssi.MustParseURI(credentialID)
+
+	t.Run("ok", func(t *testing.T) {
+		testContext := newMockContext(t)
+		testContext.mockIssuer.EXPECT().Suspend(gomock.Any(), credentialURI).Return(nil)
+
+		response, err := testContext.client.SuspendVC(testContext.requestCtx, SuspendVCRequestObject{Id: credentialID})
+
+		assert.NoError(t, err)
+		assert.Equal(t, SuspendVC204Response{}, response)
+	})
+	t.Run("vcr returns an error", func(t *testing.T) {
+		testContext := newMockContext(t)
+		testContext.mockIssuer.EXPECT().Suspend(gomock.Any(), credentialURI).Return(types.ErrSuspended)
+
+		response, err := testContext.client.SuspendVC(testContext.requestCtx, SuspendVCRequestObject{Id: credentialID})
+
+		assert.Empty(t, response)
+		assert.ErrorIs(t, err, types.ErrSuspended)
+		assert.Equal(t, http.StatusConflict, testContext.client.ResolveStatusCode(err))
+	})
+	t.Run("invalid credential id format", func(t *testing.T) {
+		testContext := newMockContext(t)
+
+		response, err := testContext.client.SuspendVC(testContext.requestCtx, SuspendVCRequestObject{Id: "%%"})
+
+		assert.Empty(t, response)
+		assert.EqualError(t, err, "invalid credential id: parse \"%%\": invalid URL escape \"%%\"")
+	})
+}
+
+func TestWrapper_UnsuspendVC(t *testing.T) {
+	credentialID := "did:web:example.com#abc"
+	credentialURI := ssi.MustParseURI(credentialID)
+
+	t.Run("ok", func(t *testing.T) {
+		testContext := newMockContext(t)
+		testContext.mockIssuer.EXPECT().Unsuspend(gomock.Any(), credentialURI).Return(nil)
+
+		response, err := testContext.client.UnsuspendVC(testContext.requestCtx, UnsuspendVCRequestObject{Id: credentialID})
+
+		assert.NoError(t, err)
+		assert.Equal(t, UnsuspendVC204Response{}, response)
+	})
+	t.Run("vcr returns an error", func(t *testing.T) {
+		testContext := newMockContext(t)
+		testContext.mockIssuer.EXPECT().Unsuspend(gomock.Any(), credentialURI).Return(types.ErrNotSuspended)
+
+		response, err := testContext.client.UnsuspendVC(testContext.requestCtx, UnsuspendVCRequestObject{Id: credentialID})
+
+		assert.Empty(t, response)
+		assert.ErrorIs(t, err, types.ErrNotSuspended)
+		assert.Equal(t, http.StatusConflict, testContext.client.ResolveStatusCode(err))
+	})
+}
+
+func TestWrapper_SetVCStatus(t *testing.T) {
+	credentialID := "did:web:example.com#abc"
+	credentialURI := ssi.MustParseURI(credentialID)
+
+	t.Run("ok", func(t *testing.T) {
+		testContext := newMockContext(t)
+		testContext.mockIssuer.EXPECT().SetMessageStatus(gomock.Any(), credentialURI, 0x1f).Return(nil)
+
+		response, err := testContext.client.SetVCStatus(testContext.requestCtx, SetVCStatusRequestObject{Id: credentialID, Body: &SetVCStatusRequest{Status: "0x1f"}})
+
+		assert.NoError(t, err)
+		assert.Equal(t, SetVCStatus204Response{}, response)
+	})
+	t.Run("vcr returns an error", func(t *testing.T) {
+		testContext := newMockContext(t)
+		testContext.mockIssuer.EXPECT().SetMessageStatus(gomock.Any(), credentialURI, 1).Return(types.ErrStatusNotFound)
+
+		response, err := testContext.client.SetVCStatus(testContext.requestCtx, SetVCStatusRequestObject{Id: credentialID, Body: &SetVCStatusRequest{Status: "0x1"}})
+
+		assert.Empty(t, response)
+		assert.ErrorIs(t, err, types.ErrStatusNotFound)
+		assert.Equal(t, http.StatusBadRequest, testContext.client.ResolveStatusCode(err))
+	})
+	t.Run("invalid credential id format", func(t *testing.T) {
+		testContext := newMockContext(t)
+
+		response, err := testContext.client.SetVCStatus(testContext.requestCtx, SetVCStatusRequestObject{Id: "%%", Body: &SetVCStatusRequest{Status: "0x1"}})
+
+		assert.Empty(t, response)
+		assert.EqualError(t, err, "invalid credential id: parse \"%%\": invalid URL escape \"%%\"")
+	})
+	t.Run("status without 0x prefix", func(t *testing.T) {
+		testContext := newMockContext(t)
+
+		response, err := testContext.client.SetVCStatus(testContext.requestCtx, SetVCStatusRequestObject{Id: credentialID, Body: &SetVCStatusRequest{Status: "1"}})
+
+		assert.Empty(t, response)
+		assert.EqualError(t, err, "invalid status: must be a hexadecimal string prefixed with '0x'")
+	})
+	t.Run("status is not a byte", func(t *testing.T) {
+		testContext := newMockContext(t)
+
+		response, err := testContext.client.SetVCStatus(testContext.requestCtx, SetVCStatusRequestObject{Id: credentialID, Body: &SetVCStatusRequest{Status: "0x100"}})
+
+		assert.Empty(t, response)
+		assert.ErrorContains(t, err, "invalid status: ")
+	})
+}
+
 // parsedTimeStr returns the original (truncated) time and an RFC3339 string with an extra round of formatting/parsing
 func parsedTimeStr(t time.Time) (time.Time, string) {
 	formatted := t.Format(time.RFC3339)
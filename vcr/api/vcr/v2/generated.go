@@ -39,8 +39,9 @@ const (
 
 // Defines values for IssueVCRequestFormat.
 const (
-	JwtVc IssueVCRequestFormat = "jwt_vc"
-	LdpVc IssueVCRequestFormat = "ldp_vc"
+	JwtVc   IssueVCRequestFormat = "jwt_vc"
+	LdpVc   IssueVCRequestFormat = "ldp_vc"
+	VcSdJwt IssueVCRequestFormat = "vc+sd-jwt"
 )
 
 // Defines values for IssueVCRequestVisibility.
@@ -111,7 +112,7 @@ type IssueVCRequest struct {
 	// ExpirationDate RFC3339 time string until when the credential is valid.
 	ExpirationDate *string `json:"expirationDate,omitempty"`
 
-	// Format Proof format for the credential (ldp_vc for JSON-LD or jwt_vc for JWT). If not set, it defaults to JSON-LD.
+	// Format Proof format for the credential (ldp_vc for JSON-LD, jwt_vc for JWT or vc+sd-jwt for SD-JWT VC). If not set, it defaults to JSON-LD.
 	Format *IssueVCRequestFormat `json:"format,omitempty"`
 
 	// Issuer DID according to Nuts specification.
@@ -124,6 +125,19 @@ type IssueVCRequest struct {
 	// Only valid for did:nuts issuers.
 	PublishToNetwork *bool `json:"publishToNetwork,omitempty"`
 
+	// SelectivelyDisclosable Names of the credentialSubject properties the holder may selectively disclose when presenting the credential.
+	// Properties not listed are always disclosed. The 'id' property can't be selectively disclosable.
+	// Only valid for the vc+sd-jwt format.
+	SelectivelyDisclosable *[]string `json:"selectivelyDisclosable,omitempty"`
+
+	// StatusMessages Add a BitstringStatusListEntry with statusPurpose 'message' to the issued credential, with these status messages.
+	// There must be a message for every status value, so the number of messages must be a power of 2 (at most 256).
+	// The status of the credential can be set using PUT /internal/vcr/v2/issuer/vc/{id}/status.
+	// See https://www.w3.org/TR/vc-bitstring-status-list/
+	//
+	// Only valid for did:web issuers.
+	StatusMessages *[]StatusMessage `json:"statusMessages,omitempty"`
+
 	// Type Type definition for the credential.
 	Type IssueVCRequest_Type `json:"type"`
 
@@ -132,14 +146,30 @@ type IssueVCRequest struct {
 	// Only valid for did:nuts issuers.
 	Visibility *IssueVCRequestVisibility `json:"visibility,omitempty"`
 
+	// WithBitstringStatusListRevocation Add a BitstringStatusListEntry with statusPurpose 'revocation' to the issued credential. This allows a credential to
+	// be revoked using the referenced BitstringStatusListCredential.
+	// See https://www.w3.org/TR/vc-bitstring-status-list/
+	//
+	// This or withStatusList2021Revocation is a required field for credentials without an expirationDate.
+	// Only valid for did:web issuers.
+	WithBitstringStatusListRevocation *bool `json:"withBitstringStatusListRevocation,omitempty"`
+
 	// WithStatusList2021Revocation Add a credentialStatus with statusPurpose 'revocation' to the issued credential. This allows a credential to
-	// be revoked using the referenced StatusList2021Credential. StatusPurpose 'suspension' is not supported (yet).
+	// be revoked using the referenced StatusList2021Credential. Use withStatusListSuspension for statusPurpose 'suspension'.
+	// Use withBitstringStatusListRevocation to use a BitstringStatusListCredential instead.
 	// See https://www.w3.org/TR/2023/WD-vc-status-list-20230427/
 	//
 	// Credentials with a short lifespan (expiry) are preferred over adding a credentialStatus.
-	// This is a required field for credentials without an expirationDate.
+	// This or withBitstringStatusListRevocation is a required field for credentials without an expirationDate.
 	// Only valid for did:web issuers.
 	WithStatusList2021Revocation *bool `json:"withStatusList2021Revocation,omitempty"`
+
+	// WithStatusListSuspension Add a BitstringStatusListEntry with statusPurpose 'suspension' to the issued credential. This allows a credential to
+	// be temporarily suspended and unsuspended using the referenced BitstringStatusListCredential.
+	// See https://www.w3.org/TR/vc-bitstring-status-list/
+	//
+	// Only valid for did:web issuers.
+	WithStatusListSuspension *bool `json:"withStatusListSuspension,omitempty"`
 }
 
 // IssueVCRequestContext0 defines model for .
@@ -154,7 +184,7 @@ type IssueVCRequest_Context struct {
 	union json.RawMessage
 }
 
-// IssueVCRequestFormat Proof format for the credential (ldp_vc for JSON-LD or jwt_vc for JWT). If not set, it defaults to JSON-LD.
+// IssueVCRequestFormat Proof format for the credential (ldp_vc for JSON-LD, jwt_vc for JWT or vc+sd-jwt for SD-JWT VC). If not set, it defaults to JSON-LD.
 type IssueVCRequestFormat string
 
 // IssueVCRequestType0 defines model for .
@@ -200,6 +230,21 @@ type SearchVCResults struct {
 	VerifiableCredentials []SearchVCResult `json:"verifiableCredentials"`
 }
 
+// SetVCStatusRequest A request for setting the status of a credential with a 'message' BitstringStatusListEntry.
+type SetVCStatusRequest struct {
+	// Status The status value as hexadecimal string, prefixed with '0x'.
+	Status string `json:"status"`
+}
+
+// StatusMessage A message describing a status value of a 'message' BitstringStatusListEntry.
+type StatusMessage struct {
+	// Message The message describing the status.
+	Message string `json:"message"`
+
+	// Status The status value as hexadecimal string, prefixed with '0x'.
+	Status string `json:"status"`
+}
+
 // VCVerificationOptions defines model for VCVerificationOptions.
 type VCVerificationOptions struct {
 	// AllowUntrustedIssuer If set to true, an untrusted credential issuer is allowed.
@@ -265,6 +310,9 @@ type LoadVCJSONRequestBody = VerifiableCredential
 // IssueVCJSONRequestBody defines body for IssueVC for application/json ContentType.
 type IssueVCJSONRequestBody = IssueVCRequest
 
+// SetVCStatusJSONRequestBody defines body for SetVCStatus for application/json ContentType.
+type SetVCStatusJSONRequestBody = SetVCStatusRequest
+
 // SearchVCsJSONRequestBody defines body for SearchVCs for application/json ContentType.
 type SearchVCsJSONRequestBody = SearchVCRequest
 
@@ -507,6 +555,17 @@ type ClientInterface interface {
 	// RevokeVC request
 	RevokeVC(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// SetVCStatusWithBody request with any body
+	SetVCStatusWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SetVCStatus(ctx context.Context, id string, body SetVCStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UnsuspendVC request
+	UnsuspendVC(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SuspendVC request
+	SuspendVC(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// SearchVCsWithBody request with any body
 	SearchVCsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -674,6 +733,54 @@ func (c *Client) RevokeVC(ctx context.Context, id string, reqEditors ...RequestE
 	return c.Client.Do(req)
 }
 
+func (c *Client) SetVCStatusWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetVCStatusRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SetVCStatus(ctx context.Context, id string, body SetVCStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetVCStatusRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UnsuspendVC(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUnsuspendVCRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SuspendVC(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSuspendVCRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) SearchVCsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewSearchVCsRequestWithBody(c.Server, contentType, body)
 	if err != nil {
@@ -1158,6 +1265,121 @@ func NewRevokeVCRequest(server string, id string) (*http.Request, error) {
 	return req, nil
 }
 
+// NewSetVCStatusRequest calls the generic SetVCStatus builder with application/json body
+func NewSetVCStatusRequest(server string, id string, body SetVCStatusJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSetVCStatusRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewSetVCStatusRequestWithBody generates requests for SetVCStatus with any type of body
+func NewSetVCStatusRequestWithBody(server string, id string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/vcr/v2/issuer/vc/%s/status", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewUnsuspendVCRequest generates requests for UnsuspendVC
+func NewUnsuspendVCRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/vcr/v2/issuer/vc/%s/suspension", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSuspendVCRequest generates requests for SuspendVC
+func NewSuspendVCRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/vcr/v2/issuer/vc/%s/suspension", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PUT", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewSearchVCsRequest calls the generic SearchVCs builder with application/json body
 func NewSearchVCsRequest(server string, body SearchVCsJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
@@ -1533,6 +1755,17 @@ type ClientWithResponsesInterface interface {
 	// RevokeVCWithResponse request
 	RevokeVCWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*RevokeVCResponse, error)
 
+	// SetVCStatusWithBodyWithResponse request with any body
+	SetVCStatusWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetVCStatusResponse, error)
+
+	SetVCStatusWithResponse(ctx context.Context, id string, body SetVCStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*SetVCStatusResponse, error)
+
+	// UnsuspendVCWithResponse request
+	UnsuspendVCWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*UnsuspendVCResponse, error)
+
+	// SuspendVCWithResponse request
+	SuspendVCWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*SuspendVCResponse, error)
+
 	// SearchVCsWithBodyWithResponse request with any body
 	SearchVCsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SearchVCsResponse, error)
 
@@ -1822,6 +2055,99 @@ func (r RevokeVCResponse) StatusCode() int {
 	return 0
 }
 
+type SetVCStatusResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	ApplicationproblemJSONDefault *struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r SetVCStatusResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SetVCStatusResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UnsuspendVCResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	ApplicationproblemJSONDefault *struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r UnsuspendVCResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UnsuspendVCResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SuspendVCResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	ApplicationproblemJSONDefault *struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r SuspendVCResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SuspendVCResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type SearchVCsResponse struct {
 	Body                          []byte
 	HTTPResponse                  *http.Response
@@ -2172,21 +2498,56 @@ func (c *ClientWithResponses) RevokeVCWithResponse(ctx context.Context, id strin
 	return ParseRevokeVCResponse(rsp)
 }
 
-// SearchVCsWithBodyWithResponse request with arbitrary body returning *SearchVCsResponse
-func (c *ClientWithResponses) SearchVCsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SearchVCsResponse, error) {
-	rsp, err := c.SearchVCsWithBody(ctx, contentType, body, reqEditors...)
+// SetVCStatusWithBodyWithResponse request with arbitrary body returning *SetVCStatusResponse
+func (c *ClientWithResponses) SetVCStatusWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetVCStatusResponse, error) {
+	rsp, err := c.SetVCStatusWithBody(ctx, id, contentType, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
-	return ParseSearchVCsResponse(rsp)
+	return ParseSetVCStatusResponse(rsp)
 }
 
-func (c *ClientWithResponses) SearchVCsWithResponse(ctx context.Context, body SearchVCsJSONRequestBody, reqEditors ...RequestEditorFn) (*SearchVCsResponse, error) {
-	rsp, err := c.SearchVCs(ctx, body, reqEditors...)
+func (c *ClientWithResponses) SetVCStatusWithResponse(ctx context.Context, id string, body SetVCStatusJSONRequestBody, reqEditors ...RequestEditorFn) (*SetVCStatusResponse, error) {
+	rsp, err := c.SetVCStatus(ctx, id, body, reqEditors...)
 	if err != nil {
 		return nil, err
 	}
-	return ParseSearchVCsResponse(rsp)
+	return ParseSetVCStatusResponse(rsp)
+}
+
+// UnsuspendVCWithResponse request returning *UnsuspendVCResponse
+func (c *ClientWithResponses) UnsuspendVCWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*UnsuspendVCResponse, error) {
+	rsp, err := c.UnsuspendVC(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUnsuspendVCResponse(rsp)
+}
+
+// SuspendVCWithResponse request returning *SuspendVCResponse
+func (c *ClientWithResponses) SuspendVCWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*SuspendVCResponse, error) {
+	rsp, err := c.SuspendVC(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSuspendVCResponse(rsp)
+}
+
+// SearchVCsWithBodyWithResponse request with arbitrary body returning *SearchVCsResponse
+func (c *ClientWithResponses) SearchVCsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SearchVCsResponse, error) {
+	rsp, err := c.SearchVCsWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSearchVCsResponse(rsp)
+}
+
+func (c *ClientWithResponses) SearchVCsWithResponse(ctx context.Context, body SearchVCsJSONRequestBody, reqEditors ...RequestEditorFn) (*SearchVCsResponse, error) {
+	rsp, err := c.SearchVCs(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSearchVCsResponse(rsp)
 }
 
 // ResolveVCWithResponse request returning *ResolveVCResponse
@@ -2606,6 +2967,111 @@ func ParseRevokeVCResponse(rsp *http.Response) (*RevokeVCResponse, error) {
 	return response, nil
 }
 
+// ParseSetVCStatusResponse parses an HTTP response from a SetVCStatusWithResponse call
+func ParseSetVCStatusResponse(rsp *http.Response) (*SetVCStatusResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SetVCStatusResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest struct {
+			// Detail A human-readable explanation specific to this occurrence of the problem.
+			Detail string `json:"detail"`
+
+			// Status HTTP statuscode
+			Status float32 `json:"status"`
+
+			// Title A short, human-readable summary of the problem type.
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUnsuspendVCResponse parses an HTTP response from a UnsuspendVCWithResponse call
+func ParseUnsuspendVCResponse(rsp *http.Response) (*UnsuspendVCResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UnsuspendVCResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest struct {
+			// Detail A human-readable explanation specific to this occurrence of the problem.
+			Detail string `json:"detail"`
+
+			// Status HTTP statuscode
+			Status float32 `json:"status"`
+
+			// Title A short, human-readable summary of the problem type.
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSuspendVCResponse parses an HTTP response from a SuspendVCWithResponse call
+func ParseSuspendVCResponse(rsp *http.Response) (*SuspendVCResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SuspendVCResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest struct {
+			// Detail A human-readable explanation specific to this occurrence of the problem.
+			Detail string `json:"detail"`
+
+			// Status HTTP statuscode
+			Status float32 `json:"status"`
+
+			// Title A short, human-readable summary of the problem type.
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseSearchVCsResponse parses an HTTP response from a SearchVCsWithResponse call
 func ParseSearchVCsResponse(rsp *http.Response) (*SearchVCsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -2954,6 +3420,15 @@ type ServerInterface interface {
 	// Revoke an issued credential
 	// (DELETE /internal/vcr/v2/issuer/vc/{id})
 	RevokeVC(ctx echo.Context, id string) error
+	// Set the status message of an issued credential
+	// (PUT /internal/vcr/v2/issuer/vc/{id}/status)
+	SetVCStatus(ctx echo.Context, id string) error
+	// Lift the suspension of an issued credential
+	// (DELETE /internal/vcr/v2/issuer/vc/{id}/suspension)
+	UnsuspendVC(ctx echo.Context, id string) error
+	// Suspend an issued credential
+	// (PUT /internal/vcr/v2/issuer/vc/{id}/suspension)
+	SuspendVC(ctx echo.Context, id string) error
 	// Searches for verifiable credentials that could be used for different use-cases.
 	// (POST /internal/vcr/v2/search)
 	SearchVCs(ctx echo.Context) error
@@ -3124,6 +3599,60 @@ func (w *ServerInterfaceWrapper) RevokeVC(ctx echo.Context) error {
 	return err
 }
 
+// SetVCStatus converts echo context to params.
+func (w *ServerInterfaceWrapper) SetVCStatus(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.SetVCStatus(ctx, id)
+	return err
+}
+
+// UnsuspendVC converts echo context to params.
+func (w *ServerInterfaceWrapper) UnsuspendVC(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.UnsuspendVC(ctx, id)
+	return err
+}
+
+// SuspendVC converts echo context to params.
+func (w *ServerInterfaceWrapper) SuspendVC(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.SuspendVC(ctx, id)
+	return err
+}
+
 // SearchVCs converts echo context to params.
 func (w *ServerInterfaceWrapper) SearchVCs(ctx echo.Context) error {
 	var err error
@@ -3269,6 +3798,9 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 	router.POST(baseURL+"/internal/vcr/v2/issuer/vc", wrapper.IssueVC)
 	router.GET(baseURL+"/internal/vcr/v2/issuer/vc/search", wrapper.SearchIssuedVCs)
 	router.DELETE(baseURL+"/internal/vcr/v2/issuer/vc/:id", wrapper.RevokeVC)
+	router.PUT(baseURL+"/internal/vcr/v2/issuer/vc/:id/status", wrapper.SetVCStatus)
+	router.DELETE(baseURL+"/internal/vcr/v2/issuer/vc/:id/suspension", wrapper.UnsuspendVC)
+	router.PUT(baseURL+"/internal/vcr/v2/issuer/vc/:id/suspension", wrapper.SuspendVC)
 	router.POST(baseURL+"/internal/vcr/v2/search", wrapper.SearchVCs)
 	router.GET(baseURL+"/internal/vcr/v2/vc/:id", wrapper.ResolveVC)
 	router.DELETE(baseURL+"/internal/vcr/v2/verifier/trust", wrapper.UntrustIssuer)
@@ -3592,6 +4124,118 @@ func (response RevokeVCdefaultApplicationProblemPlusJSONResponse) VisitRevokeVCR
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
+type SetVCStatusRequestObject struct {
+	Id   string `json:"id"`
+	Body *SetVCStatusJSONRequestBody
+}
+
+type SetVCStatusResponseObject interface {
+	VisitSetVCStatusResponse(w http.ResponseWriter) error
+}
+
+type SetVCStatus204Response struct {
+}
+
+func (response SetVCStatus204Response) VisitSetVCStatusResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type SetVCStatusdefaultApplicationProblemPlusJSONResponse struct {
+	Body struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+	StatusCode int
+}
+
+func (response SetVCStatusdefaultApplicationProblemPlusJSONResponse) VisitSetVCStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type UnsuspendVCRequestObject struct {
+	Id string `json:"id"`
+}
+
+type UnsuspendVCResponseObject interface {
+	VisitUnsuspendVCResponse(w http.ResponseWriter) error
+}
+
+type UnsuspendVC204Response struct {
+}
+
+func (response UnsuspendVC204Response) VisitUnsuspendVCResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type UnsuspendVCdefaultApplicationProblemPlusJSONResponse struct {
+	Body struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+	StatusCode int
+}
+
+func (response UnsuspendVCdefaultApplicationProblemPlusJSONResponse) VisitUnsuspendVCResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type SuspendVCRequestObject struct {
+	Id string `json:"id"`
+}
+
+type SuspendVCResponseObject interface {
+	VisitSuspendVCResponse(w http.ResponseWriter) error
+}
+
+type SuspendVC204Response struct {
+}
+
+func (response SuspendVC204Response) VisitSuspendVCResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type SuspendVCdefaultApplicationProblemPlusJSONResponse struct {
+	Body struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+	StatusCode int
+}
+
+func (response SuspendVCdefaultApplicationProblemPlusJSONResponse) VisitSuspendVCResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
 type SearchVCsRequestObject struct {
 	Body *SearchVCsJSONRequestBody
 }
@@ -3920,6 +4564,15 @@ type StrictServerInterface interface {
 	// Revoke an issued credential
 	// (DELETE /internal/vcr/v2/issuer/vc/{id})
 	RevokeVC(ctx context.Context, request RevokeVCRequestObject) (RevokeVCResponseObject, error)
+	// Set the status message of an issued credential
+	// (PUT /internal/vcr/v2/issuer/vc/{id}/status)
+	SetVCStatus(ctx context.Context, request SetVCStatusRequestObject) (SetVCStatusResponseObject, error)
+	// Lift the suspension of an issued credential
+	// (DELETE /internal/vcr/v2/issuer/vc/{id}/suspension)
+	UnsuspendVC(ctx context.Context, request UnsuspendVCRequestObject) (UnsuspendVCResponseObject, error)
+	// Suspend an issued credential
+	// (PUT /internal/vcr/v2/issuer/vc/{id}/suspension)
+	SuspendVC(ctx context.Context, request SuspendVCRequestObject) (SuspendVCResponseObject, error)
 	// Searches for verifiable credentials that could be used for different use-cases.
 	// (POST /internal/vcr/v2/search)
 	SearchVCs(ctx context.Context, request SearchVCsRequestObject) (SearchVCsResponseObject, error)
@@ -4173,6 +4826,87 @@ func (sh *strictHandler) RevokeVC(ctx echo.Context, id string) error {
 	return nil
 }
 
+// SetVCStatus operation middleware
+func (sh *strictHandler) SetVCStatus(ctx echo.Context, id string) error {
+	var request SetVCStatusRequestObject
+
+	request.Id = id
+
+	var body SetVCStatusJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.SetVCStatus(ctx.Request().Context(), request.(SetVCStatusRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SetVCStatus")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(SetVCStatusResponseObject); ok {
+		return validResponse.VisitSetVCStatusResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// UnsuspendVC operation middleware
+func (sh *strictHandler) UnsuspendVC(ctx echo.Context, id string) error {
+	var request UnsuspendVCRequestObject
+
+	request.Id = id
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.UnsuspendVC(ctx.Request().Context(), request.(UnsuspendVCRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UnsuspendVC")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(UnsuspendVCResponseObject); ok {
+		return validResponse.VisitUnsuspendVCResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// SuspendVC operation middleware
+func (sh *strictHandler) SuspendVC(ctx echo.Context, id string) error {
+	var request SuspendVCRequestObject
+
+	request.Id = id
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.SuspendVC(ctx.Request().Context(), request.(SuspendVCRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SuspendVC")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(SuspendVCResponseObject); ok {
+		return validResponse.VisitSuspendVCResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // SearchVCs operation middleware
 func (sh *strictHandler) SearchVCs(ctx echo.Context) error {
 	var request SearchVCsRequestObject
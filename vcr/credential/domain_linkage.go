@@ -0,0 +1,118 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package credential
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/jsonld"
+)
+
+// DIDConfigurationWellKnownPath is the path at which the DID Configuration resource is served, relative to the origin.
+const DIDConfigurationWellKnownPath = "/.well-known/did-configuration.json"
+
+// LinkedDomainsServiceType is the type of the DID document service that lists the origins a DID is linked to.
+const LinkedDomainsServiceType = "LinkedDomains"
+
+// DIDConfiguration is the DIF Well-Known DID Configuration resource served at DIDConfigurationWellKnownPath,
+// which contains Domain Linkage Credentials linking DIDs to the origin it's served on.
+// See https://identity.foundation/.well-known/resources/did-configuration/
+type DIDConfiguration struct {
+	Context    string                    `json:"@context"`
+	LinkedDIDs []vc.VerifiableCredential `json:"linked_dids"`
+}
+
+// ParseOrigin parses the given web origin (e.g. https://example.com), as used by Domain Linkage Credentials.
+// It returns the origin in its canonical form: lowercase scheme and host, without trailing slash.
+func ParseOrigin(origin string) (string, error) {
+	parsed, err := url.Parse(origin)
+	if err != nil {
+		return "", err
+	}
+	if parsed.Scheme != "https" && parsed.Scheme != "http" {
+		return "", errors.New("scheme must be https or http")
+	}
+	if parsed.Host == "" || parsed.User != nil || (parsed.Path != "" && parsed.Path != "/") || parsed.RawQuery != "" || parsed.Fragment != "" {
+		return "", errors.New("must only contain scheme, host and port")
+	}
+	return strings.ToLower(parsed.Scheme + "://" + parsed.Host), nil
+}
+
+// LinkedOrigins returns the (normalized) origins listed by the LinkedDomains services of the given DID document.
+// The service endpoint can be a single origin, a list of origins or an object containing a list of 'origins'.
+// Invalid origins are ignored.
+func LinkedOrigins(document did.Document) []string {
+	var result []string
+	for _, service := range document.Service {
+		if service.Type != LinkedDomainsServiceType {
+			continue
+		}
+		for _, origin := range endpointOrigins(service.ServiceEndpoint) {
+			if normalized, err := ParseOrigin(origin); err == nil {
+				result = append(result, normalized)
+			}
+		}
+	}
+	return result
+}
+
+// IsLinkedToOrigin returns whether the given DID document lists the given origin in its LinkedDomains services.
+func IsLinkedToOrigin(document did.Document, origin string) bool {
+	normalized, err := ParseOrigin(origin)
+	if err != nil {
+		return false
+	}
+	for _, curr := range LinkedOrigins(document) {
+		if curr == normalized {
+			return true
+		}
+	}
+	return false
+}
+
+// NewDIDConfiguration creates a DID Configuration resource containing the given Domain Linkage Credentials.
+func NewDIDConfiguration(credentials []vc.VerifiableCredential) DIDConfiguration {
+	if credentials == nil {
+		credentials = []vc.VerifiableCredential{}
+	}
+	return DIDConfiguration{
+		Context:    jsonld.DIDConfigurationContext,
+		LinkedDIDs: credentials,
+	}
+}
+
+func endpointOrigins(endpoint interface{}) []string {
+	switch value := endpoint.(type) {
+	case string:
+		return []string{value}
+	case []interface{}:
+		var result []string
+		for _, curr := range value {
+			result = append(result, endpointOrigins(curr)...)
+		}
+		return result
+	case map[string]interface{}:
+		return endpointOrigins(value["origins"])
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package credential
+
+import (
+	"testing"
+
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinkedOrigins(t *testing.T) {
+	t.Run("string, list and object endpoints", func(t *testing.T) {
+		document := did.Document{
+			Service: []did.Service{
+				{Type: LinkedDomainsServiceType, ServiceEndpoint: "https://Example.com/"},
+				{Type: LinkedDomainsServiceType, ServiceEndpoint: []interface{}{"https://a.example.com", "https://b.example.com"}},
+				{Type: LinkedDomainsServiceType, ServiceEndpoint: map[string]interface{}{"origins": []interface{}{"https://c.example.com"}}},
+				{Type: "Other", ServiceEndpoint: "https://other.example.com"},
+			},
+		}
+
+		origins := LinkedOrigins(document)
+
+		assert.Equal(t, []string{"https://example.com", "https://a.example.com", "https://b.example.com", "https://c.example.com"}, origins)
+	})
+	t.Run("invalid origins are ignored", func(t *testing.T) {
+		document := did.Document{
+			Service: []did.Service{
+				{Type: LinkedDomainsServiceType, ServiceEndpoint: []interface{}{"https://example.com/path", 1, "https://example.com"}},
+			},
+		}
+
+		origins := LinkedOrigins(document)
+
+		assert.Equal(t, []string{"https://example.com"}, origins)
+	})
+}
+
+func TestIsLinkedToOrigin(t *testing.T) {
+	document := did.Document{
+		Service: []did.Service{{Type: LinkedDomainsServiceType, ServiceEndpoint: "https://example.com"}},
+	}
+
+	assert.True(t, IsLinkedToOrigin(document, "https://EXAMPLE.com/"))
+	assert.False(t, IsLinkedToOrigin(document, "https://other.example.com"))
+	assert.False(t, IsLinkedToOrigin(document, "invalid"))
+}
+
+func TestParseOrigin(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		origin, err := ParseOrigin("HTTPS://Example.com:8443/")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "https://example.com:8443", origin)
+	})
+	t.Run("invalid scheme", func(t *testing.T) {
+		_, err := ParseOrigin("ftp://example.com")
+
+		assert.EqualError(t, err, "scheme must be https or http")
+	})
+	t.Run("query", func(t *testing.T) {
+		_, err := ParseOrigin("https://example.com?foo=bar")
+
+		assert.EqualError(t, err, "must only contain scheme, host and port")
+	})
+	t.Run("missing host", func(t *testing.T) {
+		_, err := ParseOrigin("https://")
+
+		assert.EqualError(t, err, "must only contain scheme, host and port")
+	})
+}
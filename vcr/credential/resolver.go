@@ -41,6 +41,8 @@ func FindValidator(credential vc.VerifiableCredential, pkiValidator pki.Validato
 				return nutsAuthorizationCredentialValidator{}
 			case X509CredentialType:
 				return x509CredentialValidator{pkiValidator: pkiValidator}
+			case DomainLinkageCredentialType:
+				return domainLinkageCredentialValidator{}
 			}
 		}
 	}
@@ -53,6 +53,10 @@ func TestFindValidator(t *testing.T) {
 	t.Run("validator found for X509Credential", func(t *testing.T) {
 		assert.IsType(t, x509CredentialValidator{}, FindValidator(test.ValidX509Credential(t), nil))
 	})
+
+	t.Run("validator found for DomainLinkageCredential", func(t *testing.T) {
+		assert.IsType(t, domainLinkageCredentialValidator{}, FindValidator(validDomainLinkageCredential(), nil))
+	})
 }
 
 func TestExtractTypes(t *testing.T) {
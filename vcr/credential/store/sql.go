@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	"gorm.io/gorm"
 	"strconv"
 	"strings"
@@ -67,7 +68,14 @@ type CredentialStore struct {
 }
 
 // Store stores a Verifiable Credential in the SQL database.
+// Enveloped SD-JWT VCs are indexed by the properties of the contained credential.
 func (c CredentialStore) Store(db *gorm.DB, credential vc.VerifiableCredential) (*CredentialRecord, error) {
+	raw := credential.Raw()
+	expanded, err := sdjwt.Expand(credential)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open SD-JWT VC: %w", err)
+	}
+	credential = *expanded
 	subjectDID, err := credential.SubjectDID()
 	if err != nil {
 		return nil, fmt.Errorf("failed to extract subject DID: %w", err)
@@ -77,7 +85,7 @@ func (c CredentialStore) Store(db *gorm.DB, credential vc.VerifiableCredential)
 		ID:        credential.ID.String(),
 		Issuer:    credential.Issuer.String(),
 		SubjectID: subjectDID.String(),
-		Raw:       credential.Raw(),
+		Raw:       raw,
 	}
 	// Set type
 	for _, currType := range credential.Type {
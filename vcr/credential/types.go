@@ -66,6 +66,20 @@ func DiscoveryRegistrationCredentialTypeV1URI() ssi.URI {
 	return ssi.MustParseURI(DiscoveryRegistrationCredentialType)
 }
 
+// DomainLinkageCredentialType is the type for a DIF Domain Linkage Credential, which links a DID to a web origin.
+// See https://identity.foundation/.well-known/resources/did-configuration/
+const DomainLinkageCredentialType = "DomainLinkageCredential"
+
+func DomainLinkageCredentialTypeURI() ssi.URI {
+	return ssi.MustParseURI(DomainLinkageCredentialType)
+}
+
+// DomainLinkageCredentialSubject defines the CredentialSubject struct for the DomainLinkageCredential
+type DomainLinkageCredentialSubject struct {
+	ID     string `json:"id"`
+	Origin string `json:"origin"`
+}
+
 // NutsAuthorizationCredentialSubject defines the CredentialSubject struct for the NutsAuthorizationCredential
 type NutsAuthorizationCredentialSubject struct {
 	// ID contains the DID of the subject
@@ -26,9 +26,11 @@ import (
 	"errors"
 	"fmt"
 	"github.com/lestrrat-go/jwx/v2/jwk"
+	ssi "github.com/nuts-foundation/go-did"
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/go-did/vc"
 	"github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/nuts-foundation/nuts-node/jsonld"
 	"github.com/nuts-foundation/nuts-node/pki"
 	"github.com/nuts-foundation/nuts-node/vcr/revocation"
 	"github.com/nuts-foundation/nuts-node/vdr/didx509"
@@ -102,7 +104,7 @@ func validateCredentialStatus(credential vc.VerifiableCredential) error {
 			return errors.New("credentialStatus.type is required")
 		}
 
-		// only validate StatusList2021Entry for now
+		// only validate StatusList2021Entry and BitstringStatusListEntry for now
 		switch credentialStatus.Type {
 		case revocation.StatusList2021EntryType:
 			// TODO: json.AllFieldsDefined validator should be sufficient?
@@ -117,6 +119,18 @@ func validateCredentialStatus(credential vc.VerifiableCredential) error {
 			if err = cs.Validate(); err != nil {
 				return err
 			}
+		case revocation.BitstringStatusListEntryType:
+			if !credential.ContainsContext(revocation.BitstringStatusListContextURI) {
+				return errors.New("BitstringStatusList context is required")
+			}
+
+			var cs revocation.BitstringStatusListEntry
+			if err = json.Unmarshal(credentialStatus.Raw(), &cs); err != nil {
+				return err
+			}
+			if err = cs.Validate(); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -261,6 +275,45 @@ func validateNutsCredentialID(credential vc.VerifiableCredential) error {
 }
 
 // x509CredentialValidator checks the did:x509 issuer and if the credentialSubject claims match the x509 certificate
+// domainLinkageCredentialValidator checks the DomainLinkageCredential according to the DIF Well-Known DID Configuration specification.
+// It doesn't require an 'id', since Domain Linkage Credentials in JWT format typically don't have one.
+type domainLinkageCredentialValidator struct {
+}
+
+func (d domainLinkageCredentialValidator) Validate(credential vc.VerifiableCredential) error {
+	if !credential.IsType(vc.VerifiableCredentialTypeV1URI()) {
+		return fmt.Errorf("%w: type 'VerifiableCredential' is required", errValidation)
+	}
+	if !credential.ContainsContext(vc.VCContextV1URI()) {
+		return fmt.Errorf("%w: default context is required", errValidation)
+	}
+	if !credential.ContainsContext(ssi.MustParseURI(jsonld.DIDConfigurationContext)) {
+		return fmt.Errorf("%w: context '%s' is required", errValidation, jsonld.DIDConfigurationContext)
+	}
+	if credential.IssuanceDate.IsZero() {
+		return fmt.Errorf("%w: 'issuanceDate' is required", errValidation)
+	}
+	if credential.ExpirationDate == nil {
+		return fmt.Errorf("%w: 'expirationDate' is required", errValidation)
+	}
+	var target []DomainLinkageCredentialSubject
+	// if it fails, length check will trigger
+	_ = credential.UnmarshalCredentialSubject(&target)
+	if len(target) != 1 {
+		return fmt.Errorf("%w: single CredentialSubject expected", errValidation)
+	}
+	if _, err := did.ParseDID(target[0].ID); err != nil {
+		return fmt.Errorf("%w: invalid 'credentialSubject.id': %w", errValidation, err)
+	}
+	if credential.Issuer.String() != target[0].ID {
+		return fmt.Errorf("%w: 'credentialSubject.id' must equal 'issuer'", errValidation)
+	}
+	if _, err := ParseOrigin(target[0].Origin); err != nil {
+		return fmt.Errorf("%w: invalid 'credentialSubject.origin': %w", errValidation, err)
+	}
+	return nil
+}
+
 type x509CredentialValidator struct {
 	pkiValidator pki.Validator
 }
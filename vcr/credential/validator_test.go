@@ -497,6 +497,35 @@ func Test_validateCredentialStatus(t *testing.T) {
 			assert.EqualError(t, err, "parse StatusList2021Entry.statusListCredential URL: parse \"make sure validator is called\": invalid URI for request")
 		})
 	})
+	t.Run(revocation.BitstringStatusListEntryType, func(t *testing.T) {
+		makeValidCSEntry := func() vc.VerifiableCredential {
+			return vc.VerifiableCredential{
+				Context: []ssi.URI{ssi.MustParseURI(jsonld.W3cBitstringStatusListContext)},
+				CredentialStatus: []any{&revocation.BitstringStatusListEntry{
+					ID:                   "https://example-com/credentials/status/3#94567",
+					Type:                 revocation.BitstringStatusListEntryType,
+					StatusPurpose:        "suspension",
+					StatusListIndex:      "94567",
+					StatusListCredential: "https://example-com/credentials/status/3",
+				}},
+			}
+		}
+		t.Run("ok", func(t *testing.T) {
+			assert.NoError(t, validateCredentialStatus(makeValidCSEntry()))
+		})
+		t.Run("error - missing context", func(t *testing.T) {
+			cred := makeValidCSEntry()
+			cred.Context = []ssi.URI{vc.VCContextV1URI()}
+			err := validateCredentialStatus(cred)
+			assert.EqualError(t, err, "BitstringStatusList context is required")
+		})
+		t.Run("error - credentialStatus validation is called", func(t *testing.T) {
+			cred := makeValidCSEntry()
+			cred.CredentialStatus[0].(*revocation.BitstringStatusListEntry).StatusListCredential = "make sure validator is called"
+			err := validateCredentialStatus(cred)
+			assert.EqualError(t, err, "parse BitstringStatusListEntry.statusListCredential URL: parse \"make sure validator is called\": invalid URI for request")
+		})
+	})
 }
 
 func TestX509CredentialValidator_Validate(t *testing.T) {
@@ -660,3 +689,81 @@ func createTestContext(t *testing.T) testContext {
 		pkiValidator: pkiValidator,
 	}
 }
+
+func TestDomainLinkageCredentialValidator_Validate(t *testing.T) {
+	validator := domainLinkageCredentialValidator{}
+
+	t.Run("ok", func(t *testing.T) {
+		err := validator.Validate(validDomainLinkageCredential())
+
+		assert.NoError(t, err)
+	})
+	t.Run("ok - origin with port and trailing slash", func(t *testing.T) {
+		v := validDomainLinkageCredential()
+		v.CredentialSubject = []map[string]any{{"id": "did:web:example.com", "origin": "https://example.com:8443/"}}
+
+		err := validator.Validate(v)
+
+		assert.NoError(t, err)
+	})
+	t.Run("failed - missing DID configuration context", func(t *testing.T) {
+		v := validDomainLinkageCredential()
+		v.Context = []ssi.URI{vc.VCContextV1URI()}
+
+		err := validator.Validate(v)
+
+		assert.EqualError(t, err, "validation failed: context 'https://identity.foundation/.well-known/did-configuration/v1' is required")
+	})
+	t.Run("failed - missing default type", func(t *testing.T) {
+		v := validDomainLinkageCredential()
+		v.Type = []ssi.URI{DomainLinkageCredentialTypeURI()}
+
+		err := validator.Validate(v)
+
+		assert.EqualError(t, err, "validation failed: type 'VerifiableCredential' is required")
+	})
+	t.Run("failed - missing expirationDate", func(t *testing.T) {
+		v := validDomainLinkageCredential()
+		v.ExpirationDate = nil
+
+		err := validator.Validate(v)
+
+		assert.EqualError(t, err, "validation failed: 'expirationDate' is required")
+	})
+	t.Run("failed - subject differs from issuer", func(t *testing.T) {
+		v := validDomainLinkageCredential()
+		v.Issuer = ssi.MustParseURI("did:web:other.com")
+
+		err := validator.Validate(v)
+
+		assert.EqualError(t, err, "validation failed: 'credentialSubject.id' must equal 'issuer'")
+	})
+	t.Run("failed - multiple credential subjects", func(t *testing.T) {
+		v := validDomainLinkageCredential()
+		v.CredentialSubject = append(v.CredentialSubject, v.CredentialSubject[0])
+
+		err := validator.Validate(v)
+
+		assert.EqualError(t, err, "validation failed: single CredentialSubject expected")
+	})
+	t.Run("failed - origin contains path", func(t *testing.T) {
+		v := validDomainLinkageCredential()
+		v.CredentialSubject = []map[string]any{{"id": "did:web:example.com", "origin": "https://example.com/path"}}
+
+		err := validator.Validate(v)
+
+		assert.EqualError(t, err, "validation failed: invalid 'credentialSubject.origin': must only contain scheme, host and port")
+	})
+}
+
+func validDomainLinkageCredential() vc.VerifiableCredential {
+	expirationDate := time.Now().Add(time.Hour)
+	return vc.VerifiableCredential{
+		Context:           []ssi.URI{vc.VCContextV1URI(), ssi.MustParseURI(jsonld.DIDConfigurationContext)},
+		Type:              []ssi.URI{vc.VerifiableCredentialTypeV1URI(), DomainLinkageCredentialTypeURI()},
+		Issuer:            ssi.MustParseURI("did:web:example.com"),
+		IssuanceDate:      time.Now(),
+		ExpirationDate:    &expirationDate,
+		CredentialSubject: []map[string]any{{"id": "did:web:example.com", "origin": "https://example.com"}},
+	}
+}
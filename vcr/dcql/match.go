@@ -0,0 +1,293 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package dcql
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
+)
+
+const (
+	// FormatLDPVC is the DCQL format identifier for W3C Verifiable Credentials secured with Data Integrity (JSON-LD) proofs.
+	FormatLDPVC = "ldp_vc"
+	// FormatJWTVC is the DCQL format identifier for W3C Verifiable Credentials secured as JWT.
+	FormatJWTVC = "jwt_vc_json"
+	// FormatSDJWTVC is the DCQL format identifier for SD-JWT Verifiable Credentials.
+	FormatSDJWTVC = "dc+sd-jwt"
+)
+
+// credentialFormats maps DCQL format identifiers to the credential formats of this node.
+// The identifiers used by Presentation Exchange are accepted as well.
+var credentialFormats = map[string]string{
+	FormatLDPVC:                 vc.JSONLDCredentialProofFormat,
+	FormatJWTVC:                 vc.JWTCredentialProofFormat,
+	vc.JWTCredentialProofFormat: vc.JWTCredentialProofFormat,
+	FormatSDJWTVC:               sdjwt.Format,
+	sdjwt.Format:                sdjwt.Format,
+}
+
+// Match selects the credentials that satisfy the query, returning them keyed by Credential Query ID.
+// Unless a Credential Query allows multiple credentials, only the first matching credential is selected for it.
+// It returns ErrNoCredentials if the credentials don't satisfy the query.
+func (q Query) Match(credentials []vc.VerifiableCredential) (map[string][]vc.VerifiableCredential, error) {
+	candidates := make(map[string][]vc.VerifiableCredential)
+	for _, credentialQuery := range q.Credentials {
+		for _, credential := range credentials {
+			matches, err := credentialQuery.Matches(credential)
+			if err != nil {
+				return nil, err
+			}
+			if !matches {
+				continue
+			}
+			candidates[credentialQuery.ID] = append(candidates[credentialQuery.ID], credential)
+			if !credentialQuery.Multiple {
+				break
+			}
+		}
+	}
+	selected, err := q.selectCredentialQueries(func(id string) bool {
+		return len(candidates[id]) > 0
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string][]vc.VerifiableCredential)
+	for _, id := range selected {
+		result[id] = candidates[id]
+	}
+	return result, nil
+}
+
+// selectCredentialQueries returns the IDs of the Credential Queries that make up the response, given which Credential Queries are satisfied.
+// Without Credential Sets, all Credential Queries must be satisfied.
+// Otherwise, the first satisfied option of every Credential Set is selected.
+// It returns ErrNoCredentials if the query can't be satisfied.
+func (q Query) selectCredentialQueries(satisfied func(id string) bool) ([]string, error) {
+	var result []string
+	if len(q.CredentialSets) == 0 {
+		var missing []string
+		for _, credentialQuery := range q.Credentials {
+			if satisfied(credentialQuery.ID) {
+				result = append(result, credentialQuery.ID)
+			} else {
+				missing = append(missing, credentialQuery.ID)
+			}
+		}
+		if len(missing) > 0 {
+			return nil, errors.Join(ErrNoCredentials, fmt.Errorf("credential queries not satisfied: %s", strings.Join(missing, ", ")))
+		}
+		return result, nil
+	}
+	for i, credentialSet := range q.CredentialSets {
+		option := credentialSet.firstSatisfiedOption(satisfied)
+		if option == nil {
+			if credentialSet.IsRequired() {
+				return nil, errors.Join(ErrNoCredentials, fmt.Errorf("credential set %d not satisfied", i))
+			}
+			continue
+		}
+		for _, id := range option {
+			if !slices.Contains(result, id) {
+				result = append(result, id)
+			}
+		}
+	}
+	return result, nil
+}
+
+func (c CredentialSetQuery) firstSatisfiedOption(satisfied func(id string) bool) []string {
+	for _, option := range c.Options {
+		optionSatisfied := true
+		for _, id := range option {
+			if !satisfied(id) {
+				optionSatisfied = false
+				break
+			}
+		}
+		if optionSatisfied {
+			return option
+		}
+	}
+	return nil
+}
+
+// Matches checks whether the credential satisfies the Credential Query.
+// Enveloped SD-JWT VCs are matched using the claims they disclose.
+func (c CredentialQuery) Matches(credential vc.VerifiableCredential) (bool, error) {
+	if credentialFormats[c.Format] != credentialFormat(credential) {
+		return false, nil
+	}
+	expanded, err := sdjwt.Expand(credential)
+	if err != nil {
+		return false, err
+	}
+	if !c.matchTypes(*expanded) {
+		return false, nil
+	}
+	if len(c.Claims) == 0 {
+		return true, nil
+	}
+	credentialAsMap, err := credentialToMap(*expanded)
+	if err != nil {
+		return false, err
+	}
+	if len(c.ClaimSets) == 0 {
+		for _, claim := range c.Claims {
+			if !claim.matches(credentialAsMap) {
+				return false, nil
+			}
+		}
+		return true, nil
+	}
+	for _, claimSet := range c.ClaimSets {
+		claimSetMatches := true
+		for _, claim := range c.Claims {
+			if slices.Contains(claimSet, claim.ID) && !claim.matches(credentialAsMap) {
+				claimSetMatches = false
+				break
+			}
+		}
+		if claimSetMatches {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// matchTypes checks the credential's type against meta.type_values.
+func (c CredentialQuery) matchTypes(credential vc.VerifiableCredential) bool {
+	if c.Meta == nil || len(c.Meta.TypeValues) == 0 {
+		return true
+	}
+	for _, typeValues := range c.Meta.TypeValues {
+		allPresent := true
+		for _, typeValue := range typeValues {
+			if !slices.ContainsFunc(credential.Type, func(curr ssi.URI) bool { return curr.String() == typeValue }) {
+				allPresent = false
+				break
+			}
+		}
+		if allPresent {
+			return true
+		}
+	}
+	return false
+}
+
+// matches checks whether the claim is present in the credential, and if values are specified, whether it has one of those values.
+func (c ClaimsQuery) matches(credential map[string]interface{}) bool {
+	selected := selectClaims(credential, c.Path)
+	if len(c.Values) == 0 {
+		return len(selected) > 0
+	}
+	for _, value := range selected {
+		for _, expected := range c.Values {
+			if reflect.DeepEqual(normalizeNumber(value), normalizeNumber(expected)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// selectClaims applies the claims path pointer to the given JSON value, returning the selected values.
+// Since W3C credentials may contain a single item (e.g. credentialSubject) as object or array,
+// a string element applied to an array selects the key from all objects in the array.
+func selectClaims(value interface{}, path []interface{}) []interface{} {
+	current := []interface{}{value}
+	for _, element := range path {
+		var next []interface{}
+		for _, curr := range current {
+			switch pathElement := element.(type) {
+			case string:
+				switch typed := curr.(type) {
+				case map[string]interface{}:
+					if child, ok := typed[pathElement]; ok {
+						next = append(next, child)
+					}
+				case []interface{}:
+					for _, item := range typed {
+						if object, ok := item.(map[string]interface{}); ok {
+							if child, ok := object[pathElement]; ok {
+								next = append(next, child)
+							}
+						}
+					}
+				}
+			case nil:
+				if array, ok := curr.([]interface{}); ok {
+					next = append(next, array...)
+				}
+			default:
+				index, isNumber := normalizeNumber(pathElement).(float64)
+				if array, isArray := curr.([]interface{}); isNumber && isArray && index >= 0 && int(index) < len(array) {
+					next = append(next, array[int(index)])
+				}
+			}
+		}
+		current = next
+	}
+	return current
+}
+
+// normalizeNumber converts integers to float64, so they can be compared to numbers unmarshalled from JSON.
+func normalizeNumber(value interface{}) interface{} {
+	if i, ok := value.(int); ok {
+		return float64(i)
+	}
+	return value
+}
+
+// credentialFormat returns the format of the credential, taking enveloped SD-JWT VCs into account.
+func credentialFormat(credential vc.VerifiableCredential) string {
+	if sdjwt.IsEnveloped(credential) {
+		return sdjwt.Format
+	}
+	return credential.Format()
+}
+
+// credentialToMap converts the credential to a generic JSON object, so claims paths can be applied to it.
+func credentialToMap(credential vc.VerifiableCredential) (map[string]interface{}, error) {
+	var data []byte
+	var err error
+	if credential.Format() == vc.JWTCredentialProofFormat {
+		// JWT-VCs marshal to a JSON string, so marshal an alias to get a JSON object with the VC properties.
+		type Alias vc.VerifiableCredential
+		data, err = json.Marshal(Alias(credential))
+	} else {
+		data, err = json.Marshal(credential)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var result map[string]interface{}
+	if err = json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,252 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package dcql
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/go-did/vc"
+	vcrTest "github.com/nuts-foundation/nuts-node/vcr/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCredentialQuery_Matches(t *testing.T) {
+	ldpCredential := vcrTest.ValidNutsOrganizationCredential(t)
+	jwtCredential := vcrTest.JWTNutsOrganizationCredential(t, did.MustParseDID("did:web:example.com"))
+	organizationTypes := &CredentialQueryMeta{TypeValues: [][]string{{"NutsOrganizationCredential"}}}
+	cityPath := []interface{}{"credentialSubject", "organization", "city"}
+
+	t.Run("JSON-LD", func(t *testing.T) {
+		query, err := Parse([]byte(organizationQuery))
+		require.NoError(t, err)
+
+		matches, err := query.Credentials[0].Matches(ldpCredential)
+
+		require.NoError(t, err)
+		assert.True(t, matches)
+	})
+	t.Run("JWT", func(t *testing.T) {
+		query := CredentialQuery{ID: "a", Format: FormatJWTVC, Meta: organizationTypes, Claims: []ClaimsQuery{{Path: cityPath, Values: []interface{}{"IJbergen"}}}}
+
+		matches, err := query.Matches(jwtCredential)
+
+		require.NoError(t, err)
+		assert.True(t, matches)
+	})
+	t.Run("SD-JWT", func(t *testing.T) {
+		issuerKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		credential := vcrTest.SDJWTCredential(t, "did:web:example.com#1", issuerKey, did.MustParseDID("did:web:example.com:holder"))
+		query := CredentialQuery{
+			ID:     "a",
+			Format: FormatSDJWTVC,
+			Meta:   &CredentialQueryMeta{TypeValues: [][]string{{"ExampleCredential"}}},
+			Claims: []ClaimsQuery{{Path: []interface{}{"credentialSubject", "name"}, Values: []interface{}{"care"}}},
+		}
+
+		matches, err := query.Matches(credential)
+
+		require.NoError(t, err)
+		assert.True(t, matches)
+	})
+	t.Run("format mismatch", func(t *testing.T) {
+		query := CredentialQuery{ID: "a", Format: FormatJWTVC}
+
+		matches, err := query.Matches(ldpCredential)
+
+		require.NoError(t, err)
+		assert.False(t, matches)
+	})
+	t.Run("unsupported format", func(t *testing.T) {
+		query := CredentialQuery{ID: "a", Format: "mso_mdoc"}
+
+		matches, err := query.Matches(ldpCredential)
+
+		require.NoError(t, err)
+		assert.False(t, matches)
+	})
+	t.Run("type mismatch", func(t *testing.T) {
+		query := CredentialQuery{ID: "a", Format: FormatLDPVC, Meta: &CredentialQueryMeta{TypeValues: [][]string{{"NutsOrganizationCredential", "OtherCredential"}}}}
+
+		matches, err := query.Matches(ldpCredential)
+
+		require.NoError(t, err)
+		assert.False(t, matches)
+	})
+	t.Run("one of the type values matches", func(t *testing.T) {
+		query := CredentialQuery{ID: "a", Format: FormatLDPVC, Meta: &CredentialQueryMeta{TypeValues: [][]string{{"OtherCredential"}, {"VerifiableCredential", "NutsOrganizationCredential"}}}}
+
+		matches, err := query.Matches(ldpCredential)
+
+		require.NoError(t, err)
+		assert.True(t, matches)
+	})
+	t.Run("claim value mismatch", func(t *testing.T) {
+		query := CredentialQuery{ID: "a", Format: FormatLDPVC, Claims: []ClaimsQuery{{Path: cityPath, Values: []interface{}{"Amsterdam"}}}}
+
+		matches, err := query.Matches(ldpCredential)
+
+		require.NoError(t, err)
+		assert.False(t, matches)
+	})
+	t.Run("claim not present", func(t *testing.T) {
+		query := CredentialQuery{ID: "a", Format: FormatLDPVC, Claims: []ClaimsQuery{{Path: []interface{}{"credentialSubject", "organization", "agb"}}}}
+
+		matches, err := query.Matches(ldpCredential)
+
+		require.NoError(t, err)
+		assert.False(t, matches)
+	})
+	t.Run("claim sets", func(t *testing.T) {
+		query := CredentialQuery{
+			ID:     "a",
+			Format: FormatLDPVC,
+			Claims: []ClaimsQuery{
+				{ID: "agb", Path: []interface{}{"credentialSubject", "organization", "agb"}},
+				{ID: "city", Path: cityPath},
+			},
+		}
+		t.Run("first set matches", func(t *testing.T) {
+			query.ClaimSets = [][]string{{"city"}, {"agb"}}
+
+			matches, err := query.Matches(ldpCredential)
+
+			require.NoError(t, err)
+			assert.True(t, matches)
+		})
+		t.Run("second set matches", func(t *testing.T) {
+			query.ClaimSets = [][]string{{"agb", "city"}, {"city"}}
+
+			matches, err := query.Matches(ldpCredential)
+
+			require.NoError(t, err)
+			assert.True(t, matches)
+		})
+		t.Run("no set matches", func(t *testing.T) {
+			query.ClaimSets = [][]string{{"agb"}}
+
+			matches, err := query.Matches(ldpCredential)
+
+			require.NoError(t, err)
+			assert.False(t, matches)
+		})
+	})
+}
+
+func TestQuery_Match(t *testing.T) {
+	ldpCredential := vcrTest.ValidNutsOrganizationCredential(t)
+	jwtCredential := vcrTest.JWTNutsOrganizationCredential(t, did.MustParseDID("did:web:example.com"))
+	otherJWTCredential := vcrTest.JWTNutsOrganizationCredential(t, did.MustParseDID("did:web:example.com"))
+	credentials := []vc.VerifiableCredential{ldpCredential, jwtCredential, otherJWTCredential}
+
+	t.Run("ok", func(t *testing.T) {
+		query := Query{Credentials: []CredentialQuery{{ID: "ldp", Format: FormatLDPVC}, {ID: "jwt", Format: FormatJWTVC}}}
+
+		result, err := query.Match(credentials)
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]vc.VerifiableCredential{
+			"ldp": {ldpCredential},
+			"jwt": {jwtCredential},
+		}, result)
+	})
+	t.Run("multiple", func(t *testing.T) {
+		query := Query{Credentials: []CredentialQuery{{ID: "jwt", Format: FormatJWTVC, Multiple: true}}}
+
+		result, err := query.Match(credentials)
+
+		require.NoError(t, err)
+		assert.Equal(t, []vc.VerifiableCredential{jwtCredential, otherJWTCredential}, result["jwt"])
+	})
+	t.Run("credential query not satisfied", func(t *testing.T) {
+		query := Query{Credentials: []CredentialQuery{{ID: "ldp", Format: FormatLDPVC}, {ID: "sd-jwt", Format: FormatSDJWTVC}}}
+
+		result, err := query.Match(credentials)
+
+		assert.ErrorIs(t, err, ErrNoCredentials)
+		assert.ErrorContains(t, err, "credential queries not satisfied: sd-jwt")
+		assert.Nil(t, result)
+	})
+	t.Run("credential sets", func(t *testing.T) {
+		optional := false
+		query := Query{
+			Credentials: []CredentialQuery{
+				{ID: "ldp", Format: FormatLDPVC},
+				{ID: "jwt", Format: FormatJWTVC},
+				{ID: "sd-jwt", Format: FormatSDJWTVC},
+			},
+		}
+		t.Run("first satisfied option is selected", func(t *testing.T) {
+			query.CredentialSets = []CredentialSetQuery{{Options: [][]string{{"sd-jwt"}, {"jwt"}, {"ldp"}}}}
+
+			result, err := query.Match(credentials)
+
+			require.NoError(t, err)
+			assert.Equal(t, map[string][]vc.VerifiableCredential{"jwt": {jwtCredential}}, result)
+		})
+		t.Run("unsatisfied optional set is skipped", func(t *testing.T) {
+			query.CredentialSets = []CredentialSetQuery{
+				{Options: [][]string{{"ldp"}}},
+				{Options: [][]string{{"sd-jwt"}}, Required: &optional},
+			}
+
+			result, err := query.Match(credentials)
+
+			require.NoError(t, err)
+			assert.Equal(t, map[string][]vc.VerifiableCredential{"ldp": {ldpCredential}}, result)
+		})
+		t.Run("required set not satisfied", func(t *testing.T) {
+			query.CredentialSets = []CredentialSetQuery{{Options: [][]string{{"ldp", "sd-jwt"}}}}
+
+			_, err := query.Match(credentials)
+
+			assert.ErrorIs(t, err, ErrNoCredentials)
+			assert.ErrorContains(t, err, "credential set 0 not satisfied")
+		})
+	})
+}
+
+func Test_selectClaims(t *testing.T) {
+	value := map[string]interface{}{
+		"credentialSubject": []interface{}{
+			map[string]interface{}{"name": "a", "roles": []interface{}{"nurse", "doctor"}},
+			map[string]interface{}{"name": "b"},
+		},
+	}
+	t.Run("key applied to array selects from all items", func(t *testing.T) {
+		assert.Equal(t, []interface{}{"a", "b"}, selectClaims(value, []interface{}{"credentialSubject", "name"}))
+	})
+	t.Run("index", func(t *testing.T) {
+		assert.Equal(t, []interface{}{"doctor"}, selectClaims(value, []interface{}{"credentialSubject", float64(0), "roles", 1}))
+	})
+	t.Run("index out of bounds", func(t *testing.T) {
+		assert.Empty(t, selectClaims(value, []interface{}{"credentialSubject", float64(5)}))
+	})
+	t.Run("wildcard", func(t *testing.T) {
+		assert.Equal(t, []interface{}{"nurse", "doctor"}, selectClaims(value, []interface{}{"credentialSubject", nil, "roles", nil}))
+	})
+	t.Run("unknown key", func(t *testing.T) {
+		assert.Empty(t, selectClaims(value, []interface{}{"issuer"}))
+	})
+}
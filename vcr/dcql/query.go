@@ -0,0 +1,201 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+// Package dcql implements the Digital Credentials Query Language (DCQL) as specified by OpenID4VP:
+// https://openid.net/specs/openid-4-verifiable-presentations-1_0.html#name-digital-credentials-query-l
+// It is the successor of Presentation Exchange (see package pe) for requesting credentials from a wallet.
+package dcql
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+
+	"github.com/nuts-foundation/nuts-node/vcr/pe"
+)
+
+// ErrNoCredentials is returned when the given credentials can't satisfy a query.
+var ErrNoCredentials = errors.New("missing credentials")
+
+// idPattern is the pattern Credential Query, Claims Query IDs must conform to.
+var idPattern = regexp.MustCompile("^[A-Za-z0-9_-]+$")
+
+// WalletOwnerMapping is a map of WalletOwnerType to DCQL Query, the DCQL equivalent of pe.WalletOwnerMapping.
+type WalletOwnerMapping map[pe.WalletOwnerType]Query
+
+// Query is a DCQL query, which is passed to the wallet using the dcql_query parameter.
+type Query struct {
+	// Credentials contains the requested credentials.
+	Credentials []CredentialQuery `json:"credentials"`
+	// CredentialSets contains additional constraints on which of the requested credentials to return.
+	// If absent, all requested credentials must be returned.
+	CredentialSets []CredentialSetQuery `json:"credential_sets,omitempty"`
+}
+
+// CredentialQuery specifies a request for one (or more, if Multiple is true) credentials.
+type CredentialQuery struct {
+	// ID identifies the Credential Query in the query and the response (vp_token).
+	ID string `json:"id"`
+	// Format is the format of the requested credential, e.g. ldp_vc or jwt_vc_json.
+	Format string `json:"format"`
+	// Multiple indicates whether multiple credentials may be returned for this Credential Query.
+	Multiple bool `json:"multiple,omitempty"`
+	// Meta contains format-specific constraints on the credential.
+	Meta *CredentialQueryMeta `json:"meta,omitempty"`
+	// Claims specifies the claims the credential must contain.
+	Claims []ClaimsQuery `json:"claims,omitempty"`
+	// ClaimSets specifies combinations of claims (by Claims Query ID) that satisfy the Credential Query, in order of preference.
+	ClaimSets [][]string `json:"claim_sets,omitempty"`
+}
+
+// CredentialQueryMeta contains the format-specific constraints of a Credential Query.
+type CredentialQueryMeta struct {
+	// TypeValues specifies the accepted W3C credential types.
+	// A credential matches if its type contains all values of at least one of the inner arrays.
+	// SD-JWT VCs are enveloped W3C credentials, so type_values also applies to them.
+	TypeValues [][]string `json:"type_values,omitempty"`
+}
+
+// ClaimsQuery specifies a claim the credential must contain.
+type ClaimsQuery struct {
+	// ID identifies the Claims Query within the Credential Query, required when ClaimSets are used.
+	ID string `json:"id,omitempty"`
+	// Path is the claims path pointer: strings select object keys, non-negative integers select array elements and null selects all array elements.
+	Path []interface{} `json:"path"`
+	// Values optionally restricts the claim to the given values.
+	Values []interface{} `json:"values,omitempty"`
+}
+
+// CredentialSetQuery specifies combinations of Credential Queries (by ID) that satisfy the query.
+type CredentialSetQuery struct {
+	// Options contains the combinations of Credential Query IDs, in order of preference.
+	Options [][]string `json:"options"`
+	// Required indicates whether the Credential Set must be satisfied. Defaults to true.
+	Required *bool `json:"required,omitempty"`
+}
+
+// IsRequired returns whether the Credential Set must be satisfied.
+func (c CredentialSetQuery) IsRequired() bool {
+	return c.Required == nil || *c.Required
+}
+
+// Parse parses and validates a DCQL query.
+func Parse(data []byte) (*Query, error) {
+	var result Query
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("invalid DCQL query: %w", err)
+	}
+	if err := result.Validate(); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Validate checks whether the query is well-formed.
+func (q Query) Validate() error {
+	if len(q.Credentials) == 0 {
+		return errors.New("invalid DCQL query: credentials must not be empty")
+	}
+	credentialQueryIDs := make(map[string]bool)
+	for _, credentialQuery := range q.Credentials {
+		if !idPattern.MatchString(credentialQuery.ID) {
+			return fmt.Errorf("invalid DCQL query: invalid credential query ID: '%s'", credentialQuery.ID)
+		}
+		if credentialQueryIDs[credentialQuery.ID] {
+			return fmt.Errorf("invalid DCQL query: duplicate credential query ID: %s", credentialQuery.ID)
+		}
+		credentialQueryIDs[credentialQuery.ID] = true
+		if err := credentialQuery.validate(); err != nil {
+			return fmt.Errorf("invalid DCQL query: credential query '%s': %w", credentialQuery.ID, err)
+		}
+	}
+	for _, credentialSet := range q.CredentialSets {
+		if len(credentialSet.Options) == 0 {
+			return errors.New("invalid DCQL query: credential set options must not be empty")
+		}
+		for _, option := range credentialSet.Options {
+			if len(option) == 0 {
+				return errors.New("invalid DCQL query: credential set option must not be empty")
+			}
+			for _, id := range option {
+				if !credentialQueryIDs[id] {
+					return fmt.Errorf("invalid DCQL query: credential set refers to unknown credential query: %s", id)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (c CredentialQuery) validate() error {
+	if c.Format == "" {
+		return errors.New("format is required")
+	}
+	claimIDs := make(map[string]bool)
+	for _, claim := range c.Claims {
+		if len(claim.Path) == 0 {
+			return errors.New("claim path must not be empty")
+		}
+		for _, element := range claim.Path {
+			if !isValidPathElement(element) {
+				return fmt.Errorf("invalid claim path element: %v", element)
+			}
+		}
+		if claim.ID == "" {
+			if len(c.ClaimSets) > 0 {
+				return errors.New("claims must have an ID when claim_sets are used")
+			}
+			continue
+		}
+		if !idPattern.MatchString(claim.ID) {
+			return fmt.Errorf("invalid claim ID: '%s'", claim.ID)
+		}
+		if claimIDs[claim.ID] {
+			return fmt.Errorf("duplicate claim ID: %s", claim.ID)
+		}
+		claimIDs[claim.ID] = true
+	}
+	for _, claimSet := range c.ClaimSets {
+		if len(claimSet) == 0 {
+			return errors.New("claim set must not be empty")
+		}
+		for _, id := range claimSet {
+			if !claimIDs[id] {
+				return fmt.Errorf("claim set refers to unknown claim: %s", id)
+			}
+		}
+	}
+	return nil
+}
+
+// isValidPathElement checks whether the claims path pointer element is a string, a non-negative integer or null.
+func isValidPathElement(element interface{}) bool {
+	switch typed := element.(type) {
+	case nil:
+		return true
+	case string:
+		return true
+	case float64:
+		return typed >= 0 && typed == math.Trunc(typed)
+	case int:
+		return typed >= 0
+	}
+	return false
+}
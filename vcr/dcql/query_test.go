@@ -0,0 +1,120 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package dcql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const organizationQuery = `{
+  "credentials": [
+    {
+      "id": "organization",
+      "format": "ldp_vc",
+      "meta": {
+        "type_values": [["NutsOrganizationCredential"]]
+      },
+      "claims": [
+        {"id": "name", "path": ["credentialSubject", "organization", "name"]},
+        {"id": "city", "path": ["credentialSubject", "organization", "city"], "values": ["IJbergen"]}
+      ]
+    }
+  ]
+}`
+
+func TestParse(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		query, err := Parse([]byte(organizationQuery))
+
+		require.NoError(t, err)
+		require.Len(t, query.Credentials, 1)
+		assert.Equal(t, "organization", query.Credentials[0].ID)
+		assert.Equal(t, FormatLDPVC, query.Credentials[0].Format)
+		assert.Equal(t, [][]string{{"NutsOrganizationCredential"}}, query.Credentials[0].Meta.TypeValues)
+		require.Len(t, query.Credentials[0].Claims, 2)
+		assert.Equal(t, []interface{}{"IJbergen"}, query.Credentials[0].Claims[1].Values)
+	})
+	t.Run("invalid JSON", func(t *testing.T) {
+		_, err := Parse([]byte(`{`))
+
+		assert.ErrorContains(t, err, "invalid DCQL query")
+	})
+	t.Run("invalid query", func(t *testing.T) {
+		_, err := Parse([]byte(`{"credentials": []}`))
+
+		assert.EqualError(t, err, "invalid DCQL query: credentials must not be empty")
+	})
+}
+
+func TestQuery_Validate(t *testing.T) {
+	valid := func() Query {
+		return Query{
+			Credentials: []CredentialQuery{
+				{ID: "a", Format: FormatLDPVC, Claims: []ClaimsQuery{{ID: "name", Path: []interface{}{"credentialSubject", "name"}}}},
+				{ID: "b", Format: FormatJWTVC},
+			},
+			CredentialSets: []CredentialSetQuery{{Options: [][]string{{"a"}, {"b"}}}},
+		}
+	}
+	t.Run("ok", func(t *testing.T) {
+		assert.NoError(t, valid().Validate())
+	})
+	t.Run("ok - path with index and wildcard", func(t *testing.T) {
+		query := valid()
+		query.Credentials[0].Claims[0].Path = []interface{}{"credentialSubject", float64(0), nil}
+
+		assert.NoError(t, query.Validate())
+	})
+	testCases := []struct {
+		name    string
+		modify  func(query *Query)
+		message string
+	}{
+		{"invalid credential query ID", func(q *Query) { q.Credentials[0].ID = "a b" }, "invalid DCQL query: invalid credential query ID: 'a b'"},
+		{"duplicate credential query ID", func(q *Query) { q.Credentials[1].ID = "a" }, "invalid DCQL query: duplicate credential query ID: a"},
+		{"missing format", func(q *Query) { q.Credentials[1].Format = "" }, "invalid DCQL query: credential query 'b': format is required"},
+		{"empty claim path", func(q *Query) { q.Credentials[0].Claims[0].Path = nil }, "invalid DCQL query: credential query 'a': claim path must not be empty"},
+		{"negative path index", func(q *Query) { q.Credentials[0].Claims[0].Path = []interface{}{float64(-1)} }, "invalid DCQL query: credential query 'a': invalid claim path element: -1"},
+		{"invalid path element", func(q *Query) { q.Credentials[0].Claims[0].Path = []interface{}{true} }, "invalid DCQL query: credential query 'a': invalid claim path element: true"},
+		{"claim without ID in claim set", func(q *Query) {
+			q.Credentials[0].Claims = append(q.Credentials[0].Claims, ClaimsQuery{Path: []interface{}{"id"}})
+			q.Credentials[0].ClaimSets = [][]string{{"name"}}
+		}, "invalid DCQL query: credential query 'a': claims must have an ID when claim_sets are used"},
+		{"claim set refers to unknown claim", func(q *Query) { q.Credentials[0].ClaimSets = [][]string{{"city"}} }, "invalid DCQL query: credential query 'a': claim set refers to unknown claim: city"},
+		{"empty credential set options", func(q *Query) { q.CredentialSets[0].Options = nil }, "invalid DCQL query: credential set options must not be empty"},
+		{"credential set refers to unknown credential query", func(q *Query) { q.CredentialSets[0].Options = [][]string{{"c"}} }, "invalid DCQL query: credential set refers to unknown credential query: c"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			query := valid()
+			testCase.modify(&query)
+
+			assert.EqualError(t, query.Validate(), testCase.message)
+		})
+	}
+}
+
+func TestCredentialSetQuery_IsRequired(t *testing.T) {
+	required := false
+	assert.True(t, CredentialSetQuery{}.IsRequired())
+	assert.False(t, CredentialSetQuery{Required: &required}.IsRequired())
+}
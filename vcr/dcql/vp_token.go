@@ -0,0 +1,125 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package dcql
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/nuts-foundation/go-did/vc"
+)
+
+// VPToken is the vp_token of an OpenID4VP Authorization Response to a DCQL query.
+// It contains the presentations keyed by Credential Query ID.
+type VPToken map[string][]vc.VerifiablePresentation
+
+// ParseVPToken parses the vp_token of an Authorization Response to a DCQL query.
+func ParseVPToken(data []byte) (VPToken, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid vp_token: %w", err)
+	}
+	if len(raw) == 0 {
+		return nil, errors.New("invalid vp_token: no presentations")
+	}
+	result := make(VPToken)
+	for id, value := range raw {
+		var presentations []vc.VerifiablePresentation
+		var err error
+		if len(value) > 0 && value[0] == '[' {
+			err = json.Unmarshal(value, &presentations)
+		} else {
+			// earlier drafts of OpenID4VP specified a single presentation instead of an array
+			var presentation vc.VerifiablePresentation
+			err = json.Unmarshal(value, &presentation)
+			presentations = append(presentations, presentation)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid vp_token: invalid presentation for credential query '%s': %w", id, err)
+		}
+		if len(presentations) == 0 {
+			return nil, fmt.Errorf("invalid vp_token: no presentations for credential query '%s'", id)
+		}
+		result[id] = presentations
+	}
+	return result, nil
+}
+
+// Presentations returns all presentations in the VP Token, ordered by Credential Query ID.
+func (t VPToken) Presentations() []vc.VerifiablePresentation {
+	ids := make([]string, 0, len(t))
+	for id := range t {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	var result []vc.VerifiablePresentation
+	for _, id := range ids {
+		result = append(result, t[id]...)
+	}
+	return result
+}
+
+// Evaluate checks whether the presentations in the VP Token satisfy the query,
+// and returns the presented credentials keyed by Credential Query ID.
+// It does not verify the presentations themselves (e.g. signatures, nonce, audience), that's the caller's responsibility.
+func (q Query) Evaluate(token VPToken) (map[string][]vc.VerifiableCredential, error) {
+	result := make(map[string][]vc.VerifiableCredential)
+	for id, presentations := range token {
+		credentialQuery := q.credentialQuery(id)
+		if credentialQuery == nil {
+			return nil, fmt.Errorf("vp_token contains presentations for unknown credential query: %s", id)
+		}
+		if !credentialQuery.Multiple && len(presentations) > 1 {
+			return nil, fmt.Errorf("vp_token contains multiple presentations for credential query '%s'", id)
+		}
+		for _, presentation := range presentations {
+			if len(presentation.VerifiableCredential) == 0 {
+				return nil, fmt.Errorf("presentation for credential query '%s' does not contain credentials", id)
+			}
+			for _, credential := range presentation.VerifiableCredential {
+				matches, err := credentialQuery.Matches(credential)
+				if err != nil {
+					return nil, fmt.Errorf("presentation for credential query '%s': %w", id, err)
+				}
+				if !matches {
+					return nil, fmt.Errorf("presentation for credential query '%s' contains a credential that does not match", id)
+				}
+				result[id] = append(result[id], credential)
+			}
+		}
+	}
+	if _, err := q.selectCredentialQueries(func(id string) bool {
+		_, ok := token[id]
+		return ok
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (q Query) credentialQuery(id string) *CredentialQuery {
+	for _, curr := range q.Credentials {
+		if curr.ID == id {
+			return &curr
+		}
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package dcql
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/go-did/vc"
+	vcrTest "github.com/nuts-foundation/nuts-node/vcr/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVPToken(t *testing.T) {
+	subjectDID := did.MustParseDID("did:web:example.com")
+	ldpPresentation := vcrTest.CreateJSONLDPresentation(t, subjectDID, nil, vcrTest.ValidNutsOrganizationCredential(t))
+	jwtPresentation, _ := vcrTest.CreateJWTPresentation(t, subjectDID, nil, vcrTest.JWTNutsOrganizationCredential(t, subjectDID))
+
+	t.Run("ok", func(t *testing.T) {
+		data, _ := json.Marshal(VPToken{"ldp": {ldpPresentation}, "jwt": {jwtPresentation}})
+
+		token, err := ParseVPToken(data)
+
+		require.NoError(t, err)
+		require.Len(t, token, 2)
+		assert.Equal(t, ldpPresentation.ID, token["ldp"][0].ID)
+		assert.Equal(t, jwtPresentation.Raw(), token["jwt"][0].Raw())
+	})
+	t.Run("ok - single presentation", func(t *testing.T) {
+		data, _ := json.Marshal(map[string]interface{}{"jwt": jwtPresentation})
+
+		token, err := ParseVPToken(data)
+
+		require.NoError(t, err)
+		require.Len(t, token["jwt"], 1)
+		assert.Equal(t, jwtPresentation.Raw(), token["jwt"][0].Raw())
+	})
+	t.Run("invalid JSON", func(t *testing.T) {
+		_, err := ParseVPToken([]byte(`"not an object"`))
+
+		assert.ErrorContains(t, err, "invalid vp_token")
+	})
+	t.Run("empty", func(t *testing.T) {
+		_, err := ParseVPToken([]byte(`{}`))
+
+		assert.EqualError(t, err, "invalid vp_token: no presentations")
+	})
+	t.Run("empty array", func(t *testing.T) {
+		_, err := ParseVPToken([]byte(`{"jwt": []}`))
+
+		assert.EqualError(t, err, "invalid vp_token: no presentations for credential query 'jwt'")
+	})
+	t.Run("invalid presentation", func(t *testing.T) {
+		_, err := ParseVPToken([]byte(`{"jwt": ["invalid"]}`))
+
+		assert.ErrorContains(t, err, "invalid vp_token: invalid presentation for credential query 'jwt'")
+	})
+}
+
+func TestVPToken_Presentations(t *testing.T) {
+	subjectDID := did.MustParseDID("did:web:example.com")
+	a := vcrTest.CreateJSONLDPresentation(t, subjectDID, nil)
+	b := vcrTest.CreateJSONLDPresentation(t, subjectDID, nil)
+	c := vcrTest.CreateJSONLDPresentation(t, subjectDID, nil)
+
+	presentations := VPToken{"b": {b, c}, "a": {a}}.Presentations()
+
+	assert.Equal(t, []vc.VerifiablePresentation{a, b, c}, presentations)
+}
+
+func TestQuery_Evaluate(t *testing.T) {
+	subjectDID := did.MustParseDID("did:web:example.com")
+	ldpCredential := vcrTest.ValidNutsOrganizationCredential(t)
+	jwtCredential := vcrTest.JWTNutsOrganizationCredential(t, subjectDID)
+	ldpPresentation := vcrTest.CreateJSONLDPresentation(t, subjectDID, nil, ldpCredential)
+	jwtPresentation, _ := vcrTest.CreateJWTPresentation(t, subjectDID, nil, jwtCredential)
+	query := Query{Credentials: []CredentialQuery{{ID: "ldp", Format: FormatLDPVC}, {ID: "jwt", Format: FormatJWTVC}}}
+
+	t.Run("ok", func(t *testing.T) {
+		result, err := query.Evaluate(VPToken{"ldp": {ldpPresentation}, "jwt": {jwtPresentation}})
+
+		require.NoError(t, err)
+		require.Len(t, result["ldp"], 1)
+		assert.Equal(t, ldpCredential.ID, result["ldp"][0].ID)
+		require.Len(t, result["jwt"], 1)
+		assert.Equal(t, jwtCredential.Raw(), result["jwt"][0].Raw())
+	})
+	t.Run("unknown credential query", func(t *testing.T) {
+		_, err := query.Evaluate(VPToken{"ldp": {ldpPresentation}, "jwt": {jwtPresentation}, "other": {ldpPresentation}})
+
+		assert.EqualError(t, err, "vp_token contains presentations for unknown credential query: other")
+	})
+	t.Run("multiple presentations not allowed", func(t *testing.T) {
+		_, err := query.Evaluate(VPToken{"ldp": {ldpPresentation, ldpPresentation}, "jwt": {jwtPresentation}})
+
+		assert.EqualError(t, err, "vp_token contains multiple presentations for credential query 'ldp'")
+	})
+	t.Run("presentation without credentials", func(t *testing.T) {
+		emptyPresentation := vcrTest.CreateJSONLDPresentation(t, subjectDID, nil)
+
+		_, err := query.Evaluate(VPToken{"ldp": {emptyPresentation}, "jwt": {jwtPresentation}})
+
+		assert.EqualError(t, err, "presentation for credential query 'ldp' does not contain credentials")
+	})
+	t.Run("credential does not match", func(t *testing.T) {
+		_, err := query.Evaluate(VPToken{"ldp": {jwtPresentation}, "jwt": {jwtPresentation}})
+
+		assert.EqualError(t, err, "presentation for credential query 'ldp' contains a credential that does not match")
+	})
+	t.Run("query not satisfied", func(t *testing.T) {
+		_, err := query.Evaluate(VPToken{"ldp": {ldpPresentation}})
+
+		assert.ErrorIs(t, err, ErrNoCredentials)
+	})
+}
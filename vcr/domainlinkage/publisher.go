@@ -0,0 +1,144 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+// Package domainlinkage publishes DIF Well-Known DID Configuration resources for local DIDs.
+// See https://identity.foundation/.well-known/resources/did-configuration/
+package domainlinkage
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/jsonld"
+	"github.com/nuts-foundation/nuts-node/vcr/credential"
+	"github.com/nuts-foundation/nuts-node/vcr/issuer"
+	"github.com/nuts-foundation/nuts-node/vcr/log"
+	"github.com/nuts-foundation/nuts-node/vdr/didsubject"
+	"github.com/nuts-foundation/nuts-node/vdr/didweb"
+	"github.com/nuts-foundation/nuts-node/vdr/resolver"
+)
+
+// DefaultCredentialValidity is the validity of the Domain Linkage Credentials issued by the Publisher.
+const DefaultCredentialValidity = 30 * 24 * time.Hour
+
+// IssuerProvider provides the credential issuer. It's satisfied by the VCR, which only has an issuer after it has been configured.
+type IssuerProvider interface {
+	Issuer() issuer.Issuer
+}
+
+// Publisher creates the DID Configuration resource for an origin,
+// containing Domain Linkage Credentials for all local did:web DIDs that list the origin in their LinkedDomains service.
+type Publisher struct {
+	issuers     IssuerProvider
+	subjects    didsubject.Manager
+	didResolver resolver.DIDResolver
+	validity    time.Duration
+	// issued contains the issued credentials by DID and origin, so they aren't issued again on every request.
+	issued  map[string]vc.VerifiableCredential
+	mux     sync.Mutex
+	nowFunc func() time.Time
+}
+
+// NewPublisher creates a new Publisher.
+func NewPublisher(issuers IssuerProvider, subjects didsubject.Manager, didResolver resolver.DIDResolver) *Publisher {
+	return &Publisher{
+		issuers:     issuers,
+		subjects:    subjects,
+		didResolver: didResolver,
+		validity:    DefaultCredentialValidity,
+		issued:      make(map[string]vc.VerifiableCredential),
+		nowFunc:     time.Now,
+	}
+}
+
+// DIDConfiguration returns the DID Configuration resource for the given origin.
+// It contains a Domain Linkage Credential for every local did:web DID that has a LinkedDomains service listing the origin.
+// Credentials are re-issued when half of their validity has passed.
+func (p *Publisher) DIDConfiguration(ctx context.Context, origin string) (*credential.DIDConfiguration, error) {
+	origin, err := credential.ParseOrigin(origin)
+	if err != nil {
+		return nil, fmt.Errorf("invalid origin: %w", err)
+	}
+	subjects, err := p.subjects.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var credentials []vc.VerifiableCredential
+	for _, dids := range subjects {
+		for _, id := range dids {
+			if id.Method != didweb.MethodName {
+				continue
+			}
+			document, _, err := p.didResolver.Resolve(id, nil)
+			if err != nil {
+				log.Logger().WithError(err).Warnf("Unable to resolve DID for DID Configuration (did=%s)", id)
+				continue
+			}
+			if !credential.IsLinkedToOrigin(*document, origin) {
+				continue
+			}
+			linkageCredential, err := p.credential(ctx, id, origin)
+			if err != nil {
+				return nil, fmt.Errorf("unable to issue Domain Linkage Credential (did=%s): %w", id, err)
+			}
+			credentials = append(credentials, *linkageCredential)
+		}
+	}
+	result := credential.NewDIDConfiguration(credentials)
+	return &result, nil
+}
+
+func (p *Publisher) credential(ctx context.Context, id did.DID, origin string) (*vc.VerifiableCredential, error) {
+	key := id.String() + " " + origin
+	p.mux.Lock()
+	defer p.mux.Unlock()
+	now := p.nowFunc()
+	if existing, ok := p.issued[key]; ok && now.Before(existing.IssuanceDate.Add(p.validity/2)) {
+		return &existing, nil
+	}
+	expirationDate := now.Add(p.validity)
+	template := vc.VerifiableCredential{
+		Context:        []ssi.URI{vc.VCContextV1URI(), ssi.MustParseURI(jsonld.DIDConfigurationContext)},
+		Type:           []ssi.URI{credential.DomainLinkageCredentialTypeURI()},
+		Issuer:         id.URI(),
+		ExpirationDate: &expirationDate,
+		CredentialSubject: []map[string]any{
+			{
+				"id":     id.String(),
+				"origin": origin,
+			},
+		},
+	}
+	issued, err := p.issuers.Issuer().Issue(ctx, template, issuer.CredentialOptions{Format: vc.JWTCredentialProofFormat})
+	if err != nil {
+		return nil, err
+	}
+	// prune credentials that are no longer valid, to keep the cache from growing indefinitely
+	for k, curr := range p.issued {
+		if curr.ExpirationDate != nil && now.After(*curr.ExpirationDate) {
+			delete(p.issued, k)
+		}
+	}
+	p.issued[key] = *issued
+	return issued, nil
+}
@@ -0,0 +1,150 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package domainlinkage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/jsonld"
+	"github.com/nuts-foundation/nuts-node/vcr/credential"
+	"github.com/nuts-foundation/nuts-node/vcr/issuer"
+	"github.com/nuts-foundation/nuts-node/vdr/didsubject"
+	"github.com/nuts-foundation/nuts-node/vdr/resolver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var webDID = did.MustParseDID("did:web:example.com:iam:123")
+var nutsDID = did.MustParseDID("did:nuts:123")
+
+func TestPublisher_DIDConfiguration(t *testing.T) {
+	linkedDocument := &did.Document{
+		ID:      webDID,
+		Service: []did.Service{{Type: credential.LinkedDomainsServiceType, ServiceEndpoint: "https://example.com"}},
+	}
+	t.Run("ok", func(t *testing.T) {
+		ctx := newTestContext(t)
+		ctx.didResolver.EXPECT().Resolve(webDID, nil).Return(linkedDocument, nil, nil)
+		ctx.issuer.EXPECT().Issue(gomock.Any(), gomock.Any(), issuer.CredentialOptions{Format: vc.JWTCredentialProofFormat}).
+			DoAndReturn(func(_ context.Context, template vc.VerifiableCredential, _ issuer.CredentialOptions) (*vc.VerifiableCredential, error) {
+				template.IssuanceDate = time.Now()
+				return &template, nil
+			})
+
+		result, err := ctx.publisher.DIDConfiguration(context.Background(), "https://example.com/")
+
+		require.NoError(t, err)
+		assert.Equal(t, jsonld.DIDConfigurationContext, result.Context)
+		require.Len(t, result.LinkedDIDs, 1)
+		linkageCredential := result.LinkedDIDs[0]
+		assert.True(t, linkageCredential.IsType(credential.DomainLinkageCredentialTypeURI()))
+		assert.True(t, linkageCredential.ContainsContext(ssi.MustParseURI(jsonld.DIDConfigurationContext)))
+		assert.Equal(t, webDID.URI(), linkageCredential.Issuer)
+		assert.NotNil(t, linkageCredential.ExpirationDate)
+		assert.Equal(t, "https://example.com", linkageCredential.CredentialSubject[0]["origin"])
+		assert.Equal(t, webDID.String(), linkageCredential.CredentialSubject[0]["id"])
+	})
+	t.Run("issued credentials are reused until half of their validity", func(t *testing.T) {
+		ctx := newTestContext(t)
+		ctx.didResolver.EXPECT().Resolve(webDID, nil).Return(linkedDocument, nil, nil).Times(3)
+		ctx.issuer.EXPECT().Issue(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, template vc.VerifiableCredential, _ issuer.CredentialOptions) (*vc.VerifiableCredential, error) {
+				template.IssuanceDate = ctx.publisher.nowFunc()
+				return &template, nil
+			}).Times(2)
+
+		_, err := ctx.publisher.DIDConfiguration(context.Background(), "https://example.com")
+		require.NoError(t, err)
+		_, err = ctx.publisher.DIDConfiguration(context.Background(), "https://example.com")
+		require.NoError(t, err)
+		ctx.publisher.nowFunc = func() time.Time {
+			return time.Now().Add(DefaultCredentialValidity/2 + time.Minute)
+		}
+		_, err = ctx.publisher.DIDConfiguration(context.Background(), "https://example.com")
+		require.NoError(t, err)
+	})
+	t.Run("origin not linked", func(t *testing.T) {
+		ctx := newTestContext(t)
+		ctx.didResolver.EXPECT().Resolve(webDID, nil).Return(linkedDocument, nil, nil)
+
+		result, err := ctx.publisher.DIDConfiguration(context.Background(), "https://other.example.com")
+
+		require.NoError(t, err)
+		assert.Empty(t, result.LinkedDIDs)
+	})
+	t.Run("unresolvable DIDs are skipped", func(t *testing.T) {
+		ctx := newTestContext(t)
+		ctx.didResolver.EXPECT().Resolve(webDID, nil).Return(nil, nil, resolver.ErrNotFound)
+
+		result, err := ctx.publisher.DIDConfiguration(context.Background(), "https://example.com")
+
+		require.NoError(t, err)
+		assert.Empty(t, result.LinkedDIDs)
+	})
+	t.Run("issuance fails", func(t *testing.T) {
+		ctx := newTestContext(t)
+		ctx.didResolver.EXPECT().Resolve(webDID, nil).Return(linkedDocument, nil, nil)
+		ctx.issuer.EXPECT().Issue(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("failed"))
+
+		_, err := ctx.publisher.DIDConfiguration(context.Background(), "https://example.com")
+
+		assert.EqualError(t, err, "unable to issue Domain Linkage Credential (did=did:web:example.com:iam:123): failed")
+	})
+	t.Run("invalid origin", func(t *testing.T) {
+		publisher := NewPublisher(nil, nil, nil)
+
+		_, err := publisher.DIDConfiguration(context.Background(), "example.com")
+
+		assert.EqualError(t, err, "invalid origin: scheme must be https or http")
+	})
+}
+
+type testIssuerProvider struct {
+	issuer issuer.Issuer
+}
+
+func (t testIssuerProvider) Issuer() issuer.Issuer {
+	return t.issuer
+}
+
+type testContext struct {
+	issuer      *issuer.MockIssuer
+	didResolver *resolver.MockDIDResolver
+	publisher   *Publisher
+}
+
+func newTestContext(t *testing.T) testContext {
+	ctrl := gomock.NewController(t)
+	mockIssuer := issuer.NewMockIssuer(ctrl)
+	subjects := didsubject.NewMockManager(ctrl)
+	subjects.EXPECT().List(gomock.Any()).Return(map[string][]did.DID{"subject": {nutsDID, webDID}}, nil).AnyTimes()
+	didResolver := resolver.NewMockDIDResolver(ctrl)
+	return testContext{
+		issuer:      mockIssuer,
+		didResolver: didResolver,
+		publisher:   NewPublisher(testIssuerProvider{issuer: mockIssuer}, subjects, didResolver),
+	}
+}
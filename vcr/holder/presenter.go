@@ -33,6 +33,7 @@ import (
 	"github.com/nuts-foundation/nuts-node/crypto"
 	"github.com/nuts-foundation/nuts-node/vcr/credential"
 	"github.com/nuts-foundation/nuts-node/vcr/pe"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	"github.com/nuts-foundation/nuts-node/vcr/signature"
 	"github.com/nuts-foundation/nuts-node/vcr/signature/proof"
 	"github.com/nuts-foundation/nuts-node/vdr/resolver"
@@ -77,8 +78,13 @@ func (p presenter) buildSubmission(ctx context.Context, credentials map[did.DID]
 		return nil, nil, err
 	}
 
+	presentedCredentials, err := limitDisclosure(presentationDefinition, signInstruction)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	holderDID := signInstruction.Holder.URI()
-	vp, err := p.buildPresentation(ctx, &signInstruction.Holder, signInstruction.VerifiableCredentials, PresentationOptions{
+	vp, err := p.buildPresentation(ctx, &signInstruction.Holder, presentedCredentials, PresentationOptions{
 		Format: format,
 		Holder: &holderDID,
 		ProofOptions: proof.ProofOptions{
@@ -109,6 +115,11 @@ func (p presenter) buildPresentation(ctx context.Context, signerDID *did.DID, cr
 		return nil, fmt.Errorf("unable to resolve assertion key for signing VP (did=%s): %w", *signerDID, err)
 	}
 
+	credentials, err = p.bindSDJWTCredentials(ctx, credentials, options, kid)
+	if err != nil {
+		return nil, err
+	}
+
 	switch options.Format {
 	case JWTPresentationFormat:
 		return p.buildJWTPresentation(ctx, *signerDID, credentials, options, kid)
@@ -121,6 +132,73 @@ func (p presenter) buildPresentation(ctx context.Context, signerDID *did.DID, cr
 	}
 }
 
+// limitDisclosure limits the disclosures of the SD-JWT VCs in the sign instruction to what their input descriptor requires.
+func limitDisclosure(presentationDefinition pe.PresentationDefinition, signInstruction pe.SignInstruction) ([]vc.VerifiableCredential, error) {
+	result := make([]vc.VerifiableCredential, len(signInstruction.VerifiableCredentials))
+	for i, cred := range signInstruction.VerifiableCredentials {
+		result[i] = cred
+		if !sdjwt.IsEnveloped(cred) {
+			continue
+		}
+		sdJWT, err := sdjwt.Open(cred)
+		if err != nil {
+			return nil, err
+		}
+		limited, err := presentationDefinition.LimitDisclosure(signInstruction.Mappings[i].Id, *sdJWT)
+		if err != nil {
+			return nil, fmt.Errorf("unable to limit disclosure of SD-JWT VC: %w", err)
+		}
+		envelope, err := sdjwt.Envelope(*limited)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = *envelope
+	}
+	return result, nil
+}
+
+// bindSDJWTCredentials adds a key binding JWT to SD-JWT VCs, signed with the holder's key that is also used to sign the VP.
+// The key binding JWT is bound to the audience and nonce of the presentation.
+func (p presenter) bindSDJWTCredentials(ctx context.Context, credentials []vc.VerifiableCredential, options PresentationOptions, keyID string) ([]vc.VerifiableCredential, error) {
+	result := make([]vc.VerifiableCredential, len(credentials))
+	for i, cred := range credentials {
+		result[i] = cred
+		if !sdjwt.IsEnveloped(cred) {
+			continue
+		}
+		if options.Format != JWTPresentationFormat {
+			return nil, fmt.Errorf("SD-JWT VCs can only be presented in %s format", JWTPresentationFormat)
+		}
+		sdJWT, err := sdjwt.Open(cred)
+		if err != nil {
+			return nil, err
+		}
+		var audience, nonce string
+		if options.ProofOptions.Domain != nil {
+			audience = *options.ProofOptions.Domain
+		}
+		if options.ProofOptions.Nonce != nil {
+			nonce = *options.ProofOptions.Nonce
+		}
+		issuedAt := options.ProofOptions.Created
+		if issuedAt.IsZero() {
+			issuedAt = time.Now()
+		}
+		bound, err := sdJWT.Bind(ctx, func(ctx context.Context, claims map[string]interface{}, headers map[string]interface{}) (string, error) {
+			return p.signer.SignJWT(ctx, claims, headers, keyID)
+		}, audience, nonce, issuedAt)
+		if err != nil {
+			return nil, err
+		}
+		envelope, err := sdjwt.Envelope(*bound)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = *envelope
+	}
+	return result, nil
+}
+
 // buildJWTPresentation builds a JWT presentation according to https://www.w3.org/TR/vc-data-model/#json-web-token
 func (p presenter) buildJWTPresentation(ctx context.Context, subjectDID did.DID, credentials []vc.VerifiableCredential, options PresentationOptions, keyID string) (*vc.VerifiablePresentation, error) {
 	headers := map[string]interface{}{
@@ -20,6 +20,10 @@ package holder
 
 import (
 	"context"
+	crypt "crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	ssi "github.com/nuts-foundation/go-did"
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/go-did/vc"
@@ -32,6 +36,7 @@ import (
 	"github.com/nuts-foundation/nuts-node/storage/orm"
 	"github.com/nuts-foundation/nuts-node/vcr/credential"
 	"github.com/nuts-foundation/nuts-node/vcr/pe"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	"github.com/nuts-foundation/nuts-node/vcr/signature/proof"
 	"github.com/nuts-foundation/nuts-node/vcr/test"
 	"github.com/nuts-foundation/nuts-node/vdr"
@@ -218,6 +223,50 @@ func TestPresenter_buildPresentation(t *testing.T) {
 			assert.Equal(t, "claim", actualCustomClaim)
 		})
 	})
+	t.Run("SD-JWT VC", func(t *testing.T) {
+		issuerKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		sdJWTCredential := test.SDJWTCredential(t, "did:web:example.com#1", issuerKey, testDID)
+		domain := "https://example.com"
+		nonce := "the-nonce"
+		t.Run("ok - key binding JWT is added", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			keyResolver := resolver.NewMockKeyResolver(ctrl)
+			keyResolver.EXPECT().ResolveKey(testDID, nil, resolver.NutsSigningKeyType).Return(kid, key.PublicKey, nil)
+			w := presenter{documentLoader: jsonldManager.DocumentLoader(), signer: keyStore, keyResolver: keyResolver}
+			options := PresentationOptions{
+				Format: JWTPresentationFormat,
+				ProofOptions: proof.ProofOptions{
+					Domain: &domain,
+					Nonce:  &nonce,
+				},
+			}
+
+			result, err := w.buildPresentation(ctx, &testDID, []vc.VerifiableCredential{sdJWTCredential}, options)
+
+			require.NoError(t, err)
+			require.Len(t, result.VerifiableCredential, 1)
+			sdJWT, err := sdjwt.Open(result.VerifiableCredential[0])
+			require.NoError(t, err)
+			keyID, keyBinding, err := sdJWT.VerifyKeyBinding(func(_ string) (crypt.PublicKey, error) {
+				return key.PublicKey, nil
+			}, time.Now())
+			require.NoError(t, err)
+			assert.Equal(t, kid, keyID)
+			assert.Equal(t, []string{domain}, keyBinding.Audience())
+			assert.Equal(t, nonce, keyBinding.PrivateClaims()["nonce"])
+		})
+		t.Run("error - JSON-LD presentation", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			keyResolver := resolver.NewMockKeyResolver(ctrl)
+			keyResolver.EXPECT().ResolveKey(testDID, nil, resolver.NutsSigningKeyType).Return(kid, key.PublicKey, nil)
+			w := presenter{documentLoader: jsonldManager.DocumentLoader(), signer: keyStore, keyResolver: keyResolver}
+
+			result, err := w.buildPresentation(ctx, &testDID, []vc.VerifiableCredential{sdJWTCredential}, PresentationOptions{Format: JSONLDPresentationFormat})
+
+			assert.EqualError(t, err, "SD-JWT VCs can only be presented in jwt_vp format")
+			assert.Nil(t, result)
+		})
+	})
 	t.Run("deriving signer from VCs", func(t *testing.T) {
 		options := PresentationOptions{ProofOptions: proof.ProofOptions{}}
 
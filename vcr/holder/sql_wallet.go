@@ -36,6 +36,7 @@ import (
 	"github.com/nuts-foundation/nuts-node/vcr/credential/store"
 	"github.com/nuts-foundation/nuts-node/vcr/log"
 	"github.com/nuts-foundation/nuts-node/vcr/pe"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	"github.com/nuts-foundation/nuts-node/vcr/types"
 	"github.com/nuts-foundation/nuts-node/vcr/verifier"
 	"github.com/nuts-foundation/nuts-node/vdr/resolver"
@@ -128,10 +129,10 @@ func (h sqlWallet) List(_ context.Context, holderDID did.DID) ([]vc.VerifiableCr
 	// now validate credentials and remove invalid ones
 	validCredentials := make([]vc.VerifiableCredential, 0, len(credentials))
 	for _, credential := range credentials {
-		// we only want to check expiration and revocation status
+		// we only want to check expiration, revocation and suspension status
 		if err = h.verifier.Verify(credential, true, false, nil); err == nil {
 			validCredentials = append(validCredentials, credential)
-		} else if !errors.Is(err, types.ErrCredentialNotValidAtTime) && !errors.Is(err, types.ErrRevoked) {
+		} else if !errors.Is(err, types.ErrCredentialNotValidAtTime) && !errors.Is(err, types.ErrRevoked) && !errors.Is(err, types.ErrSuspended) {
 			// a possible technical error has occurred that should be logged.
 			log.Logger().WithError(err).WithField(core.LogFieldCredentialID, credential.ID).Warn("unable to verify credential")
 		}
@@ -215,9 +216,13 @@ func (s walletStore) list(holderDID did.DID) ([]vc.VerifiableCredential, error)
 func (s walletStore) put(credentials ...vc.VerifiableCredential) error {
 	return s.db.Transaction(func(tx *gorm.DB) error {
 		for _, curr := range credentials {
-			subjectDID, err := curr.SubjectDID()
+			expanded, err := sdjwt.Expand(curr)
 			if err != nil {
-				return fmt.Errorf("unable to resolve subject DID from VC %s: %w", curr.ID, err)
+				return fmt.Errorf("unable to open SD-JWT VC: %w", err)
+			}
+			subjectDID, err := expanded.SubjectDID()
+			if err != nil {
+				return fmt.Errorf("unable to resolve subject DID from VC %s: %w", expanded.ID, err)
 			}
 			record, err := store.CredentialStore{}.Store(tx, curr)
 			if err != nil {
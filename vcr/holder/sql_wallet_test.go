@@ -453,3 +453,7 @@ func (t testVerifier) RegisterRevocation(revocation credential.Revocation) error
 func (t testVerifier) VerifyVP(presentation vc.VerifiablePresentation, verifyVCs bool, allowUntrustedVCs bool, validAt *time.Time) ([]vc.VerifiableCredential, error) {
 	panic("implement me")
 }
+
+func (t testVerifier) VerifyDomainLinkage(ctx context.Context, id did.DID, origin string) error {
+	panic("implement me")
+}
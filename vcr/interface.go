@@ -57,7 +57,7 @@ type TrustManager interface {
 type Resolver interface {
 	// Resolve returns a credential based on its ID.
 	// The optional resolveTime will resolve the credential at that point in time.
-	// The credential will still be returned in the case of ErrRevoked and ErrUntrusted.
+	// The credential will still be returned in the case of ErrRevoked, ErrSuspended and ErrUntrusted.
 	// For other errors, nil is returned
 	Resolve(ID ssi.URI, resolveTime *time.Time) (*vc.VerifiableCredential, error)
 }
@@ -27,6 +27,7 @@ import (
 	"github.com/nuts-foundation/go-did/vc"
 	"github.com/nuts-foundation/nuts-node/core"
 	"github.com/nuts-foundation/nuts-node/vcr/credential"
+	"github.com/nuts-foundation/nuts-node/vcr/revocation"
 )
 
 // Publisher publishes new credentials and revocations to a channel. Used by a credential issuer.
@@ -47,9 +48,22 @@ type Issuer interface {
 	// It returns types.ErrNotFound if the credential is not issued by this node, or types.ErrRevoked if already revoked.
 	// The revocation will be published to the network by the issuers Publisher if issuer by did:nuts.
 	Revoke(ctx context.Context, credentialID ssi.URI) (*credential.Revocation, error)
+	// Suspend credential with credentialID, by setting its 'suspension' BitstringStatusListEntry.
+	// It returns types.ErrStatusNotFound if the credential has no suspension status, or types.ErrSuspended if already suspended.
+	// did:nuts credentials can't be suspended.
+	Suspend(ctx context.Context, credentialID ssi.URI) error
+	// Unsuspend credential with credentialID, by clearing its 'suspension' BitstringStatusListEntry.
+	// It returns types.ErrStatusNotFound if the credential has no suspension status, or types.ErrNotSuspended if not suspended.
+	Unsuspend(ctx context.Context, credentialID ssi.URI) error
+	// SetMessageStatus sets the status value of the 'message' BitstringStatusListEntry of the credential with credentialID.
+	// It returns types.ErrStatusNotFound if the credential has no message status. did:nuts credentials don't have status messages.
+	SetMessageStatus(ctx context.Context, credentialID ssi.URI, status int) error
 	// StatusList returns the StatusList2021Credential tracking status list revocations for this issuer at /iam/issuerID/status/page.
 	// Returns types.ErrNotFound when no credential statuses have been published using the issuer and page combination.
 	StatusList(ctx context.Context, issuer did.DID, page int) (*vc.VerifiableCredential, error)
+	// BitstringStatusList returns the BitstringStatusListCredential tracking statuses with the given purpose for this issuer at /statuslist/issuerID/purpose/page.
+	// Returns types.ErrNotFound when no credential statuses have been published using the issuer, purpose and page combination.
+	BitstringStatusList(ctx context.Context, issuer did.DID, purpose string, page int) (*vc.VerifiableCredential, error)
 	// GetRevocation returns a revocation for a credential ID.
 	// Returns nil when no revocation is found.
 	GetRevocation(id ssi.URI) (*credential.Revocation, error)
@@ -87,12 +101,23 @@ type CredentialSearcher interface {
 // CredentialOptions specifies options for issuing a credential.
 type CredentialOptions struct {
 	// Format specifies the proof format for the issued credential. If not set, it defaults to JSON-LD.
-	// Valid options are: ldp_vc or jwt_vc
+	// Valid options are: ldp_vc, jwt_vc or vc+sd-jwt
 	Format string
 	// Publish param indicates if the credential should be published to the network.
 	Publish bool
 	// Public param instructs the Publisher to publish the param with a certain visibility.
 	Public bool
-	// WithStatusListRevocation adds a 'revocation' entry to the credential. Requires Publish to be False.
+	// WithStatusListRevocation adds a 'revocation' StatusList2021Entry to the credential. Requires Publish to be False.
 	WithStatusListRevocation bool
+	// WithBitstringStatusListRevocation adds a 'revocation' BitstringStatusListEntry to the credential. Requires Publish to be False.
+	WithBitstringStatusListRevocation bool
+	// WithStatusListSuspension adds a 'suspension' BitstringStatusListEntry to the credential, so it can be suspended and unsuspended.
+	// Requires Publish to be False.
+	WithStatusListSuspension bool
+	// StatusMessages adds a 'message' BitstringStatusListEntry to the credential when not empty, so its status can be changed using SetMessageStatus.
+	// It must contain a message for every status value. Requires Publish to be False.
+	StatusMessages []revocation.StatusMessage
+	// SelectivelyDisclosable lists the credentialSubject properties the holder can choose to disclose or withhold.
+	// Only valid for the vc+sd-jwt format.
+	SelectivelyDisclosable []string
 }
@@ -29,6 +29,7 @@ import (
 	"github.com/nuts-foundation/go-stoabs"
 	"github.com/nuts-foundation/nuts-node/vcr/openid4vci"
 	"github.com/nuts-foundation/nuts-node/vcr/revocation"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	"github.com/nuts-foundation/nuts-node/vdr/didnuts"
 	"github.com/nuts-foundation/nuts-node/vdr/resolver"
 	"gorm.io/gorm"
@@ -40,6 +41,7 @@ import (
 	"github.com/nuts-foundation/nuts-node/core"
 	"github.com/nuts-foundation/nuts-node/crypto"
 	"github.com/nuts-foundation/nuts-node/jsonld"
+	"github.com/nuts-foundation/nuts-node/storage/orm"
 	"github.com/nuts-foundation/nuts-node/vcr/credential"
 	"github.com/nuts-foundation/nuts-node/vcr/log"
 	"github.com/nuts-foundation/nuts-node/vcr/signature"
@@ -60,7 +62,7 @@ var TimeFunc = time.Now
 func NewIssuer(store Store, vcrStore types.Writer, networkPublisher Publisher,
 	openidHandlerFn func(ctx context.Context, id did.DID) (OpenIDHandler, error),
 	didResolver resolver.DIDResolver, keyStore crypto.KeyStore, jsonldManager jsonld.JSONLD, trustConfig *trust.Config,
-	statusList *revocation.StatusList2021) Issuer {
+	statusList *revocation.StatusList2021, bitstringStatusList *revocation.BitstringStatusList) Issuer {
 	keyResolver := resolver.DIDKeyResolver{Resolver: didResolver}
 	i := &issuer{
 		store:            store,
@@ -69,29 +71,33 @@ func NewIssuer(store Store, vcrStore types.Writer, networkPublisher Publisher,
 		walletResolver: openid4vci.DIDIdentifierResolver{
 			ServiceResolver: resolver.DIDServiceResolver{Resolver: didResolver},
 		},
-		keyResolver:   keyResolver,
-		keyStore:      keyStore,
-		jsonldManager: jsonldManager,
-		trustConfig:   trustConfig,
-		vcrStore:      vcrStore,
-		statusList:    statusList,
+		keyResolver:         keyResolver,
+		keyStore:            keyStore,
+		jsonldManager:       jsonldManager,
+		trustConfig:         trustConfig,
+		vcrStore:            vcrStore,
+		statusList:          statusList,
+		bitstringStatusList: bitstringStatusList,
 	}
 	statusList.Sign = i.buildJSONLDCredential
 	statusList.ResolveKey = i.keyResolver.ResolveKey
+	bitstringStatusList.Sign = i.buildJSONLDCredential
+	bitstringStatusList.ResolveKey = i.keyResolver.ResolveKey
 	return i
 }
 
 type issuer struct {
-	store            Store
-	networkPublisher Publisher
-	openidHandlerFn  func(ctx context.Context, id did.DID) (OpenIDHandler, error)
-	keyResolver      resolver.KeyResolver
-	keyStore         crypto.KeyStore
-	trustConfig      *trust.Config
-	jsonldManager    jsonld.JSONLD
-	vcrStore         types.Writer
-	walletResolver   openid4vci.IdentifierResolver
-	statusList       revocation.StatusList2021Issuer
+	store               Store
+	networkPublisher    Publisher
+	openidHandlerFn     func(ctx context.Context, id did.DID) (OpenIDHandler, error)
+	keyResolver         resolver.KeyResolver
+	keyStore            crypto.KeyStore
+	trustConfig         *trust.Config
+	jsonldManager       jsonld.JSONLD
+	vcrStore            types.Writer
+	walletResolver      openid4vci.IdentifierResolver
+	statusList          revocation.StatusList2021Issuer
+	bitstringStatusList revocation.BitstringStatusListIssuer
 }
 
 func (i issuer) GetRevocation(credentialID ssi.URI) (*credential.Revocation, error) {
@@ -112,7 +118,7 @@ func (i issuer) GetRevocation(credentialID ssi.URI) (*credential.Revocation, err
 		return &revocations[0], nil
 	}
 	// other DID method; use statusList
-	cred, err := i.store.GetCredential(credentialID)
+	cred, err := i.getCredential(credentialID)
 	if err != nil {
 		return nil, err
 	}
@@ -120,6 +126,12 @@ func (i issuer) GetRevocation(credentialID ssi.URI) (*credential.Revocation, err
 	if err != nil {
 		return nil, err
 	}
+	if rev == nil {
+		rev, err = i.bitstringStatusList.GetRevocation(credentialID)
+		if err != nil {
+			return nil, err
+		}
+	}
 	if rev == nil {
 		return nil, nil
 	}
@@ -136,17 +148,29 @@ func (i issuer) GetRevocation(credentialID ssi.URI) (*credential.Revocation, err
 // Use the public flag to pass the visibility settings to the Publisher.
 func (i issuer) Issue(ctx context.Context, template vc.VerifiableCredential, options CredentialOptions) (*vc.VerifiableCredential, error) {
 	// Until further notice we don't support publishing JWT VCs, since they're not officially supported by Nuts yet.
-	if options.Publish && options.Format == vc.JWTCredentialProofFormat {
+	if options.Publish && (options.Format == vc.JWTCredentialProofFormat || options.Format == sdjwt.Format) {
 		return nil, errors.New("publishing VC JWTs is not supported")
 	}
+	// Issued did:nuts credentials are stored by their ID, which is not the ID of the envelope of an SD-JWT VC.
+	if options.Format == sdjwt.Format && strings.HasPrefix(template.Issuer.String(), "did:nuts:") {
+		return nil, core.InvalidInputError("format %s is not supported for did:nuts issuers", sdjwt.Format)
+	}
+	if len(options.SelectivelyDisclosable) > 0 && options.Format != sdjwt.Format {
+		return nil, core.InvalidInputError("selectively disclosable claims are only supported for format %s", sdjwt.Format)
+	}
 
 	createdVC, err := i.buildAndSignVC(ctx, template, options)
 	if err != nil {
 		return nil, err
 	}
+	// SD-JWT VCs are enveloped, the checks below need the actual credential.
+	expandedVC, err := sdjwt.Expand(*createdVC)
+	if err != nil {
+		return nil, err
+	}
 
 	// Sanity check: all provided fields must be defined by the context: otherwise they're not protected by the signature
-	createdVCJSON, _ := json.Marshal(createdVC) // can't use createdVC.Raw() it does not return json for JWT-VCs
+	createdVCJSON, _ := json.Marshal(expandedVC) // can't use createdVC.Raw() it does not return json for JWT-VCs
 	err = jsonld.AllFieldsDefined(i.jsonldManager.DocumentLoader(), createdVCJSON)
 	if err != nil {
 		return nil, err
@@ -154,18 +178,18 @@ func (i issuer) Issue(ctx context.Context, template vc.VerifiableCredential, opt
 
 	// Validate the VC using the type-specific validator
 	// we don't pass a pki.Validator since we don't issue x509 certs
-	validator := credential.FindValidator(*createdVC, nil)
-	if err := validator.Validate(*createdVC); err != nil {
+	validator := credential.FindValidator(*expandedVC, nil)
+	if err := validator.Validate(*expandedVC); err != nil {
 		return nil, err
 	}
 
 	// Trust credential before storing/publishing, otherwise it might self-issued credentials might not be trusted,
 	// if AddTrust() fails for whatever reason.
 	// Only 1 allowed for now, but looping over all types (VerifiableCredential is excluded by ExtractTypes()) is future-proof.
-	for _, credentialType := range credential.ExtractTypes(*createdVC) {
+	for _, credentialType := range credential.ExtractTypes(*expandedVC) {
 		// MustParseURI is safe since it came from vc.Type, which contains URIs
-		if err := i.trustConfig.AddTrust(ssi.MustParseURI(credentialType), createdVC.Issuer); err != nil {
-			return nil, fmt.Errorf("failed to trust issuer when issuing VC (did=%s,type=%s): %w", createdVC.Issuer, credentialType, err)
+		if err := i.trustConfig.AddTrust(ssi.MustParseURI(credentialType), expandedVC.Issuer); err != nil {
+			return nil, fmt.Errorf("failed to trust issuer when issuing VC (did=%s,type=%s): %w", expandedVC.Issuer, credentialType, err)
 		}
 	}
 
@@ -272,6 +296,27 @@ func (i issuer) buildAndSignVC(ctx context.Context, template vc.VerifiableCreden
 			unsignedCredential.Context = append(unsignedCredential.Context, revocation.StatusList2021ContextURI)
 		}
 	}
+	if options.WithBitstringStatusListRevocation {
+		credentialStatusEntry, err := i.bitstringStatusList.Entry(ctx, *issuerDID, revocation.StatusPurposeRevocation)
+		if err != nil {
+			return nil, err
+		}
+		addBitstringStatusListEntry(&unsignedCredential, *credentialStatusEntry)
+	}
+	if options.WithStatusListSuspension {
+		credentialStatusEntry, err := i.bitstringStatusList.Entry(ctx, *issuerDID, revocation.StatusPurposeSuspension)
+		if err != nil {
+			return nil, err
+		}
+		addBitstringStatusListEntry(&unsignedCredential, *credentialStatusEntry)
+	}
+	if len(options.StatusMessages) > 0 {
+		credentialStatusEntry, err := i.bitstringStatusList.MessageEntry(ctx, *issuerDID, options.StatusMessages)
+		if err != nil {
+			return nil, core.InvalidInputError("invalid status messages: %w", err)
+		}
+		addBitstringStatusListEntry(&unsignedCredential, *credentialStatusEntry)
+	}
 
 	// context
 	if !unsignedCredential.ContainsContext(vc.VCContextV1URI()) {
@@ -289,12 +334,15 @@ func (i issuer) buildAndSignVC(ctx context.Context, template vc.VerifiableCreden
 		unsignedCredential.Type = append(unsignedCredential.Type, vc.VerifiableCredentialTypeV1URI())
 	}
 
-	// sign
+	// sign, the KeyStore refuses keys that aren't registered as assertionMethod
+	ctx = crypto.WithKeyUsage(ctx, orm.AssertionMethodUsage)
 	switch options.Format {
 	case vc.JWTCredentialProofFormat:
 		return vc.CreateJWTVerifiableCredential(ctx, unsignedCredential, func(ctx context.Context, claims map[string]interface{}, headers map[string]interface{}) (string, error) {
 			return i.keyStore.SignJWT(ctx, claims, headers, keyURI)
 		})
+	case sdjwt.Format:
+		return i.buildSDJWTCredential(ctx, unsignedCredential, keyURI, options.SelectivelyDisclosable)
 	case "":
 		fallthrough
 	case vc.JSONLDCredentialProofFormat:
@@ -320,6 +368,22 @@ func (i issuer) buildJSONLDCredential(ctx context.Context, unsignedCredential vc
 	return vc.ParseVerifiableCredential(string(credentialJSON))
 }
 
+// buildSDJWTCredential creates an SD-JWT VC and envelops it, so it can be stored and transported like any other credential.
+func (i issuer) buildSDJWTCredential(ctx context.Context, unsignedCredential vc.VerifiableCredential, kid string, disclosable []string) (*vc.VerifiableCredential, error) {
+	for _, name := range disclosable {
+		if len(unsignedCredential.CredentialSubject) > 0 && unsignedCredential.CredentialSubject[0][name] == nil {
+			return nil, core.InvalidInputError("selectively disclosable claim not found in credentialSubject: %s", name)
+		}
+	}
+	sdJWT, err := sdjwt.CreateVerifiableCredential(ctx, unsignedCredential, disclosable, func(ctx context.Context, claims map[string]interface{}, headers map[string]interface{}) (string, error) {
+		return i.keyStore.SignJWT(ctx, claims, headers, kid)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return sdjwt.Envelope(*sdJWT)
+}
+
 func (i issuer) Revoke(ctx context.Context, credentialID ssi.URI) (*credential.Revocation, error) {
 	credentialDIDURL, err := did.ParseDIDURL(credentialID.String())
 
@@ -369,7 +433,7 @@ func (i issuer) revokeDIDNuts(ctx context.Context, credentialID ssi.URI) (*crede
 
 // revokeStatusList revokes a credential through its credential status
 func (i issuer) revokeStatusList(ctx context.Context, credentialID ssi.URI) error {
-	cred, err := i.store.GetCredential(credentialID)
+	cred, err := i.getCredential(credentialID)
 	if err != nil {
 		return err
 	}
@@ -381,23 +445,115 @@ func (i issuer) revokeStatusList(ctx context.Context, credentialID ssi.URI) erro
 
 	// find the correct credentialStatus and revoke it on the relevant statuslist
 	for _, status := range statuses {
-		if status.Type == revocation.StatusList2021EntryType {
+		switch status.Type {
+		case revocation.StatusList2021EntryType:
 			var slEntry revocation.StatusList2021Entry
 			err = json.Unmarshal(status.Raw(), &slEntry)
 			if err != nil {
 				return err
 			}
-			// TODO: make sure it is the correct entry when we allow other purposes, or VC issuance that include other credential statuses
 			if slEntry.StatusPurpose != revocation.StatusPurposeRevocation {
 				continue
 			}
 			return i.statusList.Revoke(ctx, credentialID, slEntry)
+		case revocation.BitstringStatusListEntryType:
+			var slEntry revocation.BitstringStatusListEntry
+			err = json.Unmarshal(status.Raw(), &slEntry)
+			if err != nil {
+				return err
+			}
+			if slEntry.StatusPurpose != revocation.StatusPurposeRevocation {
+				continue
+			}
+			return i.bitstringStatusList.Revoke(ctx, credentialID, slEntry)
 		}
 	}
 
 	return types.ErrStatusNotFound
 }
 
+func (i issuer) Suspend(ctx context.Context, credentialID ssi.URI) error {
+	entry, err := i.bitstringStatusListEntry(credentialID, revocation.StatusPurposeSuspension)
+	if err != nil {
+		return err
+	}
+	if err = i.bitstringStatusList.Suspend(ctx, credentialID, *entry); err != nil {
+		return err
+	}
+	log.Logger().
+		WithField(core.LogFieldCredentialID, credentialID).
+		Info("Verifiable Credential suspended")
+	return nil
+}
+
+func (i issuer) Unsuspend(ctx context.Context, credentialID ssi.URI) error {
+	entry, err := i.bitstringStatusListEntry(credentialID, revocation.StatusPurposeSuspension)
+	if err != nil {
+		return err
+	}
+	if err = i.bitstringStatusList.Unsuspend(ctx, *entry); err != nil {
+		return err
+	}
+	log.Logger().
+		WithField(core.LogFieldCredentialID, credentialID).
+		Info("Verifiable Credential unsuspended")
+	return nil
+}
+
+func (i issuer) SetMessageStatus(ctx context.Context, credentialID ssi.URI, status int) error {
+	entry, err := i.bitstringStatusListEntry(credentialID, revocation.StatusPurposeMessage)
+	if err != nil {
+		return err
+	}
+	if err = i.bitstringStatusList.SetMessageStatus(ctx, credentialID, *entry, status); err != nil {
+		return err
+	}
+	log.Logger().
+		WithField(core.LogFieldCredentialID, credentialID).
+		Infof("Verifiable Credential status set to %d", status)
+	return nil
+}
+
+// addBitstringStatusListEntry adds the entry to the credentialStatus of the credential, and the BitstringStatusList context if missing.
+func addBitstringStatusListEntry(credential *vc.VerifiableCredential, entry revocation.BitstringStatusListEntry) {
+	credential.CredentialStatus = append(credential.CredentialStatus, entry)
+	if !credential.ContainsContext(revocation.BitstringStatusListContextURI) {
+		credential.Context = append(credential.Context, revocation.BitstringStatusListContextURI)
+	}
+}
+
+// bitstringStatusListEntry returns the BitstringStatusListEntry with the given purpose of the issued credential.
+func (i issuer) bitstringStatusListEntry(credentialID ssi.URI, purpose string) (*revocation.BitstringStatusListEntry, error) {
+	credentialDIDURL, err := did.ParseDIDURL(credentialID.String())
+	if err != nil {
+		return nil, core.InvalidInputError("invalid credential ID: %w", err)
+	}
+	if credentialDIDURL.Method == didnuts.MethodName {
+		return nil, core.InvalidInputError("%s is not supported for did:nuts credentials", purpose)
+	}
+	cred, err := i.getCredential(credentialID)
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := cred.CredentialStatuses()
+	if err != nil {
+		return nil, err
+	}
+	for _, status := range statuses {
+		if status.Type != revocation.BitstringStatusListEntryType {
+			continue
+		}
+		var slEntry revocation.BitstringStatusListEntry
+		if err = json.Unmarshal(status.Raw(), &slEntry); err != nil {
+			return nil, err
+		}
+		if slEntry.StatusPurpose == purpose {
+			return &slEntry, nil
+		}
+	}
+	return nil, types.ErrStatusNotFound
+}
+
 func (i issuer) buildRevocation(ctx context.Context, credentialID ssi.URI) (*credential.Revocation, error) {
 	// Sanity check: since we don't check existence of the VC, at least somewhat guard against mistyped credential IDs
 	// (although nobody should be typing those in).
@@ -464,6 +620,10 @@ func (i issuer) StatusList(ctx context.Context, issuerDID did.DID, page int) (*v
 	return i.statusList.Credential(ctx, issuerDID, page)
 }
 
+func (i issuer) BitstringStatusList(ctx context.Context, issuerDID did.DID, purpose string, page int) (*vc.VerifiableCredential, error) {
+	return i.bitstringStatusList.Credential(ctx, issuerDID, revocation.StatusPurpose(purpose), page)
+}
+
 func NewStore(db *gorm.DB, leiaIssuerStorePath string, leiaIssuerBackupStore stoabs.KVStore) (Store, error) {
 	didNutsStore, err := NewLeiaIssuerStore(leiaIssuerStorePath, leiaIssuerBackupStore)
 	if err != nil {
@@ -540,3 +700,12 @@ func (c combinedStore) Close() error {
 	// did:web SQL store does not need closing
 	return c.didNutsStore.Close()
 }
+
+// getCredential returns the issued credential with the given ID. Enveloped SD-JWT VCs are expanded, so their properties (e.g. credentialStatus) can be read.
+func (i issuer) getCredential(credentialID ssi.URI) (*vc.VerifiableCredential, error) {
+	cred, err := i.store.GetCredential(credentialID)
+	if err != nil {
+		return nil, err
+	}
+	return sdjwt.Expand(*cred)
+}
@@ -44,6 +44,7 @@ import (
 	"github.com/nuts-foundation/nuts-node/vcr/credential"
 	"github.com/nuts-foundation/nuts-node/vcr/openid4vci"
 	"github.com/nuts-foundation/nuts-node/vcr/revocation"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	"github.com/nuts-foundation/nuts-node/vcr/signature"
 	"github.com/nuts-foundation/nuts-node/vcr/test"
 	"github.com/nuts-foundation/nuts-node/vcr/trust"
@@ -149,6 +150,51 @@ func Test_issuer_buildAndSignVC(t *testing.T) {
 			assert.Equal(t, result.ID.String(), result.JWT().JwtID())
 		})
 	})
+	t.Run("SD-JWT", func(t *testing.T) {
+		sdTemplate := template
+		sdTemplate.CredentialSubject = []map[string]any{{
+			"id":   subjectDID,
+			"name": "John Doe",
+			"city": "Utrecht",
+		}}
+		t.Run("ok", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			keyResolverMock := resolver.NewMockKeyResolver(ctrl)
+			keyResolverMock.EXPECT().ResolveKey(issuerDID, nil, resolver.AssertionMethod).Return(kid, signingKey, nil)
+			sut := issuer{keyResolver: keyResolverMock, keyStore: keyStore}
+
+			result, err := sut.buildAndSignVC(ctx, sdTemplate, CredentialOptions{Format: sdjwt.Format, SelectivelyDisclosable: []string{"name"}})
+
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			require.True(t, sdjwt.IsEnveloped(*result))
+			sdJWT, err := sdjwt.Open(*result)
+			require.NoError(t, err)
+			require.Len(t, sdJWT.Disclosures, 1)
+			assert.Equal(t, "name", sdJWT.Disclosures[0].Name)
+			assert.Equal(t, "John Doe", sdJWT.Disclosures[0].Value)
+			// Assert credential contained in the SD-JWT
+			expanded, err := sdjwt.Expand(*result)
+			require.NoError(t, err)
+			assert.Contains(t, expanded.Type, credentialType, "expected vc to be of right type")
+			assert.Contains(t, expanded.Context, schemaOrgContext)
+			assert.Equal(t, issuance.Local(), expanded.IssuanceDate.Local())
+			assert.Equal(t, template.ExpirationDate.Local(), expanded.ExpirationDate.Local())
+			assert.Equal(t, template.Issuer, expanded.Issuer)
+			assert.Equal(t, sdTemplate.CredentialSubject, expanded.CredentialSubject)
+		})
+		t.Run("selectively disclosable claim not in credentialSubject", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			keyResolverMock := resolver.NewMockKeyResolver(ctrl)
+			keyResolverMock.EXPECT().ResolveKey(issuerDID, nil, resolver.AssertionMethod).Return(kid, signingKey, nil)
+			sut := issuer{keyResolver: keyResolverMock, keyStore: keyStore}
+
+			result, err := sut.buildAndSignVC(ctx, sdTemplate, CredentialOptions{Format: sdjwt.Format, SelectivelyDisclosable: []string{"birthDate"}})
+
+			assert.ErrorIs(t, err, core.InvalidInputError("selectively disclosable claim not found in credentialSubject: birthDate"))
+			assert.Nil(t, result)
+		})
+	})
 	t.Run("credentialStatus", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		keyResolverMock := resolver.NewMockKeyResolver(ctrl)
@@ -172,6 +218,65 @@ func Test_issuer_buildAndSignVC(t *testing.T) {
 			require.Len(t, statuses, 1)
 			assert.Equal(t, revocation.StatusList2021EntryType, statuses[0].Type)
 		})
+		t.Run("ok - suspension", func(t *testing.T) {
+			slTemplate := template
+			slTemplate.Issuer = webIssuerDID.URI() // does not overwrite template
+
+			jsonldManager := jsonld.NewTestJSONLDManager(t)
+			sut := issuer{keyResolver: keyResolverMock, jsonldManager: jsonldManager, keyStore: keyStore, bitstringStatusList: newTestBitstringStatusList(t, orm.NewTestDatabase(t), signingKey, webIssuerDID)}
+
+			result, err := sut.buildAndSignVC(ctx, slTemplate, CredentialOptions{WithStatusListSuspension: true})
+
+			// only check fields relevant to credential status
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			assert.Contains(t, result.Context, revocation.BitstringStatusListContextURI)
+
+			statuses, err := result.CredentialStatuses()
+			require.NoError(t, err)
+			require.Len(t, statuses, 1)
+			assert.Equal(t, revocation.BitstringStatusListEntryType, statuses[0].Type)
+		})
+		t.Run("ok - BitstringStatusList revocation and messages", func(t *testing.T) {
+			slTemplate := template
+			slTemplate.Issuer = webIssuerDID.URI() // does not overwrite template
+			messages := []revocation.StatusMessage{{Status: "0x0", Message: "valid"}, {Status: "0x1", Message: "under review"}}
+
+			jsonldManager := jsonld.NewTestJSONLDManager(t)
+			sut := issuer{keyResolver: keyResolverMock, jsonldManager: jsonldManager, keyStore: keyStore, bitstringStatusList: newTestBitstringStatusList(t, orm.NewTestDatabase(t), signingKey, webIssuerDID)}
+
+			result, err := sut.buildAndSignVC(ctx, slTemplate, CredentialOptions{WithBitstringStatusListRevocation: true, StatusMessages: messages})
+
+			// only check fields relevant to credential status
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			assert.Contains(t, result.Context, revocation.BitstringStatusListContextURI)
+
+			statuses, err := result.CredentialStatuses()
+			require.NoError(t, err)
+			require.Len(t, statuses, 2)
+			var entries []revocation.BitstringStatusListEntry
+			for _, status := range statuses {
+				var entry revocation.BitstringStatusListEntry
+				require.NoError(t, json.Unmarshal(status.Raw(), &entry))
+				entries = append(entries, entry)
+			}
+			assert.Equal(t, revocation.StatusPurposeRevocation, entries[0].StatusPurpose)
+			assert.Equal(t, revocation.StatusPurposeMessage, entries[1].StatusPurpose)
+			assert.Equal(t, messages, entries[1].StatusMessage)
+		})
+		t.Run("error - invalid status messages", func(t *testing.T) {
+			slTemplate := template
+			slTemplate.Issuer = webIssuerDID.URI() // does not overwrite template
+
+			jsonldManager := jsonld.NewTestJSONLDManager(t)
+			sut := issuer{keyResolver: keyResolverMock, jsonldManager: jsonldManager, keyStore: keyStore, bitstringStatusList: newTestBitstringStatusList(t, orm.NewTestDatabase(t), signingKey, webIssuerDID)}
+
+			result, err := sut.buildAndSignVC(ctx, slTemplate, CredentialOptions{StatusMessages: []revocation.StatusMessage{{Status: "0x0"}}})
+
+			assert.ErrorContains(t, err, "invalid status messages")
+			assert.Nil(t, result)
+		})
 	})
 
 	t.Run("it does not add the default context twice", func(t *testing.T) {
@@ -328,6 +433,71 @@ func Test_issuer_Issue(t *testing.T) {
 		assert.Nil(t, result)
 	})
 
+	t.Run("SD-JWT VC", func(t *testing.T) {
+		sdTemplate := template
+		sdTemplate.Context = []ssi.URI{credential.NutsV1ContextURI, ssi.MustParseURI(jsonld.SchemaOrgContext)}
+		sdTemplate.Issuer = webIssuerDID.URI()
+		sdTemplate.CredentialSubject = []map[string]any{{
+			"id":   holderDID.String(),
+			"name": "John Doe",
+		}}
+		webIssuerKeyID := webIssuerDID.String() + "#abc"
+		_, webIssuerKey, _ := nutsCryptoInstance.New(audit.TestContext(), nutsCrypto.StringNamingFunc(webIssuerKeyID))
+		t.Run("ok", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			trustConfig := trust.NewConfig(path.Join(io.TestDirectory(t), "trust.config"))
+			keyResolverMock := resolver.NewMockKeyResolver(ctrl)
+			keyResolverMock.EXPECT().ResolveKey(webIssuerDID, nil, resolver.AssertionMethod).Return(webIssuerKeyID, webIssuerKey, nil)
+			mockStore := NewMockStore(ctrl)
+			mockStore.EXPECT().StoreCredential(gomock.Any())
+			sut := issuer{
+				keyResolver: keyResolverMock, store: mockStore,
+				jsonldManager: jsonldManager, trustConfig: trustConfig,
+				keyStore: nutsCryptoInstance,
+			}
+
+			result, err := sut.Issue(ctx, sdTemplate, CredentialOptions{
+				Format:                 sdjwt.Format,
+				SelectivelyDisclosable: []string{"name"},
+			})
+
+			require.NoError(t, err)
+			require.True(t, sdjwt.IsEnveloped(*result))
+			// Assert issuing a credential makes it trusted
+			assert.True(t, trustConfig.IsTrusted(credentialType, webIssuerDID.URI()))
+		})
+		t.Run("publishing is disallowed", func(t *testing.T) {
+			sut := issuer{}
+
+			result, err := sut.Issue(ctx, sdTemplate, CredentialOptions{
+				Publish: true,
+				Format:  sdjwt.Format,
+			})
+
+			require.EqualError(t, err, "publishing VC JWTs is not supported")
+			assert.Nil(t, result)
+		})
+		t.Run("did:nuts issuer is disallowed", func(t *testing.T) {
+			sut := issuer{}
+
+			result, err := sut.Issue(ctx, template, CredentialOptions{Format: sdjwt.Format})
+
+			require.EqualError(t, err, "format vc+sd-jwt is not supported for did:nuts issuers")
+			assert.Nil(t, result)
+		})
+		t.Run("selectively disclosable claims for other format", func(t *testing.T) {
+			sut := issuer{}
+
+			result, err := sut.Issue(ctx, sdTemplate, CredentialOptions{
+				Format:                 vc.JWTCredentialProofFormat,
+				SelectivelyDisclosable: []string{"name"},
+			})
+
+			require.EqualError(t, err, "selectively disclosable claims are only supported for format vc+sd-jwt")
+			assert.Nil(t, result)
+		})
+	})
+
 	t.Run("OpenID4VCI", func(t *testing.T) {
 		const walletIdentifier = "http://example.com/wallet"
 		t.Run("ok - publish over OpenID4VCI fails - fallback to network", func(t *testing.T) {
@@ -535,7 +705,7 @@ func Test_issuer_Issue(t *testing.T) {
 }
 
 func TestNewIssuer(t *testing.T) {
-	createdIssuer := NewIssuer(nil, nil, nil, nil, nil, nil, nil, nil, &revocation.StatusList2021{})
+	createdIssuer := NewIssuer(nil, nil, nil, nil, nil, nil, nil, nil, &revocation.StatusList2021{}, &revocation.BitstringStatusList{})
 	assert.IsType(t, &issuer{}, createdIssuer)
 }
 
@@ -725,12 +895,56 @@ func Test_issuer_GetRevocation(t *testing.T) {
 
 			statusList := revocation.NewMockStatusList2021Issuer(ctrl)
 			statusList.EXPECT().GetRevocation(webCredentialID).Return(nil, nil)
+			bitstringStatusList := revocation.NewMockBitstringStatusListIssuer(ctrl)
+			bitstringStatusList.EXPECT().GetRevocation(webCredentialID).Return(nil, nil)
 
-			sut := issuer{store: store, statusList: statusList}
+			sut := issuer{store: store, statusList: statusList, bitstringStatusList: bitstringStatusList}
+
+			result, err := sut.GetRevocation(webCredentialID)
+
+			assert.NoError(t, err)
+			assert.Nil(t, result)
+		})
+
+		t.Run("ok - revoked on BitstringStatusList", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			revokedAt := time.Now()
+
+			store := NewMockStore(ctrl)
+			store.EXPECT().GetCredential(webCredentialID).Return(testCredential, nil)
+			statusList := revocation.NewMockStatusList2021Issuer(ctrl)
+			statusList.EXPECT().GetRevocation(webCredentialID).Return(nil, nil)
+			bitstringStatusList := revocation.NewMockBitstringStatusListIssuer(ctrl)
+			bitstringStatusList.EXPECT().GetRevocation(webCredentialID).Return(&revocation.Revocation{
+				Purpose:   revocation.StatusPurposeRevocation,
+				RevokedAt: revokedAt,
+			}, nil)
+
+			sut := issuer{store: store, statusList: statusList, bitstringStatusList: bitstringStatusList}
 
 			result, err := sut.GetRevocation(webCredentialID)
 
 			assert.NoError(t, err)
+			require.NotNil(t, result)
+			assert.Equal(t, revocation.StatusPurposeRevocation, result.Reason)
+			assert.Equal(t, revokedAt, result.Date)
+		})
+
+		t.Run("error - BitstringStatusList fails", func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			store := NewMockStore(ctrl)
+			store.EXPECT().GetCredential(webCredentialID).Return(testCredential, nil)
+			statusList := revocation.NewMockStatusList2021Issuer(ctrl)
+			statusList.EXPECT().GetRevocation(webCredentialID).Return(nil, nil)
+			bitstringStatusList := revocation.NewMockBitstringStatusListIssuer(ctrl)
+			bitstringStatusList.EXPECT().GetRevocation(webCredentialID).Return(nil, assert.AnError)
+
+			sut := issuer{store: store, statusList: statusList, bitstringStatusList: bitstringStatusList}
+
+			result, err := sut.GetRevocation(webCredentialID)
+
+			assert.ErrorIs(t, err, assert.AnError)
 			assert.Nil(t, result)
 		})
 
@@ -997,6 +1211,153 @@ func TestIssuer_revokeStatusList(t *testing.T) {
 	})
 }
 
+func TestIssuer_Suspend(t *testing.T) {
+	storeWithCred := func(c *gomock.Controller, credentialID ssi.URI, entries ...any) *MockStore {
+		cred := &vc.VerifiableCredential{
+			ID:               &credentialID,
+			Issuer:           ssi.MustParseURI(webIssuerDID.String()),
+			CredentialStatus: entries,
+		}
+		store := NewMockStore(c)
+		store.EXPECT().GetCredential(credentialID).Return(cred, nil).MinTimes(1)
+		return store
+	}
+	credentialID := ssi.MustParseURI(webIssuerDID.String() + "#identifier")
+
+	ctx := audit.TestContext()
+	keyStore := nutsCrypto.NewMemoryCryptoInstance(t)
+	_, signingKey, err := keyStore.New(ctx, nutsCrypto.StringNamingFunc(webIssuerDID.String()+"#abc"))
+	require.NoError(t, err)
+
+	t.Run("ok - suspend and unsuspend", func(t *testing.T) {
+		status := newTestBitstringStatusList(t, orm.NewTestDatabase(t), signingKey, webIssuerDID)
+		entry, err := status.Entry(ctx, webIssuerDID, revocation.StatusPurposeSuspension)
+		require.NoError(t, err)
+		sut := issuer{
+			store:               storeWithCred(gomock.NewController(t), credentialID, *entry),
+			bitstringStatusList: status,
+		}
+
+		err = sut.Suspend(ctx, credentialID)
+		require.NoError(t, err)
+		err = sut.Unsuspend(ctx, credentialID)
+		assert.NoError(t, err)
+	})
+	t.Run("error - double suspension", func(t *testing.T) {
+		status := newTestBitstringStatusList(t, orm.NewTestDatabase(t), signingKey, webIssuerDID)
+		entry, err := status.Entry(ctx, webIssuerDID, revocation.StatusPurposeSuspension)
+		require.NoError(t, err)
+		sut := issuer{
+			store:               storeWithCred(gomock.NewController(t), credentialID, *entry),
+			bitstringStatusList: status,
+		}
+
+		err = sut.Suspend(ctx, credentialID)
+		require.NoError(t, err)
+		err = sut.Suspend(ctx, credentialID)
+
+		assert.ErrorIs(t, err, vcr.ErrSuspended)
+	})
+	t.Run("error - not suspended", func(t *testing.T) {
+		status := newTestBitstringStatusList(t, orm.NewTestDatabase(t), signingKey, webIssuerDID)
+		entry, err := status.Entry(ctx, webIssuerDID, revocation.StatusPurposeSuspension)
+		require.NoError(t, err)
+		sut := issuer{
+			store:               storeWithCred(gomock.NewController(t), credentialID, *entry),
+			bitstringStatusList: status,
+		}
+
+		err = sut.Unsuspend(ctx, credentialID)
+
+		assert.ErrorIs(t, err, vcr.ErrNotSuspended)
+	})
+	t.Run("error - no suspension credential status", func(t *testing.T) {
+		sut := issuer{
+			store: storeWithCred(gomock.NewController(t), credentialID, revocation.StatusList2021Entry{
+				Type:          revocation.StatusList2021EntryType,
+				StatusPurpose: revocation.StatusPurposeRevocation,
+			}),
+		}
+
+		err := sut.Suspend(ctx, credentialID)
+
+		assert.ErrorIs(t, err, vcr.ErrStatusNotFound)
+	})
+	t.Run("error - did:nuts credential", func(t *testing.T) {
+		sut := issuer{}
+
+		err := sut.Suspend(ctx, ssi.MustParseURI("did:nuts:123#abc"))
+
+		assert.EqualError(t, err, "suspension is not supported for did:nuts credentials")
+	})
+	t.Run("error - credential not found", func(t *testing.T) {
+		store := NewMockStore(gomock.NewController(t))
+		store.EXPECT().GetCredential(gomock.Any()).Return(nil, vcr.ErrNotFound)
+		sut := issuer{store: store}
+
+		err := sut.Unsuspend(ctx, ssi.MustParseURI("did:web:example.com:iam#not-found"))
+
+		assert.ErrorIs(t, err, vcr.ErrNotFound)
+	})
+}
+
+func TestIssuer_SetMessageStatus(t *testing.T) {
+	credentialID := ssi.MustParseURI(webIssuerDID.String() + "#identifier")
+	storeWithCred := func(c *gomock.Controller, entries ...any) *MockStore {
+		cred := &vc.VerifiableCredential{
+			ID:               &credentialID,
+			Issuer:           ssi.MustParseURI(webIssuerDID.String()),
+			CredentialStatus: entries,
+		}
+		store := NewMockStore(c)
+		store.EXPECT().GetCredential(credentialID).Return(cred, nil).MinTimes(1)
+		return store
+	}
+
+	ctx := audit.TestContext()
+	keyStore := nutsCrypto.NewMemoryCryptoInstance(t)
+	_, signingKey, err := keyStore.New(ctx, nutsCrypto.StringNamingFunc(webIssuerDID.String()+"#abc"))
+	require.NoError(t, err)
+	messages := []revocation.StatusMessage{{Status: "0x0", Message: "valid"}, {Status: "0x1", Message: "under review"}}
+
+	t.Run("ok", func(t *testing.T) {
+		status := newTestBitstringStatusList(t, orm.NewTestDatabase(t), signingKey, webIssuerDID)
+		entry, err := status.MessageEntry(ctx, webIssuerDID, messages)
+		require.NoError(t, err)
+		sut := issuer{
+			store:               storeWithCred(gomock.NewController(t), *entry),
+			bitstringStatusList: status,
+		}
+
+		err = sut.SetMessageStatus(ctx, credentialID, 1)
+
+		require.NoError(t, err)
+		result, err := status.Status(*entry)
+		require.NoError(t, err)
+		assert.Equal(t, "under review", result.Message)
+	})
+	t.Run("error - no message credential status", func(t *testing.T) {
+		status := newTestBitstringStatusList(t, orm.NewTestDatabase(t), signingKey, webIssuerDID)
+		entry, err := status.Entry(ctx, webIssuerDID, revocation.StatusPurposeSuspension)
+		require.NoError(t, err)
+		sut := issuer{
+			store:               storeWithCred(gomock.NewController(t), *entry),
+			bitstringStatusList: status,
+		}
+
+		err = sut.SetMessageStatus(ctx, credentialID, 1)
+
+		assert.ErrorIs(t, err, vcr.ErrStatusNotFound)
+	})
+	t.Run("error - did:nuts credential", func(t *testing.T) {
+		sut := issuer{}
+
+		err := sut.SetMessageStatus(ctx, ssi.MustParseURI("did:nuts:123#abc"), 1)
+
+		assert.EqualError(t, err, "message is not supported for did:nuts credentials")
+	})
+}
+
 func TestIssuer_isRevoked(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
@@ -1091,7 +1452,7 @@ func TestIssuer_StatusList(t *testing.T) {
 		vDIDResolverMock.EXPECT().Resolve(gomock.Any(), gomock.Any())
 		vKeyResolverMock := resolver.NewMockKeyResolver(ctrl)
 		vKeyResolverMock.EXPECT().ResolveKeyByID(gomock.Any(), gomock.Any(), gomock.Any()).Return(signingKey, nil)
-		verif := verifier.NewVerifier(vStoreMock, vDIDResolverMock, vKeyResolverMock, jsonldManager, nil, &revocation.StatusList2021{}, nil)
+		verif := verifier.NewVerifier(vStoreMock, vDIDResolverMock, vKeyResolverMock, jsonldManager, nil, &revocation.StatusList2021{}, &revocation.BitstringStatusList{}, nil, nil)
 		assert.NoError(t, verif.Verify(*result, true, true, nil))
 	})
 	t.Run("error - unknown status list credential", func(t *testing.T) {
@@ -1122,6 +1483,24 @@ func newTestStatusList2021(t testing.TB, db *gorm.DB, signingKey crypto.PublicKe
 	return cs
 }
 
+func newTestBitstringStatusList(t testing.TB, db *gorm.DB, signingKey crypto.PublicKey, dids ...did.DID) *revocation.BitstringStatusList {
+	storage.AddDIDtoSQLDB(t, db, dids...)
+	cs := revocation.NewBitstringStatusList(db, nil, "https://example.com")
+	cs.Sign = func(_ context.Context, unsignedCredential vc.VerifiableCredential, kid string) (*vc.VerifiableCredential, error) {
+		unsignedCredential.ID, _ = ssi.ParseURI("test-credential")
+		bs, err := json.Marshal(unsignedCredential)
+		require.NoError(t, err)
+		unsignedWithRawField := new(vc.VerifiableCredential)
+		require.NoError(t, json.Unmarshal(bs, unsignedWithRawField))
+		return unsignedWithRawField, nil
+	}
+	ctrl := gomock.NewController(t)
+	mockKeyResolver := resolver.NewMockKeyResolver(ctrl)
+	mockKeyResolver.EXPECT().ResolveKey(webIssuerDID, nil, resolver.AssertionMethod).Return(webIssuerDID.String()+"#abc", signingKey, nil).AnyTimes()
+	cs.ResolveKey = mockKeyResolver.ResolveKey
+	return cs
+}
+
 func Test_combinedStore_Diagnostics(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
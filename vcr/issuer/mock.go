@@ -97,6 +97,21 @@ func (m *MockIssuer) EXPECT() *MockIssuerMockRecorder {
 	return m.recorder
 }
 
+// BitstringStatusList mocks base method.
+func (m *MockIssuer) BitstringStatusList(ctx context.Context, issuer did.DID, purpose string, page int) (*vc.VerifiableCredential, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BitstringStatusList", ctx, issuer, purpose, page)
+	ret0, _ := ret[0].(*vc.VerifiableCredential)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BitstringStatusList indicates an expected call of BitstringStatusList.
+func (mr *MockIssuerMockRecorder) BitstringStatusList(ctx, issuer, purpose, page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BitstringStatusList", reflect.TypeOf((*MockIssuer)(nil).BitstringStatusList), ctx, issuer, purpose, page)
+}
+
 // GetRevocation mocks base method.
 func (m *MockIssuer) GetRevocation(id ssi.URI) (*credential.Revocation, error) {
 	m.ctrl.T.Helper()
@@ -157,6 +172,20 @@ func (mr *MockIssuerMockRecorder) SearchCredential(credentialType, issuer, subje
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchCredential", reflect.TypeOf((*MockIssuer)(nil).SearchCredential), credentialType, issuer, subject)
 }
 
+// SetMessageStatus mocks base method.
+func (m *MockIssuer) SetMessageStatus(ctx context.Context, credentialID ssi.URI, status int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMessageStatus", ctx, credentialID, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMessageStatus indicates an expected call of SetMessageStatus.
+func (mr *MockIssuerMockRecorder) SetMessageStatus(ctx, credentialID, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMessageStatus", reflect.TypeOf((*MockIssuer)(nil).SetMessageStatus), ctx, credentialID, status)
+}
+
 // StatusList mocks base method.
 func (m *MockIssuer) StatusList(ctx context.Context, issuer did.DID, page int) (*vc.VerifiableCredential, error) {
 	m.ctrl.T.Helper()
@@ -172,6 +201,34 @@ func (mr *MockIssuerMockRecorder) StatusList(ctx, issuer, page any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StatusList", reflect.TypeOf((*MockIssuer)(nil).StatusList), ctx, issuer, page)
 }
 
+// Suspend mocks base method.
+func (m *MockIssuer) Suspend(ctx context.Context, credentialID ssi.URI) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suspend", ctx, credentialID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Suspend indicates an expected call of Suspend.
+func (mr *MockIssuerMockRecorder) Suspend(ctx, credentialID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suspend", reflect.TypeOf((*MockIssuer)(nil).Suspend), ctx, credentialID)
+}
+
+// Unsuspend mocks base method.
+func (m *MockIssuer) Unsuspend(ctx context.Context, credentialID ssi.URI) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unsuspend", ctx, credentialID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unsuspend indicates an expected call of Unsuspend.
+func (mr *MockIssuerMockRecorder) Unsuspend(ctx, credentialID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsuspend", reflect.TypeOf((*MockIssuer)(nil).Unsuspend), ctx, credentialID)
+}
+
 // MockStore is a mock of Store interface.
 type MockStore struct {
 	ctrl     *gomock.Controller
@@ -24,7 +24,10 @@ import (
 	"fmt"
 	"github.com/lestrrat-go/jwx/v2/jwa"
 	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/lestrrat-go/jwx/v2/jwt"
 	v2 "github.com/nuts-foundation/nuts-node/vcr/pe/schema/v2"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
+	"reflect"
 	"strings"
 
 	"github.com/PaesslerAG/jsonpath"
@@ -68,6 +71,7 @@ type PresentationContext struct {
 // It supports the following:
 // - ldp_vc format
 // - jwt_vc format
+// - vc+sd-jwt format (enveloped SD-JWT VCs)
 // - pattern, const and enum only on string fields
 // - number, boolean, array and string JSON schema types
 // - Submission Requirements Feature
@@ -117,6 +121,88 @@ func (presentationDefinition PresentationDefinition) ResolveConstraintsFields(cr
 	return result, nil
 }
 
+// LimitDisclosure returns the SD-JWT with only the disclosures required to satisfy the constraints of the given input descriptor,
+// if the input descriptor requires or prefers limited disclosure (limit_disclosure).
+// Otherwise, the SD-JWT is returned with all its disclosures.
+// A disclosure is withheld if the constraints still match and resolve to the same field values without it.
+func (presentationDefinition PresentationDefinition) LimitDisclosure(inputDescriptorID string, sdJWT sdjwt.SDJWT) (*sdjwt.SDJWT, error) {
+	var constraints *Constraints
+	for _, curr := range presentationDefinition.InputDescriptors {
+		if curr.Id == inputDescriptorID {
+			constraints = curr.Constraints
+			break
+		}
+	}
+	if constraints == nil || (constraints.LimitDisclosure != LimitDisclosureRequired && constraints.LimitDisclosure != LimitDisclosurePreferred) {
+		return &sdJWT, nil
+	}
+	matches, expectedValues, err := matchSDJWTConstraint(constraints, sdJWT)
+	if err != nil {
+		return nil, err
+	}
+	if !matches {
+		return nil, fmt.Errorf("credential does not match constraints of input descriptor '%s'", inputDescriptorID)
+	}
+	result := sdJWT
+	for _, disclosure := range sdJWT.Disclosures {
+		candidate := result.Select(func(curr sdjwt.Disclosure) bool {
+			return curr.Encoded() != disclosure.Encoded()
+		})
+		matches, values, err := matchSDJWTConstraint(constraints, candidate)
+		if err != nil {
+			return nil, err
+		}
+		if matches && reflect.DeepEqual(values, expectedValues) {
+			result = candidate
+		}
+	}
+	return &result, nil
+}
+
+func matchSDJWTConstraint(constraints *Constraints, sdJWT sdjwt.SDJWT) (bool, map[string]interface{}, error) {
+	credentialAsMap, err := sdJWTCredentialAsMap(sdJWT)
+	if err != nil {
+		return false, nil, err
+	}
+	return matchFields(constraints, credentialAsMap)
+}
+
+// sdJWTCredentialAsMap converts an SD-JWT VC to a map for matching constraints, using only the claims that are disclosed.
+// The properties of the VC are taken from the (expanded) vc claim, so they have the same structure as when they were issued.
+func sdJWTCredentialAsMap(sdJWT sdjwt.SDJWT) (map[string]interface{}, error) {
+	credential, err := sdJWT.VerifiableCredential()
+	if err != nil {
+		return nil, err
+	}
+	// JWT-VCs marshal to a JSON string, so marshal an alias to get the properties derived from the registered JWT claims (e.g. iss, jti).
+	type Alias vc.VerifiableCredential
+	result, err := remarshalToMap(Alias(*credential))
+	if err != nil {
+		return nil, err
+	}
+	claims, err := sdJWT.Claims()
+	if err != nil {
+		return nil, err
+	}
+	vcClaim, _ := claims["vc"].(map[string]interface{})
+	for name, value := range vcClaim {
+		result[name] = value
+	}
+	if sub, ok := claims[jwt.SubjectKey].(string); ok && sub != "" {
+		switch credentialSubject := result["credentialSubject"].(type) {
+		case map[string]interface{}:
+			credentialSubject["id"] = sub
+		case []interface{}:
+			for _, curr := range credentialSubject {
+				if asMap, ok := curr.(map[string]interface{}); ok {
+					asMap["id"] = sub
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
 // CredentialsRequired returns true if the presentation definition requires credentials.
 // This is the case if there are any InputDescriptors with constraints and no SubmissionRequirements.
 // Or if there are SubmissionRequirements with an "all" rule or a "pick" rule that requires credentials.
@@ -190,7 +276,7 @@ func (presentationDefinition PresentationDefinition) matchBasic(vcs []vc.Verifia
 		// create the InputDescriptorMappingObject with the relative path
 		mapping := InputDescriptorMappingObject{
 			Id:     candidate.InputDescriptor.Id,
-			Format: candidate.VC.Format(),
+			Format: credentialFormat(*candidate.VC),
 			Path:   fmt.Sprintf("$.verifiableCredential[%d]", index),
 		}
 		descriptors = append(descriptors, mapping)
@@ -260,7 +346,7 @@ outer:
 			if candidate.VC != nil && vcEqual(uniqueVC, *candidate.VC) {
 				mapping := InputDescriptorMappingObject{
 					Id:     candidate.InputDescriptor.Id,
-					Format: candidate.VC.Format(),
+					Format: credentialFormat(*candidate.VC),
 					Path:   fmt.Sprintf("$.verifiableCredential[%d]", index),
 				}
 				descriptors = append(descriptors, mapping)
@@ -310,7 +396,7 @@ func matchFormat(format *PresentationDefinitionClaimFormatDesignations, credenti
 	}
 
 	asMap := map[string]map[string][]string(*format)
-	switch credential.Format() {
+	switch credentialFormat(credential) {
 	case vc.JSONLDCredentialProofFormat:
 		if entry := asMap[vc.JSONLDCredentialProofFormat]; entry != nil {
 			if len(credential.Proof) == 0 {
@@ -325,6 +411,27 @@ func matchFormat(format *PresentationDefinitionClaimFormatDesignations, credenti
 				}
 			}
 		}
+	case sdjwt.Format:
+		if entry := asMap[sdjwt.Format]; entry != nil {
+			supportedAlgorithms := entry["sd-jwt_alg_values"]
+			if supportedAlgorithms == nil {
+				return true
+			}
+			sdJWT, err := sdjwt.Open(credential)
+			if err != nil {
+				return false
+			}
+			message, err := jws.ParseString(sdJWT.IssuerSigned)
+			if err != nil {
+				return false
+			}
+			signingAlgorithm, _ := message.Signatures()[0].ProtectedHeaders().Get(jws.AlgorithmKey)
+			for _, supportedAlgorithm := range supportedAlgorithms {
+				if signingAlgorithm == jwa.SignatureAlgorithm(supportedAlgorithm) {
+					return true
+				}
+			}
+		}
 	case vc.JWTCredentialProofFormat:
 		// Get signing algorithm used to sign the JWT
 		message, _ := jws.ParseString(credential.Raw()) // can't really fail, JWT has been parsed before.
@@ -361,6 +468,10 @@ func matchCredential(descriptor InputDescriptor, credential vc.VerifiableCredent
 	// for each constraint in descriptor.constraints:
 	//   a vc must match the constraint
 	if descriptor.Constraints != nil {
+		// Only SD-JWT VCs allow the holder to limit the disclosed claims
+		if descriptor.Constraints.LimitDisclosure == LimitDisclosureRequired && !sdjwt.IsEnveloped(credential) {
+			return false, nil
+		}
 		matches, _, err := matchConstraint(descriptor.Constraints, credential)
 		if err != nil {
 			return false, fmt.Errorf("failed to match constraint for input descriptor '%s' and credential '%s': %w", descriptor.Name, credential.ID, err)
@@ -373,9 +484,16 @@ func matchCredential(descriptor InputDescriptor, credential vc.VerifiableCredent
 // matchConstraint matches the constraint against the VC.
 // All Fields need to match according to the Field rules.
 // IsHolder, SameSubject, SubjectIsIssuer, Statuses are not supported for now.
-// LimitDisclosure is not supported for now.
+// Enveloped SD-JWT VCs are matched using the claims that are disclosed.
 // If the constraint matches, it returns true and a map containing constraint field IDs and matched values.
 func matchConstraint(constraint *Constraints, credential vc.VerifiableCredential) (bool, map[string]interface{}, error) {
+	if sdjwt.IsEnveloped(credential) {
+		sdJWT, err := sdjwt.Open(credential)
+		if err != nil {
+			return false, nil, err
+		}
+		return matchSDJWTConstraint(constraint, *sdJWT)
+	}
 	// jsonpath works on interfaces, so convert the VC to an interface
 	var credentialAsMap map[string]interface{}
 	var err error
@@ -394,7 +512,12 @@ func matchConstraint(constraint *Constraints, credential vc.VerifiableCredential
 	if err != nil {
 		return false, nil, err
 	}
+	return matchFields(constraint, credentialAsMap)
+}
 
+// matchFields matches the fields of the constraint against the credential, which is converted to a map.
+// If all fields match, it returns true and a map containing constraint field IDs and matched values.
+func matchFields(constraint *Constraints, credentialAsMap map[string]interface{}) (bool, map[string]interface{}, error) {
 	// for each field in constraint.fields:
 	//   a vc must match the field
 	values := make(map[string]interface{})
@@ -559,6 +682,14 @@ func matchFilter(filter Filter, value interface{}) (bool, interface{}, error) {
 	return true, value, nil
 }
 
+// credentialFormat returns the format designation of the credential, taking enveloped SD-JWT VCs into account.
+func credentialFormat(credential vc.VerifiableCredential) string {
+	if sdjwt.IsEnveloped(credential) {
+		return sdjwt.Format
+	}
+	return credential.Format()
+}
+
 // deduplicate removes duplicate VCs from the slice.
 // It uses JSON marshalling to determine if two VCs are equal.
 func deduplicate(vcs []vc.VerifiableCredential) []vc.VerifiableCredential {
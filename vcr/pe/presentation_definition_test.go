@@ -35,6 +35,7 @@ import (
 	ssi "github.com/nuts-foundation/go-did"
 	"github.com/nuts-foundation/go-did/vc"
 	"github.com/nuts-foundation/nuts-node/vcr/pe/test"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -233,6 +234,48 @@ func TestMatch(t *testing.T) {
 				assert.Empty(t, mappingObjects)
 			})
 		})
+		t.Run("SD-JWT", func(t *testing.T) {
+			issuerKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			sdJWTVC := vcrTest.SDJWTCredential(t, "did:web:example.com#1", issuerKey, did.MustParseDID("did:web:example.com:holder"))
+			presentationDefinition := PresentationDefinition{
+				Format: &PresentationDefinitionClaimFormatDesignations{"vc+sd-jwt": {"sd-jwt_alg_values": {"ES256"}}},
+				InputDescriptors: []*InputDescriptor{
+					{
+						Id: "example",
+						Constraints: &Constraints{
+							Fields: []Field{{Path: []string{"$.credentialSubject.name"}}},
+						},
+					},
+				},
+			}
+			t.Run("Happy flow", func(t *testing.T) {
+				vcs, mappingObjects, err := presentationDefinition.Match([]vc.VerifiableCredential{sdJWTVC})
+
+				require.NoError(t, err)
+				assert.Len(t, vcs, 1)
+				require.Len(t, mappingObjects, 1)
+				assert.Equal(t, "$.verifiableCredential[0]", mappingObjects[0].Path)
+				assert.Equal(t, "vc+sd-jwt", mappingObjects[0].Format)
+			})
+			t.Run("limit_disclosure required, but not an SD-JWT VC", func(t *testing.T) {
+				presentationDefinition := PresentationDefinition{
+					InputDescriptors: []*InputDescriptor{
+						{
+							Id: "organization",
+							Constraints: &Constraints{
+								LimitDisclosure: LimitDisclosureRequired,
+								Fields:          []Field{{Path: []string{"$.credentialSubject.organization.name"}}},
+							},
+						},
+					},
+				}
+
+				vcs, _, err := presentationDefinition.Match([]vc.VerifiableCredential{jwtVC})
+
+				assert.ErrorIs(t, err, ErrNoCredentials)
+				assert.Empty(t, vcs)
+			})
+		})
 	})
 	t.Run("Input Descriptor Claim Format matching", func(t *testing.T) {
 		// making sure this test doesn't break when testPresentationDefinition changes
@@ -567,6 +610,103 @@ txJy6M1-lD7a5HTzanYTWBPAUHDZGyGKXdJw-W_x0IWChBzI8t3kpG253fg6V3tPgHeKXE94fz_QpYfg
 		})
 	})
 
+	t.Run("SD-JWT", func(t *testing.T) {
+		issuerKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		verifiableCredential := vcrTest.SDJWTCredential(t, "did:web:example.com#1", issuerKey, did.MustParseDID("did:web:example.com:holder"))
+
+		t.Run("alg match", func(t *testing.T) {
+			asFormat := PresentationDefinitionClaimFormatDesignations{"vc+sd-jwt": {"sd-jwt_alg_values": {"ES256"}}}
+			match := matchFormat(&asFormat, verifiableCredential)
+
+			assert.True(t, match)
+		})
+		t.Run("no alg match", func(t *testing.T) {
+			asFormat := PresentationDefinitionClaimFormatDesignations{"vc+sd-jwt": {"sd-jwt_alg_values": {"ES384"}}}
+			match := matchFormat(&asFormat, verifiableCredential)
+
+			assert.False(t, match)
+		})
+		t.Run("no alg values", func(t *testing.T) {
+			asFormat := PresentationDefinitionClaimFormatDesignations{"vc+sd-jwt": {}}
+			match := matchFormat(&asFormat, verifiableCredential)
+
+			assert.True(t, match)
+		})
+		t.Run("format not supported", func(t *testing.T) {
+			asFormat := PresentationDefinitionClaimFormatDesignations{"jwt_vc": {"alg": {"ES256"}}}
+			match := matchFormat(&asFormat, verifiableCredential)
+
+			assert.False(t, match)
+		})
+	})
+}
+
+func TestPresentationDefinition_LimitDisclosure(t *testing.T) {
+	issuerKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	verifiableCredential := vcrTest.SDJWTCredential(t, "did:web:example.com#1", issuerKey, did.MustParseDID("did:web:example.com:holder"))
+	sdJWT, err := sdjwt.Open(verifiableCredential)
+	require.NoError(t, err)
+	require.Len(t, sdJWT.Disclosures, 2)
+	definition := func(limitDisclosure string) PresentationDefinition {
+		return PresentationDefinition{
+			InputDescriptors: []*InputDescriptor{
+				{
+					Id: "example",
+					Constraints: &Constraints{
+						LimitDisclosure: limitDisclosure,
+						Fields:          []Field{{Path: []string{"$.credentialSubject.name"}}},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("required", func(t *testing.T) {
+		result, err := definition(LimitDisclosureRequired).LimitDisclosure("example", *sdJWT)
+
+		require.NoError(t, err)
+		require.Len(t, result.Disclosures, 1)
+		assert.Equal(t, "name", result.Disclosures[0].Name)
+	})
+	t.Run("preferred", func(t *testing.T) {
+		result, err := definition(LimitDisclosurePreferred).LimitDisclosure("example", *sdJWT)
+
+		require.NoError(t, err)
+		require.Len(t, result.Disclosures, 1)
+		assert.Equal(t, "name", result.Disclosures[0].Name)
+	})
+	t.Run("not limited", func(t *testing.T) {
+		result, err := definition("").LimitDisclosure("example", *sdJWT)
+
+		require.NoError(t, err)
+		assert.Len(t, result.Disclosures, 2)
+	})
+	t.Run("unknown input descriptor", func(t *testing.T) {
+		result, err := definition(LimitDisclosureRequired).LimitDisclosure("other", *sdJWT)
+
+		require.NoError(t, err)
+		assert.Len(t, result.Disclosures, 2)
+	})
+	t.Run("field value must not change", func(t *testing.T) {
+		// a path that matches any of the disclosable claims requires both to be disclosed, since the resolved value would change otherwise
+		presentationDefinition := definition(LimitDisclosureRequired)
+		fieldID := "subject"
+		presentationDefinition.InputDescriptors[0].Constraints.Fields = []Field{{Id: &fieldID, Path: []string{"$.credentialSubject"}}}
+
+		result, err := presentationDefinition.LimitDisclosure("example", *sdJWT)
+
+		require.NoError(t, err)
+		assert.Len(t, result.Disclosures, 2)
+	})
+	t.Run("credential does not match", func(t *testing.T) {
+		selection := sdJWT.Select(func(disclosure sdjwt.Disclosure) bool {
+			return disclosure.Name != "name"
+		})
+
+		_, err := definition(LimitDisclosureRequired).LimitDisclosure("example", selection)
+
+		assert.EqualError(t, err, "credential does not match constraints of input descriptor 'example'")
+	})
 }
 
 func Test_matchCredential(t *testing.T) {
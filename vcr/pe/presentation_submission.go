@@ -28,6 +28,7 @@ import (
 	"github.com/nuts-foundation/go-did/vc"
 	"github.com/nuts-foundation/nuts-node/vcr/credential"
 	v2 "github.com/nuts-foundation/nuts-node/vcr/pe/schema/v2"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	"strings"
 )
 
@@ -190,9 +191,9 @@ func resolveCredential(path []string, mapping InputDescriptorMappingObject, valu
 			}
 		}
 	case map[string]interface{}:
-		// must be JSON-LD
+		// must be JSON-LD or an enveloped SD-JWT VC
 		targetValueAsJSON, _ := json.Marshal(targetValue)
-		if mapping.Format == vc.JSONLDCredentialProofFormat {
+		if mapping.Format == vc.JSONLDCredentialProofFormat || mapping.Format == sdjwt.Format {
 			decodedTargetValue, err = vc.ParseVerifiableCredential(string(targetValueAsJSON))
 			if err != nil {
 				return nil, fmt.Errorf("invalid JSON-LD credential at path '%s': %w", fullPathString, err)
@@ -23,6 +23,13 @@ import "errors"
 
 var ErrNoCredentials = errors.New("missing credentials")
 
+const (
+	// LimitDisclosureRequired indicates the holder must limit the submitted claims to those required by the constraints.
+	LimitDisclosureRequired = "required"
+	// LimitDisclosurePreferred indicates the holder should limit the submitted claims to those required by the constraints.
+	LimitDisclosurePreferred = "preferred"
+)
+
 // PresentationDefinitionClaimFormatDesignations (replaces generated one)
 type PresentationDefinitionClaimFormatDesignations map[string]map[string][]string
 
@@ -26,6 +26,7 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"strings"
 )
 
 var ErrIndexNotInBitstring = errors.New("index not in status list")
@@ -99,6 +100,46 @@ func (bs *bitstring) setBit(statusListIndex int, value bool) error {
 	return nil
 }
 
+// bits returns the statusSize bits of the entry at statusListIndex as an unsigned integer, most significant bit first.
+// The entry starts at bit position statusListIndex * statusSize. Returns an error if the entry is (partially) out of bounds.
+func (bs *bitstring) bits(statusListIndex int, statusSize int) (int, error) {
+	if statusListIndex < 0 || statusSize < 1 || (statusListIndex+1)*statusSize > len(*bs)*8 {
+		return 0, ErrIndexNotInBitstring
+	}
+	value := 0
+	for i := statusListIndex * statusSize; i < (statusListIndex+1)*statusSize; i++ {
+		set, err := bs.bit(i)
+		if err != nil {
+			// can't happen, range is checked above
+			return 0, err
+		}
+		value <<= 1
+		if set {
+			value |= 1
+		}
+	}
+	return value, nil
+}
+
+// setBits sets the statusSize bits of the entry at statusListIndex to value, most significant bit first.
+// Returns an error if the entry is (partially) out of bounds, or the value does not fit in statusSize bits.
+func (bs *bitstring) setBits(statusListIndex int, statusSize int, value int) error {
+	if statusListIndex < 0 || statusSize < 1 || (statusListIndex+1)*statusSize > len(*bs)*8 {
+		return ErrIndexNotInBitstring
+	}
+	if value < 0 || value >= 1<<statusSize {
+		return fmt.Errorf("status value %d does not fit in %d bit(s)", value, statusSize)
+	}
+	for i := 0; i < statusSize; i++ {
+		set := value>>(statusSize-1-i)&1 == 1
+		if err := bs.setBit(statusListIndex*statusSize+i, set); err != nil {
+			// can't happen, range is checked above
+			return err
+		}
+	}
+	return nil
+}
+
 // isSet returns true if the r-th bit in b is 1. r MUST be in range [0, 7].
 func isSet(b, r byte) bool {
 	return b>>(7-r)&1 == 1
@@ -123,6 +164,24 @@ func compress(bitstring []byte) (string, error) {
 	return base64.RawURLEncoding.EncodeToString(buf.Bytes()), nil
 }
 
+// multibaseBase64URLPrefix is the multibase prefix for base64url encoding without padding.
+const multibaseBase64URLPrefix = "u"
+
+// compressMultibase compresses a BitstringStatusList bitstring. The compressed bitstring is multibase encoded using base64url without padding.
+func compressMultibase(bitstring []byte) (string, error) {
+	compressed, err := compress(bitstring)
+	if err != nil {
+		return "", err
+	}
+	return multibaseBase64URLPrefix + compressed, nil
+}
+
+// expandMultibase expands a compressed BitstringStatusList bitstring.
+// The multibase prefix is optional, since some implementations omit it. A GZIP-compressed bitstring never starts with a 'u' when base64 encoded.
+func expandMultibase(encodedList string) (bitstring, error) {
+	return expand(strings.TrimPrefix(encodedList, multibaseBase64URLPrefix))
+}
+
 // expand a compressed StatusList2021 bitstring. It first applies base64 decoding followed by gzip decompression.
 func expand(encodedList string) (bitstring, error) {
 	// base64 decode
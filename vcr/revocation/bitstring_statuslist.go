@@ -0,0 +1,202 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package revocation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/jsonld"
+	"gorm.io/gorm"
+)
+
+const (
+	// BitstringStatusListCredentialType is the type of BitstringStatusListCredential
+	BitstringStatusListCredentialType = "BitstringStatusListCredential"
+	// BitstringStatusListCredentialSubjectType is the credentialSubject.type in a BitstringStatusListCredential
+	BitstringStatusListCredentialSubjectType = "BitstringStatusList"
+	// BitstringStatusListEntryType is the credentialStatus.type
+	BitstringStatusListEntryType = "BitstringStatusListEntry"
+)
+
+// maxStatusSize is the largest statusSize supported by the BitstringStatusList verifier.
+// The spec does not limit the size, but every status value must have a statusMessage, so larger sizes are impractical.
+const maxStatusSize = 8
+
+var BitstringStatusListContextURI = ssi.MustParseURI(jsonld.W3cBitstringStatusListContext)
+var bitstringStatusListCredentialTypeURI = ssi.MustParseURI(BitstringStatusListCredentialType)
+
+// BitstringStatusListIssuer is the issuer side of the BitstringStatusList
+type BitstringStatusListIssuer interface {
+	// Credential provides a valid BitstringStatusListCredential with subject ID derived from the issuer, purpose and page.
+	// It returns the last issued BitstringStatusListCredential if it is still valid or issues a new BitstringStatusListCredential.
+	Credential(ctx context.Context, issuer did.DID, purpose StatusPurpose, page int) (*vc.VerifiableCredential, error)
+	// Entry creates a BitstringStatusListEntry that can be added to the credentialStatus of a VC.
+	// Only the purposes 'revocation' and 'suspension' can be issued. Each purpose is tracked on its own BitstringStatusListCredentials.
+	// The corresponding BitstringStatusListCredential will have a gap in the bitstring if the returned entry does not make it into a VC.
+	Entry(ctx context.Context, issuer did.DID, purpose StatusPurpose) (*BitstringStatusListEntry, error)
+	// MessageEntry creates a BitstringStatusListEntry with statusPurpose 'message' that can be added to the credentialStatus of a VC.
+	// There must be a message for every status value, so the number of messages must be a power of 2; it determines the statusSize of the entry.
+	// Message entries are tracked on their own BitstringStatusListCredentials, separately for every statusSize.
+	MessageEntry(ctx context.Context, issuer did.DID, messages []StatusMessage) (*BitstringStatusListEntry, error)
+	// Revoke sets the status of a 'revocation' BitstringStatusListEntry, and updates the relevant BitstringStatusListCredential.
+	// The credentialID allows reverse search of revocations, its issuer is NOT verified against the entry issuer or VC.
+	// Returns types.ErrRevoked if already revoked, or types.ErrNotFound when the entry.StatusListCredential is unknown.
+	Revoke(ctx context.Context, credentialID ssi.URI, entry BitstringStatusListEntry) error
+	// Suspend sets the status of a 'suspension' BitstringStatusListEntry, and updates the relevant BitstringStatusListCredential.
+	// Returns types.ErrSuspended if already suspended, or types.ErrNotFound when the entry.StatusListCredential is unknown.
+	Suspend(ctx context.Context, credentialID ssi.URI, entry BitstringStatusListEntry) error
+	// Unsuspend clears the status of a 'suspension' BitstringStatusListEntry, and updates the relevant BitstringStatusListCredential.
+	// Returns types.ErrNotSuspended if not suspended, or types.ErrNotFound when the entry.StatusListCredential is unknown.
+	Unsuspend(ctx context.Context, entry BitstringStatusListEntry) error
+	// SetMessageStatus sets the status value of a 'message' BitstringStatusListEntry, and updates the relevant BitstringStatusListCredential.
+	// A status of 0 clears the entry. Returns types.ErrNotFound when the entry.StatusListCredential is unknown.
+	SetMessageStatus(ctx context.Context, credentialID ssi.URI, entry BitstringStatusListEntry, status int) error
+	// GetRevocation checks if the credential, issued locally, was revoked.
+	// Returns the revocation information if the credential is revoked, or nil if not revoked.
+	// Suspensions are not reported as revocation.
+	GetRevocation(credentialID ssi.URI) (*Revocation, error)
+}
+
+// BitstringStatusListVerifier is the verifier side of the BitstringStatusList
+type BitstringStatusListVerifier interface {
+	// Verify returns types.ErrRevoked or types.ErrSuspended when the credentialStatus contains a 'BitstringStatusListEntry'
+	// with statusPurpose 'revocation' or 'suspension' that can be resolved and lists the credential. Revocation takes precedence over suspension.
+	// Other credentialStatus types and purposes are ignored. Verification may fail with other non-standardized errors.
+	Verify(credentialToVerify vc.VerifiableCredential) error
+	// Status resolves the current status of the BitstringStatusListEntry.
+	Status(entry BitstringStatusListEntry) (*Status, error)
+}
+
+var _ BitstringStatusListIssuer = (*BitstringStatusList)(nil)
+var _ BitstringStatusListVerifier = (*BitstringStatusList)(nil)
+
+// BitstringStatusList implements the W3C Bitstring Status List v1.0 specification.
+// https://www.w3.org/TR/vc-bitstring-status-list/
+// VerifySignature and Sign methods are used to verify and sign BitstringStatusListCredentials
+type BitstringStatusList struct {
+	statusListCore
+}
+
+// NewBitstringStatusList returns a BitstringStatusList without a Sign or VerifySignature method.
+// The URL in the credential will be constructed as follows using the given base URL: <baseURL>/statuslist/<did>/<purpose>/<page>
+func NewBitstringStatusList(db *gorm.DB, client core.HTTPRequestDoer, baseURL string) *BitstringStatusList {
+	return &BitstringStatusList{statusListCore{client: client, db: db, baseURL: baseURL, format: bitstringStatusListFormat}}
+}
+
+// Status is the resolved status of a BitstringStatusListEntry.
+type Status struct {
+	// Purpose is the statusPurpose of the BitstringStatusListEntry
+	Purpose string
+	// Value is the value of the statusSize bits of the entry. A value of 0 means the status is not set.
+	Value int
+	// Message is the statusMessage.message matching the Value. Only set when the entry contains status messages.
+	Message string
+}
+
+// StatusMessage maps a status value to a message, used for multi-bit BitstringStatusListEntries.
+type StatusMessage struct {
+	// Status is the hexadecimal status value, prefixed with '0x'.
+	Status string `json:"status"`
+	// Message describes the status.
+	Message string `json:"message"`
+}
+
+// BitstringStatusListEntry is the "credentialStatus" property used by issuers to enable VerifiableCredential status information.
+type BitstringStatusListEntry struct {
+	// ID is expected to be a URL that identifies the status information associated with the verifiable credential.
+	// It MUST NOT be the URL for the status list, which is in StatusListCredential.
+	ID string `json:"id,omitempty"`
+	// Type MUST be "BitstringStatusListEntry"
+	Type string `json:"type,omitempty"`
+	// StatusPurpose indicates what it means if the VerifiableCredential is on the list.
+	// Predefined values are `revocation`, `suspension` and `message`.
+	// This value must match credentialSubject.statusPurpose value in the BitstringStatusListCredential.
+	StatusPurpose string `json:"statusPurpose,omitempty"`
+	// StatusListIndex is an arbitrary size integer greater than or equal to 0, expressed as a string.
+	// The value identifies the position of the status in the bitstring of the corresponding StatusListCredential.
+	StatusListIndex string `json:"statusListIndex,omitempty"`
+	// StatusListCredential property MUST be a URL to the BitstringStatusListCredential.
+	StatusListCredential string `json:"statusListCredential,omitempty"`
+	// StatusSize is the number of bits per status. Defaults to 1 if absent.
+	StatusSize int `json:"statusSize,omitempty"`
+	// StatusMessage lists a message for every possible status value. Required if StatusSize is larger than 1.
+	StatusMessage []StatusMessage `json:"statusMessage,omitempty"`
+}
+
+// size returns the StatusSize, or its default value if absent.
+func (e BitstringStatusListEntry) size() int {
+	if e.StatusSize == 0 {
+		return 1
+	}
+	return e.StatusSize
+}
+
+// Validate returns an error if the contents of the BitstringStatusListEntry violate the spec.
+func (e BitstringStatusListEntry) Validate() error {
+	// 'id' MUST NOT be the URL for the status list
+	if e.ID == e.StatusListCredential {
+		return errors.New("BitstringStatusListEntry.id is the same as the BitstringStatusListEntry.statusListCredential")
+	}
+
+	if e.Type != BitstringStatusListEntryType {
+		return errors.New("BitstringStatusListEntry.type must be BitstringStatusListEntry")
+	}
+
+	// StatusPurpose must contain a purpose
+	if e.StatusPurpose == "" {
+		return errors.New("BitstringStatusListEntry.statusPurpose is required")
+	}
+
+	// statusListIndex must be a non-negative number
+	if n, err := strconv.Atoi(e.StatusListIndex); err != nil || n < 0 {
+		return errors.New("invalid BitstringStatusListEntry.statusListIndex")
+	}
+
+	// 'statusListCredential' must be a URL
+	if _, err := url.ParseRequestURI(e.StatusListCredential); err != nil {
+		return fmt.Errorf("parse BitstringStatusListEntry.statusListCredential URL: %w", err)
+	}
+
+	// multi-bit statuses must have a message for every possible value
+	if e.StatusSize < 0 || e.StatusSize > maxStatusSize {
+		return fmt.Errorf("BitstringStatusListEntry.statusSize must be in range [1, %d]", maxStatusSize)
+	}
+	if e.size() > 1 && len(e.StatusMessage) != 1<<e.size() {
+		return errors.New("BitstringStatusListEntry.statusMessage must contain a message for every status value")
+	}
+	for _, message := range e.StatusMessage {
+		if _, err := strconv.ParseUint(message.Status, 0, 64); err != nil {
+			return fmt.Errorf("invalid BitstringStatusListEntry.statusMessage.status: %s", message.Status)
+		}
+	}
+
+	return nil
+}
+
+// BitstringStatusListCredentialSubject of a BitstringStatusListCredential.
+// EncodedList is the multibase base64url encoded, GZIP-compressed [RFC1952] bitstring instead of the base-64 encoding of a StatusList2021Credential.
+type BitstringStatusListCredentialSubject = StatusList2021CredentialSubject
@@ -0,0 +1,292 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package revocation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/bits"
+	"net/url"
+	"strconv"
+	"time"
+
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/vcr/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (s bitstringIssuerRecord) TableName() string {
+	return "bitstring_status_list"
+}
+
+// bitstringIssuerRecord keeps track of a BitstringStatusListCredential issued by the Issuer, and what the LastIssuedIndex is for the BitstringStatusListCredential.
+// Issuers can have multiple BitstringStatusListCredentials per purpose, the one with the highest page number is the one currently being issued on.
+type bitstringIssuerRecord struct {
+	// SubjectID is the VC.credentialSubject.ID for this BitstringStatusListCredential.
+	// It is the URL where the BitstringStatusListCredential can be downloaded e.g., https://example.com/iam/statuslist/did:web:example.com/suspension/1.
+	SubjectID string `gorm:"primaryKey"`
+	// Issuer of the BitstringStatusListCredential.
+	Issuer string
+	// StatusPurpose of all entries on this page.
+	StatusPurpose string
+	// StatusSize is the number of bits per entry on this page.
+	StatusSize int
+	// Page number corresponding to this SubjectID.
+	Page int
+	// LastIssuedIndex on this page. Range:  0 <= StatusListIndex <= maxBitstringIndex / StatusSize
+	LastIssuedIndex int
+	// Entries lists all entries that have their status set for this SubjectID
+	Entries []bitstringEntryRecord `gorm:"foreignKey:StatusListCredential;references:SubjectID"`
+}
+
+func (s bitstringEntryRecord) TableName() string {
+	return "bitstring_status_list_entry"
+}
+
+// bitstringEntryRecord is created when the status of a BitstringStatusListEntry is set, and removed when it is cleared.
+type bitstringEntryRecord struct {
+	// StatusListCredential is the credentialSubject.ID this entry belongs to.
+	StatusListCredential string `gorm:"primaryKey"`
+	// StatusListIndex of the status list entry. Range: 0 <= StatusListIndex <= maxBitstringIndex / StatusSize
+	StatusListIndex int `gorm:"primaryKey;autoIncrement:false"`
+	// Status is the value of the entry. Always 1 for 'revocation' and 'suspension' entries.
+	Status int
+	// CredentialID is the VC.ID of the credential listed by this status list entry.
+	// The value is stored as convenience when the status is set, but is not validated.
+	CredentialID string
+	// CreatedAt contains the UNIX timestamp the status was set.
+	CreatedAt int64 `gorm:"autoCreateTime"`
+}
+
+func (cs *BitstringStatusList) Credential(ctx context.Context, issuerDID did.DID, purpose StatusPurpose, page int) (*vc.VerifiableCredential, error) {
+	return cs.credential(ctx, issuerDID, cs.statusListURL(issuerDID, purpose, page), cs.loadIssuedList)
+}
+
+// loadIssuedList loads the bitstringIssuerRecord and all its entries.
+func (cs *BitstringStatusList) loadIssuedList(tx *gorm.DB, subjectID string) (*issuedList, error) {
+	issuerRecord := new(bitstringIssuerRecord)
+	err := tx.Preload("Entries").First(issuerRecord, "subject_id = ?", subjectID).Error
+	if err != nil {
+		return nil, err
+	}
+	result := &issuedList{
+		statusListPage: statusListPage{
+			SubjectID:       issuerRecord.SubjectID,
+			Issuer:          issuerRecord.Issuer,
+			StatusPurpose:   issuerRecord.StatusPurpose,
+			StatusSize:      issuerRecord.StatusSize,
+			Page:            issuerRecord.Page,
+			LastIssuedIndex: issuerRecord.LastIssuedIndex,
+		},
+		statuses: make(map[int]int, len(issuerRecord.Entries)),
+	}
+	for _, entry := range issuerRecord.Entries {
+		result.statuses[entry.StatusListIndex] = entry.Status
+	}
+	return result, nil
+}
+
+func (cs *BitstringStatusList) Entry(ctx context.Context, issuer did.DID, purpose StatusPurpose) (*BitstringStatusListEntry, error) {
+	if purpose != StatusPurposeRevocation && purpose != StatusPurposeSuspension {
+		return nil, errUnsupportedPurpose
+	}
+	return cs.entry(ctx, issuer, purpose, 1)
+}
+
+func (cs *BitstringStatusList) MessageEntry(ctx context.Context, issuer did.DID, messages []StatusMessage) (*BitstringStatusListEntry, error) {
+	// every status value must have a message, so the number of messages determines the statusSize
+	statusSize := bits.Len(uint(len(messages))) - 1
+	if len(messages) < 2 || len(messages) != 1<<statusSize || statusSize > maxStatusSize {
+		return nil, fmt.Errorf("status list: number of status messages must be a power of 2 in range [2, %d]", 1<<maxStatusSize)
+	}
+	listed := make(map[uint64]bool, len(messages))
+	for _, message := range messages {
+		status, err := strconv.ParseUint(message.Status, 0, 64)
+		if err != nil || status >= uint64(len(messages)) || listed[status] {
+			return nil, fmt.Errorf("status list: invalid status message status: %s", message.Status)
+		}
+		listed[status] = true
+	}
+
+	entry, err := cs.entry(ctx, issuer, StatusPurposeMessage, statusSize)
+	if err != nil {
+		return nil, err
+	}
+	entry.StatusSize = statusSize
+	entry.StatusMessage = messages
+	return entry, nil
+}
+
+// entry reserves an entry of statusSize bits on the last BitstringStatusListCredential of the issuer for the purpose.
+func (cs *BitstringStatusList) entry(ctx context.Context, issuer did.DID, purpose StatusPurpose, statusSize int) (*BitstringStatusListEntry, error) {
+	template := statusListPage{Issuer: issuer.String(), StatusPurpose: string(purpose), StatusSize: statusSize}
+	pageURL := func(page int) string {
+		return cs.statusListURL(issuer, purpose, page)
+	}
+	newRecord := func(page statusListPage) any {
+		return &bitstringIssuerRecord{
+			SubjectID:       page.SubjectID,
+			Issuer:          page.Issuer,
+			StatusPurpose:   page.StatusPurpose,
+			StatusSize:      page.size(),
+			Page:            page.Page,
+			LastIssuedIndex: page.LastIssuedIndex,
+		}
+	}
+	page, err := cs.nextIndex(ctx, template, pageURL, newRecord, "issuer = ? AND status_purpose = ?", issuer.String(), string(purpose))
+	if err != nil {
+		return nil, err
+	}
+
+	return &BitstringStatusListEntry{
+		ID:                   fmt.Sprintf("%s#%d", page.SubjectID, page.LastIssuedIndex),
+		Type:                 BitstringStatusListEntryType,
+		StatusPurpose:        string(purpose),
+		StatusListIndex:      strconv.Itoa(page.LastIssuedIndex),
+		StatusListCredential: page.SubjectID,
+	}, nil
+}
+
+func (cs *BitstringStatusList) Revoke(ctx context.Context, credentialID ssi.URI, entry BitstringStatusListEntry) error {
+	if entry.StatusPurpose != StatusPurposeRevocation {
+		return errUnsupportedPurpose
+	}
+	return cs.setStatus(ctx, credentialID, entry, 1)
+}
+
+func (cs *BitstringStatusList) Suspend(ctx context.Context, credentialID ssi.URI, entry BitstringStatusListEntry) error {
+	if entry.StatusPurpose != StatusPurposeSuspension {
+		return errUnsupportedPurpose
+	}
+	return cs.setStatus(ctx, credentialID, entry, 1)
+}
+
+func (cs *BitstringStatusList) Unsuspend(ctx context.Context, entry BitstringStatusListEntry) error {
+	if entry.StatusPurpose != StatusPurposeSuspension {
+		return errUnsupportedPurpose
+	}
+	return cs.setStatus(ctx, ssi.URI{}, entry, 0)
+}
+
+func (cs *BitstringStatusList) SetMessageStatus(ctx context.Context, credentialID ssi.URI, entry BitstringStatusListEntry, status int) error {
+	if entry.StatusPurpose != StatusPurposeMessage {
+		return errUnsupportedPurpose
+	}
+	if status < 0 || status >= 1<<entry.size() {
+		return fmt.Errorf("status list: status %d does not fit in %d bit(s)", status, entry.size())
+	}
+	return cs.setStatus(ctx, credentialID, entry, status)
+}
+
+// setStatus sets the status value of the entry, and re-issues the BitstringStatusListCredential. A status of 0 clears the entry.
+// Setting a 'revocation' or 'suspension' that is already set fails, as does clearing a 'suspension' that is not set.
+func (cs *BitstringStatusList) setStatus(ctx context.Context, credentialID ssi.URI, entry BitstringStatusListEntry, status int) error {
+	// parse StatusListIndex
+	statusListIndex, err := strconv.Atoi(entry.StatusListIndex)
+	if err != nil {
+		return err
+	}
+
+	// check if BitstringStatusListCredential is managed by this node
+	page, err := cs.page(entry.StatusListCredential)
+	if err != nil {
+		return err
+	}
+	if page.StatusPurpose != entry.StatusPurpose {
+		return fmt.Errorf("BitstringStatusListCredential.credentialSubject.statusPurpose='%s' does not match vc.credentialStatus.statusPurpose='%s'", page.StatusPurpose, entry.StatusPurpose)
+	}
+	if page.size() != entry.size() {
+		return fmt.Errorf("BitstringStatusListCredential entries have statusSize=%d, vc.credentialStatus.statusSize=%d", page.size(), entry.size())
+	}
+
+	return cs.updateStatus(ctx, *page, statusListIndex, func(tx *gorm.DB) error {
+		record := bitstringEntryRecord{
+			StatusListCredential: entry.StatusListCredential,
+			StatusListIndex:      statusListIndex,
+			Status:               status,
+			CredentialID:         credentialID.String(),
+		}
+		switch {
+		case status == 0:
+			result := tx.Where("status_list_credential = ? AND status_list_index = ?", entry.StatusListCredential, statusListIndex).
+				Delete(&bitstringEntryRecord{})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 && entry.StatusPurpose == StatusPurposeSuspension {
+				return types.ErrNotSuspended
+			}
+			return nil
+		case entry.StatusPurpose == StatusPurposeMessage:
+			// messages can change to any other status
+			return tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&record).Error
+		default:
+			// fail fast, immediately fail if the status is already set
+			err := tx.Create(&record).Error
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return errStatusAlreadySet(entry.StatusPurpose)
+			}
+			return err
+		}
+	}, cs.loadIssuedList)
+}
+
+// errStatusAlreadySet returns the error for setting a status that is already set.
+func errStatusAlreadySet(purpose string) error {
+	if purpose == StatusPurposeSuspension {
+		return types.ErrSuspended
+	}
+	return types.ErrRevoked
+}
+
+// GetRevocation checks if the credential, issued locally, was revoked.
+// Returns the revocation information if the credential is revoked, or nil if not revoked.
+// Returns an error if the database query fails.
+func (cs *BitstringStatusList) GetRevocation(credentialID ssi.URI) (*Revocation, error) {
+	var result struct {
+		StatusPurpose string
+		CreatedAt     int64
+	}
+	err := cs.db.Model(&bitstringEntryRecord{}).
+		Select("bitstring_status_list.status_purpose, bitstring_status_list_entry.created_at").
+		Joins("JOIN bitstring_status_list ON bitstring_status_list_entry.status_list_credential = bitstring_status_list.subject_id").
+		Where("bitstring_status_list_entry.credential_id = ? AND bitstring_status_list.status_purpose = ?", credentialID.String(), StatusPurposeRevocation).
+		First(&result).
+		Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &Revocation{
+		Purpose:   result.StatusPurpose,
+		RevokedAt: time.Unix(result.CreatedAt, 0),
+	}, nil
+}
+
+func (cs *BitstringStatusList) statusListURL(issuer did.DID, purpose StatusPurpose, page int) string {
+	// https://example.com/statuslist/<did>/<purpose>/page
+	result, _ := url.Parse(cs.baseURL)
+	return result.JoinPath("statuslist", issuer.String(), string(purpose), strconv.Itoa(page)).String()
+}
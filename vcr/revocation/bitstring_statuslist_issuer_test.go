@@ -0,0 +1,402 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package revocation
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/audit"
+	"github.com/nuts-foundation/nuts-node/vcr/types"
+	"github.com/nuts-foundation/nuts-node/vdr/resolver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func Test_BitstringTableNames(t *testing.T) {
+	assert.Equal(t, bitstringIssuerRecord{}.TableName(), "bitstring_status_list")
+	assert.Equal(t, bitstringEntryRecord{}.TableName(), "bitstring_status_list_entry")
+}
+
+func TestBitstringStatusList_Entry(t *testing.T) {
+	s := newTestBitstringStatusList(t, aliceDID, bobDID)
+	testCtx := context.Background()
+
+	t.Run("ok - new issuer", func(t *testing.T) {
+		statusListCredential := s.statusListURL(aliceDID, StatusPurposeSuspension, 1)
+		// confirm empty DB
+		assert.ErrorIs(t, s.db.First(new(bitstringIssuerRecord), "subject_id = ?", statusListCredential).Error, gorm.ErrRecordNotFound)
+
+		entry, err := s.Entry(testCtx, aliceDID, StatusPurposeSuspension)
+
+		assert.NoError(t, err)
+		require.NotNil(t, entry)
+		assert.Equal(t, "https://example.com/statuslist/did:web:example.com:iam:alice/suspension/1", entry.StatusListCredential)
+		assert.Equal(t, "0", entry.StatusListIndex)
+		assert.Equal(t, fmt.Sprintf("%s#0", statusListCredential), entry.ID)
+		assert.Equal(t, BitstringStatusListEntryType, entry.Type)
+		assert.Equal(t, StatusPurposeSuspension, entry.StatusPurpose)
+		assert.NoError(t, entry.Validate())
+
+		// confirm records created
+		assert.NoError(t, s.db.First(new(bitstringIssuerRecord), "subject_id = ?", statusListCredential).Error)
+		assert.NoError(t, s.db.First(new(credentialRecord), "subject_id = ?", statusListCredential).Error)
+	})
+	t.Run("ok - second entry", func(t *testing.T) {
+		entry, err := s.Entry(testCtx, aliceDID, StatusPurposeSuspension)
+
+		assert.NoError(t, err)
+		require.NotNil(t, entry)
+		assert.Equal(t, "1", entry.StatusListIndex)
+	})
+	t.Run("ok - purposes have separate lists", func(t *testing.T) {
+		entry, err := s.Entry(testCtx, aliceDID, StatusPurposeRevocation)
+
+		assert.NoError(t, err)
+		require.NotNil(t, entry)
+		assert.Equal(t, s.statusListURL(aliceDID, StatusPurposeRevocation, 1), entry.StatusListCredential)
+		assert.Equal(t, "0", entry.StatusListIndex)
+		assert.Equal(t, StatusPurposeRevocation, entry.StatusPurpose)
+	})
+	t.Run("ok - credential rollover", func(t *testing.T) {
+		s.db.Model(&bitstringIssuerRecord{}).
+			Where("subject_id = ?", s.statusListURL(aliceDID, StatusPurposeSuspension, 1)).
+			Update("last_issued_index", maxBitstringIndex)
+
+		entry, err := s.Entry(testCtx, aliceDID, StatusPurposeSuspension)
+
+		assert.NoError(t, err)
+		require.NotNil(t, entry)
+		assert.Equal(t, s.statusListURL(aliceDID, StatusPurposeSuspension, 2), entry.StatusListCredential)
+		assert.Equal(t, "0", entry.StatusListIndex)
+	})
+	t.Run("error - signing key not found", func(t *testing.T) {
+		s := newTestBitstringStatusList(t, aliceDID)
+		s.ResolveKey = func(_ did.DID, _ *time.Time, _ resolver.RelationType) (string, crypto.PublicKey, error) {
+			return "", nil, errors.New("do re mi")
+		}
+
+		entry, err := s.Entry(testCtx, aliceDID, StatusPurposeSuspension)
+
+		assert.EqualError(t, err, "do re mi")
+		assert.Nil(t, entry)
+	})
+	t.Run("error - unsupported purpose", func(t *testing.T) {
+		entry, err := s.Entry(testCtx, aliceDID, StatusPurposeMessage)
+		assert.ErrorIs(t, err, errUnsupportedPurpose)
+		assert.Nil(t, entry)
+	})
+}
+
+func TestBitstringStatusList_Suspend(t *testing.T) {
+	s := newTestBitstringStatusList(t, aliceDID, bobDID)
+	entryP, err := s.Entry(context.Background(), aliceDID, StatusPurposeSuspension)
+	require.NoError(t, err)
+	entry := *entryP
+	credentialID := ssi.MustParseURI("did:web:example.com:iam:alice#123")
+
+	isSet := func(t *testing.T) bool {
+		credRecord, err := s.loadCredential(entry.StatusListCredential)
+		require.NoError(t, err)
+		set, _ := credRecord.Bitstring.bit(0)
+		return set
+	}
+
+	t.Run("ok - suspend", func(t *testing.T) {
+		require.False(t, isSet(t))
+
+		require.NoError(t, s.Suspend(context.Background(), credentialID, entry))
+
+		assert.True(t, isSet(t))
+		var record bitstringEntryRecord
+		require.NoError(t, s.db.First(&record, "status_list_credential = ? AND status_list_index = 0", entry.StatusListCredential).Error)
+		assert.Equal(t, credentialID.String(), record.CredentialID)
+		assert.InDelta(t, time.Now().Unix(), record.CreatedAt, 2)
+	})
+	t.Run("error - already suspended", func(t *testing.T) {
+		assert.ErrorIs(t, s.Suspend(context.Background(), credentialID, entry), types.ErrSuspended)
+	})
+	t.Run("ok - suspension is not a revocation", func(t *testing.T) {
+		revocation, err := s.GetRevocation(credentialID)
+		assert.NoError(t, err)
+		assert.Nil(t, revocation)
+	})
+	t.Run("ok - unsuspend", func(t *testing.T) {
+		require.NoError(t, s.Unsuspend(context.Background(), entry))
+
+		assert.False(t, isSet(t))
+	})
+	t.Run("error - not suspended", func(t *testing.T) {
+		assert.ErrorIs(t, s.Unsuspend(context.Background(), entry), types.ErrNotSuspended)
+	})
+	t.Run("ok - suspend again", func(t *testing.T) {
+		require.NoError(t, s.Suspend(context.Background(), credentialID, entry))
+
+		assert.True(t, isSet(t))
+	})
+	t.Run("error - unsupported purpose", func(t *testing.T) {
+		cEntry := entry
+		cEntry.StatusPurpose = StatusPurposeRevocation
+		assert.ErrorIs(t, s.Suspend(context.Background(), credentialID, cEntry), errUnsupportedPurpose)
+		assert.ErrorIs(t, s.Unsuspend(context.Background(), cEntry), errUnsupportedPurpose)
+	})
+	t.Run("error - purpose does not match status list", func(t *testing.T) {
+		revocationEntry, err := s.Entry(context.Background(), aliceDID, StatusPurposeRevocation)
+		require.NoError(t, err)
+		revocationEntry.StatusPurpose = StatusPurposeSuspension
+
+		err = s.Suspend(context.Background(), credentialID, *revocationEntry)
+
+		assert.EqualError(t, err, "BitstringStatusListCredential.credentialSubject.statusPurpose='revocation' does not match vc.credentialStatus.statusPurpose='suspension'")
+	})
+	t.Run("error - ErrNotFound", func(t *testing.T) {
+		cEntry := entry
+		cEntry.StatusListCredential += "unknown"
+		assert.ErrorIs(t, s.Suspend(context.Background(), credentialID, cEntry), types.ErrNotFound)
+	})
+	t.Run("error - statusListIndex OOB", func(t *testing.T) {
+		cEntry := entry
+		cEntry.StatusListIndex = "10"
+		assert.ErrorIs(t, s.Suspend(context.Background(), credentialID, cEntry), ErrIndexNotInBitstring)
+	})
+}
+
+func TestBitstringStatusList_Revoke(t *testing.T) {
+	s := newTestBitstringStatusList(t, aliceDID)
+	entry, err := s.Entry(context.Background(), aliceDID, StatusPurposeRevocation)
+	require.NoError(t, err)
+	credentialID := ssi.MustParseURI("did:web:example.com:iam:alice#123")
+
+	t.Run("ok", func(t *testing.T) {
+		require.NoError(t, s.Revoke(context.Background(), credentialID, *entry))
+
+		revocation, err := s.GetRevocation(credentialID)
+		assert.NoError(t, err)
+		require.NotNil(t, revocation)
+		assert.Equal(t, StatusPurposeRevocation, revocation.Purpose)
+		assert.False(t, revocation.RevokedAt.IsZero())
+	})
+	t.Run("error - ErrRevoked", func(t *testing.T) {
+		assert.ErrorIs(t, s.Revoke(context.Background(), credentialID, *entry), types.ErrRevoked)
+	})
+	t.Run("error - signing key not found", func(t *testing.T) {
+		s := newTestBitstringStatusList(t, aliceDID)
+		entry, err := s.Entry(context.Background(), aliceDID, StatusPurposeRevocation)
+		require.NoError(t, err)
+		s.ResolveKey = func(_ did.DID, _ *time.Time, _ resolver.RelationType) (string, crypto.PublicKey, error) {
+			return "", nil, errors.New("no key")
+		}
+		assert.EqualError(t, s.Revoke(context.Background(), credentialID, *entry), "no key")
+	})
+}
+
+func TestBitstringStatusList_MessageEntry(t *testing.T) {
+	s := newTestBitstringStatusList(t, aliceDID)
+	testCtx := context.Background()
+	messages := []StatusMessage{{Status: "0x0", Message: "pending"}, {Status: "0x1", Message: "accepted"}, {Status: "0x2", Message: "rejected"}, {Status: "0x3", Message: "undecided"}}
+
+	t.Run("ok", func(t *testing.T) {
+		entry, err := s.MessageEntry(testCtx, aliceDID, messages)
+
+		assert.NoError(t, err)
+		require.NotNil(t, entry)
+		assert.Equal(t, s.statusListURL(aliceDID, StatusPurposeMessage, 1), entry.StatusListCredential)
+		assert.Equal(t, "0", entry.StatusListIndex)
+		assert.Equal(t, StatusPurposeMessage, entry.StatusPurpose)
+		assert.Equal(t, 2, entry.StatusSize)
+		assert.Equal(t, messages, entry.StatusMessage)
+		assert.NoError(t, entry.Validate())
+	})
+	t.Run("ok - statusSize have separate lists", func(t *testing.T) {
+		entry, err := s.MessageEntry(testCtx, aliceDID, messages[:2])
+
+		assert.NoError(t, err)
+		require.NotNil(t, entry)
+		assert.Equal(t, s.statusListURL(aliceDID, StatusPurposeMessage, 2), entry.StatusListCredential)
+		assert.Equal(t, "0", entry.StatusListIndex)
+		assert.Equal(t, 1, entry.StatusSize)
+
+		entry, err = s.MessageEntry(testCtx, aliceDID, messages)
+
+		assert.NoError(t, err)
+		require.NotNil(t, entry)
+		assert.Equal(t, s.statusListURL(aliceDID, StatusPurposeMessage, 1), entry.StatusListCredential)
+		assert.Equal(t, "1", entry.StatusListIndex)
+	})
+	t.Run("ok - credential rollover", func(t *testing.T) {
+		s.db.Model(&bitstringIssuerRecord{}).
+			Where("subject_id = ?", s.statusListURL(aliceDID, StatusPurposeMessage, 1)).
+			Update("last_issued_index", (maxBitstringIndex+1)/2-1)
+
+		entry, err := s.MessageEntry(testCtx, aliceDID, messages)
+
+		assert.NoError(t, err)
+		require.NotNil(t, entry)
+		assert.Equal(t, s.statusListURL(aliceDID, StatusPurposeMessage, 3), entry.StatusListCredential)
+		assert.Equal(t, "0", entry.StatusListIndex)
+	})
+	t.Run("error - number of messages is not a power of 2", func(t *testing.T) {
+		entry, err := s.MessageEntry(testCtx, aliceDID, messages[:3])
+
+		assert.EqualError(t, err, "status list: number of status messages must be a power of 2 in range [2, 256]")
+		assert.Nil(t, entry)
+	})
+	t.Run("error - single message", func(t *testing.T) {
+		entry, err := s.MessageEntry(testCtx, aliceDID, messages[:1])
+
+		assert.EqualError(t, err, "status list: number of status messages must be a power of 2 in range [2, 256]")
+		assert.Nil(t, entry)
+	})
+	t.Run("error - status value listed twice", func(t *testing.T) {
+		entry, err := s.MessageEntry(testCtx, aliceDID, []StatusMessage{{Status: "0x0"}, {Status: "0x0"}})
+
+		assert.EqualError(t, err, "status list: invalid status message status: 0x0")
+		assert.Nil(t, entry)
+	})
+	t.Run("error - status value out of range", func(t *testing.T) {
+		entry, err := s.MessageEntry(testCtx, aliceDID, []StatusMessage{{Status: "0x0"}, {Status: "0x2"}})
+
+		assert.EqualError(t, err, "status list: invalid status message status: 0x2")
+		assert.Nil(t, entry)
+	})
+}
+
+func TestBitstringStatusList_SetMessageStatus(t *testing.T) {
+	s := newTestBitstringStatusList(t, aliceDID)
+	messages := []StatusMessage{{Status: "0x0", Message: "pending"}, {Status: "0x1", Message: "accepted"}, {Status: "0x2", Message: "rejected"}, {Status: "0x3", Message: "undecided"}}
+	entry, err := s.MessageEntry(context.Background(), aliceDID, messages)
+	require.NoError(t, err)
+	credentialID := ssi.MustParseURI("did:web:example.com:iam:alice#123")
+
+	status := func(t *testing.T) *Status {
+		result, err := s.Status(*entry)
+		require.NoError(t, err)
+		return result
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		require.NoError(t, s.SetMessageStatus(context.Background(), credentialID, *entry, 2))
+
+		assert.Equal(t, &Status{Purpose: StatusPurposeMessage, Value: 2, Message: "rejected"}, status(t))
+	})
+	t.Run("ok - change status", func(t *testing.T) {
+		require.NoError(t, s.SetMessageStatus(context.Background(), credentialID, *entry, 3))
+
+		assert.Equal(t, 3, status(t).Value)
+	})
+	t.Run("ok - clear status", func(t *testing.T) {
+		require.NoError(t, s.SetMessageStatus(context.Background(), credentialID, *entry, 0))
+
+		assert.Equal(t, &Status{Purpose: StatusPurposeMessage, Value: 0, Message: "pending"}, status(t))
+		assert.ErrorIs(t, s.db.First(new(bitstringEntryRecord), "status_list_credential = ?", entry.StatusListCredential).Error, gorm.ErrRecordNotFound)
+	})
+	t.Run("error - status does not fit", func(t *testing.T) {
+		err := s.SetMessageStatus(context.Background(), credentialID, *entry, 4)
+
+		assert.EqualError(t, err, "status list: status 4 does not fit in 2 bit(s)")
+	})
+	t.Run("error - unsupported purpose", func(t *testing.T) {
+		cEntry := *entry
+		cEntry.StatusPurpose = StatusPurposeRevocation
+
+		assert.ErrorIs(t, s.SetMessageStatus(context.Background(), credentialID, cEntry, 1), errUnsupportedPurpose)
+	})
+	t.Run("error - statusSize does not match status list", func(t *testing.T) {
+		cEntry := *entry
+		cEntry.StatusSize = 1
+
+		err := s.SetMessageStatus(context.Background(), credentialID, cEntry, 1)
+
+		assert.EqualError(t, err, "BitstringStatusListCredential entries have statusSize=2, vc.credentialStatus.statusSize=1")
+	})
+}
+
+func TestBitstringStatusList_Credential(t *testing.T) {
+	s := newTestBitstringStatusList(t, aliceDID)
+	auditCtx := audit.TestContext()
+	entryP, err := s.Entry(auditCtx, aliceDID, StatusPurposeSuspension)
+	require.NoError(t, err)
+	entry := *entryP
+
+	t.Run("ok - empty bitstring", func(t *testing.T) {
+		encodedList, err := compressMultibase(*newBitstring())
+		require.NoError(t, err)
+		expectedCS := toMap(t, BitstringStatusListCredentialSubject{
+			ID:            entry.StatusListCredential,
+			Type:          BitstringStatusListCredentialSubjectType,
+			StatusPurpose: StatusPurposeSuspension,
+			EncodedList:   encodedList,
+		})
+		s.Sign = nil // guarantees credential comes from db
+		defer func() { s.Sign = noopSign }()
+
+		cred, err := s.Credential(auditCtx, aliceDID, StatusPurposeSuspension, 1)
+
+		assert.NoError(t, err)
+		require.NotNil(t, cred)
+		assert.Equal(t, expectedCS, cred.CredentialSubject[0])
+		assert.True(t, cred.ContainsContext(BitstringStatusListContextURI))
+		assert.True(t, cred.IsType(bitstringStatusListCredentialTypeURI))
+	})
+	t.Run("ok - with suspension", func(t *testing.T) {
+		require.NoError(t, s.Suspend(context.Background(), ssi.URI{}, entry))
+		s.Sign = nil // guarantees credential comes from db
+		defer func() { s.Sign = noopSign }()
+
+		cred, err := s.Credential(auditCtx, aliceDID, StatusPurposeSuspension, 1)
+		require.NoError(t, err)
+
+		var credSubs []BitstringStatusListCredentialSubject
+		require.NoError(t, cred.UnmarshalCredentialSubject(&credSubs))
+		bs, err := expandMultibase(credSubs[0].EncodedList)
+		require.NoError(t, err)
+		set, _ := bs.bit(0)
+		assert.True(t, set)
+	})
+	t.Run("ok - refresh expired credential", func(t *testing.T) {
+		s.Sign = func(ctx context.Context, unsignedCredential vc.VerifiableCredential, kid string) (*vc.VerifiableCredential, error) {
+			info := audit.InfoFromContext(ctx)
+			require.NotNil(t, info)
+			assert.Equal(t, info.Actor, "_system_signing_expired_bitstringstatuslistcredential")
+			return noopSign(ctx, unsignedCredential, kid)
+		}
+		defer func() { s.Sign = noopSign }()
+		err = s.db.Model(new(credentialRecord)).Where("subject_id = ?", entry.StatusListCredential).
+			UpdateColumn("expires", time.Now().Add(minTimeUntilExpired-time.Second).Unix()).Error
+		require.NoError(t, err)
+
+		cred, err := s.Credential(auditCtx, aliceDID, StatusPurposeSuspension, 1)
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, cred)
+	})
+	t.Run("error - ErrNotFound", func(t *testing.T) {
+		cred, err := s.Credential(auditCtx, aliceDID, StatusPurposeRevocation, 1)
+		assert.ErrorIs(t, err, types.ErrNotFound)
+		assert.Nil(t, cred)
+	})
+}
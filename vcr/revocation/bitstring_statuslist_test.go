@@ -0,0 +1,103 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package revocation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBitstringStatusListEntry_Validate(t *testing.T) {
+	makeValidCSEntry := func() BitstringStatusListEntry {
+		return BitstringStatusListEntry{
+			ID:                   "https://example-com/credentials/status/3#94567",
+			Type:                 "BitstringStatusListEntry",
+			StatusPurpose:        "suspension",
+			StatusListIndex:      "94567",
+			StatusListCredential: "https://example-com/credentials/status/3",
+		}
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		assert.NoError(t, makeValidCSEntry().Validate())
+	})
+	t.Run("ok - multi-bit status", func(t *testing.T) {
+		entry := makeValidCSEntry()
+		entry.StatusPurpose = StatusPurposeMessage
+		entry.StatusSize = 2
+		entry.StatusMessage = []StatusMessage{
+			{Status: "0x0", Message: "pending_review"},
+			{Status: "0x1", Message: "accepted"},
+			{Status: "0x2", Message: "rejected"},
+			{Status: "0x3", Message: "undefined"},
+		}
+		assert.NoError(t, entry.Validate())
+	})
+	t.Run("error - id == statusListCredential", func(t *testing.T) {
+		entry := makeValidCSEntry()
+		entry.ID = entry.StatusListCredential
+		err := entry.Validate()
+		assert.EqualError(t, err, "BitstringStatusListEntry.id is the same as the BitstringStatusListEntry.statusListCredential")
+	})
+	t.Run("error - incorrect type", func(t *testing.T) {
+		entry := makeValidCSEntry()
+		entry.Type = StatusList2021EntryType
+		err := entry.Validate()
+		assert.EqualError(t, err, "BitstringStatusListEntry.type must be BitstringStatusListEntry")
+	})
+	t.Run("error - missing statusPurpose", func(t *testing.T) {
+		entry := makeValidCSEntry()
+		entry.StatusPurpose = ""
+		err := entry.Validate()
+		assert.EqualError(t, err, "BitstringStatusListEntry.statusPurpose is required")
+	})
+	t.Run("error - statusListIndex is negative", func(t *testing.T) {
+		entry := makeValidCSEntry()
+		entry.StatusListIndex = "-1"
+		err := entry.Validate()
+		assert.EqualError(t, err, "invalid BitstringStatusListEntry.statusListIndex")
+	})
+	t.Run("error - statusListCredential is not a valid URL", func(t *testing.T) {
+		entry := makeValidCSEntry()
+		entry.StatusListCredential = "not a URL"
+		err := entry.Validate()
+		assert.EqualError(t, err, "parse BitstringStatusListEntry.statusListCredential URL: parse \"not a URL\": invalid URI for request")
+	})
+	t.Run("error - statusSize too large", func(t *testing.T) {
+		entry := makeValidCSEntry()
+		entry.StatusSize = 9
+		err := entry.Validate()
+		assert.EqualError(t, err, "BitstringStatusListEntry.statusSize must be in range [1, 8]")
+	})
+	t.Run("error - missing statusMessage", func(t *testing.T) {
+		entry := makeValidCSEntry()
+		entry.StatusSize = 2
+		entry.StatusMessage = []StatusMessage{{Status: "0x0", Message: "pending_review"}}
+		err := entry.Validate()
+		assert.EqualError(t, err, "BitstringStatusListEntry.statusMessage must contain a message for every status value")
+	})
+	t.Run("error - invalid statusMessage.status", func(t *testing.T) {
+		entry := makeValidCSEntry()
+		entry.StatusSize = 1
+		entry.StatusMessage = []StatusMessage{{Status: "zero", Message: "active"}}
+		err := entry.Validate()
+		assert.EqualError(t, err, "invalid BitstringStatusListEntry.statusMessage.status: zero")
+	})
+}
@@ -0,0 +1,121 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package revocation
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/vcr/log"
+	"github.com/nuts-foundation/nuts-node/vcr/types"
+)
+
+// Verify returns a types.ErrRevoked or types.ErrSuspended when the credentialStatus contains a 'BitstringStatusListEntry'
+// with statusPurpose 'revocation' or 'suspension' that can be resolved and lists the credential.
+// Other credentialStatus type/statusPurpose are ignored. Verification may fail with other non-standardized errors.
+func (cs *BitstringStatusList) Verify(credentialToVerify vc.VerifiableCredential) error {
+	if credentialToVerify.CredentialStatus == nil {
+		return nil
+	}
+	statuses, err := credentialToVerify.CredentialStatuses()
+	if err != nil {
+		// cannot happen. already validated in defaultCredentialValidator{}
+		return err
+	}
+
+	suspended := false
+	for _, status := range statuses {
+		if status.Type != BitstringStatusListEntryType {
+			// other types are handled (and logged) by their own verifier
+			continue
+		}
+		var entry BitstringStatusListEntry // CredentialStatus of the credentialToVerify
+		if err = json.Unmarshal(status.Raw(), &entry); err != nil {
+			// cannot happen. already validated in credential.defaultCredentialValidator{}
+			return err
+		}
+		if entry.StatusPurpose != StatusPurposeRevocation && entry.StatusPurpose != StatusPurposeSuspension {
+			// other purposes, such as 'message', don't affect the validity of the credential
+			log.Logger().
+				WithField("credentialStatus.statusPurpose", entry.StatusPurpose).
+				WithField(core.LogFieldCredentialID, credentialToVerify.ID).
+				WithField(core.LogFieldCredentialType, credentialToVerify.Type).
+				Debug("Ignoring credentialStatus with purpose other than 'revocation' or 'suspension'")
+			continue
+		}
+
+		result, err := cs.Status(entry)
+		if err != nil {
+			return err
+		}
+		if result.Value == 0 {
+			continue
+		}
+		if result.Purpose == StatusPurposeRevocation {
+			return types.ErrRevoked
+		}
+		// revocation takes precedence, so check remaining statuses before reporting the suspension
+		suspended = true
+	}
+	if suspended {
+		return types.ErrSuspended
+	}
+	return nil
+}
+
+func (cs *BitstringStatusList) Status(entry BitstringStatusListEntry) (*Status, error) {
+	if err := entry.Validate(); err != nil {
+		return nil, err
+	}
+
+	// get BitstringStatusListCredential with same purpose
+	sList, err := cs.statusList(entry.StatusListCredential)
+	if err != nil {
+		return nil, fmt.Errorf("status list: %w", err)
+	}
+	if sList.StatusPurpose != entry.StatusPurpose {
+		return nil, fmt.Errorf("BitstringStatusListCredential.credentialSubject.statusPurpose='%s' does not match vc.credentialStatus.statusPurpose='%s'", sList.StatusPurpose, entry.StatusPurpose)
+	}
+
+	// read status
+	index, err := strconv.Atoi(entry.StatusListIndex)
+	if err != nil {
+		// can't happen, checked during validation of the entry
+		return nil, err
+	}
+	value, err := sList.Bitstring.bits(index, entry.size())
+	if err != nil {
+		return nil, err
+	}
+	result := &Status{
+		Purpose: entry.StatusPurpose,
+		Value:   value,
+	}
+	for _, message := range entry.StatusMessage {
+		// parse errors can't happen, checked during validation of the entry
+		if status, _ := strconv.ParseUint(message.Status, 0, 64); status == uint64(value) {
+			result.Message = message.Message
+			break
+		}
+	}
+	return result, nil
+}
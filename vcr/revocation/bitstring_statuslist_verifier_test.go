@@ -0,0 +1,222 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package revocation
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/vcr/test"
+	"github.com/nuts-foundation/nuts-node/vcr/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBitstringStatusList_Verify(t *testing.T) {
+	t.Run("ok - not suspended", func(t *testing.T) {
+		cs, entry, _ := bitstringTestSetup(t, false)
+		cred := test.ValidNutsOrganizationCredential(t)
+		cred.CredentialStatus = []any{entry}
+		assert.NoError(t, cs.Verify(cred))
+	})
+	t.Run("ok - no credentialStatus", func(t *testing.T) {
+		cs, _, _ := bitstringTestSetup(t, false)
+		cred := test.ValidNutsOrganizationCredential(t)
+		assert.NoError(t, cs.Verify(cred))
+	})
+	t.Run("ok - StatusList2021Entry is ignored", func(t *testing.T) {
+		cs, entry, _ := bitstringTestSetup(t, true)
+		entry.Type = StatusList2021EntryType
+		cred := test.ValidNutsOrganizationCredential(t)
+		cred.CredentialStatus = []any{entry}
+		assert.NoError(t, cs.Verify(cred))
+	})
+	t.Run("ok - statusPurpose 'message' is ignored", func(t *testing.T) {
+		cs, entry, _ := bitstringTestSetup(t, true)
+		entry.StatusPurpose = StatusPurposeMessage
+		cred := test.ValidNutsOrganizationCredential(t)
+		cred.CredentialStatus = []any{entry}
+		assert.NoError(t, cs.Verify(cred))
+	})
+	t.Run("suspended", func(t *testing.T) {
+		cs, entry, _ := bitstringTestSetup(t, true)
+		cred := test.ValidNutsOrganizationCredential(t)
+		cred.CredentialStatus = []any{entry}
+		assert.ErrorIs(t, cs.Verify(cred), types.ErrSuspended)
+	})
+	t.Run("managed - revoked takes precedence over suspended", func(t *testing.T) {
+		cs := newTestBitstringStatusList(t, aliceDID)
+		ctx := context.Background()
+		suspensionEntry, err := cs.Entry(ctx, aliceDID, StatusPurposeSuspension)
+		require.NoError(t, err)
+		revocationEntry, err := cs.Entry(ctx, aliceDID, StatusPurposeRevocation)
+		require.NoError(t, err)
+		require.NoError(t, cs.Suspend(ctx, ssi.URI{}, *suspensionEntry))
+		cred := test.ValidNutsOrganizationCredential(t)
+		cred.CredentialStatus = []any{suspensionEntry, revocationEntry}
+
+		assert.ErrorIs(t, cs.Verify(cred), types.ErrSuspended)
+
+		require.NoError(t, cs.Revoke(ctx, ssi.URI{}, *revocationEntry))
+
+		assert.ErrorIs(t, cs.Verify(cred), types.ErrRevoked)
+	})
+	t.Run("error - statusPurpose mismatch", func(t *testing.T) {
+		cs, entry, _ := bitstringTestSetup(t, true)
+		entry.StatusPurpose = StatusPurposeRevocation
+		cred := test.ValidNutsOrganizationCredential(t)
+		cred.CredentialStatus = []any{entry}
+
+		err := cs.Verify(cred)
+
+		assert.EqualError(t, err, "BitstringStatusListCredential.credentialSubject.statusPurpose='suspension' does not match vc.credentialStatus.statusPurpose='revocation'")
+	})
+	t.Run("error - credentialStatus.statusListIndex out of bounds", func(t *testing.T) {
+		cs, entry, _ := bitstringTestSetup(t, false)
+		entry.StatusListIndex = "500000" // max is ±130k
+		cred := test.ValidNutsOrganizationCredential(t)
+		cred.CredentialStatus = []any{entry}
+		assert.EqualError(t, cs.Verify(cred), "index not in status list")
+	})
+}
+
+func TestBitstringStatusList_Status(t *testing.T) {
+	messages := []StatusMessage{
+		{Status: "0x0", Message: "pending_review"},
+		{Status: "0x1", Message: "accepted"},
+		{Status: "0x2", Message: "rejected"},
+		{Status: "0x3", Message: "undefined"},
+	}
+	t.Run("ok - single bit", func(t *testing.T) {
+		cs, entry, _ := bitstringTestSetup(t, true)
+
+		status, err := cs.Status(entry)
+
+		require.NoError(t, err)
+		assert.Equal(t, Status{Purpose: StatusPurposeSuspension, Value: 1}, *status)
+	})
+	t.Run("ok - multi-bit status message", func(t *testing.T) {
+		// bits 0-1 are '01'
+		cs, entry, _ := bitstringTestSetup(t, false)
+		entry.StatusListIndex = "0"
+		entry.StatusSize = 2
+		entry.StatusMessage = messages
+
+		status, err := cs.Status(entry)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, status.Value)
+		assert.Equal(t, "accepted", status.Message)
+	})
+	t.Run("ok - multi-bit status not set", func(t *testing.T) {
+		cs, entry, _ := bitstringTestSetup(t, false)
+		entry.StatusListIndex = "1"
+		entry.StatusSize = 2
+		entry.StatusMessage = messages
+
+		status, err := cs.Status(entry)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, status.Value)
+		assert.Equal(t, "pending_review", status.Message)
+	})
+	t.Run("error - invalid entry", func(t *testing.T) {
+		cs, entry, _ := bitstringTestSetup(t, false)
+		entry.StatusSize = 2
+
+		status, err := cs.Status(entry)
+
+		assert.EqualError(t, err, "BitstringStatusListEntry.statusMessage must contain a message for every status value")
+		assert.Nil(t, status)
+	})
+}
+
+func TestBitstringStatusList_validate(t *testing.T) {
+	cs := BitstringStatusList{statusListCore{format: bitstringStatusListFormat}}
+	t.Run("ok", func(t *testing.T) {
+		cred := test.ValidBitstringStatusListCredential(t)
+		credSubject, err := cs.validate(cred)
+		assert.NoError(t, err)
+		assert.Equal(t, StatusPurposeSuspension, credSubject.StatusPurpose)
+	})
+	t.Run("error - missing context", func(t *testing.T) {
+		cred := test.ValidBitstringStatusListCredential(t)
+		cred.Context = []ssi.URI{vc.VCContextV1URI()}
+		_, err := cs.validate(cred)
+		assert.EqualError(t, err, "context 'https://www.w3.org/ns/credentials/status/v1' is required")
+	})
+	t.Run("error - StatusList2021Credential type", func(t *testing.T) {
+		cred := test.ValidBitstringStatusListCredential(t)
+		cred.Type = []ssi.URI{vc.VerifiableCredentialTypeV1URI(), statusList2021CredentialTypeURI}
+		_, err := cs.validate(cred)
+		assert.EqualError(t, err, "type 'BitstringStatusListCredential' is required")
+	})
+	t.Run("error - credentialSubject.type", func(t *testing.T) {
+		cred := test.ValidBitstringStatusListCredential(t)
+		cred.CredentialSubject[0]["type"] = StatusList2021CredentialSubjectType
+		_, err := cs.validate(cred)
+		assert.EqualError(t, err, "credentialSubject.type 'BitstringStatusList' is required")
+	})
+	t.Run("error - credentialStatus not supported", func(t *testing.T) {
+		cred := test.ValidBitstringStatusListCredential(t)
+		cred.CredentialStatus = []any{BitstringStatusListEntry{}}
+		_, err := cs.validate(cred)
+		assert.EqualError(t, err, "BitstringStatusListCredential with a CredentialStatus is not supported")
+	})
+}
+
+// bitstringTestSetup returns
+//   - a BitstringStatusList that does NOT Verify signatures, and a client configured for the test server
+//   - a 'suspension' BitstringStatusListEntry pointing to the test server, optionally matching the suspended bit in the list
+//   - the test server
+func bitstringTestSetup(t testing.TB, entryIsSuspended bool) (*BitstringStatusList, BitstringStatusListEntry, *httptest.Server) {
+	ts := httptest.NewTLSServer(nil)
+	t.Cleanup(func() { ts.Close() })
+
+	statusListCredential := test.ValidBitstringStatusListCredential(t) // has bit 1 set
+	statusListCredential.CredentialSubject[0]["id"] = ts.URL           // point to the test server
+	credBytes, err := json.Marshal(statusListCredential)
+	require.NoError(t, err)
+	ts.Config.Handler = http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if _, err = writer.Write(credBytes); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	cs := newTestBitstringStatusList(t, aliceDID, bobDID)
+	cs.client = ts.Client()
+
+	entry := BitstringStatusListEntry{
+		ID:                   ts.URL + "#76248",
+		Type:                 BitstringStatusListEntryType,
+		StatusPurpose:        StatusPurposeSuspension,
+		StatusListIndex:      "76248",
+		StatusListCredential: ts.URL,
+	}
+	if entryIsSuspended {
+		entry.ID = ts.URL + "#1"
+		entry.StatusListIndex = "1"
+	}
+	return cs, entry, ts
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"math/rand"
+	"strings"
 	"testing"
 )
 
@@ -190,3 +191,91 @@ func Test_CompressExpand(t *testing.T) {
 		assert.Equal(t, padExpanded, nopadExpanded)
 	})
 }
+
+func TestBitstring_Bits(t *testing.T) {
+	bs := bitstring{33, 255} // 00100001 11111111
+	type test struct {
+		index int
+		size  int
+		value int
+		err   error
+	}
+
+	tt := []test{
+		{-1, 1, 0, ErrIndexNotInBitstring},
+		{0, 0, 0, ErrIndexNotInBitstring},
+		{2, 1, 1, nil},
+		{0, 2, 0, nil},   // 00
+		{1, 2, 2, nil},   // 10
+		{3, 2, 1, nil},   // 01
+		{4, 2, 3, nil},   // 11
+		{0, 4, 2, nil},   // 0010
+		{1, 4, 1, nil},   // 0001
+		{1, 8, 255, nil}, // 11111111
+		{8, 2, 0, ErrIndexNotInBitstring},
+		{2, 8, 0, ErrIndexNotInBitstring},
+	}
+	for _, tc := range tt {
+		v, err := bs.bits(tc.index, tc.size)
+		assert.ErrorIs(t, err, tc.err)
+		assert.Equal(t, tc.value, v, "index=%d size=%d", tc.index, tc.size)
+	}
+}
+
+func TestBitstring_SetBits(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		bs := *newBitstring()
+
+		require.NoError(t, bs.setBits(3, 2, 2))
+
+		value, err := bs.bits(3, 2)
+		require.NoError(t, err)
+		assert.Equal(t, 2, value)
+		set, _ := bs.bit(6)
+		assert.True(t, set)
+		set, _ = bs.bit(7)
+		assert.False(t, set)
+	})
+	t.Run("ok - clear", func(t *testing.T) {
+		bs := *newBitstring()
+		require.NoError(t, bs.setBits(0, 8, 255))
+
+		require.NoError(t, bs.setBits(0, 8, 0))
+
+		assert.Equal(t, *newBitstring(), bs)
+	})
+	t.Run("error - out of bounds", func(t *testing.T) {
+		bs := *newBitstring()
+
+		assert.ErrorIs(t, bs.setBits(maxBitstringIndex, 2, 1), ErrIndexNotInBitstring)
+		assert.ErrorIs(t, bs.setBits(-1, 1, 1), ErrIndexNotInBitstring)
+	})
+	t.Run("error - value too large", func(t *testing.T) {
+		bs := *newBitstring()
+
+		assert.EqualError(t, bs.setBits(0, 2, 4), "status value 4 does not fit in 2 bit(s)")
+	})
+}
+
+func Test_CompressExpandMultibase(t *testing.T) {
+	t.Run("ok - input >> compress >> expand == input", func(t *testing.T) {
+		bs := *newBitstring()
+		require.NoError(t, bs.setBit(42, true))
+
+		compressed, err := compressMultibase(bs)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(compressed, "u"))
+
+		expanded, err := expandMultibase(compressed)
+		require.NoError(t, err)
+		assert.Equal(t, bs, expanded)
+	})
+	t.Run("ok - multibase prefix is optional", func(t *testing.T) {
+		compressed, err := compress(*newBitstring())
+		require.NoError(t, err)
+
+		expanded, err := expandMultibase(compressed)
+		require.NoError(t, err)
+		assert.Equal(t, *newBitstring(), expanded)
+	})
+}
@@ -0,0 +1,657 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package revocation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/audit"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/storage"
+	"github.com/nuts-foundation/nuts-node/vcr/log"
+	"github.com/nuts-foundation/nuts-node/vdr/resolver"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// statusListFormat contains the differences between the StatusList2021 and BitstringStatusList status list credentials.
+type statusListFormat struct {
+	// credentialType is the type of the status list credential.
+	credentialType string
+	// subjectType is the credentialSubject.type of the status list credential.
+	subjectType string
+	// context is the JSON-LD context defining the status list credential.
+	context ssi.URI
+	// idRequired indicates the status list credential must have an 'id'.
+	idRequired bool
+	// issuerTable is the table tracking the status list credentials issued by this node.
+	issuerTable string
+	// compress encodes a bitstring as credentialSubject.encodedList.
+	compress func(bitstring []byte) (string, error)
+	// expand decodes the bitstring in credentialSubject.encodedList.
+	expand func(encodedList string) (bitstring, error)
+}
+
+var statusList2021Format = statusListFormat{
+	credentialType: StatusList2021CredentialType,
+	subjectType:    StatusList2021CredentialSubjectType,
+	context:        StatusList2021ContextURI,
+	idRequired:     true,
+	issuerTable:    credentialIssuerRecord{}.TableName(),
+	compress:       compress,
+	expand:         expand,
+}
+
+var bitstringStatusListFormat = statusListFormat{
+	credentialType: BitstringStatusListCredentialType,
+	subjectType:    BitstringStatusListCredentialSubjectType,
+	context:        BitstringStatusListContextURI,
+	issuerTable:    bitstringIssuerRecord{}.TableName(),
+	compress:       compressMultibase,
+	expand:         expandMultibase,
+}
+
+// statusListCore contains the logic shared by StatusList2021 and BitstringStatusList:
+// issuing the status list credentials of this node, and downloading and validating the status list credentials of other issuers.
+type statusListCore struct {
+	client          core.HTTPRequestDoer
+	db              *gorm.DB
+	baseURL         string
+	VerifySignature VerifySignFn // injected by verifier
+	Sign            SignFn       // injected by issuer, context must contain an audit log
+	ResolveKey      ResolveKeyFn // injected by issuer
+	format          statusListFormat
+}
+
+// statusListPage contains the columns shared by the tables tracking the status list credentials issued by this node.
+// Columns a table doesn't have are left empty.
+type statusListPage struct {
+	// SubjectID is the VC.credentialSubject.ID of the status list credential, and the URL it can be downloaded from.
+	SubjectID string
+	// Issuer of the status list credential.
+	Issuer string
+	// StatusPurpose of all entries on this page.
+	StatusPurpose string
+	// StatusSize is the number of bits per entry on this page. Defaults to 1 if empty.
+	StatusSize int
+	// Page number corresponding to this SubjectID.
+	Page int
+	// LastIssuedIndex on this page.
+	LastIssuedIndex int
+}
+
+// size returns the StatusSize, or its default value if empty.
+func (p statusListPage) size() int {
+	if p.StatusSize == 0 {
+		return 1
+	}
+	return p.StatusSize
+}
+
+// maxIndex returns the highest statusListIndex that fits in a bitstring for entries of this page.
+func (p statusListPage) maxIndex() int {
+	return (maxBitstringIndex+1)/p.size() - 1
+}
+
+// issuedList is a status list credential issued by this node, including the status of all its entries.
+type issuedList struct {
+	statusListPage
+	// statuses maps the statusListIndex of the entries that have a status set to their status value.
+	statuses map[int]int
+}
+
+// loadIssuedListFn loads the issuedList of the status list credential at subjectID from the given transaction.
+type loadIssuedListFn func(tx *gorm.DB, subjectID string) (*issuedList, error)
+
+func (cs *statusListCore) loadCredential(subjectID string) (*credentialRecord, error) {
+	cr := new(credentialRecord)
+	err := cs.db.First(cr, "subject_id = ?", subjectID).Error
+	if err != nil {
+		return nil, err
+	}
+	return cr, nil
+}
+
+// isManaged returns true if the status list credential is issued by this node.
+// returns false on db errors, or if the status list credential does not exist.
+func (cs *statusListCore) isManaged(subjectID string) bool {
+	var count int64
+	cs.db.Table(cs.format.issuerTable).
+		Where("subject_id = ?", subjectID).
+		Count(&count)
+	return count > 0
+}
+
+// credential returns the status list credential at subjectID, if it is issued by this node.
+// It returns the stored credential if it is still valid for long enough, or re-issues it using the issuedList returned by load.
+func (cs *statusListCore) credential(ctx context.Context, issuerDID did.DID, subjectID string, load loadIssuedListFn) (*vc.VerifiableCredential, error) {
+	// only return the status list credential if it already exists, and we are the issuer
+	if !cs.isManaged(subjectID) {
+		return nil, errNotFound
+	}
+
+	// return stored status list credential if valid for long enough
+	credRecord, err := cs.loadCredential(subjectID)
+	if err == nil && time.Now().Add(minTimeUntilExpired).Before(time.Unix(*credRecord.Expires, 0)) {
+		cred, err := vc.ParseVerifiableCredential(credRecord.Raw)
+		if err == nil {
+			return cred, nil
+		}
+		// log broken status list credential in DB and try to issue a new one
+		log.Logger().WithError(err).WithField(cs.format.credentialType, subjectID).Errorf("Failed to parse managed %s in database", cs.format.credentialType)
+	}
+
+	// Rewrite audit context. This is a system action and should not be logged against an external party.
+	info := audit.InfoFromContext(ctx)
+	if info != nil {
+		module, operation, ok := strings.Cut(info.Operation, ".")
+		if ok {
+			ctx = audit.Context(ctx, "_system_signing_expired_"+strings.ToLower(cs.format.credentialType), module, operation)
+		}
+	}
+
+	// resolve signing key outside of transaction
+	kid, _, err := cs.ResolveKey(issuerDID, nil, resolver.AssertionMethod)
+	if err != nil {
+		// should never happen; credential confirmed to issued by this node
+		return nil, err
+	}
+
+	// issue a new status list credential if we can't load the existing, or it's about to expire
+	var cred *vc.VerifiableCredential // is nil, so if this panics outside this method the var name is probably shadowed in the db.Transaction.
+	err = cs.db.Transaction(func(tx *gorm.DB) error {
+		// lock credentialRecord row for subjectID since it will be updated.
+		err = lockCredentialRecord(tx, subjectID)
+		if err != nil {
+			return err
+		}
+
+		list, err := load(tx, subjectID)
+		if err != nil {
+			// gorm.ErrRecordNotFound can't happen, isManaged() confirmed it exists
+			return err
+		}
+		transactionContext := context.WithValue(ctx, storage.TransactionKey{}, tx)
+		cred, credRecord, err = cs.sign(transactionContext, *list, kid)
+		if err != nil {
+			return err
+		}
+
+		err = tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(credRecord).Error
+		if err != nil {
+			// log error, but don't fail.
+			log.Logger().
+				WithError(err).
+				WithField("Status list URL", subjectID).
+				Errorf("failed to store issued %s", cs.format.credentialType)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return cred, nil
+}
+
+// sign creates a signed status list credential and a credentialRecord from the issuedList.
+// The caller is responsible for writing the credentialRecord to the db.
+func (cs *statusListCore) sign(ctx context.Context, list issuedList, kid string) (*vc.VerifiableCredential, *credentialRecord, error) {
+	issuerDID, err := did.ParseDID(list.Issuer)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// bit string
+	expanded := newBitstring()
+	for index, value := range list.statuses {
+		if err = expanded.setBits(index, list.size(), value); err != nil {
+			// can't happen
+			return nil, nil, err
+		}
+	}
+	encodedList, err := cs.format.compress(*expanded)
+	if err != nil {
+		// can't happen
+		return nil, nil, err
+	}
+
+	// credential subject
+	credSubject := StatusList2021CredentialSubject{
+		ID:            list.SubjectID,
+		Type:          cs.format.subjectType,
+		StatusPurpose: list.StatusPurpose,
+		EncodedList:   encodedList,
+	}
+	// create and sign a new status list credential
+	statusListCredential, err := cs.buildAndSignVC(ctx, *issuerDID, credSubject, kid)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// create new credentialRecord
+	expires := statusListCredential.ExpirationDate.Unix()
+	credRecord := &credentialRecord{
+		SubjectID:     credSubject.ID,
+		StatusPurpose: credSubject.StatusPurpose,
+		Bitstring:     *expanded,
+		Expires:       &expires,
+		Raw:           statusListCredential.Raw(),
+	}
+	return statusListCredential, credRecord, nil
+}
+
+// buildAndSignVC intends to do the same as vcr.issuer.buildAndSignVC
+func (cs *statusListCore) buildAndSignVC(ctx context.Context, issuerDID did.DID, credSubject StatusList2021CredentialSubject, kid string) (*vc.VerifiableCredential, error) {
+	iss := time.Now()
+	exp := iss.Add(statusListValidity)
+	credentialID := ssi.MustParseURI(fmt.Sprintf("%s#%s", issuerDID.String(), uuid.New().String()))
+	template := vc.VerifiableCredential{
+		Context: []ssi.URI{
+			vc.VCContextV1URI(),
+			cs.format.context,
+		},
+		Type: []ssi.URI{
+			vc.VerifiableCredentialTypeV1URI(),
+			ssi.MustParseURI(cs.format.credentialType),
+		},
+		ID:                &credentialID,
+		CredentialSubject: []map[string]any{credSubject.toMap()},
+		Issuer:            issuerDID.URI(),
+		IssuanceDate:      iss,
+		ExpirationDate:    &exp,
+	}
+
+	// sign the status list credential
+	return cs.Sign(ctx, template, kid)
+}
+
+// nextIndex reserves the statusListIndex for a new entry on the last page of the issuer with the StatusPurpose and StatusSize of the template.
+// The query must select all pages that share page numbering with the template; they are locked while the index is reserved.
+// If there is no page yet, or the last page is full, a new page is created at pageURL: newRecord returns the record that is stored for it,
+// after which an empty status list credential is issued.
+func (cs *statusListCore) nextIndex(ctx context.Context, template statusListPage, pageURL func(page int) string, newRecord func(page statusListPage) any, query string, args ...any) (*statusListPage, error) {
+	issuerDID, err := did.ParseDID(template.Issuer)
+	if err != nil {
+		return nil, err
+	}
+	// resolve signing key outside of transaction
+	kid, _, err := cs.ResolveKey(*issuerDID, nil, resolver.AssertionMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	var result statusListPage
+	for {
+		err := cs.db.Transaction(func(tx *gorm.DB) error {
+			// Find the issuer's pages and lock them.
+			// Microsoft SQL server does not support the locking clause, so we have to use a raw query instead.
+			// See https://github.com/nuts-foundation/nuts-node/issues/3393
+			var pages []statusListPage
+			var err error
+			if tx.Dialector.Name() == "sqlserver" {
+				err = tx.Raw(fmt.Sprintf("SELECT * FROM %s WITH (UPDLOCK, ROWLOCK) WHERE %s", cs.format.issuerTable, query), args...).
+					Scan(&pages).
+					Error
+			} else {
+				err = tx.Table(cs.format.issuerTable).
+					Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+					Where(query, args...).
+					Find(&pages).
+					Error
+			}
+			if err != nil {
+				return err
+			}
+
+			// find the last page with entries of the same size, and the last page number in use
+			var current *statusListPage
+			lastPage := 0
+			for i, page := range pages {
+				lastPage = max(lastPage, page.Page)
+				if page.size() == template.size() && (current == nil || page.Page > current.Page) {
+					current = &pages[i]
+				}
+			}
+
+			// next index; update last_issued_index and release lock
+			if current != nil && current.LastIssuedIndex < current.maxIndex() {
+				result = *current
+				result.LastIssuedIndex++
+				return tx.Table(cs.format.issuerTable).
+					Where("subject_id = ?", result.SubjectID).
+					UpdateColumn("last_issued_index", result.LastIssuedIndex).Error
+			}
+
+			// create new page (status list credential) if there is none or the current is full, and release lock
+			// write actions here are not protected by the SELECT FOR UPDATE clause, so can fail with gorm.ErrDuplicatedKey
+			result = template
+			result.Page = lastPage + 1
+			result.LastIssuedIndex = 0
+			result.SubjectID = pageURL(result.Page)
+			if err = tx.Create(newRecord(result)).Error; err != nil {
+				return err
+			}
+
+			// store transaction context
+			transactionContext := context.WithValue(ctx, storage.TransactionKey{}, tx)
+			_, credRecord, err := cs.sign(transactionContext, issuedList{statusListPage: result}, kid)
+			if err != nil {
+				return err
+			}
+			return tx.Create(credRecord).Error
+		})
+		if err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				// gorm.ErrDuplicatedKey means that a race condition occurred while trying to add a new credentialRecord
+				// or page. We just have to try again.
+				continue
+			}
+			return nil, err
+		}
+		return &result, nil
+	}
+}
+
+// page returns the statusListPage of the status list credential at subjectID.
+// Returns types.ErrNotFound when the status list credential is not issued by this node.
+func (cs *statusListCore) page(subjectID string) (*statusListPage, error) {
+	var result statusListPage
+	err := cs.db.Table(cs.format.issuerTable).Where("subject_id = ?", subjectID).Take(&result).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errNotFound
+		}
+		return nil, err
+	}
+	return &result, nil
+}
+
+// updateStatus changes the status of the entry at statusListIndex on the page, and re-issues the status list credential.
+// Within the transaction, change is called to store the new status, after which the updated issuedList is loaded using load.
+func (cs *statusListCore) updateStatus(ctx context.Context, page statusListPage, statusListIndex int, change func(tx *gorm.DB) error, load loadIssuedListFn) error {
+	// resolve signing key outside of transaction
+	issuerDID, err := did.ParseDID(page.Issuer)
+	if err != nil {
+		// can't happen; own DB
+		return err
+	}
+	kid, _, err := cs.ResolveKey(*issuerDID, nil, resolver.AssertionMethod)
+	if err != nil {
+		// can't happen; credential confirmed to issued by this node
+		return err
+	}
+	subjectID := page.SubjectID
+
+	return cs.db.Transaction(func(tx *gorm.DB) error {
+		// lock relevant credentialRecord. It was created when the first entry was issued for this status list credential.
+		err = lockCredentialRecord(tx, subjectID)
+		if err != nil {
+			return err
+		}
+
+		if err = change(tx); err != nil {
+			return err
+		}
+
+		// load all statuses
+		list, err := load(tx, subjectID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				// can't happen, already checked
+				return errNotFound
+			}
+			return err
+		}
+
+		// validate StatusListIndex; triggers a rollback after the fact, but this should never happen.
+		if statusListIndex < 0 || statusListIndex > list.LastIssuedIndex {
+			return ErrIndexNotInBitstring
+		}
+
+		// re-issue the status list credential.
+		transactionContext := context.WithValue(ctx, storage.TransactionKey{}, tx)
+		_, credRecord, err := cs.sign(transactionContext, *list, kid)
+		if err != nil {
+			return err
+		}
+		return tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(credRecord).Error
+	})
+}
+
+func (cs *statusListCore) statusList(statusListCredential string) (*credentialRecord, error) {
+	cr, err := cs.loadCredential(statusListCredential)
+	if err != nil {
+		// assume any error means we don't have the credential, so try fetching remote
+		return cs.update(statusListCredential)
+	}
+
+	// managed status list credentials are always up-to-date, does not matter if it is expired
+	if cs.isManaged(statusListCredential) {
+		return cr, nil
+	}
+
+	// renew expired credentials, or credentials older than maxAgeExternal since a suspension may have been lifted.
+	if (cr.Expires != nil && time.Unix(*cr.Expires, 0).Before(time.Now())) || // expired
+		time.Unix(cr.CreatedAt, 0).Add(maxAgeExternal).Before(time.Now()) { // older than 15 min
+		crUpdated, err := cs.update(statusListCredential)
+		if err == nil {
+			return crUpdated, nil
+		}
+		// use known status list credential if we can't fetch a new one, even if it is older/expired
+		if cr.Expires != nil && time.Unix(*cr.Expires, 0).Before(time.Now()) {
+			// log warning if using expired status list credential
+			log.Logger().WithError(err).WithField(core.LogFieldCredentialSubject, statusListCredential).
+				Infof("Validating credentialStatus using expired %s", cs.format.credentialType)
+		}
+	}
+
+	// return credentialRecord, which could be outdated but is the best information available.
+	return cr, nil
+}
+
+// update status list credential in db by downloading remote status list credential. Storage failures are logged, but do not return an error.
+func (cs *statusListCore) update(statusListCredential string) (*credentialRecord, error) {
+	// TODO: use caching headers for unchanged status list credentials
+	// download and verify
+	cred, err := cs.download(statusListCredential)
+	if err != nil {
+		return nil, err
+	}
+	credSubject, err := cs.verify(*cred)
+	if err != nil {
+		return nil, err
+	}
+	if statusListCredential != credSubject.ID {
+		return nil, fmt.Errorf("status list: wrong credential: expected '%s', got '%s'", statusListCredential, credSubject.ID)
+	}
+
+	// make bit string
+	expanded, err := cs.format.expand(credSubject.EncodedList)
+	if err != nil {
+		// cant happen, already checked in verify
+		return nil, err
+	}
+
+	var expiresPtr *int64
+	if cred.ExpirationDate != nil && !cred.ExpirationDate.IsZero() {
+		expires := cred.ExpirationDate.Unix()
+		expiresPtr = &expires
+	}
+
+	sl := credentialRecord{
+		SubjectID:     statusListCredential,
+		StatusPurpose: credSubject.StatusPurpose,
+		Bitstring:     expanded,
+		//Created:              time.Now(), // set by gorm when stored
+		Expires: expiresPtr,
+		Raw:     cred.Raw(),
+	}
+
+	// store status list credential
+	err = cs.db.Clauses(clause.OnConflict{UpdateAll: true}).Create(&sl).Error
+	if err != nil {
+		// log if storage fails, but still return the credential
+		log.Logger().WithError(err).Infof("Failed to store %s", cs.format.credentialType)
+	}
+	return &sl, nil
+}
+
+// download the status list credential found at the statusListCredential URL of a credentialStatus entry
+func (cs *statusListCore) download(statusListCredential string) (*vc.VerifiableCredential, error) {
+	var cred vc.VerifiableCredential // VC containing CredentialStatus of the credentialToVerify
+	req, err := http.NewRequest(http.MethodGet, statusListCredential, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := cs.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err = res.Body.Close(); err != nil {
+			// log, don't fail
+			log.Logger().WithError(err).WithField(cs.format.credentialType+" url", statusListCredential).
+				Debug("Failed to close response body")
+		}
+	}()
+	body, err := io.ReadAll(res.Body)
+	if res.StatusCode > 299 || err != nil {
+		return nil, errors.Join(fmt.Errorf("fetching %s from '%s' failed", cs.format.credentialType, statusListCredential), err)
+	}
+	if err = json.Unmarshal(body, &cred); err != nil {
+		return nil, err
+	}
+	return &cred, nil
+}
+
+// verify returns the credentialSubject of the status list credential,
+// or an error if the signature is invalid or the status list credential does not meet the spec.
+func (cs *statusListCore) verify(cred vc.VerifiableCredential) (*StatusList2021CredentialSubject, error) {
+	// confirm contents match spec
+	credSubj, err := cs.validate(cred)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = cs.format.expand(credSubj.EncodedList); err != nil {
+		return nil, fmt.Errorf("credentialSubject.encodedList is invalid: %w", err)
+	}
+
+	// Verify signature
+	if err = cs.VerifySignature(cred, nil); err != nil {
+		return nil, err
+	}
+
+	return credSubj, nil
+}
+
+// validate returns an error when the status list credential doesn't meet the spec.
+func (cs *statusListCore) validate(cred vc.VerifiableCredential) (*StatusList2021CredentialSubject, error) {
+	// TODO: replace with json schema validator?
+	{ // Credential checks
+		// context
+		if !cred.ContainsContext(vc.VCContextV1URI()) {
+			return nil, errors.New("default context is required")
+		}
+		if !cred.ContainsContext(cs.format.context) {
+			return nil, fmt.Errorf("context '%s' is required", cs.format.context)
+		}
+
+		// type
+		if !cred.IsType(vc.VerifiableCredentialTypeV1URI()) { // same type for vc v2 spec
+			return nil, errors.New("type 'VerifiableCredential' is required")
+		}
+		if !cred.IsType(ssi.MustParseURI(cs.format.credentialType)) {
+			return nil, fmt.Errorf("type '%s' is required", cs.format.credentialType)
+		}
+		if len(cred.Type) > 2 {
+			return nil, fmt.Errorf("%s contains other types", cs.format.credentialType)
+		}
+
+		// id
+		if cs.format.idRequired && cred.ID == nil {
+			return nil, errors.New("'ID' is required")
+		}
+
+		if cred.IssuanceDate.IsZero() {
+			return nil, errors.New("issuanceDate is required")
+		}
+
+		if cred.Format() == vc.JSONLDCredentialProofFormat && cred.Proof == nil {
+			return nil, errors.New("'proof' is required for JSON-LD credentials")
+		}
+
+		// prevent an infinite loops in credentialStatus resolution; note that this is not prohibited by the spec
+		if cred.CredentialStatus != nil {
+			return nil, fmt.Errorf("%s with a CredentialStatus is not supported", cs.format.credentialType)
+		}
+	}
+
+	var credentialSubject StatusList2021CredentialSubject
+	{ // credentialSubject checks
+		var target []StatusList2021CredentialSubject
+		err := cred.UnmarshalCredentialSubject(&target)
+		if err != nil {
+			return nil, err
+		}
+		// The spec is not clear if there could be multiple CredentialSubjects. This could allow 'revocation' and 'suspension' to be defined in a single credential.
+		// However, it is not defined how to select the correct list (StatusPurpose) when validating credentials that are using this status list credential.
+		if len(target) != 1 {
+			return nil, errors.New("single credentialSubject expected")
+		}
+		credentialSubject = target[0]
+
+		if credentialSubject.Type != cs.format.subjectType {
+			return nil, fmt.Errorf("credentialSubject.type '%s' is required", cs.format.subjectType)
+		}
+		if credentialSubject.StatusPurpose == "" {
+			return nil, errors.New("credentialSubject.statusPurpose is required")
+		}
+		if credentialSubject.EncodedList == "" {
+			return nil, errors.New("credentialSubject.encodedList is required")
+		}
+	}
+
+	return &credentialSubject, nil
+}
+
+func lockCredentialRecord(tx *gorm.DB, statusListCredentialURL string) error {
+	// Microsoft SQL server does not support the locking clause, so we have to use a raw query instead.
+	// See https://github.com/nuts-foundation/nuts-node/issues/3393
+	if tx.Dialector.Name() == "sqlserver" {
+		return tx.Raw("SELECT * FROM status_list_credential WITH (UPDLOCK, ROWLOCK) WHERE subject_id = ?", statusListCredentialURL).
+			Scan(new(credentialRecord)).
+			Error
+	}
+	return tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Find(new(credentialRecord), "subject_id = ?", statusListCredentialURL).
+		Error
+}
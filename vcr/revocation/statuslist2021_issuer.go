@@ -23,23 +23,14 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
-	"slices"
 	"strconv"
-	"strings"
 	"time"
 
-	"github.com/nuts-foundation/nuts-node/audit"
-	"github.com/nuts-foundation/nuts-node/storage"
-	"github.com/nuts-foundation/nuts-node/vcr/types"
-	"github.com/nuts-foundation/nuts-node/vdr/resolver"
-
-	"github.com/google/uuid"
 	ssi "github.com/nuts-foundation/go-did"
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/go-did/vc"
-	"github.com/nuts-foundation/nuts-node/vcr/log"
+	"github.com/nuts-foundation/nuts-node/vcr/types"
 	"gorm.io/gorm"
-	"gorm.io/gorm/clause"
 )
 
 // statusListValidity is default validity of a StatusList2021Credential
@@ -108,170 +99,31 @@ type revocationRecord struct {
 	RevokedAt int64 `gorm:"autoCreateTime;column:created_at"`
 }
 
-func (cs *StatusList2021) loadCredential(subjectID string) (*credentialRecord, error) {
-	cr := new(credentialRecord)
-	err := cs.db.First(cr, "subject_id = ?", subjectID).Error
-	if err != nil {
-		return nil, err
-	}
-	return cr, nil
-}
-
-// isManaged returns true if the StatusList2021Credential is issued by this node.
-// returns false on db errors, or if the StatusList2021Credential does not exist.
-func (cs *StatusList2021) isManaged(subjectID string) bool {
-	var count int
-	cs.db.Model(new(credentialIssuerRecord)).
-		Select("count(*)").
-		Group("subject_id").
-		Where("subject_id = ?", subjectID).
-		Find(&count)
-	return count > 0
-}
-
 func (cs *StatusList2021) Credential(ctx context.Context, issuerDID did.DID, page int) (*vc.VerifiableCredential, error) {
-	statusListCredentialURL := cs.statusListURL(issuerDID, page)
-
-	// only return StatusList2021Credential if it already exists, and we are the issuer
-	if !cs.isManaged(statusListCredentialURL) {
-		return nil, errNotFound
-	}
-
-	// return stored StatusList2021Credential if valid for long enough
-	credRecord, err := cs.loadCredential(statusListCredentialURL)
-	if err == nil && time.Now().Add(minTimeUntilExpired).Before(time.Unix(*credRecord.Expires, 0)) {
-		cred, err := vc.ParseVerifiableCredential(credRecord.Raw)
-		if err == nil {
-			return cred, nil
-		}
-		// log broken StatusList2021Credential in DB and try to issue a new one
-		log.Logger().WithError(err).WithField("StatusList2021Credential", statusListCredentialURL).Error("Failed to parse managed StatusList2021Credential in database")
-	}
-
-	// Rewrite audit context. This is a system action and should not be logged against an external party.
-	info := audit.InfoFromContext(ctx)
-	if info != nil {
-		module, operation, ok := strings.Cut(info.Operation, ".")
-		if ok {
-			ctx = audit.Context(ctx, "_system_signing_expired_statuslist2021credential", module, operation)
-		}
-	}
-
-	// resolve signing key outside of transaction
-	kid, _, err := cs.ResolveKey(issuerDID, nil, resolver.AssertionMethod)
-	if err != nil {
-		// should never happen; credential confirmed to issued by this node
-		return nil, err
-	}
-
-	// issue a new StatusList2021Credential if we can't load the existing, or it's about to expire
-	var cred *vc.VerifiableCredential // is nil, so if this panics outside this method the var name is probably shadowed in the db.Transaction.
-	err = cs.db.Transaction(func(tx *gorm.DB) error {
-		// lock credentialRecord row for statusListCredentialURL since it will be updated.
-		err = lockCredentialRecord(tx, statusListCredentialURL)
-		if err != nil {
-			return err
-		}
-
-		issuerRecord := new(credentialIssuerRecord)
-		err = tx.Preload("Revocations").First(issuerRecord, "subject_id = ?", statusListCredentialURL).Error
-		if err != nil {
-			// gorm.ErrRecordNotFound can't happen, isManaged() confirmed it exists
-			return err
-		}
-		transactionContext := context.WithValue(ctx, storage.TransactionKey{}, tx)
-		cred, credRecord, err = cs.updateCredential(transactionContext, issuerRecord, kid)
-		if err != nil {
-			return err
-		}
+	return cs.credential(ctx, issuerDID, cs.statusListURL(issuerDID, page), cs.loadIssuedList)
+}
 
-		err = tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(credRecord).Error
-		if err != nil {
-			// log error, but don't fail.
-			log.Logger().
-				WithError(err).
-				WithField("Status list URL", statusListCredentialURL).
-				Error("failed to store issued StatusList2021Credential")
-		}
-		return nil
-	})
+// loadIssuedList loads the credentialIssuerRecord and all its revocations.
+func (cs *StatusList2021) loadIssuedList(tx *gorm.DB, subjectID string) (*issuedList, error) {
+	issuerRecord := new(credentialIssuerRecord)
+	err := tx.Preload("Revocations").First(issuerRecord, "subject_id = ?", subjectID).Error
 	if err != nil {
 		return nil, err
 	}
-
-	return cred, nil
-}
-
-// updateCredential creates a signed StatusList2021Credential and a credentialRecord from the credentialIssuerRecord.
-// All revocations must be present in the issuerRecord. The caller is responsible for writing the credentialRecord to the db.
-func (cs *StatusList2021) updateCredential(ctx context.Context, issuerRecord *credentialIssuerRecord, kid string) (*vc.VerifiableCredential, *credentialRecord, error) {
-	issuerDID, err := did.ParseDID(issuerRecord.Issuer)
-	if err != nil {
-		return nil, nil, err
+	result := &issuedList{
+		statusListPage: statusListPage{
+			SubjectID:       issuerRecord.SubjectID,
+			Issuer:          issuerRecord.Issuer,
+			StatusPurpose:   StatusPurposeRevocation,
+			Page:            issuerRecord.Page,
+			LastIssuedIndex: issuerRecord.LastIssuedIndex,
+		},
+		statuses: make(map[int]int, len(issuerRecord.Revocations)),
 	}
-
-	// bit string
-	expanded := newBitstring()
 	for _, rev := range issuerRecord.Revocations {
-		if err = expanded.setBit(rev.StatusListIndex, true); err != nil {
-			// can't happen
-			return nil, nil, err
-		}
-	}
-	encodedList, err := compress(*expanded)
-	if err != nil {
-		// can't happen
-		return nil, nil, err
-	}
-
-	// credential subject
-	credSubject := &StatusList2021CredentialSubject{
-		ID:            issuerRecord.SubjectID,
-		Type:          StatusList2021CredentialSubjectType,
-		StatusPurpose: StatusPurposeRevocation,
-		EncodedList:   encodedList,
-	}
-	// create and sign a new StatusList2021Credential
-	statusListCredential, err := cs.buildAndSignVC(ctx, *issuerDID, *credSubject, kid)
-	if err != nil {
-		return nil, nil, err
-	}
-
-	// create new credentialRecord
-	expires := statusListCredential.ExpirationDate.Unix()
-	credRecord := &credentialRecord{
-		SubjectID:     credSubject.ID,
-		StatusPurpose: credSubject.StatusPurpose,
-		Bitstring:     *expanded,
-		Expires:       &expires,
-		Raw:           statusListCredential.Raw(),
+		result.statuses[rev.StatusListIndex] = 1
 	}
-	return statusListCredential, credRecord, nil
-}
-
-// buildAndSignVC intends to do the same as vcr.issuer.buildAndSignVC
-func (cs *StatusList2021) buildAndSignVC(ctx context.Context, issuerDID did.DID, credSubject StatusList2021CredentialSubject, kid string) (*vc.VerifiableCredential, error) {
-	iss := time.Now()
-	exp := iss.Add(statusListValidity)
-	credentialID := ssi.MustParseURI(fmt.Sprintf("%s#%s", issuerDID.String(), uuid.New().String()))
-	template := vc.VerifiableCredential{
-		Context: []ssi.URI{
-			vc.VCContextV1URI(),
-			StatusList2021ContextURI,
-		},
-		Type: []ssi.URI{
-			vc.VerifiableCredentialTypeV1URI(),
-			statusList2021CredentialTypeURI,
-		},
-		ID:                &credentialID,
-		CredentialSubject: []map[string]any{credSubject.toMap()},
-		Issuer:            issuerDID.URI(),
-		IssuanceDate:      iss,
-		ExpirationDate:    &exp,
-	}
-
-	// sign the StatusList2021Credential
-	return cs.Sign(ctx, template, kid)
+	return result, nil
 }
 
 func (cs *StatusList2021) Entry(ctx context.Context, issuer did.DID, purpose StatusPurpose) (*StatusList2021Entry, error) {
@@ -279,99 +131,29 @@ func (cs *StatusList2021) Entry(ctx context.Context, issuer did.DID, purpose Sta
 		return nil, errUnsupportedPurpose
 	}
 
-	// resolve signing key outside of transaction
-	kid, _, err := cs.ResolveKey(issuer, nil, resolver.AssertionMethod)
-	if err != nil {
-		return nil, err
+	template := statusListPage{Issuer: issuer.String(), StatusPurpose: StatusPurposeRevocation}
+	pageURL := func(page int) string {
+		return cs.statusListURL(issuer, page)
 	}
-
-	credentialIssuer := new(credentialIssuerRecord)
-	for {
-		err := cs.db.Transaction(func(tx *gorm.DB) error {
-			// Find issuer's last page; if it exists. Lock all pages.
-			// Microsoft SQL server does not support the locking clause, so we have to use a raw query instead.
-			// See https://github.com/nuts-foundation/nuts-node/issues/3393
-			if tx.Dialector.Name() == "sqlserver" {
-				var pages []credentialIssuerRecord
-				if err = tx.Raw("SELECT * FROM status_list WITH (UPDLOCK, ROWLOCK) WHERE issuer = ?", issuer.String()).
-					Scan(&pages).
-					Error; err != nil {
-					return err
-				}
-				if len(pages) == 0 {
-					// mimic non-SQL Server behavior
-					err = gorm.ErrRecordNotFound
-				} else {
-					// get last page, order by page first
-					slices.SortFunc(pages, func(i, j credentialIssuerRecord) int {
-						return i.Page - j.Page
-					})
-					credentialIssuer = &pages[len(pages)-1]
-				}
-			} else {
-				err = tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
-					Order("page").
-					Last(credentialIssuer, "issuer = ?", issuer.String()).
-					Error
-			}
-			if err != nil {
-				if !errors.Is(err, gorm.ErrRecordNotFound) {
-					return err
-				}
-
-				// first time issuer; prepare to create a new Page / StatusListCredential
-				credentialIssuer = &credentialIssuerRecord{
-					Issuer:          issuer.String(),
-					LastIssuedIndex: maxBitstringIndex, // this will be incremented to move to page 1
-					Page:            0,
-				}
-			}
-
-			// next index
-			credentialIssuer.LastIssuedIndex++
-
-			// create new page (statusListCredential) if current is full and release lock
-			// write actions here are not protected by the SELECT FOR UPDATE clause, so can fail with gorm.ErrDuplicatedKey
-			if credentialIssuer.LastIssuedIndex > maxBitstringIndex {
-				credentialIssuer.LastIssuedIndex = 0
-				credentialIssuer.Page++
-				credentialIssuer.SubjectID = cs.statusListURL(issuer, credentialIssuer.Page)
-				// add new credentialIssuerRecord
-				if err = tx.Create(credentialIssuer).Error; err != nil {
-					return err
-				}
-
-				// store transaction context
-				transactionContext := context.WithValue(ctx, storage.TransactionKey{}, tx)
-				_, credRecord, err := cs.updateCredential(transactionContext, credentialIssuer, kid)
-				if err != nil {
-					return err
-				}
-				return tx.Create(credRecord).Error
-			}
-
-			// update last_issued_index and release lock
-			return tx.Model(&credentialIssuerRecord{}).
-				Where("subject_id = ?", credentialIssuer.SubjectID).
-				UpdateColumn("last_issued_index", credentialIssuer.LastIssuedIndex).Error // only then update
-		})
-		if err != nil {
-			if errors.Is(err, gorm.ErrDuplicatedKey) {
-				// gorm.ErrDuplicatedKey means that a race condition occurred while trying to add a new credentialRecord
-				// or credentialIssuerRecord. We just have to try again.
-				continue
-			}
-			return nil, err
+	newRecord := func(page statusListPage) any {
+		return &credentialIssuerRecord{
+			SubjectID:       page.SubjectID,
+			Issuer:          page.Issuer,
+			Page:            page.Page,
+			LastIssuedIndex: page.LastIssuedIndex,
 		}
-		break
+	}
+	page, err := cs.nextIndex(ctx, template, pageURL, newRecord, "issuer = ?", issuer.String())
+	if err != nil {
+		return nil, err
 	}
 
 	return &StatusList2021Entry{
-		ID:                   fmt.Sprintf("%s#%d", credentialIssuer.SubjectID, credentialIssuer.LastIssuedIndex),
+		ID:                   fmt.Sprintf("%s#%d", page.SubjectID, page.LastIssuedIndex),
 		Type:                 StatusList2021EntryType,
 		StatusPurpose:        StatusPurposeRevocation,
-		StatusListIndex:      strconv.Itoa(credentialIssuer.LastIssuedIndex),
-		StatusListCredential: credentialIssuer.SubjectID,
+		StatusListIndex:      strconv.Itoa(page.LastIssuedIndex),
+		StatusListCredential: page.SubjectID,
 	}, nil
 }
 
@@ -388,75 +170,23 @@ func (cs *StatusList2021) Revoke(ctx context.Context, credentialID ssi.URI, entr
 	}
 
 	// check if StatusList2021Credential is managed by this node
-	if !cs.isManaged(entry.StatusListCredential) {
-		return errNotFound
-	}
-
-	// resolve signing key outside of transaction
-	var issuerStr string
-	err = cs.db.Model(&credentialIssuerRecord{}).Select("issuer").First(&issuerStr, "subject_id = ?", entry.StatusListCredential).Error
+	page, err := cs.page(entry.StatusListCredential)
 	if err != nil {
-		// can't happen; confirmed isManaged
 		return err
 	}
-	issuerDID, err := did.ParseDID(issuerStr)
-	if err != nil {
-		// can't happen; own DB
-		return err
-	}
-	kid, _, err := cs.ResolveKey(*issuerDID, nil, resolver.AssertionMethod)
-	if err != nil {
-		// can't happen; credential confirmed to issued by this node
-		return err
-	}
-
-	return cs.db.Transaction(func(tx *gorm.DB) error {
-		// lock relevant credentialRecord. It was created when the first entry was issued for this StatusList2021Credential.
-		err = lockCredentialRecord(tx, entry.StatusListCredential)
-		if err != nil {
-			return err
-		}
 
-		// revoke
-		revocation := revocationRecord{
+	return cs.updateStatus(ctx, *page, statusListIndex, func(tx *gorm.DB) error {
+		// fail fast, immediately fail if revocation already exists
+		err := tx.Create(&revocationRecord{
 			StatusListCredential: entry.StatusListCredential,
 			StatusListIndex:      statusListIndex,
 			CredentialID:         credentialID.String(),
+		}).Error
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return types.ErrRevoked // already revoked
 		}
-
-		// fail fast, immediately fail if revocation already exists
-		err = tx.Create(&revocation).Error
-		if err != nil {
-			if errors.Is(err, gorm.ErrDuplicatedKey) {
-				return types.ErrRevoked // already revoked
-			}
-			return err
-		}
-
-		// load all revocations
-		issuerRecord := new(credentialIssuerRecord)
-		err = tx.Preload("Revocations").First(issuerRecord, "subject_id = ?", entry.StatusListCredential).Error
-		if err != nil {
-			if errors.Is(err, gorm.ErrRecordNotFound) {
-				// can't happen, already checked
-				return errNotFound
-			}
-			return err
-		}
-
-		// validate StatusListIndex; triggers a rollback after the fact, but this should never happen.
-		if statusListIndex < 0 || statusListIndex > issuerRecord.LastIssuedIndex {
-			return ErrIndexNotInBitstring
-		}
-
-		// append new revocation and re-issue the StatusList2021Credential.
-		transactionContext := context.WithValue(ctx, storage.TransactionKey{}, tx)
-		_, credRecord, err := cs.updateCredential(transactionContext, issuerRecord, kid)
-		if err != nil {
-			return err
-		}
-		return tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(credRecord).Error
-	})
+		return err
+	}, cs.loadIssuedList)
 }
 
 // GetRevocation checks if the credential, issued locally, was revoked.
@@ -490,16 +220,3 @@ func (cs *StatusList2021) statusListURL(issuer did.DID, page int) string {
 	result, _ := url.Parse(cs.baseURL)
 	return result.JoinPath("statuslist", issuer.String(), strconv.Itoa(page)).String()
 }
-
-func lockCredentialRecord(tx *gorm.DB, statusListCredentialURL string) error {
-	// Microsoft SQL server does not support the locking clause, so we have to use a raw query instead.
-	// See https://github.com/nuts-foundation/nuts-node/issues/3393
-	if tx.Dialector.Name() == "sqlserver" {
-		return tx.Raw("SELECT * FROM status_list_credential WITH (UPDLOCK, ROWLOCK) WHERE subject_id = ?", statusListCredentialURL).
-			Scan(new(credentialRecord)).
-			Error
-	}
-	return tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
-		Find(new(credentialRecord), "subject_id = ?", statusListCredentialURL).
-		Error
-}
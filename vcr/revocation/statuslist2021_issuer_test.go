@@ -132,7 +132,7 @@ func TestStatusList2021_Entry(t *testing.T) {
 		assert.Nil(t, entry)
 	})
 	t.Run("error - unsupported purpose", func(t *testing.T) {
-		entry, err = s.Entry(testCtx, aliceDID, statusPurposeSuspension)
+		entry, err = s.Entry(testCtx, aliceDID, StatusPurposeSuspension)
 		assert.ErrorIs(t, err, errUnsupportedPurpose)
 		assert.Nil(t, entry)
 	})
@@ -250,7 +250,7 @@ func TestStatusList2021_Revoke(t *testing.T) {
 	})
 	t.Run("error - unsupportedPurpose", func(t *testing.T) {
 		cEntry := entry
-		cEntry.StatusPurpose = statusPurposeSuspension
+		cEntry.StatusPurpose = StatusPurposeSuspension
 		assert.ErrorIs(t, s.Revoke(context.Background(), ssi.URI{}, cEntry), errUnsupportedPurpose)
 	})
 	t.Run("error - ErrNotFound", func(t *testing.T) {
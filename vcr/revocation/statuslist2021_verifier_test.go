@@ -127,7 +127,11 @@ func TestStatusList2021_statusList(t *testing.T) {
 				StatusListIndex:      1,
 			}},
 		}
-		_, cr, err := (&StatusList2021{Sign: noopSign}).updateCredential(nil, &cir, "")
+		list := issuedList{
+			statusListPage: statusListPage{SubjectID: subjectID, Issuer: aliceDID.String(), StatusPurpose: StatusPurposeRevocation, Page: 1},
+			statuses:       map[int]int{1: 1},
+		}
+		_, cr, err := (&StatusList2021{statusListCore{Sign: noopSign, format: statusList2021Format}}).sign(nil, list, "")
 		require.NoError(t, err)
 		return *cr, cir
 	}
@@ -307,7 +311,7 @@ func TestStatusList2021_download(t *testing.T) {
 		}))
 		defer ts.Close()
 
-		cs := StatusList2021{client: ts.Client()}
+		cs := StatusList2021{statusListCore{client: ts.Client(), format: statusList2021Format}}
 		received, err := cs.download(ts.URL)
 
 		assert.NoError(t, err)
@@ -316,7 +320,7 @@ func TestStatusList2021_download(t *testing.T) {
 		assert.JSONEq(t, string(expected), string(actual))
 	})
 	t.Run("error - StatusListCredential not a URL", func(t *testing.T) {
-		cs := StatusList2021{client: http.DefaultClient}
+		cs := StatusList2021{statusListCore{client: http.DefaultClient, format: statusList2021Format}}
 		received, err := cs.download("%%")
 		assert.EqualError(t, err, "parse \"%%\": invalid URL escape \"%%\"")
 		assert.Nil(t, received)
@@ -327,7 +331,7 @@ func TestStatusList2021_download(t *testing.T) {
 		}))
 		defer ts.Close()
 
-		cs := StatusList2021{client: ts.Client()}
+		cs := StatusList2021{statusListCore{client: ts.Client(), format: statusList2021Format}}
 		received, err := cs.download(ts.URL)
 
 		assert.ErrorContains(t, err, "fetching StatusList2021Credential from")
@@ -341,7 +345,7 @@ func TestStatusList2021_download(t *testing.T) {
 		}))
 		defer ts.Close()
 
-		cs := &StatusList2021{client: ts.Client()}
+		cs := &StatusList2021{statusListCore{client: ts.Client(), format: statusList2021Format}}
 
 		received, err := cs.download(ts.URL)
 		assert.EqualError(t, err, "unexpected end of JSON input")
@@ -350,12 +354,13 @@ func TestStatusList2021_download(t *testing.T) {
 }
 
 func TestStatusList2021_verify(t *testing.T) {
-	credentialStatusNoSignCheck := &StatusList2021{
+	credentialStatusNoSignCheck := &StatusList2021{statusListCore{
 		client: nil,
 		VerifySignature: func(credentialToVerify vc.VerifiableCredential, validateAt *time.Time) error {
 			return nil
 		},
-	}
+		format: statusList2021Format,
+	}}
 	t.Run("ok", func(t *testing.T) {
 		cred := test.ValidStatusList2021Credential(t)
 		expectedBs, err := json.Marshal(cred.CredentialSubject[0])
@@ -384,8 +389,11 @@ func TestStatusList2021_verify(t *testing.T) {
 	})
 	t.Run("error -invalid signature", func(t *testing.T) {
 		cred := test.ValidStatusList2021Credential(t)
-		cs := StatusList2021{VerifySignature: func(credentialToVerify vc.VerifiableCredential, validateAt *time.Time) error {
-			return errors.New("invalid signature")
+		cs := StatusList2021{statusListCore{
+			VerifySignature: func(credentialToVerify vc.VerifiableCredential, validateAt *time.Time) error {
+				return errors.New("invalid signature")
+			},
+			format: statusList2021Format,
 		}}
 		credSubj, err := cs.verify(cred)
 		assert.EqualError(t, err, "invalid signature")
@@ -394,9 +402,10 @@ func TestStatusList2021_verify(t *testing.T) {
 }
 
 func TestStatusList2021_validate(t *testing.T) {
-	cs := StatusList2021{
+	cs := StatusList2021{statusListCore{
 		VerifySignature: func(credentialToVerify vc.VerifiableCredential, validateAt *time.Time) error { return nil },
-	}
+		format:          statusList2021Format,
+	}}
 
 	// Credential checks
 	t.Run("ok", func(t *testing.T) {
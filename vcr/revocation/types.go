@@ -52,14 +52,17 @@ var statusList2021CredentialTypeURI = ssi.MustParseURI(StatusList2021CredentialT
 // errNotFound wraps types.ErrNotFound to clarify which StatusList2021Credential is not found
 var errNotFound = fmt.Errorf("status list: %w", types.ErrNotFound)
 
-// errUnsupportedPurpose limits current usage to 'revocation'
+// errUnsupportedPurpose limits StatusList2021 usage to 'revocation', and BitstringStatusList usage to 'revocation' and 'suspension'
 var errUnsupportedPurpose = errors.New("status list: purpose not supported")
 
 type StatusPurpose string
 
 const (
 	StatusPurposeRevocation = "revocation"
-	statusPurposeSuspension = "suspension" // currently not supported
+	// StatusPurposeSuspension is only supported by the BitstringStatusList
+	StatusPurposeSuspension = "suspension"
+	// StatusPurposeMessage is only supported by the BitstringStatusList
+	StatusPurposeMessage = "message"
 )
 
 // Revocation contains information about a revoked credential.
@@ -113,18 +116,13 @@ var _ StatusList2021Verifier = (*StatusList2021)(nil)
 // https://www.w3.org/TR/2023/WD-vc-status-list-20230427/
 // VerifySignature and Sign methods are used to verify and sign StatusList2021Credentials
 type StatusList2021 struct {
-	client          core.HTTPRequestDoer
-	db              *gorm.DB
-	baseURL         string
-	VerifySignature VerifySignFn // injected by verifier
-	Sign            SignFn       // injected by issuer, context must contain an audit log
-	ResolveKey      ResolveKeyFn // injected by issuer
+	statusListCore
 }
 
 // NewStatusList2021 returns a StatusList2021 without a Sign or VerifySignature method.
 // The URL in the credential will be constructed as follows using the given base URL: <baseURL>/statuslist/<did>/<page>
 func NewStatusList2021(db *gorm.DB, client core.HTTPRequestDoer, baseURL string) *StatusList2021 {
-	return &StatusList2021{client: client, db: db, baseURL: baseURL}
+	return &StatusList2021{statusListCore{client: client, db: db, baseURL: baseURL, format: statusList2021Format}}
 }
 
 // StatusList2021Entry is the "credentialStatus" property used by issuers to enable VerifiableCredential status information.
@@ -175,7 +173,8 @@ func (e StatusList2021Entry) Validate() error {
 	return nil
 }
 
-// StatusList2021CredentialSubject of a StatusList2021Credential
+// StatusList2021CredentialSubject of a StatusList2021Credential.
+// It is also used for BitstringStatusListCredentials, whose credentialSubject has the same properties.
 type StatusList2021CredentialSubject struct {
 	// ID for the credential subject
 	ID string `json:"id"`
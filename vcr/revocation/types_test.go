@@ -43,6 +43,16 @@ func newTestStatusList2021(t testing.TB, dids ...did.DID) *StatusList2021 {
 	return cs
 }
 
+// newTestBitstringStatusList returns a BitstringStatusList that does not Sign or VerifySignature, with a SQLite db containing the dids, and no http-client.
+func newTestBitstringStatusList(t testing.TB, dids ...did.DID) *BitstringStatusList {
+	cs := NewBitstringStatusList(storage.NewTestStorageEngine(t).GetSQLDatabase(), nil, "https://example.com")
+	cs.Sign = noopSign
+	cs.ResolveKey = noopResolveKey
+	cs.VerifySignature = noopSignVerify
+	storage.AddDIDtoSQLDB(t, cs.db, dids...)
+	return cs
+}
+
 func noopSign(_ context.Context, unsignedCredential vc.VerifiableCredential, _ string) (*vc.VerifiableCredential, error) {
 	// marshal-unmarshal credential to set the .raw field
 	bs, err := json.Marshal(unsignedCredential)
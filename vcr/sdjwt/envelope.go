@@ -0,0 +1,78 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package sdjwt
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/vc"
+)
+
+// EnvelopedVerifiableCredentialType is the type of credential that envelops a credential in another format.
+// See https://www.w3.org/TR/vc-data-model-2.0/#enveloped-verifiable-credentials
+const EnvelopedVerifiableCredentialType = "EnvelopedVerifiableCredential"
+
+// VCContextV2 is the JSON-LD context of the Verifiable Credentials Data Model v2.0.
+const VCContextV2 = "https://www.w3.org/ns/credentials/v2"
+
+// dataURIPrefix is the prefix of the 'id' of an enveloped SD-JWT VC, which contains the SD-JWT as data URL.
+const dataURIPrefix = "data:application/" + Format + ","
+
+// Envelope wraps the SD-JWT in an EnvelopedVerifiableCredential.
+// Since an SD-JWT can't be represented as JSON-LD or JWT VC, this allows it to be stored and transported as vc.VerifiableCredential
+// (e.g. in wallets and Verifiable Presentations).
+func Envelope(s SDJWT) (*vc.VerifiableCredential, error) {
+	data, _ := json.Marshal(map[string]interface{}{
+		"@context": []string{VCContextV2},
+		"id":       dataURIPrefix + s.String(),
+		"type":     []string{EnvelopedVerifiableCredentialType},
+	})
+	return vc.ParseVerifiableCredential(string(data))
+}
+
+// IsEnveloped returns true if the given credential is an EnvelopedVerifiableCredential containing an SD-JWT.
+func IsEnveloped(credential vc.VerifiableCredential) bool {
+	return credential.ID != nil &&
+		credential.IsType(ssi.MustParseURI(EnvelopedVerifiableCredentialType)) &&
+		strings.HasPrefix(credential.ID.String(), dataURIPrefix)
+}
+
+// Open returns the SD-JWT contained in an EnvelopedVerifiableCredential.
+func Open(credential vc.VerifiableCredential) (*SDJWT, error) {
+	if !IsEnveloped(credential) {
+		return nil, errors.New("credential is not an enveloped SD-JWT VC")
+	}
+	return Parse(strings.TrimPrefix(credential.ID.String(), dataURIPrefix))
+}
+
+// Expand returns the credential contained in an enveloped SD-JWT VC, with all released disclosures applied.
+// Other credentials are returned as-is.
+func Expand(credential vc.VerifiableCredential) (*vc.VerifiableCredential, error) {
+	if !IsEnveloped(credential) {
+		return &credential, nil
+	}
+	sdJWT, err := Open(credential)
+	if err != nil {
+		return nil, err
+	}
+	return sdJWT.VerifiableCredential()
+}
@@ -0,0 +1,78 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package sdjwt
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvelope(t *testing.T) {
+	key := newKey(t)
+	sdJWT := issue(t, key, "givenName")
+
+	envelope, err := Envelope(sdJWT)
+	require.NoError(t, err)
+
+	t.Run("is enveloped", func(t *testing.T) {
+		assert.True(t, IsEnveloped(*envelope))
+		assert.Equal(t, vc.JSONLDCredentialProofFormat, envelope.Format())
+	})
+	t.Run("open", func(t *testing.T) {
+		opened, err := Open(*envelope)
+
+		require.NoError(t, err)
+		assert.Equal(t, sdJWT.String(), opened.String())
+	})
+	t.Run("survives JSON marshalling", func(t *testing.T) {
+		data, err := json.Marshal(envelope)
+		require.NoError(t, err)
+		var unmarshalled vc.VerifiableCredential
+		require.NoError(t, json.Unmarshal(data, &unmarshalled))
+
+		opened, err := Open(unmarshalled)
+
+		require.NoError(t, err)
+		assert.Equal(t, sdJWT.String(), opened.String())
+	})
+	t.Run("expand", func(t *testing.T) {
+		expanded, err := Expand(*envelope)
+
+		require.NoError(t, err)
+		assert.Equal(t, "did:web:example.com#123", expanded.ID.String())
+		assert.Equal(t, "John", expanded.CredentialSubject[0]["givenName"])
+	})
+	t.Run("expand other credential", func(t *testing.T) {
+		other := vc.VerifiableCredential{Issuer: envelope.Issuer}
+
+		expanded, err := Expand(other)
+
+		require.NoError(t, err)
+		assert.Equal(t, other, *expanded)
+	})
+	t.Run("open other credential", func(t *testing.T) {
+		_, err := Open(vc.VerifiableCredential{})
+
+		assert.EqualError(t, err, "credential is not an enveloped SD-JWT VC")
+	})
+}
@@ -0,0 +1,112 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package sdjwt
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/crypto/jwx"
+)
+
+// KeyBindingJWTType is the 'typ' header of a key binding JWT.
+const KeyBindingJWTType = "kb+jwt"
+
+// sdHashClaim is the claim in the key binding JWT that contains the digest over the SD-JWT it is bound to.
+const sdHashClaim = "sd_hash"
+
+// keyBindingMaxAge is the maximum age of a key binding JWT. It is created for a single presentation, so it must be fresh.
+const keyBindingMaxAge = 5 * time.Minute
+
+// keyBindingClockSkew is the clock skew allowed when validating the time claims of a key binding JWT.
+const keyBindingClockSkew = 5 * time.Second
+
+// ErrMissingKeyBinding is returned when an SD-JWT is presented without key binding JWT.
+var ErrMissingKeyBinding = errors.New("SD-JWT is missing key binding JWT")
+
+// Bind returns a copy of the SD-JWT with a key binding JWT, proving possession of the holder's key to the given audience.
+// The signer is responsible for adding the right key claims (e.g. `kid`).
+func (s SDJWT) Bind(ctx context.Context, signer vc.JWTSigner, audience string, nonce string, issuedAt time.Time) (*SDJWT, error) {
+	headers := map[string]interface{}{
+		jws.TypeKey: KeyBindingJWTType,
+	}
+	claims := map[string]interface{}{
+		jwt.IssuedAtKey: issuedAt.Unix(),
+		jwt.AudienceKey: audience,
+		"nonce":         nonce,
+		sdHashClaim:     digest(s.presentation()),
+	}
+	token, err := signer(ctx, claims, headers)
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign SD-JWT key binding JWT: %w", err)
+	}
+	result := s.Select(func(_ Disclosure) bool { return true })
+	result.KeyBinding = token
+	return &result, nil
+}
+
+// VerifyKeyBinding verifies the key binding JWT of the SD-JWT, using the key resolved by the given key resolver.
+// It checks the signature, the 'typ' header, that the JWT was issued at most keyBindingMaxAge before the given time and that it is bound to the released disclosures.
+// It returns the key ID used to sign the key binding JWT and the parsed key binding JWT, so the caller can check the key, audience and nonce.
+func (s SDJWT) VerifyKeyBinding(keyResolver func(kid string) (crypto.PublicKey, error), at time.Time) (string, jwt.Token, error) {
+	if s.KeyBinding == "" {
+		return "", nil, ErrMissingKeyBinding
+	}
+	message, err := jws.ParseString(s.KeyBinding)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid key binding JWT: %w", err)
+	}
+	if len(message.Signatures()) != 1 {
+		return "", nil, errors.New("invalid key binding JWT: expected exactly 1 signature")
+	}
+	headers := message.Signatures()[0].ProtectedHeaders()
+	if headers.Type() != KeyBindingJWTType {
+		return "", nil, fmt.Errorf("invalid key binding JWT: typ must be %s", KeyBindingJWTType)
+	}
+	if !jwx.IsAlgorithmSupported(headers.Algorithm()) {
+		return "", nil, fmt.Errorf("invalid key binding JWT: signing algorithm is not supported: %s", headers.Algorithm())
+	}
+	key, err := keyResolver(headers.KeyID())
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to resolve key binding JWT key: %w", err)
+	}
+	token, err := jwt.ParseString(s.KeyBinding, jwt.WithKey(headers.Algorithm(), key), jwt.WithClock(jwt.ClockFunc(func() time.Time {
+		return at
+	})), jwt.WithAcceptableSkew(keyBindingClockSkew))
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid key binding JWT: %w", err)
+	}
+	if token.IssuedAt().IsZero() || token.IssuedAt().After(at.Add(keyBindingClockSkew)) {
+		return "", nil, errors.New("invalid key binding JWT: missing or invalid iat")
+	}
+	if token.IssuedAt().Before(at.Add(-keyBindingMaxAge - keyBindingClockSkew)) {
+		return "", nil, errors.New("invalid key binding JWT: iat is too old")
+	}
+	sdHash, _ := token.PrivateClaims()[sdHashClaim].(string)
+	if sdHash != digest(s.presentation()) {
+		return "", nil, errors.New("invalid key binding JWT: sd_hash does not match")
+	}
+	return headers.KeyID(), token, nil
+}
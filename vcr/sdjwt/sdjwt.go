@@ -0,0 +1,397 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+// Package sdjwt implements Selective Disclosure for JWTs (SD-JWT, RFC 9901) for Verifiable Credentials.
+// The issuer-signed JWT follows the JWT VC data model (claims in the 'vc' claim),
+// with selectively disclosable claims replaced by digests in '_sd' arrays.
+package sdjwt
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/nuts-foundation/go-did/vc"
+)
+
+// Format is the credential format designator of SD-JWT Verifiable Credentials.
+const Format = "vc+sd-jwt"
+
+const (
+	// separator separates the issuer-signed JWT, the disclosures and the key binding JWT.
+	separator = "~"
+	// digestsClaim is the claim that holds the digests of the disclosures in a JSON object.
+	digestsClaim = "_sd"
+	// hashAlgorithmClaim is the claim that holds the hash algorithm used for the digests.
+	hashAlgorithmClaim = "_sd_alg"
+	// hashAlgorithm is the only supported hash algorithm for disclosure digests.
+	hashAlgorithm = "sha-256"
+	// saltLength is the number of random bytes used as salt for a disclosure (128 bits, as recommended by the spec).
+	saltLength = 16
+)
+
+// Disclosure is a single selectively disclosable claim of an SD-JWT: a salt, claim name and claim value.
+type Disclosure struct {
+	Salt  string
+	Name  string
+	Value interface{}
+	// encoded contains the disclosure as it was received or created, since the digest is calculated over the encoded form.
+	encoded string
+}
+
+// NewDisclosure creates a new disclosure for the given claim, using a random salt.
+func NewDisclosure(name string, value interface{}) (*Disclosure, error) {
+	saltBytes := make([]byte, saltLength)
+	if _, err := rand.Read(saltBytes); err != nil {
+		return nil, err
+	}
+	salt := base64.RawURLEncoding.EncodeToString(saltBytes)
+	data, err := json.Marshal([]interface{}{salt, name, value})
+	if err != nil {
+		return nil, fmt.Errorf("invalid disclosure value for claim '%s': %w", name, err)
+	}
+	return &Disclosure{
+		Salt:    salt,
+		Name:    name,
+		Value:   value,
+		encoded: base64.RawURLEncoding.EncodeToString(data),
+	}, nil
+}
+
+// ParseDisclosure parses a base64url-encoded disclosure. Only disclosures of object properties are supported.
+func ParseDisclosure(encoded string) (*Disclosure, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("invalid disclosure encoding: %w", err)
+	}
+	var elements []interface{}
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return nil, fmt.Errorf("invalid disclosure: %w", err)
+	}
+	if len(elements) != 3 {
+		return nil, errors.New("invalid disclosure: only object property disclosures are supported")
+	}
+	salt, ok := elements[0].(string)
+	if !ok {
+		return nil, errors.New("invalid disclosure: salt must be a string")
+	}
+	name, ok := elements[1].(string)
+	if !ok {
+		return nil, errors.New("invalid disclosure: claim name must be a string")
+	}
+	if name == digestsClaim || name == "..." {
+		return nil, fmt.Errorf("invalid disclosure: illegal claim name '%s'", name)
+	}
+	return &Disclosure{
+		Salt:    salt,
+		Name:    name,
+		Value:   elements[2],
+		encoded: encoded,
+	}, nil
+}
+
+// Encoded returns the base64url-encoded form of the disclosure.
+func (d Disclosure) Encoded() string {
+	return d.encoded
+}
+
+// Digest returns the base64url-encoded SHA-256 digest of the disclosure, as it appears in an '_sd' array.
+func (d Disclosure) Digest() string {
+	return digest(d.encoded)
+}
+
+// Conceal returns a copy of the given JSON object where the given properties are replaced by digests.
+// It returns the disclosures of the concealed properties, which must be handed to the holder along with the issuer-signed JWT.
+// It returns an error if one of the properties does not exist in the object.
+func Conceal(object map[string]interface{}, names []string) (map[string]interface{}, []Disclosure, error) {
+	result := make(map[string]interface{}, len(object))
+	for name, value := range object {
+		result[name] = value
+	}
+	var disclosures []Disclosure
+	var digests []interface{}
+	if existing, ok := result[digestsClaim].([]interface{}); ok {
+		digests = existing
+	}
+	for _, name := range names {
+		value, ok := result[name]
+		if !ok {
+			return nil, nil, fmt.Errorf("selectively disclosable claim not found: %s", name)
+		}
+		disclosure, err := NewDisclosure(name, value)
+		if err != nil {
+			return nil, nil, err
+		}
+		delete(result, name)
+		disclosures = append(disclosures, *disclosure)
+		digests = append(digests, disclosure.Digest())
+	}
+	if len(digests) > 0 {
+		result[digestsClaim] = digests
+	}
+	return result, disclosures, nil
+}
+
+// CreateVerifiableCredential creates an SD-JWT VC from the given credential template,
+// making the given credentialSubject properties selectively disclosable.
+// The issuer-signed JWT follows the JWT VC encoding of the credential (https://www.w3.org/TR/vc-data-model/#jwt-encoding).
+// For signing the actual JWT it calls the given signer, which must return the created JWT in string format.
+func CreateVerifiableCredential(ctx context.Context, template vc.VerifiableCredential, disclosable []string, signer vc.JWTSigner) (*SDJWT, error) {
+	subjectDID, err := template.SubjectDID()
+	if err != nil {
+		return nil, err
+	}
+	if len(template.CredentialSubject) != 1 {
+		return nil, errors.New("SD-JWT VC must contain exactly one credentialSubject")
+	}
+	for _, name := range disclosable {
+		if name == "id" {
+			return nil, errors.New("credentialSubject.id can't be selectively disclosable")
+		}
+	}
+	credentialSubject, disclosures, err := Conceal(template.CredentialSubject[0], disclosable)
+	if err != nil {
+		return nil, err
+	}
+	headers := map[string]interface{}{
+		jws.TypeKey: Format,
+	}
+	vcMap := map[string]interface{}{
+		"@context":          template.Context,
+		"type":              template.Type,
+		"credentialSubject": credentialSubject,
+	}
+	claims := map[string]interface{}{
+		jwt.NotBeforeKey:   template.IssuanceDate,
+		jwt.IssuerKey:      template.Issuer.String(),
+		jwt.SubjectKey:     subjectDID.String(),
+		hashAlgorithmClaim: hashAlgorithm,
+		"vc":               vcMap,
+	}
+	if template.ID != nil {
+		claims[jwt.JwtIDKey] = template.ID.String()
+	}
+	if template.ExpirationDate != nil {
+		claims[jwt.ExpirationKey] = *template.ExpirationDate
+	}
+	if template.CredentialStatus != nil {
+		vcMap["credentialStatus"] = template.CredentialStatus
+	}
+	token, err := signer(ctx, claims, headers)
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign SD-JWT credential: %w", err)
+	}
+	return &SDJWT{IssuerSigned: token, Disclosures: disclosures}, nil
+}
+
+// SDJWT is an SD-JWT: an issuer-signed JWT, the disclosures released by the holder and an optional key binding JWT.
+type SDJWT struct {
+	// IssuerSigned contains the issuer-signed JWT.
+	IssuerSigned string
+	// Disclosures contains the disclosures for the selectively disclosable claims that are released.
+	Disclosures []Disclosure
+	// KeyBinding contains the key binding JWT, if present.
+	KeyBinding string
+}
+
+// Parse parses an SD-JWT in compact serialization (<issuer-signed JWT>~<disclosure>~...~<optional KB-JWT>).
+// It checks that every disclosure is referenced by a digest in the issuer-signed JWT, and that no disclosure is repeated.
+// It does not verify signatures.
+func Parse(raw string) (*SDJWT, error) {
+	parts := strings.Split(strings.TrimSpace(raw), separator)
+	if len(parts) < 2 {
+		return nil, errors.New("invalid SD-JWT: missing separator")
+	}
+	result := SDJWT{
+		IssuerSigned: parts[0],
+		KeyBinding:   parts[len(parts)-1],
+	}
+	for _, encoded := range parts[1 : len(parts)-1] {
+		if encoded == "" {
+			return nil, errors.New("invalid SD-JWT: empty disclosure")
+		}
+		disclosure, err := ParseDisclosure(encoded)
+		if err != nil {
+			return nil, err
+		}
+		result.Disclosures = append(result.Disclosures, *disclosure)
+	}
+	if _, err := result.Claims(); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// String returns the SD-JWT in compact serialization.
+func (s SDJWT) String() string {
+	return s.presentation() + s.KeyBinding
+}
+
+// presentation returns the SD-JWT without key binding JWT, which is input for the key binding JWT's sd_hash.
+func (s SDJWT) presentation() string {
+	var builder strings.Builder
+	builder.WriteString(s.IssuerSigned)
+	builder.WriteString(separator)
+	for _, disclosure := range s.Disclosures {
+		builder.WriteString(disclosure.encoded)
+		builder.WriteString(separator)
+	}
+	return builder.String()
+}
+
+// Token returns the claims of the issuer-signed JWT as-is (with digests instead of the disclosed claims).
+// It does not verify the signature.
+func (s SDJWT) Token() (jwt.Token, error) {
+	return jwt.ParseString(s.IssuerSigned, jwt.WithVerify(false), jwt.WithValidate(false))
+}
+
+// Claims returns the claims of the issuer-signed JWT, with the digests of the released disclosures replaced by their claims.
+// Digests of claims that were not disclosed are removed. It returns an error if:
+// - the hash algorithm is not supported,
+// - a disclosure is not referenced by the issuer-signed JWT,
+// - a disclosure or digest is referenced more than once,
+// - a disclosed claim would overwrite an existing claim.
+func (s SDJWT) Claims() (map[string]interface{}, error) {
+	message, err := jws.ParseString(s.IssuerSigned)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SD-JWT: %w", err)
+	}
+	var claims map[string]interface{}
+	if err := json.Unmarshal(message.Payload(), &claims); err != nil {
+		return nil, fmt.Errorf("invalid SD-JWT: %w", err)
+	}
+	if alg, ok := claims[hashAlgorithmClaim]; ok && alg != hashAlgorithm {
+		return nil, fmt.Errorf("unsupported SD-JWT hash algorithm: %v", alg)
+	}
+	delete(claims, hashAlgorithmClaim)
+
+	disclosures := make(map[string]Disclosure, len(s.Disclosures))
+	for _, disclosure := range s.Disclosures {
+		d := disclosure.Digest()
+		if _, exists := disclosures[d]; exists {
+			return nil, errors.New("invalid SD-JWT: disclosure is released more than once")
+		}
+		disclosures[d] = disclosure
+	}
+	used := make(map[string]bool, len(disclosures))
+	if err := resolveDigests(claims, disclosures, used); err != nil {
+		return nil, err
+	}
+	if len(used) != len(disclosures) {
+		return nil, errors.New("invalid SD-JWT: disclosure is not referenced by the issuer-signed JWT")
+	}
+	return claims, nil
+}
+
+// resolveDigests replaces the digests in the given JSON value with the claims of the matching disclosures.
+func resolveDigests(value interface{}, disclosures map[string]Disclosure, used map[string]bool) error {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		digests, _ := typed[digestsClaim].([]interface{})
+		delete(typed, digestsClaim)
+		for _, curr := range digests {
+			d, ok := curr.(string)
+			if !ok {
+				return errors.New("invalid SD-JWT: digest must be a string")
+			}
+			if used[d] {
+				return errors.New("invalid SD-JWT: digest is referenced more than once")
+			}
+			disclosure, ok := disclosures[d]
+			if !ok {
+				// not disclosed
+				continue
+			}
+			used[d] = true
+			if _, exists := typed[disclosure.Name]; exists {
+				return fmt.Errorf("invalid SD-JWT: disclosed claim '%s' already exists", disclosure.Name)
+			}
+			typed[disclosure.Name] = disclosure.Value
+		}
+		for _, curr := range typed {
+			if err := resolveDigests(curr, disclosures, used); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, curr := range typed {
+			if err := resolveDigests(curr, disclosures, used); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Select returns a copy of the SD-JWT with only the disclosures for which the given function returns true.
+// The key binding JWT is removed, since it is bound to the released disclosures.
+func (s SDJWT) Select(fn func(disclosure Disclosure) bool) SDJWT {
+	result := SDJWT{IssuerSigned: s.IssuerSigned}
+	for _, disclosure := range s.Disclosures {
+		if fn(disclosure) {
+			result.Disclosures = append(result.Disclosures, disclosure)
+		}
+	}
+	return result
+}
+
+// VerifiableCredential returns the credential contained in the SD-JWT, with all released disclosures applied.
+// The returned credential has the JWT VC format, backed by the issuer-signed JWT.
+// This allows the credential to be validated (e.g. signature, validity, revocation) like any other JWT VC.
+func (s SDJWT) VerifiableCredential() (*vc.VerifiableCredential, error) {
+	claims, err := s.Claims()
+	if err != nil {
+		return nil, err
+	}
+	result, err := vc.ParseVerifiableCredential(s.IssuerSigned)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SD-JWT VC: %w", err)
+	}
+	vcClaim, _ := claims["vc"].(map[string]interface{})
+	var disclosed vc.VerifiableCredential
+	if err := remarshal(map[string]interface{}{"credentialSubject": vcClaim["credentialSubject"]}, &disclosed); err != nil {
+		return nil, fmt.Errorf("invalid SD-JWT VC: %w", err)
+	}
+	result.CredentialSubject = disclosed.CredentialSubject
+	if sub, ok := claims[jwt.SubjectKey].(string); ok && sub != "" {
+		for _, credentialSubject := range result.CredentialSubject {
+			credentialSubject["id"] = sub
+		}
+	}
+	return result, nil
+}
+
+func digest(input string) string {
+	sum := sha256.Sum256([]byte(input))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func remarshal(src interface{}, target interface{}) error {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
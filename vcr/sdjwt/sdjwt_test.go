@@ -0,0 +1,290 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package sdjwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const issuerKID = "did:web:example.com#1"
+const holderKID = "did:web:example.com:holder#1"
+
+func TestConceal(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		object := map[string]interface{}{"id": "did:web:example.com:holder", "givenName": "John", "familyName": "Doe"}
+
+		concealed, disclosures, err := Conceal(object, []string{"givenName"})
+
+		require.NoError(t, err)
+		require.Len(t, disclosures, 1)
+		assert.Equal(t, "givenName", disclosures[0].Name)
+		assert.Equal(t, "John", disclosures[0].Value)
+		assert.NotContains(t, concealed, "givenName")
+		assert.Equal(t, "Doe", concealed["familyName"])
+		assert.Equal(t, []interface{}{disclosures[0].Digest()}, concealed["_sd"])
+		t.Run("input is not altered", func(t *testing.T) {
+			assert.Equal(t, "John", object["givenName"])
+		})
+	})
+	t.Run("unknown claim", func(t *testing.T) {
+		_, _, err := Conceal(map[string]interface{}{}, []string{"givenName"})
+
+		assert.EqualError(t, err, "selectively disclosable claim not found: givenName")
+	})
+}
+
+func TestParseDisclosure(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		// Example from RFC 9901
+		const encoded = "WyIyR0xDNDJzS1F2ZUNmR2ZyeU5STjl3IiwgImdpdmVuX25hbWUiLCAiSm9obiJd"
+
+		disclosure, err := ParseDisclosure(encoded)
+
+		require.NoError(t, err)
+		assert.Equal(t, "2GLC42sKQveCfGfryNRN9w", disclosure.Salt)
+		assert.Equal(t, "given_name", disclosure.Name)
+		assert.Equal(t, "John", disclosure.Value)
+		assert.Equal(t, encoded, disclosure.Encoded())
+		assert.Equal(t, "jsu9yVulwQQlhFlM_3JlzMaSFzglhQG0DpfayQwLUK4", disclosure.Digest())
+	})
+	t.Run("array element disclosure", func(t *testing.T) {
+		// ["lklxF5jMYlGTPUovMNIvCA", "US"]
+		_, err := ParseDisclosure("WyJsa2x4RjVqTVlsR1RQVW92TU5JdkNBIiwgIlVTIl0")
+
+		assert.EqualError(t, err, "invalid disclosure: only object property disclosures are supported")
+	})
+	t.Run("invalid encoding", func(t *testing.T) {
+		_, err := ParseDisclosure("!!")
+
+		assert.ErrorContains(t, err, "invalid disclosure encoding")
+	})
+}
+
+func TestParse(t *testing.T) {
+	key := newKey(t)
+	sdJWT := issue(t, key, "givenName", "familyName")
+
+	t.Run("ok", func(t *testing.T) {
+		actual, err := Parse(sdJWT.String())
+
+		require.NoError(t, err)
+		assert.Equal(t, sdJWT.IssuerSigned, actual.IssuerSigned)
+		assert.Len(t, actual.Disclosures, 2)
+		assert.Empty(t, actual.KeyBinding)
+		assert.Equal(t, sdJWT.String(), actual.String())
+	})
+	t.Run("without disclosures", func(t *testing.T) {
+		actual, err := Parse(sdJWT.IssuerSigned + "~")
+
+		require.NoError(t, err)
+		assert.Empty(t, actual.Disclosures)
+	})
+	t.Run("missing separator", func(t *testing.T) {
+		_, err := Parse(sdJWT.IssuerSigned)
+
+		assert.EqualError(t, err, "invalid SD-JWT: missing separator")
+	})
+	t.Run("disclosure not referenced by issuer-signed JWT", func(t *testing.T) {
+		other, _ := NewDisclosure("givenName", "Jane")
+
+		_, err := Parse(sdJWT.IssuerSigned + "~" + other.Encoded() + "~")
+
+		assert.EqualError(t, err, "invalid SD-JWT: disclosure is not referenced by the issuer-signed JWT")
+	})
+	t.Run("disclosure released twice", func(t *testing.T) {
+		d := sdJWT.Disclosures[0].Encoded()
+
+		_, err := Parse(sdJWT.IssuerSigned + "~" + d + "~" + d + "~")
+
+		assert.EqualError(t, err, "invalid SD-JWT: disclosure is released more than once")
+	})
+}
+
+func TestSDJWT_Claims(t *testing.T) {
+	key := newKey(t)
+	sdJWT := issue(t, key, "givenName", "familyName")
+
+	t.Run("all disclosed", func(t *testing.T) {
+		claims, err := sdJWT.Claims()
+
+		require.NoError(t, err)
+		assert.NotContains(t, claims, "_sd_alg")
+		subject := claims["vc"].(map[string]interface{})["credentialSubject"].(map[string]interface{})
+		assert.Equal(t, "John", subject["givenName"])
+		assert.Equal(t, "Doe", subject["familyName"])
+		assert.Equal(t, "Utrecht", subject["city"])
+		assert.NotContains(t, subject, "_sd")
+	})
+	t.Run("selection", func(t *testing.T) {
+		selection := sdJWT.Select(func(disclosure Disclosure) bool {
+			return disclosure.Name == "familyName"
+		})
+
+		claims, err := selection.Claims()
+
+		require.NoError(t, err)
+		subject := claims["vc"].(map[string]interface{})["credentialSubject"].(map[string]interface{})
+		assert.NotContains(t, subject, "givenName")
+		assert.Equal(t, "Doe", subject["familyName"])
+	})
+}
+
+func TestSDJWT_VerifiableCredential(t *testing.T) {
+	key := newKey(t)
+	sdJWT := issue(t, key, "givenName")
+
+	credential, err := sdJWT.VerifiableCredential()
+
+	require.NoError(t, err)
+	assert.Equal(t, "jwt_vc", credential.Format())
+	assert.Equal(t, sdJWT.IssuerSigned, credential.Raw())
+	assert.Equal(t, "did:web:example.com#123", credential.ID.String())
+	assert.Equal(t, "did:web:example.com", credential.Issuer.String())
+	require.Len(t, credential.CredentialSubject, 1)
+	assert.Equal(t, "did:web:example.com:holder", credential.CredentialSubject[0]["id"])
+	assert.Equal(t, "John", credential.CredentialSubject[0]["givenName"])
+	assert.NotContains(t, credential.CredentialSubject[0], "_sd")
+}
+
+func TestSDJWT_Bind(t *testing.T) {
+	issuerKey := newKey(t)
+	holderKey := newKey(t)
+	sdJWT := issue(t, issuerKey, "givenName", "familyName")
+	now := time.Now()
+	keyResolver := func(kid string) (crypto.PublicKey, error) {
+		assert.Equal(t, holderKID, kid)
+		return holderKey.Public(), nil
+	}
+
+	bound, err := sdJWT.Bind(context.Background(), signer(holderKey, holderKID), "https://example.com/verifier", "nonce", now)
+	require.NoError(t, err)
+
+	t.Run("ok", func(t *testing.T) {
+		parsed, err := Parse(bound.String())
+		require.NoError(t, err)
+
+		kid, token, err := parsed.VerifyKeyBinding(keyResolver, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, holderKID, kid)
+		assert.Equal(t, []string{"https://example.com/verifier"}, token.Audience())
+		assert.Equal(t, "nonce", token.PrivateClaims()["nonce"])
+	})
+	t.Run("disclosures altered after binding", func(t *testing.T) {
+		altered := bound.Select(func(disclosure Disclosure) bool {
+			return disclosure.Name == "givenName"
+		})
+		altered.KeyBinding = bound.KeyBinding
+
+		_, _, err := altered.VerifyKeyBinding(keyResolver, now)
+
+		assert.EqualError(t, err, "invalid key binding JWT: sd_hash does not match")
+	})
+	t.Run("signed by other key", func(t *testing.T) {
+		_, _, err := bound.VerifyKeyBinding(func(_ string) (crypto.PublicKey, error) {
+			return issuerKey.Public(), nil
+		}, now)
+
+		assert.ErrorContains(t, err, "invalid key binding JWT")
+	})
+	t.Run("issued in the future", func(t *testing.T) {
+		_, _, err := bound.VerifyKeyBinding(keyResolver, now.Add(-time.Minute))
+
+		assert.ErrorContains(t, err, "invalid key binding JWT")
+	})
+	t.Run("issued too long ago", func(t *testing.T) {
+		_, _, err := bound.VerifyKeyBinding(keyResolver, now.Add(keyBindingMaxAge+time.Minute))
+
+		assert.EqualError(t, err, "invalid key binding JWT: iat is too old")
+	})
+	t.Run("missing key binding", func(t *testing.T) {
+		_, _, err := sdJWT.VerifyKeyBinding(keyResolver, now)
+
+		assert.ErrorIs(t, err, ErrMissingKeyBinding)
+	})
+	t.Run("input is not altered", func(t *testing.T) {
+		assert.Empty(t, sdJWT.KeyBinding)
+		assert.True(t, strings.HasSuffix(sdJWT.String(), "~"))
+	})
+}
+
+func newKey(t *testing.T) *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return key
+}
+
+func signer(key *ecdsa.PrivateKey, kid string) func(ctx context.Context, claims map[string]interface{}, headers map[string]interface{}) (string, error) {
+	return func(_ context.Context, claims map[string]interface{}, headers map[string]interface{}) (string, error) {
+		token := jwt.New()
+		for name, value := range claims {
+			if err := token.Set(name, value); err != nil {
+				return "", err
+			}
+		}
+		hdrs := jws.NewHeaders()
+		for name, value := range headers {
+			if err := hdrs.Set(name, value); err != nil {
+				return "", err
+			}
+		}
+		_ = hdrs.Set(jws.KeyIDKey, kid)
+		signed, err := jwt.Sign(token, jwt.WithKey(jwa.ES256, key, jws.WithProtectedHeaders(hdrs)))
+		return string(signed), err
+	}
+}
+
+// issue creates an SD-JWT VC with the given credentialSubject claims being selectively disclosable.
+func issue(t *testing.T, key *ecdsa.PrivateKey, disclosable ...string) SDJWT {
+	credentialSubject := map[string]interface{}{
+		"givenName":  "John",
+		"familyName": "Doe",
+		"city":       "Utrecht",
+	}
+	concealed, disclosures, err := Conceal(credentialSubject, disclosable)
+	require.NoError(t, err)
+	claims := map[string]interface{}{
+		jwt.IssuerKey:      "did:web:example.com",
+		jwt.SubjectKey:     "did:web:example.com:holder",
+		jwt.JwtIDKey:       "did:web:example.com#123",
+		jwt.NotBeforeKey:   time.Now().Unix(),
+		hashAlgorithmClaim: hashAlgorithm,
+		"vc": map[string]interface{}{
+			"@context":          []string{"https://www.w3.org/2018/credentials/v1"},
+			"type":              []string{"VerifiableCredential", "ExampleCredential"},
+			"credentialSubject": concealed,
+		},
+	}
+	token, err := signer(key, issuerKID)(context.Background(), claims, map[string]interface{}{jws.TypeKey: Format})
+	require.NoError(t, err)
+	return SDJWT{IssuerSigned: token, Disclosures: disclosures}
+}
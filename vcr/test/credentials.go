@@ -20,6 +20,7 @@
 package test
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
@@ -27,6 +28,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"github.com/google/uuid"
 	"github.com/lestrrat-go/jwx/v2/cert"
 	"github.com/lestrrat-go/jwx/v2/jwa"
 	"github.com/lestrrat-go/jwx/v2/jws"
@@ -34,6 +36,7 @@ import (
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/nuts-node/test/pki"
 	"github.com/nuts-foundation/nuts-node/vcr/assets"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	"github.com/stretchr/testify/require"
 	"testing"
 	"time"
@@ -106,6 +109,46 @@ func JWTNutsOrganizationCredential(t *testing.T, subjectID did.DID) vc.Verifiabl
 	return *jwtVC
 }
 
+// SDJWTCredential creates an enveloped SD-JWT VC issued by the DID of the given key, signed with the given key.
+// The name and city in the credentialSubject are selectively disclosable.
+func SDJWTCredential(t *testing.T, issuerKeyID string, issuerKey *ecdsa.PrivateKey, subjectID did.DID) vc.VerifiableCredential {
+	issuerDID := did.MustParseDIDURL(issuerKeyID).DID
+	credentialID := ssi.MustParseURI(issuerDID.String() + "#" + uuid.NewString())
+	template := vc.VerifiableCredential{
+		Context:      []ssi.URI{vc.VCContextV1URI(), ssi.MustParseURI("https://schema.org")},
+		ID:           &credentialID,
+		Type:         []ssi.URI{vc.VerifiableCredentialTypeV1URI(), ssi.MustParseURI("ExampleCredential")},
+		Issuer:       issuerDID.URI(),
+		IssuanceDate: time.Now().Add(-time.Minute).Truncate(time.Second),
+		CredentialSubject: []map[string]any{{
+			"id":   subjectID.String(),
+			"name": "care",
+			"city": "IJbergen",
+		}},
+	}
+	sdJWT, err := sdjwt.CreateVerifiableCredential(context.Background(), template, []string{"name", "city"}, func(_ context.Context, claims map[string]interface{}, headers map[string]interface{}) (string, error) {
+		token := jwt.New()
+		for name, value := range claims {
+			if err := token.Set(name, value); err != nil {
+				return "", err
+			}
+		}
+		hdrs := jws.NewHeaders()
+		for name, value := range headers {
+			if err := hdrs.Set(name, value); err != nil {
+				return "", err
+			}
+		}
+		_ = hdrs.Set(jws.KeyIDKey, issuerKeyID)
+		signed, err := jwt.Sign(token, jwt.WithKey(jwa.ES256, issuerKey, jws.WithProtectedHeaders(hdrs)))
+		return string(signed), err
+	})
+	require.NoError(t, err)
+	envelope, err := sdjwt.Envelope(*sdJWT)
+	require.NoError(t, err)
+	return *envelope
+}
+
 func ValidStatusList2021Credential(t testing.TB) vc.VerifiableCredential {
 	if t == nil {
 		panic("can only be used in tests")
@@ -133,6 +176,34 @@ func ValidStatusList2021Credential(t testing.TB) vc.VerifiableCredential {
 	}
 }
 
+// ValidBitstringStatusListCredential returns a BitstringStatusListCredential with statusPurpose 'suspension' and bit 1 set.
+func ValidBitstringStatusListCredential(t testing.TB) vc.VerifiableCredential {
+	if t == nil {
+		panic("can only be used in tests")
+	}
+	id := ssi.MustParseURI("https://example.com/credentials/status/4")
+	issuanceDate := time.Now()
+	expirationDate := issuanceDate.Add(24 * time.Hour)
+	return vc.VerifiableCredential{
+		Context:          []ssi.URI{vc.VCContextV1URI(), ssi.MustParseURI("https://www.w3.org/ns/credentials/status/v1")},
+		ID:               &id,
+		Type:             []ssi.URI{vc.VerifiableCredentialTypeV1URI(), ssi.MustParseURI("BitstringStatusListCredential")},
+		Issuer:           ssi.MustParseURI("did:example:12345"),
+		IssuanceDate:     issuanceDate,
+		ExpirationDate:   &expirationDate,
+		CredentialStatus: nil,
+		CredentialSubject: []map[string]any{
+			{
+				"id":            "https://example-com/status/4#list",
+				"type":          "BitstringStatusList",
+				"statusPurpose": "suspension",
+				"encodedList":   "uH4sIAAAAAAAA_-zAsQAAAAACsNDypwqjZ2sAAAAAAAAAAAAAAAAAAACAtwUAAP__NxdfzQBAAAA", // has bit 1 set to true
+			},
+		},
+		Proof: []any{vc.Proof{}},
+	}
+}
+
 type credentialOption func(*jwt.Builder) *jwt.Builder
 
 func ValidX509Credential(t *testing.T, options ...credentialOption) vc.VerifiableCredential {
@@ -35,6 +35,12 @@ var ErrMultipleFound = errors.New("multiple found")
 // ErrRevoked is returned when a credential has been revoked and the required action requires it to not be revoked.
 var ErrRevoked = errors.New("credential is revoked")
 
+// ErrSuspended is returned when a credential has been suspended and the required action requires it to not be suspended.
+var ErrSuspended = errors.New("credential is suspended")
+
+// ErrNotSuspended is returned when a credential is unsuspended, but it is not suspended.
+var ErrNotSuspended = errors.New("credential is not suspended")
+
 // ErrUntrusted is returned when a credential is resolved or searched but its issuer is not trusted.
 var ErrUntrusted = errors.New("credential issuer is untrusted")
 
@@ -230,8 +230,9 @@ func (c *vcr) Configure(config core.ServerConfig) error {
 	}
 
 	status := revocation.NewStatusList2021(c.storageClient.GetSQLDatabase(), client.NewWithCache(config.HTTPClient.Timeout), config.URL)
-	c.issuer = issuer.NewIssuer(c.issuerStore, c, networkPublisher, openidHandlerFn, didResolver, c.keyStore, c.jsonldManager, c.trustConfig, status)
-	c.verifier = verifier.NewVerifier(c.verifierStore, didResolver, c.keyResolver, c.jsonldManager, c.trustConfig, status, c.pkiProvider)
+	bitstringStatus := revocation.NewBitstringStatusList(c.storageClient.GetSQLDatabase(), client.NewWithCache(config.HTTPClient.Timeout), config.URL)
+	c.issuer = issuer.NewIssuer(c.issuerStore, c, networkPublisher, openidHandlerFn, didResolver, c.keyStore, c.jsonldManager, c.trustConfig, status, bitstringStatus)
+	c.verifier = verifier.NewVerifier(c.verifierStore, didResolver, c.keyResolver, c.jsonldManager, c.trustConfig, status, bitstringStatus, c.pkiProvider, client.NewWithCache(config.HTTPClient.Timeout))
 
 	if !c.network.Disabled() {
 		c.ambassador = NewAmbassador(c.network, c, c.verifier, c.eventManager)
@@ -409,6 +410,8 @@ func (c *vcr) Resolve(ID ssi.URI, resolveTime *time.Time) (*vc.VerifiableCredent
 		switch err {
 		case types.ErrRevoked:
 			return &credential, types.ErrRevoked
+		case types.ErrSuspended:
+			return &credential, types.ErrSuspended
 		case types.ErrUntrusted:
 			return &credential, types.ErrUntrusted
 		default:
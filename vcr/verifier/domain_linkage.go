@@ -0,0 +1,101 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package verifier
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/vcr/credential"
+	"github.com/nuts-foundation/nuts-node/vcr/log"
+)
+
+// maxDIDConfigurationSize is the maximum size of a DID Configuration resource that will be read.
+const maxDIDConfigurationSize = 1024 * 1024
+
+// VerifyDomainLinkage implements the Verifier interface.
+func (v verifier) VerifyDomainLinkage(ctx context.Context, id did.DID, origin string) error {
+	origin, err := credential.ParseOrigin(origin)
+	if err != nil {
+		return fmt.Errorf("invalid origin: %w", err)
+	}
+	document, _, err := v.didResolver.Resolve(id, nil)
+	if err != nil {
+		return fmt.Errorf("unable to resolve DID: %w", err)
+	}
+	if !credential.IsLinkedToOrigin(*document, origin) {
+		return fmt.Errorf("DID document does not contain a %s service for origin %s", credential.LinkedDomainsServiceType, origin)
+	}
+	configuration, err := v.fetchDIDConfiguration(ctx, origin)
+	if err != nil {
+		return fmt.Errorf("unable to retrieve DID Configuration of %s: %w", origin, err)
+	}
+	for _, linkageCredential := range configuration.LinkedDIDs {
+		if linkageCredential.Issuer.String() != id.String() || !linkageCredential.IsType(credential.DomainLinkageCredentialTypeURI()) {
+			continue
+		}
+		var subjects []credential.DomainLinkageCredentialSubject
+		if err := linkageCredential.UnmarshalCredentialSubject(&subjects); err != nil || len(subjects) != 1 {
+			continue
+		}
+		if subjectOrigin, err := credential.ParseOrigin(subjects[0].Origin); err != nil || subjectOrigin != origin {
+			continue
+		}
+		if err := v.Verify(linkageCredential, true, true, nil); err != nil {
+			log.Logger().WithError(err).Debugf("Domain Linkage Credential is invalid (did=%s, origin=%s)", id, origin)
+			continue
+		}
+		return nil
+	}
+	return fmt.Errorf("DID Configuration of %s does not contain a valid Domain Linkage Credential for %s", origin, id)
+}
+
+func (v verifier) fetchDIDConfiguration(ctx context.Context, origin string) (*credential.DIDConfiguration, error) {
+	if v.httpClient == nil {
+		return nil, errors.New("no HTTP client configured")
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, origin+credential.DIDConfigurationWellKnownPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Accept", "application/json")
+	response, err := v.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if err = core.TestResponseCode(http.StatusOK, response); err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(io.LimitReader(response.Body, maxDIDConfigurationSize))
+	if err != nil {
+		return nil, err
+	}
+	var result credential.DIDConfiguration
+	if err = json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("invalid DID Configuration: %w", err)
+	}
+	return &result, nil
+}
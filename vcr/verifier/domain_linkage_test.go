@@ -0,0 +1,150 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package verifier
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/audit"
+	nutsCrypto "github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/nuts-foundation/nuts-node/jsonld"
+	"github.com/nuts-foundation/nuts-node/vcr/credential"
+	"github.com/nuts-foundation/nuts-node/vdr/resolver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestVerifier_VerifyDomainLinkage(t *testing.T) {
+	const kid = "did:web:example.com#0"
+	id := did.MustParseDID("did:web:example.com")
+	keyStore := nutsCrypto.NewMemoryCryptoInstance(t)
+	_, publicKey, err := keyStore.New(audit.TestContext(), func(_ crypto.PublicKey) (string, error) {
+		return kid, nil
+	})
+	require.NoError(t, err)
+	var configuration credential.DIDConfiguration
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path != credential.DIDConfigurationWellKnownPath || configuration.LinkedDIDs == nil {
+			writer.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(writer).Encode(configuration)
+	}))
+	t.Cleanup(server.Close)
+	origin := server.URL
+	document := &did.Document{
+		ID:      id,
+		Service: []did.Service{{Type: credential.LinkedDomainsServiceType, ServiceEndpoint: origin}},
+	}
+	linkageCredential := func(t *testing.T, origin string) vc.VerifiableCredential {
+		expirationDate := time.Now().Add(time.Hour)
+		template := vc.VerifiableCredential{
+			Context:           []ssi.URI{vc.VCContextV1URI(), ssi.MustParseURI(jsonld.DIDConfigurationContext)},
+			Type:              []ssi.URI{vc.VerifiableCredentialTypeV1URI(), credential.DomainLinkageCredentialTypeURI()},
+			Issuer:            id.URI(),
+			IssuanceDate:      time.Now().Add(-time.Minute),
+			ExpirationDate:    &expirationDate,
+			CredentialSubject: []map[string]any{{"id": id.String(), "origin": origin}},
+		}
+		result, err := vc.CreateJWTVerifiableCredential(audit.TestContext(), template, func(ctx context.Context, claims map[string]interface{}, headers map[string]interface{}) (string, error) {
+			return keyStore.SignJWT(ctx, claims, headers, kid)
+		})
+		require.NoError(t, err)
+		return *result
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		configuration = credential.NewDIDConfiguration([]vc.VerifiableCredential{linkageCredential(t, origin)})
+		ctx := newMockContext(t)
+		ctx.didResolver.EXPECT().Resolve(id, gomock.Any()).Return(document, nil, nil).AnyTimes()
+		ctx.keyResolver.EXPECT().ResolveKeyByID(kid, gomock.Any(), resolver.NutsSigningKeyType).Return(publicKey, nil)
+		ctx.store.EXPECT().GetRevocations(gomock.Any()).Return(nil, ErrNotFound).AnyTimes()
+
+		err := ctx.verifier.VerifyDomainLinkage(context.Background(), id, origin+"/")
+
+		assert.NoError(t, err)
+	})
+	t.Run("DID document does not list origin", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.didResolver.EXPECT().Resolve(id, gomock.Any()).Return(&did.Document{ID: id}, nil, nil)
+
+		err := ctx.verifier.VerifyDomainLinkage(context.Background(), id, origin)
+
+		assert.EqualError(t, err, "DID document does not contain a LinkedDomains service for origin "+origin)
+	})
+	t.Run("DID can't be resolved", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.didResolver.EXPECT().Resolve(id, gomock.Any()).Return(nil, nil, resolver.ErrNotFound)
+
+		err := ctx.verifier.VerifyDomainLinkage(context.Background(), id, origin)
+
+		assert.ErrorIs(t, err, resolver.ErrNotFound)
+	})
+	t.Run("DID Configuration not found", func(t *testing.T) {
+		configuration = credential.DIDConfiguration{}
+		ctx := newMockContext(t)
+		ctx.didResolver.EXPECT().Resolve(id, gomock.Any()).Return(document, nil, nil)
+
+		err := ctx.verifier.VerifyDomainLinkage(context.Background(), id, origin)
+
+		assert.ErrorContains(t, err, "unable to retrieve DID Configuration of "+origin)
+	})
+	t.Run("credential is for another origin", func(t *testing.T) {
+		configuration = credential.NewDIDConfiguration([]vc.VerifiableCredential{linkageCredential(t, "https://other.example.com")})
+		ctx := newMockContext(t)
+		ctx.didResolver.EXPECT().Resolve(id, gomock.Any()).Return(document, nil, nil)
+
+		err := ctx.verifier.VerifyDomainLinkage(context.Background(), id, origin)
+
+		assert.EqualError(t, err, "DID Configuration of "+origin+" does not contain a valid Domain Linkage Credential for did:web:example.com")
+	})
+	t.Run("invalid signature", func(t *testing.T) {
+		configuration = credential.NewDIDConfiguration([]vc.VerifiableCredential{linkageCredential(t, origin)})
+		otherKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		ctx := newMockContext(t)
+		ctx.didResolver.EXPECT().Resolve(id, gomock.Any()).Return(document, nil, nil).AnyTimes()
+		ctx.keyResolver.EXPECT().ResolveKeyByID(kid, gomock.Any(), resolver.NutsSigningKeyType).Return(otherKey.Public(), nil)
+		ctx.store.EXPECT().GetRevocations(gomock.Any()).Return(nil, ErrNotFound).AnyTimes()
+
+		err := ctx.verifier.VerifyDomainLinkage(context.Background(), id, origin)
+
+		assert.EqualError(t, err, "DID Configuration of "+origin+" does not contain a valid Domain Linkage Credential for did:web:example.com")
+	})
+	t.Run("invalid origin", func(t *testing.T) {
+		ctx := newMockContext(t)
+
+		err := ctx.verifier.VerifyDomainLinkage(context.Background(), id, "example.com")
+
+		assert.EqualError(t, err, "invalid origin: scheme must be https or http")
+	})
+}
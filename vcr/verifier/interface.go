@@ -19,6 +19,7 @@
 package verifier
 
 import (
+	"context"
 	"errors"
 	"io"
 	"time"
@@ -26,6 +27,7 @@ import (
 	"github.com/nuts-foundation/nuts-node/core"
 
 	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/go-did/vc"
 	"github.com/nuts-foundation/nuts-node/vcr/credential"
 )
@@ -56,6 +58,10 @@ type Verifier interface {
 	// It always checks whether the signer of the presentation is the holder of the presented credentials,
 	// but only if there are credentials in the presentation.
 	VerifyVP(presentation vc.VerifiablePresentation, verifyVCs bool, allowUntrustedVCs bool, validAt *time.Time) ([]vc.VerifiableCredential, error)
+	// VerifyDomainLinkage checks whether the DID is linked to the given origin, according to the DIF Well-Known DID Configuration specification.
+	// The DID document must list the origin in a LinkedDomains service, and the DID Configuration resource served by the origin
+	// must contain a valid Domain Linkage Credential issued by the DID for that origin.
+	VerifyDomainLinkage(ctx context.Context, id did.DID, origin string) error
 }
 
 // ErrNotFound is returned when a credential or revocation can not be found based on its ID.
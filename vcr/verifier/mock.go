@@ -10,10 +10,12 @@
 package verifier
 
 import (
+	context "context"
 	reflect "reflect"
 	time "time"
 
 	ssi "github.com/nuts-foundation/go-did"
+	did "github.com/nuts-foundation/go-did/did"
 	vc "github.com/nuts-foundation/go-did/vc"
 	core "github.com/nuts-foundation/nuts-node/core"
 	credential "github.com/nuts-foundation/nuts-node/vcr/credential"
@@ -102,6 +104,20 @@ func (mr *MockVerifierMockRecorder) Verify(arg0, allowUntrusted, checkSignature,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockVerifier)(nil).Verify), arg0, allowUntrusted, checkSignature, validAt)
 }
 
+// VerifyDomainLinkage mocks base method.
+func (m *MockVerifier) VerifyDomainLinkage(ctx context.Context, id did.DID, origin string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyDomainLinkage", ctx, id, origin)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyDomainLinkage indicates an expected call of VerifyDomainLinkage.
+func (mr *MockVerifierMockRecorder) VerifyDomainLinkage(ctx, id, origin any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyDomainLinkage", reflect.TypeOf((*MockVerifier)(nil).VerifyDomainLinkage), ctx, id, origin)
+}
+
 // VerifySignature mocks base method.
 func (m *MockVerifier) VerifySignature(credentialToVerify vc.VerifiableCredential, at *time.Time) error {
 	m.ctrl.T.Helper()
@@ -30,6 +30,7 @@ import (
 	"github.com/nuts-foundation/nuts-node/crypto"
 	"github.com/nuts-foundation/nuts-node/jsonld"
 	"github.com/nuts-foundation/nuts-node/vcr/credential"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	"github.com/nuts-foundation/nuts-node/vcr/signature"
 	"github.com/nuts-foundation/nuts-node/vcr/signature/proof"
 	"github.com/nuts-foundation/nuts-node/vcr/types"
@@ -44,7 +45,15 @@ type signatureVerifier struct {
 var ExtractProtectedHeaders = crypto.ExtractProtectedHeaders
 
 // VerifySignature checks if the signature on a VP is valid at a given time
+// Enveloped SD-JWT VCs are verified by verifying the issuer-signed JWT and the disclosures.
 func (sv *signatureVerifier) VerifySignature(credentialToVerify vc.VerifiableCredential, validateAt *time.Time) error {
+	if sdjwt.IsEnveloped(credentialToVerify) {
+		expanded, err := sdjwt.Expand(credentialToVerify)
+		if err != nil {
+			return newVerificationError("invalid SD-JWT VC: %w", err)
+		}
+		credentialToVerify = *expanded
+	}
 	switch credentialToVerify.Format() {
 	case vc.JSONLDCredentialProofFormat:
 		return sv.jsonldProof(credentialToVerify, credentialToVerify.Issuer.String(), validateAt)
@@ -19,12 +19,15 @@
 package verifier
 
 import (
+	crypt "crypto"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/nuts-foundation/nuts-node/core"
 	"github.com/nuts-foundation/nuts-node/pki"
 	"github.com/nuts-foundation/nuts-node/vcr/revocation"
 
@@ -34,6 +37,7 @@ import (
 	"github.com/nuts-foundation/nuts-node/jsonld"
 	"github.com/nuts-foundation/nuts-node/vcr/credential"
 	"github.com/nuts-foundation/nuts-node/vcr/log"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	"github.com/nuts-foundation/nuts-node/vcr/signature"
 	"github.com/nuts-foundation/nuts-node/vcr/signature/proof"
 	"github.com/nuts-foundation/nuts-node/vcr/trust"
@@ -57,8 +61,10 @@ type verifier struct {
 	store         Store
 	trustConfig   *trust.Config
 	signatureVerifier
-	credentialStatus revocation.StatusList2021Verifier
-	pkiValidator     pki.Validator
+	credentialStatus    revocation.StatusList2021Verifier
+	bitstringStatusList revocation.BitstringStatusListVerifier
+	pkiValidator        pki.Validator
+	httpClient          core.HTTPRequestDoer
 }
 
 // VerificationError is used to describe a VC/VP verification failure.
@@ -90,22 +96,32 @@ func (e VerificationError) Error() string {
 }
 
 // NewVerifier creates a new instance of the verifier. It needs a key resolver for validating signatures.
-func NewVerifier(store Store, didResolver resolver.DIDResolver, keyResolver resolver.KeyResolver, jsonldManager jsonld.JSONLD, trustConfig *trust.Config, credentialStatus *revocation.StatusList2021, pkiValidator pki.Validator) Verifier {
+// The HTTP client is used to retrieve DID Configuration resources when verifying domain linkage.
+func NewVerifier(store Store, didResolver resolver.DIDResolver, keyResolver resolver.KeyResolver, jsonldManager jsonld.JSONLD, trustConfig *trust.Config, credentialStatus *revocation.StatusList2021, bitstringStatusList *revocation.BitstringStatusList, pkiValidator pki.Validator, httpClient core.HTTPRequestDoer) Verifier {
 	v := &verifier{store: store, didResolver: didResolver, keyResolver: keyResolver, jsonldManager: jsonldManager, trustConfig: trustConfig,
 		signatureVerifier: signatureVerifier{
 			keyResolver:   keyResolver,
 			jsonldManager: jsonldManager,
 		},
-		credentialStatus: credentialStatus,
-		pkiValidator:     pkiValidator,
+		credentialStatus:    credentialStatus,
+		bitstringStatusList: bitstringStatusList,
+		pkiValidator:        pkiValidator,
+		httpClient:          httpClient,
 	}
 	credentialStatus.VerifySignature = v.VerifySignature
+	bitstringStatusList.VerifySignature = v.VerifySignature
 	return v
 }
 
 // Verify implements the verify interface.
 // It currently checks if the credential has the required fields and values, if it is valid at the given time and optional the signature.
+// Enveloped SD-JWT VCs are verified using the claims that are disclosed.
 func (v verifier) Verify(credentialToVerify vc.VerifiableCredential, allowUntrusted bool, checkSignature bool, validAt *time.Time) error {
+	expanded, err := sdjwt.Expand(credentialToVerify)
+	if err != nil {
+		return newVerificationError("invalid SD-JWT VC: %w", err)
+	}
+	credentialToVerify = *expanded
 	// it must have valid content
 	validator := credential.FindValidator(credentialToVerify, v.pkiValidator)
 	if err := validator.Validate(credentialToVerify); err != nil {
@@ -128,6 +144,15 @@ func (v verifier) Verify(credentialToVerify vc.VerifiableCredential, allowUntrus
 	if rev != nil {
 		return types.ErrRevoked
 	}
+	// Check suspension status
+	err = v.bitstringStatusList.Verify(credentialToVerify)
+	if errors.Is(err, types.ErrSuspended) {
+		return types.ErrSuspended
+	} else if err != nil {
+		// soft fail, only return an error when suspension is confirmed and log everything else
+		bs, _ := json.Marshal(credentialToVerify)
+		log.Logger().WithError(err).WithField("credential", string(bs)).Info("credential suspension verification failed")
+	}
 	// Check trust status
 	if !allowUntrusted {
 		for _, t := range credentialToVerify.Type {
@@ -186,6 +211,11 @@ func (v *verifier) IsRevoked(credentialID ssi.URI) (bool, error) {
 }
 
 func (v *verifier) GetRevocation(cred vc.VerifiableCredential) (*credential.Revocation, error) {
+	expanded, err := sdjwt.Expand(cred)
+	if err != nil {
+		return nil, err
+	}
+	cred = *expanded
 	if cred.ID != nil {
 		var rev []*credential.Revocation
 		rev, err := v.store.GetRevocations(*cred.ID)
@@ -199,7 +229,14 @@ func (v *verifier) GetRevocation(cred vc.VerifiableCredential) (*credential.Revo
 		}
 	}
 	// Check the credentialStatus if the credential is revoked
-	err := v.credentialStatus.Verify(cred)
+	err = v.credentialStatus.Verify(cred)
+	if err == nil {
+		// a suspension is not a revocation, it is checked separately in Verify()
+		err = v.bitstringStatusList.Verify(cred)
+		if errors.Is(err, types.ErrSuspended) {
+			err = nil
+		}
+	}
 	if errors.Is(err, types.ErrRevoked) {
 		// revoked
 		return &credential.Revocation{
@@ -270,11 +307,19 @@ func (v verifier) VerifyVP(vp vc.VerifiablePresentation, verifyVCs bool, allowUn
 
 // doVerifyVP delegates VC verification to the supplied Verifier, to aid unit testing.
 func (v verifier) doVerifyVP(vcVerifier Verifier, presentation vc.VerifiablePresentation, verifyVCs bool, allowUntrustedVCs bool, validAt *time.Time) ([]vc.VerifiableCredential, error) {
+	// SD-JWT VCs are enveloped: verify their key binding and continue with the credentials they contain.
+	credentials, err := v.openSDJWTCredentials(presentation, validAt)
+	if err != nil {
+		return nil, err
+	}
+	expandedPresentation := presentation
+	expandedPresentation.VerifiableCredential = credentials
+
 	// custom requirement: credentials may only be presented by subject
-	subjectDID, err := credential.PresenterIsCredentialSubject(presentation)
+	subjectDID, err := credential.PresenterIsCredentialSubject(expandedPresentation)
 	if err != nil {
 		return nil, newVerificationError("presenter is credential subject: %w", err)
-	} else if subjectDID == nil && len(presentation.VerifiableCredential) > 0 {
+	} else if subjectDID == nil && len(credentials) > 0 {
 		return nil, newVerificationError("credential(s) must be presented by subject")
 	}
 
@@ -291,7 +336,7 @@ func (v verifier) doVerifyVP(vcVerifier Verifier, presentation vc.VerifiablePres
 	}
 
 	if verifyVCs {
-		for _, current := range presentation.VerifiableCredential {
+		for _, current := range credentials {
 			checkSignature := true
 			if presentation.Holder != nil && presentation.Holder.String() == current.Issuer.String() {
 				// self-attested VC: https://www.w3.org/TR/vc-data-model-2.0/#presentations-including-holder-claims
@@ -305,5 +350,54 @@ func (v verifier) doVerifyVP(vcVerifier Verifier, presentation vc.VerifiablePres
 		}
 	}
 
-	return presentation.VerifiableCredential, nil
+	return credentials, nil
+}
+
+// openSDJWTCredentials returns the credentials of the presentation, with enveloped SD-JWT VCs replaced by the credentials they contain.
+// It verifies the key binding JWT of each SD-JWT VC: it must be signed by the credential subject and bound to the presentation's audience and nonce.
+// SD-JWT VCs are only supported in JWT presentations.
+func (v verifier) openSDJWTCredentials(presentation vc.VerifiablePresentation, validAt *time.Time) ([]vc.VerifiableCredential, error) {
+	result := make([]vc.VerifiableCredential, len(presentation.VerifiableCredential))
+	for i, current := range presentation.VerifiableCredential {
+		result[i] = current
+		if !sdjwt.IsEnveloped(current) {
+			continue
+		}
+		if presentation.Format() != vc.JWTPresentationProofFormat {
+			return nil, newVerificationError("SD-JWT VCs are only supported in %s presentations", vc.JWTPresentationProofFormat)
+		}
+		sdJWT, err := sdjwt.Open(current)
+		if err != nil {
+			return nil, newVerificationError("invalid SD-JWT VC: %w", err)
+		}
+		expanded, err := sdJWT.VerifiableCredential()
+		if err != nil {
+			return nil, newVerificationError("invalid SD-JWT VC: %w", err)
+		}
+		at := time.Now()
+		if validAt != nil {
+			at = *validAt
+		}
+		kid, keyBinding, err := sdJWT.VerifyKeyBinding(func(kid string) (crypt.PublicKey, error) {
+			return v.keyResolver.ResolveKeyByID(kid, &resolver.ResolveMetadata{ResolveTime: validAt}, resolver.NutsSigningKeyType)
+		}, at)
+		if err != nil {
+			return nil, newVerificationError("invalid SD-JWT VC (id=%s): %w", expanded.ID, err)
+		}
+		subjectDID, err := expanded.SubjectDID()
+		if err != nil {
+			return nil, newVerificationError("invalid SD-JWT VC (id=%s): %w", expanded.ID, err)
+		}
+		if strings.Split(kid, "#")[0] != subjectDID.String() {
+			return nil, newVerificationError("invalid SD-JWT VC (id=%s): key binding JWT must be signed by credential subject", expanded.ID)
+		}
+		presentationToken := presentation.JWT()
+		presentationNonce, _ := presentationToken.PrivateClaims()["nonce"].(string)
+		keyBindingNonce, _ := keyBinding.PrivateClaims()["nonce"].(string)
+		if keyBindingNonce != presentationNonce || !slices.Equal(keyBinding.Audience(), presentationToken.Audience()) {
+			return nil, newVerificationError("invalid SD-JWT VC (id=%s): key binding JWT audience or nonce does not match presentation", expanded.ID)
+		}
+		result[i] = *expanded
+	}
+	return result, nil
 }
@@ -21,6 +21,9 @@ package verifier
 import (
 	"context"
 	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -40,12 +43,15 @@ import (
 	ssi "github.com/nuts-foundation/go-did"
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/go-did/vc"
+	"github.com/nuts-foundation/nuts-node/audit"
+	nutsCrypto "github.com/nuts-foundation/nuts-node/crypto"
 	"github.com/nuts-foundation/nuts-node/crypto/storage/spi"
 	"github.com/nuts-foundation/nuts-node/jsonld"
 	"github.com/nuts-foundation/nuts-node/storage"
 	"github.com/nuts-foundation/nuts-node/test/io"
 	"github.com/nuts-foundation/nuts-node/vcr/credential"
 	"github.com/nuts-foundation/nuts-node/vcr/revocation"
+	"github.com/nuts-foundation/nuts-node/vcr/sdjwt"
 	"github.com/nuts-foundation/nuts-node/vcr/signature/proof"
 	"github.com/nuts-foundation/nuts-node/vcr/test"
 	"github.com/nuts-foundation/nuts-node/vcr/trust"
@@ -93,6 +99,34 @@ func TestVerifier_Verify(t *testing.T) {
 		assert.EqualError(t, err, "unable to resolve valid signing key: not found")
 	})
 
+	t.Run("SD-JWT VC", func(t *testing.T) {
+		const issuerKeyID = "did:web:example.com#1"
+		issuerKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		credential := test.SDJWTCredential(t, issuerKeyID, issuerKey, did.MustParseDID("did:web:example.com:holder"))
+
+		t.Run("ok", func(t *testing.T) {
+			ctx := newMockContext(t)
+			ctx.keyResolver.EXPECT().ResolveKeyByID(issuerKeyID, gomock.Any(), resolver.NutsSigningKeyType).Return(issuerKey.Public(), nil)
+			ctx.store.EXPECT().GetRevocations(gomock.Any()).Return(nil, ErrNotFound)
+			ctx.didResolver.EXPECT().Resolve(did.MustParseDID("did:web:example.com"), gomock.Any()).Return(nil, nil, nil)
+
+			err := ctx.verifier.Verify(credential, true, true, nil)
+
+			assert.NoError(t, err)
+		})
+		t.Run("invalid signature", func(t *testing.T) {
+			otherKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			ctx := newMockContext(t)
+			ctx.keyResolver.EXPECT().ResolveKeyByID(issuerKeyID, gomock.Any(), resolver.NutsSigningKeyType).Return(otherKey.Public(), nil)
+			ctx.store.EXPECT().GetRevocations(gomock.Any()).Return(nil, ErrNotFound)
+			ctx.didResolver.EXPECT().Resolve(did.MustParseDID("did:web:example.com"), gomock.Any()).Return(nil, nil, nil)
+
+			err := ctx.verifier.Verify(credential, true, true, nil)
+
+			assert.ErrorContains(t, err, "unable to validate JWT signature")
+		})
+	})
+
 	// Verify calls other verifiers / validators.
 	// These test do not try to be complete, only test the calling of these validators and the error handling.
 
@@ -224,6 +258,61 @@ func TestVerifier_Verify(t *testing.T) {
 		})
 	})
 
+	t.Run("validate BitstringStatusListEntry", func(t *testing.T) {
+		// Test server serving a 'suspension' BitstringStatusListCredential with bit 1 set
+		var statusListCredBytes []byte
+		ts := httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			writer.Write(statusListCredBytes)
+		}))
+		defer ts.Close()
+		statusListCred := test.ValidBitstringStatusListCredential(t)
+		statusListCred.CredentialSubject[0]["id"] = ts.URL
+		statusListCredBytes, _ = json.Marshal(statusListCred)
+
+		ctx := newMockContext(t)
+		ctx.store.EXPECT().GetRevocations(gomock.Any()).Return([]*credential.Revocation{}, ErrNotFound).AnyTimes()
+		db := storage.NewTestStorageEngine(t).GetSQLDatabase()
+		bitstringStatusList := revocation.NewBitstringStatusList(db, ts.Client(), "https://example.com")
+		bitstringStatusList.VerifySignature = func(_ vc.VerifiableCredential, _ *time.Time) error { return nil } // don't check signatures on 'downloaded' BitstringStatusListCredentials
+		ctx.verifier.bitstringStatusList = bitstringStatusList
+
+		slEntry := revocation.BitstringStatusListEntry{
+			ID:                   ts.URL + "#1",
+			Type:                 revocation.BitstringStatusListEntryType,
+			StatusPurpose:        revocation.StatusPurposeSuspension,
+			StatusListIndex:      "1",
+			StatusListCredential: ts.URL,
+		}
+		cred := test.ValidNutsOrganizationCredential(t)
+		cred.Context = append(cred.Context, revocation.BitstringStatusListContextURI)
+
+		t.Run("is suspended", func(t *testing.T) {
+			cred.CredentialStatus = []any{slEntry}
+
+			validationErr := ctx.verifier.Verify(cred, true, false, nil)
+
+			assert.ErrorIs(t, validationErr, types.ErrSuspended)
+		})
+		t.Run("suspension is not a revocation", func(t *testing.T) {
+			cred.CredentialStatus = []any{slEntry}
+
+			rev, err := ctx.verifier.GetRevocation(cred)
+
+			assert.NoError(t, err)
+			assert.Nil(t, rev)
+		})
+		t.Run("not suspended", func(t *testing.T) {
+			slEntry := slEntry
+			slEntry.ID = ts.URL + "#2"
+			slEntry.StatusListIndex = "2"
+			cred.CredentialStatus = []any{slEntry}
+
+			validationErr := ctx.verifier.Verify(cred, true, false, nil)
+
+			assert.NoError(t, validationErr)
+		})
+	})
+
 	t.Run("trust check", func(t *testing.T) {
 		t.Run("trusted", func(t *testing.T) {
 			vc := testCredential(t)
@@ -527,6 +616,116 @@ func TestVerifier_VerifyVP(t *testing.T) {
 				assert.Len(t, vcs, 1)
 			})
 		})
+		t.Run("SD-JWT VC", func(t *testing.T) {
+			holderDID := did.MustParseDID("did:web:example.com:holder")
+			holderKeyID := holderDID.String() + "#1"
+			keyStore := nutsCrypto.NewMemoryCryptoInstance(t)
+			_, holderKey, err := keyStore.New(audit.TestContext(), nutsCrypto.StringNamingFunc(holderKeyID))
+			require.NoError(t, err)
+			issuerKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			credential := test.SDJWTCredential(t, "did:web:example.com#1", issuerKey, holderDID)
+			const audience = "https://example.com/verifier"
+			const nonce = "nonce"
+			// presentSDJWT creates a JWT VP containing the given SD-JWT VC
+			presentSDJWT := func(t *testing.T, sdJWT sdjwt.SDJWT) vc.VerifiablePresentation {
+				envelope, err := sdjwt.Envelope(sdJWT)
+				require.NoError(t, err)
+				claims := map[string]interface{}{
+					jwt.IssuerKey:     holderDID.String(),
+					jwt.SubjectKey:    holderDID.String(),
+					jwt.AudienceKey:   audience,
+					jwt.NotBeforeKey:  time.Now().Unix(),
+					jwt.ExpirationKey: time.Now().Add(5 * time.Second).Unix(),
+					"nonce":           nonce,
+					"vp": vc.VerifiablePresentation{
+						Type:                 []ssi.URI{vc.VerifiablePresentationTypeV1URI()},
+						VerifiableCredential: []vc.VerifiableCredential{*envelope},
+					},
+				}
+				signedToken, err := keyStore.SignJWT(audit.TestContext(), claims, map[string]interface{}{"typ": "JWT"}, holderKeyID)
+				require.NoError(t, err)
+				result, err := vc.ParseVerifiablePresentation(signedToken)
+				require.NoError(t, err)
+				return *result
+			}
+			// createPresentation creates a JWT VP containing the SD-JWT VC, with a key binding JWT for the given audience and nonce
+			createPresentation := func(t *testing.T, keyBindingKeyID string, keyBindingAudience string, keyBindingNonce string) vc.VerifiablePresentation {
+				sdJWT, err := sdjwt.Open(credential)
+				require.NoError(t, err)
+				bound, err := sdJWT.Bind(audit.TestContext(), func(ctx context.Context, claims map[string]interface{}, headers map[string]interface{}) (string, error) {
+					return keyStore.SignJWT(ctx, claims, headers, keyBindingKeyID)
+				}, keyBindingAudience, keyBindingNonce, time.Now())
+				require.NoError(t, err)
+				return presentSDJWT(t, *bound)
+			}
+			t.Run("ok", func(t *testing.T) {
+				presentation := createPresentation(t, holderKeyID, audience, nonce)
+				ctx := newMockContext(t)
+				ctx.keyResolver.EXPECT().ResolveKeyByID(holderKeyID, gomock.Any(), resolver.NutsSigningKeyType).Return(holderKey, nil).Times(2)
+				mockVerifier := NewMockVerifier(ctx.ctrl)
+				mockVerifier.EXPECT().Verify(gomock.Any(), false, true, nil)
+
+				vcs, err := ctx.verifier.doVerifyVP(mockVerifier, presentation, true, false, nil)
+
+				require.NoError(t, err)
+				require.Len(t, vcs, 1)
+				assert.Equal(t, vc.JWTCredentialProofFormat, vcs[0].Format(), "expected the credential contained in the SD-JWT VC")
+				assert.Equal(t, "care", vcs[0].CredentialSubject[0]["name"])
+			})
+			t.Run("key binding JWT for other nonce", func(t *testing.T) {
+				presentation := createPresentation(t, holderKeyID, audience, "other")
+				ctx := newMockContext(t)
+				ctx.keyResolver.EXPECT().ResolveKeyByID(holderKeyID, gomock.Any(), resolver.NutsSigningKeyType).Return(holderKey, nil)
+
+				vcs, err := ctx.verifier.VerifyVP(presentation, true, false, nil)
+
+				assert.ErrorContains(t, err, "key binding JWT audience or nonce does not match presentation")
+				assert.Empty(t, vcs)
+			})
+			t.Run("key binding JWT for other audience", func(t *testing.T) {
+				presentation := createPresentation(t, holderKeyID, "https://example.com/other", nonce)
+				ctx := newMockContext(t)
+				ctx.keyResolver.EXPECT().ResolveKeyByID(holderKeyID, gomock.Any(), resolver.NutsSigningKeyType).Return(holderKey, nil)
+
+				vcs, err := ctx.verifier.VerifyVP(presentation, true, false, nil)
+
+				assert.ErrorContains(t, err, "key binding JWT audience or nonce does not match presentation")
+				assert.Empty(t, vcs)
+			})
+			t.Run("key binding JWT not signed by credential subject", func(t *testing.T) {
+				const otherKeyID = "did:web:example.com:other#1"
+				_, otherKey, err := keyStore.New(audit.TestContext(), nutsCrypto.StringNamingFunc(otherKeyID))
+				require.NoError(t, err)
+				presentation := createPresentation(t, otherKeyID, audience, nonce)
+				ctx := newMockContext(t)
+				ctx.keyResolver.EXPECT().ResolveKeyByID(otherKeyID, gomock.Any(), resolver.NutsSigningKeyType).Return(otherKey, nil)
+
+				vcs, err := ctx.verifier.VerifyVP(presentation, true, false, nil)
+
+				assert.ErrorContains(t, err, "key binding JWT must be signed by credential subject")
+				assert.Empty(t, vcs)
+			})
+			t.Run("missing key binding JWT", func(t *testing.T) {
+				sdJWT, err := sdjwt.Open(credential)
+				require.NoError(t, err)
+				presentation := presentSDJWT(t, *sdJWT)
+				ctx := newMockContext(t)
+
+				vcs, err := ctx.verifier.VerifyVP(presentation, true, false, nil)
+
+				assert.ErrorContains(t, err, sdjwt.ErrMissingKeyBinding.Error())
+				assert.Empty(t, vcs)
+			})
+			t.Run("SD-JWT VC in JSON-LD presentation", func(t *testing.T) {
+				presentation := test.CreateJSONLDPresentation(t, holderDID, nil, credential)
+				ctx := newMockContext(t)
+
+				vcs, err := ctx.verifier.VerifyVP(presentation, true, false, nil)
+
+				assert.ErrorContains(t, err, "SD-JWT VCs are only supported in jwt_vp presentations")
+				assert.Empty(t, vcs)
+			})
+		})
 		t.Run("holder != subject", func(t *testing.T) {
 			presentationWithHolder := *presentation
 			presentationWithHolder.Holder, _ = ssi.ParseURI("other")
@@ -855,7 +1054,7 @@ func newMockContext(t *testing.T) mockContext {
 	trustConfig := trust.NewConfig(path.Join(io.TestDirectory(t), "trust.yaml"))
 	db := orm.NewTestDatabase(t)
 
-	verifier := NewVerifier(verifierStore, didResolver, keyResolver, jsonldManager, trustConfig, revocation.NewStatusList2021(db, nil, ""), nil).(*verifier)
+	verifier := NewVerifier(verifierStore, didResolver, keyResolver, jsonldManager, trustConfig, revocation.NewStatusList2021(db, nil, ""), revocation.NewBitstringStatusList(db, nil, ""), nil, http.DefaultClient).(*verifier)
 
 	return mockContext{
 		ctrl:        ctrl,
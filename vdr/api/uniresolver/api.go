@@ -0,0 +1,243 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package uniresolver
+
+import (
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/vdr"
+	"github.com/nuts-foundation/nuts-node/vdr/log"
+	"github.com/nuts-foundation/nuts-node/vdr/resolver"
+)
+
+// ResolvePath is the path of the DID resolution endpoint, as specified by the DIF Universal Resolver driver contract.
+// The DID is appended to the path.
+const ResolvePath = "/1.0/identifiers/"
+
+const (
+	// DIDDocumentContentType is the media type of the JSON-LD representation of a DID document.
+	DIDDocumentContentType = "application/did+ld+json"
+	// ResolutionResultContentType is the media type of a DID resolution result.
+	ResolutionResultContentType = `application/ld+json;profile="https://w3id.org/did-resolution"`
+	resolutionResultProfile     = "https://w3id.org/did-resolution"
+	resolutionResultContext     = "https://w3id.org/did-resolution/v1"
+)
+
+// DID resolution errors, see https://www.w3.org/TR/did-resolution/#errors
+const (
+	errorInvalidDID                 = "invalidDid"
+	errorNotFound                   = "notFound"
+	errorRepresentationNotSupported = "representationNotSupported"
+	errorMethodNotSupported         = "methodNotSupported"
+	errorInternal                   = "internalError"
+)
+
+var _ core.Routable = (*Wrapper)(nil)
+
+// Wrapper implements the HTTP driver endpoint of the DIF Universal Resolver (https://github.com/decentralized-identity/universal-resolver),
+// so the node can be used as DID resolver by systems that don't implement the Nuts APIs.
+// It resolves DIDs of all DID methods supported by the node.
+type Wrapper struct {
+	VDR vdr.VDR
+}
+
+// ResolutionResult is the DID resolution result as specified by https://www.w3.org/TR/did-resolution/#did-resolution-result
+type ResolutionResult struct {
+	Context            string             `json:"@context"`
+	Document           *did.Document      `json:"didDocument"`
+	ResolutionMetadata ResolutionMetadata `json:"didResolutionMetadata"`
+	DocumentMetadata   DocumentMetadata   `json:"didDocumentMetadata"`
+}
+
+// ResolutionMetadata contains the DID resolution metadata, see https://www.w3.org/TR/did-core/#did-resolution-metadata
+type ResolutionMetadata struct {
+	ContentType  string `json:"contentType,omitempty"`
+	Error        string `json:"error,omitempty"`
+	ErrorMessage string `json:"errorMessage,omitempty"`
+}
+
+// DocumentMetadata contains the DID document metadata, see https://www.w3.org/TR/did-core/#did-document-metadata
+type DocumentMetadata struct {
+	Created     string `json:"created,omitempty"`
+	Updated     string `json:"updated,omitempty"`
+	Deactivated bool   `json:"deactivated,omitempty"`
+	VersionID   string `json:"versionId,omitempty"`
+}
+
+func (w *Wrapper) Routes(router core.EchoRouter) {
+	router.Add(http.MethodGet, ResolvePath+":did", w.Resolve)
+}
+
+// Resolve resolves the DID in the request path.
+// Depending on the Accept header, it returns the DID document (application/did+ld+json) or the DID resolution result (application/ld+json;profile="https://w3id.org/did-resolution").
+// Errors are always returned as DID resolution result.
+func (w *Wrapper) Resolve(ctx echo.Context) error {
+	returnDocument, ok := negotiateRepresentation(ctx.Request().Header.Get("Accept"))
+	if !ok {
+		return resolutionError(ctx, http.StatusNotAcceptable, errorRepresentationNotSupported, "supported representations: "+DIDDocumentContentType+", "+ResolutionResultContentType)
+	}
+	didURL, err := parseDIDURL(ctx.Param("did"), ctx.Request().URL.RawQuery)
+	if err != nil {
+		return resolutionError(ctx, http.StatusBadRequest, errorInvalidDID, err.Error())
+	}
+	if didURL.Path != "" || didURL.Fragment != "" {
+		return resolutionError(ctx, http.StatusBadRequest, errorInvalidDID, "DID URL dereferencing is not supported, DID can not have path or fragment")
+	}
+	resolveMetadata, err := resolver.VersionParameters(*didURL, &resolver.ResolveMetadata{AllowDeactivated: true})
+	if err != nil {
+		return resolutionError(ctx, http.StatusBadRequest, errorInvalidDID, err.Error())
+	}
+
+	document, metadata, err := w.VDR.Resolver().Resolve(didURL.DID, resolveMetadata)
+	switch {
+	case err == nil:
+	case errors.Is(err, resolver.ErrDIDMethodNotSupported):
+		return resolutionError(ctx, http.StatusNotImplemented, errorMethodNotSupported, err.Error())
+	case errors.Is(err, did.ErrInvalidDID):
+		return resolutionError(ctx, http.StatusBadRequest, errorInvalidDID, err.Error())
+	case errors.Is(err, resolver.ErrDeactivated):
+		return writeResolutionResult(ctx, http.StatusGone, ResolutionResult{
+			Context:          resolutionResultContext,
+			DocumentMetadata: DocumentMetadata{Deactivated: true},
+		})
+	case resolver.IsFunctionalResolveError(err):
+		return resolutionError(ctx, http.StatusNotFound, errorNotFound, err.Error())
+	default:
+		log.Logger().WithError(err).Warnf("Universal Resolver: failed to resolve DID (did=%s)", didURL.DID)
+		return resolutionError(ctx, http.StatusInternalServerError, errorInternal, err.Error())
+	}
+
+	// deactivated DID documents are returned with HTTP 410 Gone
+	statusCode := http.StatusOK
+	if metadata.Deactivated {
+		statusCode = http.StatusGone
+	}
+	if returnDocument {
+		ctx.Response().Header().Set(echo.HeaderContentType, DIDDocumentContentType)
+		ctx.Response().WriteHeader(statusCode)
+		return json.NewEncoder(ctx.Response()).Encode(document)
+	}
+	return writeResolutionResult(ctx, statusCode, ResolutionResult{
+		Context:            resolutionResultContext,
+		Document:           document,
+		ResolutionMetadata: ResolutionMetadata{ContentType: DIDDocumentContentType},
+		DocumentMetadata:   toDocumentMetadata(*metadata),
+	})
+}
+
+// parseDIDURL parses the DID (URL) from the request path. Query parameters of the request (e.g. versionId) are considered part of the DID URL.
+// Clients might percent-encode the DID, which is decoded if the path parameter isn't a DID itself.
+func parseDIDURL(pathParam string, rawQuery string) (*did.DIDURL, error) {
+	input := pathParam
+	if !strings.HasPrefix(input, "did:") {
+		unescaped, err := url.PathUnescape(input)
+		if err != nil {
+			return nil, err
+		}
+		input = unescaped
+	}
+	if rawQuery != "" && !strings.Contains(input, "?") {
+		input += "?" + rawQuery
+	}
+	return did.ParseDIDURL(input)
+}
+
+// negotiateRepresentation determines from the Accept header whether the DID document (true) or the DID resolution result (false) is to be returned.
+// If none of the accepted media types is supported, ok is false. If the Accept header is absent, the DID resolution result is returned.
+func negotiateRepresentation(accept string) (returnDocument bool, ok bool) {
+	if strings.TrimSpace(accept) == "" {
+		return false, true
+	}
+	type acceptedType struct {
+		mediaType string
+		params    map[string]string
+		quality   float64
+	}
+	var accepted []acceptedType
+	for _, curr := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(curr)
+		if err != nil {
+			continue
+		}
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			if quality, err = strconv.ParseFloat(q, 64); err != nil {
+				continue
+			}
+		}
+		if quality > 0 {
+			accepted = append(accepted, acceptedType{mediaType: mediaType, params: params, quality: quality})
+		}
+	}
+	sort.SliceStable(accepted, func(i, j int) bool {
+		return accepted[i].quality > accepted[j].quality
+	})
+	for _, curr := range accepted {
+		switch curr.mediaType {
+		case DIDDocumentContentType:
+			return true, true
+		case "application/ld+json":
+			if curr.params["profile"] == "" || slices.Contains(strings.Fields(curr.params["profile"]), resolutionResultProfile) {
+				return false, true
+			}
+		case "application/json", "application/*", "*/*":
+			return false, true
+		}
+	}
+	return false, false
+}
+
+func toDocumentMetadata(metadata resolver.DocumentMetadata) DocumentMetadata {
+	result := DocumentMetadata{
+		Deactivated: metadata.Deactivated,
+		VersionID:   metadata.VersionID,
+	}
+	if !metadata.Created.IsZero() {
+		result.Created = metadata.Created.UTC().Format(time.RFC3339)
+	}
+	if metadata.Updated != nil {
+		result.Updated = metadata.Updated.UTC().Format(time.RFC3339)
+	}
+	return result
+}
+
+func resolutionError(ctx echo.Context, statusCode int, code string, message string) error {
+	return writeResolutionResult(ctx, statusCode, ResolutionResult{
+		Context:            resolutionResultContext,
+		ResolutionMetadata: ResolutionMetadata{Error: code, ErrorMessage: message},
+	})
+}
+
+func writeResolutionResult(ctx echo.Context, statusCode int, result ResolutionResult) error {
+	ctx.Response().Header().Set(echo.HeaderContentType, ResolutionResultContentType)
+	ctx.Response().WriteHeader(statusCode)
+	return json.NewEncoder(ctx.Response()).Encode(result)
+}
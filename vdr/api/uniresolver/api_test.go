@@ -0,0 +1,198 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package uniresolver
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/vdr"
+	"github.com/nuts-foundation/nuts-node/vdr/resolver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var id = did.MustParseDID("did:web:example.com:iam:1")
+
+func TestWrapper_Resolve(t *testing.T) {
+	created := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	document := &did.Document{ID: id, Context: []interface{}{did.DIDContextV1URI()}}
+	metadata := &resolver.DocumentMetadata{Created: created, VersionID: "1"}
+	allowDeactivated := &resolver.ResolveMetadata{AllowDeactivated: true}
+
+	t.Run("resolution result", func(t *testing.T) {
+		for _, accept := range []string{"", "*/*", "application/json", ResolutionResultContentType, "application/ld+json"} {
+			t.Run(accept, func(t *testing.T) {
+				ctx := newMockContext(t)
+				ctx.resolver.EXPECT().Resolve(id, allowDeactivated).Return(document, metadata, nil)
+
+				response := ctx.get(id.String(), accept)
+
+				assert.Equal(t, http.StatusOK, response.Code)
+				assert.Equal(t, ResolutionResultContentType, response.Header().Get("Content-Type"))
+				var result ResolutionResult
+				require.NoError(t, json.Unmarshal(response.Body.Bytes(), &result))
+				assert.Equal(t, "https://w3id.org/did-resolution/v1", result.Context)
+				assert.Equal(t, id.String(), result.Document.ID.String())
+				assert.Equal(t, DIDDocumentContentType, result.ResolutionMetadata.ContentType)
+				assert.Equal(t, DocumentMetadata{Created: "2026-01-02T03:04:05Z", VersionID: "1"}, result.DocumentMetadata)
+			})
+		}
+	})
+	t.Run("DID document", func(t *testing.T) {
+		for _, accept := range []string{DIDDocumentContentType, "application/json;q=0.5, application/did+ld+json"} {
+			t.Run(accept, func(t *testing.T) {
+				ctx := newMockContext(t)
+				ctx.resolver.EXPECT().Resolve(id, allowDeactivated).Return(document, metadata, nil)
+
+				response := ctx.get(id.String(), accept)
+
+				assert.Equal(t, http.StatusOK, response.Code)
+				assert.Equal(t, DIDDocumentContentType, response.Header().Get("Content-Type"))
+				var result did.Document
+				require.NoError(t, json.Unmarshal(response.Body.Bytes(), &result))
+				assert.Equal(t, id.String(), result.ID.String())
+			})
+		}
+	})
+	t.Run("percent-encoded DID", func(t *testing.T) {
+		ctx := newMockContext(t)
+		webDID := did.MustParseDID("did:web:example.com%3A8080")
+		ctx.resolver.EXPECT().Resolve(webDID, allowDeactivated).Return(document, metadata, nil)
+
+		response := ctx.get("did%3Aweb%3Aexample.com%253A8080", "")
+
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+	t.Run("versionId", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.resolver.EXPECT().Resolve(id, &resolver.ResolveMetadata{AllowDeactivated: true, VersionID: "1"}).Return(document, metadata, nil)
+
+		response := ctx.get(id.String()+"?versionId=1", "")
+
+		assert.Equal(t, http.StatusOK, response.Code)
+	})
+	t.Run("deactivated", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.resolver.EXPECT().Resolve(id, allowDeactivated).Return(document, &resolver.DocumentMetadata{Deactivated: true}, nil)
+
+		response := ctx.get(id.String(), "")
+
+		assert.Equal(t, http.StatusGone, response.Code)
+		var result ResolutionResult
+		require.NoError(t, json.Unmarshal(response.Body.Bytes(), &result))
+		assert.True(t, result.DocumentMetadata.Deactivated)
+	})
+	t.Run("errors", func(t *testing.T) {
+		testCases := []struct {
+			name       string
+			did        string
+			accept     string
+			resolveErr error
+			statusCode int
+			error      string
+		}{
+			{name: "representation not supported", did: id.String(), accept: "text/html", statusCode: http.StatusNotAcceptable, error: "representationNotSupported"},
+			{name: "invalid DID", did: "not-a-did", statusCode: http.StatusBadRequest, error: "invalidDid"},
+			{name: "DID URL with fragment", did: id.String() + "%230", statusCode: http.StatusBadRequest, error: "invalidDid"},
+			{name: "invalid versionTime", did: id.String() + "?versionTime=yesterday", statusCode: http.StatusBadRequest, error: "invalidDid"},
+			{name: "not found", did: id.String(), resolveErr: resolver.ErrNotFound, statusCode: http.StatusNotFound, error: "notFound"},
+			{name: "method not supported", did: id.String(), resolveErr: resolver.ErrDIDMethodNotSupported, statusCode: http.StatusNotImplemented, error: "methodNotSupported"},
+			{name: "deactivated", did: id.String(), resolveErr: resolver.ErrDeactivated, statusCode: http.StatusGone},
+			{name: "other error", did: id.String(), resolveErr: errors.New("failed"), statusCode: http.StatusInternalServerError, error: "internalError"},
+		}
+		for _, testCase := range testCases {
+			t.Run(testCase.name, func(t *testing.T) {
+				ctx := newMockContext(t)
+				if testCase.resolveErr != nil {
+					ctx.resolver.EXPECT().Resolve(id, allowDeactivated).Return(nil, nil, testCase.resolveErr)
+				}
+
+				response := ctx.get(testCase.did, testCase.accept)
+
+				assert.Equal(t, testCase.statusCode, response.Code)
+				assert.Equal(t, ResolutionResultContentType, response.Header().Get("Content-Type"))
+				var result ResolutionResult
+				require.NoError(t, json.Unmarshal(response.Body.Bytes(), &result))
+				assert.Nil(t, result.Document)
+				assert.Equal(t, testCase.error, result.ResolutionMetadata.Error)
+			})
+		}
+	})
+}
+
+func Test_negotiateRepresentation(t *testing.T) {
+	testCases := []struct {
+		accept         string
+		returnDocument bool
+		ok             bool
+	}{
+		{accept: "", returnDocument: false, ok: true},
+		{accept: "application/did+ld+json", returnDocument: true, ok: true},
+		{accept: `application/ld+json;profile="https://w3id.org/did-resolution"`, returnDocument: false, ok: true},
+		{accept: `application/ld+json;profile="https://example.com/other"`, ok: false},
+		{accept: "application/did+ld+json;q=0.1, application/json", returnDocument: false, ok: true},
+		{accept: "application/json;q=0, application/did+ld+json", returnDocument: true, ok: true},
+		{accept: "text/html, image/png", ok: false},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.accept, func(t *testing.T) {
+			returnDocument, ok := negotiateRepresentation(testCase.accept)
+
+			assert.Equal(t, testCase.returnDocument, returnDocument)
+			assert.Equal(t, testCase.ok, ok)
+		})
+	}
+}
+
+type mockContext struct {
+	resolver *resolver.MockDIDResolver
+	router   *echo.Echo
+}
+
+func newMockContext(t *testing.T) mockContext {
+	ctrl := gomock.NewController(t)
+	didResolver := resolver.NewMockDIDResolver(ctrl)
+	mockVDR := vdr.NewMockVDR(ctrl)
+	mockVDR.EXPECT().Resolver().Return(didResolver).AnyTimes()
+	router := echo.New()
+	(&Wrapper{VDR: mockVDR}).Routes(router)
+	return mockContext{
+		resolver: didResolver,
+		router:   router,
+	}
+}
+
+// get performs a resolution request for the given (already encoded) DID and Accept header.
+func (m mockContext) get(encodedDID string, accept string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodGet, ResolvePath+encodedDID, nil)
+	if accept != "" {
+		request.Header.Set("Accept", accept)
+	}
+	recorder := httptest.NewRecorder()
+	m.router.ServeHTTP(recorder, request)
+	return recorder
+}
@@ -20,6 +20,7 @@
 package v2
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"github.com/labstack/echo/v4"
@@ -29,6 +30,8 @@ import (
 	"github.com/nuts-foundation/nuts-node/core"
 	"github.com/nuts-foundation/nuts-node/http/cache"
 	"github.com/nuts-foundation/nuts-node/storage/orm"
+	"github.com/nuts-foundation/nuts-node/vcr/credential"
+	"github.com/nuts-foundation/nuts-node/vcr/domainlinkage"
 	"github.com/nuts-foundation/nuts-node/vdr"
 	"github.com/nuts-foundation/nuts-node/vdr/didsubject"
 	"github.com/nuts-foundation/nuts-node/vdr/didweb"
@@ -44,13 +47,20 @@ var _ core.ErrorStatusCodeResolver = (*Wrapper)(nil)
 // cacheControlMaxAgeURLs holds API endpoints that should have a max-age cache control header set.
 var cacheControlMaxAgeURLs = []string{
 	"/.well-known/did.json",
+	"/.well-known/did-configuration.json",
 	"/iam/:id/did.json",
+	"/iam/:id/did.jsonl",
 }
 
+// httpRequestContextKey is the context key for the *http.Request, used to derive the requested origin.
+type httpRequestContextKey struct{}
+
 // Wrapper is needed to connect the implementation to the echo ServiceWrapper
 type Wrapper struct {
 	VDR            vdr.VDR
 	SubjectManager didsubject.Manager
+	// DomainLinkage publishes the DID Configuration resource. If nil, the resource is not served.
+	DomainLinkage *domainlinkage.Publisher
 }
 
 // ResolveStatusCode maps errors returned by this API to specific HTTP status codes.
@@ -78,6 +88,9 @@ func (w *Wrapper) Routes(router core.EchoRouter) {
 				ctx.Set(core.OperationIDContextKey, operationID)
 				ctx.Set(core.ModuleNameContextKey, vdr.ModuleName)
 				ctx.Set(core.StatusCodeResolverContextKey, w)
+				// Add http.Request to context, to allow reading the requested host
+				requestCtx := context.WithValue(ctx.Request().Context(), httpRequestContextKey{}, ctx.Request())
+				ctx.SetRequest(ctx.Request().WithContext(requestCtx))
 				return f(ctx, request)
 			}
 		},
@@ -101,6 +114,21 @@ func (r Wrapper) GetTenantWebDID(ctx context.Context, request GetTenantWebDIDReq
 	return GetTenantWebDID200JSONResponse(*document), nil
 }
 
+func (r Wrapper) GetTenantWebVHLog(ctx context.Context, request GetTenantWebVHLogRequestObject) (GetTenantWebVHLogResponseObject, error) {
+	didLog, err := r.VDR.TenantWebVHLog(ctx, request.Id)
+	if err != nil {
+		if resolver.IsFunctionalResolveError(err) {
+			return GetTenantWebVHLog404Response{}, nil
+		}
+		log.Logger().WithContext(ctx).WithError(err).Errorf("Could not load did:webvh log of tenant: %s", request.Id)
+		return nil, errors.New("unable to load DID log")
+	}
+	return GetTenantWebVHLog200ApplicationjsonlResponse{
+		Body:          bytes.NewReader(didLog),
+		ContentLength: int64(len(didLog)),
+	}, nil
+}
+
 func (r Wrapper) GetRootWebDID(ctx context.Context, _ GetRootWebDIDRequestObject) (GetRootWebDIDResponseObject, error) {
 	ownDID := r.requestedWebDID("")
 	document, err := r.VDR.ResolveManaged(ownDID)
@@ -114,6 +142,26 @@ func (r Wrapper) GetRootWebDID(ctx context.Context, _ GetRootWebDIDRequestObject
 	return GetRootWebDID200JSONResponse(*document), nil
 }
 
+func (r Wrapper) GetDIDConfiguration(ctx context.Context, _ GetDIDConfigurationRequestObject) (GetDIDConfigurationResponseObject, error) {
+	if r.DomainLinkage == nil {
+		return GetDIDConfiguration404Response{}, nil
+	}
+	origin, err := r.requestedOrigin(ctx)
+	if err != nil {
+		log.Logger().WithContext(ctx).WithError(err).Debug("Invalid origin requested for DID Configuration")
+		return GetDIDConfiguration404Response{}, nil
+	}
+	configuration, err := r.DomainLinkage.DIDConfiguration(ctx, origin)
+	if err != nil {
+		log.Logger().WithContext(ctx).WithError(err).Errorf("Could not create DID Configuration for origin: %s", origin)
+		return nil, errors.New("unable to create DID Configuration")
+	}
+	if len(configuration.LinkedDIDs) == 0 {
+		return GetDIDConfiguration404Response{}, nil
+	}
+	return GetDIDConfiguration200JSONResponse(*configuration), nil
+}
+
 func (w *Wrapper) CreateSubject(ctx context.Context, request CreateSubjectRequestObject) (CreateSubjectResponseObject, error) {
 	options := didsubject.DefaultCreationOptions()
 	if request.Body.Subject != nil {
@@ -124,6 +172,9 @@ func (w *Wrapper) CreateSubject(ctx context.Context, request CreateSubjectReques
 			options = options.With(didsubject.EncryptionKeyCreationOption{})
 		}
 	}
+	if request.Body.DidMethods != nil {
+		options = options.With(didsubject.DIDMethodsCreationOption{Methods: *request.Body.DidMethods})
+	}
 
 	docs, subject, err := w.SubjectManager.Create(ctx, options)
 	if err != nil {
@@ -159,12 +210,31 @@ func (w *Wrapper) Deactivate(ctx context.Context, request DeactivateRequestObjec
 	return Deactivate204Response{}, nil
 }
 
+func (w *Wrapper) InvalidateResolutionCache(_ context.Context, request InvalidateResolutionCacheRequestObject) (InvalidateResolutionCacheResponseObject, error) {
+	id, err := did.ParseDID(request.Did)
+	if err != nil {
+		return nil, err
+	}
+	if err = w.VDR.InvalidateResolutionCache(*id); err != nil {
+		return nil, err
+	}
+	return InvalidateResolutionCache204Response{}, nil
+}
+
 func (w *Wrapper) ResolveDID(_ context.Context, request ResolveDIDRequestObject) (ResolveDIDResponseObject, error) {
-	targetDID, err := did.ParseDID(request.Did)
+	// the DID may contain versionId and/or versionTime DID parameters, to resolve a historic version of the DID document
+	didURL, err := did.ParseDIDURL(request.Did)
 	if err != nil {
 		return nil, err
 	}
-	didDocument, metadata, err := w.VDR.Resolver().Resolve(*targetDID, nil)
+	if didURL.Path != "" || didURL.Fragment != "" {
+		return nil, core.InvalidInputError("DID can not have path or fragment")
+	}
+	resolveMetadata, err := resolver.VersionParameters(*didURL, nil)
+	if err != nil {
+		return nil, core.InvalidInputError("%s", err)
+	}
+	didDocument, metadata, err := w.VDR.Resolver().Resolve(didURL.DID, resolveMetadata)
 	if err != nil {
 		return nil, err
 	}
@@ -187,6 +257,17 @@ func (w *Wrapper) SubjectDIDs(ctx context.Context, request SubjectDIDsRequestObj
 	return SubjectDIDs200JSONResponse(result), nil
 }
 
+func (w *Wrapper) CreatePeerDID(ctx context.Context, request CreatePeerDIDRequestObject) (CreatePeerDIDResponseObject, error) {
+	if request.Body == nil || request.Body.Relationship == "" {
+		return nil, core.InvalidInputError("relationship is required")
+	}
+	document, err := w.SubjectManager.CreatePeerDID(ctx, request.Id, request.Body.Relationship)
+	if err != nil {
+		return nil, err
+	}
+	return CreatePeerDID200JSONResponse(*document), nil
+}
+
 func (w *Wrapper) FindServices(ctx context.Context, request FindServicesRequestObject) (FindServicesResponseObject, error) {
 	services, err := w.SubjectManager.FindServices(ctx, request.Id, request.Params.Type)
 	if err != nil {
@@ -275,10 +356,46 @@ func (w *Wrapper) AddVerificationMethod(ctx context.Context, request AddVerifica
 	return AddVerificationMethod200JSONResponse(vms), nil
 }
 
+func (w *Wrapper) RotateVerificationMethod(ctx context.Context, request RotateVerificationMethodRequestObject) (RotateVerificationMethodResponseObject, error) {
+	subject := request.Id
+	keyUsage := orm.AssertionKeyUsage()
+	if request.Body != nil {
+		if request.Body.EncryptionKey {
+			keyUsage ^= orm.EncryptionKeyUsage()
+		}
+		if !request.Body.AssertionKey {
+			keyUsage ^= orm.AssertionKeyUsage()
+		}
+		if keyUsage == 0 {
+			return nil, core.InvalidInputError("at least one key must be rotated")
+		}
+	}
+
+	vms, err := w.SubjectManager.RotateVerificationMethod(ctx, subject, keyUsage)
+	if err != nil {
+		return nil, err
+	}
+	return RotateVerificationMethod200JSONResponse(vms), nil
+}
+
 // requestedWebDID constructs a did:web DID as it was requested by the API caller. It can be a DID with or without user path, e.g.:
 // - did:web:example.com
 // - did:web:example:iam:1234
 // When userID is given, it's appended to the DID as `:iam:<userID>`. If it's absent, the DID is returned as is.
+// requestedOrigin returns the origin the request was sent to, derived from the X-Forwarded-Host or Host header.
+// The scheme is taken from the node's public URL, since TLS is typically terminated before the request reaches the node.
+func (r Wrapper) requestedOrigin(ctx context.Context) (string, error) {
+	httpRequest, ok := ctx.Value(httpRequestContextKey{}).(*http.Request)
+	if !ok {
+		return "", errors.New("no HTTP request in context")
+	}
+	host := httpRequest.Header.Get("X-Forwarded-Host")
+	if host == "" {
+		host = httpRequest.Host
+	}
+	return credential.ParseOrigin(r.VDR.PublicURL().Scheme + "://" + host)
+}
+
 func (r Wrapper) requestedWebDID(userID string) did.DID {
 	identityURL := r.VDR.PublicURL()
 	if userID != "" {
@@ -27,12 +27,18 @@ import (
 	ssi "github.com/nuts-foundation/go-did"
 	"github.com/nuts-foundation/nuts-node/core"
 	"github.com/nuts-foundation/nuts-node/storage/orm"
+	"github.com/nuts-foundation/nuts-node/vcr/credential"
+	"github.com/nuts-foundation/nuts-node/vcr/domainlinkage"
+	"github.com/nuts-foundation/nuts-node/vcr/issuer"
 	"github.com/nuts-foundation/nuts-node/vdr/didsubject"
+	"io"
 	"net/http"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/go-did/vc"
 	"github.com/nuts-foundation/nuts-node/audit"
 	"github.com/nuts-foundation/nuts-node/vdr"
 	"github.com/nuts-foundation/nuts-node/vdr/resolver"
@@ -73,6 +79,20 @@ func TestWrapper_CreateSubject(t *testing.T) {
 		assert.Len(t, response.(CreateSubject200JSONResponse).Documents, 1)
 		assert.Equal(t, "subject", response.(CreateSubject200JSONResponse).Subject)
 	})
+	t.Run("with DID methods", func(t *testing.T) {
+		ctx := newMockContext(t)
+		methods := []string{"jwk", "key"}
+		ctx.subjectManager.EXPECT().Create(gomock.Any(), didsubject.DefaultCreationOptions().With(didsubject.DIDMethodsCreationOption{Methods: methods})).Return([]did.Document{didDoc}, "subject", nil)
+
+		response, err := ctx.client.CreateSubject(nil, CreateSubjectRequestObject{
+			Body: &CreateSubjectJSONRequestBody{
+				DidMethods: &methods,
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Len(t, response.(CreateSubject200JSONResponse).Documents, 1)
+	})
 	t.Run("error - create fails", func(t *testing.T) {
 		ctx := newMockContext(t)
 		ctx.subjectManager.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil, "", assert.AnError)
@@ -105,6 +125,34 @@ func TestWrapper_Deactivate(t *testing.T) {
 	})
 }
 
+func TestWrapper_InvalidateResolutionCache(t *testing.T) {
+	id := did.MustParseDID("did:web:example.com")
+	t.Run("ok", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.vdr.EXPECT().InvalidateResolutionCache(id).Return(nil)
+
+		response, err := ctx.client.InvalidateResolutionCache(nil, InvalidateResolutionCacheRequestObject{Did: id.String()})
+
+		require.NoError(t, err)
+		assert.IsType(t, InvalidateResolutionCache204Response{}, response)
+	})
+	t.Run("invalid DID", func(t *testing.T) {
+		ctx := newMockContext(t)
+
+		_, err := ctx.client.InvalidateResolutionCache(nil, InvalidateResolutionCacheRequestObject{Did: "invalid"})
+
+		assert.ErrorIs(t, err, did.ErrInvalidDID)
+	})
+	t.Run("error", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.vdr.EXPECT().InvalidateResolutionCache(id).Return(assert.AnError)
+
+		_, err := ctx.client.InvalidateResolutionCache(nil, InvalidateResolutionCacheRequestObject{Did: id.String()})
+
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
+
 func TestWrapper_CreateService(t *testing.T) {
 	service := did.Service{
 		Type:            "api",
@@ -242,6 +290,46 @@ func TestWrapper_SubjectDIDs(t *testing.T) {
 	})
 }
 
+func TestWrapper_CreatePeerDID(t *testing.T) {
+	relationship := "https://example.com/oauth2/verifier"
+	t.Run("ok", func(t *testing.T) {
+		ctx := newMockContext(t)
+		document := &did.Document{ID: did.MustParseDID("did:peer:0z6MkhaXgBZDvotDkL5257faiztiGiC2QtKLGpbnnEGta2doK")}
+		ctx.subjectManager.EXPECT().CreatePeerDID(gomock.Any(), "subject", relationship).Return(document, nil)
+
+		response, err := ctx.client.CreatePeerDID(context.Background(), CreatePeerDIDRequestObject{
+			Id:   "subject",
+			Body: &CreatePeerDIDJSONRequestBody{Relationship: relationship},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, document.ID, response.(CreatePeerDID200JSONResponse).ID)
+	})
+	t.Run("error - relationship missing", func(t *testing.T) {
+		ctx := newMockContext(t)
+
+		response, err := ctx.client.CreatePeerDID(context.Background(), CreatePeerDIDRequestObject{
+			Id:   "subject",
+			Body: &CreatePeerDIDJSONRequestBody{},
+		})
+
+		assert.EqualError(t, err, "relationship is required")
+		assert.Nil(t, response)
+	})
+	t.Run("error - create fails", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.subjectManager.EXPECT().CreatePeerDID(gomock.Any(), "subject", relationship).Return(nil, didsubject.ErrSubjectNotFound)
+
+		response, err := ctx.client.CreatePeerDID(context.Background(), CreatePeerDIDRequestObject{
+			Id:   "subject",
+			Body: &CreatePeerDIDJSONRequestBody{Relationship: relationship},
+		})
+
+		assert.ErrorIs(t, err, didsubject.ErrSubjectNotFound)
+		assert.Nil(t, response)
+	})
+}
+
 func TestWrapper_ResolveDID(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
 		ctx := newMockContext(t)
@@ -263,6 +351,34 @@ func TestWrapper_ResolveDID(t *testing.T) {
 		assert.ErrorIs(t, err, did.ErrInvalidDID)
 		assert.Nil(t, response)
 	})
+	t.Run("versionId and versionTime", func(t *testing.T) {
+		ctx := newMockContext(t)
+		id := did.MustParseDID("did:web:example.com:iam:1")
+		versionTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		ctx.didResolver.EXPECT().Resolve(id, &resolver.ResolveMetadata{VersionID: "2", ResolveTime: &versionTime}).Return(&did.Document{ID: id}, &resolver.DocumentMetadata{VersionID: "2"}, nil)
+
+		response, err := ctx.client.ResolveDID(nil, ResolveDIDRequestObject{Did: id.String() + "?versionId=2&versionTime=2024-01-01T00:00:00Z"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "2", response.(ResolveDID200JSONResponse).DocumentMetadata.VersionID)
+	})
+	t.Run("invalid versionTime", func(t *testing.T) {
+		ctx := newMockContext(t)
+
+		response, err := ctx.client.ResolveDID(nil, ResolveDIDRequestObject{Did: "did:web:example.com:iam:1?versionTime=yesterday"})
+
+		assert.ErrorIs(t, err, core.InvalidInputError(""))
+		assert.ErrorContains(t, err, "invalid versionTime DID parameter")
+		assert.Nil(t, response)
+	})
+	t.Run("DID URL with fragment", func(t *testing.T) {
+		ctx := newMockContext(t)
+
+		response, err := ctx.client.ResolveDID(nil, ResolveDIDRequestObject{Did: "did:web:example.com:iam:1#key-1"})
+
+		assert.EqualError(t, err, "DID can not have path or fragment")
+		assert.Nil(t, response)
+	})
 	t.Run("resolver error", func(t *testing.T) {
 		ctx := newMockContext(t)
 		id := did.MustParseDID("did:web:example.com:iam:1")
@@ -446,6 +562,59 @@ func TestWrapper_AddVerificationMethod(t *testing.T) {
 	})
 }
 
+func TestWrapper_RotateVerificationMethod(t *testing.T) {
+	vm := did.VerificationMethod{ID: did.MustParseDIDURL("did:example:1#key-2")}
+	t.Run("ok - defaults", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.subjectManager.EXPECT().RotateVerificationMethod(gomock.Any(), "subject", orm.AssertionKeyUsage()).Return([]did.VerificationMethod{vm}, nil)
+
+		response, err := ctx.client.RotateVerificationMethod(nil, RotateVerificationMethodRequestObject{
+			Id: "subject",
+		})
+
+		require.NoError(t, err)
+		require.Len(t, response.(RotateVerificationMethod200JSONResponse), 1)
+	})
+	t.Run("encryption key only", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.subjectManager.EXPECT().RotateVerificationMethod(gomock.Any(), "subject", orm.EncryptionKeyUsage()).Return([]did.VerificationMethod{vm}, nil)
+
+		_, err := ctx.client.RotateVerificationMethod(nil, RotateVerificationMethodRequestObject{
+			Id: "subject",
+			Body: &KeyCreationOptions{
+				AssertionKey:  false,
+				EncryptionKey: true,
+			},
+		})
+
+		require.NoError(t, err)
+	})
+	t.Run("error on no keys", func(t *testing.T) {
+		ctx := newMockContext(t)
+
+		_, err := ctx.client.RotateVerificationMethod(nil, RotateVerificationMethodRequestObject{
+			Id: "subject",
+			Body: &KeyCreationOptions{
+				AssertionKey:  false,
+				EncryptionKey: false,
+			},
+		})
+
+		require.Error(t, err)
+		assert.Equal(t, http.StatusBadRequest, err.(core.HTTPStatusCodeError).StatusCode())
+	})
+	t.Run("unknown subject", func(t *testing.T) {
+		ctx := newMockContext(t)
+		ctx.subjectManager.EXPECT().RotateVerificationMethod(gomock.Any(), "subject", orm.AssertionKeyUsage()).Return(nil, didsubject.ErrSubjectNotFound)
+
+		_, err := ctx.client.RotateVerificationMethod(nil, RotateVerificationMethodRequestObject{
+			Id: "subject",
+		})
+
+		assert.ErrorIs(t, err, didsubject.ErrSubjectNotFound)
+	})
+}
+
 func TestWrapper_GetTenantWebDID(t *testing.T) {
 	const webIDPart = "123"
 	var webDID = did.MustParseDID("did:web:example.com:iam:123")
@@ -508,6 +677,42 @@ func TestWrapper_GetTenantWebDID(t *testing.T) {
 	})
 }
 
+func TestWrapper_GetTenantWebVHLog(t *testing.T) {
+	ctx := audit.TestContext()
+	didLog := []byte(`{"versionId":"1-abc"}` + "\n")
+
+	t.Run("ok", func(t *testing.T) {
+		test := newMockContext(t)
+		test.vdr.EXPECT().TenantWebVHLog(ctx, "123").Return(didLog, nil)
+
+		response, err := test.client.GetTenantWebVHLog(ctx, GetTenantWebVHLogRequestObject{"123"})
+
+		require.NoError(t, err)
+		actual := response.(GetTenantWebVHLog200ApplicationjsonlResponse)
+		body, _ := io.ReadAll(actual.Body)
+		assert.Equal(t, didLog, body)
+		assert.Equal(t, int64(len(didLog)), actual.ContentLength)
+	})
+	t.Run("unknown DID", func(t *testing.T) {
+		test := newMockContext(t)
+		test.vdr.EXPECT().TenantWebVHLog(ctx, "123").Return(nil, resolver.ErrNotFound)
+
+		response, err := test.client.GetTenantWebVHLog(ctx, GetTenantWebVHLogRequestObject{"123"})
+
+		assert.NoError(t, err)
+		assert.IsType(t, GetTenantWebVHLog404Response{}, response)
+	})
+	t.Run("other error", func(t *testing.T) {
+		test := newMockContext(t)
+		test.vdr.EXPECT().TenantWebVHLog(ctx, "123").Return(nil, errors.New("failed"))
+
+		response, err := test.client.GetTenantWebVHLog(ctx, GetTenantWebVHLogRequestObject{"123"})
+
+		assert.EqualError(t, err, "unable to load DID log")
+		assert.Nil(t, response)
+	})
+}
+
 func TestWrapper_GetRootWebDID(t *testing.T) {
 	var rootWebDID = did.MustParseDID("did:web:example.com")
 	baseURL, _ := url.Parse("https://example.com")
@@ -551,6 +756,88 @@ func TestWrapper_GetRootWebDID(t *testing.T) {
 	})
 }
 
+func TestWrapper_GetDIDConfiguration(t *testing.T) {
+	baseURL, _ := url.Parse("https://nuts.example.com")
+	linkedDocument := &did.Document{
+		ID:      id,
+		Service: []did.Service{{Type: credential.LinkedDomainsServiceType, ServiceEndpoint: "https://example.com"}},
+	}
+	requestContext := func(host string, forwardedHost string) context.Context {
+		httpRequest, _ := http.NewRequest(http.MethodGet, "http://"+host+"/.well-known/did-configuration.json", nil)
+		if forwardedHost != "" {
+			httpRequest.Header.Set("X-Forwarded-Host", forwardedHost)
+		}
+		return context.WithValue(audit.TestContext(), httpRequestContextKey{}, httpRequest)
+	}
+	setup := func(t *testing.T) (mockContext, *issuer.MockIssuer) {
+		test := newMockContext(t)
+		mockIssuer := issuer.NewMockIssuer(test.ctrl)
+		test.client.DomainLinkage = domainlinkage.NewPublisher(testIssuerProvider{issuer: mockIssuer}, test.subjectManager, test.didResolver)
+		test.vdr.EXPECT().PublicURL().Return(baseURL).AnyTimes()
+		test.subjectManager.EXPECT().List(gomock.Any()).Return(map[string][]did.DID{"subject": {id}}, nil).AnyTimes()
+		test.didResolver.EXPECT().Resolve(id, nil).Return(linkedDocument, nil, nil).AnyTimes()
+		return test, mockIssuer
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		test, mockIssuer := setup(t)
+		mockIssuer.EXPECT().Issue(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, template vc.VerifiableCredential, _ issuer.CredentialOptions) (*vc.VerifiableCredential, error) {
+			assert.Equal(t, "https://example.com", template.CredentialSubject[0]["origin"])
+			return &template, nil
+		})
+
+		response, err := test.client.GetDIDConfiguration(requestContext("example.com", ""), GetDIDConfigurationRequestObject{})
+
+		require.NoError(t, err)
+		require.IsType(t, GetDIDConfiguration200JSONResponse{}, response)
+		assert.Len(t, response.(GetDIDConfiguration200JSONResponse).LinkedDIDs, 1)
+	})
+	t.Run("X-Forwarded-Host takes precedence", func(t *testing.T) {
+		test, mockIssuer := setup(t)
+		mockIssuer.EXPECT().Issue(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, template vc.VerifiableCredential, _ issuer.CredentialOptions) (*vc.VerifiableCredential, error) {
+			return &template, nil
+		})
+
+		response, err := test.client.GetDIDConfiguration(requestContext("nuts.internal", "example.com"), GetDIDConfigurationRequestObject{})
+
+		require.NoError(t, err)
+		assert.IsType(t, GetDIDConfiguration200JSONResponse{}, response)
+	})
+	t.Run("origin not linked", func(t *testing.T) {
+		test, _ := setup(t)
+
+		response, err := test.client.GetDIDConfiguration(requestContext("other.example.com", ""), GetDIDConfigurationRequestObject{})
+
+		require.NoError(t, err)
+		assert.IsType(t, GetDIDConfiguration404Response{}, response)
+	})
+	t.Run("issuance fails", func(t *testing.T) {
+		test, mockIssuer := setup(t)
+		mockIssuer.EXPECT().Issue(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("failed"))
+
+		response, err := test.client.GetDIDConfiguration(requestContext("example.com", ""), GetDIDConfigurationRequestObject{})
+
+		assert.EqualError(t, err, "unable to create DID Configuration")
+		assert.Nil(t, response)
+	})
+	t.Run("not enabled", func(t *testing.T) {
+		test := newMockContext(t)
+
+		response, err := test.client.GetDIDConfiguration(requestContext("example.com", ""), GetDIDConfigurationRequestObject{})
+
+		require.NoError(t, err)
+		assert.IsType(t, GetDIDConfiguration404Response{}, response)
+	})
+}
+
+type testIssuerProvider struct {
+	issuer issuer.Issuer
+}
+
+func (t testIssuerProvider) Issuer() issuer.Issuer {
+	return t.issuer
+}
+
 func TestWrapper_Routes(t *testing.T) {
 	t.Run("cache middleware URLs match registered paths", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
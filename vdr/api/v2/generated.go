@@ -29,8 +29,18 @@ const (
 	String FindServicesParamsEndpointType = "string"
 )
 
+// CreatePeerDIDOptions Options for the did:peer DID creation.
+type CreatePeerDIDOptions struct {
+	// Relationship Identifies the party the did:peer DID is used with: the audience of presentations made with it, e.g. the issuer URL of its authorization server or its OpenID4VP client_id.
+	Relationship string `json:"relationship"`
+}
+
 // CreateSubjectOptions Options for the subject creation.
 type CreateSubjectOptions struct {
+	// DidMethods restricts the DIDs that are created for the subject to the given DID methods (without did: prefix), e.g. "jwk" or "key".
+	// The DID methods must be enabled on the node. If not given, a DID is created for every enabled DID method.
+	DidMethods *[]string `json:"didMethods,omitempty"`
+
 	// Keys Options for the key creation.
 	Keys *KeyCreationOptions `json:"keys,omitempty"`
 
@@ -87,6 +97,9 @@ type FindServicesParamsEndpointType string
 // CreateSubjectJSONRequestBody defines body for CreateSubject for application/json ContentType.
 type CreateSubjectJSONRequestBody = CreateSubjectOptions
 
+// CreatePeerDIDJSONRequestBody defines body for CreatePeerDID for application/json ContentType.
+type CreatePeerDIDJSONRequestBody = CreatePeerDIDOptions
+
 // CreateServiceJSONRequestBody defines body for CreateService for application/json ContentType.
 type CreateServiceJSONRequestBody = ServiceRequest
 
@@ -96,6 +109,9 @@ type UpdateServiceJSONRequestBody = ServiceRequest
 // AddVerificationMethodJSONRequestBody defines body for AddVerificationMethod for application/json ContentType.
 type AddVerificationMethodJSONRequestBody = KeyCreationOptions
 
+// RotateVerificationMethodJSONRequestBody defines body for RotateVerificationMethod for application/json ContentType.
+type RotateVerificationMethodJSONRequestBody = KeyCreationOptions
+
 // RequestEditorFn  is the function signature for the RequestEditor callback function
 type RequestEditorFn func(ctx context.Context, req *http.Request) error
 
@@ -172,9 +188,15 @@ type ClientInterface interface {
 	// GetRootWebDID request
 	GetRootWebDID(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// GetDIDConfiguration request
+	GetDIDConfiguration(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetTenantWebDID request
 	GetTenantWebDID(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// GetTenantWebVHLog request
+	GetTenantWebVHLog(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// ResolveDID request
 	ResolveDID(ctx context.Context, did string, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -189,9 +211,16 @@ type ClientInterface interface {
 	// Deactivate request
 	Deactivate(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// InvalidateResolutionCache request
+	InvalidateResolutionCache(ctx context.Context, did string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// SubjectDIDs request
 	SubjectDIDs(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// CreatePeerDIDWithBody request with any body
+	CreatePeerDIDWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreatePeerDID(ctx context.Context, id string, body CreatePeerDIDJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 	// FindServices request
 	FindServices(ctx context.Context, id string, params *FindServicesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -212,6 +241,10 @@ type ClientInterface interface {
 	AddVerificationMethodWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	AddVerificationMethod(ctx context.Context, id string, body AddVerificationMethodJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+	// RotateVerificationMethodWithBody request with any body
+	RotateVerificationMethodWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	RotateVerificationMethod(ctx context.Context, id string, body RotateVerificationMethodJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
 func (c *Client) GetRootWebDID(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -226,6 +259,18 @@ func (c *Client) GetRootWebDID(ctx context.Context, reqEditors ...RequestEditorF
 	return c.Client.Do(req)
 }
 
+func (c *Client) GetDIDConfiguration(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetDIDConfigurationRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) GetTenantWebDID(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetTenantWebDIDRequest(c.Server, id)
 	if err != nil {
@@ -238,6 +283,18 @@ func (c *Client) GetTenantWebDID(ctx context.Context, id string, reqEditors ...R
 	return c.Client.Do(req)
 }
 
+func (c *Client) GetTenantWebVHLog(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTenantWebVHLogRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) ResolveDID(ctx context.Context, did string, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewResolveDIDRequest(c.Server, did)
 	if err != nil {
@@ -298,6 +355,18 @@ func (c *Client) Deactivate(ctx context.Context, id string, reqEditors ...Reques
 	return c.Client.Do(req)
 }
 
+func (c *Client) InvalidateResolutionCache(ctx context.Context, did string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewInvalidateResolutionCacheRequest(c.Server, did)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) SubjectDIDs(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewSubjectDIDsRequest(c.Server, id)
 	if err != nil {
@@ -310,6 +379,30 @@ func (c *Client) SubjectDIDs(ctx context.Context, id string, reqEditors ...Reque
 	return c.Client.Do(req)
 }
 
+func (c *Client) CreatePeerDIDWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreatePeerDIDRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreatePeerDID(ctx context.Context, id string, body CreatePeerDIDJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreatePeerDIDRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) FindServices(ctx context.Context, id string, params *FindServicesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewFindServicesRequest(c.Server, id, params)
 	if err != nil {
@@ -406,6 +499,30 @@ func (c *Client) AddVerificationMethod(ctx context.Context, id string, body AddV
 	return c.Client.Do(req)
 }
 
+func (c *Client) RotateVerificationMethodWithBody(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRotateVerificationMethodRequestWithBody(c.Server, id, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RotateVerificationMethod(ctx context.Context, id string, body RotateVerificationMethodJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRotateVerificationMethodRequest(c.Server, id, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 // NewGetRootWebDIDRequest generates requests for GetRootWebDID
 func NewGetRootWebDIDRequest(server string) (*http.Request, error) {
 	var err error
@@ -433,6 +550,33 @@ func NewGetRootWebDIDRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
+// NewGetDIDConfigurationRequest generates requests for GetDIDConfiguration
+func NewGetDIDConfigurationRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/.well-known/did-configuration.json")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewGetTenantWebDIDRequest generates requests for GetTenantWebDID
 func NewGetTenantWebDIDRequest(server string, id string) (*http.Request, error) {
 	var err error
@@ -467,6 +611,40 @@ func NewGetTenantWebDIDRequest(server string, id string) (*http.Request, error)
 	return req, nil
 }
 
+// NewGetTenantWebVHLogRequest generates requests for GetTenantWebVHLog
+func NewGetTenantWebVHLogRequest(server string, id string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/iam/%s/did.jsonl", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewResolveDIDRequest generates requests for ResolveDID
 func NewResolveDIDRequest(server string, did string) (*http.Request, error) {
 	var err error
@@ -596,6 +774,37 @@ func NewDeactivateRequest(server string, id string) (*http.Request, error) {
 	return req, nil
 }
 
+// NewInvalidateResolutionCacheRequest generates requests for InvalidateResolutionCache
+func NewInvalidateResolutionCacheRequest(server string, did string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0 = did
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/vdr/v2/resolutioncache/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewSubjectDIDsRequest generates requests for SubjectDIDs
 func NewSubjectDIDsRequest(server string, id string) (*http.Request, error) {
 	var err error
@@ -627,6 +836,50 @@ func NewSubjectDIDsRequest(server string, id string) (*http.Request, error) {
 	return req, nil
 }
 
+// NewCreatePeerDIDRequest calls the generic CreatePeerDID builder with application/json body
+func NewCreatePeerDIDRequest(server string, id string, body CreatePeerDIDJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreatePeerDIDRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewCreatePeerDIDRequestWithBody generates requests for CreatePeerDID with any type of body
+func NewCreatePeerDIDRequestWithBody(server string, id string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0 = id
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/vdr/v2/subject/%s/peer", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
 // NewFindServicesRequest generates requests for FindServices
 func NewFindServicesRequest(server string, id string, params *FindServicesParams) (*http.Request, error) {
 	var err error
@@ -867,6 +1120,50 @@ func NewAddVerificationMethodRequestWithBody(server string, id string, contentTy
 	return req, nil
 }
 
+// NewRotateVerificationMethodRequest calls the generic RotateVerificationMethod builder with application/json body
+func NewRotateVerificationMethodRequest(server string, id string, body RotateVerificationMethodJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewRotateVerificationMethodRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewRotateVerificationMethodRequestWithBody generates requests for RotateVerificationMethod with any type of body
+func NewRotateVerificationMethodRequestWithBody(server string, id string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0 = id
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/internal/vdr/v2/subject/%s/verificationmethod/rotate", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
 func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
 	for _, r := range c.RequestEditors {
 		if err := r(ctx, req); err != nil {
@@ -913,9 +1210,15 @@ type ClientWithResponsesInterface interface {
 	// GetRootWebDIDWithResponse request
 	GetRootWebDIDWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetRootWebDIDResponse, error)
 
+	// GetDIDConfigurationWithResponse request
+	GetDIDConfigurationWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetDIDConfigurationResponse, error)
+
 	// GetTenantWebDIDWithResponse request
 	GetTenantWebDIDWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetTenantWebDIDResponse, error)
 
+	// GetTenantWebVHLogWithResponse request
+	GetTenantWebVHLogWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetTenantWebVHLogResponse, error)
+
 	// ResolveDIDWithResponse request
 	ResolveDIDWithResponse(ctx context.Context, did string, reqEditors ...RequestEditorFn) (*ResolveDIDResponse, error)
 
@@ -930,9 +1233,16 @@ type ClientWithResponsesInterface interface {
 	// DeactivateWithResponse request
 	DeactivateWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*DeactivateResponse, error)
 
+	// InvalidateResolutionCacheWithResponse request
+	InvalidateResolutionCacheWithResponse(ctx context.Context, did string, reqEditors ...RequestEditorFn) (*InvalidateResolutionCacheResponse, error)
+
 	// SubjectDIDsWithResponse request
 	SubjectDIDsWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*SubjectDIDsResponse, error)
 
+	// CreatePeerDIDWithBodyWithResponse request with any body
+	CreatePeerDIDWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreatePeerDIDResponse, error)
+
+	CreatePeerDIDWithResponse(ctx context.Context, id string, body CreatePeerDIDJSONRequestBody, reqEditors ...RequestEditorFn) (*CreatePeerDIDResponse, error)
 	// FindServicesWithResponse request
 	FindServicesWithResponse(ctx context.Context, id string, params *FindServicesParams, reqEditors ...RequestEditorFn) (*FindServicesResponse, error)
 
@@ -953,6 +1263,10 @@ type ClientWithResponsesInterface interface {
 	AddVerificationMethodWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddVerificationMethodResponse, error)
 
 	AddVerificationMethodWithResponse(ctx context.Context, id string, body AddVerificationMethodJSONRequestBody, reqEditors ...RequestEditorFn) (*AddVerificationMethodResponse, error)
+	// RotateVerificationMethodWithBodyWithResponse request with any body
+	RotateVerificationMethodWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RotateVerificationMethodResponse, error)
+
+	RotateVerificationMethodWithResponse(ctx context.Context, id string, body RotateVerificationMethodJSONRequestBody, reqEditors ...RequestEditorFn) (*RotateVerificationMethodResponse, error)
 }
 
 type GetRootWebDIDResponse struct {
@@ -977,14 +1291,14 @@ func (r GetRootWebDIDResponse) StatusCode() int {
 	return 0
 }
 
-type GetTenantWebDIDResponse struct {
+type GetDIDConfigurationResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *DIDDocument
+	JSON200      *DIDConfiguration
 }
 
 // Status returns HTTPResponse.Status
-func (r GetTenantWebDIDResponse) Status() string {
+func (r GetDIDConfigurationResponse) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -992,21 +1306,64 @@ func (r GetTenantWebDIDResponse) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r GetTenantWebDIDResponse) StatusCode() int {
+func (r GetDIDConfigurationResponse) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type ResolveDIDResponse struct {
-	Body                          []byte
-	HTTPResponse                  *http.Response
-	JSON200                       *DIDResolutionResult
-	ApplicationproblemJSONDefault *struct {
-		// Detail A human-readable explanation specific to this occurrence of the problem.
-		Detail string `json:"detail"`
-
+type GetTenantWebDIDResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *DIDDocument
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTenantWebDIDResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTenantWebDIDResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetTenantWebVHLogResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTenantWebVHLogResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTenantWebVHLogResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ResolveDIDResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *DIDResolutionResult
+	ApplicationproblemJSONDefault *struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
 		// Status HTTP statuscode
 		Status float32 `json:"status"`
 
@@ -1126,6 +1483,37 @@ func (r DeactivateResponse) StatusCode() int {
 	return 0
 }
 
+type InvalidateResolutionCacheResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	ApplicationproblemJSONDefault *struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r InvalidateResolutionCacheResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r InvalidateResolutionCacheResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type SubjectDIDsResponse struct {
 	Body                          []byte
 	HTTPResponse                  *http.Response
@@ -1158,6 +1546,38 @@ func (r SubjectDIDsResponse) StatusCode() int {
 	return 0
 }
 
+type CreatePeerDIDResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *DIDDocument
+	ApplicationproblemJSONDefault *struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r CreatePeerDIDResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreatePeerDIDResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type FindServicesResponse struct {
 	Body                          []byte
 	HTTPResponse                  *http.Response
@@ -1317,6 +1737,38 @@ func (r AddVerificationMethodResponse) StatusCode() int {
 	return 0
 }
 
+type RotateVerificationMethodResponse struct {
+	Body                          []byte
+	HTTPResponse                  *http.Response
+	JSON200                       *[]VerificationMethod
+	ApplicationproblemJSONDefault *struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+}
+
+// Status returns HTTPResponse.Status
+func (r RotateVerificationMethodResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RotateVerificationMethodResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 // GetRootWebDIDWithResponse request returning *GetRootWebDIDResponse
 func (c *ClientWithResponses) GetRootWebDIDWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetRootWebDIDResponse, error) {
 	rsp, err := c.GetRootWebDID(ctx, reqEditors...)
@@ -1326,6 +1778,15 @@ func (c *ClientWithResponses) GetRootWebDIDWithResponse(ctx context.Context, req
 	return ParseGetRootWebDIDResponse(rsp)
 }
 
+// GetDIDConfigurationWithResponse request returning *GetDIDConfigurationResponse
+func (c *ClientWithResponses) GetDIDConfigurationWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetDIDConfigurationResponse, error) {
+	rsp, err := c.GetDIDConfiguration(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetDIDConfigurationResponse(rsp)
+}
+
 // GetTenantWebDIDWithResponse request returning *GetTenantWebDIDResponse
 func (c *ClientWithResponses) GetTenantWebDIDWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetTenantWebDIDResponse, error) {
 	rsp, err := c.GetTenantWebDID(ctx, id, reqEditors...)
@@ -1335,6 +1796,15 @@ func (c *ClientWithResponses) GetTenantWebDIDWithResponse(ctx context.Context, i
 	return ParseGetTenantWebDIDResponse(rsp)
 }
 
+// GetTenantWebVHLogWithResponse request returning *GetTenantWebVHLogResponse
+func (c *ClientWithResponses) GetTenantWebVHLogWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*GetTenantWebVHLogResponse, error) {
+	rsp, err := c.GetTenantWebVHLog(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTenantWebVHLogResponse(rsp)
+}
+
 // ResolveDIDWithResponse request returning *ResolveDIDResponse
 func (c *ClientWithResponses) ResolveDIDWithResponse(ctx context.Context, did string, reqEditors ...RequestEditorFn) (*ResolveDIDResponse, error) {
 	rsp, err := c.ResolveDID(ctx, did, reqEditors...)
@@ -1379,6 +1849,15 @@ func (c *ClientWithResponses) DeactivateWithResponse(ctx context.Context, id str
 	return ParseDeactivateResponse(rsp)
 }
 
+// InvalidateResolutionCacheWithResponse request returning *InvalidateResolutionCacheResponse
+func (c *ClientWithResponses) InvalidateResolutionCacheWithResponse(ctx context.Context, did string, reqEditors ...RequestEditorFn) (*InvalidateResolutionCacheResponse, error) {
+	rsp, err := c.InvalidateResolutionCache(ctx, did, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseInvalidateResolutionCacheResponse(rsp)
+}
+
 // SubjectDIDsWithResponse request returning *SubjectDIDsResponse
 func (c *ClientWithResponses) SubjectDIDsWithResponse(ctx context.Context, id string, reqEditors ...RequestEditorFn) (*SubjectDIDsResponse, error) {
 	rsp, err := c.SubjectDIDs(ctx, id, reqEditors...)
@@ -1388,6 +1867,23 @@ func (c *ClientWithResponses) SubjectDIDsWithResponse(ctx context.Context, id st
 	return ParseSubjectDIDsResponse(rsp)
 }
 
+// CreatePeerDIDWithBodyWithResponse request with arbitrary body returning *CreatePeerDIDResponse
+func (c *ClientWithResponses) CreatePeerDIDWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreatePeerDIDResponse, error) {
+	rsp, err := c.CreatePeerDIDWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreatePeerDIDResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreatePeerDIDWithResponse(ctx context.Context, id string, body CreatePeerDIDJSONRequestBody, reqEditors ...RequestEditorFn) (*CreatePeerDIDResponse, error) {
+	rsp, err := c.CreatePeerDID(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreatePeerDIDResponse(rsp)
+}
+
 // FindServicesWithResponse request returning *FindServicesResponse
 func (c *ClientWithResponses) FindServicesWithResponse(ctx context.Context, id string, params *FindServicesParams, reqEditors ...RequestEditorFn) (*FindServicesResponse, error) {
 	rsp, err := c.FindServices(ctx, id, params, reqEditors...)
@@ -1457,6 +1953,23 @@ func (c *ClientWithResponses) AddVerificationMethodWithResponse(ctx context.Cont
 	return ParseAddVerificationMethodResponse(rsp)
 }
 
+// RotateVerificationMethodWithBodyWithResponse request with arbitrary body returning *RotateVerificationMethodResponse
+func (c *ClientWithResponses) RotateVerificationMethodWithBodyWithResponse(ctx context.Context, id string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RotateVerificationMethodResponse, error) {
+	rsp, err := c.RotateVerificationMethodWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRotateVerificationMethodResponse(rsp)
+}
+
+func (c *ClientWithResponses) RotateVerificationMethodWithResponse(ctx context.Context, id string, body RotateVerificationMethodJSONRequestBody, reqEditors ...RequestEditorFn) (*RotateVerificationMethodResponse, error) {
+	rsp, err := c.RotateVerificationMethod(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRotateVerificationMethodResponse(rsp)
+}
+
 // ParseGetRootWebDIDResponse parses an HTTP response from a GetRootWebDIDWithResponse call
 func ParseGetRootWebDIDResponse(rsp *http.Response) (*GetRootWebDIDResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -1483,6 +1996,32 @@ func ParseGetRootWebDIDResponse(rsp *http.Response) (*GetRootWebDIDResponse, err
 	return response, nil
 }
 
+// ParseGetDIDConfigurationResponse parses an HTTP response from a GetDIDConfigurationWithResponse call
+func ParseGetDIDConfigurationResponse(rsp *http.Response) (*GetDIDConfigurationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetDIDConfigurationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest DIDConfiguration
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseGetTenantWebDIDResponse parses an HTTP response from a GetTenantWebDIDWithResponse call
 func ParseGetTenantWebDIDResponse(rsp *http.Response) (*GetTenantWebDIDResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -1509,6 +2048,22 @@ func ParseGetTenantWebDIDResponse(rsp *http.Response) (*GetTenantWebDIDResponse,
 	return response, nil
 }
 
+// ParseGetTenantWebVHLogResponse parses an HTTP response from a GetTenantWebVHLogWithResponse call
+func ParseGetTenantWebVHLogResponse(rsp *http.Response) (*GetTenantWebVHLogResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetTenantWebVHLogResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
 // ParseResolveDIDResponse parses an HTTP response from a ResolveDIDWithResponse call
 func ParseResolveDIDResponse(rsp *http.Response) (*ResolveDIDResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -1670,6 +2225,41 @@ func ParseDeactivateResponse(rsp *http.Response) (*DeactivateResponse, error) {
 	return response, nil
 }
 
+// ParseInvalidateResolutionCacheResponse parses an HTTP response from a InvalidateResolutionCacheWithResponse call
+func ParseInvalidateResolutionCacheResponse(rsp *http.Response) (*InvalidateResolutionCacheResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &InvalidateResolutionCacheResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest struct {
+			// Detail A human-readable explanation specific to this occurrence of the problem.
+			Detail string `json:"detail"`
+
+			// Status HTTP statuscode
+			Status float32 `json:"status"`
+
+			// Title A short, human-readable summary of the problem type.
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseSubjectDIDsResponse parses an HTTP response from a SubjectDIDsWithResponse call
 func ParseSubjectDIDsResponse(rsp *http.Response) (*SubjectDIDsResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -1712,6 +2302,48 @@ func ParseSubjectDIDsResponse(rsp *http.Response) (*SubjectDIDsResponse, error)
 	return response, nil
 }
 
+// ParseCreatePeerDIDResponse parses an HTTP response from a CreatePeerDIDWithResponse call
+func ParseCreatePeerDIDResponse(rsp *http.Response) (*CreatePeerDIDResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreatePeerDIDResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest DIDDocument
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest struct {
+			// Detail A human-readable explanation specific to this occurrence of the problem.
+			Detail string `json:"detail"`
+
+			// Status HTTP statuscode
+			Status float32 `json:"status"`
+
+			// Title A short, human-readable summary of the problem type.
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseFindServicesResponse parses an HTTP response from a FindServicesWithResponse call
 func ParseFindServicesResponse(rsp *http.Response) (*FindServicesResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -1915,14 +2547,62 @@ func ParseAddVerificationMethodResponse(rsp *http.Response) (*AddVerificationMet
 	return response, nil
 }
 
+// ParseRotateVerificationMethodResponse parses an HTTP response from a RotateVerificationMethodWithResponse call
+func ParseRotateVerificationMethodResponse(rsp *http.Response) (*RotateVerificationMethodResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RotateVerificationMethodResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []VerificationMethod
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest struct {
+			// Detail A human-readable explanation specific to this occurrence of the problem.
+			Detail string `json:"detail"`
+
+			// Status HTTP statuscode
+			Status float32 `json:"status"`
+
+			// Title A short, human-readable summary of the problem type.
+			Title string `json:"title"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 	// Returns the root did:web DID of this domain.
 	// (GET /.well-known/did.json)
 	GetRootWebDID(ctx echo.Context) error
+	// Returns the root did:web DID of this domain.
+	// (GET /.well-known/did-configuration.json)
+	GetDIDConfiguration(ctx echo.Context) error
 	// Returns the did:web DID for the specified tenant.
 	// (GET /iam/{id}/did.json)
 	GetTenantWebDID(ctx echo.Context, id string) error
+	// Returns the did:webvh DID log for the specified tenant.
+	// (GET /iam/{id}/did.jsonl)
+	GetTenantWebVHLog(ctx echo.Context, id string) error
 	// Resolves a DID document
 	// (GET /internal/vdr/v2/did/{did})
 	ResolveDID(ctx echo.Context, did string) error
@@ -1935,9 +2615,15 @@ type ServerInterface interface {
 	// Deactivate all DID Documents for a subject.
 	// (DELETE /internal/vdr/v2/subject/{id})
 	Deactivate(ctx echo.Context, id string) error
+	// Removes a DID document from the DID resolution cache.
+	// (DELETE /internal/vdr/v2/resolutioncache/{did})
+	InvalidateResolutionCache(ctx echo.Context, did string) error
 	// Lists all DIDs for a subject
 	// (GET /internal/vdr/v2/subject/{id})
 	SubjectDIDs(ctx echo.Context, id string) error
+	// Creates a did:peer DID for a subject, to be used for the relationship with a single party.
+	// (POST /internal/vdr/v2/subject/{id}/peer)
+	CreatePeerDID(ctx echo.Context, id string) error
 	// Find services of a subject
 	// (GET /internal/vdr/v2/subject/{id}/service)
 	FindServices(ctx echo.Context, id string, params FindServicesParams) error
@@ -1953,6 +2639,9 @@ type ServerInterface interface {
 	// Creates and adds one or more verificationMethods to each DID document in the subject.
 	// (POST /internal/vdr/v2/subject/{id}/verificationmethod)
 	AddVerificationMethod(ctx echo.Context, id string) error
+	// Rotates the keys of each DID document in the subject.
+	// (POST /internal/vdr/v2/subject/{id}/verificationmethod/rotate)
+	RotateVerificationMethod(ctx echo.Context, id string) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -1971,6 +2660,17 @@ func (w *ServerInterfaceWrapper) GetRootWebDID(ctx echo.Context) error {
 	return err
 }
 
+// GetDIDConfiguration converts echo context to params.
+func (w *ServerInterfaceWrapper) GetDIDConfiguration(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetDIDConfiguration(ctx)
+	return err
+}
+
 // GetTenantWebDID converts echo context to params.
 func (w *ServerInterfaceWrapper) GetTenantWebDID(ctx echo.Context) error {
 	var err error
@@ -1989,6 +2689,24 @@ func (w *ServerInterfaceWrapper) GetTenantWebDID(ctx echo.Context) error {
 	return err
 }
 
+// GetTenantWebVHLog converts echo context to params.
+func (w *ServerInterfaceWrapper) GetTenantWebVHLog(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetTenantWebVHLog(ctx, id)
+	return err
+}
+
 // ResolveDID converts echo context to params.
 func (w *ServerInterfaceWrapper) ResolveDID(ctx echo.Context) error {
 	var err error
@@ -2015,19 +2733,49 @@ func (w *ServerInterfaceWrapper) ListSubjects(ctx echo.Context) error {
 	return err
 }
 
-// CreateSubject converts echo context to params.
-func (w *ServerInterfaceWrapper) CreateSubject(ctx echo.Context) error {
+// CreateSubject converts echo context to params.
+func (w *ServerInterfaceWrapper) CreateSubject(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.CreateSubject(ctx)
+	return err
+}
+
+// Deactivate converts echo context to params.
+func (w *ServerInterfaceWrapper) Deactivate(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	id = ctx.Param("id")
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.Deactivate(ctx, id)
+	return err
+}
+
+// InvalidateResolutionCache converts echo context to params.
+func (w *ServerInterfaceWrapper) InvalidateResolutionCache(ctx echo.Context) error {
 	var err error
+	// ------------- Path parameter "did" -------------
+	var did string
+
+	did = ctx.Param("did")
 
 	ctx.Set(JwtBearerAuthScopes, []string{})
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.CreateSubject(ctx)
+	err = w.Handler.InvalidateResolutionCache(ctx, did)
 	return err
 }
 
-// Deactivate converts echo context to params.
-func (w *ServerInterfaceWrapper) Deactivate(ctx echo.Context) error {
+// SubjectDIDs converts echo context to params.
+func (w *ServerInterfaceWrapper) SubjectDIDs(ctx echo.Context) error {
 	var err error
 	// ------------- Path parameter "id" -------------
 	var id string
@@ -2037,12 +2785,12 @@ func (w *ServerInterfaceWrapper) Deactivate(ctx echo.Context) error {
 	ctx.Set(JwtBearerAuthScopes, []string{})
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.Deactivate(ctx, id)
+	err = w.Handler.SubjectDIDs(ctx, id)
 	return err
 }
 
-// SubjectDIDs converts echo context to params.
-func (w *ServerInterfaceWrapper) SubjectDIDs(ctx echo.Context) error {
+// CreatePeerDID converts echo context to params.
+func (w *ServerInterfaceWrapper) CreatePeerDID(ctx echo.Context) error {
 	var err error
 	// ------------- Path parameter "id" -------------
 	var id string
@@ -2052,7 +2800,7 @@ func (w *ServerInterfaceWrapper) SubjectDIDs(ctx echo.Context) error {
 	ctx.Set(JwtBearerAuthScopes, []string{})
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.SubjectDIDs(ctx, id)
+	err = w.Handler.CreatePeerDID(ctx, id)
 	return err
 }
 
@@ -2157,6 +2905,21 @@ func (w *ServerInterfaceWrapper) AddVerificationMethod(ctx echo.Context) error {
 	return err
 }
 
+// RotateVerificationMethod converts echo context to params.
+func (w *ServerInterfaceWrapper) RotateVerificationMethod(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	id = ctx.Param("id")
+
+	ctx.Set(JwtBearerAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.RotateVerificationMethod(ctx, id)
+	return err
+}
+
 // This is a simple interface which specifies echo.Route addition functions which
 // are present on both echo.Echo and echo.Group, since we want to allow using
 // either of them for path registration
@@ -2186,17 +2949,22 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 	}
 
 	router.GET(baseURL+"/.well-known/did.json", wrapper.GetRootWebDID)
+	router.GET(baseURL+"/.well-known/did-configuration.json", wrapper.GetDIDConfiguration)
 	router.GET(baseURL+"/iam/:id/did.json", wrapper.GetTenantWebDID)
+	router.GET(baseURL+"/iam/:id/did.jsonl", wrapper.GetTenantWebVHLog)
 	router.GET(baseURL+"/internal/vdr/v2/did/:did", wrapper.ResolveDID)
 	router.GET(baseURL+"/internal/vdr/v2/subject", wrapper.ListSubjects)
 	router.POST(baseURL+"/internal/vdr/v2/subject", wrapper.CreateSubject)
 	router.DELETE(baseURL+"/internal/vdr/v2/subject/:id", wrapper.Deactivate)
+	router.DELETE(baseURL+"/internal/vdr/v2/resolutioncache/:did", wrapper.InvalidateResolutionCache)
 	router.GET(baseURL+"/internal/vdr/v2/subject/:id", wrapper.SubjectDIDs)
+	router.POST(baseURL+"/internal/vdr/v2/subject/:id/peer", wrapper.CreatePeerDID)
 	router.GET(baseURL+"/internal/vdr/v2/subject/:id/service", wrapper.FindServices)
 	router.POST(baseURL+"/internal/vdr/v2/subject/:id/service", wrapper.CreateService)
 	router.DELETE(baseURL+"/internal/vdr/v2/subject/:id/service/:serviceId", wrapper.DeleteService)
 	router.POST(baseURL+"/internal/vdr/v2/subject/:id/service/:serviceId", wrapper.UpdateService)
 	router.POST(baseURL+"/internal/vdr/v2/subject/:id/verificationmethod", wrapper.AddVerificationMethod)
+	router.POST(baseURL+"/internal/vdr/v2/subject/:id/verificationmethod/rotate", wrapper.RotateVerificationMethod)
 
 }
 
@@ -2224,6 +2992,30 @@ func (response GetRootWebDID404Response) VisitGetRootWebDIDResponse(w http.Respo
 	return nil
 }
 
+type GetDIDConfigurationRequestObject struct {
+}
+
+type GetDIDConfigurationResponseObject interface {
+	VisitGetDIDConfigurationResponse(w http.ResponseWriter) error
+}
+
+type GetDIDConfiguration200JSONResponse DIDConfiguration
+
+func (response GetDIDConfiguration200JSONResponse) VisitGetDIDConfigurationResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetDIDConfiguration404Response struct {
+}
+
+func (response GetDIDConfiguration404Response) VisitGetDIDConfigurationResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
 type GetTenantWebDIDRequestObject struct {
 	Id string `json:"id"`
 }
@@ -2249,6 +3041,41 @@ func (response GetTenantWebDID404Response) VisitGetTenantWebDIDResponse(w http.R
 	return nil
 }
 
+type GetTenantWebVHLogRequestObject struct {
+	Id string `json:"id"`
+}
+
+type GetTenantWebVHLogResponseObject interface {
+	VisitGetTenantWebVHLogResponse(w http.ResponseWriter) error
+}
+
+type GetTenantWebVHLog200ApplicationjsonlResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response GetTenantWebVHLog200ApplicationjsonlResponse) VisitGetTenantWebVHLogResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/jsonl")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetTenantWebVHLog404Response struct {
+}
+
+func (response GetTenantWebVHLog404Response) VisitGetTenantWebVHLogResponse(w http.ResponseWriter) error {
+	w.WriteHeader(404)
+	return nil
+}
+
 type ResolveDIDRequestObject struct {
 	Did string `json:"did"`
 }
@@ -2399,6 +3226,43 @@ func (response DeactivatedefaultApplicationProblemPlusJSONResponse) VisitDeactiv
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
+type InvalidateResolutionCacheRequestObject struct {
+	Did string `json:"did"`
+}
+
+type InvalidateResolutionCacheResponseObject interface {
+	VisitInvalidateResolutionCacheResponse(w http.ResponseWriter) error
+}
+
+type InvalidateResolutionCache204Response struct {
+}
+
+func (response InvalidateResolutionCache204Response) VisitInvalidateResolutionCacheResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type InvalidateResolutionCachedefaultApplicationProblemPlusJSONResponse struct {
+	Body struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+	StatusCode int
+}
+
+func (response InvalidateResolutionCachedefaultApplicationProblemPlusJSONResponse) VisitInvalidateResolutionCacheResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
 type SubjectDIDsRequestObject struct {
 	Id string `json:"id"`
 }
@@ -2437,6 +3301,45 @@ func (response SubjectDIDsdefaultApplicationProblemPlusJSONResponse) VisitSubjec
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
+type CreatePeerDIDRequestObject struct {
+	Id   string `json:"id"`
+	Body *CreatePeerDIDJSONRequestBody
+}
+
+type CreatePeerDIDResponseObject interface {
+	VisitCreatePeerDIDResponse(w http.ResponseWriter) error
+}
+
+type CreatePeerDID200JSONResponse DIDDocument
+
+func (response CreatePeerDID200JSONResponse) VisitCreatePeerDIDResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CreatePeerDIDdefaultApplicationProblemPlusJSONResponse struct {
+	Body struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+	StatusCode int
+}
+
+func (response CreatePeerDIDdefaultApplicationProblemPlusJSONResponse) VisitCreatePeerDIDResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
 type FindServicesRequestObject struct {
 	Id     string `json:"id"`
 	Params FindServicesParams
@@ -2632,14 +3535,59 @@ func (response AddVerificationMethoddefaultApplicationProblemPlusJSONResponse) V
 	return json.NewEncoder(w).Encode(response.Body)
 }
 
+type RotateVerificationMethodRequestObject struct {
+	Id   string `json:"id"`
+	Body *RotateVerificationMethodJSONRequestBody
+}
+
+type RotateVerificationMethodResponseObject interface {
+	VisitRotateVerificationMethodResponse(w http.ResponseWriter) error
+}
+
+type RotateVerificationMethod200JSONResponse []VerificationMethod
+
+func (response RotateVerificationMethod200JSONResponse) VisitRotateVerificationMethodResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type RotateVerificationMethoddefaultApplicationProblemPlusJSONResponse struct {
+	Body struct {
+		// Detail A human-readable explanation specific to this occurrence of the problem.
+		Detail string `json:"detail"`
+
+		// Status HTTP statuscode
+		Status float32 `json:"status"`
+
+		// Title A short, human-readable summary of the problem type.
+		Title string `json:"title"`
+	}
+	StatusCode int
+}
+
+func (response RotateVerificationMethoddefaultApplicationProblemPlusJSONResponse) VisitRotateVerificationMethodResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(response.StatusCode)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
 	// Returns the root did:web DID of this domain.
 	// (GET /.well-known/did.json)
 	GetRootWebDID(ctx context.Context, request GetRootWebDIDRequestObject) (GetRootWebDIDResponseObject, error)
+	// Returns the root did:web DID of this domain.
+	// (GET /.well-known/did-configuration.json)
+	GetDIDConfiguration(ctx context.Context, request GetDIDConfigurationRequestObject) (GetDIDConfigurationResponseObject, error)
 	// Returns the did:web DID for the specified tenant.
 	// (GET /iam/{id}/did.json)
 	GetTenantWebDID(ctx context.Context, request GetTenantWebDIDRequestObject) (GetTenantWebDIDResponseObject, error)
+	// Returns the did:webvh DID log for the specified tenant.
+	// (GET /iam/{id}/did.jsonl)
+	GetTenantWebVHLog(ctx context.Context, request GetTenantWebVHLogRequestObject) (GetTenantWebVHLogResponseObject, error)
 	// Resolves a DID document
 	// (GET /internal/vdr/v2/did/{did})
 	ResolveDID(ctx context.Context, request ResolveDIDRequestObject) (ResolveDIDResponseObject, error)
@@ -2652,9 +3600,15 @@ type StrictServerInterface interface {
 	// Deactivate all DID Documents for a subject.
 	// (DELETE /internal/vdr/v2/subject/{id})
 	Deactivate(ctx context.Context, request DeactivateRequestObject) (DeactivateResponseObject, error)
+	// Removes a DID document from the DID resolution cache.
+	// (DELETE /internal/vdr/v2/resolutioncache/{did})
+	InvalidateResolutionCache(ctx context.Context, request InvalidateResolutionCacheRequestObject) (InvalidateResolutionCacheResponseObject, error)
 	// Lists all DIDs for a subject
 	// (GET /internal/vdr/v2/subject/{id})
 	SubjectDIDs(ctx context.Context, request SubjectDIDsRequestObject) (SubjectDIDsResponseObject, error)
+	// Creates a did:peer DID for a subject, to be used for the relationship with a single party.
+	// (POST /internal/vdr/v2/subject/{id}/peer)
+	CreatePeerDID(ctx context.Context, request CreatePeerDIDRequestObject) (CreatePeerDIDResponseObject, error)
 	// Find services of a subject
 	// (GET /internal/vdr/v2/subject/{id}/service)
 	FindServices(ctx context.Context, request FindServicesRequestObject) (FindServicesResponseObject, error)
@@ -2670,6 +3624,9 @@ type StrictServerInterface interface {
 	// Creates and adds one or more verificationMethods to each DID document in the subject.
 	// (POST /internal/vdr/v2/subject/{id}/verificationmethod)
 	AddVerificationMethod(ctx context.Context, request AddVerificationMethodRequestObject) (AddVerificationMethodResponseObject, error)
+	// Rotates the keys of each DID document in the subject.
+	// (POST /internal/vdr/v2/subject/{id}/verificationmethod/rotate)
+	RotateVerificationMethod(ctx context.Context, request RotateVerificationMethodRequestObject) (RotateVerificationMethodResponseObject, error)
 }
 
 type StrictHandlerFunc = strictecho.StrictEchoHandlerFunc
@@ -2707,6 +3664,29 @@ func (sh *strictHandler) GetRootWebDID(ctx echo.Context) error {
 	return nil
 }
 
+// GetDIDConfiguration operation middleware
+func (sh *strictHandler) GetDIDConfiguration(ctx echo.Context) error {
+	var request GetDIDConfigurationRequestObject
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetDIDConfiguration(ctx.Request().Context(), request.(GetDIDConfigurationRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetDIDConfiguration")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetDIDConfigurationResponseObject); ok {
+		return validResponse.VisitGetDIDConfigurationResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // GetTenantWebDID operation middleware
 func (sh *strictHandler) GetTenantWebDID(ctx echo.Context, id string) error {
 	var request GetTenantWebDIDRequestObject
@@ -2732,6 +3712,31 @@ func (sh *strictHandler) GetTenantWebDID(ctx echo.Context, id string) error {
 	return nil
 }
 
+// GetTenantWebVHLog operation middleware
+func (sh *strictHandler) GetTenantWebVHLog(ctx echo.Context, id string) error {
+	var request GetTenantWebVHLogRequestObject
+
+	request.Id = id
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetTenantWebVHLog(ctx.Request().Context(), request.(GetTenantWebVHLogRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetTenantWebVHLog")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetTenantWebVHLogResponseObject); ok {
+		return validResponse.VisitGetTenantWebVHLogResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // ResolveDID operation middleware
 func (sh *strictHandler) ResolveDID(ctx echo.Context, did string) error {
 	var request ResolveDIDRequestObject
@@ -2834,6 +3839,31 @@ func (sh *strictHandler) Deactivate(ctx echo.Context, id string) error {
 	return nil
 }
 
+// InvalidateResolutionCache operation middleware
+func (sh *strictHandler) InvalidateResolutionCache(ctx echo.Context, did string) error {
+	var request InvalidateResolutionCacheRequestObject
+
+	request.Did = did
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.InvalidateResolutionCache(ctx.Request().Context(), request.(InvalidateResolutionCacheRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "InvalidateResolutionCache")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(InvalidateResolutionCacheResponseObject); ok {
+		return validResponse.VisitInvalidateResolutionCacheResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // SubjectDIDs operation middleware
 func (sh *strictHandler) SubjectDIDs(ctx echo.Context, id string) error {
 	var request SubjectDIDsRequestObject
@@ -2859,6 +3889,37 @@ func (sh *strictHandler) SubjectDIDs(ctx echo.Context, id string) error {
 	return nil
 }
 
+// CreatePeerDID operation middleware
+func (sh *strictHandler) CreatePeerDID(ctx echo.Context, id string) error {
+	var request CreatePeerDIDRequestObject
+
+	request.Id = id
+
+	var body CreatePeerDIDJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.CreatePeerDID(ctx.Request().Context(), request.(CreatePeerDIDRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreatePeerDID")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(CreatePeerDIDResponseObject); ok {
+		return validResponse.VisitCreatePeerDIDResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // FindServices operation middleware
 func (sh *strictHandler) FindServices(ctx echo.Context, id string, params FindServicesParams) error {
 	var request FindServicesRequestObject
@@ -3004,3 +4065,34 @@ func (sh *strictHandler) AddVerificationMethod(ctx echo.Context, id string) erro
 	}
 	return nil
 }
+
+// RotateVerificationMethod operation middleware
+func (sh *strictHandler) RotateVerificationMethod(ctx echo.Context, id string) error {
+	var request RotateVerificationMethodRequestObject
+
+	request.Id = id
+
+	var body RotateVerificationMethodJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.RotateVerificationMethod(ctx.Request().Context(), request.(RotateVerificationMethodRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RotateVerificationMethod")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(RotateVerificationMethodResponseObject); ok {
+		return validResponse.VisitRotateVerificationMethodResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
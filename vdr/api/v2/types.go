@@ -19,12 +19,16 @@ package v2
 
 import (
 	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/vcr/credential"
 	"github.com/nuts-foundation/nuts-node/vdr/resolver"
 )
 
 // DIDDocument is an alias
 type DIDDocument = did.Document
 
+// DIDConfiguration is an alias
+type DIDConfiguration = credential.DIDConfiguration
+
 // DIDDocumentMetadata is an alias
 type DIDDocumentMetadata = resolver.DocumentMetadata
 
@@ -30,13 +30,35 @@ import (
 
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/vdr"
 	api "github.com/nuts-foundation/nuts-node/vdr/api/v1"
 	apiv2 "github.com/nuts-foundation/nuts-node/vdr/api/v2"
 	"github.com/nuts-foundation/nuts-node/vdr/didnuts"
 	"github.com/nuts-foundation/nuts-node/vdr/resolver"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
+// FlagSet contains flags relevant for the module
+func FlagSet() *pflag.FlagSet {
+	defs := vdr.DefaultConfig()
+	flagSet := pflag.NewFlagSet("vdr", pflag.ContinueOnError)
+	flagSet.Duration("vdr.keyrotation.graceperiod", defs.KeyRotation.GracePeriod, "Period a verification method that was replaced by a key rotation remains in the DID documents of the subject, "+
+		"so signatures created with the replaced key can still be verified. After this period, the verification method is removed and its private key is deleted.")
+	flagSet.Duration("vdr.keyrotation.maxage.assertion", defs.KeyRotation.MaxAge.Assertion, "Maximum age of keys used for assertion and authentication. "+
+		"Older keys of subjects are rotated automatically. Set to 0 to disable automatic rotation of these keys.")
+	flagSet.Duration("vdr.keyrotation.maxage.encryption", defs.KeyRotation.MaxAge.Encryption, "Maximum age of keys used for encryption (key agreement). "+
+		"Older keys of subjects are rotated automatically. Set to 0 to disable automatic rotation of these keys.")
+	flagSet.StringToString("vdr.remoteresolution.resolvers", defs.RemoteResolution.Resolvers, "Maps DID methods the node doesn't support itself to the base URL of a DIF Universal Resolver instance used to resolve DIDs of that method, "+
+		"e.g. ebsi=https://resolver.example.com. Multiple URLs can be given separated by spaces, which are tried in order if resolution fails. Only DIDs of the listed DID methods are resolved remotely.")
+	flagSet.Duration("vdr.remoteresolution.timeout", defs.RemoteResolution.Timeout, "Timeout for HTTP requests to the Universal Resolver instances.")
+	flagSet.Duration("vdr.remoteresolution.cachettl", defs.RemoteResolution.CacheTTL, "Period remotely resolved DID documents are cached. Set to 0 to disable caching.")
+	flagSet.StringToString("vdr.resolutioncache.ttl", defs.ResolutionCache.TTL, "Maps DID methods to the period DID documents resolved from remote sources are cached (persistently), e.g. web=5m. "+
+		"DID methods that aren't listed or have a TTL of 0 aren't cached. HTTP cache headers indicating a shorter period are honoured.")
+	flagSet.Duration("vdr.resolutioncache.staleiferror", defs.ResolutionCache.StaleIfError, "Period an expired cached DID document may still be used when resolving it fails because its source is unavailable.")
+	return flagSet
+}
+
 // Cmd contains sub-commands for the remote client
 func Cmd() *cobra.Command {
 	cmd := &cobra.Command{
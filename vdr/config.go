@@ -0,0 +1,88 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package vdr
+
+import "time"
+
+// Config holds the config for the VDR engine
+type Config struct {
+	// KeyRotation holds the config for rotating the keys of subjects
+	KeyRotation KeyRotationConfig `koanf:"keyrotation"`
+	// RemoteResolution holds the config for resolving DIDs of DID methods the node doesn't support itself
+	RemoteResolution RemoteResolutionConfig `koanf:"remoteresolution"`
+	// ResolutionCache holds the config for caching DID documents resolved from remote sources
+	ResolutionCache ResolutionCacheConfig `koanf:"resolutioncache"`
+}
+
+// ResolutionCacheConfig holds the config for the persistent cache of DID documents resolved from remote sources (e.g. did:web).
+type ResolutionCacheConfig struct {
+	// TTL maps DID methods (without did: prefix) to the period their DID documents are cached, e.g. web=5m.
+	// DID methods that aren't listed (or have a TTL of 0) aren't cached. HTTP cache headers indicating a shorter period are honoured.
+	TTL map[string]string `koanf:"ttl"`
+	// StaleIfError is the period an expired DID document may still be used when resolving it fails because its source is unavailable.
+	StaleIfError time.Duration `koanf:"staleiferror"`
+}
+
+// RemoteResolutionConfig holds the config for resolving DIDs of DID methods the node doesn't support itself,
+// using external DIF Universal Resolver instances.
+type RemoteResolutionConfig struct {
+	// Resolvers maps DID methods (without did: prefix) to the base URL of the Universal Resolver used to resolve DIDs of that method.
+	// Multiple base URLs can be given separated by spaces, which are tried in order if resolution fails.
+	// Only DIDs of the listed DID methods are resolved remotely.
+	Resolvers map[string]string `koanf:"resolvers"`
+	// Timeout is the timeout for HTTP requests to the Universal Resolvers.
+	Timeout time.Duration `koanf:"timeout"`
+	// CacheTTL is the period remotely resolved DID documents are cached. A value of 0 disables caching.
+	CacheTTL time.Duration `koanf:"cachettl"`
+}
+
+// KeyRotationConfig holds the config for rotating the keys of subjects
+type KeyRotationConfig struct {
+	// GracePeriod is the period a replaced verification method remains in the DID documents of a subject after a key rotation,
+	// so that signatures created with the replaced key (e.g. on issued credentials or status lists) can still be verified.
+	GracePeriod time.Duration `koanf:"graceperiod"`
+	// MaxAge holds the maximum age of keys per key usage. Keys exceeding it are rotated automatically.
+	MaxAge KeyMaxAgeConfig `koanf:"maxage"`
+}
+
+// KeyMaxAgeConfig holds the maximum age of keys per key usage, after which they are rotated automatically.
+// A value of 0 disables automatic rotation for that key usage.
+type KeyMaxAgeConfig struct {
+	// Assertion is the maximum age of keys used for assertion, authentication, capability invocation and capability delegation.
+	Assertion time.Duration `koanf:"assertion"`
+	// Encryption is the maximum age of keys used for key agreement.
+	Encryption time.Duration `koanf:"encryption"`
+}
+
+// DefaultConfig returns a fresh Config filled with default values
+func DefaultConfig() Config {
+	return Config{
+		KeyRotation: KeyRotationConfig{
+			GracePeriod: 7 * 24 * time.Hour,
+		},
+		RemoteResolution: RemoteResolutionConfig{
+			Timeout:  5 * time.Second,
+			CacheTTL: 15 * time.Minute,
+		},
+		ResolutionCache: ResolutionCacheConfig{
+			TTL:          map[string]string{"web": "5m"},
+			StaleIfError: 24 * time.Hour,
+		},
+	}
+}
@@ -0,0 +1,188 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package didcache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/storage/orm"
+	"github.com/nuts-foundation/nuts-node/vdr/log"
+	"github.com/nuts-foundation/nuts-node/vdr/resolver"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Cache is a persistent cache for DID documents resolved from remote sources (e.g. did:web).
+// Entries are stored in the SQL database, so they survive restarts.
+// DID documents are cached for the TTL configured for their DID method; DID methods without TTL aren't cached.
+// If the source indicates a shorter cache time (e.g. through HTTP cache headers), that is honoured.
+// When an entry has expired but resolving the DID fails because the source is unavailable,
+// the stale entry is returned if it expired less than staleIfError ago.
+type Cache struct {
+	db           *gorm.DB
+	ttl          map[string]time.Duration
+	staleIfError time.Duration
+	nowFunc      func() time.Time
+}
+
+// New creates a new Cache. The ttl map contains the time DID documents are cached, per DID method.
+func New(db *gorm.DB, ttl map[string]time.Duration, staleIfError time.Duration) *Cache {
+	return &Cache{
+		db:           db,
+		ttl:          ttl,
+		staleIfError: staleIfError,
+		nowFunc:      time.Now,
+	}
+}
+
+// Wrap returns a DIDResolver that caches the DID documents resolved by the given resolver.
+func (c *Cache) Wrap(underlying resolver.DIDResolver) resolver.DIDResolver {
+	return &cachingResolver{cache: c, underlying: underlying}
+}
+
+// Invalidate removes the cached DID document of the given DID, so it will be resolved from its source again.
+func (c *Cache) Invalidate(id did.DID) error {
+	return c.db.Where("did = ?", id.String()).Delete(&orm.DIDResolutionCacheEntry{}).Error
+}
+
+func (c *Cache) get(id did.DID) (*orm.DIDResolutionCacheEntry, error) {
+	var entry orm.DIDResolutionCacheEntry
+	err := c.db.Where("did = ?", id.String()).First(&entry).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (c *Cache) put(id did.DID, document *did.Document, metadata *resolver.DocumentMetadata) error {
+	now := c.nowFunc()
+	expiresAt := now.Add(c.ttl[id.Method])
+	if metadata.CacheUntil != nil {
+		if metadata.CacheUntil.IsZero() {
+			// source indicated the DID document must not be cached
+			return nil
+		}
+		if metadata.CacheUntil.Before(expiresAt) {
+			expiresAt = *metadata.CacheUntil
+		}
+	}
+	if !expiresAt.After(now) {
+		return nil
+	}
+	documentJSON, err := json.Marshal(document)
+	if err != nil {
+		return err
+	}
+	metadataJSON, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+	entry := orm.DIDResolutionCacheEntry{
+		DID:        id.String(),
+		Document:   string(documentJSON),
+		Metadata:   string(metadataJSON),
+		ExpiresAt:  expiresAt.Unix(),
+		StaleUntil: expiresAt.Add(c.staleIfError).Unix(),
+	}
+	return c.db.Transaction(func(tx *gorm.DB) error {
+		// prune entries that can't be used anymore to keep the cache from growing indefinitely
+		if err := tx.Where("stale_until < ?", now.Unix()).Delete(&orm.DIDResolutionCacheEntry{}).Error; err != nil {
+			return err
+		}
+		return tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&entry).Error
+	})
+}
+
+var _ resolver.DIDResolver = (*cachingResolver)(nil)
+
+type cachingResolver struct {
+	cache      *Cache
+	underlying resolver.DIDResolver
+}
+
+func (r *cachingResolver) Resolve(id did.DID, metadata *resolver.ResolveMetadata) (*did.Document, *resolver.DocumentMetadata, error) {
+	if r.cache.ttl[id.Method] <= 0 || !isCacheable(metadata) {
+		return r.underlying.Resolve(id, metadata)
+	}
+	now := r.cache.nowFunc()
+	entry, err := r.cache.get(id)
+	if err != nil {
+		log.Logger().WithError(err).Warnf("Unable to read DID resolution cache (did=%s)", id)
+	}
+	if entry != nil && entry.ExpiresAt > now.Unix() {
+		return fromEntry(*entry, metadata)
+	}
+	document, documentMetadata, err := r.underlying.Resolve(id, withAllowDeactivated(metadata))
+	if err != nil {
+		if entry != nil && entry.StaleUntil > now.Unix() && !resolver.IsFunctionalResolveError(err) && !errors.Is(err, resolver.ErrDIDMethodNotSupported) {
+			log.Logger().WithError(err).Warnf("Unable to resolve DID, using stale DID document from cache (did=%s)", id)
+			return fromEntry(*entry, metadata)
+		}
+		return nil, nil, err
+	}
+	if err := r.cache.put(id, document, documentMetadata); err != nil {
+		log.Logger().WithError(err).Warnf("Unable to store DID document in DID resolution cache (did=%s)", id)
+	}
+	if documentMetadata.Deactivated && (metadata == nil || !metadata.AllowDeactivated) {
+		return nil, nil, resolver.ErrDeactivated
+	}
+	return document, documentMetadata, nil
+}
+
+// isCacheable returns whether the DID resolution request can be served from the cache.
+// Requests for a specific version of a DID document are always resolved from the source.
+func isCacheable(metadata *resolver.ResolveMetadata) bool {
+	if metadata == nil {
+		return true
+	}
+	return metadata.ResolveTime == nil && metadata.VersionID == "" && metadata.SourceTransaction == nil && metadata.Hash == nil
+}
+
+// withAllowDeactivated returns a copy of the given metadata that allows deactivated DID documents to be resolved,
+// so deactivated DID documents are cached as well.
+func withAllowDeactivated(metadata *resolver.ResolveMetadata) *resolver.ResolveMetadata {
+	result := resolver.ResolveMetadata{}
+	if metadata != nil {
+		result = *metadata
+	}
+	result.AllowDeactivated = true
+	return &result
+}
+
+func fromEntry(entry orm.DIDResolutionCacheEntry, metadata *resolver.ResolveMetadata) (*did.Document, *resolver.DocumentMetadata, error) {
+	var document did.Document
+	if err := json.Unmarshal([]byte(entry.Document), &document); err != nil {
+		return nil, nil, fmt.Errorf("invalid DID document in DID resolution cache: %w", err)
+	}
+	var documentMetadata resolver.DocumentMetadata
+	if err := json.Unmarshal([]byte(entry.Metadata), &documentMetadata); err != nil {
+		return nil, nil, fmt.Errorf("invalid DID document metadata in DID resolution cache: %w", err)
+	}
+	if documentMetadata.Deactivated && (metadata == nil || !metadata.AllowDeactivated) {
+		return nil, nil, resolver.ErrDeactivated
+	}
+	return &document, &documentMetadata, nil
+}
@@ -0,0 +1,241 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package didcache
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/storage"
+	"github.com/nuts-foundation/nuts-node/storage/orm"
+	"github.com/nuts-foundation/nuts-node/vdr/resolver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+var webDID = did.MustParseDID("did:web:example.com")
+
+func TestCache_Resolve(t *testing.T) {
+	document := &did.Document{ID: webDID}
+	resolveError := errors.New("did:web HTTP error: connection refused")
+
+	t.Run("DID document is cached", func(t *testing.T) {
+		cache, underlying := newTestCache(t)
+		underlying.EXPECT().Resolve(webDID, gomock.Any()).Return(document, &resolver.DocumentMetadata{}, nil)
+		cachingResolver := cache.Wrap(underlying)
+
+		_, _, err := cachingResolver.Resolve(webDID, nil)
+		require.NoError(t, err)
+		result, metadata, err := cachingResolver.Resolve(webDID, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, webDID, result.ID)
+		assert.NotNil(t, metadata)
+	})
+	t.Run("cached DID document survives restart", func(t *testing.T) {
+		cache, underlying := newTestCache(t)
+		underlying.EXPECT().Resolve(webDID, gomock.Any()).Return(document, &resolver.DocumentMetadata{}, nil)
+		_, _, err := cache.Wrap(underlying).Resolve(webDID, nil)
+		require.NoError(t, err)
+
+		result, _, err := New(cache.db, cache.ttl, cache.staleIfError).Wrap(underlying).Resolve(webDID, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, webDID, result.ID)
+	})
+	t.Run("expired DID document is resolved again", func(t *testing.T) {
+		cache, underlying := newTestCache(t)
+		underlying.EXPECT().Resolve(webDID, gomock.Any()).Return(document, &resolver.DocumentMetadata{}, nil).Times(2)
+		cachingResolver := cache.Wrap(underlying)
+
+		_, _, err := cachingResolver.Resolve(webDID, nil)
+		require.NoError(t, err)
+		cache.nowFunc = func() time.Time {
+			return time.Now().Add(2 * time.Minute)
+		}
+		_, _, err = cachingResolver.Resolve(webDID, nil)
+
+		require.NoError(t, err)
+	})
+	t.Run("DID method without TTL is not cached", func(t *testing.T) {
+		cache, underlying := newTestCache(t)
+		id := did.MustParseDID("did:example:123")
+		underlying.EXPECT().Resolve(id, nil).Return(&did.Document{ID: id}, &resolver.DocumentMetadata{}, nil).Times(2)
+		cachingResolver := cache.Wrap(underlying)
+
+		_, _, err := cachingResolver.Resolve(id, nil)
+		require.NoError(t, err)
+		_, _, err = cachingResolver.Resolve(id, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, countEntries(t, cache))
+	})
+	t.Run("versioned resolution is not cached", func(t *testing.T) {
+		cache, underlying := newTestCache(t)
+		metadata := &resolver.ResolveMetadata{VersionID: "1"}
+		underlying.EXPECT().Resolve(webDID, metadata).Return(document, &resolver.DocumentMetadata{}, nil)
+
+		_, _, err := cache.Wrap(underlying).Resolve(webDID, metadata)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, countEntries(t, cache))
+	})
+	t.Run("HTTP cache headers", func(t *testing.T) {
+		t.Run("shorter cache time is honoured", func(t *testing.T) {
+			cache, underlying := newTestCache(t)
+			cacheUntil := time.Now().Add(10 * time.Second)
+			underlying.EXPECT().Resolve(webDID, gomock.Any()).Return(document, &resolver.DocumentMetadata{CacheUntil: &cacheUntil}, nil)
+
+			_, _, err := cache.Wrap(underlying).Resolve(webDID, nil)
+
+			require.NoError(t, err)
+			entry, err := cache.get(webDID)
+			require.NoError(t, err)
+			assert.Equal(t, cacheUntil.Unix(), entry.ExpiresAt)
+			assert.Equal(t, cacheUntil.Add(time.Hour).Unix(), entry.StaleUntil)
+		})
+		t.Run("longer cache time is capped to the TTL", func(t *testing.T) {
+			cache, underlying := newTestCache(t)
+			cacheUntil := time.Now().Add(time.Hour)
+			underlying.EXPECT().Resolve(webDID, gomock.Any()).Return(document, &resolver.DocumentMetadata{CacheUntil: &cacheUntil}, nil)
+
+			_, _, err := cache.Wrap(underlying).Resolve(webDID, nil)
+
+			require.NoError(t, err)
+			entry, err := cache.get(webDID)
+			require.NoError(t, err)
+			assert.LessOrEqual(t, entry.ExpiresAt, time.Now().Add(time.Minute).Unix())
+		})
+		t.Run("must not be cached", func(t *testing.T) {
+			cache, underlying := newTestCache(t)
+			underlying.EXPECT().Resolve(webDID, gomock.Any()).Return(document, &resolver.DocumentMetadata{CacheUntil: &time.Time{}}, nil)
+
+			_, _, err := cache.Wrap(underlying).Resolve(webDID, nil)
+
+			require.NoError(t, err)
+			assert.Equal(t, 0, countEntries(t, cache))
+		})
+	})
+	t.Run("stale-if-error", func(t *testing.T) {
+		t.Run("stale DID document is returned when resolving fails", func(t *testing.T) {
+			cache, underlying := newTestCache(t)
+			gomock.InOrder(
+				underlying.EXPECT().Resolve(webDID, gomock.Any()).Return(document, &resolver.DocumentMetadata{}, nil),
+				underlying.EXPECT().Resolve(webDID, gomock.Any()).Return(nil, nil, resolveError),
+			)
+			cachingResolver := cache.Wrap(underlying)
+			_, _, err := cachingResolver.Resolve(webDID, nil)
+			require.NoError(t, err)
+			cache.nowFunc = func() time.Time {
+				return time.Now().Add(30 * time.Minute)
+			}
+
+			result, _, err := cachingResolver.Resolve(webDID, nil)
+
+			require.NoError(t, err)
+			assert.Equal(t, webDID, result.ID)
+		})
+		t.Run("too stale", func(t *testing.T) {
+			cache, underlying := newTestCache(t)
+			gomock.InOrder(
+				underlying.EXPECT().Resolve(webDID, gomock.Any()).Return(document, &resolver.DocumentMetadata{}, nil),
+				underlying.EXPECT().Resolve(webDID, gomock.Any()).Return(nil, nil, resolveError),
+			)
+			cachingResolver := cache.Wrap(underlying)
+			_, _, err := cachingResolver.Resolve(webDID, nil)
+			require.NoError(t, err)
+			cache.nowFunc = func() time.Time {
+				return time.Now().Add(2 * time.Hour)
+			}
+
+			_, _, err = cachingResolver.Resolve(webDID, nil)
+
+			assert.ErrorIs(t, err, resolveError)
+		})
+		t.Run("functional errors are returned", func(t *testing.T) {
+			cache, underlying := newTestCache(t)
+			gomock.InOrder(
+				underlying.EXPECT().Resolve(webDID, gomock.Any()).Return(document, &resolver.DocumentMetadata{}, nil),
+				underlying.EXPECT().Resolve(webDID, gomock.Any()).Return(nil, nil, resolver.ErrNotFound),
+			)
+			cachingResolver := cache.Wrap(underlying)
+			_, _, err := cachingResolver.Resolve(webDID, nil)
+			require.NoError(t, err)
+			cache.nowFunc = func() time.Time {
+				return time.Now().Add(30 * time.Minute)
+			}
+
+			_, _, err = cachingResolver.Resolve(webDID, nil)
+
+			assert.ErrorIs(t, err, resolver.ErrNotFound)
+		})
+	})
+	t.Run("deactivated DID document", func(t *testing.T) {
+		cache, underlying := newTestCache(t)
+		underlying.EXPECT().Resolve(webDID, &resolver.ResolveMetadata{AllowDeactivated: true}).Return(document, &resolver.DocumentMetadata{Deactivated: true}, nil)
+		cachingResolver := cache.Wrap(underlying)
+
+		_, _, err := cachingResolver.Resolve(webDID, nil)
+		assert.ErrorIs(t, err, resolver.ErrDeactivated)
+		_, _, err = cachingResolver.Resolve(webDID, nil)
+		assert.ErrorIs(t, err, resolver.ErrDeactivated)
+		_, metadata, err := cachingResolver.Resolve(webDID, &resolver.ResolveMetadata{AllowDeactivated: true})
+		require.NoError(t, err)
+		assert.True(t, metadata.Deactivated)
+	})
+}
+
+func TestCache_Invalidate(t *testing.T) {
+	cache, underlying := newTestCache(t)
+	otherDID := did.MustParseDID("did:web:example.com:other")
+	underlying.EXPECT().Resolve(gomock.Any(), gomock.Any()).DoAndReturn(func(id did.DID, _ *resolver.ResolveMetadata) (*did.Document, *resolver.DocumentMetadata, error) {
+		return &did.Document{ID: id}, &resolver.DocumentMetadata{}, nil
+	}).Times(3)
+	cachingResolver := cache.Wrap(underlying)
+	_, _, err := cachingResolver.Resolve(webDID, nil)
+	require.NoError(t, err)
+	_, _, err = cachingResolver.Resolve(otherDID, nil)
+	require.NoError(t, err)
+
+	err = cache.Invalidate(webDID)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, countEntries(t, cache))
+	// resolves webDID again, otherDID is still cached
+	_, _, err = cachingResolver.Resolve(webDID, nil)
+	require.NoError(t, err)
+	_, _, err = cachingResolver.Resolve(otherDID, nil)
+	require.NoError(t, err)
+}
+
+func newTestCache(t *testing.T) (*Cache, *resolver.MockDIDResolver) {
+	storageEngine := storage.NewTestStorageEngine(t)
+	require.NoError(t, storageEngine.Start())
+	cache := New(storageEngine.GetSQLDatabase(), map[string]time.Duration{"web": time.Minute}, time.Hour)
+	return cache, resolver.NewMockDIDResolver(gomock.NewController(t))
+}
+
+func countEntries(t *testing.T, cache *Cache) int {
+	var count int64
+	require.NoError(t, cache.db.Model(&orm.DIDResolutionCacheEntry{}).Count(&count).Error)
+	return int(count)
+}
@@ -0,0 +1,123 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package didjwk
+
+import (
+	"context"
+	"crypto"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/did"
+	nutsCrypto "github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/nuts-foundation/nuts-node/storage/orm"
+	"github.com/nuts-foundation/nuts-node/vdr/didsubject"
+)
+
+var _ didsubject.MethodManager = (*Manager)(nil)
+
+// errImmutable is returned when a did:jwk DID document is changed, which isn't possible since the DID is derived from its key.
+var errImmutable = errors.New("did:jwk DID documents can't be changed")
+
+// NewManager creates a new Manager to create did:jwk DID documents.
+func NewManager(keyStore nutsCrypto.KeyStore) *Manager {
+	return &Manager{
+		keyStore: keyStore,
+	}
+}
+
+// Manager creates did:jwk DID documents, of which the key is stored in the KeyStore.
+// Nothing is published: the DID contains its key, so any party can resolve it.
+// Since the DID is derived from its key, the DID document can't be changed afterward.
+type Manager struct {
+	keyStore nutsCrypto.KeyStore
+}
+
+func (m Manager) NewDocument(ctx context.Context, keyFlags orm.DIDKeyFlags) (*orm.DidDocument, error) {
+	if keyFlags.Is(orm.KeyAgreementUsage) {
+		return nil, errors.New("key agreement not supported for did:jwk")
+	}
+	var verificationMethodID did.DIDURL
+	_, publicKey, err := m.keyStore.New(ctx, func(publicKey crypto.PublicKey) (string, error) {
+		id, err := didFromPublicKey(publicKey)
+		if err != nil {
+			return "", err
+		}
+		verificationMethodID = did.DIDURL{DID: *id, Fragment: "0"}
+		return verificationMethodID.String(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	verificationMethod, err := did.NewVerificationMethod(verificationMethodID, ssi.JsonWebKey2020, verificationMethodID.DID, publicKey)
+	if err != nil {
+		return nil, err
+	}
+	asJson, _ := json.Marshal(verificationMethod)
+
+	now := time.Now().Unix()
+	return &orm.DidDocument{
+		DID: orm.DID{
+			ID: verificationMethodID.DID.String(),
+		},
+		CreatedAt: now,
+		UpdatedAt: now,
+		Version:   0,
+		VerificationMethods: []orm.VerificationMethod{
+			{
+				ID: verificationMethodID.String(),
+				// the resolver only adds the key as assertionMethod
+				KeyTypes: orm.VerificationMethodKeyType(orm.AssertionMethodUsage),
+				Data:     asJson,
+			},
+		},
+	}, nil
+}
+
+// NewVerificationMethod returns an error, since did:jwk DID documents can't be changed.
+func (m Manager) NewVerificationMethod(_ context.Context, _ did.DID, _ orm.DIDKeyFlags) (*did.VerificationMethod, error) {
+	return nil, errImmutable
+}
+
+// Commit does nothing for did:jwk, since nothing is published.
+func (m Manager) Commit(_ context.Context, _ orm.DIDChangeLog) error {
+	return nil
+}
+
+// IsCommitted always returns true for did:jwk, since nothing is published.
+func (m Manager) IsCommitted(_ context.Context, _ orm.DIDChangeLog) (bool, error) {
+	return true, nil
+}
+
+// didFromPublicKey derives the did:jwk DID from the given public key: the base64url encoded JWK.
+func didFromPublicKey(publicKey crypto.PublicKey) (*did.DID, error) {
+	key, err := jwk.FromRaw(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	keyJSON, err := json.Marshal(key)
+	if err != nil {
+		return nil, err
+	}
+	return did.ParseDID("did:" + MethodName + ":" + base64.RawURLEncoding.EncodeToString(keyJSON))
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package didjwk
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/audit"
+	nutsCrypto "github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/nuts-foundation/nuts-node/storage"
+	"github.com/nuts-foundation/nuts-node/storage/orm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_NewDocument(t *testing.T) {
+	ctx := audit.TestContext()
+	manager, keyStore := newTestManager(t)
+
+	t.Run("ok", func(t *testing.T) {
+		doc, err := manager.NewDocument(ctx, orm.AssertionKeyUsage())
+
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(doc.DID.ID, "did:jwk:"))
+		require.Len(t, doc.VerificationMethods, 1)
+		assert.Equal(t, doc.DID.ID+"#0", doc.VerificationMethods[0].ID)
+		assert.Equal(t, orm.VerificationMethodKeyType(orm.AssertionMethodUsage), doc.VerificationMethods[0].KeyTypes)
+		t.Run("DID can be resolved", func(t *testing.T) {
+			resolved, _, err := Resolver{}.Resolve(did.MustParseDID(doc.DID.ID), nil)
+			require.NoError(t, err)
+
+			stored, err := doc.ToDIDDocument()
+			require.NoError(t, err)
+			require.Len(t, resolved.AssertionMethod, 1)
+			assert.Equal(t, stored.AssertionMethod[0].ID.String(), resolved.AssertionMethod[0].ID.String())
+			assert.Equal(t, stored.AssertionMethod[0].PublicKeyJwk, resolved.AssertionMethod[0].PublicKeyJwk)
+		})
+		t.Run("private key is stored under the verification method ID", func(t *testing.T) {
+			exists, err := keyStore.Exists(ctx, doc.VerificationMethods[0].ID)
+			require.NoError(t, err)
+			assert.True(t, exists)
+		})
+	})
+	t.Run("key agreement is not supported", func(t *testing.T) {
+		doc, err := manager.NewDocument(ctx, orm.AssertionKeyUsage()|orm.EncryptionKeyUsage())
+
+		assert.EqualError(t, err, "key agreement not supported for did:jwk")
+		assert.Nil(t, doc)
+	})
+}
+
+func TestManager_NewVerificationMethod(t *testing.T) {
+	manager, _ := newTestManager(t)
+
+	vm, err := manager.NewVerificationMethod(audit.TestContext(), did.MustParseDID("did:jwk:eyJ9"), orm.AssertionKeyUsage())
+
+	assert.ErrorIs(t, err, errImmutable)
+	assert.Nil(t, vm)
+}
+
+func TestManager_Commit(t *testing.T) {
+	manager, _ := newTestManager(t)
+
+	assert.NoError(t, manager.Commit(audit.TestContext(), orm.DIDChangeLog{}))
+	committed, err := manager.IsCommitted(audit.TestContext(), orm.DIDChangeLog{})
+	assert.NoError(t, err)
+	assert.True(t, committed)
+}
+
+func newTestManager(t *testing.T) (*Manager, nutsCrypto.KeyStore) {
+	storageEngine := storage.NewTestStorageEngine(t)
+	require.NoError(t, storageEngine.Start())
+	keyStore := nutsCrypto.NewDatabaseCryptoInstance(storageEngine.GetSQLDatabase())
+	return NewManager(keyStore), keyStore
+}
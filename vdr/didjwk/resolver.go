@@ -53,8 +53,11 @@ func (w Resolver) Resolve(id did.DID, _ *resolver.ResolveMetadata) (*did.Documen
 	// Get the third section of the did, e.g. "did:jwk:..."
 	b64EncodedJWK := id.ID
 
-	// Decode the base64 to JWK
-	encodedJWK, err := base64.RawStdEncoding.DecodeString(b64EncodedJWK)
+	// Decode the base64url to JWK. For backwards compatibility, DIDs that were (incorrectly) encoded using standard base64 are accepted as well.
+	encodedJWK, err := base64.RawURLEncoding.DecodeString(b64EncodedJWK)
+	if err != nil {
+		encodedJWK, err = base64.RawStdEncoding.DecodeString(b64EncodedJWK)
+	}
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to decode base64 (%v): %w", b64EncodedJWK, err)
 	}
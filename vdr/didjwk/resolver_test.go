@@ -61,6 +61,7 @@ func TestResolver_Resolve(t *testing.T) {
 
 	// Ensure the canonical example from the DID JWK spec can be resolved
 	t.Run("resolve did:jwk", success(b64(canonicalJWK)))
+	t.Run("resolve base64url encoded did:jwk", success(base64.RawURLEncoding.EncodeToString([]byte(canonicalJWK))))
 
 	// Test the various failure modes of resolution
 	t.Run("resolution errors", func(t *testing.T) {
@@ -0,0 +1,139 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package didkey
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mr-tron/base58"
+	"github.com/multiformats/go-multicodec"
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/did"
+	nutsCrypto "github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/nuts-foundation/nuts-node/storage/orm"
+	"github.com/nuts-foundation/nuts-node/vdr/didsubject"
+)
+
+var _ didsubject.MethodManager = (*Manager)(nil)
+
+// errImmutable is returned when a did:key DID document is changed, which isn't possible since the DID is derived from its key.
+var errImmutable = errors.New("did:key DID documents can't be changed")
+
+// NewManager creates a new Manager to create did:key DID documents.
+func NewManager(keyStore nutsCrypto.KeyStore) *Manager {
+	return &Manager{
+		keyStore: keyStore,
+	}
+}
+
+// Manager creates did:key DID documents, of which the key is stored in the KeyStore.
+// Nothing is published: the DID contains its key, so any party can resolve it.
+// Since the DID is derived from its key, the DID document can't be changed afterward.
+type Manager struct {
+	keyStore nutsCrypto.KeyStore
+}
+
+func (m Manager) NewDocument(ctx context.Context, _ orm.DIDKeyFlags) (*orm.DidDocument, error) {
+	var verificationMethodID did.DIDURL
+	_, publicKey, err := m.keyStore.New(ctx, func(publicKey crypto.PublicKey) (string, error) {
+		id, err := didFromPublicKey(publicKey)
+		if err != nil {
+			return "", err
+		}
+		verificationMethodID = did.DIDURL{DID: *id, Fragment: id.ID}
+		return verificationMethodID.String(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	verificationMethod, err := did.NewVerificationMethod(verificationMethodID, ssi.JsonWebKey2020, verificationMethodID.DID, publicKey)
+	if err != nil {
+		return nil, err
+	}
+	asJson, _ := json.Marshal(verificationMethod)
+
+	now := time.Now().Unix()
+	return &orm.DidDocument{
+		DID: orm.DID{
+			ID: verificationMethodID.DID.String(),
+		},
+		CreatedAt: now,
+		UpdatedAt: now,
+		Version:   0,
+		VerificationMethods: []orm.VerificationMethod{
+			{
+				ID: verificationMethodID.String(),
+				// the resolver adds the key for every verification relationship
+				KeyTypes: orm.VerificationMethodKeyType(orm.AssertionKeyUsage() | orm.EncryptionKeyUsage()),
+				Data:     asJson,
+			},
+		},
+	}, nil
+}
+
+// NewVerificationMethod returns an error, since did:key DID documents can't be changed.
+func (m Manager) NewVerificationMethod(_ context.Context, _ did.DID, _ orm.DIDKeyFlags) (*did.VerificationMethod, error) {
+	return nil, errImmutable
+}
+
+// Commit does nothing for did:key, since nothing is published.
+func (m Manager) Commit(_ context.Context, _ orm.DIDChangeLog) error {
+	return nil
+}
+
+// IsCommitted always returns true for did:key, since nothing is published.
+func (m Manager) IsCommitted(_ context.Context, _ orm.DIDChangeLog) (bool, error) {
+	return true, nil
+}
+
+// didFromPublicKey derives the did:key DID from the given public key: the base58btc encoded multicodec public key.
+// See https://w3c-ccg.github.io/did-method-key/#create
+func didFromPublicKey(publicKey crypto.PublicKey) (*did.DID, error) {
+	var code multicodec.Code
+	var keyBytes []byte
+	switch key := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		switch key.Curve {
+		case elliptic.P256():
+			code = multicodec.P256Pub
+		case elliptic.P384():
+			code = multicodec.P384Pub
+		default:
+			return nil, fmt.Errorf("did:key: unsupported curve: %s", key.Curve.Params().Name)
+		}
+		keyBytes = elliptic.MarshalCompressed(key.Curve, key.X, key.Y)
+	case ed25519.PublicKey:
+		code = multicodec.Ed25519Pub
+		keyBytes = key
+	default:
+		return nil, fmt.Errorf("did:key: unsupported public key type: %T", publicKey)
+	}
+	mcBytes := binary.AppendUvarint(nil, uint64(code))
+	mcBytes = append(mcBytes, keyBytes...)
+	return did.ParseDID("did:" + MethodName + ":z" + base58.EncodeAlphabet(mcBytes, base58.BTCAlphabet))
+}
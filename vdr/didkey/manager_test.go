@@ -0,0 +1,119 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package didkey
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"strings"
+	"testing"
+
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/audit"
+	nutsCrypto "github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/nuts-foundation/nuts-node/storage"
+	"github.com/nuts-foundation/nuts-node/storage/orm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_NewDocument(t *testing.T) {
+	ctx := audit.TestContext()
+	manager, keyStore := newTestManager(t)
+
+	t.Run("ok", func(t *testing.T) {
+		doc, err := manager.NewDocument(ctx, orm.AssertionKeyUsage())
+
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(doc.DID.ID, "did:key:z"))
+		require.Len(t, doc.VerificationMethods, 1)
+		assert.Equal(t, doc.DID.ID+"#"+strings.TrimPrefix(doc.DID.ID, "did:key:"), doc.VerificationMethods[0].ID)
+		assert.Equal(t, orm.VerificationMethodKeyType(orm.AssertionKeyUsage()|orm.KeyAgreementUsage), doc.VerificationMethods[0].KeyTypes)
+		t.Run("DID can be resolved", func(t *testing.T) {
+			resolved, _, err := Resolver{}.Resolve(did.MustParseDID(doc.DID.ID), nil)
+			require.NoError(t, err)
+
+			stored, err := doc.ToDIDDocument()
+			require.NoError(t, err)
+			require.Len(t, resolved.AssertionMethod, 1)
+			assert.Equal(t, stored.AssertionMethod[0].ID.String(), resolved.AssertionMethod[0].ID.String())
+			assert.Equal(t, stored.AssertionMethod[0].PublicKeyJwk, resolved.AssertionMethod[0].PublicKeyJwk)
+		})
+		t.Run("private key is stored under the verification method ID", func(t *testing.T) {
+			exists, err := keyStore.Exists(ctx, doc.VerificationMethods[0].ID)
+			require.NoError(t, err)
+			assert.True(t, exists)
+		})
+	})
+	t.Run("key agreement", func(t *testing.T) {
+		doc, err := manager.NewDocument(ctx, orm.AssertionKeyUsage()|orm.EncryptionKeyUsage())
+
+		require.NoError(t, err)
+		assert.Len(t, doc.VerificationMethods, 1)
+	})
+}
+
+func Test_didFromPublicKey(t *testing.T) {
+	t.Run("Ed25519", func(t *testing.T) {
+		publicKey, _, _ := ed25519.GenerateKey(rand.Reader)
+
+		id, err := didFromPublicKey(publicKey)
+
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(id.String(), "did:key:z6Mk"))
+		resolved, _, err := Resolver{}.Resolve(*id, nil)
+		require.NoError(t, err)
+		resolvedKey, err := resolved.VerificationMethod[0].PublicKey()
+		require.NoError(t, err)
+		assert.Equal(t, publicKey, resolvedKey)
+	})
+	t.Run("unsupported key type", func(t *testing.T) {
+		privateKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+
+		_, err := didFromPublicKey(privateKey.Public())
+
+		assert.EqualError(t, err, "did:key: unsupported public key type: *rsa.PublicKey")
+	})
+}
+
+func TestManager_NewVerificationMethod(t *testing.T) {
+	manager, _ := newTestManager(t)
+
+	vm, err := manager.NewVerificationMethod(audit.TestContext(), did.MustParseDID("did:key:z6Mk"), orm.AssertionKeyUsage())
+
+	assert.ErrorIs(t, err, errImmutable)
+	assert.Nil(t, vm)
+}
+
+func TestManager_Commit(t *testing.T) {
+	manager, _ := newTestManager(t)
+
+	assert.NoError(t, manager.Commit(audit.TestContext(), orm.DIDChangeLog{}))
+	committed, err := manager.IsCommitted(audit.TestContext(), orm.DIDChangeLog{})
+	assert.NoError(t, err)
+	assert.True(t, committed)
+}
+
+func newTestManager(t *testing.T) (*Manager, nutsCrypto.KeyStore) {
+	storageEngine := storage.NewTestStorageEngine(t)
+	require.NoError(t, storageEngine.Start())
+	keyStore := nutsCrypto.NewDatabaseCryptoInstance(storageEngine.GetSQLDatabase())
+	return NewManager(keyStore), keyStore
+}
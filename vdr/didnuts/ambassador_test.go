@@ -81,6 +81,10 @@ func (m *mockKeyStore) EncryptJWE(ctx context.Context, payload []byte, headers m
 	panic("not implemented")
 }
 
+func (m *mockKeyStore) EncryptJWEForRecipients(ctx context.Context, payload []byte, headers map[string]interface{}, recipients []jwk.Key) (string, error) {
+	panic("not implemented")
+}
+
 func (m *mockKeyStore) DecryptJWE(ctx context.Context, message string) (body []byte, headers map[string]interface{}, err error) {
 	panic("not implemented")
 }
@@ -109,10 +113,22 @@ func (m *mockKeyStore) SignDPoP(ctx context.Context, token dpop.DPoP, kid string
 	panic("not implemented")
 }
 
+func (m *mockKeyStore) SignData(ctx context.Context, data []byte, kid string) ([]byte, error) {
+	panic("not implemented")
+}
+
 func (m *mockKeyStore) Delete(ctx context.Context, kid string) error {
 	panic("not implemented")
 }
 
+func (m *mockKeyStore) Backup(ctx context.Context, key nutsCrypto.ArchiveKey) ([]byte, error) {
+	panic("not implemented")
+}
+
+func (m *mockKeyStore) Restore(ctx context.Context, archive []byte, key nutsCrypto.ArchiveKey) ([]string, error) {
+	panic("not implemented")
+}
+
 type testTransaction struct {
 	clock        uint32
 	signingKey   jwk.Key
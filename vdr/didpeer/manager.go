@@ -0,0 +1,170 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package didpeer
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/did"
+	nutsCrypto "github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/nuts-foundation/nuts-node/storage"
+	"github.com/nuts-foundation/nuts-node/storage/orm"
+	"github.com/nuts-foundation/nuts-node/vdr/didsubject"
+	"gorm.io/gorm"
+)
+
+var _ didsubject.MethodManager = (*Manager)(nil)
+
+// errImmutable is returned when a did:peer DID document is changed, which isn't possible since the DID is derived from its keys.
+var errImmutable = errors.New("did:peer DID documents can't be changed")
+
+// keyPurposes maps the key usages to the purpose codes of the numalgo 2 did:peer elements.
+// Capability invocation and delegation are omitted, since a did:peer DID document can't be updated.
+var keyPurposes = []struct {
+	usage   orm.DIDKeyFlags
+	purpose rune
+}{
+	{usage: orm.AssertionMethodUsage, purpose: purposeAssertion},
+	{usage: orm.AuthenticationUsage, purpose: purposeAuthentication},
+	{usage: orm.KeyAgreementUsage, purpose: purposeKeyAgreement},
+}
+
+// NewManager creates a new Manager to create did:peer DID documents.
+func NewManager(keyStore nutsCrypto.KeyStore, db *gorm.DB) *Manager {
+	return &Manager{
+		db:       db,
+		keyStore: keyStore,
+	}
+}
+
+// Manager creates numalgo 2 did:peer DID documents.
+// Every DID document gets new keys, so the DIDs can't be correlated with other DIDs of the subject.
+// Nothing is published: the DID contains its keys, so any party can resolve it.
+// Since the DID is derived from its keys, the DID document can't be changed afterward.
+type Manager struct {
+	db       *gorm.DB
+	keyStore nutsCrypto.KeyStore
+}
+
+func (m Manager) NewDocument(ctx context.Context, keyFlags orm.DIDKeyFlags) (*orm.DidDocument, error) {
+	type newKey struct {
+		usage     orm.DIDKeyFlags
+		publicKey crypto.PublicKey
+		multikey  string
+		ref       *orm.KeyReference
+	}
+	// the keys are generated before the DID is known, so they are stored under a temporary kid,
+	// which is replaced by the verification method IDs once the DID is derived from the keys.
+	var keys []newKey
+	for _, keyType := range []orm.DIDKeyFlags{orm.AssertionKeyUsage(), orm.EncryptionKeyUsage()} {
+		if !keyType.Is(keyFlags) {
+			continue
+		}
+		ref, publicKey, err := m.keyStore.New(ctx, func(_ crypto.PublicKey) (string, error) {
+			return "urn:uuid:" + uuid.NewString(), nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		multikey, err := encodeMultikey(publicKey)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, newKey{usage: keyType, publicKey: publicKey, multikey: multikey, ref: ref})
+	}
+
+	// derive the DID from the keys: every key usage becomes an element (and thus a verification method) of the DID
+	var elements []numalgo2Element
+	var elementKeys []newKey
+	var elementUsages []orm.DIDKeyFlags
+	for _, key := range keys {
+		for _, keyPurpose := range keyPurposes {
+			if keyPurpose.usage.Is(key.usage) {
+				elements = append(elements, numalgo2Element{purpose: keyPurpose.purpose, value: key.multikey})
+				elementKeys = append(elementKeys, key)
+				elementUsages = append(elementUsages, keyPurpose.usage)
+			}
+		}
+	}
+	newDID := newNumalgo2DID(elements)
+
+	var sqlVerificationMethods []orm.VerificationMethod
+	for i, key := range elementKeys {
+		verificationMethodID := did.DIDURL{DID: newDID, Fragment: fmt.Sprintf("key-%d", i+1)}
+		if err := m.keyStore.Link(ctx, verificationMethodID.String(), key.ref.KeyName, key.ref.Version); err != nil {
+			return nil, err
+		}
+		verificationMethod, err := did.NewVerificationMethod(verificationMethodID, ssi.JsonWebKey2020, newDID, key.publicKey)
+		if err != nil {
+			return nil, err
+		}
+		asJson, _ := json.Marshal(verificationMethod)
+		sqlVerificationMethods = append(sqlVerificationMethods, orm.VerificationMethod{
+			ID:       verificationMethodID.String(),
+			KeyTypes: orm.VerificationMethodKeyType(elementUsages[i]),
+			Data:     asJson,
+		})
+	}
+	for _, key := range keys {
+		if err := m.transaction(ctx).Where("kid = ?", key.ref.KID).Delete(&orm.KeyReference{}).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now().Unix()
+	return &orm.DidDocument{
+		DID: orm.DID{
+			ID: newDID.String(),
+		},
+		CreatedAt:           now,
+		UpdatedAt:           now,
+		Version:             0,
+		VerificationMethods: sqlVerificationMethods,
+	}, nil
+}
+
+// NewVerificationMethod returns an error, since did:peer DID documents can't be changed.
+func (m Manager) NewVerificationMethod(_ context.Context, _ did.DID, _ orm.DIDKeyFlags) (*did.VerificationMethod, error) {
+	return nil, errImmutable
+}
+
+// Commit does nothing for did:peer, since nothing is published.
+func (m Manager) Commit(_ context.Context, _ orm.DIDChangeLog) error {
+	return nil
+}
+
+// IsCommitted always returns true for did:peer, since nothing is published.
+func (m Manager) IsCommitted(_ context.Context, _ orm.DIDChangeLog) (bool, error) {
+	return true, nil
+}
+
+// transaction returns the DB transaction passed through the context, or the DB if there's none.
+func (m Manager) transaction(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value(storage.TransactionKey{}).(*gorm.DB); ok {
+		return tx
+	}
+	return m.db
+}
@@ -0,0 +1,114 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package didpeer
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/audit"
+	nutsCrypto "github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/nuts-foundation/nuts-node/storage"
+	"github.com/nuts-foundation/nuts-node/storage/orm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_NewDocument(t *testing.T) {
+	ctx := audit.TestContext()
+	manager, keyStore := newTestManager(t)
+
+	t.Run("assertion key", func(t *testing.T) {
+		doc, err := manager.NewDocument(ctx, orm.AssertionKeyUsage())
+
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(doc.DID.ID, "did:peer:2.Az"))
+		// the assertion key is used for assertionMethod and authentication
+		require.Len(t, doc.VerificationMethods, 2)
+		assert.Equal(t, doc.DID.ID+"#key-1", doc.VerificationMethods[0].ID)
+		assert.Equal(t, orm.VerificationMethodKeyType(orm.AssertionMethodUsage), doc.VerificationMethods[0].KeyTypes)
+		assert.Equal(t, doc.DID.ID+"#key-2", doc.VerificationMethods[1].ID)
+		assert.Equal(t, orm.VerificationMethodKeyType(orm.AuthenticationUsage), doc.VerificationMethods[1].KeyTypes)
+		t.Run("stored DID document matches the resolved DID document", func(t *testing.T) {
+			resolved, _, err := Resolver{}.Resolve(did.MustParseDID(doc.DID.ID), nil)
+			require.NoError(t, err)
+
+			stored, err := doc.ToDIDDocument()
+			require.NoError(t, err)
+			resolvedJSON, _ := json.Marshal(resolved)
+			storedJSON, _ := json.Marshal(stored)
+			assert.JSONEq(t, string(resolvedJSON), string(storedJSON))
+		})
+		t.Run("private key is stored under the verification method IDs", func(t *testing.T) {
+			for _, vm := range doc.VerificationMethods {
+				exists, err := keyStore.Exists(ctx, vm.ID)
+				require.NoError(t, err)
+				assert.True(t, exists)
+			}
+		})
+	})
+	t.Run("assertion and encryption key", func(t *testing.T) {
+		doc, err := manager.NewDocument(ctx, orm.AssertionKeyUsage()|orm.EncryptionKeyUsage())
+
+		require.NoError(t, err)
+		require.Len(t, doc.VerificationMethods, 3)
+		assert.Equal(t, orm.VerificationMethodKeyType(orm.KeyAgreementUsage), doc.VerificationMethods[2].KeyTypes)
+	})
+	t.Run("every DID document has new keys", func(t *testing.T) {
+		doc1, err := manager.NewDocument(ctx, orm.AssertionKeyUsage())
+		require.NoError(t, err)
+		doc2, err := manager.NewDocument(ctx, orm.AssertionKeyUsage())
+		require.NoError(t, err)
+
+		assert.NotEqual(t, doc1.DID.ID, doc2.DID.ID)
+	})
+	t.Run("temporary key IDs are removed", func(t *testing.T) {
+		for _, kid := range keyStore.List(ctx) {
+			assert.True(t, strings.HasPrefix(kid, "did:peer:2"), kid)
+		}
+	})
+}
+
+func TestManager_NewVerificationMethod(t *testing.T) {
+	manager, _ := newTestManager(t)
+
+	vm, err := manager.NewVerificationMethod(audit.TestContext(), did.MustParseDID("did:peer:2.Vz6Mk"), orm.AssertionKeyUsage())
+
+	assert.ErrorIs(t, err, errImmutable)
+	assert.Nil(t, vm)
+}
+
+func TestManager_Commit(t *testing.T) {
+	manager, _ := newTestManager(t)
+
+	assert.NoError(t, manager.Commit(audit.TestContext(), orm.DIDChangeLog{}))
+	committed, err := manager.IsCommitted(audit.TestContext(), orm.DIDChangeLog{})
+	assert.NoError(t, err)
+	assert.True(t, committed)
+}
+
+func newTestManager(t *testing.T) (*Manager, nutsCrypto.KeyStore) {
+	storageEngine := storage.NewTestStorageEngine(t)
+	require.NoError(t, storageEngine.Start())
+	db := storageEngine.GetSQLDatabase()
+	keyStore := nutsCrypto.NewDatabaseCryptoInstance(db)
+	return NewManager(keyStore, db), keyStore
+}
@@ -0,0 +1,118 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package didpeer
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/lestrrat-go/jwx/v2/x25519"
+	"github.com/mr-tron/base58"
+	"github.com/multiformats/go-multicodec"
+)
+
+// multihashSHA256 is the multihash prefix for SHA2-256 digests: the code (0x12) followed by the digest length (32 bytes).
+var multihashSHA256 = []byte{0x12, 0x20}
+
+// encodeMultibase encodes the data as multibase base58btc string.
+func encodeMultibase(data []byte) string {
+	return "z" + base58.Encode(data)
+}
+
+// decodeMultibase decodes a multibase base58btc string.
+func decodeMultibase(value string) ([]byte, error) {
+	if !strings.HasPrefix(value, "z") {
+		return nil, errors.New("multibase value must be base58btc encoded")
+	}
+	return base58.Decode(value[1:])
+}
+
+// hashMultibase returns the multibase (base58btc) encoded SHA2-256 multihash of the given data.
+func hashMultibase(data []byte) string {
+	digest := sha256.Sum256(data)
+	return encodeMultibase(append(append([]byte{}, multihashSHA256...), digest[:]...))
+}
+
+// encodeMultikey encodes a public key as multibase (base58btc) encoded multicodec key.
+// Only P-256, Ed25519 and X25519 keys are supported.
+func encodeMultikey(publicKey crypto.PublicKey) (string, error) {
+	var code multicodec.Code
+	var keyBytes []byte
+	switch key := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		if key.Curve != elliptic.P256() {
+			return "", errors.New("unsupported EC curve (only P-256 is supported)")
+		}
+		code = multicodec.P256Pub
+		keyBytes = elliptic.MarshalCompressed(key.Curve, key.X, key.Y)
+	case ed25519.PublicKey:
+		code = multicodec.Ed25519Pub
+		keyBytes = key
+	case x25519.PublicKey:
+		code = multicodec.X25519Pub
+		keyBytes = key
+	default:
+		return "", fmt.Errorf("unsupported key type: %T", publicKey)
+	}
+	return encodeMultibase(append(binary.AppendUvarint(nil, uint64(code)), keyBytes...)), nil
+}
+
+// decodeMultikey decodes a public key from its multibase (base58btc) encoded multicodec form.
+// Only P-256, Ed25519 and X25519 keys are supported.
+func decodeMultikey(multikey string) (crypto.PublicKey, error) {
+	data, err := decodeMultibase(multikey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid multikey: %w", err)
+	}
+	reader := bytes.NewReader(data)
+	code, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, fmt.Errorf("invalid multikey: %w", err)
+	}
+	keyBytes, _ := io.ReadAll(reader)
+	switch multicodec.Code(code) {
+	case multicodec.P256Pub:
+		x, y := elliptic.UnmarshalCompressed(elliptic.P256(), keyBytes)
+		if x == nil {
+			return nil, errors.New("invalid multikey: invalid P-256 public key")
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, nil
+	case multicodec.Ed25519Pub:
+		if len(keyBytes) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid multikey: invalid Ed25519 public key")
+		}
+		return ed25519.PublicKey(keyBytes), nil
+	case multicodec.X25519Pub:
+		if len(keyBytes) != x25519.PublicKeySize {
+			return nil, errors.New("invalid multikey: invalid X25519 public key")
+		}
+		return x25519.PublicKey(keyBytes), nil
+	default:
+		return nil, fmt.Errorf("invalid multikey: unsupported key type: 0x%x", code)
+	}
+}
@@ -0,0 +1,188 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package didpeer
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/jsonld"
+)
+
+// Purpose codes of the elements of a numalgo 2 did:peer,
+// see https://identity.foundation/peer-did-method-spec/#method-2-multiple-inception-key-without-doc
+const (
+	purposeAssertion            = 'A'
+	purposeKeyAgreement         = 'E'
+	purposeAuthentication       = 'V'
+	purposeCapabilityInvocation = 'I'
+	purposeCapabilityDelegation = 'D'
+	purposeService              = 'S'
+)
+
+// serviceAbbreviations contains the abbreviations of service properties used when encoding services in a numalgo 2 did:peer.
+var serviceAbbreviations = map[string]string{
+	"t": "type",
+	"s": "serviceEndpoint",
+	"r": "routingKeys",
+	"a": "accept",
+}
+
+// serviceTypeAbbreviations contains the abbreviations of service types used when encoding services in a numalgo 2 did:peer.
+var serviceTypeAbbreviations = map[string]string{
+	"dm": "DIDCommMessaging",
+}
+
+// numalgo2Element is a key or service encoded in a numalgo 2 did:peer.
+type numalgo2Element struct {
+	purpose rune
+	value   string
+}
+
+// newNumalgo2DID creates a numalgo 2 did:peer from the given elements.
+func newNumalgo2DID(elements []numalgo2Element) did.DID {
+	var builder strings.Builder
+	builder.WriteString("2")
+	for _, element := range elements {
+		builder.WriteRune('.')
+		builder.WriteRune(element.purpose)
+		builder.WriteString(element.value)
+	}
+	return did.DID{Method: MethodName, ID: builder.String()}
+}
+
+// resolveNumalgo2 resolves the DID document of a numalgo 2 did:peer.
+// Keys are resolved to JsonWebKey2020 verification methods, identified by their position: #key-1, #key-2, etc.
+func resolveNumalgo2(id did.DID) (*did.Document, error) {
+	elements := strings.Split(id.ID, ".")
+	if elements[0] != "2" || len(elements) < 2 {
+		return nil, errors.New("invalid numalgo 2 did:peer")
+	}
+	document := did.Document{
+		Context: []interface{}{
+			did.DIDContextV1URI(),
+			jsonld.JWS2020ContextV1URI(),
+		},
+		ID: id,
+	}
+	keyIndex := 0
+	serviceIndex := 0
+	for _, element := range elements[1:] {
+		if len(element) < 2 {
+			return nil, errors.New("invalid numalgo 2 did:peer: empty element")
+		}
+		purpose, value := rune(element[0]), element[1:]
+		if purpose == purposeService {
+			service, err := decodeService(id, value, serviceIndex)
+			if err != nil {
+				return nil, err
+			}
+			document.Service = append(document.Service, *service)
+			serviceIndex++
+			continue
+		}
+		keyIndex++
+		publicKey, err := decodeMultikey(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid numalgo 2 did:peer: %w", err)
+		}
+		vmID := did.DIDURL{DID: id, Fragment: fmt.Sprintf("key-%d", keyIndex)}
+		vm, err := did.NewVerificationMethod(vmID, ssi.JsonWebKey2020, id, publicKey)
+		if err != nil {
+			return nil, err
+		}
+		switch purpose {
+		case purposeAssertion:
+			document.AddAssertionMethod(vm)
+		case purposeKeyAgreement:
+			document.AddKeyAgreement(vm)
+		case purposeAuthentication:
+			document.AddAuthenticationMethod(vm)
+		case purposeCapabilityInvocation:
+			document.AddCapabilityInvocation(vm)
+		case purposeCapabilityDelegation:
+			document.AddCapabilityDelegation(vm)
+		default:
+			return nil, fmt.Errorf("invalid numalgo 2 did:peer: unsupported purpose code: %c", purpose)
+		}
+	}
+	return &document, nil
+}
+
+// decodeService decodes a base64url encoded, abbreviated service of a numalgo 2 did:peer.
+// Services without ID are identified by their position: #service, #service-1, etc.
+func decodeService(id did.DID, value string, index int) (*did.Service, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid numalgo 2 did:peer service: %w", err)
+	}
+	var abbreviated map[string]interface{}
+	if err = json.Unmarshal(data, &abbreviated); err != nil {
+		return nil, fmt.Errorf("invalid numalgo 2 did:peer service: %w", err)
+	}
+	expanded := expandService(abbreviated).(map[string]interface{})
+	if serviceID, ok := expanded["id"].(string); !ok || serviceID == "" {
+		expanded["id"] = "#service"
+		if index > 0 {
+			expanded["id"] = fmt.Sprintf("#service-%d", index)
+		}
+	}
+	if serviceID := expanded["id"].(string); strings.HasPrefix(serviceID, "#") {
+		expanded["id"] = id.String() + serviceID
+	}
+	data, _ = json.Marshal(expanded)
+	var service did.Service
+	if err = json.Unmarshal(data, &service); err != nil {
+		return nil, fmt.Errorf("invalid numalgo 2 did:peer service: %w", err)
+	}
+	return &service, nil
+}
+
+// expandService replaces the abbreviated property names and service types of an encoded service (including nested service endpoint objects).
+func expandService(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, curr := range v {
+			if expandedKey, ok := serviceAbbreviations[key]; ok {
+				key = expandedKey
+			}
+			if serviceType, ok := curr.(string); ok && key == "type" {
+				if expandedType, ok := serviceTypeAbbreviations[serviceType]; ok {
+					curr = expandedType
+				}
+			}
+			result[key] = expandService(curr)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, curr := range v {
+			result[i] = expandService(curr)
+		}
+		return result
+	default:
+		return value
+	}
+}
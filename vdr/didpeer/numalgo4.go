@@ -0,0 +1,97 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package didpeer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/multiformats/go-multicodec"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/vdr/resolver"
+)
+
+// resolveNumalgo4 resolves the DID document of a long form numalgo 4 did:peer,
+// see https://identity.foundation/peer-did-method-spec/#method-4-short-form-and-long-form.
+// The short form can't be resolved, since it doesn't contain the DID document.
+func resolveNumalgo4(id did.DID) (*did.Document, error) {
+	hash, encodedDocument, isLongForm := strings.Cut(strings.TrimPrefix(id.ID, "4"), ":")
+	if !isLongForm {
+		return nil, fmt.Errorf("short form numalgo 4 did:peer can't be resolved: %w", resolver.ErrNotFound)
+	}
+	if hashMultibase([]byte(encodedDocument)) != hash {
+		return nil, errors.New("invalid numalgo 4 did:peer: hash mismatch")
+	}
+	data, err := decodeMultibase(encodedDocument)
+	if err != nil {
+		return nil, fmt.Errorf("invalid numalgo 4 did:peer: %w", err)
+	}
+	reader := bytes.NewReader(data)
+	code, err := binary.ReadUvarint(reader)
+	if err != nil || multicodec.Code(code) != multicodec.Json {
+		return nil, errors.New("invalid numalgo 4 did:peer: document must be JSON")
+	}
+	data, _ = io.ReadAll(reader)
+	var inputDocument map[string]interface{}
+	if err = json.Unmarshal(data, &inputDocument); err != nil {
+		return nil, fmt.Errorf("invalid numalgo 4 did:peer: %w", err)
+	}
+	contextualized := contextualize(id, inputDocument).(map[string]interface{})
+	contextualized["id"] = id.String()
+	contextualized["alsoKnownAs"] = []string{did.DID{Method: MethodName, ID: "4" + hash}.String()}
+	data, _ = json.Marshal(contextualized)
+	var document did.Document
+	if err = json.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("invalid numalgo 4 did:peer: %w", err)
+	}
+	return &document, nil
+}
+
+// contextualize makes the relative DID URLs (e.g. #key-1) in the input document absolute, and sets the controller of verification methods that don't have one.
+func contextualize(id did.DID, value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, curr := range v {
+			result[key] = contextualize(id, curr)
+		}
+		if _, isVerificationMethod := v["type"]; isVerificationMethod && v["controller"] == nil && (v["publicKeyJwk"] != nil || v["publicKeyMultibase"] != nil) {
+			result["controller"] = id.String()
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, curr := range v {
+			result[i] = contextualize(id, curr)
+		}
+		return result
+	case string:
+		if strings.HasPrefix(v, "#") {
+			return id.String() + v
+		}
+		return v
+	default:
+		return value
+	}
+}
@@ -0,0 +1,62 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package didpeer
+
+import (
+	"fmt"
+
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/vdr/resolver"
+)
+
+// MethodName is the DID method name used by did:peer
+const MethodName = "peer"
+
+var _ resolver.DIDResolver = (*Resolver)(nil)
+
+// Resolver is a DID resolver for the did:peer method, supporting numalgo 2 and (long form) numalgo 4.
+// did:peer DIDs contain their DID document, so resolving them doesn't require any network or storage access.
+type Resolver struct {
+}
+
+// NewResolver creates a new did:peer Resolver.
+func NewResolver() *Resolver {
+	return &Resolver{}
+}
+
+// Resolve implements the DIDResolver interface.
+func (r Resolver) Resolve(id did.DID, _ *resolver.ResolveMetadata) (*did.Document, *resolver.DocumentMetadata, error) {
+	if id.Method != MethodName {
+		return nil, nil, fmt.Errorf("unsupported DID method: %s", id.Method)
+	}
+	var document *did.Document
+	var err error
+	switch id.ID[0] {
+	case '2':
+		document, err = resolveNumalgo2(id)
+	case '4':
+		document, err = resolveNumalgo4(id)
+	default:
+		return nil, nil, fmt.Errorf("unsupported did:peer numalgo: %c", id.ID[0])
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return document, &resolver.DocumentMetadata{}, nil
+}
@@ -0,0 +1,148 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package didpeer
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/x25519"
+	"github.com/multiformats/go-multicodec"
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/vdr/resolver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolver_Resolve(t *testing.T) {
+	t.Run("numalgo 2", func(t *testing.T) {
+		signingKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		signingMultikey, _ := encodeMultikey(signingKey.Public())
+		agreementKey, _, _ := x25519.GenerateKey(rand.Reader)
+		agreementMultikey, _ := encodeMultikey(agreementKey)
+		service := base64.RawURLEncoding.EncodeToString([]byte(`{"t":"dm","s":{"uri":"https://example.com/didcomm","a":["didcomm/v2"]}}`))
+		id := newNumalgo2DID([]numalgo2Element{
+			{purpose: purposeAuthentication, value: signingMultikey},
+			{purpose: purposeKeyAgreement, value: agreementMultikey},
+			{purpose: purposeService, value: service},
+			{purpose: purposeService, value: base64.RawURLEncoding.EncodeToString([]byte(`{"t":"LinkedDomains","s":"https://example.com"}`))},
+		})
+
+		doc, md, err := Resolver{}.Resolve(id, nil)
+
+		require.NoError(t, err)
+		assert.NotNil(t, md)
+		assert.Equal(t, id.String(), doc.ID.String())
+		require.Len(t, doc.VerificationMethod, 2)
+		require.Len(t, doc.Authentication, 1)
+		assert.Equal(t, id.String()+"#key-1", doc.Authentication[0].ID.String())
+		assert.Equal(t, id.String(), doc.Authentication[0].Controller.String())
+		publicKey, err := doc.Authentication[0].PublicKey()
+		require.NoError(t, err)
+		assert.True(t, signingKey.PublicKey.Equal(publicKey))
+		require.Len(t, doc.KeyAgreement, 1)
+		assert.Equal(t, id.String()+"#key-2", doc.KeyAgreement[0].ID.String())
+		require.Len(t, doc.Service, 2)
+		assert.Equal(t, id.String()+"#service", doc.Service[0].ID.String())
+		assert.Equal(t, "DIDCommMessaging", doc.Service[0].Type)
+		assert.Equal(t, map[string]interface{}{"uri": "https://example.com/didcomm", "accept": []interface{}{"didcomm/v2"}}, doc.Service[0].ServiceEndpoint)
+		assert.Equal(t, id.String()+"#service-1", doc.Service[1].ID.String())
+		assert.Equal(t, "https://example.com", doc.Service[1].ServiceEndpoint)
+	})
+	t.Run("numalgo 4", func(t *testing.T) {
+		signingKey, _, _ := ed25519.GenerateKey(rand.Reader)
+		signingMultikey, _ := encodeMultikey(signingKey)
+		inputDocument := `{
+  "@context": ["https://www.w3.org/ns/did/v1", "https://w3id.org/security/multikey/v1"],
+  "verificationMethod": [{"id": "#key-1", "type": "Multikey", "publicKeyMultibase": "` + signingMultikey + `"}],
+  "assertionMethod": ["#key-1"],
+  "service": [{"id": "#service-1", "type": "LinkedDomains", "serviceEndpoint": "https://example.com"}]
+}`
+		id := newNumalgo4DID([]byte(inputDocument))
+
+		doc, md, err := Resolver{}.Resolve(id, nil)
+
+		require.NoError(t, err)
+		assert.NotNil(t, md)
+		assert.Equal(t, id.String(), doc.ID.String())
+		shortForm := id.String()[:strings.LastIndex(id.String(), ":")]
+		require.Len(t, doc.AlsoKnownAs, 1)
+		assert.Equal(t, shortForm, doc.AlsoKnownAs[0].String())
+		require.Len(t, doc.AssertionMethod, 1)
+		assert.Equal(t, id.String()+"#key-1", doc.AssertionMethod[0].ID.String())
+		assert.Equal(t, id.String(), doc.AssertionMethod[0].Controller.String())
+		require.Len(t, doc.Service, 1)
+		assert.Equal(t, id.String()+"#service-1", doc.Service[0].ID.String())
+		t.Run("short form can't be resolved", func(t *testing.T) {
+			_, _, err := Resolver{}.Resolve(did.MustParseDID(shortForm), nil)
+
+			assert.ErrorIs(t, err, resolver.ErrNotFound)
+		})
+		t.Run("hash mismatch", func(t *testing.T) {
+			other := newNumalgo4DID([]byte(`{}`))
+			altered := did.MustParseDID(shortForm + other.ID[strings.Index(other.ID, ":"):])
+
+			_, _, err := Resolver{}.Resolve(altered, nil)
+
+			assert.EqualError(t, err, "invalid numalgo 4 did:peer: hash mismatch")
+		})
+	})
+	t.Run("unsupported numalgo", func(t *testing.T) {
+		_, _, err := Resolver{}.Resolve(did.MustParseDID("did:peer:0z6MkhaXgBZDvotDkL5257faiztiGiC2QtKLGpbnnEGta2doK"), nil)
+
+		assert.EqualError(t, err, "unsupported did:peer numalgo: 0")
+	})
+	t.Run("unsupported method", func(t *testing.T) {
+		_, _, err := Resolver{}.Resolve(did.MustParseDID("did:web:example.com"), nil)
+
+		assert.EqualError(t, err, "unsupported DID method: web")
+	})
+	t.Run("numalgo 2 errors", func(t *testing.T) {
+		testCases := []struct {
+			name  string
+			id    string
+			error string
+		}{
+			{name: "no elements", id: "did:peer:2", error: "invalid numalgo 2 did:peer"},
+			{name: "empty element", id: "did:peer:2.V", error: "invalid numalgo 2 did:peer: empty element"},
+			{name: "invalid key", id: "did:peer:2.Vz123", error: "invalid numalgo 2 did:peer: invalid multikey: unsupported key type: 0x0"},
+			{name: "invalid purpose", id: "did:peer:2.Xz6MkhaXgBZDvotDkL5257faiztiGiC2QtKLGpbnnEGta2doK", error: "invalid numalgo 2 did:peer: unsupported purpose code: X"},
+			{name: "invalid service", id: "did:peer:2.Sabc", error: "invalid numalgo 2 did:peer service: invalid character 'i' looking for beginning of value"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, _, err := Resolver{}.Resolve(did.MustParseDID(tc.id), nil)
+
+				assert.EqualError(t, err, tc.error)
+			})
+		}
+	})
+}
+
+// newNumalgo4DID creates the long form of a numalgo 4 did:peer for the given input document.
+func newNumalgo4DID(inputDocument []byte) did.DID {
+	encodedDocument := encodeMultibase(append(binary.AppendUvarint(nil, uint64(multicodec.Json)), inputDocument...))
+	return did.DID{Method: MethodName, ID: "4" + hashMultibase([]byte(encodedDocument)) + ":" + encodedDocument}
+}
@@ -41,6 +41,9 @@ type DIDDocumentManager interface {
 	// Latest returns the latest version of a DID document
 	// if notAfter is given, it will return the latest version before that time
 	Latest(did did.DID, notAfter *time.Time) (*orm.DidDocument, error)
+	// Version returns the given version of a DID document.
+	// It returns gorm.ErrRecordNotFound if the version does not exist.
+	Version(did did.DID, version int) (*orm.DidDocument, error)
 }
 
 // SqlDIDDocumentManager is the implementation of the DIDDocumentManager interface
@@ -90,3 +93,12 @@ func (s *SqlDIDDocumentManager) Latest(did did.DID, resolveTime *time.Time) (*or
 	}
 	return &doc, nil
 }
+
+func (s *SqlDIDDocumentManager) Version(did did.DID, version int) (*orm.DidDocument, error) {
+	doc := orm.DidDocument{}
+	err := s.tx.InnerJoins("DID").Preload("Services").Preload("VerificationMethods").Where("did = ? AND version = ?", did.String(), version).First(&doc).Error
+	if err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
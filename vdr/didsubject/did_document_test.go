@@ -160,3 +160,31 @@ func TestSqlDIDDocumentManager_Latest(t *testing.T) {
 		assert.Nil(t, latest)
 	})
 }
+
+func TestSqlDIDDocumentManager_Version(t *testing.T) {
+	db := testDB(t)
+	tx := transaction(t, db)
+	docManager := NewDIDDocumentManager(tx)
+	vm := orm.VerificationMethod{
+		ID:   "#1",
+		Data: []byte("{}"),
+	}
+	first, err := docManager.CreateOrUpdate(sqlDidAlice, []orm.VerificationMethod{vm}, nil)
+	require.NoError(t, err)
+	_, err = docManager.CreateOrUpdate(sqlDidAlice, nil, nil)
+	require.NoError(t, err)
+
+	t.Run("found", func(t *testing.T) {
+		version, err := docManager.Version(alice, first.Version)
+		require.NoError(t, err)
+
+		assert.Equal(t, first.ID, version.ID)
+		assert.Len(t, version.VerificationMethods, 1)
+	})
+	t.Run("not found", func(t *testing.T) {
+		version, err := docManager.Version(alice, 10)
+
+		assert.Equal(t, gorm.ErrRecordNotFound, err)
+		assert.Nil(t, version)
+	})
+}
@@ -21,6 +21,8 @@ package didsubject
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	ssi "github.com/nuts-foundation/go-did"
 	"github.com/nuts-foundation/go-did/did"
@@ -33,8 +35,8 @@ var ErrInvalidService = errors.New("invalid DID document service")
 // ErrUnsupportedDIDMethod is returned when a DID method is not supported.
 var ErrUnsupportedDIDMethod = errors.New("unsupported DID method")
 
-// ErrKeyAgreementNotSupported is returned key agreement is required for did:web.
-var ErrKeyAgreementNotSupported = errors.New("key agreement not supported for did:web")
+// ErrKeyAgreementNotSupported is returned when key agreement is required for a DID method that doesn't support it (did:web, did:webvh and did:jwk).
+var ErrKeyAgreementNotSupported = errors.New("key agreement not supported")
 
 // ErrSubjectValidation is returned when the subject creation request is invalid.
 var ErrSubjectValidation = errors.New("subject creation validation error")
@@ -105,6 +107,18 @@ type Manager interface {
 	// Exists returns true if the subject exists
 	Exists(ctx context.Context, subject string) (bool, error)
 
+	// CreatePeerDID creates a new did:peer DID for the subject, to be used for the relationship with the given party only.
+	// The relationship identifies the party, i.e. the audience of presentations made with the DID.
+	// If the subject already has a did:peer DID for the relationship, its DID document is returned.
+	// It returns an ErrSubjectNotFound when the subject could not be found.
+	CreatePeerDID(ctx context.Context, subject string, relationship string) (*did.Document, error)
+
+	// ListDIDsForRelationship returns the DIDs of a subject that may be presented to the given party (relationship).
+	// did:peer DIDs are pairwise: if the subject has a did:peer DID for the relationship (see CreatePeerDID), only that DID is returned.
+	// Otherwise, all DIDs except did:peer DIDs are returned.
+	// Relationships are URLs of which scheme and host are compared case-insensitively, ignoring default ports and trailing slashes.
+	ListDIDsForRelationship(ctx context.Context, subject string, relationship string) ([]did.DID, error)
+
 	// CreateService creates a new service in DID documents for the given subject.
 	// The service ID will be generated.
 	CreateService(ctx context.Context, subject string, service did.Service) ([]did.Service, error)
@@ -128,6 +142,22 @@ type Manager interface {
 	// It returns an ErrDeactivated when the subject has the deactivated state.
 	AddVerificationMethod(ctx context.Context, subject string, keyUsage orm.DIDKeyFlags) ([]did.VerificationMethod, error)
 
+	// RotateVerificationMethod replaces the keys of a subject that match the given key usage.
+	// For every DID of the subject, each matching verification method gets a successor with the same key usage.
+	// The replaced verification methods remain in the DID documents for the configured grace period,
+	// so signatures made with them can still be verified. RetireVerificationMethods removes them after that period.
+	// It returns the new verification methods.
+	// It returns an ErrNotFound when the subject could not be found.
+	RotateVerificationMethod(ctx context.Context, subject string, keyUsage orm.DIDKeyFlags) ([]did.VerificationMethod, error)
+
+	// RotateExpiredVerificationMethods rotates the keys of all subjects that exceed the maximum key age of the key rotation policy, using RotateVerificationMethod.
+	// It returns the next scheduled rotation, or nil if no keys are subject to the key rotation policy.
+	RotateExpiredVerificationMethods(ctx context.Context) (*ScheduledKeyRotation, error)
+
+	// RetireVerificationMethods removes verification methods that were replaced by RotateVerificationMethod and of which the grace period has passed.
+	// The verification methods are removed from the DID documents, after which their private keys are deleted.
+	RetireVerificationMethods(ctx context.Context)
+
 	// Rollback queries the did_change_log table for all changes that are older than 1 minute.
 	// Any entry that's still there is considered not committed and will be rolled back.
 	// All DID Document versions that are part of the same transaction_id will be deleted.
@@ -135,6 +165,24 @@ type Manager interface {
 	Rollback(ctx context.Context)
 }
 
+// KeyRotationPolicy sets the maximum age of keys per key usage (e.g. orm.AssertionKeyUsage()).
+// Keys are rotated when they have been part of the subject's DID documents for longer than the maximum age.
+type KeyRotationPolicy map[orm.DIDKeyFlags]time.Duration
+
+// ScheduledKeyRotation describes when the next automatic key rotation takes place.
+type ScheduledKeyRotation struct {
+	// Subject is the subject of which the keys will be rotated.
+	Subject string `json:"subject"`
+	// VerificationMethodID is the ID of the verification method that will reach its maximum age.
+	VerificationMethodID string `json:"verification_method"`
+	// At is the time at which the verification method reaches its maximum age.
+	At time.Time `json:"at"`
+}
+
+func (s ScheduledKeyRotation) String() string {
+	return fmt.Sprintf("%s (subject=%s, verification_method=%s)", s.At.Format(time.RFC3339), s.Subject, s.VerificationMethodID)
+}
+
 // DocumentMigration is used to migrate DID document versions to the SQL DB. This should only be used for DID documents managed by this node.
 type DocumentMigration interface {
 	// MigrateDIDHistoryToSQL is used to migrate the history of a DID Document to SQL.
@@ -160,6 +208,12 @@ type SkipAssertionKeyCreationOption struct{}
 // NutsLegacyNamingOption will make the subject equal to the Nuts DID.
 type NutsLegacyNamingOption struct{}
 
+// DIDMethodsCreationOption restricts the DIDs that are created for the subject to the given DID methods (without did: prefix).
+// The DID methods must be enabled. If not given, a DID is created for every enabled DID method.
+type DIDMethodsCreationOption struct {
+	Methods []string
+}
+
 // CreationOptions defines options for creating DID Documents.
 type CreationOptions interface {
 	// With adds an option to the CreationOptions.
@@ -28,19 +28,29 @@ import (
 	"github.com/mr-tron/base58"
 	ssi "github.com/nuts-foundation/go-did"
 	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/audit"
 	"github.com/nuts-foundation/nuts-node/core"
 	nutsCrypto "github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/nuts-foundation/nuts-node/crypto/storage/spi"
 	"github.com/nuts-foundation/nuts-node/storage"
 	"github.com/nuts-foundation/nuts-node/storage/orm"
 	"github.com/nuts-foundation/nuts-node/vdr/log"
 	"github.com/nuts-foundation/nuts-node/vdr/resolver"
 	"gorm.io/gorm"
+	"net/url"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
 	"time"
 )
 
+// peerMethod is the DID method of pairwise DIDs, which are bound to a single relationship of the subject.
+const peerMethod = "peer"
+
+// immutableMethods contains the DID methods of which the DID documents can't be changed, since the DID is derived from the keys it contains.
+var immutableMethods = []string{"jwk", "key", "peer"}
+
 // subjectPattern is a regular expression for checking whether a subject follows the allowed pattern; a-z, 0-9, -, _, . (case insensitive)
 var subjectPattern = regexp.MustCompile(`^[a-zA-Z0-9._-]+$`)
 
@@ -52,6 +62,10 @@ type SqlManager struct {
 	KeyStore       nutsCrypto.KeyStore
 	// PreferredOrder is the order in which the methods are preferred, which dictates the order in which they are returned.
 	PreferredOrder []string
+	// KeyRotationGracePeriod is the period a verification method replaced by RotateVerificationMethod remains in the DID documents.
+	KeyRotationGracePeriod time.Duration
+	// KeyRotationPolicy sets the maximum age of keys, after which RotateExpiredVerificationMethods rotates them.
+	KeyRotationPolicy KeyRotationPolicy
 }
 
 func New(db *gorm.DB, methodManagers map[string]MethodManager, keyStore nutsCrypto.KeyStore, preferredOrder []string) *SqlManager {
@@ -106,6 +120,119 @@ func (r *SqlManager) Exists(_ context.Context, subject string) (bool, error) {
 	return sqlDIDManager.SubjectExists(subject)
 }
 
+func (r *SqlManager) ListDIDsForRelationship(ctx context.Context, subject string, relationship string) ([]did.DID, error) {
+	dids, err := r.ListDIDs(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+	var relationshipDIDs []string
+	if err := r.DB.Model(&orm.DIDPeerRelationship{}).Where("relationship = ?", normalizeRelationship(relationship)).Pluck("did", &relationshipDIDs).Error; err != nil {
+		return nil, err
+	}
+	// the did:peer DID of the relationship is the only DID that may be presented to the party, other DIDs would correlate the subject
+	for _, id := range dids {
+		if id.Method == peerMethod && slices.Contains(relationshipDIDs, id.String()) {
+			return []did.DID{id}, nil
+		}
+	}
+	result := make([]did.DID, 0, len(dids))
+	for _, id := range dids {
+		if id.Method != peerMethod {
+			result = append(result, id)
+		}
+	}
+	return result, nil
+}
+
+// normalizeRelationship returns the key under which a relationship is stored.
+// Relationships are identified by the URL of the party (e.g. an authorization server issuer or a verifier's client_id),
+// which are compared case-insensitively on scheme and host, without default port and trailing slash.
+// Relationships that aren't absolute URLs are returned as-is.
+func normalizeRelationship(relationship string) string {
+	parsed, err := url.Parse(relationship)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return relationship
+	}
+	parsed.Scheme = strings.ToLower(parsed.Scheme)
+	parsed.Host = strings.ToLower(parsed.Host)
+	if (parsed.Scheme == "https" && parsed.Port() == "443") || (parsed.Scheme == "http" && parsed.Port() == "80") {
+		parsed.Host = parsed.Hostname()
+	}
+	parsed.Path = strings.TrimRight(parsed.Path, "/")
+	parsed.RawPath = ""
+	parsed.Fragment = ""
+	return parsed.String()
+}
+
+func (r *SqlManager) CreatePeerDID(ctx context.Context, subject string, relationship string) (*did.Document, error) {
+	if relationship == "" {
+		return nil, errors.Join(ErrSubjectValidation, errors.New("relationship must be given"))
+	}
+	relationship = normalizeRelationship(relationship)
+	manager, ok := r.MethodManagers[peerMethod]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedDIDMethod, peerMethod)
+	}
+	var sqlDoc *orm.DidDocument
+	err := r.transactionHelper(ctx, func(tx *gorm.DB) (map[string]orm.DIDChangeLog, error) {
+		sqlDIDDocumentManager := NewDIDDocumentManager(tx)
+		dids, err := NewDIDManager(tx).FindBySubject(subject)
+		if err != nil {
+			return nil, err
+		}
+		subjectDIDs := make([]string, len(dids))
+		for i, sqlDID := range dids {
+			subjectDIDs[i] = sqlDID.ID
+		}
+		// return the did:peer DID of the relationship if it already exists
+		var existing []orm.DIDPeerRelationship
+		if err := tx.Where("relationship = ? and did in ?", relationship, subjectDIDs).Find(&existing).Error; err != nil {
+			return nil, err
+		}
+		if len(existing) > 0 {
+			id, err := did.ParseDID(existing[0].DID)
+			if err != nil {
+				return nil, err
+			}
+			sqlDoc, err = sqlDIDDocumentManager.Latest(*id, nil)
+			return nil, err
+		}
+
+		// save tx in context to pass all the way down to KeyStore
+		transactionContext := context.WithValue(ctx, storage.TransactionKey{}, tx)
+		newDoc, err := manager.NewDocument(transactionContext, orm.AssertionKeyUsage())
+		if err != nil {
+			return nil, fmt.Errorf("could not generate DID document (method %s): %w", peerMethod, err)
+		}
+		sqlDoc, err = sqlDIDDocumentManager.CreateOrUpdate(orm.DID{ID: newDoc.DID.ID, Subject: subject}, newDoc.VerificationMethods, nil)
+		if err != nil {
+			return nil, err
+		}
+		if err := tx.Create(&orm.DIDPeerRelationship{DID: sqlDoc.DID.ID, Relationship: relationship}).Error; err != nil {
+			return nil, err
+		}
+		return map[string]orm.DIDChangeLog{
+			peerMethod: {
+				DIDDocumentVersionID: sqlDoc.ID,
+				Type:                 orm.DIDChangeCreated,
+				TransactionID:        uuid.New().String(),
+				DIDDocumentVersion:   *sqlDoc,
+			},
+		}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not create did:peer DID: %w", err)
+	}
+	doc, err := sqlDoc.ToDIDDocument()
+	if err != nil {
+		return nil, err
+	}
+	log.Logger().
+		WithField(core.LogFieldDIDSubject, subject).
+		Infof("Created did:peer DID for relationship (DID: %s, relationship: %s)", doc.ID, relationship)
+	return &doc, nil
+}
+
 // Create generates new DID Documents
 func (r *SqlManager) Create(ctx context.Context, options CreationOptions) ([]did.Document, string, error) {
 	log.Logger().Debug("Creating new DID Documents.")
@@ -114,6 +241,10 @@ func (r *SqlManager) Create(ctx context.Context, options CreationOptions) ([]did
 	keyFlags := orm.AssertionKeyUsage()
 	subject := uuid.New().String()
 	nutsLegacy := false
+	methods := make([]string, 0, len(r.MethodManagers))
+	for method := range r.MethodManagers {
+		methods = append(methods, method)
+	}
 
 	// apply options
 	for _, option := range options.All() {
@@ -127,6 +258,16 @@ func (r *SqlManager) Create(ctx context.Context, options CreationOptions) ([]did
 			keyFlags = keyFlags | orm.EncryptionKeyUsage()
 		case NutsLegacyNamingOption:
 			nutsLegacy = true
+		case DIDMethodsCreationOption:
+			if len(opt.Methods) == 0 {
+				return nil, "", errors.Join(ErrSubjectValidation, errors.New("at least one DID method must be given"))
+			}
+			for _, method := range opt.Methods {
+				if _, ok := r.MethodManagers[method]; !ok {
+					return nil, "", fmt.Errorf("%w: %s", ErrUnsupportedDIDMethod, method)
+				}
+			}
+			methods = opt.Methods
 		default:
 			return nil, "", errors.Join(ErrSubjectValidation, fmt.Errorf("unknown option: %T", option))
 		}
@@ -145,12 +286,13 @@ func (r *SqlManager) Create(ctx context.Context, options CreationOptions) ([]did
 			return nil, ErrSubjectAlreadyExists
 		}
 
-		// call generate on all managers
-		for method, manager := range r.MethodManagers {
+		// call generate on all managers of the requested methods
+		for _, method := range methods {
+			manager := r.MethodManagers[method]
 			// known limitation, check is also done within the manager, but at this point we can return a known error for the API
 			// requires update to nutsCrypto module
-			if keyFlags.Is(orm.KeyAgreementUsage) && method == "web" {
-				return nil, ErrKeyAgreementNotSupported
+			if keyFlags.Is(orm.KeyAgreementUsage) && (method == "web" || method == "webvh" || method == "jwk") {
+				return nil, fmt.Errorf("%w for did:%s", ErrKeyAgreementNotSupported, method)
 			}
 
 			// save tx in context to pass all the way down to KeyStore
@@ -230,7 +372,12 @@ func (r *SqlManager) Deactivate(ctx context.Context, subject string) error {
 				return changes, err
 			}
 			id, _ := did.ParseDID(sqlDID.ID)
-			changes[id.Method] = orm.DIDChangeLog{
+			changeKey := id.Method
+			if id.Method == peerMethod {
+				// a subject can have a did:peer DID per relationship, which don't need to be committed since nothing is published
+				changeKey = sqlDID.ID
+			}
+			changes[changeKey] = orm.DIDChangeLog{
 				DIDDocumentVersionID: sqlDoc.ID,
 				Type:                 orm.DIDChangeDeactivated,
 				TransactionID:        transactionID,
@@ -394,7 +541,7 @@ func (r *SqlManager) AddVerificationMethod(ctx context.Context, subject string,
 	err := r.applyToDIDDocuments(ctx, subject, func(tx *gorm.DB, id did.DID, current *orm.DidDocument) (*orm.DidDocument, error) {
 		// known limitation
 		if keyUsage.Is(orm.KeyAgreementUsage) && id.Method == "web" {
-			return nil, ErrKeyAgreementNotSupported
+			return nil, fmt.Errorf("%w for did:web", ErrKeyAgreementNotSupported)
 			// requires update to nutsCrypto module
 			//verificationMethodKey, err = m.keyStore.NewRSA(ctx, func(key crypt.PublicKey) (string, error) {
 			//	return verificationMethodID.String(), nil
@@ -427,6 +574,240 @@ func (r *SqlManager) AddVerificationMethod(ctx context.Context, subject string,
 	return verificationMethods, nil
 }
 
+func (r *SqlManager) RotateVerificationMethod(ctx context.Context, subject string, keyUsage orm.DIDKeyFlags) ([]did.VerificationMethod, error) {
+	log.Logger().Debug("Rotating VerificationMethods.")
+
+	verificationMethods := make([]did.VerificationMethod, 0)
+	var vmIDs []string
+	retireAt := time.Now().Add(r.KeyRotationGracePeriod).Unix()
+	err := r.applyToDIDDocuments(ctx, subject, func(tx *gorm.DB, id did.DID, current *orm.DidDocument) (*orm.DidDocument, error) {
+		// verification methods that are already scheduled for retirement are not rotated again
+		scheduled := make([]orm.VerificationMethodRetirement, 0)
+		if err := tx.Where("did = ?", id.String()).Find(&scheduled).Error; err != nil {
+			return nil, err
+		}
+		isScheduled := make(map[string]bool, len(scheduled))
+		for _, retirement := range scheduled {
+			isScheduled[retirement.VerificationMethodID] = true
+		}
+
+		transactionContext := context.WithValue(ctx, storage.TransactionKey{}, tx)
+		var successors []orm.VerificationMethod
+		for _, sqlMethod := range current.VerificationMethods {
+			// rotated keys retain the key usage of the key they replace
+			flags := orm.DIDKeyFlags(sqlMethod.KeyTypes)
+			if !flags.Is(keyUsage) || isScheduled[sqlMethod.ID] {
+				continue
+			}
+			vm, err := r.MethodManagers[id.Method].NewVerificationMethod(transactionContext, id, flags)
+			if err != nil {
+				return nil, err
+			}
+			verificationMethods = append(verificationMethods, *vm)
+			data, _ := json.Marshal(*vm)
+			successors = append(successors, orm.VerificationMethod{
+				ID:       vm.ID.String(),
+				KeyTypes: sqlMethod.KeyTypes,
+				Data:     data,
+			})
+			vmIDs = append(vmIDs, vm.ID.String())
+			err = tx.Create(&orm.VerificationMethodRetirement{
+				VerificationMethodID: sqlMethod.ID,
+				DID:                  id.String(),
+				RetireAt:             retireAt,
+			}).Error
+			if err != nil {
+				return nil, err
+			}
+		}
+		if len(successors) == 0 {
+			return nil, nil
+		}
+		current.VerificationMethods = append(current.VerificationMethods, successors...)
+		return current, nil
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("could not update DID documents: %w", err)
+	}
+	if len(vmIDs) > 0 {
+		audit.Log(ctx, log.Logger().WithField(core.LogFieldDIDSubject, subject), audit.KeyRotatedEvent).
+			Infof("Rotated verification methods for subject, replaced keys are retired at %s (IDs: [%s])", time.Unix(retireAt, 0).Format(time.RFC3339), strings.Join(vmIDs, ", "))
+	}
+	return verificationMethods, nil
+}
+
+// RotateExpiredVerificationMethods checks the age of the keys of all subjects against the KeyRotationPolicy.
+// The age of a key is determined by the first DID document version it was added to.
+// Keys that are scheduled for retirement (already rotated) are not considered.
+// Failure to rotate the keys of a subject is logged, so other subjects are still rotated.
+func (r *SqlManager) RotateExpiredVerificationMethods(ctx context.Context) (*ScheduledKeyRotation, error) {
+	if len(r.KeyRotationPolicy) == 0 {
+		return nil, nil
+	}
+	addedAt, err := r.verificationMethodsAddedAt()
+	if err != nil {
+		return nil, err
+	}
+	retirements := make([]orm.VerificationMethodRetirement, 0)
+	if err = r.DB.Find(&retirements).Error; err != nil {
+		return nil, err
+	}
+	isScheduled := make(map[string]bool, len(retirements))
+	for _, retirement := range retirements {
+		isScheduled[retirement.VerificationMethodID] = true
+	}
+	subjects, err := r.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var next *ScheduledKeyRotation
+	schedule := func(subject string, vmID string, at time.Time) {
+		if next == nil || at.Before(next.At) {
+			next = &ScheduledKeyRotation{Subject: subject, VerificationMethodID: vmID, At: at}
+		}
+	}
+	sqlDIDDocumentManager := NewDIDDocumentManager(r.DB)
+outer:
+	for subject, dids := range subjects {
+		var expiredUsage orm.DIDKeyFlags
+		for _, id := range dids {
+			// keys of DID documents that can't be changed aren't rotated
+			if slices.Contains(immutableMethods, id.Method) {
+				continue
+			}
+			current, err := sqlDIDDocumentManager.Latest(id, nil)
+			if err != nil {
+				log.Logger().WithError(err).WithField(core.LogFieldDIDSubject, subject).Errorf("Failed to load DID document to check for expired keys (did=%s)", id)
+				continue outer
+			}
+			for _, sqlMethod := range current.VerificationMethods {
+				created, ok := addedAt[sqlMethod.ID]
+				if !ok || isScheduled[sqlMethod.ID] {
+					continue
+				}
+				for keyUsage, maxAge := range r.KeyRotationPolicy {
+					if maxAge <= 0 || !orm.DIDKeyFlags(sqlMethod.KeyTypes).Is(keyUsage) {
+						continue
+					}
+					if rotateAt := created.Add(maxAge); rotateAt.After(now) {
+						schedule(subject, sqlMethod.ID, rotateAt)
+					} else {
+						expiredUsage |= keyUsage
+					}
+				}
+			}
+		}
+		if expiredUsage == 0 {
+			continue
+		}
+		vms, err := r.RotateVerificationMethod(ctx, subject, expiredUsage)
+		if err != nil {
+			log.Logger().WithError(err).WithField(core.LogFieldDIDSubject, subject).Error("Failed to rotate expired keys of subject")
+			continue
+		}
+		// the new keys will be rotated when they reach the maximum age
+		for _, vm := range vms {
+			for keyUsage, maxAge := range r.KeyRotationPolicy {
+				if maxAge > 0 && expiredUsage.Is(keyUsage) {
+					schedule(subject, vm.ID.String(), now.Add(maxAge))
+				}
+			}
+		}
+	}
+	return next, nil
+}
+
+// verificationMethodsAddedAt returns, per verification method ID, the time the verification method was first added to a DID document.
+func (r *SqlManager) verificationMethodsAddedAt() (map[string]time.Time, error) {
+	var rows []struct {
+		VerificationMethodID string
+		AddedAt              int64
+	}
+	// note: any changes to this query needs to manually be tested in all supported DBs
+	err := r.DB.Table("did_document_to_verification_method").
+		Select("did_document_to_verification_method.verification_method_id, min(did_document_version.updated_at) as added_at").
+		Joins("inner join did_document_version on did_document_version.id = did_document_to_verification_method.did_document_id").
+		Group("did_document_to_verification_method.verification_method_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]time.Time, len(rows))
+	for _, row := range rows {
+		result[row.VerificationMethodID] = time.Unix(row.AddedAt, 0)
+	}
+	return result, nil
+}
+
+// RetireVerificationMethods queries the did_verification_method_retirement table for verification methods of which the grace period has passed.
+// The verification methods are removed from the DID documents of the subject, after which the private keys are deleted from the key store.
+// Private keys of storage backends that can't delete keys (e.g. CSC) are left in place, the verification method is retired nonetheless.
+// Failures are logged and retried on the next run.
+func (r *SqlManager) RetireVerificationMethods(ctx context.Context) {
+	retirements := make([]orm.VerificationMethodRetirement, 0)
+	if err := r.DB.Where("retire_at <= ?", time.Now().Unix()).Find(&retirements).Error; err != nil {
+		log.Logger().WithError(err).Error("Failed to query verification methods to retire")
+		return
+	}
+	// DID documents are updated per subject
+	retirementsPerSubject := make(map[string]map[string]bool)
+	sqlDIDManager := NewDIDManager(r.DB)
+	for _, retirement := range retirements {
+		id, err := did.ParseDID(retirement.DID)
+		if err != nil {
+			log.Logger().WithError(err).Errorf("Failed to retire verification method (id=%s)", retirement.VerificationMethodID)
+			continue
+		}
+		sqlDID, err := sqlDIDManager.Find(*id)
+		if err != nil || sqlDID == nil {
+			log.Logger().WithError(err).Errorf("Failed to retire verification method, could not find DID (id=%s)", retirement.VerificationMethodID)
+			continue
+		}
+		if retirementsPerSubject[sqlDID.Subject] == nil {
+			retirementsPerSubject[sqlDID.Subject] = make(map[string]bool)
+		}
+		retirementsPerSubject[sqlDID.Subject][retirement.VerificationMethodID] = true
+	}
+
+	for subject, vmIDs := range retirementsPerSubject {
+		err := r.applyToDIDDocuments(ctx, subject, func(_ *gorm.DB, _ did.DID, current *orm.DidDocument) (*orm.DidDocument, error) {
+			remaining := make([]orm.VerificationMethod, 0, len(current.VerificationMethods))
+			for _, sqlMethod := range current.VerificationMethods {
+				if !vmIDs[sqlMethod.ID] {
+					remaining = append(remaining, sqlMethod)
+				}
+			}
+			if len(remaining) == len(current.VerificationMethods) {
+				return nil, nil
+			}
+			current.VerificationMethods = remaining
+			return current, nil
+		})
+		if err != nil {
+			log.Logger().WithError(err).WithField(core.LogFieldDIDSubject, subject).Error("Failed to remove retired verification methods from DID documents")
+			continue
+		}
+		// the private keys are only deleted after the DID documents no longer contain the verification methods
+		for vmID := range vmIDs {
+			err = r.KeyStore.Delete(ctx, vmID)
+			if errors.Is(err, spi.ErrDeleteNotSupported) {
+				log.Logger().WithError(err).WithField(core.LogFieldDIDSubject, subject).Warnf("Private key of retired verification method can't be deleted, it must be revoked at its storage backend (id=%s)", vmID)
+			} else if err != nil && !errors.Is(err, nutsCrypto.ErrPrivateKeyNotFound) {
+				log.Logger().WithError(err).WithField(core.LogFieldDIDSubject, subject).Errorf("Failed to delete private key of retired verification method (id=%s)", vmID)
+				continue
+			}
+			if err = r.DB.Where("verification_method_id = ?", vmID).Delete(&orm.VerificationMethodRetirement{}).Error; err != nil {
+				log.Logger().WithError(err).WithField(core.LogFieldDIDSubject, subject).Errorf("Failed to delete retirement of verification method (id=%s)", vmID)
+				continue
+			}
+			log.Logger().WithField(core.LogFieldDIDSubject, subject).Infof("Retired verification method (id=%s)", vmID)
+		}
+	}
+}
+
 // transactionHelper is a helper function that starts a transaction, performs an operation, and emits an event.
 func (r *SqlManager) transactionHelper(ctx context.Context, operation func(tx *gorm.DB) (map[string]orm.DIDChangeLog, error)) error {
 	var changes map[string]orm.DIDChangeLog
@@ -494,6 +875,7 @@ func (r *SqlManager) transactionHelper(ctx context.Context, operation func(tx *g
 // applyToDIDDocuments is a helper function that applies an operation to all DID documents of a subject (1 per did method).
 // It uses transactionHelper to perform the operation in a transaction.
 // if the operation returns nil then no changes are made.
+// DID documents of immutable DID methods (e.g. did:peer) are skipped, since they can't be changed.
 func (r *SqlManager) applyToDIDDocuments(ctx context.Context, subject string, operation func(tx *gorm.DB, id did.DID, current *orm.DidDocument) (*orm.DidDocument, error)) error {
 	return r.transactionHelper(ctx, func(tx *gorm.DB) (map[string]orm.DIDChangeLog, error) {
 		eventLog := make(map[string]orm.DIDChangeLog)
@@ -509,6 +891,9 @@ func (r *SqlManager) applyToDIDDocuments(ctx context.Context, subject string, op
 			if err != nil {
 				return nil, err
 			}
+			if slices.Contains(immutableMethods, id.Method) {
+				continue
+			}
 			current, err := sqlDIDDocumentManager.Latest(*id, nil)
 			if err != nil {
 				return nil, err
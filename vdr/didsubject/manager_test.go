@@ -35,9 +35,11 @@ import (
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/nuts-node/audit"
 	"github.com/nuts-foundation/nuts-node/core/to"
+	nutsCrypto "github.com/nuts-foundation/nuts-node/crypto"
 	"github.com/nuts-foundation/nuts-node/storage"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 	"gorm.io/gorm"
 )
 
@@ -103,6 +105,118 @@ func TestManager_ListDIDs(t *testing.T) {
 	})
 }
 
+func TestManager_CreatePeerDID(t *testing.T) {
+	newManager := func(t *testing.T) SqlManager {
+		return SqlManager{DB: testDB(t), MethodManagers: map[string]MethodManager{
+			"example": testMethod{},
+			"peer":    testMethod{method: "peer"},
+		}, PreferredOrder: []string{"example", "peer"}}
+	}
+	ctx := audit.TestContext()
+
+	t.Run("ok", func(t *testing.T) {
+		m := newManager(t)
+		_, subject, err := m.Create(ctx, DefaultCreationOptions())
+		require.NoError(t, err)
+
+		document, err := m.CreatePeerDID(ctx, subject, "https://example.com/oauth2/a")
+
+		require.NoError(t, err)
+		require.NotNil(t, document)
+		assert.Equal(t, "peer", document.ID.Method)
+		dids, err := m.ListDIDs(ctx, subject)
+		require.NoError(t, err)
+		assert.Contains(t, dids, document.ID)
+		t.Run("same relationship returns the existing DID", func(t *testing.T) {
+			existing, err := m.CreatePeerDID(ctx, subject, "https://example.com/oauth2/a")
+
+			require.NoError(t, err)
+			assert.Equal(t, document.ID, existing.ID)
+		})
+		t.Run("other relationship gets a new DID", func(t *testing.T) {
+			other, err := m.CreatePeerDID(ctx, subject, "https://example.com/oauth2/b")
+
+			require.NoError(t, err)
+			assert.NotEqual(t, document.ID, other.ID)
+		})
+		t.Run("deactivate", func(t *testing.T) {
+			err := m.Deactivate(ctx, subject)
+
+			require.NoError(t, err)
+		})
+	})
+	t.Run("unknown subject", func(t *testing.T) {
+		m := newManager(t)
+
+		_, err := m.CreatePeerDID(ctx, "subject", "https://example.com/oauth2/a")
+
+		assert.ErrorIs(t, err, ErrSubjectNotFound)
+	})
+	t.Run("no relationship", func(t *testing.T) {
+		m := newManager(t)
+
+		_, err := m.CreatePeerDID(ctx, "subject", "")
+
+		assert.ErrorIs(t, err, ErrSubjectValidation)
+	})
+	t.Run("did:peer not enabled", func(t *testing.T) {
+		m := SqlManager{DB: testDB(t), MethodManagers: map[string]MethodManager{"example": testMethod{}}}
+
+		_, err := m.CreatePeerDID(ctx, "subject", "https://example.com/oauth2/a")
+
+		assert.ErrorIs(t, err, ErrUnsupportedDIDMethod)
+	})
+}
+
+func TestManager_ListDIDsForRelationship(t *testing.T) {
+	ctx := audit.TestContext()
+	m := SqlManager{DB: testDB(t), MethodManagers: map[string]MethodManager{
+		"example": testMethod{},
+		"peer":    testMethod{method: "peer"},
+	}, PreferredOrder: []string{"example", "peer"}}
+	documents, subject, err := m.Create(ctx, DefaultCreationOptions())
+	require.NoError(t, err)
+	require.Len(t, documents, 2)
+	peerA, err := m.CreatePeerDID(ctx, subject, "https://example.com/oauth2/a")
+	require.NoError(t, err)
+	peerB, err := m.CreatePeerDID(ctx, subject, "https://example.com/oauth2/b")
+	require.NoError(t, err)
+
+	t.Run("only the did:peer DID of the relationship", func(t *testing.T) {
+		dids, err := m.ListDIDsForRelationship(ctx, subject, "https://example.com/oauth2/a")
+
+		require.NoError(t, err)
+		assert.Equal(t, []did.DID{peerA.ID}, dids)
+	})
+	t.Run("relationship is normalized", func(t *testing.T) {
+		dids, err := m.ListDIDsForRelationship(ctx, subject, "HTTPS://Example.com:443/oauth2/b/")
+
+		require.NoError(t, err)
+		assert.Equal(t, []did.DID{peerB.ID}, dids)
+	})
+	t.Run("no did:peer DID for unknown relationship", func(t *testing.T) {
+		dids, err := m.ListDIDsForRelationship(ctx, subject, "https://example.com/oauth2/c")
+
+		require.NoError(t, err)
+		require.Len(t, dids, 1)
+		assert.Equal(t, "example", dids[0].Method)
+	})
+	t.Run("unknown subject", func(t *testing.T) {
+		_, err := m.ListDIDsForRelationship(ctx, "unknown", "https://example.com/oauth2/a")
+
+		assert.ErrorIs(t, err, ErrSubjectNotFound)
+	})
+}
+
+func Test_normalizeRelationship(t *testing.T) {
+	assert.Equal(t, "https://example.com/oauth2/a", normalizeRelationship("https://example.com/oauth2/a"))
+	assert.Equal(t, "https://example.com/oauth2/a", normalizeRelationship("HTTPS://EXAMPLE.com/oauth2/a/"))
+	assert.Equal(t, "https://example.com/oauth2/a", normalizeRelationship("https://example.com:443/oauth2/a"))
+	assert.Equal(t, "https://example.com:8443/oauth2/a", normalizeRelationship("https://example.com:8443/oauth2/a"))
+	assert.Equal(t, "https://example.com/oauth2/A", normalizeRelationship("https://example.com/oauth2/A"))
+	assert.Equal(t, "did:web:example.com", normalizeRelationship("did:web:example.com"))
+}
+
 func TestManager_Create(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
 		db := testDB(t)
@@ -138,6 +252,38 @@ func TestManager_Create(t *testing.T) {
 		assert.True(t, strings.HasPrefix(IDs[0], "did:test:"))
 		assert.True(t, strings.HasPrefix(IDs[1], "did:example:"))
 	})
+	t.Run("with DID methods", func(t *testing.T) {
+		newManager := func(t *testing.T) SqlManager {
+			return SqlManager{DB: testDB(t), MethodManagers: map[string]MethodManager{
+				"example": testMethod{},
+				"test":    testMethod{method: "test"},
+			}, PreferredOrder: []string{"test", "example"}}
+		}
+		t.Run("ok", func(t *testing.T) {
+			m := newManager(t)
+
+			documents, _, err := m.Create(audit.TestContext(), DefaultCreationOptions().With(DIDMethodsCreationOption{Methods: []string{"example"}}))
+
+			require.NoError(t, err)
+			require.Len(t, documents, 1)
+			assert.True(t, strings.HasPrefix(documents[0].ID.String(), "did:example:"))
+		})
+		t.Run("unsupported DID method", func(t *testing.T) {
+			m := newManager(t)
+
+			_, _, err := m.Create(audit.TestContext(), DefaultCreationOptions().With(DIDMethodsCreationOption{Methods: []string{"example", "web"}}))
+
+			assert.ErrorIs(t, err, ErrUnsupportedDIDMethod)
+			assert.ErrorContains(t, err, "web")
+		})
+		t.Run("empty", func(t *testing.T) {
+			m := newManager(t)
+
+			_, _, err := m.Create(audit.TestContext(), DefaultCreationOptions().With(DIDMethodsCreationOption{}))
+
+			assert.ErrorIs(t, err, ErrSubjectValidation)
+		})
+	})
 	t.Run("with unknown option", func(t *testing.T) {
 		db := testDB(t)
 		m := SqlManager{DB: db, MethodManagers: map[string]MethodManager{"example": testMethod{}}}
@@ -277,6 +423,266 @@ func TestManager_AddVerificationMethod(t *testing.T) {
 		require.NoError(t, err)
 		require.Len(t, vms, 2)
 	})
+	t.Run("did:peer DID documents are not changed", func(t *testing.T) {
+		m := SqlManager{DB: db, MethodManagers: map[string]MethodManager{"example": testMethod{}, "peer": testMethod{method: "peer"}}}
+		subject := "peer-subject"
+		_, _, err := m.Create(audit.TestContext(), DefaultCreationOptions().With(SubjectCreationOption{Subject: subject}))
+		require.NoError(t, err)
+
+		vms, err := m.AddVerificationMethod(audit.TestContext(), subject, orm.AssertionKeyUsage())
+
+		require.NoError(t, err)
+		require.Len(t, vms, 1)
+		assert.Equal(t, "example", vms[0].ID.Method)
+	})
+}
+
+func TestManager_RotateVerificationMethod(t *testing.T) {
+	subject := "subject"
+	setup := func(t *testing.T) (SqlManager, []did.VerificationMethod) {
+		db := testDB(t)
+		m := SqlManager{DB: db, MethodManagers: map[string]MethodManager{"example": testMethod{}, "test": testMethod{}}, KeyRotationGracePeriod: time.Hour}
+		opts := DefaultCreationOptions().With(SubjectCreationOption{Subject: subject})
+		_, _, err := m.Create(audit.TestContext(), opts)
+		require.NoError(t, err)
+		vms, err := m.AddVerificationMethod(audit.TestContext(), subject, orm.AssertionKeyUsage())
+		require.NoError(t, err)
+		return m, vms
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		m, replaced := setup(t)
+
+		vms, err := m.RotateVerificationMethod(audit.TestContext(), subject, orm.AssertionKeyUsage())
+
+		require.NoError(t, err)
+		require.Len(t, vms, 2)
+		t.Run("replaced keys are scheduled for retirement", func(t *testing.T) {
+			var retirements []orm.VerificationMethodRetirement
+			require.NoError(t, m.DB.Find(&retirements).Error)
+			require.Len(t, retirements, 2)
+			for _, retirement := range retirements {
+				assert.Contains(t, []string{replaced[0].ID.String(), replaced[1].ID.String()}, retirement.VerificationMethodID)
+				assert.InDelta(t, time.Now().Add(time.Hour).Unix(), retirement.RetireAt, 5)
+			}
+		})
+		t.Run("DID documents contain both keys", func(t *testing.T) {
+			dids, err := m.ListDIDs(audit.TestContext(), subject)
+			require.NoError(t, err)
+			for _, id := range dids {
+				doc, err := NewDIDDocumentManager(m.DB).Latest(id, nil)
+				require.NoError(t, err)
+				require.Len(t, doc.VerificationMethods, 2)
+				for _, vm := range doc.VerificationMethods {
+					assert.Equal(t, orm.VerificationMethodKeyType(orm.AssertionKeyUsage()), vm.KeyTypes)
+				}
+			}
+		})
+	})
+	t.Run("keys scheduled for retirement are not rotated again", func(t *testing.T) {
+		m, _ := setup(t)
+		_, err := m.RotateVerificationMethod(audit.TestContext(), subject, orm.AssertionKeyUsage())
+		require.NoError(t, err)
+
+		vms, err := m.RotateVerificationMethod(audit.TestContext(), subject, orm.AssertionKeyUsage())
+
+		require.NoError(t, err)
+		assert.Len(t, vms, 2)
+		var count int64
+		require.NoError(t, m.DB.Model(&orm.VerificationMethodRetirement{}).Count(&count).Error)
+		assert.Equal(t, int64(4), count)
+	})
+	t.Run("no keys with the given usage", func(t *testing.T) {
+		m, _ := setup(t)
+
+		vms, err := m.RotateVerificationMethod(audit.TestContext(), subject, orm.EncryptionKeyUsage())
+
+		require.NoError(t, err)
+		assert.Empty(t, vms)
+	})
+	t.Run("unknown subject", func(t *testing.T) {
+		m, _ := setup(t)
+
+		_, err := m.RotateVerificationMethod(audit.TestContext(), "unknown", orm.AssertionKeyUsage())
+
+		assert.ErrorIs(t, err, ErrSubjectNotFound)
+	})
+}
+
+func TestManager_RotateExpiredVerificationMethods(t *testing.T) {
+	subject := "subject"
+	policy := KeyRotationPolicy{orm.AssertionKeyUsage(): 24 * time.Hour}
+	setup := func(t *testing.T, keyAge time.Duration) (SqlManager, did.VerificationMethod) {
+		db := testDB(t)
+		m := SqlManager{DB: db, MethodManagers: map[string]MethodManager{"example": testMethod{}}, KeyRotationGracePeriod: time.Hour, KeyRotationPolicy: policy}
+		opts := DefaultCreationOptions().With(SubjectCreationOption{Subject: subject})
+		_, _, err := m.Create(audit.TestContext(), opts)
+		require.NoError(t, err)
+		vms, err := m.AddVerificationMethod(audit.TestContext(), subject, orm.AssertionKeyUsage())
+		require.NoError(t, err)
+		// the age of a key is derived from the DID document version it was added in
+		require.NoError(t, db.Model(&orm.DidDocument{}).Where("1 = 1").Update("updated_at", time.Now().Add(-keyAge).Unix()).Error)
+		return m, vms[0]
+	}
+	retirementCount := func(t *testing.T, m SqlManager) int64 {
+		var count int64
+		require.NoError(t, m.DB.Model(&orm.VerificationMethodRetirement{}).Count(&count).Error)
+		return count
+	}
+
+	t.Run("expired key is rotated", func(t *testing.T) {
+		m, vm := setup(t, 48*time.Hour)
+
+		next, err := m.RotateExpiredVerificationMethods(audit.TestContext())
+
+		require.NoError(t, err)
+		require.NotNil(t, next)
+		assert.Equal(t, subject, next.Subject)
+		assert.NotEqual(t, vm.ID.String(), next.VerificationMethodID)
+		assert.WithinDuration(t, time.Now().Add(24*time.Hour), next.At, 5*time.Second)
+		var retirement orm.VerificationMethodRetirement
+		require.NoError(t, m.DB.First(&retirement).Error)
+		assert.Equal(t, vm.ID.String(), retirement.VerificationMethodID)
+	})
+	t.Run("subject of which the DID document can't be loaded is skipped", func(t *testing.T) {
+		m, vm := setup(t, 48*time.Hour)
+		_, _, err := m.Create(audit.TestContext(), DefaultCreationOptions().With(SubjectCreationOption{Subject: "other"}))
+		require.NoError(t, err)
+		otherDIDs, err := m.ListDIDs(audit.TestContext(), "other")
+		require.NoError(t, err)
+		// a DID document version in the future isn't returned as latest version
+		require.NoError(t, m.DB.Model(&orm.DidDocument{}).Where("did = ?", otherDIDs[0].String()).Update("updated_at", time.Now().Add(2*time.Hour).Unix()).Error)
+
+		_, err = m.RotateExpiredVerificationMethods(audit.TestContext())
+
+		require.NoError(t, err)
+		var retirement orm.VerificationMethodRetirement
+		require.NoError(t, m.DB.First(&retirement).Error)
+		assert.Equal(t, vm.ID.String(), retirement.VerificationMethodID)
+	})
+	t.Run("rotated keys are not rotated again", func(t *testing.T) {
+		m, _ := setup(t, 48*time.Hour)
+		_, err := m.RotateExpiredVerificationMethods(audit.TestContext())
+		require.NoError(t, err)
+
+		_, err = m.RotateExpiredVerificationMethods(audit.TestContext())
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), retirementCount(t, m))
+	})
+	t.Run("key not expired", func(t *testing.T) {
+		m, vm := setup(t, time.Hour)
+
+		next, err := m.RotateExpiredVerificationMethods(audit.TestContext())
+
+		require.NoError(t, err)
+		require.NotNil(t, next)
+		assert.Equal(t, vm.ID.String(), next.VerificationMethodID)
+		assert.WithinDuration(t, time.Now().Add(23*time.Hour), next.At, 5*time.Second)
+		assert.Equal(t, int64(0), retirementCount(t, m))
+	})
+	t.Run("key usage not in policy", func(t *testing.T) {
+		m, _ := setup(t, 48*time.Hour)
+		m.KeyRotationPolicy = KeyRotationPolicy{orm.EncryptionKeyUsage(): 24 * time.Hour}
+
+		next, err := m.RotateExpiredVerificationMethods(audit.TestContext())
+
+		require.NoError(t, err)
+		assert.Nil(t, next)
+		assert.Equal(t, int64(0), retirementCount(t, m))
+	})
+	t.Run("no policy", func(t *testing.T) {
+		m, _ := setup(t, 48*time.Hour)
+		m.KeyRotationPolicy = nil
+
+		next, err := m.RotateExpiredVerificationMethods(audit.TestContext())
+
+		require.NoError(t, err)
+		assert.Nil(t, next)
+		assert.Equal(t, int64(0), retirementCount(t, m))
+	})
+}
+
+func TestManager_RetireVerificationMethods(t *testing.T) {
+	subject := "subject"
+	setup := func(t *testing.T, gracePeriod time.Duration) (SqlManager, *nutsCrypto.MockKeyStore, []did.VerificationMethod) {
+		db := testDB(t)
+		keyStore := nutsCrypto.NewMockKeyStore(gomock.NewController(t))
+		m := SqlManager{DB: db, MethodManagers: map[string]MethodManager{"example": testMethod{}}, KeyStore: keyStore, KeyRotationGracePeriod: gracePeriod}
+		opts := DefaultCreationOptions().With(SubjectCreationOption{Subject: subject})
+		_, _, err := m.Create(audit.TestContext(), opts)
+		require.NoError(t, err)
+		replaced, err := m.AddVerificationMethod(audit.TestContext(), subject, orm.AssertionKeyUsage())
+		require.NoError(t, err)
+		_, err = m.RotateVerificationMethod(audit.TestContext(), subject, orm.AssertionKeyUsage())
+		require.NoError(t, err)
+		return m, keyStore, replaced
+	}
+	latestVerificationMethods := func(t *testing.T, m SqlManager) []string {
+		dids, err := m.ListDIDs(audit.TestContext(), subject)
+		require.NoError(t, err)
+		doc, err := NewDIDDocumentManager(m.DB).Latest(dids[0], nil)
+		require.NoError(t, err)
+		var result []string
+		for _, vm := range doc.VerificationMethods {
+			result = append(result, vm.ID)
+		}
+		return result
+	}
+	retirementCount := func(t *testing.T, m SqlManager) int64 {
+		var count int64
+		require.NoError(t, m.DB.Model(&orm.VerificationMethodRetirement{}).Count(&count).Error)
+		return count
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		m, keyStore, replaced := setup(t, 0)
+		keyStore.EXPECT().Delete(gomock.Any(), replaced[0].ID.String()).Return(nil)
+
+		m.RetireVerificationMethods(audit.TestContext())
+
+		vms := latestVerificationMethods(t, m)
+		require.Len(t, vms, 1)
+		assert.NotEqual(t, replaced[0].ID.String(), vms[0])
+		assert.Equal(t, int64(0), retirementCount(t, m))
+	})
+	t.Run("private key already deleted", func(t *testing.T) {
+		m, keyStore, replaced := setup(t, 0)
+		keyStore.EXPECT().Delete(gomock.Any(), replaced[0].ID.String()).Return(nutsCrypto.ErrPrivateKeyNotFound)
+
+		m.RetireVerificationMethods(audit.TestContext())
+
+		assert.Len(t, latestVerificationMethods(t, m), 1)
+		assert.Equal(t, int64(0), retirementCount(t, m))
+	})
+	t.Run("storage backend can't delete private keys", func(t *testing.T) {
+		m, keyStore, replaced := setup(t, 0)
+		keyStore.EXPECT().Delete(gomock.Any(), replaced[0].ID.String()).Return(fmt.Errorf("DeletePrivateKey() is not supported for CSC: %w", spi.ErrDeleteNotSupported))
+
+		m.RetireVerificationMethods(audit.TestContext())
+
+		// retirement isn't retried, since deleting the private key will never succeed
+		assert.Len(t, latestVerificationMethods(t, m), 1)
+		assert.Equal(t, int64(0), retirementCount(t, m))
+	})
+	t.Run("grace period not passed", func(t *testing.T) {
+		m, _, _ := setup(t, time.Hour)
+
+		m.RetireVerificationMethods(audit.TestContext())
+
+		assert.Len(t, latestVerificationMethods(t, m), 2)
+		assert.Equal(t, int64(1), retirementCount(t, m))
+	})
+	t.Run("deleting private key fails", func(t *testing.T) {
+		m, keyStore, replaced := setup(t, 0)
+		keyStore.EXPECT().Delete(gomock.Any(), replaced[0].ID.String()).Return(assert.AnError)
+
+		m.RetireVerificationMethods(audit.TestContext())
+
+		// verification method is removed, but retirement is retried on the next run
+		assert.Len(t, latestVerificationMethods(t, m), 1)
+		assert.Equal(t, int64(1), retirementCount(t, m))
+	})
 }
 
 func TestManager_Deactivate(t *testing.T) {
@@ -209,6 +209,21 @@ func (mr *MockManagerMockRecorder) Create(ctx, options any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockManager)(nil).Create), ctx, options)
 }
 
+// CreatePeerDID mocks base method.
+func (m *MockManager) CreatePeerDID(ctx context.Context, subject, relationship string) (*did.Document, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePeerDID", ctx, subject, relationship)
+	ret0, _ := ret[0].(*did.Document)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePeerDID indicates an expected call of CreatePeerDID.
+func (mr *MockManagerMockRecorder) CreatePeerDID(ctx, subject, relationship any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePeerDID", reflect.TypeOf((*MockManager)(nil).CreatePeerDID), ctx, subject, relationship)
+}
+
 // CreateService mocks base method.
 func (m *MockManager) CreateService(ctx context.Context, subject string, service did.Service) ([]did.Service, error) {
 	m.ctrl.T.Helper()
@@ -312,6 +327,33 @@ func (mr *MockManagerMockRecorder) ListDIDs(ctx, subject any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDIDs", reflect.TypeOf((*MockManager)(nil).ListDIDs), ctx, subject)
 }
 
+// ListDIDsForRelationship mocks base method.
+func (m *MockManager) ListDIDsForRelationship(ctx context.Context, subject, relationship string) ([]did.DID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDIDsForRelationship", ctx, subject, relationship)
+	ret0, _ := ret[0].([]did.DID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDIDsForRelationship indicates an expected call of ListDIDsForRelationship.
+func (mr *MockManagerMockRecorder) ListDIDsForRelationship(ctx, subject, relationship any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDIDsForRelationship", reflect.TypeOf((*MockManager)(nil).ListDIDsForRelationship), ctx, subject, relationship)
+}
+
+// RetireVerificationMethods mocks base method.
+func (m *MockManager) RetireVerificationMethods(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RetireVerificationMethods", ctx)
+}
+
+// RetireVerificationMethods indicates an expected call of RetireVerificationMethods.
+func (mr *MockManagerMockRecorder) RetireVerificationMethods(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetireVerificationMethods", reflect.TypeOf((*MockManager)(nil).RetireVerificationMethods), ctx)
+}
+
 // Rollback mocks base method.
 func (m *MockManager) Rollback(ctx context.Context) {
 	m.ctrl.T.Helper()
@@ -324,6 +366,36 @@ func (mr *MockManagerMockRecorder) Rollback(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*MockManager)(nil).Rollback), ctx)
 }
 
+// RotateExpiredVerificationMethods mocks base method.
+func (m *MockManager) RotateExpiredVerificationMethods(ctx context.Context) (*ScheduledKeyRotation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateExpiredVerificationMethods", ctx)
+	ret0, _ := ret[0].(*ScheduledKeyRotation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateExpiredVerificationMethods indicates an expected call of RotateExpiredVerificationMethods.
+func (mr *MockManagerMockRecorder) RotateExpiredVerificationMethods(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateExpiredVerificationMethods", reflect.TypeOf((*MockManager)(nil).RotateExpiredVerificationMethods), ctx)
+}
+
+// RotateVerificationMethod mocks base method.
+func (m *MockManager) RotateVerificationMethod(ctx context.Context, subject string, keyUsage orm.DIDKeyFlags) ([]did.VerificationMethod, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateVerificationMethod", ctx, subject, keyUsage)
+	ret0, _ := ret[0].([]did.VerificationMethod)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RotateVerificationMethod indicates an expected call of RotateVerificationMethod.
+func (mr *MockManagerMockRecorder) RotateVerificationMethod(ctx, subject, keyUsage any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateVerificationMethod", reflect.TypeOf((*MockManager)(nil).RotateVerificationMethod), ctx, subject, keyUsage)
+}
+
 // UpdateService mocks base method.
 func (m *MockManager) UpdateService(ctx context.Context, subject string, serviceID ssi.URI, service did.Service) ([]did.Service, error) {
 	m.ctrl.T.Helper()
@@ -21,11 +21,16 @@ package didsubject
 import (
 	"errors"
 	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/storage/orm"
 	"github.com/nuts-foundation/nuts-node/vdr/resolver"
 	"gorm.io/gorm"
+	"strconv"
 	"time"
 )
 
+// Resolver resolves DID documents managed by this node from the local DID document history.
+// Historic versions can be resolved by setting ResolveTime (versionTime) or VersionID (versionId) in the resolve metadata,
+// where VersionID takes precedence.
 type Resolver struct {
 	DB *gorm.DB
 }
@@ -39,7 +44,17 @@ func (r Resolver) Resolve(id did.DID, metadata *resolver.ResolveMetadata) (*did.
 		notAfter = metadata.ResolveTime
 	}
 
-	doc, err := didDocumentMananager.Latest(id, notAfter)
+	var doc *orm.DidDocument
+	var err error
+	if metadata != nil && metadata.VersionID != "" {
+		version, parseErr := strconv.Atoi(metadata.VersionID)
+		if parseErr != nil {
+			return nil, nil, resolver.ErrNotFound
+		}
+		doc, err = didDocumentMananager.Version(id, version)
+	} else {
+		doc, err = didDocumentMananager.Latest(id, notAfter)
+	}
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, nil, resolver.ErrNotFound
@@ -48,8 +63,9 @@ func (r Resolver) Resolve(id did.DID, metadata *resolver.ResolveMetadata) (*did.
 	}
 	updated := time.Unix(doc.UpdatedAt, 0)
 	resolverMetadata := resolver.DocumentMetadata{
-		Created: time.Unix(doc.CreatedAt, 0),
-		Updated: &updated,
+		Created:   time.Unix(doc.CreatedAt, 0),
+		Updated:   &updated,
+		VersionID: strconv.Itoa(doc.Version),
 	}
 	document, err := doc.ToDIDDocument()
 	if resolver.IsDeactivated(document) {
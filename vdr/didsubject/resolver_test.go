@@ -49,6 +49,39 @@ func TestResolver_Resolve(t *testing.T) {
 		require.NotNil(t, doc)
 		require.NotNil(t, meta)
 		assert.Equal(t, exampleDID.String(), doc.ID.String())
+		assert.Equal(t, "0", meta.VersionID)
+	})
+	t.Run("versionId", func(t *testing.T) {
+		db := testDB(t)
+		sqlDIDDocumentManager := NewDIDDocumentManager(db)
+		dbResolver := Resolver{DB: db}
+		_, err := sqlDIDDocumentManager.CreateOrUpdate(orm.DID{ID: exampleDID.String()}, []orm.VerificationMethod{verificationMethod}, nil)
+		require.NoError(t, err)
+		_, err = sqlDIDDocumentManager.CreateOrUpdate(orm.DID{ID: exampleDID.String()}, nil, nil)
+		require.NoError(t, err)
+
+		t.Run("historic version", func(t *testing.T) {
+			doc, meta, err := dbResolver.Resolve(exampleDID, &resolver.ResolveMetadata{VersionID: "0"})
+
+			require.NoError(t, err)
+			assert.Len(t, doc.VerificationMethod, 1)
+			assert.Equal(t, "0", meta.VersionID)
+		})
+		t.Run("latest version is deactivated", func(t *testing.T) {
+			_, _, err := dbResolver.Resolve(exampleDID, &resolver.ResolveMetadata{VersionID: "1"})
+
+			assert.Equal(t, resolver.ErrDeactivated, err)
+		})
+		t.Run("unknown version", func(t *testing.T) {
+			_, _, err := dbResolver.Resolve(exampleDID, &resolver.ResolveMetadata{VersionID: "2"})
+
+			assert.Equal(t, resolver.ErrNotFound, err)
+		})
+		t.Run("invalid version", func(t *testing.T) {
+			_, _, err := dbResolver.Resolve(exampleDID, &resolver.ResolveMetadata{VersionID: "abc"})
+
+			assert.Equal(t, resolver.ErrNotFound, err)
+		})
 	})
 	t.Run("not found with resolve time", func(t *testing.T) {
 		db := testDB(t)
@@ -42,17 +42,25 @@ type Resolver struct {
 }
 
 // NewResolver creates a new did:web Resolver with default TLS configuration.
+// HTTP responses aren't cached, DID documents are cached by the DID resolution cache instead (see didcache),
+// so invalidating a DID there makes the next resolution fetch the DID document from its origin.
 func NewResolver() *Resolver {
 	return &Resolver{
-		HttpClient: client.NewWithCache(5 * time.Second),
+		HttpClient: client.New(5 * time.Second),
 	}
 }
 
 // Resolve implements the DIDResolver interface.
-func (w Resolver) Resolve(id did.DID, _ *resolver.ResolveMetadata) (*did.Document, *resolver.DocumentMetadata, error) {
+// did:web only publishes the current DID document, so a specific version (versionId) can't be resolved.
+// Those are only available for DIDs managed by this node (see didsubject.Resolver).
+// ResolveTime is ignored: the current DID document is returned, leaving it to the caller to check key validity at that time.
+func (w Resolver) Resolve(id did.DID, metadata *resolver.ResolveMetadata) (*did.Document, *resolver.DocumentMetadata, error) {
 	if id.Method != "web" {
 		return nil, nil, errors.New("DID is not did:web")
 	}
+	if metadata != nil && metadata.VersionID != "" {
+		return nil, nil, fmt.Errorf("did:web versionId can only be resolved for DIDs managed by this node: %w", resolver.ErrNotFound)
+	}
 
 	baseURL, err := DIDToURL(id)
 	if err != nil {
@@ -113,5 +121,5 @@ func (w Resolver) Resolve(id did.DID, _ *resolver.ResolveMetadata) (*did.Documen
 		return nil, nil, fmt.Errorf("did:web document ID mismatch: %s != %s", document.ID, id)
 	}
 
-	return &document, &resolver.DocumentMetadata{}, nil
+	return &document, &resolver.DocumentMetadata{CacheUntil: client.CacheExpiration(request, httpResponse)}, nil
 }
@@ -21,6 +21,7 @@ package didweb
 import (
 	"github.com/nuts-foundation/go-did/did"
 	http2 "github.com/nuts-foundation/nuts-node/test/http"
+	vdrResolver "github.com/nuts-foundation/nuts-node/vdr/resolver"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"io"
@@ -28,6 +29,7 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 )
 
 const didDocTemplate = `
@@ -70,6 +72,12 @@ func TestResolver_Resolve(t *testing.T) {
 			writer.WriteHeader(http.StatusOK)
 			_, _ = writer.Write([]byte(strings.ReplaceAll(didDocTemplate, "<did>", baseDID.String()+":json-ld")))
 			return
+		case "/cache-control/did.json":
+			writer.Header().Add("Content-Type", "application/did+json")
+			writer.Header().Add("Cache-Control", "max-age=60")
+			writer.WriteHeader(http.StatusOK)
+			_, _ = writer.Write([]byte(strings.ReplaceAll(didDocTemplate, "<did>", baseDID.String()+":cache-control")))
+			return
 		case "/json/did.json":
 			writer.Header().Add("Content-Type", "application/json")
 			writer.WriteHeader(http.StatusOK)
@@ -126,6 +134,20 @@ func TestResolver_Resolve(t *testing.T) {
 		assert.NotNil(t, md)
 		assert.NotNil(t, doc)
 	})
+	t.Run("HTTP cache headers are returned as metadata", func(t *testing.T) {
+		doc, md, err := resolver.Resolve(did.MustParseDID(baseDID.String()+":cache-control"), nil)
+
+		require.NoError(t, err)
+		require.NotNil(t, doc)
+		require.NotNil(t, md.CacheUntil)
+		assert.WithinDuration(t, time.Now().Add(time.Minute), *md.CacheUntil, 5*time.Second)
+	})
+	t.Run("no HTTP cache headers", func(t *testing.T) {
+		_, md, err := resolver.Resolve(baseDID, nil)
+
+		require.NoError(t, err)
+		assert.Nil(t, md.CacheUntil)
+	})
 
 	t.Run("resolve without port number", func(t *testing.T) {
 		// The other tests all use a port number, since the test HTTPS server is running on a random port.
@@ -156,6 +178,13 @@ func TestResolver_Resolve(t *testing.T) {
 		assert.Nil(t, md)
 		assert.Nil(t, doc)
 	})
+	t.Run("versionId is not supported", func(t *testing.T) {
+		doc, md, err := resolver.Resolve(baseDID, &vdrResolver.ResolveMetadata{VersionID: "1"})
+
+		assert.ErrorIs(t, err, vdrResolver.ErrNotFound)
+		assert.Nil(t, md)
+		assert.Nil(t, doc)
+	})
 	t.Run("unsupported content-type", func(t *testing.T) {
 		id := did.MustParseDID(baseDID.String() + ":unsupported-content-type")
 		doc, md, err := resolver.Resolve(id, nil)
@@ -0,0 +1,58 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package didwebvh
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_canonicalize(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"sorts properties", `{"b": 1, "a": {"d": true, "c": null}}`, `{"a":{"c":null,"d":true},"b":1}`},
+		{"sorts by UTF-16 code units", `{"\u20ac": 1, "\ud83d\ude00": 2, "\r": 3, "1": 4}`, "{\"\\r\":3,\"1\":4,\"\u20ac\":1,\"\U0001F600\":2}"},
+		{"keeps array order", `[3, 1, "2"]`, `[3,1,"2"]`},
+		{"numbers", `[1.0, 0.5, -0, 1e21, 1e-7, 123456789012345680000, 0.000001]`, `[1,0.5,0,1e+21,1e-7,123456789012345680000,0.000001]`},
+		{"escapes strings", `"\u0000\u001f\"\\\/\u2028é"`, "\"\\u0000\\u001f\\\"\\\\/\u2028é\""},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual, err := canonicalize([]byte(testCase.input))
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, string(actual))
+		})
+	}
+	t.Run("error - invalid JSON", func(t *testing.T) {
+		_, err := canonicalize([]byte(`{`))
+
+		assert.Error(t, err)
+	})
+	t.Run("error - trailing data", func(t *testing.T) {
+		_, err := canonicalize([]byte(`{} {}`))
+
+		assert.EqualError(t, err, "JCS: unexpected data after JSON value")
+	})
+}
@@ -0,0 +1,451 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package didwebvh
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	"github.com/mr-tron/base58"
+	nutsCrypto "github.com/nuts-foundation/nuts-node/crypto"
+)
+
+// MethodVersion is the value of the method parameter of the first log entry, indicating the version of the did:webvh specification.
+const MethodVersion = "did:webvh:1.0"
+
+const (
+	scidPlaceholder     = "{SCID}"
+	proofType           = "DataIntegrityProof"
+	proofPurpose        = "assertionMethod"
+	cryptosuiteECDSAJCS = "ecdsa-jcs-2019"
+	cryptosuiteEdDSAJCS = "eddsa-jcs-2022"
+	logEntryTimeFormat  = time.RFC3339Nano
+	maxLogEntryLength   = 1024 * 1024
+	// invalidLogEntryFormat is the format of errors returned when verifying a log entry fails, taking the entry number and cause.
+	invalidLogEntryFormat = "invalid DID log entry %d: %w"
+)
+
+// LogEntry is a single entry (line) of a did:webvh DID log (did.jsonl).
+type LogEntry struct {
+	VersionID   string          `json:"versionId"`
+	VersionTime string          `json:"versionTime"`
+	Parameters  Parameters      `json:"parameters"`
+	State       json.RawMessage `json:"state"`
+	Proof       []Proof         `json:"proof,omitempty"`
+}
+
+// Parameters contains the did:webvh parameters of a log entry. Parameters that are absent keep their previous value.
+type Parameters struct {
+	Method        string    `json:"method,omitempty"`
+	SCID          string    `json:"scid,omitempty"`
+	UpdateKeys    *[]string `json:"updateKeys,omitempty"`
+	NextKeyHashes *[]string `json:"nextKeyHashes,omitempty"`
+	Portable      *bool     `json:"portable,omitempty"`
+	Deactivated   *bool     `json:"deactivated,omitempty"`
+	// Witness is not supported; a log that configures witnesses can't be verified.
+	Witness json.RawMessage `json:"witness,omitempty"`
+	TTL     *int            `json:"ttl,omitempty"`
+}
+
+// Proof is a Data Integrity proof over a log entry.
+type Proof struct {
+	Type               string `json:"type"`
+	Cryptosuite        string `json:"cryptosuite"`
+	VerificationMethod string `json:"verificationMethod"`
+	Created            string `json:"created"`
+	ProofPurpose       string `json:"proofPurpose"`
+	ProofValue         string `json:"proofValue,omitempty"`
+}
+
+// versionNumber returns the version number part of the versionId.
+func (e LogEntry) versionNumber() (int, error) {
+	number, _, ok := strings.Cut(e.VersionID, "-")
+	if !ok {
+		return 0, fmt.Errorf("invalid versionId: %s", e.VersionID)
+	}
+	return strconv.Atoi(number)
+}
+
+// entryHash returns the hash of the entry (without proof), with the versionId set to the given versionId.
+func (e LogEntry) entryHash(versionID string) (string, error) {
+	e.VersionID = versionID
+	e.Proof = nil
+	data, err := canonicalizeValue(e)
+	if err != nil {
+		return "", err
+	}
+	return hashString(data), nil
+}
+
+// MarshalLine returns the canonical JSON representation of the entry, as it's stored as line in the DID log.
+func (e LogEntry) MarshalLine() ([]byte, error) {
+	return canonicalizeValue(e)
+}
+
+// newGenesisEntry creates the first log entry of a DID log. All occurrences of {SCID} in the parameters and state are replaced
+// by the self-certifying identifier, which is derived from the entry itself.
+// The returned entry is not signed yet.
+func newGenesisEntry(versionTime time.Time, parameters Parameters, state interface{}) (*LogEntry, error) {
+	parameters.Method = MethodVersion
+	parameters.SCID = scidPlaceholder
+	stateJSON, err := json.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+	preliminary := LogEntry{
+		VersionID:   scidPlaceholder,
+		VersionTime: versionTime.UTC().Format(logEntryTimeFormat),
+		Parameters:  parameters,
+		State:       stateJSON,
+	}
+	preliminaryJSON, err := canonicalizeValue(preliminary)
+	if err != nil {
+		return nil, err
+	}
+	scid := hashString(preliminaryJSON)
+	var entry LogEntry
+	if err = json.Unmarshal(bytes.ReplaceAll(preliminaryJSON, []byte(scidPlaceholder), []byte(scid)), &entry); err != nil {
+		return nil, err
+	}
+	hash, err := entry.entryHash(scid)
+	if err != nil {
+		return nil, err
+	}
+	entry.VersionID = "1-" + hash
+	return &entry, nil
+}
+
+// newEntry creates a log entry that follows up on the given (previous) entry.
+// The returned entry is not signed yet.
+func newEntry(previous LogEntry, versionTime time.Time, parameters Parameters, state interface{}) (*LogEntry, error) {
+	previousNumber, err := previous.versionNumber()
+	if err != nil {
+		return nil, err
+	}
+	stateJSON, err := json.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+	entry := LogEntry{
+		VersionTime: versionTime.UTC().Format(logEntryTimeFormat),
+		Parameters:  parameters,
+		State:       stateJSON,
+	}
+	hash, err := entry.entryHash(previous.VersionID)
+	if err != nil {
+		return nil, err
+	}
+	entry.VersionID = fmt.Sprintf("%d-%s", previousNumber+1, hash)
+	return &entry, nil
+}
+
+// sign adds a Data Integrity proof to the entry, created with the given update key.
+func (e *LogEntry) sign(ctx context.Context, signer nutsCrypto.DataSigner, updateKey string, created time.Time) error {
+	publicKey, err := decodeMultikey(updateKey)
+	if err != nil {
+		return err
+	}
+	proof := Proof{
+		Type:               proofType,
+		VerificationMethod: multikeyToKID(updateKey),
+		Created:            created.UTC().Format(time.RFC3339),
+		ProofPurpose:       proofPurpose,
+	}
+	switch publicKey.(type) {
+	case *ecdsa.PublicKey:
+		proof.Cryptosuite = cryptosuiteECDSAJCS
+	default:
+		proof.Cryptosuite = cryptosuiteEdDSAJCS
+	}
+	hashData, err := proofHashData(*e, proof)
+	if err != nil {
+		return err
+	}
+	signature, err := signer.SignData(ctx, hashData, multikeyToKID(updateKey))
+	if err != nil {
+		return fmt.Errorf("unable to sign DID log entry: %w", err)
+	}
+	proof.ProofValue = "z" + base58.Encode(signature)
+	e.Proof = append(e.Proof, proof)
+	return nil
+}
+
+// proofHashData returns the data to be signed for a JCS Data Integrity cryptosuite:
+// the hash of the canonicalized proof configuration, followed by the hash of the canonicalized entry (without proofs).
+func proofHashData(entry LogEntry, proof Proof) ([]byte, error) {
+	entry.Proof = nil
+	proof.ProofValue = ""
+	proofConfig, err := canonicalizeValue(proof)
+	if err != nil {
+		return nil, err
+	}
+	document, err := canonicalizeValue(entry)
+	if err != nil {
+		return nil, err
+	}
+	proofConfigHash := sha256.Sum256(proofConfig)
+	documentHash := sha256.Sum256(document)
+	return append(proofConfigHash[:], documentHash[:]...), nil
+}
+
+// verifyProof verifies the proof over the entry, and checks that it's created by one of the authorized update keys.
+func verifyProof(entry LogEntry, proof Proof, authorizedKeys []string) error {
+	if proof.Type != proofType {
+		return fmt.Errorf("unsupported proof type: %s", proof.Type)
+	}
+	if proof.ProofPurpose != proofPurpose {
+		return fmt.Errorf("unsupported proof purpose: %s", proof.ProofPurpose)
+	}
+	updateKey, err := kidToMultikey(proof.VerificationMethod)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(authorizedKeys, updateKey) {
+		return fmt.Errorf("proof is not created by an authorized update key: %s", proof.VerificationMethod)
+	}
+	publicKey, err := decodeMultikey(updateKey)
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(proof.ProofValue, "z") {
+		return errors.New("proofValue must be base58btc encoded")
+	}
+	signature, err := base58.Decode(proof.ProofValue[1:])
+	if err != nil {
+		return fmt.Errorf("invalid proofValue: %w", err)
+	}
+	hashData, err := proofHashData(entry, proof)
+	if err != nil {
+		return err
+	}
+	switch key := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		if proof.Cryptosuite != cryptosuiteECDSAJCS {
+			return fmt.Errorf("unsupported cryptosuite for P-256 key: %s", proof.Cryptosuite)
+		}
+		verifier, _ := jws.NewVerifier(jwa.ES256)
+		if err = verifier.Verify(hashData, signature, key); err != nil {
+			return fmt.Errorf("invalid proof signature: %w", err)
+		}
+	case ed25519.PublicKey:
+		if proof.Cryptosuite != cryptosuiteEdDSAJCS {
+			return fmt.Errorf("unsupported cryptosuite for Ed25519 key: %s", proof.Cryptosuite)
+		}
+		if !ed25519.Verify(key, hashData, signature) {
+			return errors.New("invalid proof signature")
+		}
+	}
+	return nil
+}
+
+// ParseLog parses a DID log (JSON Lines, one entry per line).
+func ParseLog(data []byte) ([]LogEntry, error) {
+	var entries []LogEntry
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLogEntryLength)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var entry LogEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return nil, fmt.Errorf(invalidLogEntryFormat, len(entries)+1, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, errors.New("DID log is empty")
+	}
+	return entries, nil
+}
+
+// Version is a verified version of a DID document from a DID log.
+type Version struct {
+	VersionID   string
+	VersionTime time.Time
+	State       json.RawMessage
+	Deactivated bool
+}
+
+// verifyLog verifies the DID log of the given DID and returns all its versions. It checks:
+//   - the self-certifying identifier (SCID) of the first entry,
+//   - the hash chain and numbering of the versionIds,
+//   - that versionTimes are increasing and not in the future,
+//   - the proofs, which must be created by an authorized update key,
+//   - that updateKeys match the pre-rotation commitment (nextKeyHashes) of the previous entry, if pre-rotation is active,
+//   - that the DID in the state matches the DID being resolved.
+func verifyLog(id string, entries []LogEntry, now time.Time) ([]Version, error) {
+	var versions []Version
+	var scid string
+	var updateKeys []string
+	var nextKeyHashes []string
+	var previous *LogEntry
+	var previousTime time.Time
+	deactivated := false
+	for i, entry := range entries {
+		number := i + 1
+		if deactivated {
+			return nil, fmt.Errorf(invalidLogEntryFormat, number, errors.New("DID is deactivated in a previous entry"))
+		}
+		params := entry.Parameters
+		if len(params.Witness) > 0 && string(params.Witness) != "null" && string(params.Witness) != "{}" {
+			return nil, fmt.Errorf(invalidLogEntryFormat, number, errors.New("witnesses are not supported"))
+		}
+		if params.Portable != nil && *params.Portable {
+			return nil, fmt.Errorf(invalidLogEntryFormat, number, errors.New("portable DIDs are not supported"))
+		}
+		entryNumber, err := entry.versionNumber()
+		if err != nil || entryNumber != number {
+			return nil, fmt.Errorf(invalidLogEntryFormat, number, fmt.Errorf("unexpected versionId: %s", entry.VersionID))
+		}
+		// check the entry hash, which chains the entry to the previous one
+		previousVersionID := scid
+		if previous == nil {
+			if params.Method != MethodVersion {
+				return nil, fmt.Errorf(invalidLogEntryFormat, number, fmt.Errorf("unsupported method version: %s", params.Method))
+			}
+			scid = params.SCID
+			if err = verifySCID(entry); err != nil {
+				return nil, fmt.Errorf(invalidLogEntryFormat, number, err)
+			}
+			previousVersionID = scid
+		} else {
+			previousVersionID = previous.VersionID
+			if params.Method != "" && params.Method != MethodVersion {
+				return nil, fmt.Errorf(invalidLogEntryFormat, number, fmt.Errorf("unsupported method version: %s", params.Method))
+			}
+			if params.SCID != "" && params.SCID != scid {
+				return nil, fmt.Errorf(invalidLogEntryFormat, number, errors.New("SCID can't be changed"))
+			}
+		}
+		hash, err := entry.entryHash(previousVersionID)
+		if err != nil {
+			return nil, fmt.Errorf(invalidLogEntryFormat, number, err)
+		}
+		if entry.VersionID != fmt.Sprintf("%d-%s", number, hash) {
+			return nil, fmt.Errorf(invalidLogEntryFormat, number, errors.New("entry hash mismatch"))
+		}
+		// check the versionTime
+		versionTime, err := time.Parse(time.RFC3339Nano, entry.VersionTime)
+		if err != nil {
+			return nil, fmt.Errorf(invalidLogEntryFormat, number, fmt.Errorf("invalid versionTime: %w", err))
+		}
+		if versionTime.After(now) {
+			return nil, fmt.Errorf(invalidLogEntryFormat, number, errors.New("versionTime is in the future"))
+		}
+		if previous != nil && !versionTime.After(previousTime) {
+			return nil, fmt.Errorf(invalidLogEntryFormat, number, errors.New("versionTime must be after the versionTime of the previous entry"))
+		}
+		// determine the update keys that are authorized to sign this entry
+		var authorizedKeys []string
+		switch {
+		case previous == nil:
+			if params.UpdateKeys == nil || len(*params.UpdateKeys) == 0 {
+				return nil, fmt.Errorf(invalidLogEntryFormat, number, errors.New("updateKeys must be set"))
+			}
+			authorizedKeys = *params.UpdateKeys
+		case len(nextKeyHashes) > 0:
+			// pre-rotation: the new update keys must have been committed to in the previous entry, and sign this entry
+			if params.UpdateKeys == nil || len(*params.UpdateKeys) == 0 {
+				return nil, fmt.Errorf(invalidLogEntryFormat, number, errors.New("updateKeys must be set when pre-rotation is active"))
+			}
+			for _, updateKey := range *params.UpdateKeys {
+				if !slices.Contains(nextKeyHashes, keyHash(updateKey)) {
+					return nil, fmt.Errorf(invalidLogEntryFormat, number, fmt.Errorf("update key is not committed to by nextKeyHashes: %s", updateKey))
+				}
+			}
+			authorizedKeys = *params.UpdateKeys
+		default:
+			authorizedKeys = updateKeys
+		}
+		if len(entry.Proof) == 0 {
+			return nil, fmt.Errorf(invalidLogEntryFormat, number, errors.New("missing proof"))
+		}
+		for _, proof := range entry.Proof {
+			if err = verifyProof(entry, proof, authorizedKeys); err != nil {
+				return nil, fmt.Errorf(invalidLogEntryFormat, number, err)
+			}
+		}
+		// check the DID of the state
+		var state struct {
+			ID string `json:"id"`
+		}
+		if err = json.Unmarshal(entry.State, &state); err != nil {
+			return nil, fmt.Errorf(invalidLogEntryFormat, number, fmt.Errorf("invalid state: %w", err))
+		}
+		if state.ID != id {
+			return nil, fmt.Errorf(invalidLogEntryFormat, number, fmt.Errorf("DID document ID mismatch: %s != %s", state.ID, id))
+		}
+		// apply the parameters
+		if params.UpdateKeys != nil {
+			updateKeys = *params.UpdateKeys
+		}
+		if params.NextKeyHashes != nil {
+			nextKeyHashes = *params.NextKeyHashes
+		}
+		if params.Deactivated != nil {
+			deactivated = *params.Deactivated
+		}
+		versions = append(versions, Version{
+			VersionID:   entry.VersionID,
+			VersionTime: versionTime,
+			State:       entry.State,
+			Deactivated: deactivated,
+		})
+		previous = &entries[i]
+		previousTime = versionTime
+	}
+	return versions, nil
+}
+
+// verifySCID checks that the SCID of the first log entry is derived from the entry itself.
+func verifySCID(entry LogEntry) error {
+	scid := entry.Parameters.SCID
+	if scid == "" {
+		return errors.New("scid must be set")
+	}
+	entry.VersionID = scidPlaceholder
+	entry.Proof = nil
+	data, err := canonicalizeValue(entry)
+	if err != nil {
+		return err
+	}
+	preliminary := bytes.ReplaceAll(data, []byte(scid), []byte(scidPlaceholder))
+	if hashString(preliminary) != scid {
+		return errors.New("SCID mismatch")
+	}
+	return nil
+}
@@ -0,0 +1,305 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package didwebvh
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jws"
+	nutsCrypto "github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_verifyLog(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		log := newTestLog(t, 3)
+
+		versions, err := verifyLog(log.id, log.entries, time.Now())
+
+		require.NoError(t, err)
+		require.Len(t, versions, 3)
+		assert.True(t, strings.HasPrefix(versions[0].VersionID, "1-"))
+		assert.True(t, strings.HasPrefix(versions[2].VersionID, "3-"))
+		assert.False(t, versions[2].Deactivated)
+	})
+	t.Run("ok - Ed25519 update keys", func(t *testing.T) {
+		log := newTestLogWithKeys(t, 2, func(signer *testSigner) string { return signer.newEd25519Key(t) })
+
+		_, err := verifyLog(log.id, log.entries, time.Now())
+
+		require.NoError(t, err)
+		assert.Equal(t, cryptosuiteEdDSAJCS, log.entries[0].Proof[0].Cryptosuite)
+	})
+	t.Run("ok - deactivated", func(t *testing.T) {
+		log := newTestLog(t, 2)
+		log.deactivate(t)
+
+		versions, err := verifyLog(log.id, log.entries, time.Now())
+
+		require.NoError(t, err)
+		assert.False(t, versions[1].Deactivated)
+		assert.True(t, versions[2].Deactivated)
+	})
+	t.Run("ok - survives marshalling", func(t *testing.T) {
+		log := newTestLog(t, 2)
+
+		entries, err := ParseLog(log.bytes(t))
+		require.NoError(t, err)
+		_, err = verifyLog(log.id, entries, time.Now())
+
+		assert.NoError(t, err)
+	})
+	t.Run("error - entry after deactivation", func(t *testing.T) {
+		log := newTestLog(t, 1)
+		log.deactivate(t)
+		log.entries = append(log.entries, log.entries[1])
+
+		_, err := verifyLog(log.id, log.entries, time.Now())
+
+		assert.EqualError(t, err, "invalid DID log entry 3: DID is deactivated in a previous entry")
+	})
+	t.Run("error - altered state", func(t *testing.T) {
+		log := newTestLog(t, 2)
+		log.entries[1].State = json.RawMessage(`{"id":"` + log.id + `","service":[]}`)
+
+		_, err := verifyLog(log.id, log.entries, time.Now())
+
+		assert.EqualError(t, err, "invalid DID log entry 2: entry hash mismatch")
+	})
+	t.Run("error - altered SCID", func(t *testing.T) {
+		log := newTestLog(t, 1)
+		scid := log.entries[0].Parameters.SCID
+		log.entries[0].Parameters.SCID = scid[:len(scid)-1] + "1"
+		if scid == log.entries[0].Parameters.SCID {
+			log.entries[0].Parameters.SCID = scid[:len(scid)-1] + "2"
+		}
+
+		_, err := verifyLog(log.id, log.entries, time.Now())
+
+		assert.EqualError(t, err, "invalid DID log entry 1: SCID mismatch")
+	})
+	t.Run("error - removed entry", func(t *testing.T) {
+		log := newTestLog(t, 3)
+		log.entries = append(log.entries[:1], log.entries[2:]...)
+
+		_, err := verifyLog(log.id, log.entries, time.Now())
+
+		assert.ErrorContains(t, err, "invalid DID log entry 2: unexpected versionId: 3-")
+	})
+	t.Run("error - versionTime in the future", func(t *testing.T) {
+		log := newTestLog(t, 1)
+
+		_, err := verifyLog(log.id, log.entries, time.Now().Add(-time.Hour))
+
+		assert.EqualError(t, err, "invalid DID log entry 1: versionTime is in the future")
+	})
+	t.Run("error - update key not committed to by pre-rotation", func(t *testing.T) {
+		log := newTestLog(t, 1)
+		// sign the next entry with a key that wasn't pre-rotated
+		otherKey := log.signer.newP256Key(t)
+		log.nextKey = otherKey
+		log.append(t, false)
+
+		_, err := verifyLog(log.id, log.entries, time.Now())
+
+		assert.EqualError(t, err, "invalid DID log entry 2: update key is not committed to by nextKeyHashes: "+otherKey)
+	})
+	t.Run("error - signed by unauthorized key", func(t *testing.T) {
+		log := newTestLog(t, 2)
+		otherKey := log.signer.newP256Key(t)
+		log.entries[1].Proof = nil
+		require.NoError(t, log.entries[1].sign(context.Background(), log.signer, otherKey, time.Now()))
+
+		_, err := verifyLog(log.id, log.entries, time.Now())
+
+		assert.EqualError(t, err, "invalid DID log entry 2: proof is not created by an authorized update key: "+multikeyToKID(otherKey))
+	})
+	t.Run("error - invalid signature", func(t *testing.T) {
+		log := newTestLog(t, 1)
+		log.entries[0].Proof[0].Created = time.Now().Add(time.Minute).UTC().Format(time.RFC3339)
+
+		_, err := verifyLog(log.id, log.entries, time.Now())
+
+		assert.ErrorContains(t, err, "invalid DID log entry 1: invalid proof signature")
+	})
+	t.Run("error - missing proof", func(t *testing.T) {
+		log := newTestLog(t, 1)
+		log.entries[0].Proof = nil
+
+		_, err := verifyLog(log.id, log.entries, time.Now())
+
+		assert.EqualError(t, err, "invalid DID log entry 1: missing proof")
+	})
+	t.Run("error - DID mismatch", func(t *testing.T) {
+		log := newTestLog(t, 1)
+
+		_, err := verifyLog("did:webvh:other:example.com", log.entries, time.Now())
+
+		assert.ErrorContains(t, err, "invalid DID log entry 1: DID document ID mismatch")
+	})
+	t.Run("error - witnesses", func(t *testing.T) {
+		log := newTestLog(t, 1)
+		log.entries[0].Parameters.Witness = json.RawMessage(`{"threshold":1}`)
+
+		_, err := verifyLog(log.id, log.entries, time.Now())
+
+		assert.EqualError(t, err, "invalid DID log entry 1: witnesses are not supported")
+	})
+}
+
+func TestParseLog(t *testing.T) {
+	t.Run("error - empty", func(t *testing.T) {
+		_, err := ParseLog([]byte("\n"))
+
+		assert.EqualError(t, err, "DID log is empty")
+	})
+	t.Run("error - invalid JSON", func(t *testing.T) {
+		_, err := ParseLog([]byte("{}\n{"))
+
+		assert.ErrorContains(t, err, "invalid DID log entry 2")
+	})
+}
+
+// testLog builds a DID log for tests, with update keys held by a testSigner.
+type testLog struct {
+	id         string
+	entries    []LogEntry
+	signer     *testSigner
+	newKey     func(signer *testSigner) string
+	updateKey  string
+	nextKey    string
+	versionOff time.Duration
+}
+
+// newTestLog creates a DID log with the given number of entries, using P-256 update keys.
+func newTestLog(t *testing.T, numEntries int) *testLog {
+	return newTestLogWithKeys(t, numEntries, func(signer *testSigner) string { return signer.newP256Key(t) })
+}
+
+func newTestLogWithKeys(t *testing.T, numEntries int, newKey func(signer *testSigner) string) *testLog {
+	signer := &testSigner{keys: map[string]crypto.Signer{}}
+	log := &testLog{signer: signer, newKey: newKey, versionOff: -time.Duration(numEntries+1) * time.Minute}
+	log.updateKey = newKey(signer)
+	log.nextKey = newKey(signer)
+	entry, err := newGenesisEntry(log.versionTime(), Parameters{
+		UpdateKeys:    &[]string{log.updateKey},
+		NextKeyHashes: &[]string{keyHash(log.nextKey)},
+	}, map[string]interface{}{"id": "did:webvh:{SCID}:example.com"})
+	require.NoError(t, err)
+	require.NoError(t, entry.sign(context.Background(), signer, log.updateKey, time.Now()))
+	log.id = "did:webvh:" + entry.Parameters.SCID + ":example.com"
+	log.entries = append(log.entries, *entry)
+	for i := 1; i < numEntries; i++ {
+		log.append(t, false)
+	}
+	return log
+}
+
+func (l *testLog) versionTime() time.Time {
+	l.versionOff += time.Minute
+	return time.Now().Add(l.versionOff)
+}
+
+// append adds a log entry, signed by the pre-rotated update key.
+func (l *testLog) append(t *testing.T, deactivate bool) {
+	updateKey := l.nextKey
+	parameters := Parameters{UpdateKeys: &[]string{updateKey}}
+	if deactivate {
+		deactivated := true
+		parameters.Deactivated = &deactivated
+		parameters.NextKeyHashes = &[]string{}
+	} else {
+		l.nextKey = l.newKey(l.signer)
+		parameters.NextKeyHashes = &[]string{keyHash(l.nextKey)}
+	}
+	state := map[string]interface{}{"id": l.id, "alsoKnownAs": []string{fmt.Sprintf("urn:version:%d", len(l.entries)+1)}}
+	entry, err := newEntry(l.entries[len(l.entries)-1], l.versionTime(), parameters, state)
+	require.NoError(t, err)
+	require.NoError(t, entry.sign(context.Background(), l.signer, updateKey, time.Now()))
+	l.entries = append(l.entries, *entry)
+	l.updateKey = updateKey
+}
+
+func (l *testLog) deactivate(t *testing.T) {
+	l.append(t, true)
+}
+
+func (l *testLog) bytes(t *testing.T) []byte {
+	var result []byte
+	for _, entry := range l.entries {
+		line, err := entry.MarshalLine()
+		require.NoError(t, err)
+		result = append(append(result, line...), '\n')
+	}
+	return result
+}
+
+var _ nutsCrypto.DataSigner = (*testSigner)(nil)
+
+// testSigner is an in-memory DataSigner for update keys, which are identified by their did:key DID URL.
+type testSigner struct {
+	keys map[string]crypto.Signer
+}
+
+func (s *testSigner) newP256Key(t *testing.T) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return s.add(t, key)
+}
+
+func (s *testSigner) newEd25519Key(t *testing.T) string {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return s.add(t, key)
+}
+
+func (s *testSigner) add(t *testing.T, key crypto.Signer) string {
+	multikey, err := encodeMultikey(key.Public())
+	require.NoError(t, err)
+	s.keys[multikeyToKID(multikey)] = key
+	return multikey
+}
+
+func (s *testSigner) SignData(_ context.Context, data []byte, kid string) ([]byte, error) {
+	key, ok := s.keys[kid]
+	if !ok {
+		return nil, nutsCrypto.ErrPrivateKeyNotFound
+	}
+	alg := jwa.ES256
+	if _, ok := key.(ed25519.PrivateKey); ok {
+		alg = jwa.EdDSA
+	}
+	signer, err := jws.NewSigner(alg)
+	if err != nil {
+		return nil, err
+	}
+	return signer.Sign(data, key)
+}
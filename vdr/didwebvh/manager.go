@@ -0,0 +1,316 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package didwebvh
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	ssi "github.com/nuts-foundation/go-did"
+	"github.com/nuts-foundation/go-did/did"
+	nutsCrypto "github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/nuts-foundation/nuts-node/storage"
+	"github.com/nuts-foundation/nuts-node/storage/orm"
+	"github.com/nuts-foundation/nuts-node/vdr/didsubject"
+	"github.com/nuts-foundation/nuts-node/vdr/log"
+	"github.com/nuts-foundation/nuts-node/vdr/resolver"
+	"gorm.io/gorm"
+)
+
+var _ didsubject.MethodManager = (*Manager)(nil)
+var _ LogStore = (*Manager)(nil)
+
+// NewManager creates a new Manager to create and update did:webvh DID documents.
+// The domain and path of the DIDs are derived from the given (did:web) root DID.
+func NewManager(rootDID did.DID, tenantPath string, keyStore nutsCrypto.KeyStore, db *gorm.DB) *Manager {
+	return &Manager{
+		db:         db,
+		rootDID:    rootDID,
+		tenantPath: tenantPath,
+		keyStore:   keyStore,
+	}
+}
+
+// Manager creates and updates did:webvh documents.
+// Every change to a DID document is appended to the DID log as new entry, signed by a pre-rotated update key:
+// each entry commits to the hash of the next update key (nextKeyHashes), which signs the next entry.
+// After signing, the previous update key is deleted.
+type Manager struct {
+	db         *gorm.DB
+	rootDID    did.DID
+	keyStore   nutsCrypto.KeyStore
+	tenantPath string
+}
+
+// NewDocument creates the DID log for a new DID, consisting of the first log entry which determines the DID (its SCID).
+// Its state only contains the DID; the verification methods are added to the DID log when the change is committed.
+func (m Manager) NewDocument(ctx context.Context, keyFlags orm.DIDKeyFlags) (*orm.DidDocument, error) {
+	updateKeyID, updateKey, err := m.newUpdateKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	nextUpdateKeyID, nextUpdateKey, err := m.newUpdateKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	idTemplate := fmt.Sprintf("did:%s:%s:%s:%s:%s", MethodName, scidPlaceholder, m.rootDID.ID, m.tenantPath, uuid.New())
+	now := time.Now()
+	entry, err := newGenesisEntry(now, Parameters{
+		UpdateKeys:    &[]string{updateKey},
+		NextKeyHashes: &[]string{keyHash(nextUpdateKey)},
+	}, map[string]interface{}{
+		"@context": []string{did.DIDContextV1},
+		"id":       idTemplate,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err = entry.sign(ctx, m.keyStore, updateKey, now); err != nil {
+		return nil, err
+	}
+	newDID, err := did.ParseDID(strings.ReplaceAll(idTemplate, scidPlaceholder, entry.Parameters.SCID))
+	if err != nil {
+		return nil, err
+	}
+	if err = m.storeEntry(m.transaction(ctx), *newDID, *entry, "", nextUpdateKeyID); err != nil {
+		return nil, err
+	}
+	log.Logger().Debugf("Created did:webvh log (did=%s, update key=%s)", newDID, updateKeyID)
+
+	var sqlVerificationMethods []orm.VerificationMethod
+	keyTypes := []orm.DIDKeyFlags{orm.AssertionKeyUsage(), orm.EncryptionKeyUsage()}
+	for _, keyType := range keyTypes {
+		if keyType.Is(keyFlags) {
+			verificationMethod, err := m.NewVerificationMethod(ctx, *newDID, keyType)
+			if err != nil {
+				return nil, err
+			}
+			asJson, _ := json.Marshal(verificationMethod)
+			sqlVerificationMethods = append(sqlVerificationMethods, orm.VerificationMethod{
+				ID:       verificationMethod.ID.String(),
+				KeyTypes: orm.VerificationMethodKeyType(keyType),
+				Data:     asJson,
+			})
+		}
+	}
+
+	// Create sql.DidDocument
+	sqlDoc := orm.DidDocument{
+		DID: orm.DID{
+			ID: newDID.String(),
+		},
+		CreatedAt:           now.Unix(),
+		UpdatedAt:           now.Unix(),
+		Version:             0,
+		VerificationMethods: sqlVerificationMethods,
+	}
+	return &sqlDoc, nil
+}
+
+func (m Manager) NewVerificationMethod(ctx context.Context, controller did.DID, keyUsage orm.DIDKeyFlags) (*did.VerificationMethod, error) {
+	if keyUsage.Is(orm.KeyAgreementUsage) {
+		return nil, errors.New("key agreement not supported for did:webvh")
+	}
+	verificationMethodID := did.DIDURL{
+		DID:      controller,
+		Fragment: uuid.New().String(),
+	}
+	_, publicKey, err := m.keyStore.New(ctx, func(key crypto.PublicKey) (string, error) {
+		return verificationMethodID.String(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return did.NewVerificationMethod(verificationMethodID, ssi.JsonWebKey2020, controller, publicKey)
+}
+
+// Commit appends a log entry containing the new version of the DID document to the DID log.
+// The entry is signed by the pre-rotated update key of the previous entry, and commits to a newly generated next update key.
+// When the DID document is deactivated, pre-rotation is switched off and no new update key is generated.
+func (m Manager) Commit(ctx context.Context, change orm.DIDChangeLog) error {
+	id := change.DID()
+	var latest orm.WebVHLogEntry
+	if err := m.db.Where("did = ?", id.String()).Order("version_number desc").First(&latest).Error; err != nil {
+		return fmt.Errorf("unable to load did:webvh log (did=%s): %w", id, err)
+	}
+	if latest.DIDDocumentVersionID == change.DIDDocumentVersionID {
+		// already committed
+		return nil
+	}
+	var previous LogEntry
+	if err := json.Unmarshal([]byte(latest.Entry), &previous); err != nil {
+		return err
+	}
+	document, err := change.DIDDocumentVersion.ToDIDDocument()
+	if err != nil {
+		return err
+	}
+	updateKey, err := kidToMultikey(latest.NextUpdateKey)
+	if err != nil {
+		return err
+	}
+	deactivate := change.Type == orm.DIDChangeDeactivated
+	parameters := Parameters{
+		UpdateKeys: &[]string{updateKey},
+	}
+	var nextUpdateKeyID string
+	if deactivate {
+		deactivated := true
+		parameters.Deactivated = &deactivated
+		parameters.NextKeyHashes = &[]string{}
+	} else {
+		var nextUpdateKey string
+		nextUpdateKeyID, nextUpdateKey, err = m.newUpdateKey(ctx)
+		if err != nil {
+			return err
+		}
+		parameters.NextKeyHashes = &[]string{keyHash(nextUpdateKey)}
+	}
+	// versionTime must be after the previous entry's versionTime
+	now := time.Now()
+	if previousTime, err := time.Parse(time.RFC3339Nano, previous.VersionTime); err == nil && !now.After(previousTime) {
+		now = previousTime.Add(time.Millisecond)
+	}
+	entry, err := newEntry(previous, now, parameters, document)
+	if err != nil {
+		return err
+	}
+	if err = entry.sign(ctx, m.keyStore, updateKey, now); err != nil {
+		return err
+	}
+	if err = m.storeEntry(m.db, id, *entry, change.DIDDocumentVersionID, nextUpdateKeyID); err != nil {
+		return err
+	}
+	// the previous update key is no longer authorized, since pre-rotation requires new entries to be signed by the next update key
+	staleKeys := []string{}
+	if previous.Parameters.UpdateKeys != nil {
+		for _, previousUpdateKey := range *previous.Parameters.UpdateKeys {
+			staleKeys = append(staleKeys, multikeyToKID(previousUpdateKey))
+		}
+	}
+	if deactivate {
+		staleKeys = append(staleKeys, latest.NextUpdateKey)
+	}
+	for _, kid := range staleKeys {
+		if err := m.keyStore.Delete(ctx, kid); err != nil && !errors.Is(err, nutsCrypto.ErrPrivateKeyNotFound) {
+			log.Logger().WithError(err).Warnf("Unable to delete stale did:webvh update key (did=%s, kid=%s)", id, kid)
+		}
+	}
+	return nil
+}
+
+// IsCommitted checks whether the DID log contains an entry for the DID document version of the change.
+func (m Manager) IsCommitted(_ context.Context, change orm.DIDChangeLog) (bool, error) {
+	var count int64
+	err := m.db.Model(&orm.WebVHLogEntry{}).Where("did_document_version_id = ?", change.DIDDocumentVersionID).Count(&count).Error
+	return count > 0, err
+}
+
+// Log returns the DID log (did.jsonl) of the given DID. It returns resolver.ErrNotFound if the DID isn't managed by this node.
+func (m Manager) Log(ctx context.Context, id did.DID) ([]byte, error) {
+	var entries []orm.WebVHLogEntry
+	if err := m.db.WithContext(ctx).Where("did = ?", id.String()).Order("version_number asc").Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, resolver.ErrNotFound
+	}
+	var result strings.Builder
+	for _, entry := range entries {
+		result.WriteString(entry.Entry)
+		result.WriteString("\n")
+	}
+	return []byte(result.String()), nil
+}
+
+// TenantLog returns the DID log of the did:webvh DID with the given tenant ID, which is the last path segment of the DID
+// (e.g. did:webvh:{SCID}:example.com:iam:{tenant}). It returns resolver.ErrNotFound if there's no such DID.
+func (m Manager) TenantLog(ctx context.Context, tenant string) ([]byte, error) {
+	if tenant == "" || strings.ContainsAny(tenant, ":/") {
+		return nil, resolver.ErrNotFound
+	}
+	pattern := fmt.Sprintf("did:%s:%%:%s", MethodName, escapeLike(fmt.Sprintf("%s:%s:%s", m.rootDID.ID, m.tenantPath, tenant)))
+	var first orm.WebVHLogEntry
+	err := m.db.WithContext(ctx).Where("version_number = 1 AND did LIKE ? ESCAPE '!'", pattern).First(&first).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, resolver.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	id, err := did.ParseDID(first.DID)
+	if err != nil {
+		return nil, err
+	}
+	return m.Log(ctx, *id)
+}
+
+// newUpdateKey generates a new update key, which is identified by its did:key DID URL.
+// It returns the key ID and the key in Multikey format.
+func (m Manager) newUpdateKey(ctx context.Context) (string, string, error) {
+	var multikey string
+	keyRef, _, err := m.keyStore.New(ctx, func(key crypto.PublicKey) (string, error) {
+		var err error
+		multikey, err = encodeMultikey(key)
+		if err != nil {
+			return "", err
+		}
+		return multikeyToKID(multikey), nil
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("unable to create did:webvh update key: %w", err)
+	}
+	return keyRef.KID, multikey, nil
+}
+
+func (m Manager) storeEntry(tx *gorm.DB, id did.DID, entry LogEntry, documentVersionID string, nextUpdateKeyID string) error {
+	number, err := entry.versionNumber()
+	if err != nil {
+		return err
+	}
+	line, err := entry.MarshalLine()
+	if err != nil {
+		return err
+	}
+	return tx.Create(&orm.WebVHLogEntry{
+		DID:                  id.String(),
+		VersionNumber:        number,
+		DIDDocumentVersionID: documentVersionID,
+		Entry:                string(line),
+		NextUpdateKey:        nextUpdateKeyID,
+	}).Error
+}
+
+// transaction returns the DB transaction from the context, or the DB if there's no transaction.
+func (m Manager) transaction(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value(storage.TransactionKey{}).(*gorm.DB); ok {
+		return tx
+	}
+	return m.db
+}
+
+// escapeLike escapes the LIKE wildcards in the given string, using '!' as escape character.
+func escapeLike(s string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(s)
+}
@@ -0,0 +1,204 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package didwebvh
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/audit"
+	nutsCrypto "github.com/nuts-foundation/nuts-node/crypto"
+	"github.com/nuts-foundation/nuts-node/storage"
+	"github.com/nuts-foundation/nuts-node/storage/orm"
+	"github.com/nuts-foundation/nuts-node/vdr/resolver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestManager_NewDocument(t *testing.T) {
+	ctx := audit.TestContext()
+	manager, keyStore := newTestManager(t)
+
+	doc, err := manager.NewDocument(ctx, orm.AssertionKeyUsage())
+
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(doc.DID.ID, "did:webvh:Qm"))
+	assert.True(t, strings.HasSuffix(doc.DID.ID[:len(doc.DID.ID)-36], ":example.com:iam:"))
+	require.Len(t, doc.VerificationMethods, 1)
+	assert.True(t, strings.HasPrefix(doc.VerificationMethods[0].ID, doc.DID.ID+"#"))
+	t.Run("DID log contains first entry", func(t *testing.T) {
+		didLog, err := manager.Log(ctx, did.MustParseDID(doc.DID.ID))
+
+		require.NoError(t, err)
+		entries, err := ParseLog(didLog)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.True(t, strings.HasPrefix(entries[0].VersionID, "1-"))
+		// update key and next update key are in the key store
+		for _, kid := range []string{multikeyToKID((*entries[0].Parameters.UpdateKeys)[0]), nextUpdateKey(t, manager, doc.DID.ID)} {
+			exists, err := keyStore.Exists(ctx, kid)
+			require.NoError(t, err)
+			assert.True(t, exists)
+		}
+	})
+	t.Run("key agreement is not supported", func(t *testing.T) {
+		_, err := manager.NewDocument(ctx, orm.EncryptionKeyUsage())
+
+		assert.EqualError(t, err, "key agreement not supported for did:webvh")
+	})
+}
+
+func TestManager_Commit(t *testing.T) {
+	ctx := audit.TestContext()
+	manager, keyStore := newTestManager(t)
+	doc, err := manager.NewDocument(ctx, orm.AssertionKeyUsage())
+	require.NoError(t, err)
+	id := did.MustParseDID(doc.DID.ID)
+	webvhResolver := Resolver{Logs: manager}
+
+	doc.ID = "version-1"
+	created := orm.DIDChangeLog{DIDDocumentVersionID: doc.ID, Type: orm.DIDChangeCreated, DIDDocumentVersion: *doc}
+	t.Run("created", func(t *testing.T) {
+		committed, err := manager.IsCommitted(ctx, created)
+		require.NoError(t, err)
+		require.False(t, committed)
+		firstUpdateKey := firstUpdateKeyID(t, manager, id)
+
+		err = manager.Commit(ctx, created)
+
+		require.NoError(t, err)
+		committed, err = manager.IsCommitted(ctx, created)
+		require.NoError(t, err)
+		assert.True(t, committed)
+		document, metadata, err := webvhResolver.Resolve(id, nil)
+		require.NoError(t, err)
+		assert.Len(t, document.AssertionMethod, 1)
+		assert.True(t, strings.HasPrefix(metadata.VersionID, "2-"))
+		// the first update key is no longer authorized, so it's deleted
+		exists, _ := keyStore.Exists(ctx, firstUpdateKey)
+		assert.False(t, exists)
+	})
+	t.Run("committing twice is a no-op", func(t *testing.T) {
+		err := manager.Commit(ctx, created)
+
+		require.NoError(t, err)
+		didLog, _ := manager.Log(ctx, id)
+		assert.Equal(t, 2, strings.Count(string(didLog), "\n"))
+	})
+	t.Run("updated", func(t *testing.T) {
+		updatedDoc := *doc
+		updatedDoc.ID = "version-2"
+		updatedDoc.VerificationMethods = nil
+		updated := orm.DIDChangeLog{DIDDocumentVersionID: updatedDoc.ID, Type: orm.DIDChangeUpdated, DIDDocumentVersion: updatedDoc}
+
+		err := manager.Commit(ctx, updated)
+
+		require.NoError(t, err)
+		document, metadata, err := webvhResolver.Resolve(id, nil)
+		require.NoError(t, err)
+		assert.Empty(t, document.AssertionMethod)
+		assert.True(t, strings.HasPrefix(metadata.VersionID, "3-"))
+		t.Run("previous version can still be resolved", func(t *testing.T) {
+			entries, _ := ParseLog(mustLog(t, manager, id))
+			document, _, err := webvhResolver.Resolve(id, &resolver.ResolveMetadata{VersionID: entries[1].VersionID})
+
+			require.NoError(t, err)
+			assert.Len(t, document.AssertionMethod, 1)
+		})
+	})
+	t.Run("deactivated", func(t *testing.T) {
+		deactivatedDoc := *doc
+		deactivatedDoc.ID = "version-3"
+		deactivatedDoc.VerificationMethods = nil
+		deactivated := orm.DIDChangeLog{DIDDocumentVersionID: deactivatedDoc.ID, Type: orm.DIDChangeDeactivated, DIDDocumentVersion: deactivatedDoc}
+		updateKey := nextUpdateKey(t, manager, id.String())
+
+		err := manager.Commit(ctx, deactivated)
+
+		require.NoError(t, err)
+		_, _, err = webvhResolver.Resolve(id, nil)
+		assert.ErrorIs(t, err, resolver.ErrDeactivated)
+		// no update keys are left
+		exists, _ := keyStore.Exists(ctx, updateKey)
+		assert.False(t, exists)
+		assert.Empty(t, nextUpdateKey(t, manager, id.String()))
+	})
+}
+
+func TestManager_TenantLog(t *testing.T) {
+	ctx := audit.TestContext()
+	manager, _ := newTestManager(t)
+	doc, err := manager.NewDocument(ctx, orm.AssertionKeyUsage())
+	require.NoError(t, err)
+	tenant := doc.DID.ID[strings.LastIndex(doc.DID.ID, ":")+1:]
+
+	t.Run("ok", func(t *testing.T) {
+		didLog, err := manager.TenantLog(ctx, tenant)
+
+		require.NoError(t, err)
+		assert.Equal(t, mustLog(t, manager, did.MustParseDID(doc.DID.ID)), didLog)
+	})
+	t.Run("unknown tenant", func(t *testing.T) {
+		_, err := manager.TenantLog(ctx, "unknown")
+
+		assert.ErrorIs(t, err, resolver.ErrNotFound)
+	})
+	t.Run("wildcards are escaped", func(t *testing.T) {
+		_, err := manager.TenantLog(ctx, "%")
+
+		assert.ErrorIs(t, err, resolver.ErrNotFound)
+	})
+	t.Run("invalid tenant", func(t *testing.T) {
+		_, err := manager.TenantLog(ctx, "a:b")
+
+		assert.ErrorIs(t, err, resolver.ErrNotFound)
+	})
+}
+
+func newTestManager(t *testing.T) (*Manager, nutsCrypto.KeyStore) {
+	storageEngine := storage.NewTestStorageEngine(t)
+	require.NoError(t, storageEngine.Start())
+	db := storageEngine.GetSQLDatabase()
+	keyStore := nutsCrypto.NewDatabaseCryptoInstance(db)
+	return NewManager(did.MustParseDID("did:web:example.com"), "iam", keyStore, db), keyStore
+}
+
+func mustLog(t *testing.T, manager *Manager, id did.DID) []byte {
+	didLog, err := manager.Log(audit.TestContext(), id)
+	require.NoError(t, err)
+	return didLog
+}
+
+func latestEntry(t *testing.T, db *gorm.DB, id string) orm.WebVHLogEntry {
+	var entry orm.WebVHLogEntry
+	require.NoError(t, db.Where("did = ?", id).Order("version_number desc").First(&entry).Error)
+	return entry
+}
+
+func nextUpdateKey(t *testing.T, manager *Manager, id string) string {
+	return latestEntry(t, manager.db, id).NextUpdateKey
+}
+
+func firstUpdateKeyID(t *testing.T, manager *Manager, id did.DID) string {
+	entries, err := ParseLog(mustLog(t, manager, id))
+	require.NoError(t, err)
+	return multikeyToKID((*entries[0].Parameters.UpdateKeys)[0])
+}
@@ -0,0 +1,119 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package didwebvh
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mr-tron/base58"
+	"github.com/multiformats/go-multicodec"
+)
+
+// multihashSHA256 is the multihash prefix for SHA2-256 digests: the code (0x12) followed by the digest length (32 bytes).
+var multihashSHA256 = []byte{0x12, 0x20}
+
+// hashString returns the base58btc encoded SHA2-256 multihash of the given data, as used for the SCID and entry hashes.
+func hashString(data []byte) string {
+	digest := sha256.Sum256(data)
+	return base58.Encode(append(append([]byte{}, multihashSHA256...), digest[:]...))
+}
+
+// keyHash returns the hash of a multikey as listed in the nextKeyHashes parameter.
+func keyHash(multikey string) string {
+	return hashString([]byte(multikey))
+}
+
+// encodeMultikey encodes a public key in the Multikey format (multicodec prefixed, base58btc multibase encoded).
+// Only P-256 and Ed25519 keys are supported.
+func encodeMultikey(publicKey crypto.PublicKey) (string, error) {
+	var code multicodec.Code
+	var keyBytes []byte
+	switch key := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		if key.Curve != elliptic.P256() {
+			return "", errors.New("unsupported EC curve (only P-256 is supported)")
+		}
+		code = multicodec.P256Pub
+		keyBytes = elliptic.MarshalCompressed(key.Curve, key.X, key.Y)
+	case ed25519.PublicKey:
+		code = multicodec.Ed25519Pub
+		keyBytes = key
+	default:
+		return "", fmt.Errorf("unsupported key type: %T", publicKey)
+	}
+	data := binary.AppendUvarint(nil, uint64(code))
+	return "z" + base58.Encode(append(data, keyBytes...)), nil
+}
+
+// decodeMultikey decodes a public key from the Multikey format. Only P-256 and Ed25519 keys are supported.
+func decodeMultikey(multikey string) (crypto.PublicKey, error) {
+	if !strings.HasPrefix(multikey, "z") {
+		return nil, errors.New("multikey must be base58btc encoded")
+	}
+	data, err := base58.Decode(multikey[1:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid multikey: %w", err)
+	}
+	reader := bytes.NewReader(data)
+	code, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, fmt.Errorf("invalid multikey: %w", err)
+	}
+	keyBytes, _ := io.ReadAll(reader)
+	switch multicodec.Code(code) {
+	case multicodec.P256Pub:
+		x, y := elliptic.UnmarshalCompressed(elliptic.P256(), keyBytes)
+		if x == nil {
+			return nil, errors.New("invalid multikey: invalid P-256 public key")
+		}
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: x, Y: y}, nil
+	case multicodec.Ed25519Pub:
+		if len(keyBytes) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid multikey: invalid Ed25519 public key")
+		}
+		return ed25519.PublicKey(keyBytes), nil
+	default:
+		return nil, fmt.Errorf("invalid multikey: unsupported key type: 0x%x", code)
+	}
+}
+
+// multikeyToKID returns the key ID of an update key: the did:key DID URL the proofs refer to.
+func multikeyToKID(multikey string) string {
+	return "did:key:" + multikey + "#" + multikey
+}
+
+// kidToMultikey returns the multikey of an update key from its did:key DID URL.
+func kidToMultikey(kid string) (string, error) {
+	didKey, fragment, ok := strings.Cut(kid, "#")
+	multikey, isDIDKey := strings.CutPrefix(didKey, "did:key:")
+	if !ok || !isDIDKey || multikey != fragment {
+		return "", fmt.Errorf("invalid update key reference: %s", kid)
+	}
+	return multikey, nil
+}
@@ -0,0 +1,206 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package didwebvh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/crypto/hash"
+	"github.com/nuts-foundation/nuts-node/http/client"
+	"github.com/nuts-foundation/nuts-node/vdr/didweb"
+	"github.com/nuts-foundation/nuts-node/vdr/resolver"
+)
+
+// MethodName is the DID method name used by did:webvh
+const MethodName = "webvh"
+
+// maxLogSize is the maximum size of a DID log that is fetched over HTTP.
+const maxLogSize = 10 * 1024 * 1024
+
+var _ resolver.DIDResolver = (*Resolver)(nil)
+
+// LogStore provides the DID logs of did:webvh DIDs that are managed by the local node.
+type LogStore interface {
+	// Log returns the DID log of the given DID. It returns resolver.ErrNotFound if the DID isn't managed by the local node.
+	Log(ctx context.Context, id did.DID) ([]byte, error)
+}
+
+// Resolver is a DID resolver for the did:webvh method.
+// It fetches the DID log, verifies it and returns the requested version of the DID document.
+type Resolver struct {
+	HttpClient core.HTTPRequestDoer
+	// Logs is consulted before fetching the DID log over HTTP, so DIDs managed by the local node are resolved without a network round trip.
+	// It's optional.
+	Logs LogStore
+}
+
+// NewResolver creates a new did:webvh Resolver with default TLS configuration.
+func NewResolver(logs LogStore) *Resolver {
+	return &Resolver{
+		HttpClient: client.NewWithCache(5 * time.Second),
+		Logs:       logs,
+	}
+}
+
+// Resolve implements the DIDResolver interface.
+// The version that is returned can be selected using metadata.VersionID or metadata.ResolveTime, it defaults to the latest version.
+func (r Resolver) Resolve(id did.DID, metadata *resolver.ResolveMetadata) (*did.Document, *resolver.DocumentMetadata, error) {
+	if id.Method != MethodName {
+		return nil, nil, errors.New("DID is not did:webvh")
+	}
+	scid, _, ok := strings.Cut(id.ID, ":")
+	if !ok || scid == "" {
+		return nil, nil, errors.New("invalid did:webvh: missing SCID or domain")
+	}
+	logData, err := r.fetchLog(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries, err := ParseLog(logData)
+	if err != nil {
+		return nil, nil, fmt.Errorf("did:webvh invalid DID log: %w", err)
+	}
+	versions, err := verifyLog(id.String(), entries, time.Now())
+	if err != nil {
+		return nil, nil, fmt.Errorf("did:webvh invalid DID log: %w", err)
+	}
+	if entries[0].Parameters.SCID != scid {
+		return nil, nil, errors.New("did:webvh invalid DID log: SCID does not match DID")
+	}
+	version, err := selectVersion(versions, metadata)
+	if err != nil {
+		return nil, nil, err
+	}
+	if version.Deactivated && (metadata == nil || !metadata.AllowDeactivated) {
+		return nil, nil, resolver.ErrDeactivated
+	}
+	var document did.Document
+	if err = document.UnmarshalJSON(version.State); err != nil {
+		return nil, nil, fmt.Errorf("did:webvh invalid DID document: %w", err)
+	}
+	documentMetadata := resolver.DocumentMetadata{
+		Created:     versions[0].VersionTime,
+		Hash:        hash.SHA256Sum(version.State),
+		Deactivated: version.Deactivated,
+		VersionID:   version.VersionID,
+	}
+	if version.VersionID != versions[0].VersionID {
+		updated := version.VersionTime
+		documentMetadata.Updated = &updated
+	}
+	return &document, &documentMetadata, nil
+}
+
+// selectVersion returns the version that matches the resolve metadata, or the latest version if no version is requested.
+func selectVersion(versions []Version, metadata *resolver.ResolveMetadata) (*Version, error) {
+	if metadata != nil && metadata.VersionID != "" {
+		for i := range versions {
+			if versions[i].VersionID == metadata.VersionID {
+				return &versions[i], nil
+			}
+		}
+		return nil, resolver.ErrNotFound
+	}
+	if metadata != nil && metadata.ResolveTime != nil {
+		var result *Version
+		for i := range versions {
+			if versions[i].VersionTime.After(*metadata.ResolveTime) {
+				break
+			}
+			result = &versions[i]
+		}
+		if result == nil {
+			return nil, resolver.ErrNotFound
+		}
+		return result, nil
+	}
+	return &versions[len(versions)-1], nil
+}
+
+// fetchLog returns the DID log from the local log store, or fetches it over HTTP if it's not managed by the local node.
+func (r Resolver) fetchLog(id did.DID) ([]byte, error) {
+	if r.Logs != nil {
+		data, err := r.Logs.Log(context.Background(), id)
+		if err == nil {
+			return data, nil
+		}
+		if !errors.Is(err, resolver.ErrNotFound) {
+			return nil, err
+		}
+	}
+	logURL, err := DIDToURL(id)
+	if err != nil {
+		return nil, err
+	}
+	request, err := http.NewRequest(http.MethodGet, logURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	httpResponse, err := r.HttpClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("did:webvh HTTP error: %w", err)
+	}
+	defer httpResponse.Body.Close()
+	if httpResponse.StatusCode == http.StatusNotFound {
+		return nil, resolver.ErrNotFound
+	}
+	if !(httpResponse.StatusCode >= 200 && httpResponse.StatusCode < 300) {
+		return nil, fmt.Errorf("did:webvh non-ok HTTP status: %s", httpResponse.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(httpResponse.Body, maxLogSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("did:webvh HTTP response read error: %w", err)
+	}
+	if len(data) > maxLogSize {
+		return nil, errors.New("did:webvh DID log exceeds maximum size")
+	}
+	return data, nil
+}
+
+// DIDToURL returns the URL of the DID log (did.jsonl) of a did:webvh DID.
+// The domain and path are transformed like did:web, e.g.:
+// - did:webvh:{SCID}:example.com -> https://example.com/.well-known/did.jsonl
+// - did:webvh:{SCID}:example.com%3A3000:iam:1234 -> https://example.com:3000/iam/1234/did.jsonl
+func DIDToURL(id did.DID) (*url.URL, error) {
+	if id.Method != MethodName {
+		return nil, fmt.Errorf("unsupported DID method: %s", id.Method)
+	}
+	_, webID, ok := strings.Cut(id.ID, ":")
+	if !ok {
+		return nil, errors.New("invalid did:webvh: missing domain")
+	}
+	result, err := didweb.DIDToURL(did.DID{Method: didweb.MethodName, ID: webID})
+	if err != nil {
+		return nil, fmt.Errorf("invalid did:webvh: %w", err)
+	}
+	if len(result.Path) == 0 {
+		result.Path = "/.well-known"
+	}
+	result.Path = result.Path + "/did.jsonl"
+	return result, nil
+}
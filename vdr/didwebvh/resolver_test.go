@@ -0,0 +1,206 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package didwebvh
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/vdr/resolver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolver_Resolve(t *testing.T) {
+	testLog := newTestLog(t, 3)
+	id := did.MustParseDID(testLog.id)
+	newResolver := func(statusCode int, body []byte) (*Resolver, *[]string) {
+		var requestedURLs []string
+		return &Resolver{HttpClient: stubHTTPClient(func(request *http.Request) (*http.Response, error) {
+			requestedURLs = append(requestedURLs, request.URL.String())
+			return &http.Response{
+				StatusCode: statusCode,
+				Status:     http.StatusText(statusCode),
+				Body:       io.NopCloser(bytes.NewReader(body)),
+			}, nil
+		})}, &requestedURLs
+	}
+
+	t.Run("ok - latest version", func(t *testing.T) {
+		r, requestedURLs := newResolver(http.StatusOK, testLog.bytes(t))
+
+		document, metadata, err := r.Resolve(id, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, id, document.ID)
+		assert.Equal(t, "urn:version:3", document.AlsoKnownAs[0].String())
+		assert.Equal(t, testLog.entries[2].VersionID, metadata.VersionID)
+		assert.NotNil(t, metadata.Updated)
+		assert.False(t, metadata.Deactivated)
+		assert.Equal(t, []string{"https://example.com/.well-known/did.jsonl"}, *requestedURLs)
+	})
+	t.Run("ok - versionId", func(t *testing.T) {
+		r, _ := newResolver(http.StatusOK, testLog.bytes(t))
+
+		document, metadata, err := r.Resolve(id, &resolver.ResolveMetadata{VersionID: testLog.entries[1].VersionID})
+
+		require.NoError(t, err)
+		assert.Equal(t, "urn:version:2", document.AlsoKnownAs[0].String())
+		assert.Equal(t, testLog.entries[1].VersionID, metadata.VersionID)
+	})
+	t.Run("ok - versionTime", func(t *testing.T) {
+		r, _ := newResolver(http.StatusOK, testLog.bytes(t))
+		firstVersionTime, _ := time.Parse(time.RFC3339Nano, testLog.entries[0].VersionTime)
+		resolveTime := firstVersionTime.Add(30 * time.Second)
+
+		document, metadata, err := r.Resolve(id, &resolver.ResolveMetadata{ResolveTime: &resolveTime})
+
+		require.NoError(t, err)
+		assert.Empty(t, document.AlsoKnownAs)
+		assert.Equal(t, testLog.entries[0].VersionID, metadata.VersionID)
+		assert.Nil(t, metadata.Updated)
+	})
+	t.Run("ok - DID log from log store", func(t *testing.T) {
+		r, requestedURLs := newResolver(http.StatusInternalServerError, nil)
+		r.Logs = stubLogStore{id.String(): testLog.bytes(t)}
+
+		document, _, err := r.Resolve(id, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, id, document.ID)
+		assert.Empty(t, *requestedURLs)
+	})
+	t.Run("deactivated", func(t *testing.T) {
+		deactivatedLog := newTestLog(t, 1)
+		deactivatedLog.deactivate(t)
+		deactivatedID := did.MustParseDID(deactivatedLog.id)
+		r, _ := newResolver(http.StatusOK, deactivatedLog.bytes(t))
+
+		t.Run("not allowed", func(t *testing.T) {
+			_, _, err := r.Resolve(deactivatedID, nil)
+
+			assert.ErrorIs(t, err, resolver.ErrDeactivated)
+		})
+		t.Run("allowed", func(t *testing.T) {
+			_, metadata, err := r.Resolve(deactivatedID, &resolver.ResolveMetadata{AllowDeactivated: true})
+
+			require.NoError(t, err)
+			assert.True(t, metadata.Deactivated)
+		})
+		t.Run("previous version", func(t *testing.T) {
+			_, metadata, err := r.Resolve(deactivatedID, &resolver.ResolveMetadata{VersionID: deactivatedLog.entries[0].VersionID})
+
+			require.NoError(t, err)
+			assert.False(t, metadata.Deactivated)
+		})
+	})
+	t.Run("unknown versionId", func(t *testing.T) {
+		r, _ := newResolver(http.StatusOK, testLog.bytes(t))
+
+		_, _, err := r.Resolve(id, &resolver.ResolveMetadata{VersionID: "4-abc"})
+
+		assert.ErrorIs(t, err, resolver.ErrNotFound)
+	})
+	t.Run("versionTime before first version", func(t *testing.T) {
+		r, _ := newResolver(http.StatusOK, testLog.bytes(t))
+		resolveTime := time.Now().Add(-time.Hour)
+
+		_, _, err := r.Resolve(id, &resolver.ResolveMetadata{ResolveTime: &resolveTime})
+
+		assert.ErrorIs(t, err, resolver.ErrNotFound)
+	})
+	t.Run("not found", func(t *testing.T) {
+		r, _ := newResolver(http.StatusNotFound, nil)
+
+		_, _, err := r.Resolve(id, nil)
+
+		assert.ErrorIs(t, err, resolver.ErrNotFound)
+	})
+	t.Run("server error", func(t *testing.T) {
+		r, _ := newResolver(http.StatusInternalServerError, nil)
+
+		_, _, err := r.Resolve(id, nil)
+
+		assert.EqualError(t, err, "did:webvh non-ok HTTP status: Internal Server Error")
+	})
+	t.Run("SCID doesn't match DID", func(t *testing.T) {
+		r, _ := newResolver(http.StatusOK, testLog.bytes(t))
+		otherID := did.MustParseDID("did:webvh:QmOther:example.com")
+
+		_, _, err := r.Resolve(otherID, nil)
+
+		assert.ErrorContains(t, err, "did:webvh invalid DID log")
+	})
+	t.Run("invalid DID log", func(t *testing.T) {
+		r, _ := newResolver(http.StatusOK, []byte("{}"))
+
+		_, _, err := r.Resolve(id, nil)
+
+		assert.ErrorContains(t, err, "did:webvh invalid DID log: invalid DID log entry 1")
+	})
+	t.Run("not did:webvh", func(t *testing.T) {
+		_, _, err := Resolver{}.Resolve(did.MustParseDID("did:web:example.com"), nil)
+
+		assert.EqualError(t, err, "DID is not did:webvh")
+	})
+}
+
+func TestDIDToURL(t *testing.T) {
+	testCases := []struct {
+		did      string
+		expected string
+	}{
+		{"did:webvh:QmSCID:example.com", "https://example.com/.well-known/did.jsonl"},
+		{"did:webvh:QmSCID:example.com:iam:123", "https://example.com/iam/123/did.jsonl"},
+		{"did:webvh:QmSCID:example.com%3A3000:iam:123", "https://example.com:3000/iam/123/did.jsonl"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.did, func(t *testing.T) {
+			actual, err := DIDToURL(did.MustParseDID(testCase.did))
+
+			require.NoError(t, err)
+			assert.Equal(t, testCase.expected, actual.String())
+		})
+	}
+	t.Run("error - missing domain", func(t *testing.T) {
+		_, err := DIDToURL(did.MustParseDID("did:webvh:QmSCID"))
+
+		assert.EqualError(t, err, "invalid did:webvh: missing domain")
+	})
+}
+
+type stubHTTPClient func(request *http.Request) (*http.Response, error)
+
+func (s stubHTTPClient) Do(request *http.Request) (*http.Response, error) {
+	return s(request)
+}
+
+type stubLogStore map[string][]byte
+
+func (s stubLogStore) Log(_ context.Context, id did.DID) ([]byte, error) {
+	if data, ok := s[id.String()]; ok {
+		return data, nil
+	}
+	return nil, resolver.ErrNotFound
+}
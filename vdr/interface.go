@@ -19,6 +19,7 @@
 package vdr
 
 import (
+	"context"
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/nuts-node/vdr/didsubject"
 	"github.com/nuts-foundation/nuts-node/vdr/resolver"
@@ -41,4 +42,10 @@ type VDR interface {
 	ConflictedDocuments() ([]did.Document, []resolver.DocumentMetadata, error)
 	// PublicURL returns the public URL of the Nuts node, which is used as base URL for web-based DIDs.
 	PublicURL() *url.URL
+	// TenantWebVHLog returns the DID log (did.jsonl) of the did:webvh DID of the given tenant, as published at /iam/{tenant}/did.jsonl.
+	// It returns resolver.ErrNotFound if there's no such DID managed by this node.
+	TenantWebVHLog(ctx context.Context, tenant string) ([]byte, error)
+	// InvalidateResolutionCache removes the DID document of the given DID from the DID resolution cache,
+	// so it's resolved from its source again the next time.
+	InvalidateResolutionCache(id did.DID) error
 }
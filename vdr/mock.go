@@ -10,6 +10,7 @@
 package vdr
 
 import (
+	context "context"
 	url "net/url"
 	reflect "reflect"
 
@@ -73,6 +74,20 @@ func (mr *MockVDRMockRecorder) DocumentOwner() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DocumentOwner", reflect.TypeOf((*MockVDR)(nil).DocumentOwner))
 }
 
+// InvalidateResolutionCache mocks base method.
+func (m *MockVDR) InvalidateResolutionCache(id did.DID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateResolutionCache", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateResolutionCache indicates an expected call of InvalidateResolutionCache.
+func (mr *MockVDRMockRecorder) InvalidateResolutionCache(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateResolutionCache", reflect.TypeOf((*MockVDR)(nil).InvalidateResolutionCache), id)
+}
+
 // NutsDocumentManager mocks base method.
 func (m *MockVDR) NutsDocumentManager() didsubject.DocumentManager {
 	m.ctrl.T.Helper()
@@ -129,3 +144,18 @@ func (mr *MockVDRMockRecorder) Resolver() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolver", reflect.TypeOf((*MockVDR)(nil).Resolver))
 }
+
+// TenantWebVHLog mocks base method.
+func (m *MockVDR) TenantWebVHLog(ctx context.Context, tenant string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TenantWebVHLog", ctx, tenant)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TenantWebVHLog indicates an expected call of TenantWebVHLog.
+func (mr *MockVDRMockRecorder) TenantWebVHLog(ctx, tenant any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TenantWebVHLog", reflect.TypeOf((*MockVDR)(nil).TenantWebVHLog), ctx, tenant)
+}
@@ -20,6 +20,7 @@ package resolver
 
 import (
 	"errors"
+	"fmt"
 	"github.com/lestrrat-go/jwx/v2/cert"
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/nuts-node/crypto/hash"
@@ -107,6 +108,11 @@ type DocumentMetadata struct {
 	SourceTransactions []hash.SHA256Hash `json:"txs"`
 	// Deactivated indicates if the document is deactivated
 	Deactivated bool `json:"deactivated"`
+	// VersionID identifies the resolved version of the DID document, for DID methods that support versioning (e.g. did:webvh).
+	VersionID string `json:"versionId,omitempty"`
+	// CacheUntil indicates until when the DID document may be cached, as indicated by the source it was resolved from (e.g. HTTP cache headers).
+	// If nil, the source gave no indication. A zero time indicates the DID document must not be cached.
+	CacheUntil *time.Time `json:"-"`
 }
 
 // Copy creates a deep copy of DocumentMetadata
@@ -115,6 +121,10 @@ func (m DocumentMetadata) Copy() DocumentMetadata {
 		updated := *m.Updated
 		m.Updated = &updated
 	}
+	if m.CacheUntil != nil {
+		cacheUntil := *m.CacheUntil
+		m.CacheUntil = &cacheUntil
+	}
 
 	if m.PreviousHash != nil {
 		prevHash := *m.PreviousHash
@@ -134,6 +144,8 @@ func (m DocumentMetadata) IsConflicted() bool {
 type ResolveMetadata struct {
 	// Resolve the version which is valid at this time
 	ResolveTime *time.Time
+	// VersionID resolves the version with the given versionId, for DID methods that support versioning (e.g. did:webvh).
+	VersionID string
 	// if provided, use the version which matches this exact hash
 	Hash *hash.SHA256Hash
 	// SourceTransaction must match a TX hash from the metadata.SourceTransaction field, if provided
@@ -149,14 +161,20 @@ var _ DIDResolver = &DIDResolverRouter{}
 // DIDResolverRouter is a DID resolver that can route to different DID resolvers based on the DID method
 type DIDResolverRouter struct {
 	resolvers sync.Map
+	// fallback is used to resolve DIDs of DID methods without registered resolver, if set.
+	fallback DIDResolver
 }
 
 // Resolve looks up the right resolver for the given DID and delegates the resolution to it.
-// If no resolver is registered for the given DID method, ErrDIDMethodNotSupported is returned.
+// If no resolver is registered for the given DID method, the fallback resolver is used.
+// If there's no fallback resolver either, ErrDIDMethodNotSupported is returned.
 func (r *DIDResolverRouter) Resolve(id did.DID, metadata *ResolveMetadata) (*did.Document, *DocumentMetadata, error) {
 	method := id.Method
 	didResolver, registered := r.resolvers.Load(method)
 	if !registered {
+		if r.fallback != nil {
+			return r.fallback.Resolve(id, metadata)
+		}
 		return nil, nil, ErrDIDMethodNotSupported
 	}
 	return didResolver.(DIDResolver).Resolve(id, metadata)
@@ -167,6 +185,13 @@ func (r *DIDResolverRouter) Register(method string, resolver DIDResolver) {
 	r.resolvers.Store(method, resolver)
 }
 
+// RegisterFallback registers the DID resolver that is used for DID methods without registered resolver.
+// It should return ErrDIDMethodNotSupported for DID methods it doesn't support either.
+// It must be called before the router is used to resolve DIDs.
+func (r *DIDResolverRouter) RegisterFallback(resolver DIDResolver) {
+	r.fallback = resolver
+}
+
 // IsFunctionalResolveError returns true if the given error indicates the DID or service not being found or invalid,
 // e.g. because it is deactivated, referenced too deeply, etc.
 func IsFunctionalResolveError(target error) bool {
@@ -188,6 +213,31 @@ func GetDIDFromURL(didURL string) (did.DID, error) {
 	return parsed.DID, nil
 }
 
+// VersionParameters returns a copy of the given ResolveMetadata with the versionId and versionTime DID URL parameters applied
+// (see https://www.w3.org/TR/did-core/#did-parameters). If the DID URL contains neither, the given metadata is returned as is.
+func VersionParameters(didURL did.DIDURL, metadata *ResolveMetadata) (*ResolveMetadata, error) {
+	versionID := didURL.Query.Get("versionId")
+	versionTime := didURL.Query.Get("versionTime")
+	if versionID == "" && versionTime == "" {
+		return metadata, nil
+	}
+	result := ResolveMetadata{}
+	if metadata != nil {
+		result = *metadata
+	}
+	if versionID != "" {
+		result.VersionID = versionID
+	}
+	if versionTime != "" {
+		resolveTime, err := time.Parse(time.RFC3339, versionTime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid versionTime DID parameter: %w", err)
+		}
+		result.ResolveTime = &resolveTime
+	}
+	return &result, nil
+}
+
 // IsDeactivated returns true if the DID.Document has already been deactivated
 func IsDeactivated(document did.Document) bool {
 	return len(document.Controller) == 0 && len(document.CapabilityInvocation) == 0
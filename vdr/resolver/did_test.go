@@ -28,6 +28,7 @@ import (
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/nuts-foundation/nuts-node/crypto/hash"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 	"io"
 	"reflect"
@@ -48,8 +49,10 @@ func TestCopy(t *testing.T) {
 		PreviousHash:       &h,
 		Deactivated:        false,
 		SourceTransactions: []hash.SHA256Hash{h},
+		VersionID:          "1",
+		CacheUntil:         &timeLater,
 	}
-	numFields := 6
+	numFields := 8
 
 	t.Run("returns error if metadata can be manipulated", func(t *testing.T) {
 		var metaCopy DocumentMetadata
@@ -63,6 +66,11 @@ func TestCopy(t *testing.T) {
 		*metaCopy.Updated = timeLater
 		assert.False(t, reflect.DeepEqual(meta, metaCopy))
 
+		// CacheUntil
+		metaCopy = meta.Copy()
+		*metaCopy.CacheUntil = timeNow
+		assert.False(t, reflect.DeepEqual(meta, metaCopy))
+
 		// Hash
 		metaCopy.Hash[0] = 0
 		assert.NotEqual(t, metaCopy.Hash, meta.Hash, "Hash is not deep-copied")
@@ -147,6 +155,19 @@ func TestDIDResolverRouter_Resolve(t *testing.T) {
 		assert.EqualError(t, err, "DID method not supported")
 		assert.Nil(t, actual)
 	})
+	t.Run("fallback", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		otherResolver := NewMockDIDResolver(ctrl)
+		fallback := NewMockDIDResolver(ctrl)
+		fallback.EXPECT().Resolve(doc.ID, gomock.Any()).Return(&doc, nil, nil)
+		router := &DIDResolverRouter{}
+		router.Register("other", otherResolver)
+		router.RegisterFallback(fallback)
+
+		actual, _, err := router.Resolve(doc.ID, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, &doc, actual)
+	})
 }
 
 func TestIsFunctionalResolveError(t *testing.T) {
@@ -189,6 +210,39 @@ func TestGetDIDFromURL(t *testing.T) {
 	})
 }
 
+func TestVersionParameters(t *testing.T) {
+	resolveTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	t.Run("no DID parameters", func(t *testing.T) {
+		metadata := &ResolveMetadata{AllowDeactivated: true}
+
+		result, err := VersionParameters(did.MustParseDIDURL("did:web:example.com"), metadata)
+
+		require.NoError(t, err)
+		assert.Same(t, metadata, result)
+	})
+	t.Run("versionId and versionTime", func(t *testing.T) {
+		metadata := &ResolveMetadata{AllowDeactivated: true}
+
+		result, err := VersionParameters(did.MustParseDIDURL("did:web:example.com?versionId=1&versionTime=2024-01-01T00:00:00Z"), metadata)
+
+		require.NoError(t, err)
+		assert.Equal(t, ResolveMetadata{AllowDeactivated: true, VersionID: "1", ResolveTime: &resolveTime}, *result)
+		assert.Empty(t, metadata.VersionID, "given metadata must not be altered")
+	})
+	t.Run("nil metadata", func(t *testing.T) {
+		result, err := VersionParameters(did.MustParseDIDURL("did:web:example.com?versionId=1"), nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, "1", result.VersionID)
+	})
+	t.Run("invalid versionTime", func(t *testing.T) {
+		result, err := VersionParameters(did.MustParseDIDURL("did:web:example.com?versionTime=yesterday"), nil)
+
+		assert.ErrorContains(t, err, "invalid versionTime DID parameter")
+		assert.Nil(t, result)
+	})
+}
+
 func TestChainedDIDResolver_Resolve(t *testing.T) {
 	expected := did.Document{
 		ID: did.MustParseDID("did:example:123"),
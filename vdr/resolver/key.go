@@ -46,6 +46,9 @@ type KeyResolver interface {
 	// If multiple keys are valid, the first one is returned.
 	// An ErrKeyNotFound is returned when no key (of the specified type) is found.
 	ResolveKey(id did.DID, validAt *time.Time, relationType RelationType) (string, crypto.PublicKey, error)
+	// ResolveKeys looks for all valid keys of the given RelationType for the given DID, and returns them mapped by their ID.
+	// An ErrKeyNotFound is returned when no key (of the specified type) is found.
+	ResolveKeys(id did.DID, validAt *time.Time, relationType RelationType) (map[string]crypto.PublicKey, error)
 }
 
 // NutsKeyResolver is the interface for resolving keys from Nuts DID Documents,
@@ -66,11 +69,25 @@ type DIDKeyResolver struct {
 }
 
 func (r DIDKeyResolver) ResolveKeyByID(keyID string, metadata *ResolveMetadata, relationType RelationType) (crypto.PublicKey, error) {
-	holder, err := GetDIDFromURL(keyID)
+	keyURL, err := did.ParseDIDURL(keyID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid key ID (id=%s): %w", keyID, err)
 	}
-	doc, _, err := r.Resolver.Resolve(holder, metadata)
+	// versionId and versionTime DID parameters select the DID document version the key is looked up in
+	if len(keyURL.Query) > 0 {
+		metadata, err = VersionParameters(*keyURL, metadata)
+		if err != nil {
+			return nil, fmt.Errorf("invalid key ID (id=%s): %w", keyID, err)
+		}
+		// the verification method IDs in the DID document don't contain the DID parameters
+		keyURL.Query.Del("versionId")
+		keyURL.Query.Del("versionTime")
+		if len(keyURL.Query) == 0 {
+			keyURL.Query = nil
+		}
+		keyID = keyURL.String()
+	}
+	doc, _, err := r.Resolver.Resolve(keyURL.DID, metadata)
 	if err != nil {
 		return nil, err
 	}
@@ -135,6 +152,31 @@ func (r DIDKeyResolver) ResolveKey(id did.DID, validAt *time.Time, relationType
 	return keys[0].ID.String(), publicKey, nil
 }
 
+func (r DIDKeyResolver) ResolveKeys(id did.DID, validAt *time.Time, relationType RelationType) (map[string]crypto.PublicKey, error) {
+	doc, _, err := r.Resolver.Resolve(id, &ResolveMetadata{
+		ResolveTime: validAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	keys, err := resolveRelationships(doc, relationType)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, ErrKeyNotFound
+	}
+	result := make(map[string]crypto.PublicKey, len(keys))
+	for _, key := range keys {
+		publicKey, err := key.PublicKey()
+		if err != nil {
+			return nil, err
+		}
+		result[key.ID.String()] = publicKey
+	}
+	return result, nil
+}
+
 func resolveRelationships(doc *did.Document, relationType RelationType) (relationships did.VerificationRelationships, err error) {
 	switch relationType {
 	case Authentication:
@@ -74,6 +74,21 @@ func (mr *MockKeyResolverMockRecorder) ResolveKeyByID(keyID, metadata, relationT
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveKeyByID", reflect.TypeOf((*MockKeyResolver)(nil).ResolveKeyByID), keyID, metadata, relationType)
 }
 
+// ResolveKeys mocks base method.
+func (m *MockKeyResolver) ResolveKeys(id did.DID, validAt *time.Time, relationType RelationType) (map[string]crypto.PublicKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveKeys", id, validAt, relationType)
+	ret0, _ := ret[0].(map[string]crypto.PublicKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveKeys indicates an expected call of ResolveKeys.
+func (mr *MockKeyResolverMockRecorder) ResolveKeys(id, validAt, relationType any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveKeys", reflect.TypeOf((*MockKeyResolver)(nil).ResolveKeys), id, validAt, relationType)
+}
+
 // MockNutsKeyResolver is a mock of NutsKeyResolver interface.
 type MockNutsKeyResolver struct {
 	ctrl     *gomock.Controller
@@ -19,6 +19,10 @@
 package resolver
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	ssi "github.com/nuts-foundation/go-did"
 	"github.com/nuts-foundation/go-did/did"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -79,6 +83,44 @@ func TestKeyResolver_ResolveKey(t *testing.T) {
 	})
 }
 
+func TestKeyResolver_ResolveKeys(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	resolver := NewMockDIDResolver(ctrl)
+	keyResolver := DIDKeyResolver{Resolver: resolver}
+
+	doc := newDidDoc()
+	secondKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	secondKeyID := did.DIDURL{DID: doc.ID, Fragment: "key-2"}
+	secondVM, _ := did.NewVerificationMethod(secondKeyID, ssi.JsonWebKey2020, doc.ID, secondKey.Public())
+	doc.AddAssertionMethod(secondVM)
+	resolver.EXPECT().Resolve(doc.ID, gomock.Any()).AnyTimes().Return(&doc, nil, nil)
+
+	t.Run("ok - it finds all keys", func(t *testing.T) {
+		keys, err := keyResolver.ResolveKeys(doc.ID, nil, AssertionMethod)
+		require.NoError(t, err)
+		require.Len(t, keys, 2)
+		assert.NotNil(t, keys[doc.VerificationMethod[0].ID.String()])
+		assert.Equal(t, secondKey.Public(), keys[secondKeyID.String()])
+	})
+	t.Run("error - document not found", func(t *testing.T) {
+		unknownDID := did.MustParseDID("did:example:123")
+		resolver.EXPECT().Resolve(unknownDID, gomock.Any()).Return(nil, nil, ErrNotFound)
+		keys, err := keyResolver.ResolveKeys(unknownDID, nil, AssertionMethod)
+		assert.ErrorIs(t, err, ErrNotFound)
+		assert.Nil(t, keys)
+	})
+	t.Run("error - key not found", func(t *testing.T) {
+		keys, err := keyResolver.ResolveKeys(doc.ID, nil, KeyAgreement)
+		assert.ErrorIs(t, err, ErrKeyNotFound)
+		assert.Nil(t, keys)
+	})
+	t.Run("error - unknown relationship type", func(t *testing.T) {
+		keys, err := keyResolver.ResolveKeys(doc.ID, nil, 1000)
+		assert.EqualError(t, err, "unable to locate RelationType 1000")
+		assert.Nil(t, keys)
+	})
+}
+
 func TestKeyResolver_ResolveKeyByID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	resolver := NewMockDIDResolver(ctrl)
@@ -93,6 +135,24 @@ func TestKeyResolver_ResolveKeyByID(t *testing.T) {
 		assert.NotNil(t, key)
 	})
 
+	t.Run("ok - key ID with versionId DID parameter", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		versionResolver := NewMockDIDResolver(ctrl)
+		versionResolver.EXPECT().Resolve(doc.ID, &ResolveMetadata{VersionID: "1"}).Return(&doc, nil, nil)
+		versionedKeyID := doc.ID.String() + "?versionId=1#" + keyID.Fragment
+
+		key, err := DIDKeyResolver{Resolver: versionResolver}.ResolveKeyByID(versionedKeyID, nil, AssertionMethod)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, key)
+	})
+
+	t.Run("error - invalid versionTime DID parameter", func(t *testing.T) {
+		key, err := keyResolver.ResolveKeyByID(doc.ID.String()+"?versionTime=yesterday#"+keyID.Fragment, nil, AssertionMethod)
+		assert.ErrorContains(t, err, "invalid versionTime DID parameter")
+		assert.Nil(t, key)
+	})
+
 	t.Run("error - invalid key ID", func(t *testing.T) {
 		key, err := keyResolver.ResolveKeyByID("abcdef", nil, AssertionMethod)
 		assert.EqualError(t, err, "invalid key ID (id=abcdef): invalid DID")
@@ -0,0 +1,275 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package uniresolver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/core"
+	"github.com/nuts-foundation/nuts-node/http/client"
+	"github.com/nuts-foundation/nuts-node/vdr/log"
+	"github.com/nuts-foundation/nuts-node/vdr/resolver"
+)
+
+var _ resolver.DIDResolver = (*Resolver)(nil)
+
+// resolvePath is the path of the DID resolution endpoint of a Universal Resolver, to which the DID is appended.
+const resolvePath = "/1.0/identifiers/"
+
+const resolutionResultContentType = `application/ld+json;profile="https://w3id.org/did-resolution"`
+
+// maxResponseSize is the maximum size of a DID resolution result that is read from a Universal Resolver.
+const maxResponseSize = 1024 * 1024
+
+// Resolver resolves DIDs using external DIF Universal Resolver (https://github.com/decentralized-identity/universal-resolver) instances.
+// It's used as fallback for DID methods the node doesn't support itself. Only DIDs of the DID methods it's configured for are resolved,
+// other DID methods yield resolver.ErrDIDMethodNotSupported.
+// If multiple Universal Resolvers are configured for a DID method, they're tried in order until one of them gives a definitive answer.
+// Successful resolution results are cached, so that DIDs that are resolved often don't lead to an HTTP request every time.
+type Resolver struct {
+	// HttpClient is the HTTP client used to call the Universal Resolver instances.
+	HttpClient core.HTTPRequestDoer
+	// baseURLs maps DID methods to the base URLs of the Universal Resolvers used to resolve DIDs of that method, in order of preference.
+	baseURLs map[string][]string
+	cacheTTL time.Duration
+	cache    map[string]cacheEntry
+	mux      sync.Mutex
+}
+
+type cacheEntry struct {
+	// document is the JSON representation of the DID document, so every caller gets its own copy.
+	document []byte
+	metadata resolver.DocumentMetadata
+	expires  time.Time
+}
+
+// resolutionResult is the DID resolution result returned by a Universal Resolver, see https://www.w3.org/TR/did-resolution/#did-resolution-result
+type resolutionResult struct {
+	Document           *did.Document `json:"didDocument"`
+	ResolutionMetadata struct {
+		Error        string `json:"error"`
+		ErrorMessage string `json:"errorMessage"`
+	} `json:"didResolutionMetadata"`
+	DocumentMetadata struct {
+		Created     *time.Time `json:"created"`
+		Updated     *time.Time `json:"updated"`
+		Deactivated bool       `json:"deactivated"`
+		VersionID   string     `json:"versionId"`
+	} `json:"didDocumentMetadata"`
+}
+
+// NewResolver creates a new Resolver. The given map contains the DID methods to resolve, and the base URLs of the Universal Resolver instances to use for each of them.
+// The timeout applies to every HTTP request to a Universal Resolver. Resolution results are cached for cacheTTL, a cacheTTL of 0 disables caching.
+func NewResolver(baseURLs map[string][]string, timeout time.Duration, cacheTTL time.Duration) *Resolver {
+	result := &Resolver{
+		HttpClient: client.New(timeout),
+		baseURLs:   make(map[string][]string),
+		cacheTTL:   cacheTTL,
+		cache:      make(map[string]cacheEntry),
+	}
+	for method, methodURLs := range baseURLs {
+		for _, baseURL := range methodURLs {
+			result.baseURLs[method] = append(result.baseURLs[method], strings.TrimSuffix(baseURL, "/"))
+		}
+	}
+	return result
+}
+
+// Resolve resolves the DID using the Universal Resolver configured for its DID method.
+// The versionId and ResolveTime (as versionTime) of the given metadata are passed to the Universal Resolver as DID parameters.
+func (r *Resolver) Resolve(id did.DID, metadata *resolver.ResolveMetadata) (*did.Document, *resolver.DocumentMetadata, error) {
+	baseURLs, ok := r.baseURLs[id.Method]
+	if !ok {
+		return nil, nil, resolver.ErrDIDMethodNotSupported
+	}
+	didURL := did.DIDURL{DID: id}
+	if metadata != nil && metadata.VersionID != "" {
+		didURL.Query = url.Values{"versionId": []string{metadata.VersionID}}
+	} else if metadata != nil && metadata.ResolveTime != nil {
+		didURL.Query = url.Values{"versionTime": []string{metadata.ResolveTime.UTC().Format(time.RFC3339)}}
+	}
+	cacheKey := didURL.String()
+
+	entry, ok := r.getCached(cacheKey)
+	if !ok {
+		var err error
+		entry, err = r.resolveWithFailover(baseURLs, didURL)
+		if err != nil {
+			return nil, nil, err
+		}
+		r.putCached(cacheKey, entry)
+	}
+	if entry.metadata.Deactivated && (metadata == nil || !metadata.AllowDeactivated) {
+		return nil, nil, resolver.ErrDeactivated
+	}
+	// return copies, so callers can't alter cached entries
+	var document did.Document
+	if err := document.UnmarshalJSON(entry.document); err != nil {
+		return nil, nil, err
+	}
+	documentMetadata := entry.metadata.Copy()
+	return &document, &documentMetadata, nil
+}
+
+// resolveWithFailover resolves the DID using the given Universal Resolvers in order.
+// The next Universal Resolver is tried if resolution fails, unless the DID was found to not exist or to be deactivated.
+// If all Universal Resolvers fail, the error of the last one is returned.
+func (r *Resolver) resolveWithFailover(baseURLs []string, didURL did.DIDURL) (cacheEntry, error) {
+	var err error
+	for i, baseURL := range baseURLs {
+		var entry cacheEntry
+		entry, err = r.resolve(baseURL, didURL)
+		if err == nil || errors.Is(err, resolver.ErrNotFound) || errors.Is(err, resolver.ErrDeactivated) {
+			return entry, err
+		}
+		if i < len(baseURLs)-1 {
+			log.Logger().WithError(err).Warnf("Universal Resolver failed to resolve DID, trying next one (url=%s, did=%s)", baseURL, didURL.DID)
+		}
+	}
+	return cacheEntry{}, err
+}
+
+// resolve performs the HTTP request to the Universal Resolver.
+func (r *Resolver) resolve(baseURL string, didURL did.DIDURL) (cacheEntry, error) {
+	targetURL := baseURL + resolvePath + url.PathEscape(didURL.String())
+	request, err := http.NewRequest(http.MethodGet, targetURL, nil)
+	if err != nil {
+		return cacheEntry{}, err
+	}
+	request.Header.Set("Accept", resolutionResultContentType)
+	response, err := r.HttpClient.Do(request)
+	if err != nil {
+		return cacheEntry{}, fmt.Errorf("universal resolver HTTP error: %w", err)
+	}
+	defer response.Body.Close()
+	switch response.StatusCode {
+	case http.StatusOK, http.StatusGone:
+		// 410 Gone indicates the DID document is deactivated, the body is a regular resolution result
+	case http.StatusNotFound:
+		return cacheEntry{}, resolver.ErrNotFound
+	case http.StatusNotImplemented:
+		return cacheEntry{}, resolver.ErrDIDMethodNotSupported
+	default:
+		return cacheEntry{}, fmt.Errorf("universal resolver non-ok HTTP status: %s", response.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(response.Body, maxResponseSize+1))
+	if err != nil {
+		return cacheEntry{}, fmt.Errorf("universal resolver HTTP response read error: %w", err)
+	}
+	if len(data) > maxResponseSize {
+		return cacheEntry{}, errors.New("universal resolver response exceeds maximum size")
+	}
+	var result resolutionResult
+	if err = json.Unmarshal(data, &result); err != nil {
+		return cacheEntry{}, fmt.Errorf("universal resolver JSON unmarshal error: %w", err)
+	}
+	if result.ResolutionMetadata.Error != "" {
+		return cacheEntry{}, fmt.Errorf("universal resolver error: %s (%s)", result.ResolutionMetadata.Error, result.ResolutionMetadata.ErrorMessage)
+	}
+	if result.Document == nil {
+		if response.StatusCode == http.StatusGone {
+			return cacheEntry{}, resolver.ErrDeactivated
+		}
+		return cacheEntry{}, errors.New("universal resolver response does not contain a DID document")
+	}
+	if result.Document.ID.String() != didURL.DID.String() {
+		return cacheEntry{}, fmt.Errorf("universal resolver DID document ID mismatch: %s != %s", result.Document.ID, didURL.DID)
+	}
+	documentJSON, err := json.Marshal(result.Document)
+	if err != nil {
+		return cacheEntry{}, err
+	}
+	entry := cacheEntry{
+		document: documentJSON,
+		metadata: resolver.DocumentMetadata{
+			Updated:     result.DocumentMetadata.Updated,
+			Deactivated: result.DocumentMetadata.Deactivated || response.StatusCode == http.StatusGone,
+			VersionID:   result.DocumentMetadata.VersionID,
+			CacheUntil:  client.CacheExpiration(request, response),
+		},
+	}
+	if result.DocumentMetadata.Created != nil {
+		entry.metadata.Created = *result.DocumentMetadata.Created
+	}
+	return entry, nil
+}
+
+func (r *Resolver) getCached(key string) (cacheEntry, bool) {
+	if r.cacheTTL <= 0 {
+		return cacheEntry{}, false
+	}
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	entry, ok := r.cache[key]
+	if !ok {
+		return cacheEntry{}, false
+	}
+	if time.Now().After(entry.expires) {
+		delete(r.cache, key)
+		return cacheEntry{}, false
+	}
+	return entry, true
+}
+
+// putCached caches the entry for cacheTTL, or shorter if the Universal Resolver's HTTP cache headers indicate so.
+// Entries that must not be cached according to the HTTP cache headers aren't cached.
+func (r *Resolver) putCached(key string, entry cacheEntry) {
+	if r.cacheTTL <= 0 {
+		return
+	}
+	now := time.Now()
+	entry.expires = now.Add(r.cacheTTL)
+	if cacheUntil := entry.metadata.CacheUntil; cacheUntil != nil {
+		if cacheUntil.IsZero() || !cacheUntil.After(now) {
+			return
+		}
+		if cacheUntil.Before(entry.expires) {
+			entry.expires = *cacheUntil
+		}
+	}
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	// prune expired entries to keep the cache from growing indefinitely
+	for k, curr := range r.cache {
+		if now.After(curr.expires) {
+			delete(r.cache, k)
+		}
+	}
+	r.cache[key] = entry
+}
+
+// Invalidate removes the cached resolution results of the given DID (all versions), so it will be resolved by the Universal Resolver again.
+func (r *Resolver) Invalidate(id did.DID) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	for key := range r.cache {
+		if didURL, err := did.ParseDIDURL(key); err != nil || didURL.DID.Equals(id) {
+			delete(r.cache, key)
+		}
+	}
+}
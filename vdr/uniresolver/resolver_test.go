@@ -0,0 +1,320 @@
+/*
+ * Copyright (C) 2026 Nuts community
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License
+ * along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package uniresolver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nuts-foundation/go-did/did"
+	"github.com/nuts-foundation/nuts-node/vdr/resolver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testDID = did.MustParseDID("did:ebsi:zvHWX359A3CvfJnCYaAiAde")
+
+func TestResolver_Resolve(t *testing.T) {
+	t.Run("ok", func(t *testing.T) {
+		server := newTestServer(t, http.StatusOK, false)
+		r := NewResolver(map[string][]string{"ebsi": {server.URL + "/"}}, time.Second, time.Minute)
+
+		document, metadata, err := r.Resolve(testDID, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, testDID.String(), document.ID.String())
+		assert.Equal(t, "2", metadata.VersionID)
+		assert.Equal(t, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), metadata.Created)
+		require.Len(t, server.requests, 1)
+		assert.Equal(t, "/1.0/identifiers/"+testDID.String(), server.requests[0].URL.Path)
+		assert.Equal(t, resolutionResultContentType, server.requests[0].Header.Get("Accept"))
+	})
+	t.Run("versionId and versionTime are passed as DID parameters", func(t *testing.T) {
+		server := newTestServer(t, http.StatusOK, false)
+		r := NewResolver(map[string][]string{"ebsi": {server.URL}}, time.Second, 0)
+		resolveTime := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+		_, _, err := r.Resolve(testDID, &resolver.ResolveMetadata{VersionID: "1"})
+		require.NoError(t, err)
+		_, _, err = r.Resolve(testDID, &resolver.ResolveMetadata{ResolveTime: &resolveTime})
+		require.NoError(t, err)
+
+		require.Len(t, server.requests, 2)
+		requestedDID, _ := url.PathUnescape(server.requests[0].URL.EscapedPath())
+		assert.Equal(t, "/1.0/identifiers/"+testDID.String()+"?versionId=1", requestedDID)
+		requestedDID, _ = url.PathUnescape(server.requests[1].URL.EscapedPath())
+		assert.Equal(t, "/1.0/identifiers/"+testDID.String()+"?versionTime=2026-01-02T03%3A04%3A05Z", requestedDID)
+	})
+	t.Run("DID method not in allow-list", func(t *testing.T) {
+		server := newTestServer(t, http.StatusOK, false)
+		r := NewResolver(map[string][]string{"ebsi": {server.URL}}, time.Second, time.Minute)
+
+		_, _, err := r.Resolve(did.MustParseDID("did:cheqd:mainnet:123"), nil)
+
+		assert.ErrorIs(t, err, resolver.ErrDIDMethodNotSupported)
+		assert.Empty(t, server.requests)
+	})
+	t.Run("caching", func(t *testing.T) {
+		server := newTestServer(t, http.StatusOK, false)
+		r := NewResolver(map[string][]string{"ebsi": {server.URL}}, time.Second, time.Minute)
+
+		document, _, err := r.Resolve(testDID, nil)
+		require.NoError(t, err)
+		// altering the result must not alter the cached document
+		document.Service = nil
+		document.ID = did.MustParseDID("did:example:other")
+		document, _, err = r.Resolve(testDID, nil)
+		require.NoError(t, err)
+		assert.Equal(t, testDID.String(), document.ID.String())
+		assert.Len(t, server.requests, 1)
+		t.Run("other version is not cached", func(t *testing.T) {
+			_, _, err = r.Resolve(testDID, &resolver.ResolveMetadata{VersionID: "1"})
+			require.NoError(t, err)
+			assert.Len(t, server.requests, 2)
+		})
+		t.Run("expired entries are not used", func(t *testing.T) {
+			for key, entry := range r.cache {
+				entry.expires = time.Now().Add(-time.Second)
+				r.cache[key] = entry
+			}
+			_, _, err = r.Resolve(testDID, nil)
+			require.NoError(t, err)
+			assert.Len(t, server.requests, 3)
+		})
+		t.Run("invalidated entries are not used", func(t *testing.T) {
+			r.Invalidate(did.MustParseDID("did:ebsi:other"))
+			require.NotEmpty(t, r.cache)
+
+			r.Invalidate(testDID)
+
+			assert.Empty(t, r.cache)
+			_, _, err = r.Resolve(testDID, nil)
+			require.NoError(t, err)
+			assert.Len(t, server.requests, 4)
+		})
+	})
+	t.Run("caching honours HTTP cache headers", func(t *testing.T) {
+		t.Run("shorter max-age", func(t *testing.T) {
+			server := newTestServer(t, http.StatusOK, false)
+			server.cacheControl = "max-age=60"
+			r := NewResolver(map[string][]string{"ebsi": {server.URL}}, time.Second, time.Hour)
+
+			_, _, err := r.Resolve(testDID, nil)
+
+			require.NoError(t, err)
+			require.Len(t, r.cache, 1)
+			for _, entry := range r.cache {
+				assert.WithinDuration(t, time.Now().Add(time.Minute), entry.expires, 5*time.Second)
+			}
+		})
+		t.Run("longer max-age", func(t *testing.T) {
+			server := newTestServer(t, http.StatusOK, false)
+			server.cacheControl = "max-age=86400"
+			r := NewResolver(map[string][]string{"ebsi": {server.URL}}, time.Second, time.Hour)
+
+			_, _, err := r.Resolve(testDID, nil)
+
+			require.NoError(t, err)
+			require.Len(t, r.cache, 1)
+			for _, entry := range r.cache {
+				assert.WithinDuration(t, time.Now().Add(time.Hour), entry.expires, 5*time.Second)
+			}
+		})
+		t.Run("no-store", func(t *testing.T) {
+			server := newTestServer(t, http.StatusOK, false)
+			server.cacheControl = "no-store"
+			r := NewResolver(map[string][]string{"ebsi": {server.URL}}, time.Second, time.Hour)
+
+			_, _, err := r.Resolve(testDID, nil)
+
+			require.NoError(t, err)
+			assert.Empty(t, r.cache)
+		})
+	})
+	t.Run("failover", func(t *testing.T) {
+		t.Run("next Universal Resolver is used if one fails", func(t *testing.T) {
+			failing := newTestServer(t, http.StatusInternalServerError, false)
+			server := newTestServer(t, http.StatusOK, false)
+			r := NewResolver(map[string][]string{"ebsi": {failing.URL, server.URL}}, time.Second, time.Minute)
+
+			document, _, err := r.Resolve(testDID, nil)
+
+			require.NoError(t, err)
+			assert.Equal(t, testDID.String(), document.ID.String())
+			assert.Len(t, failing.requests, 1)
+			assert.Len(t, server.requests, 1)
+		})
+		t.Run("not found is definitive", func(t *testing.T) {
+			notFound := newTestServer(t, http.StatusNotFound, false)
+			server := newTestServer(t, http.StatusOK, false)
+			r := NewResolver(map[string][]string{"ebsi": {notFound.URL, server.URL}}, time.Second, time.Minute)
+
+			_, _, err := r.Resolve(testDID, nil)
+
+			assert.ErrorIs(t, err, resolver.ErrNotFound)
+			assert.Empty(t, server.requests)
+		})
+		t.Run("all fail", func(t *testing.T) {
+			failing := newTestServer(t, http.StatusInternalServerError, false)
+			unsupported := newTestServer(t, http.StatusNotImplemented, false)
+			r := NewResolver(map[string][]string{"ebsi": {failing.URL, unsupported.URL}}, time.Second, time.Minute)
+
+			_, _, err := r.Resolve(testDID, nil)
+
+			assert.ErrorIs(t, err, resolver.ErrDIDMethodNotSupported)
+			assert.Len(t, failing.requests, 1)
+			assert.Len(t, unsupported.requests, 1)
+		})
+	})
+	t.Run("deactivated", func(t *testing.T) {
+		server := newTestServer(t, http.StatusGone, true)
+		r := NewResolver(map[string][]string{"ebsi": {server.URL}}, time.Second, time.Minute)
+
+		_, _, err := r.Resolve(testDID, nil)
+		assert.ErrorIs(t, err, resolver.ErrDeactivated)
+
+		document, metadata, err := r.Resolve(testDID, &resolver.ResolveMetadata{AllowDeactivated: true})
+		require.NoError(t, err)
+		assert.NotNil(t, document)
+		assert.True(t, metadata.Deactivated)
+		assert.Len(t, server.requests, 1)
+	})
+	t.Run("errors", func(t *testing.T) {
+		t.Run("not found", func(t *testing.T) {
+			server := newTestServer(t, http.StatusNotFound, false)
+			r := NewResolver(map[string][]string{"ebsi": {server.URL}}, time.Second, time.Minute)
+
+			_, _, err := r.Resolve(testDID, nil)
+
+			assert.ErrorIs(t, err, resolver.ErrNotFound)
+			t.Run("errors are not cached", func(t *testing.T) {
+				_, _, _ = r.Resolve(testDID, nil)
+				assert.Len(t, server.requests, 2)
+			})
+		})
+		t.Run("method not supported by Universal Resolver", func(t *testing.T) {
+			server := newTestServer(t, http.StatusNotImplemented, false)
+			r := NewResolver(map[string][]string{"ebsi": {server.URL}}, time.Second, time.Minute)
+
+			_, _, err := r.Resolve(testDID, nil)
+
+			assert.ErrorIs(t, err, resolver.ErrDIDMethodNotSupported)
+		})
+		t.Run("server error", func(t *testing.T) {
+			server := newTestServer(t, http.StatusInternalServerError, false)
+			r := NewResolver(map[string][]string{"ebsi": {server.URL}}, time.Second, time.Minute)
+
+			_, _, err := r.Resolve(testDID, nil)
+
+			assert.EqualError(t, err, "universal resolver non-ok HTTP status: 500 Internal Server Error")
+		})
+		t.Run("timeout", func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				time.Sleep(100 * time.Millisecond)
+			}))
+			t.Cleanup(server.Close)
+			r := NewResolver(map[string][]string{"ebsi": {server.URL}}, 10*time.Millisecond, time.Minute)
+
+			_, _, err := r.Resolve(testDID, nil)
+
+			assert.ErrorContains(t, err, "universal resolver HTTP error")
+		})
+		t.Run("resolution error in response", func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				_, _ = writer.Write([]byte(`{"didResolutionMetadata": {"error": "invalidDid", "errorMessage": "bad DID"}}`))
+			}))
+			t.Cleanup(server.Close)
+			r := NewResolver(map[string][]string{"ebsi": {server.URL}}, time.Second, time.Minute)
+
+			_, _, err := r.Resolve(testDID, nil)
+
+			assert.EqualError(t, err, "universal resolver error: invalidDid (bad DID)")
+		})
+		t.Run("response too large", func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				_, _ = writer.Write([]byte(strings.Repeat(" ", maxResponseSize+1)))
+			}))
+			t.Cleanup(server.Close)
+			r := NewResolver(map[string][]string{"ebsi": {server.URL}}, time.Second, time.Minute)
+			// the default HTTP client has a safety limit of its own
+			r.HttpClient = server.Client()
+
+			_, _, err := r.Resolve(testDID, nil)
+
+			assert.EqualError(t, err, "universal resolver response exceeds maximum size")
+		})
+		t.Run("DID document ID mismatch", func(t *testing.T) {
+			server := newTestServer(t, http.StatusOK, false)
+			r := NewResolver(map[string][]string{"ebsi": {server.URL}}, time.Second, time.Minute)
+			otherDID := did.MustParseDID("did:ebsi:other")
+			server.documentID = &testDID
+
+			_, _, err := r.Resolve(otherDID, nil)
+
+			assert.ErrorContains(t, err, "universal resolver DID document ID mismatch")
+		})
+	})
+}
+
+type testServer struct {
+	*httptest.Server
+	requests []*http.Request
+	// documentID overrides the ID of the returned DID document, if set.
+	documentID *did.DID
+	// cacheControl is returned as Cache-Control header, if set.
+	cacheControl string
+}
+
+// newTestServer starts a stand-in Universal Resolver that responds with the given status code.
+// For 200 OK and 410 Gone, it returns a resolution result of the requested DID.
+func newTestServer(t *testing.T, statusCode int, deactivated bool) *testServer {
+	result := &testServer{}
+	result.Server = httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		result.requests = append(result.requests, request)
+		writer.Header().Set("Content-Type", resolutionResultContentType)
+		if result.cacheControl != "" {
+			writer.Header().Set("Cache-Control", result.cacheControl)
+		}
+		writer.WriteHeader(statusCode)
+		if statusCode != http.StatusOK && statusCode != http.StatusGone {
+			return
+		}
+		requestedDID, _ := url.PathUnescape(strings.TrimPrefix(request.URL.EscapedPath(), resolvePath))
+		id := did.MustParseDIDURL(requestedDID).DID
+		if result.documentID != nil {
+			id = *result.documentID
+		}
+		_ = json.NewEncoder(writer).Encode(map[string]interface{}{
+			"@context":    "https://w3id.org/did-resolution/v1",
+			"didDocument": did.Document{ID: id, Context: []interface{}{did.DIDContextV1URI()}},
+			"didDocumentMetadata": map[string]interface{}{
+				"created":     "2026-01-02T03:04:05Z",
+				"versionId":   "2",
+				"deactivated": deactivated,
+			},
+		})
+	}))
+	t.Cleanup(result.Server.Close)
+	return result
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/nuts-foundation/nuts-node/pki"
 	"net/url"
 	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -40,20 +41,28 @@ import (
 	"github.com/nuts-foundation/nuts-node/events"
 	"github.com/nuts-foundation/nuts-node/network"
 	"github.com/nuts-foundation/nuts-node/storage"
+	"github.com/nuts-foundation/nuts-node/storage/orm"
+	"github.com/nuts-foundation/nuts-node/vdr/didcache"
 	"github.com/nuts-foundation/nuts-node/vdr/didjwk"
 	"github.com/nuts-foundation/nuts-node/vdr/didkey"
 	"github.com/nuts-foundation/nuts-node/vdr/didnuts"
 	didnutsStore "github.com/nuts-foundation/nuts-node/vdr/didnuts/didstore"
+	"github.com/nuts-foundation/nuts-node/vdr/didpeer"
 	"github.com/nuts-foundation/nuts-node/vdr/didsubject"
 	"github.com/nuts-foundation/nuts-node/vdr/didweb"
+	"github.com/nuts-foundation/nuts-node/vdr/didwebvh"
 	"github.com/nuts-foundation/nuts-node/vdr/didx509"
 	"github.com/nuts-foundation/nuts-node/vdr/log"
 	"github.com/nuts-foundation/nuts-node/vdr/resolver"
+	"github.com/nuts-foundation/nuts-node/vdr/uniresolver"
 )
 
 // ModuleName is the name of the engine
 const ModuleName = "VDR"
 
+// keyRotationInterval is the interval at which the age of keys is checked against the key rotation policy.
+const keyRotationInterval = time.Hour
+
 var _ VDR = (*Module)(nil)
 var _ core.Named = (*Module)(nil)
 var _ core.Configurable = (*Module)(nil)
@@ -63,6 +72,7 @@ var _ didsubject.Manager = (*Module)(nil)
 // It connects the Resolve, Create and Update DID methods to the network, and receives events back from the network which are processed in the store.
 // It is also a Runnable, Diagnosable and Configurable Nuts Engine.
 type Module struct {
+	config              Config
 	supportedDIDMethods []string
 	publicURL           *url.URL
 	store               didnutsStore.Store
@@ -82,9 +92,18 @@ type Module struct {
 	// migrations are registered functions to simplify testing
 	migrations   []migration
 	pkiValidator pki.Validator
+	// webvhManager provides the DID logs of did:webvh DIDs, nil if did:webvh is disabled
+	webvhManager *didwebvh.Manager
+	// resolutionCache caches DID documents resolved from remote sources
+	resolutionCache *didcache.Cache
+	// remoteResolver resolves DIDs through Universal Resolvers, nil if remote resolution is disabled
+	remoteResolver *uniresolver.Resolver
 
 	// new style DID management
 	didsubject.Manager
+	// nextKeyRotation is the next automatic key rotation as determined by the last run of keyRotationLoop, reported through Diagnostics()
+	nextKeyRotation *didsubject.ScheduledKeyRotation
+	keyRotationMux  sync.Mutex
 
 	// Start/Shutdown
 	ctx      context.Context
@@ -112,10 +131,28 @@ func (r *Module) Resolver() resolver.DIDResolver {
 	return r.didResolver
 }
 
+// TenantWebVHLog returns the DID log (did.jsonl) of the did:webvh DID of the given tenant.
+func (r *Module) TenantWebVHLog(ctx context.Context, tenant string) ([]byte, error) {
+	if r.webvhManager == nil {
+		return nil, resolver.ErrNotFound
+	}
+	return r.webvhManager.TenantLog(ctx, tenant)
+}
+
+// InvalidateResolutionCache removes the DID document of the given DID from the DID resolution cache.
+// Resolution results the Universal Resolver client keeps in memory are removed as well, so the DID document is fetched from its source again.
+func (r *Module) InvalidateResolutionCache(id did.DID) error {
+	if r.remoteResolver != nil {
+		r.remoteResolver.Invalidate(id)
+	}
+	return r.resolutionCache.Invalidate(id)
+}
+
 // NewVDR creates a new Module with provided params
 func NewVDR(cryptoClient crypto.KeyStore, networkClient network.Transactions,
 	didStore didnutsStore.Store, eventManager events.Event, storageInstance storage.Engine, pkiValidator pki.Validator) *Module {
 	m := &Module{
+		config:          DefaultConfig(),
 		didResolver:     &resolver.DIDResolverRouter{},
 		network:         networkClient,
 		eventManager:    eventManager,
@@ -134,6 +171,10 @@ func (r *Module) Name() string {
 	return ModuleName
 }
 
+func (r *Module) Config() interface{} {
+	return &r.config
+}
+
 // Configure configures the Module engine.
 func (r *Module) Configure(config core.ServerConfig) error {
 	r.supportedDIDMethods = config.DIDMethods
@@ -148,7 +189,7 @@ func (r *Module) Configure(config core.ServerConfig) error {
 	// check if all configured methods are supported
 	for _, method := range r.supportedDIDMethods {
 		switch method {
-		case didnuts.MethodName, didweb.MethodName:
+		case didnuts.MethodName, didweb.MethodName, didwebvh.MethodName, didpeer.MethodName, didjwk.MethodName, didkey.MethodName:
 			continue
 		default:
 			return fmt.Errorf("unsupported DID method: %s", method)
@@ -160,10 +201,39 @@ func (r *Module) Configure(config core.ServerConfig) error {
 		r.networkAmbassador = didnuts.NewAmbassador(r.network, r.store, r.eventManager)
 	}
 	db := r.storageInstance.GetSQLDatabase()
+	resolutionCacheTTL := make(map[string]time.Duration)
+	for method, value := range r.config.ResolutionCache.TTL {
+		ttl, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid DID resolution cache TTL for DID method %s: %w", method, err)
+		}
+		resolutionCacheTTL[method] = ttl
+	}
+	r.resolutionCache = didcache.New(db, resolutionCacheTTL, r.config.ResolutionCache.StaleIfError)
 
 	r.didResolver.(*resolver.DIDResolverRouter).Register(didjwk.MethodName, didjwk.NewResolver())
 	r.didResolver.(*resolver.DIDResolverRouter).Register(didkey.MethodName, didkey.NewResolver())
 	r.didResolver.(*resolver.DIDResolverRouter).Register(didx509.MethodName, didx509.NewResolver())
+	r.didResolver.(*resolver.DIDResolverRouter).Register(didpeer.MethodName, didpeer.NewResolver())
+	// DIDs of DID methods the node doesn't support itself can be resolved using external Universal Resolvers
+	if len(r.config.RemoteResolution.Resolvers) > 0 {
+		// multiple Universal Resolvers can be configured for a DID method (space-separated), which are tried in order
+		baseURLs := make(map[string][]string)
+		for method, methodURLs := range r.config.RemoteResolution.Resolvers {
+			for _, baseURL := range strings.Fields(methodURLs) {
+				parsedURL, err := url.Parse(baseURL)
+				if err != nil || (parsedURL.Scheme != "https" && parsedURL.Scheme != "http") || parsedURL.Host == "" {
+					return fmt.Errorf("invalid Universal Resolver URL for DID method %s: %s", method, baseURL)
+				}
+				baseURLs[method] = append(baseURLs[method], baseURL)
+			}
+			if len(baseURLs[method]) == 0 {
+				return fmt.Errorf("no Universal Resolver URL configured for DID method %s", method)
+			}
+		}
+		r.remoteResolver = uniresolver.NewResolver(baseURLs, r.config.RemoteResolution.Timeout, r.config.RemoteResolution.CacheTTL)
+		r.didResolver.(*resolver.DIDResolverRouter).RegisterFallback(r.resolutionCache.Wrap(r.remoteResolver))
+	}
 	// Register DID resolver and DID methods we can resolve
 	r.ownedDIDResolver = didsubject.Resolver{DB: db}
 
@@ -199,7 +269,7 @@ func (r *Module) Configure(config core.ServerConfig) error {
 		Resolvers: []resolver.DIDResolver{
 			// did:web resolver should first look in own database, then resolve over the web
 			r.ownedDIDResolver,
-			didweb.NewResolver(),
+			r.resolutionCache.Wrap(didweb.NewResolver()),
 		},
 	}
 	if slices.Contains(r.supportedDIDMethods, didweb.MethodName) {
@@ -207,7 +277,37 @@ func (r *Module) Configure(config core.ServerConfig) error {
 		r.didResolver.(*resolver.DIDResolverRouter).Register(didweb.MethodName, webResolver)
 	}
 
-	r.Manager = didsubject.New(db, methodManagers, r.keyStore, r.supportedDIDMethods)
+	// did:webvh, resolving DID logs managed by this node from the database
+	webvhResolver := didwebvh.NewResolver(nil)
+	if slices.Contains(r.supportedDIDMethods, didwebvh.MethodName) {
+		r.webvhManager = didwebvh.NewManager(*rootDID, "iam", r.keyStore, db)
+		methodManagers[didwebvh.MethodName] = r.webvhManager
+		webvhResolver.Logs = r.webvhManager
+	}
+	r.didResolver.(*resolver.DIDResolverRouter).Register(didwebvh.MethodName, webvhResolver)
+
+	// did:peer, pairwise DIDs that aren't published
+	if slices.Contains(r.supportedDIDMethods, didpeer.MethodName) {
+		methodManagers[didpeer.MethodName] = didpeer.NewManager(r.keyStore, db)
+	}
+	// did:jwk and did:key, DIDs that contain their key and thus aren't published
+	if slices.Contains(r.supportedDIDMethods, didjwk.MethodName) {
+		methodManagers[didjwk.MethodName] = didjwk.NewManager(r.keyStore)
+	}
+	if slices.Contains(r.supportedDIDMethods, didkey.MethodName) {
+		methodManagers[didkey.MethodName] = didkey.NewManager(r.keyStore)
+	}
+
+	manager := didsubject.New(db, methodManagers, r.keyStore, r.supportedDIDMethods)
+	manager.KeyRotationGracePeriod = r.config.KeyRotation.GracePeriod
+	manager.KeyRotationPolicy = didsubject.KeyRotationPolicy{}
+	if r.config.KeyRotation.MaxAge.Assertion > 0 {
+		manager.KeyRotationPolicy[orm.AssertionKeyUsage()] = r.config.KeyRotation.MaxAge.Assertion
+	}
+	if r.config.KeyRotation.MaxAge.Encryption > 0 {
+		manager.KeyRotationPolicy[orm.EncryptionKeyUsage()] = r.config.KeyRotation.MaxAge.Encryption
+	}
+	r.Manager = manager
 
 	// Initiate the routines for auto-updating the data.
 	if r.networkAmbassador != nil {
@@ -217,7 +317,22 @@ func (r *Module) Configure(config core.ServerConfig) error {
 }
 
 func (r *Module) Start() error {
-	// nothing to start if did:nuts is disabled
+	// start retirement loop for verification methods replaced by a key rotation
+	r.routines.Add(1)
+	go func() {
+		defer r.routines.Done()
+		r.retirementLoop()
+	}()
+	// start automatic key rotation loop if a key rotation policy is configured
+	if r.keyRotationEnabled() {
+		r.routines.Add(1)
+		go func() {
+			defer r.routines.Done()
+			r.keyRotationLoop()
+		}()
+	}
+
+	// nothing else to start if did:nuts is disabled
 	if r.networkAmbassador == nil {
 		return nil
 	}
@@ -256,6 +371,71 @@ func (r *Module) rollbackLoop() {
 	}
 }
 
+// retirementLoop checks every minute if there are any verification methods of which the key rotation grace period has passed.
+// uses RetireVerificationMethods() to do the actual work.
+func (r *Module) retirementLoop() {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	// run once at startup
+	r.RetireVerificationMethods(r.ctx)
+	for {
+		select {
+		// stop at shutdown
+		case <-r.ctx.Done():
+			return
+		case <-ticker.C:
+			r.RetireVerificationMethods(r.ctx)
+		}
+	}
+}
+
+// keyRotationEnabled returns true if a maximum key age is configured for any key usage.
+func (r *Module) keyRotationEnabled() bool {
+	return r.config.KeyRotation.MaxAge.Assertion > 0 || r.config.KeyRotation.MaxAge.Encryption > 0
+}
+
+// scheduledKeyRotation returns the next automatic key rotation for diagnostics, or "none" if no rotation is scheduled.
+func (r *Module) scheduledKeyRotation() interface{} {
+	r.keyRotationMux.Lock()
+	defer r.keyRotationMux.Unlock()
+	if r.nextKeyRotation == nil {
+		return "none"
+	}
+	return *r.nextKeyRotation
+}
+
+// keyRotationLoop checks every hour if there are any keys that exceed the maximum key age of the key rotation policy.
+// uses RotateExpiredVerificationMethods() to do the actual work.
+func (r *Module) keyRotationLoop() {
+	ticker := time.NewTicker(keyRotationInterval)
+	defer ticker.Stop()
+
+	ctx := audit.Context(r.ctx, "system", ModuleName, "RotateExpiredKeys")
+	// run once at startup
+	r.rotateExpiredKeys(ctx)
+	for {
+		select {
+		// stop at shutdown
+		case <-r.ctx.Done():
+			return
+		case <-ticker.C:
+			r.rotateExpiredKeys(ctx)
+		}
+	}
+}
+
+func (r *Module) rotateExpiredKeys(ctx context.Context) {
+	next, err := r.RotateExpiredVerificationMethods(ctx)
+	if err != nil {
+		log.Logger().WithError(err).Error("Failed to rotate expired keys")
+		return
+	}
+	r.keyRotationMux.Lock()
+	defer r.keyRotationMux.Unlock()
+	r.nextKeyRotation = next
+}
+
 func (r *Module) Shutdown() error {
 	r.cancel()
 	r.routines.Wait()
@@ -336,7 +516,7 @@ func (r *Module) Diagnostics() []core.DiagnosticResult {
 		return count
 	}
 
-	return []core.DiagnosticResult{
+	results := []core.DiagnosticResult{
 		core.DiagnosticResultMap{
 			Title: "conflicted_did_documents",
 			Items: []core.DiagnosticResult{
@@ -355,6 +535,13 @@ func (r *Module) Diagnostics() []core.DiagnosticResult {
 			Outcome: docCount,
 		},
 	}
+	if r.keyRotationEnabled() {
+		results = append(results, &core.GenericDiagnosticResult{
+			Title:   "next_key_rotation",
+			Outcome: r.scheduledKeyRotation(),
+		})
+	}
+	return results
 }
 
 func (r *Module) Migrate() error {
@@ -45,10 +45,12 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
-	"io"
+	"net"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 )
 
 // testCtx contains the controller and mocks needed fot testing the Manipulator
@@ -121,6 +123,23 @@ func TestVDR_ConflictingDocuments(t *testing.T) {
 			assert.Equal(t, "0", results[1].String())
 		})
 
+		t.Run("ok - next key rotation", func(t *testing.T) {
+			vdr := NewVDR(nil, nil, nil, nil, nil, nil)
+			vdr.store = didstore.NewTestStore(t)
+			vdr.config.KeyRotation.MaxAge.Assertion = 24 * time.Hour
+			results := vdr.Diagnostics()
+
+			require.Len(t, results, 3)
+			assert.Equal(t, "next_key_rotation", results[2].Name())
+			assert.Equal(t, "none", results[2].String())
+
+			vdr.nextKeyRotation = &didsubject.ScheduledKeyRotation{Subject: "subject", VerificationMethodID: "did:web:example.com#1", At: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}
+			results = vdr.Diagnostics()
+
+			require.Len(t, results, 3)
+			assert.Equal(t, "2026-01-01T00:00:00Z (subject=subject, verification_method=did:web:example.com#1)", results[2].String())
+		})
+
 		t.Run("ok - 1 conflict", func(t *testing.T) {
 			vdr := NewVDR(nil, nil, nil, nil, nil, nil)
 			vdr.store = didstore.NewTestStore(t)
@@ -225,13 +244,19 @@ func TestVDR_Configure(t *testing.T) {
 	pkiMock := pki.NewMockValidator(ctrl)
 	t.Run("it can resolve using did:web", func(t *testing.T) {
 		t.Run("not in database", func(t *testing.T) {
-			client.DefaultCachingTransport = roundTripperFunc(func(r *http.Request) (*http.Response, error) {
-				return &http.Response{
-					Header:     map[string][]string{"Content-Type": {"application/json"}},
-					StatusCode: http.StatusOK,
-					Body:       io.NopCloser(strings.NewReader(`{"id": "did:web:example.com"}`)),
-				}, nil
-			})
+			// the test server's certificate is valid for example.com, so connections to example.com are routed to it
+			server := httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				writer.Header().Set("Content-Type", "application/json")
+				_, _ = writer.Write([]byte(`{"id": "did:web:example.com"}`))
+			}))
+			t.Cleanup(server.Close)
+			transport := server.Client().Transport.(*http.Transport).Clone()
+			transport.DialContext = func(ctx context.Context, network, _ string) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, network, server.Listener.Addr().String())
+			}
+			original := client.SafeHttpTransport
+			client.SafeHttpTransport = transport
+			t.Cleanup(func() { client.SafeHttpTransport = original })
 
 			instance := NewVDR(nil, nil, nil, nil, storageInstance, pkiMock)
 			err := instance.Configure(core.ServerConfig{URL: "https://nuts.nl", DIDMethods: []string{"web", "nuts"}})
@@ -263,6 +288,72 @@ func TestVDR_Configure(t *testing.T) {
 			assert.NotNil(t, md)
 		})
 	})
+	t.Run("it can create and resolve did:webvh", func(t *testing.T) {
+		db := storageInstance.GetSQLDatabase()
+		instance := NewVDR(nutsCrypto.NewDatabaseCryptoInstance(db), nil, nil, nil, storageInstance, pkiMock)
+		err := instance.Configure(core.ServerConfig{URL: "https://example.com", DIDMethods: []string{"webvh"}})
+		require.NoError(t, err)
+
+		docs, _, err := instance.Create(audit.TestContext(), didsubject.DefaultCreationOptions())
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+		doc, md, err := instance.Resolver().Resolve(docs[0].ID, nil)
+
+		require.NoError(t, err)
+		assert.Len(t, doc.AssertionMethod, 1)
+		assert.True(t, strings.HasPrefix(md.VersionID, "2-"))
+		didLog, err := instance.TenantWebVHLog(audit.TestContext(), docs[0].ID.ID[strings.LastIndex(docs[0].ID.ID, ":")+1:])
+		require.NoError(t, err)
+		assert.Equal(t, 2, strings.Count(string(didLog), "\n"))
+	})
+	t.Run("it can create and resolve did:peer", func(t *testing.T) {
+		db := storageInstance.GetSQLDatabase()
+		keyStore := nutsCrypto.NewDatabaseCryptoInstance(db)
+		instance := NewVDR(keyStore, nil, nil, nil, storageInstance, pkiMock)
+		err := instance.Configure(core.ServerConfig{URL: "https://example.com", DIDMethods: []string{"peer"}})
+		require.NoError(t, err)
+
+		docs, _, err := instance.Create(audit.TestContext(), didsubject.DefaultCreationOptions().With(didsubject.EncryptionKeyCreationOption{}))
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+		doc, _, err := instance.Resolver().Resolve(docs[0].ID, nil)
+
+		require.NoError(t, err)
+		require.Len(t, doc.AssertionMethod, 1)
+		require.Len(t, doc.KeyAgreement, 1)
+		assert.Equal(t, docs[0].AssertionMethod[0].ID.String(), doc.AssertionMethod[0].ID.String())
+		t.Run("private keys are stored under the verification method IDs", func(t *testing.T) {
+			for _, vm := range doc.VerificationMethod {
+				exists, err := keyStore.Exists(audit.TestContext(), vm.ID.String())
+				require.NoError(t, err)
+				assert.True(t, exists, vm.ID.String())
+			}
+			for _, kid := range keyStore.List(audit.TestContext()) {
+				assert.False(t, strings.HasPrefix(kid, "urn:uuid:"), "temporary kid is not removed")
+			}
+		})
+	})
+	t.Run("it can create and resolve did:jwk and did:key", func(t *testing.T) {
+		db := storageInstance.GetSQLDatabase()
+		keyStore := nutsCrypto.NewDatabaseCryptoInstance(db)
+		instance := NewVDR(keyStore, nil, nil, nil, storageInstance, pkiMock)
+		err := instance.Configure(core.ServerConfig{URL: "https://example.com", DIDMethods: []string{"web", "jwk", "key"}})
+		require.NoError(t, err)
+
+		docs, _, err := instance.Create(audit.TestContext(), didsubject.DefaultCreationOptions().With(didsubject.DIDMethodsCreationOption{Methods: []string{"jwk", "key"}}))
+		require.NoError(t, err)
+		require.Len(t, docs, 2)
+		for _, created := range docs {
+			doc, _, err := instance.Resolver().Resolve(created.ID, nil)
+
+			require.NoError(t, err)
+			require.Len(t, doc.AssertionMethod, 1)
+			assert.Equal(t, created.AssertionMethod[0].ID.String(), doc.AssertionMethod[0].ID.String())
+			exists, err := keyStore.Exists(audit.TestContext(), doc.AssertionMethod[0].ID.String())
+			require.NoError(t, err)
+			assert.True(t, exists)
+		}
+	})
 	t.Run("it can resolve using did:jwk", func(t *testing.T) {
 		privateKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 		expectedJWK, err := jwk.FromRaw(privateKey.Public())
@@ -297,6 +388,83 @@ func TestVDR_Configure(t *testing.T) {
 		assert.NotNil(t, doc)
 		assert.NotNil(t, md)
 	})
+	t.Run("remote resolution", func(t *testing.T) {
+		t.Run("unsupported DID methods are resolved remotely", func(t *testing.T) {
+			remoteDID := did.MustParseDID("did:ebsi:123")
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				_ = json.NewEncoder(writer).Encode(map[string]interface{}{"didDocument": did.Document{ID: remoteDID}})
+			}))
+			t.Cleanup(server.Close)
+			instance := NewVDR(nil, nil, nil, nil, storageInstance, pkiMock)
+			instance.config.RemoteResolution.Resolvers = map[string]string{"ebsi": server.URL}
+			err := instance.Configure(core.TestServerConfig())
+			require.NoError(t, err)
+
+			doc, _, err := instance.Resolver().Resolve(remoteDID, nil)
+			require.NoError(t, err)
+			assert.Equal(t, remoteDID.String(), doc.ID.String())
+
+			_, _, err = instance.Resolver().Resolve(did.MustParseDID("did:cheqd:123"), nil)
+			assert.ErrorIs(t, err, resolver.ErrDIDMethodNotSupported)
+		})
+		t.Run("invalidating the resolution cache resolves the DID remotely again", func(t *testing.T) {
+			remoteDID := did.MustParseDID("did:ebsi:456")
+			var requests int
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				requests++
+				_ = json.NewEncoder(writer).Encode(map[string]interface{}{"didDocument": did.Document{ID: remoteDID}})
+			}))
+			t.Cleanup(server.Close)
+			instance := NewVDR(nil, nil, nil, nil, storageInstance, pkiMock)
+			instance.config.RemoteResolution.Resolvers = map[string]string{"ebsi": server.URL}
+			err := instance.Configure(core.TestServerConfig())
+			require.NoError(t, err)
+			_, _, err = instance.Resolver().Resolve(remoteDID, nil)
+			require.NoError(t, err)
+			_, _, err = instance.Resolver().Resolve(remoteDID, nil)
+			require.NoError(t, err)
+			require.Equal(t, 1, requests)
+
+			err = instance.InvalidateResolutionCache(remoteDID)
+			require.NoError(t, err)
+			_, _, err = instance.Resolver().Resolve(remoteDID, nil)
+
+			require.NoError(t, err)
+			assert.Equal(t, 2, requests)
+		})
+		t.Run("invalid URL", func(t *testing.T) {
+			instance := NewVDR(nil, nil, nil, nil, storageInstance, pkiMock)
+			instance.config.RemoteResolution.Resolvers = map[string]string{"ebsi": "resolver.example.com"}
+
+			err := instance.Configure(core.TestServerConfig())
+
+			assert.EqualError(t, err, "invalid Universal Resolver URL for DID method ebsi: resolver.example.com")
+		})
+		t.Run("multiple URLs, one invalid", func(t *testing.T) {
+			instance := NewVDR(nil, nil, nil, nil, storageInstance, pkiMock)
+			instance.config.RemoteResolution.Resolvers = map[string]string{"ebsi": "https://resolver.example.com resolver2.example.com"}
+
+			err := instance.Configure(core.TestServerConfig())
+
+			assert.EqualError(t, err, "invalid Universal Resolver URL for DID method ebsi: resolver2.example.com")
+		})
+		t.Run("no URL", func(t *testing.T) {
+			instance := NewVDR(nil, nil, nil, nil, storageInstance, pkiMock)
+			instance.config.RemoteResolution.Resolvers = map[string]string{"ebsi": " "}
+
+			err := instance.Configure(core.TestServerConfig())
+
+			assert.EqualError(t, err, "no Universal Resolver URL configured for DID method ebsi")
+		})
+	})
+	t.Run("invalid DID resolution cache TTL", func(t *testing.T) {
+		instance := NewVDR(nil, nil, nil, nil, storageInstance, pkiMock)
+		instance.config.ResolutionCache.TTL = map[string]string{"web": "5 minutes"}
+
+		err := instance.Configure(core.TestServerConfig())
+
+		assert.ErrorContains(t, err, "invalid DID resolution cache TTL for DID method web")
+	})
 }
 
 func TestVDR_Migrate(t *testing.T) {
@@ -471,9 +639,3 @@ func TestVDR_Migrate(t *testing.T) {
 		})
 	})
 }
-
-type roundTripperFunc func(*http.Request) (*http.Response, error)
-
-func (fn roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
-	return fn(r)
-}